@@ -22,6 +22,7 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 // CwfOrchestratorPlugin implements OrchestratorPlugin for the CWF module
@@ -66,3 +67,7 @@ func (*CwfOrchestratorPlugin) GetObsidianHandlers(metricsConfig *config.ConfigMa
 func (*CwfOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
 	return []providers.StreamProvider{}
 }
+
+func (*CwfOrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	return []sqorc.Migration{}
+}
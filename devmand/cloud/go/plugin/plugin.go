@@ -20,6 +20,7 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 	"orc8r/devmand/cloud/go/devmand"
 	"orc8r/devmand/cloud/go/plugin/handlers"
 	"orc8r/devmand/cloud/go/plugin/models"
@@ -72,3 +73,8 @@ func (*DevmandOrchestratorPlugin) GetObsidianHandlers(metricsConfig *config.Conf
 func (*DevmandOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
 	return []providers.StreamProvider{}
 }
+
+// GetMigrations gets the SQL schema migrations
+func (*DevmandOrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	return []sqorc.Migration{}
+}
@@ -24,6 +24,7 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 // FegOrchestratorPlugin is an implementation of OrchestratorPlugin for the
@@ -70,3 +71,7 @@ func (*FegOrchestratorPlugin) GetObsidianHandlers(metricsConfig *config.ConfigMa
 func (*FegOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
 	return []providers.StreamProvider{}
 }
+
+func (*FegOrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	return []sqorc.Migration{}
+}
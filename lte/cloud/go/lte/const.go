@@ -8,6 +8,8 @@ LICENSE file in the root directory of this source tree.
 
 package lte
 
+import "time"
+
 const ModuleName = "lte"
 
 const (
@@ -26,3 +28,8 @@ const (
 
 	RatingGroupEntityType = "rating_group"
 )
+
+// EnodebStateTTL is how long the state of an enodeb is kept after a gateway
+// last reported it. The state of an enodeb which was disconnected from its
+// gateway, or whose gateway is gone, is deleted once it expires.
+const EnodebStateTTL = time.Hour
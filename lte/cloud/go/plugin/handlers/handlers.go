@@ -167,7 +167,7 @@ func getGateway(c echo.Context) error {
 		return nerr
 	}
 
-	magmadModel, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
+	magmadModel, magmadEnt, nerr := handlers.LoadMagmadGatewayModelAndEntity(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
@@ -198,6 +198,7 @@ func getGateway(c echo.Context) error {
 			ret.ConnectedEnodebSerials = append(ret.ConnectedEnodebSerials, tk.Key)
 		}
 	}
+	obsidian.SetETag(c, handlers.GetEntitiesETag(magmadEnt, ent))
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -432,6 +433,14 @@ func listSubscribers(c echo.Context) error {
 		return nerr
 	}
 
+	pageSize, pageToken, nerr := obsidian.GetPaginationParams(c)
+	if nerr != nil {
+		return nerr
+	}
+	if pageSize != 0 {
+		return listSubscribersPage(c, networkID, pageSize, pageToken)
+	}
+
 	ents, err := configurator.LoadAllEntitiesInNetwork(networkID, lte.SubscriberEntityType, configurator.EntityLoadCriteria{LoadConfig: true})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
@@ -444,6 +453,22 @@ func listSubscribers(c echo.Context) error {
 	return c.JSON(http.StatusOK, ret)
 }
 
+func listSubscribersPage(c echo.Context, networkID string, pageSize uint32, pageToken string) error {
+	ents, nextPageToken, err := configurator.LoadAllEntitiesInNetworkPage(networkID, lte.SubscriberEntityType, configurator.EntityLoadCriteria{LoadConfig: true}, pageSize, pageToken)
+	if err != nil {
+		return obsidian.PaginatedLoadHttpError(err)
+	}
+
+	ret := &ltemodels.PaginatedSubscribers{
+		Subscribers:   make(map[string]ltemodels.Subscriber, len(ents)),
+		NextPageToken: nextPageToken,
+	}
+	for _, ent := range ents {
+		ret.Subscribers[ent.Key] = *(&ltemodels.Subscriber{}).FromBackendModels(ent)
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
 func createSubscriber(c echo.Context) error {
 	networkID, nerr := obsidian.GetNetworkId(c)
 	if nerr != nil {
@@ -489,6 +514,7 @@ func getSubscriber(c echo.Context) error {
 	}
 
 	ret := (&ltemodels.Subscriber{}).FromBackendModels(ent)
+	obsidian.SetETag(c, handlers.GetEntitiesETag(ent))
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -506,7 +532,7 @@ func updateSubscriber(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{})
+	existingEnt, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{})
 	switch {
 	case err == merrors.ErrNotFound:
 		return echo.ErrNotFound
@@ -518,9 +544,19 @@ func updateSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	err = configurator.CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, subscriberID, payload.Lte)
+	writes, nerr := handlers.ApplyIfMatch(
+		c,
+		configurator.NetworkEntities{existingEnt},
+		[]configurator.EntityWriteOperation{
+			configurator.EntityUpdateCriteria{Type: lte.SubscriberEntityType, Key: subscriberID, NewConfig: payload.Lte},
+		},
+	)
+	if nerr != nil {
+		return nerr
+	}
+	err = configurator.WriteEntities(networkID, writes...)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return obsidian.ConditionalWriteHttpError(err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -531,9 +567,27 @@ func deleteSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.SubscriberEntityType, subscriberID)
+	writes := []configurator.EntityWriteOperation{
+		configurator.EntityUpdateCriteria{Type: lte.SubscriberEntityType, Key: subscriberID, DeleteEntity: true},
+	}
+	// Only conditional deletes need to know the current version
+	if obsidian.HasIfMatch(c) {
+		existingEnt, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{})
+		switch {
+		case err == merrors.ErrNotFound:
+			return echo.ErrNotFound
+		case err != nil:
+			return obsidian.HttpError(errors.Wrap(err, "failed to load existing subscriber"), http.StatusInternalServerError)
+		}
+		writes, nerr = handlers.ApplyIfMatch(c, configurator.NetworkEntities{existingEnt}, writes)
+		if nerr != nil {
+			return nerr
+		}
+	}
+
+	err := configurator.WriteEntities(networkID, writes...)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return obsidian.ConditionalWriteHttpError(err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
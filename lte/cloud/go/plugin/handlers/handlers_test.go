@@ -25,6 +25,7 @@ import (
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/plugin"
 	"magma/orc8r/cloud/go/pluginimpl"
+	orc8rhandlers "magma/orc8r/cloud/go/pluginimpl/handlers"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/key"
@@ -1245,6 +1246,51 @@ func TestUpdateGateway(t *testing.T) {
 	}
 	assert.Equal(t, expectedEnts, actualEnts)
 	assert.Equal(t, payload.Device, actualDevice)
+
+	// stale If-Match
+	payload.Name = "bazqux"
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Handler:        updateGateway,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": `"deadbeef"`},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "resource has been modified since the provided ETag was issued",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// current If-Match
+	tc.Headers = map[string]string{"If-Match": fmt.Sprintf("%q", orc8rhandlers.GetEntitiesETag(actualEnts[0], actualEnts[1]))}
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	actualEnts, _, err = configurator.LoadEntities(
+		"n1", nil, nil, nil,
+		[]storage.TypeAndKey{
+			{Type: orc8r.MagmadGatewayType, Key: "g1"},
+			{Type: lte.CellularGatewayType, Key: "g1"},
+			{Type: orc8r.UpgradeTierEntityType, Key: "t1"},
+		},
+		configurator.EntityLoadCriteria{LoadMetadata: true},
+	)
+	assert.NoError(t, err)
+	versionsByType := map[string]uint64{}
+	for _, ent := range actualEnts {
+		versionsByType[ent.Type] = ent.Version
+		if ent.Type != orc8r.UpgradeTierEntityType {
+			assert.Equal(t, "bazqux", ent.Name)
+		}
+	}
+	expectedVersions := map[string]uint64{
+		orc8r.MagmadGatewayType:     2,
+		lte.CellularGatewayType:     2,
+		orc8r.UpgradeTierEntityType: 0,
+	}
+	assert.Equal(t, expectedVersions, versionsByType)
 }
 
 func TestDeleteGateway(t *testing.T) {
@@ -2434,6 +2480,49 @@ func TestListSubscribers(t *testing.T) {
 		}),
 	}
 	tests.RunUnitTest(t, e, tc)
+
+	// paginated
+	_, expectedPageToken, err := configurator.ListEntityKeysPage("n1", lte.SubscriberEntityType, 1, "")
+	assert.NoError(t, err)
+	tc.URL = testURLRoot + "?page_size=1"
+	tc.ExpectedResult = &models2.PaginatedSubscribers{
+		Subscribers: map[string]models2.Subscriber{
+			"IMSI0987654321": {
+				ID: "IMSI0987654321",
+				Lte: &models2.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
+					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
+					State:      "ACTIVE",
+					SubProfile: "foo",
+				},
+			},
+		},
+		NextPageToken: expectedPageToken,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?page_size=1&page_token=" + expectedPageToken
+	tc.ExpectedResult = &models2.PaginatedSubscribers{
+		Subscribers: map[string]models2.Subscriber{
+			"IMSI1234567890": {
+				ID: "IMSI1234567890",
+				Lte: &models2.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+					State:      "ACTIVE",
+					SubProfile: "default",
+				},
+			},
+		},
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?page_token=foo"
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "invalid page token"
+	tests.RunUnitTest(t, e, tc)
 }
 
 func TestGetSubscriber(t *testing.T) {
@@ -2475,6 +2564,8 @@ func TestGetSubscriber(t *testing.T) {
 		},
 	)
 	assert.NoError(t, err)
+	subEnt, err := configurator.LoadEntity("n1", lte.SubscriberEntityType, "IMSI1234567890", configurator.EntityLoadCriteria{})
+	assert.NoError(t, err)
 
 	tc = tests.Test{
 		Method:         "GET",
@@ -2493,6 +2584,7 @@ func TestGetSubscriber(t *testing.T) {
 				SubProfile: "default",
 			},
 		},
+		ExpectedHeaders: map[string]string{"ETag": fmt.Sprintf("%q", orc8rhandlers.GetEntitiesETag(subEnt))},
 	}
 	tests.RunUnitTest(t, e, tc)
 }
@@ -2596,6 +2688,31 @@ func TestUpdateSubscriber(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 
+	// stale If-Match
+	staleETag := orc8rhandlers.GetEntitiesETag(configurator.NetworkEntity{Type: lte.SubscriberEntityType, Key: "IMSI1234567890", Version: 0})
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Handler:        updateSubscriber,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": fmt.Sprintf("%q", staleETag)},
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1234567890"},
+		ExpectedStatus: 412,
+		ExpectedError:  "resource has been modified since the provided ETag was issued",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// current If-Match
+	tc.Headers = map[string]string{"If-Match": fmt.Sprintf("%q", orc8rhandlers.GetEntitiesETag(actual))}
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	actual, err = configurator.LoadEntity("n1", lte.SubscriberEntityType, "IMSI1234567890", configurator.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), actual.Version)
+
 	// No profile matching
 	payload.Lte.SubProfile = "bar"
 	tc = tests.Test{
@@ -2639,7 +2756,20 @@ func TestDeleteSubscriber(t *testing.T) {
 	)
 	assert.NoError(t, err)
 
+	// stale If-Match
 	tc := tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot,
+		Handler:        deleteSubscriber,
+		Headers:        map[string]string{"If-Match": `"deadbeef"`},
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1234567890"},
+		ExpectedStatus: 412,
+		ExpectedError:  "resource has been modified since the provided ETag was issued",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
 		Method:         "DELETE",
 		URL:            testURLRoot,
 		Handler:        deleteSubscriber,
@@ -0,0 +1,57 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package plugin
+
+import (
+	"fmt"
+
+	"magma/lte/cloud/go/lte"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	entityTable   = "cfg_entities"
+	entityKeyCol  = "\"key\""
+	entityTypeCol = "type"
+)
+
+func getMigrations() []sqorc.Migration {
+	return []sqorc.Migration{
+		{
+			// Formerly the m007_na_cleanup one-off migration,
+			// see https://github.com/facebookincubator/magma/issues/1071
+			Module:      lte.ModuleName,
+			Version:     1,
+			Description: "delete cellular gateways without a magmad gateway",
+			Service:     configurator.ServiceName,
+			Destructive: true,
+			Up:          deleteHangingCellularGateways,
+		},
+	}
+}
+
+// deleteHangingCellularGateways deletes the cellular gateways which have no
+// magmad gateway with the same key. The magmad gateway keys are selected
+// through a derived table since MariaDB can't delete from a table which is
+// also selected from in a subquery.
+func deleteHangingCellularGateways(builder sqorc.StatementBuilder) []squirrel.Sqlizer {
+	magmadGatewayKeys := fmt.Sprintf(
+		"SELECT %s FROM (SELECT %s FROM %s WHERE %s = '%s') AS md_gw",
+		entityKeyCol, entityKeyCol, entityTable, entityTypeCol, orc8r.MagmadGatewayType,
+	)
+	return []squirrel.Sqlizer{
+		builder.Delete(entityTable).
+			Where(squirrel.Eq{entityTypeCol: lte.CellularGatewayType}).
+			Where(fmt.Sprintf("%s NOT IN (%s)", entityKeyCol, magmadGatewayKeys)),
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package plugin_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/plugin"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMigrations_DeleteHangingCellularGateways(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	builder := sqorc.NewPostgresStatementBuilder()
+	_, err = builder.CreateTable("cfg_entities").
+		Column("pk").Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column("\"key\"").Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column("type").Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		RunWith(db).
+		Exec()
+	require.NoError(t, err)
+	_, err = builder.Insert("cfg_entities").
+		Columns("pk", "\"key\"", "type").
+		Values("1", "gw1", orc8r.MagmadGatewayType).
+		Values("2", "gw1", lte.CellularGatewayType).
+		Values("3", "gw2", lte.CellularGatewayType).
+		Values("4", "gw3", orc8r.MagmadGatewayType).
+		RunWith(db).
+		Exec()
+	require.NoError(t, err)
+
+	migs := (&plugin.LteOrchestratorPlugin{}).GetMigrations()
+	require.Len(t, migs, 1)
+	assert.Equal(t, lte.ModuleName, migs[0].Module)
+	assert.Equal(t, configurator.ServiceName, migs[0].Service)
+	assert.True(t, migs[0].Destructive)
+	for _, stmt := range migs[0].Up(builder) {
+		query, args, err := stmt.ToSql()
+		require.NoError(t, err)
+		_, err = db.Exec(query, args...)
+		require.NoError(t, err)
+	}
+
+	rows, err := builder.Select("pk").From("cfg_entities").OrderBy("pk").RunWith(db).Query()
+	require.NoError(t, err)
+	defer rows.Close()
+	var pks []string
+	for rows.Next() {
+		var pk string
+		require.NoError(t, rows.Scan(&pk))
+		pks = append(pks, pk)
+	}
+	assert.Equal(t, []string{"1", "2", "4"}, pks)
+}
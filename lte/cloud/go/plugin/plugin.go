@@ -25,6 +25,7 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 // LteOrchestratorPlugin implements OrchestratorPlugin for the LTE module
@@ -44,7 +45,7 @@ func (*LteOrchestratorPlugin) GetServices() []registry.ServiceLocation {
 
 func (*LteOrchestratorPlugin) GetSerdes() []serde.Serde {
 	return []serde.Serde{
-		state.NewStateSerde(lte.EnodebStateType, &lteModels.EnodebState{}),
+		state.NewStateSerdeWithTTL(lte.EnodebStateType, &lteModels.EnodebState{}, lte.EnodebStateTTL),
 
 		// Configurator serdes
 		configurator.NewNetworkConfigSerde(lte.CellularNetworkType, &lteModels.NetworkCellularConfigs{}),
@@ -83,3 +84,7 @@ func (*LteOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider
 		&policyStreamer.RuleMappingsProvider{},
 	}
 }
+
+func (*LteOrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	return getMigrations()
+}
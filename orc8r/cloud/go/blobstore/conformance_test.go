@@ -0,0 +1,299 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package blobstore_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conformance runs the behaviors every blobstore implementation must share.
+// Each case gets a fresh factory.
+func conformance(t *testing.T, newFactory func(t *testing.T) blobstore.BlobStorageFactory) {
+	t.Run("CreateOrUpdateIfVersion", func(t *testing.T) {
+		testCreateOrUpdateIfVersion(t, newFactory(t))
+	})
+	t.Run("CreateOrUpdateIfVersion_Conflict", func(t *testing.T) {
+		testCreateOrUpdateIfVersionConflict(t, newFactory(t))
+	})
+	t.Run("CreateOrUpdateIfVersion_Validation", func(t *testing.T) {
+		testCreateOrUpdateIfVersionValidation(t, newFactory(t))
+	})
+	t.Run("DeleteIfVersion", func(t *testing.T) {
+		testDeleteIfVersion(t, newFactory(t))
+	})
+	t.Run("CreateOrUpdateIfVersion_Interleaved", func(t *testing.T) {
+		testCreateOrUpdateIfVersionInterleaved(t, newFactory(t))
+	})
+}
+
+// newSharedSQLiteDB returns an in-memory SQLite database which all
+// connections of the pool share, so that concurrent transactions see the
+// same data. Each call returns a new database.
+func newSharedSQLiteDB(t *testing.T) *sql.DB {
+	sharedDBCount++
+	db, err := sqorc.Open("sqlite3", fmt.Sprintf("file:blobstore_conformance_%d?mode=memory&cache=shared", sharedDBCount))
+	require.NoError(t, err)
+	return db
+}
+
+var sharedDBCount = 0
+
+var (
+	id1 = storage.TypeAndKey{Type: "t1", Key: "k1"}
+	id2 = storage.TypeAndKey{Type: "t1", Key: "k2"}
+	id3 = storage.TypeAndKey{Type: "t2", Key: "k1"}
+)
+
+func testCreateOrUpdateIfVersion(t *testing.T, fact blobstore.BlobStorageFactory) {
+	require.NoError(t, fact.InitializeFactory())
+
+	// Create, expecting the blobs don't exist
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1")},
+		{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 42},
+	}, map[storage.TypeAndKey]uint64{id1: 0, id2: 0})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Passed versions are ignored, blobs are at version 1
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1, id2}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1"), Version: 1},
+		{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 1},
+	})
+
+	// Update one and create another in the same call
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1.1")},
+		{Type: "t2", Key: "k1", Value: []byte("v3")},
+	}, map[storage.TypeAndKey]uint64{id1: 1, id3: 0})
+	assert.NoError(t, err)
+
+	// Writes are visible within the transaction and can be chained
+	blob, err := store.Get("n1", id1)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "t1", Key: "k1", Value: []byte("v1.1"), Version: 2}, blob)
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1.2")},
+	}, map[storage.TypeAndKey]uint64{id1: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1, id2, id3}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1.2"), Version: 3},
+		{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 1},
+		{Type: "t2", Key: "k1", Value: []byte("v3"), Version: 1},
+	})
+
+	// A blob written by CreateOrUpdate with version 0 matches an expected
+	// version of 0
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	assert.NoError(t, store.CreateOrUpdate("n2", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("old")}}))
+	assert.NoError(t, store.Commit())
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdateIfVersion("n2", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("new")},
+	}, map[storage.TypeAndKey]uint64{id1: 0})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+	assertBlobs(t, fact, "n2", []storage.TypeAndKey{id1}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("new"), Version: 1},
+	})
+
+	// Networks are independent
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1.2"), Version: 3},
+	})
+}
+
+func testCreateOrUpdateIfVersionConflict(t *testing.T, fact blobstore.BlobStorageFactory) {
+	require.NoError(t, fact.InitializeFactory())
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdate("n1", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1"), Version: 5},
+		{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 1},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Commit())
+
+	// Stale version, existing blob expected not to exist, and missing blob
+	// expected to exist all conflict
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{
+		{Type: "t2", Key: "k1", Value: []byte("new3")},
+		{Type: "t1", Key: "k1", Value: []byte("new1")},
+		{Type: "t1", Key: "k2", Value: []byte("new2")},
+	}, map[storage.TypeAndKey]uint64{id1: 4, id2: 0, id3: 1})
+	assert.True(t, blobstore.IsVersionConflict(err))
+	assert.Equal(t, &blobstore.VersionConflictError{
+		NetworkID: "n1",
+		Conflicts: []blobstore.VersionConflict{
+			{ID: id1, Expected: 4, Actual: 5},
+			{ID: id2, Expected: 0, Actual: 1},
+			{ID: id3, Expected: 1, Actual: 0},
+		},
+	}, err)
+	assert.EqualError(t, err, "version conflict in network n1: t1-k1 (expected version 4, found 5), t1-k2 (expected version 0, found 1), t2-k1 (expected version 1, found 0)")
+	assert.NoError(t, store.Rollback())
+
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1, id2, id3}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1"), Version: 5},
+		{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 1},
+	})
+
+	// A conflicting write after a competing commit is detected, so the
+	// second read-modify-write doesn't overwrite the first
+	store1, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store1.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("first")}}, map[storage.TypeAndKey]uint64{id1: 5})
+	assert.NoError(t, err)
+	assert.NoError(t, store1.Commit())
+
+	store2, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store2.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("second")}}, map[storage.TypeAndKey]uint64{id1: 5})
+	assert.True(t, blobstore.IsVersionConflict(err))
+	assert.NoError(t, store2.Rollback())
+
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("first"), Version: 6},
+	})
+}
+
+func testCreateOrUpdateIfVersionValidation(t *testing.T, fact blobstore.BlobStorageFactory) {
+	require.NoError(t, fact.InitializeFactory())
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1"}}, map[storage.TypeAndKey]uint64{id2: 0})
+	assert.EqualError(t, err, "no expected version for blob t1-k1")
+	assert.False(t, blobstore.IsVersionConflict(err))
+
+	err = store.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1"}, {Type: "t1", Key: "k1"}}, map[storage.TypeAndKey]uint64{id1: 0})
+	assert.EqualError(t, err, "blob t1-k1 appears more than once")
+
+	assert.NoError(t, store.CreateOrUpdateIfVersion("n1", nil, nil))
+	assert.NoError(t, store.Rollback())
+}
+
+func assertBlobs(t *testing.T, fact blobstore.BlobStorageFactory, networkID string, ids []storage.TypeAndKey, expected []blobstore.Blob) {
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	actual, err := store.GetMany(networkID, ids)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expected, actual)
+	assert.NoError(t, store.Commit())
+}
+
+func testDeleteIfVersion(t *testing.T, fact blobstore.BlobStorageFactory) {
+	require.NoError(t, fact.InitializeFactory())
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store.CreateOrUpdate("n1", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v1")},
+		{Type: "t1", Key: "k2", Value: []byte("v2")},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Only blobs at the expected version are deleted, missing blobs are
+	// ignored
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	assert.NoError(t, store.CreateOrUpdate("n1", []blobstore.Blob{{Type: "t1", Key: "k2", Value: []byte("v2.1")}}))
+	deleted, err := store.DeleteIfVersion("n1", map[storage.TypeAndKey]uint64{id1: 0, id2: 0, id3: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{id1}, deleted)
+	assert.NoError(t, store.Commit())
+
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1, id2}, []blobstore.Blob{
+		{Type: "t1", Key: "k2", Value: []byte("v2.1"), Version: 1},
+	})
+
+	store, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	deleted, err = store.DeleteIfVersion("n1", map[storage.TypeAndKey]uint64{id2: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{id2}, deleted)
+	assert.NoError(t, store.Commit())
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1, id2}, []blobstore.Blob{})
+}
+
+func testCreateOrUpdateIfVersionInterleaved(t *testing.T, fact blobstore.BlobStorageFactory) {
+	require.NoError(t, fact.InitializeFactory())
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateOrUpdate("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("v1"), Version: 1}}))
+	require.NoError(t, store.Commit())
+
+	// Two transactions read the same version and write the blob. Whichever
+	// fails, on write or on commit, the second write doesn't overwrite the
+	// first.
+	store1, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	store2, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	err = store1.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("first")}}, map[storage.TypeAndKey]uint64{id1: 1})
+	require.NoError(t, err)
+	err = store2.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("second")}}, map[storage.TypeAndKey]uint64{id1: 1})
+	if err == nil {
+		assert.NoError(t, store1.Commit())
+		err = store2.Commit()
+		assert.True(t, blobstore.IsVersionConflict(err))
+		assert.Equal(t, &blobstore.VersionConflictError{
+			NetworkID: "n1",
+			Conflicts: []blobstore.VersionConflict{{ID: id1, Expected: 1, Actual: 2}},
+		}, err)
+	} else {
+		// SQL databases lock the row written by the first transaction
+		assert.NoError(t, store2.Rollback())
+		assert.NoError(t, store1.Commit())
+	}
+	assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1}, []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("first"), Version: 2},
+	})
+
+	// A conditional delete interleaved with a write doesn't delete the
+	// written blob
+	store1, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	store2, err = fact.StartTransaction(nil)
+	require.NoError(t, err)
+	deleted, err := store1.DeleteIfVersion("n1", map[storage.TypeAndKey]uint64{id1: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{id1}, deleted)
+	err = store2.CreateOrUpdateIfVersion("n1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("third")}}, map[storage.TypeAndKey]uint64{id1: 2})
+	if err == nil {
+		assert.NoError(t, store2.Commit())
+		assert.True(t, blobstore.IsVersionConflict(store1.Commit()))
+		assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1}, []blobstore.Blob{
+			{Type: "t1", Key: "k1", Value: []byte("third"), Version: 3},
+		})
+	} else {
+		assert.NoError(t, store2.Rollback())
+		assert.NoError(t, store1.Commit())
+		assertBlobs(t, fact, "n1", []storage.TypeAndKey{id1}, []blobstore.Blob{})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"unicode/utf8"
 
 	"magma/orc8r/cloud/go/blobstore/ent"
 	"magma/orc8r/cloud/go/blobstore/ent/blob"
@@ -113,6 +114,68 @@ func (e *entStorage) IncrementVersion(networkID string, id storage.TypeAndKey) e
 	}
 }
 
+// CreateOrUpdateIfVersion updates each blob conditionally on its version and
+// checks that exactly one row was updated. Blobs expected not to exist are
+// created if no row was updated and they don't exist.
+func (e *entStorage) CreateOrUpdateIfVersion(networkID string, blobs []Blob, expectedVersions map[storage.TypeAndKey]uint64) error {
+	ctx := context.Background()
+	if err := validateExpectedVersions(blobs, expectedVersions); err != nil {
+		return err
+	}
+
+	var conflictIDs []storage.TypeAndKey
+	for _, b := range getSortedBlobs(blobs) {
+		id := b.toID()
+		n, err := e.Blob.Update().
+			SetValue(b.Value).
+			SetVersion(expectedVersions[id]+1).
+			Where(P(networkID, []storage.TypeAndKey{id}), blob.Version(expectedVersions[id])).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 1 {
+			continue
+		}
+		if expectedVersions[id] != 0 {
+			conflictIDs = append(conflictIDs, id)
+			continue
+		}
+		switch _, err := e.Get(networkID, id); {
+		case err == magmaerrors.ErrNotFound:
+			_, err = e.Blob.Create().
+				SetKey(b.Key).
+				SetType(b.Type).
+				SetNetworkID(networkID).
+				SetValue(b.Value).
+				SetVersion(1).
+				Save(ctx)
+			// The blob was created by a concurrent transaction since it was
+			// read. The stored versions can't be read anymore as PostgreSQL
+			// aborts the transaction.
+			if sqorc.IsUniqueViolation(err) {
+				return newVersionConflictError(networkID, append(conflictIDs, id), expectedVersions, nil)
+			}
+			if err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			conflictIDs = append(conflictIDs, id)
+		}
+	}
+	if len(conflictIDs) == 0 {
+		return nil
+	}
+
+	storedBlobs, err := e.GetMany(networkID, conflictIDs)
+	if err != nil {
+		return fmt.Errorf("error reading conflicting blobs: %s", err)
+	}
+	return newVersionConflictError(networkID, conflictIDs, expectedVersions, storedBlobs)
+}
+
 func (e *entStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	ctx := context.Background()
 	_, err := e.Blob.Delete().
@@ -121,6 +184,23 @@ func (e *entStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	return err
 }
 
+func (e *entStorage) DeleteIfVersion(networkID string, expectedVersions map[storage.TypeAndKey]uint64) ([]storage.TypeAndKey, error) {
+	ctx := context.Background()
+	deleted := []storage.TypeAndKey{}
+	for _, id := range getSortedIDs(expectedVersions) {
+		n, err := e.Blob.Delete().
+			Where(P(networkID, []storage.TypeAndKey{id}), blob.Version(expectedVersions[id])).
+			Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if n == 1 {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
 func (e *entStorage) CreateOrUpdate(networkID string, blobs []Blob) error {
 	ctx := context.Background()
 	existingBlobs, err := e.GetMany(networkID, getBlobIDs(blobs))
@@ -176,6 +256,54 @@ func (e *entStorage) GetExistingKeys(keys []string, filter SearchFilter) ([]stri
 		Strings(ctx)
 }
 
+func (e *entStorage) Search(filter SearchFilter) (map[string][]Blob, error) {
+	ctx := context.Background()
+	var preds []predicate.Blob
+	if filter.NetworkID != nil {
+		preds = append(preds, blob.NetworkID(*filter.NetworkID))
+	}
+	if len(filter.Types) > 0 {
+		preds = append(preds, blob.TypeIn(filter.Types...))
+	}
+	if filter.KeyPrefix != nil {
+		preds = append(preds, keyHasPrefix(*filter.KeyPrefix))
+	}
+	var results []struct {
+		NetworkID string `json:"network_id"`
+		Type      string
+		Key       string
+		Value     []byte
+		Version   uint64
+	}
+	err := e.Blob.Query().
+		Where(preds...).
+		Select(blob.FieldNetworkID, blob.FieldKey, blob.FieldType, blob.FieldValue, blob.FieldVersion).
+		Scan(ctx, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string][]Blob{}
+	for _, result := range results {
+		b := Blob{Type: result.Type, Key: result.Key, Value: result.Value, Version: result.Version}
+		if filter.matches(result.NetworkID, b) {
+			ret[result.NetworkID] = append(ret[result.NetworkID], b)
+		}
+	}
+	sortSearchResults(ret)
+	return ret, nil
+}
+
+// keyHasPrefix matches the blobs whose key starts with prefix. ent's LIKE
+// predicates can't set an escape character, so the prefix is compared to the
+// start of the key instead of being matched as a pattern.
+func keyHasPrefix(prefix string) predicate.Blob {
+	return predicate.Blob(func(s *entsql.Selector) {
+		keyStart := fmt.Sprintf("SUBSTR(%s, 1, %d)", s.C(blob.FieldKey), utf8.RuneCountInString(prefix))
+		s.Where(entsql.EQ(keyStart, prefix))
+	})
+}
+
 func P(networkID string, ids []storage.TypeAndKey) predicate.Blob {
 	preds := make([]predicate.Blob, 0, len(ids))
 	for _, id := range ids {
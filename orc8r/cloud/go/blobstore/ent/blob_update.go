@@ -165,11 +165,14 @@ func (bu *BlobUpdate) sqlSave(ctx context.Context) (int, error) {
 	if err := tx.Exec(ctx, query, args, &res); err != nil {
 		return 0, rollback(tx, err)
 	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
 	if err := tx.Commit(); err != nil {
 		return 0, err
 	}
-	// returning the amount of nodes is not necessary.
-	return 0, nil
+	return int(affected), nil
 
 }
 
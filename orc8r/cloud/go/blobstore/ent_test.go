@@ -122,3 +122,39 @@ func TestIntegration(t *testing.T) {
 	fact := blobstore.NewEntStorage("states", db, sqorc.GetSqlBuilder())
 	integration(t, fact)
 }
+
+func TestConformance(t *testing.T) {
+	conformance(t, func(t *testing.T) blobstore.BlobStorageFactory {
+		return blobstore.NewEntStorage("states", newSharedSQLiteDB(t), sqorc.GetSqlBuilder())
+	})
+}
+
+// A blob created by a concurrent transaction after it was read is a version
+// conflict
+func TestEntCreateOrUpdateIfVersion_ConcurrentCreate(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	fact := blobstore.NewEntStorage("states", db, sqorc.GetSqlBuilder())
+	require.NoError(t, fact.InitializeFactory())
+	// Insert the blob right before the store does, as if a concurrent
+	// transaction committed it
+	_, err = db.Exec(`
+		CREATE TRIGGER concurrent_create BEFORE INSERT ON states
+		BEGIN
+			INSERT INTO states (network_id, type, "key", value, version)
+			VALUES (NEW.network_id, NEW.type, NEW."key", x'', 1);
+		END`)
+	require.NoError(t, err)
+
+	store, err := fact.StartTransaction(nil)
+	require.NoError(t, err)
+	id := storage.TypeAndKey{Type: "t1", Key: "k1"}
+	err = store.CreateOrUpdateIfVersion(
+		"n1",
+		[]blobstore.Blob{{Type: id.Type, Key: id.Key, Value: []byte("v1")}},
+		map[storage.TypeAndKey]uint64{id: 0},
+	)
+	require.True(t, blobstore.IsVersionConflict(err), "unexpected error %v", err)
+	require.Equal(t, []blobstore.VersionConflict{{ID: id}}, err.(*blobstore.VersionConflictError).Conflicts)
+	require.NoError(t, store.Rollback())
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package blobstore
+
+import (
+	"fmt"
+	"strings"
+
+	"magma/orc8r/cloud/go/storage"
+)
+
+// VersionConflict describes a blob whose stored version didn't match the
+// version expected by CreateOrUpdateIfVersion.
+type VersionConflict struct {
+	ID       storage.TypeAndKey
+	Expected uint64
+	// Actual is the stored version of the blob, 0 if the blob doesn't exist
+	// or if its version couldn't be read, as when a concurrent transaction
+	// created it
+	Actual uint64
+}
+
+// VersionConflictError is returned by CreateOrUpdateIfVersion when the stored
+// version of one or more blobs didn't match the expected version.
+type VersionConflictError struct {
+	NetworkID string
+	Conflicts []VersionConflict
+}
+
+func (e *VersionConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%s (expected version %d, found %d)", c.ID, c.Expected, c.Actual))
+	}
+	return fmt.Sprintf("version conflict in network %s: %s", e.NetworkID, strings.Join(conflicts, ", "))
+}
+
+// IsVersionConflict returns true if the error was returned because of a
+// version conflict in CreateOrUpdateIfVersion.
+func IsVersionConflict(err error) bool {
+	_, ok := err.(*VersionConflictError)
+	return ok
+}
+
+// newVersionConflictError returns a VersionConflictError for the ids, reading
+// the actual versions from the stored blobs. Returns nil if ids is empty.
+func newVersionConflictError(networkID string, ids []storage.TypeAndKey, expectedVersions map[storage.TypeAndKey]uint64, storedBlobs []Blob) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	storedByID := GetBlobsByTypeAndKey(storedBlobs)
+	err := &VersionConflictError{NetworkID: networkID}
+	for _, id := range ids {
+		err.Conflicts = append(err.Conflicts, VersionConflict{ID: id, Expected: expectedVersions[id], Actual: storedByID[id].Version})
+	}
+	return err
+}
@@ -239,4 +239,69 @@ func integration(t *testing.T, fact blobstore.BlobStorageFactory) {
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, []blobstore.Blob{{Type: "t3", Key: "k3", Value: []byte("v5"), Version: 2}}, getManyActual)
+	assert.NoError(t, store.Commit())
+
+	// Search
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	err = store.CreateOrUpdate("search1", []blobstore.Blob{
+		{Type: "s1", Key: "gw_a", Value: []byte("a")},
+		{Type: "s1", Key: "gw_b", Value: []byte("b")},
+		{Type: "s1", Key: "gwxc", Value: []byte("c")},
+		{Type: "s2", Key: "gw_a", Value: []byte("d")},
+	})
+	assert.NoError(t, err)
+	err = store.CreateOrUpdate("search2", []blobstore.Blob{
+		{Type: "s1", Key: "gw_a", Value: []byte("e")},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	searchActual, err := store.Search(blobstore.SearchFilter{NetworkID: strPtr("search1")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]blobstore.Blob{
+		"search1": {
+			{Type: "s1", Key: "gw_a", Value: []byte("a")},
+			{Type: "s1", Key: "gw_b", Value: []byte("b")},
+			{Type: "s1", Key: "gwxc", Value: []byte("c")},
+			{Type: "s2", Key: "gw_a", Value: []byte("d")},
+		},
+	}, searchActual)
+
+	// Underscore in the prefix is matched literally
+	searchActual, err = store.Search(blobstore.SearchFilter{Types: []string{"s1"}, KeyPrefix: strPtr("gw_")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]blobstore.Blob{
+		"search1": {
+			{Type: "s1", Key: "gw_a", Value: []byte("a")},
+			{Type: "s1", Key: "gw_b", Value: []byte("b")},
+		},
+		"search2": {
+			{Type: "s1", Key: "gw_a", Value: []byte("e")},
+		},
+	}, searchActual)
+
+	searchActual, err = store.Search(blobstore.SearchFilter{NetworkID: strPtr("search1"), Types: []string{"s3"}})
+	assert.NoError(t, err)
+	assert.Empty(t, searchActual)
+
+	// Uncommitted changes are visible within the transaction
+	assert.NoError(t, store.Delete("search1", []storage.TypeAndKey{{Type: "s1", Key: "gw_a"}}))
+	assert.NoError(t, store.CreateOrUpdate("search1", []blobstore.Blob{{Type: "s2", Key: "gw_b", Value: []byte("f")}}))
+	searchActual, err = store.Search(blobstore.SearchFilter{NetworkID: strPtr("search1"), KeyPrefix: strPtr("gw_")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]blobstore.Blob{
+		"search1": {
+			{Type: "s1", Key: "gw_b", Value: []byte("b")},
+			{Type: "s2", Key: "gw_a", Value: []byte("d")},
+			{Type: "s2", Key: "gw_b", Value: []byte("f")},
+		},
+	}, searchActual)
+	assert.NoError(t, store.Commit())
+}
+
+func strPtr(s string) *string {
+	return &s
 }
@@ -52,6 +52,10 @@ type memoryBlobStorage struct {
 	transactionExists bool
 	// changes stores changes during a transaction
 	changes transactionTable
+	// sharedVersions holds the shared versions of the blobs which
+	// conditional changes were checked against, 0 for blobs which didn't
+	// exist. They are checked again on commit.
+	sharedVersions map[networkIDAndTK]uint64
 
 	// stores everything needed to access the shared map
 	shared sharedMemoryBlobTables
@@ -84,7 +88,8 @@ func (fact *memoryBlobStoreFactory) StartTransaction(opts *storage.TxOptions) (T
 	return &memoryBlobStorage{
 		shared:            sharedMemoryBlobTables{RWMutex: &fact.RWMutex, table: fact.table},
 		transactionExists: true,
-		changes:           transactionTable{}}, nil
+		changes:           transactionTable{},
+		sharedVersions:    map[networkIDAndTK]uint64{}}, nil
 }
 
 func (fact *memoryBlobStoreFactory) InitializeFactory() error {
@@ -100,11 +105,14 @@ func (store *memoryBlobStorage) Commit() error {
 	}
 
 	store.shared.Lock()
-	store.applyChangesToShared()
+	err := store.checkSharedVersionsUnsafe()
+	if err == nil {
+		store.applyChangesToShared()
+	}
 	store.shared.Unlock()
 
 	store.resetTransaction()
-	return nil
+	return err
 }
 
 func (store *memoryBlobStorage) Rollback() error {
@@ -199,6 +207,51 @@ func (store *memoryBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) e
 	return nil
 }
 
+// CreateOrUpdateIfVersion checks the versions of the blobs, including changes
+// from the ongoing transaction, before writing any of them. Versions read
+// from the shared map are checked again on commit.
+func (store *memoryBlobStorage) CreateOrUpdateIfVersion(networkID string, blobs []Blob, expectedVersions map[storage.TypeAndKey]uint64) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	if err := validateExpectedVersions(blobs, expectedVersions); err != nil {
+		return err
+	}
+
+	ids := blobsToIDs(blobs)
+	store.shared.RLock()
+	sharedBlobs := store.getManyFromShared(networkID, ids)
+	store.shared.RUnlock()
+	currentBlobs, err := store.updateBlobsWithLocalChangesUnsafe(networkID, ids, sharedBlobs)
+	if err != nil {
+		return err
+	}
+
+	currentByID := GetBlobsByTypeAndKey(currentBlobs)
+	var conflictIDs []storage.TypeAndKey
+	for _, id := range ids {
+		if currentByID[id].Version != expectedVersions[id] {
+			conflictIDs = append(conflictIDs, id)
+		}
+	}
+	if len(conflictIDs) > 0 {
+		sort.Slice(conflictIDs, func(i, j int) bool { return conflictIDs[i].String() < conflictIDs[j].String() })
+		return newVersionConflictError(networkID, conflictIDs, expectedVersions, currentBlobs)
+	}
+
+	store.recordSharedVersionsUnsafe(networkID, ids, sharedBlobs)
+	store.changes.initializeNetworkTable(networkID)
+	for _, blob := range blobs {
+		id := blob.toID()
+		blob.Version = expectedVersions[id] + 1
+		store.changes[networkID][id] = change{cType: CreateOrUpdate, blob: blob}
+	}
+	return nil
+}
+
 func (store *memoryBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	store.Lock()
 	defer store.Unlock()
@@ -214,6 +267,43 @@ func (store *memoryBlobStorage) Delete(networkID string, ids []storage.TypeAndKe
 	return nil
 }
 
+// DeleteIfVersion checks the versions of the blobs, including changes from
+// the ongoing transaction, before deleting them. Versions read from the
+// shared map are checked again on commit.
+func (store *memoryBlobStorage) DeleteIfVersion(networkID string, expectedVersions map[storage.TypeAndKey]uint64) ([]storage.TypeAndKey, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	ids := getSortedIDs(expectedVersions)
+	store.shared.RLock()
+	sharedBlobs := store.getManyFromShared(networkID, ids)
+	store.shared.RUnlock()
+	currentBlobs, err := store.updateBlobsWithLocalChangesUnsafe(networkID, ids, sharedBlobs)
+	if err != nil {
+		return nil, err
+	}
+
+	currentByID := GetBlobsByTypeAndKey(currentBlobs)
+	deleted := []storage.TypeAndKey{}
+	for _, id := range ids {
+		current, exists := currentByID[id]
+		if !exists || current.Version != expectedVersions[id] {
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+	store.recordSharedVersionsUnsafe(networkID, deleted, sharedBlobs)
+	store.changes.initializeNetworkTable(networkID)
+	for _, id := range deleted {
+		store.changes[networkID][id] = change{cType: Delete}
+	}
+	return deleted, nil
+}
+
 func (store *memoryBlobStorage) GetExistingKeys(keys []string, filter SearchFilter) ([]string, error) {
 	store.Lock()
 	defer store.Unlock()
@@ -228,6 +318,54 @@ func (store *memoryBlobStorage) GetExistingKeys(keys []string, filter SearchFilt
 	return store.getExistingKeysAllNetworks(keySet)
 }
 
+// Search grabs matching blobs from the shared map, then updates the results
+// with changes from the ongoing transaction
+func (store *memoryBlobStorage) Search(filter SearchFilter) (map[string][]Blob, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	matching := blobTable{}
+	store.shared.RLock()
+	for networkID, blobs := range store.shared.table {
+		for id, blob := range blobs {
+			if filter.matches(networkID, blob) {
+				matching.initializeNetworkTable(networkID)
+				matching[networkID][id] = blob
+			}
+		}
+	}
+	store.shared.RUnlock()
+
+	for networkID, changes := range store.changes {
+		for id, change := range changes {
+			switch change.cType {
+			case Delete:
+				delete(matching[networkID], id)
+			case CreateOrUpdate:
+				if filter.matches(networkID, change.blob) {
+					matching.initializeNetworkTable(networkID)
+					matching[networkID][id] = change.blob
+				}
+			default:
+				return nil, fmt.Errorf("This transaction contains ill-formatted changes.")
+			}
+		}
+	}
+
+	ret := map[string][]Blob{}
+	for networkID, blobs := range matching {
+		if len(blobs) > 0 {
+			ret[networkID] = blobs.toBlobList()
+		}
+	}
+	sortSearchResults(ret)
+	return ret, nil
+}
+
 func (store *memoryBlobStorage) IncrementVersion(networkID string, id storage.TypeAndKey) error {
 	store.Lock()
 	defer store.Unlock()
@@ -368,6 +506,52 @@ func (store *memoryBlobStorage) applyChangesToShared() error {
 func (store *memoryBlobStorage) resetTransaction() {
 	store.transactionExists = false
 	store.changes = nil
+	store.sharedVersions = nil
+}
+
+// recordSharedVersionsUnsafe records the shared versions of the blobs a
+// conditional change was checked against, so that the check is repeated on
+// commit. Blobs which already had changes in the transaction were checked
+// against those changes instead. Must be called with write lock on change
+// map.
+func (store *memoryBlobStorage) recordSharedVersionsUnsafe(networkID string, ids []storage.TypeAndKey, sharedBlobs blobsByID) {
+	for _, id := range ids {
+		if _, changed := store.changes[networkID][id]; changed {
+			continue
+		}
+		store.sharedVersions[networkIDAndTK{networkID: networkID, typeAndKey: id}] = sharedBlobs[id].Version
+	}
+}
+
+// checkSharedVersionsUnsafe returns a VersionConflictError if a blob which a
+// conditional change was checked against was changed in the shared map by
+// another transaction since. Must be called with lock on both local and
+// shared maps.
+func (store *memoryBlobStorage) checkSharedVersionsUnsafe() error {
+	var conflict *VersionConflictError
+	for id, expected := range store.sharedVersions {
+		actual := store.shared.table[id.networkID][id.typeAndKey].Version
+		if actual == expected {
+			continue
+		}
+		if conflict == nil {
+			conflict = &VersionConflictError{NetworkID: id.networkID}
+		} else if conflict.NetworkID != id.networkID {
+			// Conflicts are reported for a single network
+			if id.networkID > conflict.NetworkID {
+				continue
+			}
+			conflict = &VersionConflictError{NetworkID: id.networkID}
+		}
+		conflict.Conflicts = append(conflict.Conflicts, VersionConflict{ID: id.typeAndKey, Expected: expected, Actual: actual})
+	}
+	if conflict == nil {
+		return nil
+	}
+	sort.Slice(conflict.Conflicts, func(i, j int) bool {
+		return conflict.Conflicts[i].ID.String() < conflict.Conflicts[j].ID.String()
+	})
+	return conflict
 }
 
 // Given a networkID and a type this function looks in the shared map and
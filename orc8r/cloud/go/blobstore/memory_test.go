@@ -252,3 +252,9 @@ func TestMemoryBlobStorage_Integration(t *testing.T) {
 	fact := blobstore.NewMemoryBlobStorageFactory()
 	integration(t, fact)
 }
+
+func TestMemoryBlobStorage_Conformance(t *testing.T) {
+	conformance(t, func(t *testing.T) blobstore.BlobStorageFactory {
+		return blobstore.NewMemoryBlobStorageFactory()
+	})
+}
@@ -47,6 +47,43 @@ func (_m *TransactionalBlobStorage) CreateOrUpdate(networkID string, blobs []blo
 	return r0
 }
 
+// CreateOrUpdateIfVersion provides a mock function with given fields: networkID, blobs, expectedVersions
+func (_m *TransactionalBlobStorage) CreateOrUpdateIfVersion(networkID string, blobs []blobstore.Blob, expectedVersions map[storage.TypeAndKey]uint64) error {
+	ret := _m.Called(networkID, blobs, expectedVersions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []blobstore.Blob, map[storage.TypeAndKey]uint64) error); ok {
+		r0 = rf(networkID, blobs, expectedVersions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIfVersion provides a mock function with given fields: networkID, expectedVersions
+func (_m *TransactionalBlobStorage) DeleteIfVersion(networkID string, expectedVersions map[storage.TypeAndKey]uint64) ([]storage.TypeAndKey, error) {
+	ret := _m.Called(networkID, expectedVersions)
+
+	var r0 []storage.TypeAndKey
+	if rf, ok := ret.Get(0).(func(string, map[storage.TypeAndKey]uint64) []storage.TypeAndKey); ok {
+		r0 = rf(networkID, expectedVersions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.TypeAndKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[storage.TypeAndKey]uint64) error); ok {
+		r1 = rf(networkID, expectedVersions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: networkID, ids
 func (_m *TransactionalBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	ret := _m.Called(networkID, ids)
@@ -178,3 +215,26 @@ func (_m *TransactionalBlobStorage) Rollback() error {
 
 	return r0
 }
+
+// Search provides a mock function with given fields: filter
+func (_m *TransactionalBlobStorage) Search(filter blobstore.SearchFilter) (map[string][]blobstore.Blob, error) {
+	ret := _m.Called(filter)
+
+	var r0 map[string][]blobstore.Blob
+	if rf, ok := ret.Get(0).(func(blobstore.SearchFilter) map[string][]blobstore.Blob); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]blobstore.Blob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(blobstore.SearchFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
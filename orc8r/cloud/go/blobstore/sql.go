@@ -210,6 +210,85 @@ func (store *sqlBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) erro
 	return nil
 }
 
+func (store *sqlBlobStorage) CreateOrUpdateIfVersion(networkID string, blobs []Blob, expectedVersions map[storage.TypeAndKey]uint64) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	if err := validateExpectedVersions(blobs, expectedVersions); err != nil {
+		return err
+	}
+
+	// Write in a consistent order so concurrent writers lock rows in the
+	// same order
+	sortedBlobs := getSortedBlobs(blobs)
+
+	var conflictIDs []storage.TypeAndKey
+	for _, blob := range sortedBlobs {
+		id := blob.toID()
+		written, err := store.writeIfVersion(networkID, blob, expectedVersions[id])
+		if err != nil {
+			return errors.Wrapf(err, "Error writing blob %s on network %s", id, networkID)
+		}
+		if !written {
+			conflictIDs = append(conflictIDs, id)
+		}
+	}
+	if len(conflictIDs) == 0 {
+		return nil
+	}
+
+	storedBlobs, err := store.GetMany(networkID, conflictIDs)
+	if err != nil {
+		return fmt.Errorf("Error reading conflicting blobs: %s", err)
+	}
+	return newVersionConflictError(networkID, conflictIDs, expectedVersions, storedBlobs)
+}
+
+// writeIfVersion atomically updates the blob if its stored version is
+// expectedVersion, or creates it if it doesn't exist and expectedVersion is
+// 0. Returns false if the blob wasn't written.
+func (store *sqlBlobStorage) writeIfVersion(networkID string, blob Blob, expectedVersion uint64) (bool, error) {
+	res, err := store.builder.Update(store.tableName).
+		Set(valCol, blob.Value).
+		Set(verCol, expectedVersion+1).
+		Where(sq.Eq{
+			nidCol:  networkID,
+			typeCol: blob.Type,
+			keyCol:  blob.Key,
+			verCol:  expectedVersion,
+		}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+	if written, err := isOneRowAffected(res); written || err != nil {
+		return written, err
+	}
+	if expectedVersion != 0 {
+		return false, nil
+	}
+
+	res, err = store.builder.Insert(store.tableName).
+		Columns(nidCol, typeCol, keyCol, valCol, verCol).
+		Values(networkID, blob.Type, blob.Key, blob.Value, 1).
+		OnConflict(nil, nidCol, typeCol, keyCol).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+	return isOneRowAffected(res)
+}
+
+func isOneRowAffected(res sql.Result) (bool, error) {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
 func (store *sqlBlobStorage) GetExistingKeys(keys []string, filter SearchFilter) ([]string, error) {
 	if err := store.validateTx(); err != nil {
 		return nil, err
@@ -244,6 +323,49 @@ func (store *sqlBlobStorage) GetExistingKeys(keys []string, filter SearchFilter)
 	return scannedKeys, nil
 }
 
+func (store *sqlBlobStorage) Search(filter SearchFilter) (map[string][]Blob, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	whereConditions := sq.And{}
+	if filter.NetworkID != nil {
+		whereConditions = append(whereConditions, sq.Eq{nidCol: *filter.NetworkID})
+	}
+	if len(filter.Types) > 0 {
+		whereConditions = append(whereConditions, sq.Eq{typeCol: filter.Types})
+	}
+	if filter.KeyPrefix != nil {
+		whereConditions = append(whereConditions, sqorc.HasPrefix(keyCol, *filter.KeyPrefix))
+	}
+	rows, err := store.builder.Select(nidCol, typeCol, keyCol, valCol, verCol).From(store.tableName).
+		Where(whereConditions).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "Search")
+
+	ret := map[string][]Blob{}
+	for rows.Next() {
+		var nid string
+		blob := Blob{}
+		err = rows.Scan(&nid, &blob.Type, &blob.Key, &blob.Value, &blob.Version)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(nid, blob) {
+			ret[nid] = append(ret[nid], blob)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	sortSearchResults(ret)
+	return ret, nil
+}
+
 func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	if err := store.validateTx(); err != nil {
 		return err
@@ -257,6 +379,34 @@ func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey)
 	return err
 }
 
+func (store *sqlBlobStorage) DeleteIfVersion(networkID string, expectedVersions map[storage.TypeAndKey]uint64) ([]storage.TypeAndKey, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	deleted := []storage.TypeAndKey{}
+	for _, id := range getSortedIDs(expectedVersions) {
+		res, err := store.builder.Delete(store.tableName).
+			Where(sq.Eq{
+				nidCol:  networkID,
+				typeCol: id.Type,
+				keyCol:  id.Key,
+				verCol:  expectedVersions[id],
+			}).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error deleting blob %s on network %s", id, networkID)
+		}
+		if isDeleted, err := isOneRowAffected(res); err != nil {
+			return nil, err
+		} else if isDeleted {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
 func (store *sqlBlobStorage) IncrementVersion(networkID string, id storage.TypeAndKey) error {
 	if err := store.validateTx(); err != nil {
 		return err
@@ -387,3 +537,11 @@ func getSortedTypeAndKeys(blobsToChange map[storage.TypeAndKey]blobChange) []sto
 	sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
 	return ret
 }
+
+// getSortedBlobs returns a copy of the blobs sorted by ID
+func getSortedBlobs(blobs []Blob) []Blob {
+	ret := make([]Blob, len(blobs))
+	copy(ret, blobs)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].toID().String() < ret[j].toID().String() })
+	return ret
+}
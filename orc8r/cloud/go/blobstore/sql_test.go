@@ -413,6 +413,12 @@ func TestSqlBlobStorage_Integration(t *testing.T) {
 	integration(t, fact)
 }
 
+func TestSqlBlobStorage_Conformance(t *testing.T) {
+	conformance(t, func(t *testing.T) blobstore.BlobStorageFactory {
+		return blobstore.NewSQLBlobStorageFactory("network_table", newSharedSQLiteDB(t), sqorc.GetSqlBuilder())
+	})
+}
+
 type testCase struct {
 	// setup query expectations (begin/table init is generically handled)
 	setup func(sqlmock.Sqlmock)
@@ -9,7 +9,13 @@
 package blobstore
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"magma/orc8r/cloud/go/storage"
+
+	"github.com/thoas/go-funk"
 )
 
 // Blob encapsulates a blob for storage
@@ -20,8 +26,16 @@ type Blob struct {
 	Version uint64
 }
 
+// SearchFilter restricts the blobs matched by a search. Unset fields don't
+// restrict the search.
+// GetExistingKeys only uses NetworkID.
 type SearchFilter struct {
 	NetworkID *string
+	// Types restricts the search to blobs of any of the types
+	Types []string
+	// KeyPrefix restricts the search to blobs whose key starts with the
+	// prefix
+	KeyPrefix *string
 }
 
 // BlobStorageFactory is an API to create a storage API bound to a transaction.
@@ -64,14 +78,34 @@ type TransactionalBlobStorage interface {
 	// internally inside the storage implementation.
 	CreateOrUpdate(networkID string, blobs []Blob) error
 
+	// CreateOrUpdateIfVersion writes blobs to the storage only if their
+	// stored versions match expectedVersions, which must contain the
+	// expected version of every blob. An expected version of 0 matches a
+	// blob which doesn't exist yet. Written blobs are given the expected
+	// version + 1; the Version field of the passed blobs is ignored.
+	// If any stored version doesn't match, a *VersionConflictError listing
+	// every conflicting blob is returned. Other blobs may have been written
+	// by then, so the transaction should be rolled back.
+	CreateOrUpdateIfVersion(networkID string, blobs []Blob, expectedVersions map[storage.TypeAndKey]uint64) error
+
 	// GetExistingKeys takes in a list of keys and returns a list of keys
 	// that exist from the input. The filter specifies whether to look at the
 	// entire storage or just in a network.
 	GetExistingKeys(keys []string, filter SearchFilter) ([]string, error)
 
+	// Search returns all blobs matching the filter, keyed by network ID.
+	// The blobs for each network are sorted by type, then key.
+	Search(filter SearchFilter) (map[string][]Blob, error)
+
 	// Delete deletes specified blobs from storage.
 	Delete(networkID string, ids []storage.TypeAndKey) error
 
+	// DeleteIfVersion deletes the blobs whose stored versions match
+	// expectedVersions and returns the IDs of the deleted blobs. Blobs whose
+	// stored versions don't match, e.g. because they were updated after they
+	// were read, are left in place.
+	DeleteIfVersion(networkID string, expectedVersions map[storage.TypeAndKey]uint64) ([]storage.TypeAndKey, error)
+
 	// IncrementVersion is an atomic upsert (INSERT DO ON CONFLICT) that
 	// increments the version column or inserts 1 if it does not exist.
 	IncrementVersion(networkID string, id storage.TypeAndKey) error
@@ -86,3 +120,59 @@ func GetBlobsByTypeAndKey(blobs []Blob) map[storage.TypeAndKey]Blob {
 	}
 	return ret
 }
+
+// getSortedIDs returns the IDs of the expected versions in a consistent
+// order, so concurrent writers lock rows in the same order.
+func getSortedIDs(expectedVersions map[storage.TypeAndKey]uint64) []storage.TypeAndKey {
+	ids := make([]storage.TypeAndKey, 0, len(expectedVersions))
+	for id := range expectedVersions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+// validateExpectedVersions checks that every blob has an expected version
+// and appears only once.
+func validateExpectedVersions(blobs []Blob, expectedVersions map[storage.TypeAndKey]uint64) error {
+	seen := map[storage.TypeAndKey]struct{}{}
+	for _, blob := range blobs {
+		id := storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
+		if _, ok := expectedVersions[id]; !ok {
+			return fmt.Errorf("no expected version for blob %s", id)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("blob %s appears more than once", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// matches returns true if the blob in the network matches the filter.
+// The SQL backends filter with the column collation, which may compare keys
+// case-insensitively, so their results are filtered again with matches.
+func (filter SearchFilter) matches(networkID string, blob Blob) bool {
+	if filter.NetworkID != nil && *filter.NetworkID != networkID {
+		return false
+	}
+	if len(filter.Types) > 0 && !funk.ContainsString(filter.Types, blob.Type) {
+		return false
+	}
+	if filter.KeyPrefix != nil && !strings.HasPrefix(blob.Key, *filter.KeyPrefix) {
+		return false
+	}
+	return true
+}
+
+// sortSearchResults sorts the blobs for each network by type, then key.
+func sortSearchResults(blobsByNetwork map[string][]Blob) {
+	for _, blobs := range blobsByNetwork {
+		sort.Slice(blobs, func(i, j int) bool {
+			if blobs[i].Type != blobs[j].Type {
+				return blobs[i].Type < blobs[j].Type
+			}
+			return blobs[i].Key < blobs[j].Key
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package broadcast fans out events published in a process to all of the
+// process's subscribers. It backs the watch streams of the orchestrator
+// services. Events are not shared between processes: a service with several
+// replicas should have each replica publish the events it reads from a
+// source shared by all replicas, like the configurator's change log.
+package broadcast
+
+import (
+	"sync"
+)
+
+// Broadcaster fans out published events to every subscription they match.
+// Subscriptions which fall more than the buffer size events behind have their
+// channel closed, and should reload the state they care about before
+// subscribing again.
+type Broadcaster struct {
+	sync.Mutex
+	bufferSize    int
+	subscriptions map[*Subscription]struct{}
+}
+
+// Subscription receives the published events which match its filter.
+type Subscription struct {
+	filter func(event interface{}) bool
+	events chan interface{}
+}
+
+// Events returns the channel of the subscription's events. The channel is
+// closed when the subscriber falls behind or unsubscribes.
+func (s *Subscription) Events() <-chan interface{} {
+	return s.events
+}
+
+// NewBroadcaster returns a Broadcaster which queues up to bufferSize events
+// for each subscription.
+func NewBroadcaster(bufferSize int) *Broadcaster {
+	return &Broadcaster{bufferSize: bufferSize, subscriptions: map[*Subscription]struct{}{}}
+}
+
+// Subscribe returns a subscription to the published events for which filter
+// returns true. A nil filter matches every event.
+func (b *Broadcaster) Subscribe(filter func(event interface{}) bool) *Subscription {
+	s := &Subscription{filter: filter, events: make(chan interface{}, b.bufferSize)}
+	b.Lock()
+	defer b.Unlock()
+	b.subscriptions[s] = struct{}{}
+	return s
+}
+
+// Unsubscribe removes the subscription and closes its channel, if the
+// subscription hasn't already been dropped.
+func (b *Broadcaster) Unsubscribe(s *Subscription) {
+	b.Lock()
+	defer b.Unlock()
+	b.removeUnsafe(s)
+}
+
+// DropAll removes every subscription and closes its channel, as if all
+// subscribers had fallen behind.
+func (b *Broadcaster) DropAll() {
+	b.Lock()
+	defer b.Unlock()
+	for s := range b.subscriptions {
+		b.removeUnsafe(s)
+	}
+}
+
+// Publish sends the events, in order, to every subscription they match.
+// Publish never blocks on subscribers: a subscription whose buffer is full is
+// dropped.
+func (b *Broadcaster) Publish(events ...interface{}) {
+	if len(events) == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	for s := range b.subscriptions {
+		for _, event := range events {
+			if s.filter != nil && !s.filter(event) {
+				continue
+			}
+			select {
+			case s.events <- event:
+				continue
+			default:
+				b.removeUnsafe(s)
+			}
+			break
+		}
+	}
+}
+
+func (b *Broadcaster) removeUnsafe(s *Subscription) {
+	if _, ok := b.subscriptions[s]; ok {
+		delete(b.subscriptions, s)
+		close(s.events)
+	}
+}
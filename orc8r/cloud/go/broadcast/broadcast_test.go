@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package broadcast_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/broadcast"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := broadcast.NewBroadcaster(2)
+	all := b.Subscribe(nil)
+	even := b.Subscribe(func(event interface{}) bool { return event.(int)%2 == 0 })
+
+	b.Publish(1, 2)
+	assert.Equal(t, []interface{}{1, 2}, drain(all))
+	assert.Equal(t, []interface{}{2}, drain(even))
+
+	// a subscription which falls behind is dropped, others keep receiving
+	b.Publish(4, 5, 6)
+	assert.Equal(t, []interface{}{4, 6}, drain(even))
+	_, ok := <-all.Events()
+	assert.True(t, ok)
+	_, ok = <-all.Events()
+	assert.True(t, ok)
+	_, ok = <-all.Events()
+	assert.False(t, ok)
+
+	b.Unsubscribe(even)
+	_, ok = <-even.Events()
+	assert.False(t, ok)
+	// unsubscribing twice is a no-op
+	b.Unsubscribe(even)
+	b.Unsubscribe(all)
+	b.Publish(8)
+
+	s := b.Subscribe(nil)
+	b.DropAll()
+	_, ok = <-s.Events()
+	assert.False(t, ok)
+}
+
+// drain returns the events queued for the subscription
+func drain(s *broadcast.Subscription) []interface{} {
+	var ret []interface{}
+	for {
+		select {
+		case event := <-s.Events():
+			ret = append(ret, event)
+		default:
+			return ret
+		}
+	}
+}
@@ -37,6 +37,21 @@ type Api interface {
 	Delete(table string, key string) error
 	DeleteMany(table string, keys []string) (map[string]error, error)
 	ListKeys(table string) ([]string, error)
+	// ListKeysWithPrefix returns the keys of the table which start with prefix
+	ListKeysWithPrefix(table string, prefix string) ([]string, error)
 	DeleteTable(table string) error
 	DoesKeyExist(table string, key string) (bool, error)
 }
+
+// TxApi is implemented by datastores which can run several reads and writes
+// atomically
+type TxApi interface {
+	Api
+	// DoInTx calls fn with a datastore whose reads and writes all run in one
+	// serializable transaction. fn can only use the given tables, and can't
+	// delete tables. The transaction is committed if fn returns nil and
+	// rolled back otherwise. Transactions which conflict with concurrent
+	// transactions are retried a few times, so fn may be called more than
+	// once. DoInTx calls of the store passed to fn join its transaction.
+	DoInTx(tables []string, fn func(store TxApi) error) error
+}
@@ -171,6 +171,29 @@ func (_m *Api) ListKeys(table string) ([]string, error) {
 	return r0, r1
 }
 
+// ListKeysWithPrefix provides a mock function with given fields: table, prefix
+func (_m *Api) ListKeysWithPrefix(table string, prefix string) ([]string, error) {
+	ret := _m.Called(table, prefix)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(table, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(table, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Put provides a mock function with given fields: table, key, value
 func (_m *Api) Put(table string, key string, value []byte) error {
 	ret := _m.Called(table, key, value)
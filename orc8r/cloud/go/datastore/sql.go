@@ -9,13 +9,16 @@ LICENSE file in the root directory of this source tree.
 package datastore
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"magma/orc8r/cloud/go/sqorc"
 
 	sq "github.com/Masterminds/squirrel"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/golang/glog"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
@@ -30,9 +33,18 @@ const (
 	deletedCol = "deleted"
 )
 
+// maxTxAttempts is the number of times DoInTx runs a transaction which
+// conflicts with concurrent transactions
+const maxTxAttempts = 3
+
 type SqlDb struct {
 	db      *sql.DB
 	builder sqorc.StatementBuilder
+	// tx is the transaction all operations run in, it's only set for the
+	// datastores passed to DoInTx functions
+	tx *sql.Tx
+	// txTables are the tables which can be used in tx
+	txTables map[string]bool
 }
 
 func NewSqlDb(driver string, source string, sqlBuilder sqorc.StatementBuilder) (*SqlDb, error) {
@@ -47,6 +59,72 @@ func NewSqlDb(driver string, source string, sqlBuilder sqorc.StatementBuilder) (
 	}, nil
 }
 
+// DoInTx runs fn in a serializable transaction, see TxApi. The tables are
+// created ahead of the transaction, since DDL statements implicitly commit
+// the running transaction in MySQL and MariaDB.
+func (store *SqlDb) DoInTx(tables []string, fn func(store TxApi) error) error {
+	if store.tx != nil {
+		for _, table := range tables {
+			if !store.txTables[table] {
+				return errors.Errorf("table %s can't be used in the running transaction", table)
+			}
+		}
+		return fn(store)
+	}
+
+	txTables := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), func(*sql.Tx) (interface{}, error) { return nil, nil })
+		if err != nil {
+			return err
+		}
+		txTables[table] = true
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = store.doInTx(txTables, fn)
+		if !sqorc.IsSerializationFailure(err) {
+			return err
+		}
+		glog.V(2).Infof("Retrying transaction after serialization failure: %s", err)
+	}
+	return err
+}
+
+func (store *SqlDb) doInTx(txTables map[string]bool, fn func(store TxApi) error) (err error) {
+	tx, err := store.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			glog.Errorf("error rolling back tx: %s", rollbackErr)
+		}
+	}()
+	return fn(&SqlDb{db: store.db, builder: store.builder, tx: tx, txTables: txTables})
+}
+
+// execInTx runs txFn in a new transaction, or in the DoInTx transaction of
+// the datastore. Tables are only created outside of DoInTx transactions.
+func (store *SqlDb) execInTx(
+	table string,
+	initFn func(*sql.Tx) error,
+	txFn func(*sql.Tx) (interface{}, error),
+) (interface{}, error) {
+	if store.tx == nil {
+		return sqorc.ExecInTx(store.db, initFn, txFn)
+	}
+	if !store.txTables[table] {
+		return nil, errors.Errorf("table %s wasn't passed to DoInTx", table)
+	}
+	return txFn(store.tx)
+}
+
 func (store *SqlDb) getInitFn(table string) func(*sql.Tx) error {
 	return func(tx *sql.Tx) error {
 		_, err := store.builder.CreateTable(table).
@@ -95,7 +173,7 @@ func (store *SqlDb) Put(table string, key string, value []byte) error {
 				Exec()
 		}
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return err
 }
 
@@ -159,7 +237,7 @@ func (store *SqlDb) PutMany(table string, valuesToPut map[string][]byte) (map[st
 		}
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return ret.(map[string]error), err
 }
 
@@ -178,7 +256,7 @@ func (store *SqlDb) Get(table string, key string) ([]byte, uint64, error) {
 		return ValueWrapper{Value: value, Generation: generationNumber}, err
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -190,7 +268,7 @@ func (store *SqlDb) GetMany(table string, keys []string) (map[string]ValueWrappe
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return store.getMany(tx, table, keys)
 	}
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return ret.(map[string]ValueWrapper), err
 }
 
@@ -198,7 +276,7 @@ func (store *SqlDb) Delete(table string, key string) error {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return store.builder.Delete(table).Where(sq.Eq{keyCol: key}).RunWith(tx).Exec()
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return err
 }
 
@@ -206,7 +284,7 @@ func (store *SqlDb) DeleteMany(table string, keys []string) (map[string]error, e
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return store.builder.Delete(table).Where(sq.Eq{keyCol: keys}).RunWith(tx).Exec()
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return map[string]error{}, err
 }
 
@@ -229,7 +307,37 @@ func (store *SqlDb) ListKeys(table string) ([]string, error) {
 		return keys, nil
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
+	return ret.([]string), err
+}
+
+func (store *SqlDb) ListKeysWithPrefix(table string, prefix string) ([]string, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		rows, err := store.builder.Select(keyCol).
+			From(table).
+			Where(sqorc.HasPrefix(keyCol, prefix)).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return []string{}, errors.Wrap(err, "failed to query for keys")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "ListKeysWithPrefix")
+
+		keys := []string{}
+		for rows.Next() {
+			var key string
+			if err = rows.Scan(&key); err != nil {
+				return []string{}, errors.Wrap(err, "failed to read key")
+			}
+			// LIKE is case-insensitive in SQLite and MySQL
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return ret.([]string), err
 }
 
@@ -237,8 +345,12 @@ func (store *SqlDb) DeleteTable(table string) error {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return tx.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", table))
 	}
+	if store.tx != nil {
+		// DROP TABLE implicitly commits the running transaction in MySQL
+		return errors.New("tables can't be deleted in a transaction")
+	}
 	// No initFn param because why would we create a table that we're dropping
-	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, txFn)
+	_, err := store.execInTx(table, func(*sql.Tx) error { return nil }, txFn)
 	return err
 }
 
@@ -258,7 +370,7 @@ func (store *SqlDb) DoesKeyExist(table string, key string) (bool, error) {
 		}
 		return true, nil
 	}
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, store.getInitFn(table), txFn)
 	return ret.(bool), err
 }
 
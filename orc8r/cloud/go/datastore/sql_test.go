@@ -9,6 +9,8 @@ LICENSE file in the root directory of this source tree.
 package datastore_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"magma/orc8r/cloud/go/datastore"
@@ -148,3 +150,99 @@ func TestDatastoreBulkOperations(t *testing.T) {
 	assert.Equal(t, expectedDbRows, dbRows)
 
 }
+
+func TestDatastoreDoInTx(t *testing.T) {
+	ds, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		if err := store.Put("test", "key1", []byte("value1")); err != nil {
+			return err
+		}
+		value, _, err := store.Get("test", "key1")
+		assert.Equal(t, []byte("value1"), value)
+		return err
+	})
+	assert.NoError(t, err)
+	value, _, err := ds.Get("test", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	// Writes are rolled back if the function fails
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		if err := store.Put("test", "key2", []byte("value2")); err != nil {
+			return err
+		}
+		if err := store.Delete("test", "key1"); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	assert.EqualError(t, err, "rollback")
+	keys, err := ds.ListKeys("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key1"}, keys)
+
+	// Only the given tables can be used
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		return store.Put("other", "key1", []byte("value1"))
+	})
+	assert.EqualError(t, err, "table other wasn't passed to DoInTx")
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		return store.DoInTx([]string{"other"}, func(datastore.TxApi) error { return nil })
+	})
+	assert.EqualError(t, err, "table other can't be used in the running transaction")
+
+	// Serialization failures are retried a few times
+	attempts := 0
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		attempts++
+		if err := store.Put("test", fmt.Sprintf("attempt%d", attempts), []byte("value")); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return errors.New("database is locked")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	keys, err = ds.ListKeys("test")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"key1", "attempt2"}, keys)
+
+	attempts = 0
+	err = ds.DoInTx([]string{"test"}, func(store datastore.TxApi) error {
+		attempts++
+		return errors.New("database is locked")
+	})
+	assert.EqualError(t, err, "database is locked")
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDatastore_ListKeysWithPrefix(t *testing.T) {
+	ds, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+
+	_, err = ds.PutMany("test", map[string][]byte{
+		"gw1/sid1":  []byte("value1"),
+		"gw1/sid2":  []byte("value2"),
+		"gw10/sid3": []byte("value3"),
+		"gw%/sid4":  []byte("value4"),
+		"GW1/sid5":  []byte("value5"),
+	})
+	assert.NoError(t, err)
+
+	keys, err := ds.ListKeysWithPrefix("test", "gw1/")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"gw1/sid1", "gw1/sid2"}, keys)
+
+	// The prefix is matched literally
+	keys, err = ds.ListKeysWithPrefix("test", "gw%/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gw%/sid4"}, keys)
+
+	keys, err = ds.ListKeysWithPrefix("test", "gw2/")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
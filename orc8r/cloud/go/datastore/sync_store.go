@@ -61,6 +61,12 @@ func (s *SyncStore) ListKeys(table string) ([]string, error) {
 	return s.store.ListKeys(table)
 }
 
+func (s *SyncStore) ListKeysWithPrefix(table string, prefix string) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.ListKeysWithPrefix(table, prefix)
+}
+
 func (s *SyncStore) DeleteTable(table string) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
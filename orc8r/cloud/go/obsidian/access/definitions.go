@@ -18,3 +18,14 @@ const (
 	// Client Certificate Serial Number Header
 	CLIENT_CERT_SN_KEY = "X-Magma-Client-Cert-Serial"
 )
+
+// API tokens are accepted in the standard Authorization header as:
+// Authorization: Bearer <token>
+const (
+	AUTHORIZATION_KEY   = "Authorization"
+	BEARER_TOKEN_PREFIX = "Bearer "
+)
+
+// The access Middleware stores the Identity of the request's authenticated
+// operator in the request's echo context under this key
+const AUTHENTICATED_OPERATOR_KEY = "magma_authenticated_operator"
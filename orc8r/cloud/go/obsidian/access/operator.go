@@ -76,3 +76,14 @@ func RequestOperator(c echo.Context) (*protos.Identity, error) {
 	// all checks are OK, return it
 	return certInfo.Id, nil
 }
+
+// AuthenticatedOperator returns Identity of request's Operator authenticated
+// by the access Middleware, either by its client certificate or by its API
+// token. nil is returned if the request's credentials weren't authenticated
+func AuthenticatedOperator(c echo.Context) *protos.Identity {
+	if c == nil {
+		return nil
+	}
+	oper, _ := c.Get(AUTHENTICATED_OPERATOR_KEY).(*protos.Identity)
+	return oper
+}
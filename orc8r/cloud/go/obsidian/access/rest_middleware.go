@@ -20,12 +20,14 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Access Middleware:
 // 1) determines request's access type (READ/WRITE)
-// 2) finds Operator & Entities of the request
-// 3) verifies Operator's access permissions for the entities
+// 2) finds Operator (or API token) & Entities of the request
+// 3) verifies Operator's (or API token's) access permissions for the entities
 
 func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -35,6 +37,26 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// find out request's access type (READ|WRITE|READ & WRITE)
 		perm := requestPermissions(c)
 
+		// Bypass farther identity Checks for static docs GET having an
+		// operator cert (or a valid API token) should be enough
+		urlPath := c.Path()
+		checkACL := perm != accessprotos.AccessControl_READ || !(strings.HasPrefix(urlPath, obsidian.StaticURLPrefix))
+		var ents []*accessprotos.AccessControl_Entity
+		if checkACL {
+			ents = requestEntities(c, perm)
+		}
+
+		// API token requests are checked by accessd against both the token
+		// scope and the token operator's ACL
+		if token := RequestBearerToken(c); len(token) > 0 {
+			oper, err := accessd.CheckTokenPermissions(token, ents...)
+			if err != nil {
+				return handleAccessError(c, err)
+			}
+			c.Set(AUTHENTICATED_OPERATOR_KEY, oper)
+			return callNext(c, next)
+		}
+
 		// Get Request's Operator
 		oper, err := RequestOperator(c)
 		if err != nil {
@@ -52,33 +74,39 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 				http.StatusUnauthorized,
 				"Missing Client Credentials")
 		}
+		c.Set(AUTHENTICATED_OPERATOR_KEY, oper)
 
-		// Bypass farther identity Checks for static docs GET having an
-		// operator cert should be enough
-		if urlPath := c.Path(); perm != accessprotos.AccessControl_READ || !(strings.HasPrefix(urlPath, obsidian.StaticURLPrefix)) {
-			// Get Request's Entities' Ids
-			ids := FindRequestedIdentities(c)
-
+		if checkACL {
 			// Check Operator's ACL for required entity permissions
-			ents := make([]*accessprotos.AccessControl_Entity, len(ids))
-			for i, e := range ids {
-				ents[i] = &accessprotos.AccessControl_Entity{Id: e, Permissions: perm}
-			}
 			err = accessd.CheckPermissions(oper, ents...)
 			if err != nil {
-				if _, ok := err.(errors.ClientInitError); ok {
-					return handleError(c, http.StatusServiceUnavailable, "Service Unavailable")
-				}
-				return handleError(
-					c, http.StatusForbidden, "Access Denied (%s)", err)
+				return handleAccessError(c, err)
 			}
 		}
-		// all good, call next handler
-		if next != nil {
-			return next(c)
-		}
-		return nil
+		return callNext(c, next)
+	}
+}
+
+// all good, call next handler
+func callNext(c echo.Context, next echo.HandlerFunc) error {
+	if next != nil {
+		return next(c)
 	}
+	return nil
+}
+
+// Return the request's entities with the required request permission
+func requestEntities(
+	c echo.Context,
+	perm accessprotos.AccessControl_Permission,
+) []*accessprotos.AccessControl_Entity {
+	// Get Request's Entities' Ids
+	ids := FindRequestedIdentities(c)
+	ents := make([]*accessprotos.AccessControl_Entity, len(ids))
+	for i, e := range ids {
+		ents[i] = &accessprotos.AccessControl_Entity{Id: e, Permissions: perm}
+	}
+	return ents
 }
 
 // Return required request permission (READ, WRITE or READ & WRITE)
@@ -99,6 +127,38 @@ func requestPermissions(c echo.Context) accessprotos.AccessControl_Permission {
 	return perm
 }
 
+// handleAccessError returns the HTTP error for an accessd permissions check
+// error. Only authentication & authorization failures are reported as 401 &
+// 403, accessd failures are server errors the client may retry
+func handleAccessError(c echo.Context, err error) error {
+	switch code := accessErrorStatus(err); code {
+	case http.StatusUnauthorized:
+		return handleError(c, code, "API Token Error: %s", err)
+	case http.StatusForbidden:
+		return handleError(c, code, "Access Denied (%s)", err)
+	case http.StatusServiceUnavailable:
+		return handleError(c, code, "Service Unavailable")
+	default:
+		return handleError(c, code, "Access Check Error: %s", err)
+	}
+}
+
+func accessErrorStatus(err error) int {
+	if _, ok := err.(errors.ClientInitError); ok {
+		return http.StatusServiceUnavailable
+	}
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied, codes.NotFound, codes.InvalidArgument:
+		return http.StatusForbidden
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func handleError(
 	c echo.Context,
 	status int,
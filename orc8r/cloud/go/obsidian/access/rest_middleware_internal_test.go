@@ -0,0 +1,31 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package access
+
+import (
+	"net/http"
+	"testing"
+
+	"magma/orc8r/cloud/go/errors"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessErrorStatus(t *testing.T) {
+	assert.Equal(t, http.StatusServiceUnavailable, accessErrorStatus(errors.NewInitError(nil, "ACCESSD")))
+	assert.Equal(t, http.StatusUnauthorized, accessErrorStatus(status.Error(codes.Unauthenticated, "")))
+	assert.Equal(t, http.StatusForbidden, accessErrorStatus(status.Error(codes.PermissionDenied, "")))
+	assert.Equal(t, http.StatusForbidden, accessErrorStatus(status.Error(codes.NotFound, "")))
+	assert.Equal(t, http.StatusServiceUnavailable, accessErrorStatus(status.Error(codes.Unavailable, "")))
+	assert.Equal(t, http.StatusServiceUnavailable, accessErrorStatus(status.Error(codes.DeadlineExceeded, "")))
+	assert.Equal(t, http.StatusInternalServerError, accessErrorStatus(status.Error(codes.Internal, "")))
+	assert.Equal(t, http.StatusInternalServerError, accessErrorStatus(status.Error(codes.Unknown, "")))
+}
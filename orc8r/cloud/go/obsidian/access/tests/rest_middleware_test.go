@@ -11,11 +11,15 @@ package tests
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 
+	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/services/accessd"
+	"magma/orc8r/cloud/go/services/accessd/protos"
 	magmadh "magma/orc8r/cloud/go/services/magmad/obsidian/handlers"
 )
 
@@ -175,6 +179,96 @@ func TestMiddleware(t *testing.T) {
 	assert.Equal(t, 200, s)
 }
 
+func TestMiddlewareWithToken(t *testing.T) {
+	_, superCertSn := MockAccessControl(t)
+
+	e := startTestMidlewareServer(t)
+
+	listener := WaitForTestServer(t, e)
+
+	if listener == nil {
+		return // WaitForTestServer should have 'logged' error already
+	}
+
+	urlPrefix := "http://" + listener.Addr().String()
+
+	// The token only has READ permissions of the operator's READ network
+	oper := identity.NewOperator(TEST_OPERATOR_ID)
+	issued, err := accessd.IssueToken(
+		oper,
+		[]*protos.AccessControl_Entity{
+			{Id: identity.NewNetwork(TEST_NETWORK_ID), Permissions: protos.AccessControl_READ},
+		},
+		"test",
+		time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	// Test READ network entity
+	s, err := SendTokenRequest(
+		"GET", // READ
+		urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID,
+		issued.Bearer,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+
+	// Test WRITE network entity, out of the operator's ACL
+	s, err = SendTokenRequest(
+		"PUT", // WRITE
+		urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID,
+		issued.Bearer,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 403, s)
+
+	// Test WRITE network entity, in the operator's ACL but out of the
+	// token scope
+	s, err = SendTokenRequest(
+		"PUT", // WRITE
+		urlPrefix+magmadh.RegisterNetwork+"/"+WRITE_TEST_NETWORK_ID,
+		issued.Bearer,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 403, s)
+
+	// Test READ network Wildcard
+	s, err = SendTokenRequest(
+		"GET", // READ
+		urlPrefix+magmadh.RegisterNetwork,
+		issued.Bearer,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 403, s)
+
+	// Test invalid token
+	s, err = SendTokenRequest(
+		"GET", // READ
+		urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID,
+		issued.Token.Id+".invalid",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, s)
+
+	// Test revoked token
+	assert.NoError(t, accessd.RevokeToken(issued.Token.Id))
+	s, err = SendTokenRequest(
+		"GET", // READ
+		urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID,
+		issued.Bearer,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, s)
+
+	// Certificates still work
+	s, err = SendRequest(
+		"GET", // READ
+		urlPrefix+magmadh.RegisterNetwork,
+		superCertSn,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+}
+
 func startTestMidlewareServer(t *testing.T) *echo.Echo {
 	e := echo.New()
 
@@ -190,8 +284,12 @@ func startTestMidlewareServer(t *testing.T) *echo.Echo {
 		return c.String(http.StatusOK, "")
 	})
 
-	// Endpoint requiring a specific Network READ Entity Access Permissions
+	// Endpoint requiring a specific Network READ Entity Access Permissions,
+	// the middleware passes the authenticated operator on to the handlers
 	e.GET(magmadh.ManageNetwork, func(c echo.Context) error {
+		if access.AuthenticatedOperator(c) == nil {
+			return c.String(http.StatusInternalServerError, "Missing Operator")
+		}
 		return c.String(http.StatusOK, "All good!")
 	})
 
@@ -62,13 +62,22 @@ func WaitForTestServer(t *testing.T, e *echo.Echo) net.Listener {
 }
 
 func SendRequest(method, url, certSn string) (int, error) {
+	return sendRequest(method, url, access.CLIENT_CERT_SN_KEY, certSn)
+}
+
+// SendTokenRequest sends a request authenticated with the given API token
+func SendTokenRequest(method, url, token string) (int, error) {
+	return sendRequest(method, url, access.AUTHORIZATION_KEY, access.BEARER_TOKEN_PREFIX+token)
+}
+
+func sendRequest(method, url, authKey, authValue string) (int, error) {
 	var body io.Reader = nil
 	request, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return 0, err
 	}
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set(access.CLIENT_CERT_SN_KEY, certSn)
+	request.Header.Set(authKey, authValue)
 
 	var client = &http.Client{}
 
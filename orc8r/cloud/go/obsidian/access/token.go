@@ -0,0 +1,47 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package access
+
+import (
+	"strings"
+
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+
+	"github.com/labstack/echo"
+)
+
+// RequestBearerToken returns the API token of the request's Authorization
+// header or an empty string if the request doesn't carry a bearer token
+func RequestBearerToken(c echo.Context) string {
+	if c == nil || c.Request() == nil {
+		return ""
+	}
+	auth := c.Request().Header.Get(AUTHORIZATION_KEY)
+	if len(auth) <= len(BEARER_TOKEN_PREFIX) ||
+		!strings.EqualFold(auth[:len(BEARER_TOKEN_PREFIX)], BEARER_TOKEN_PREFIX) {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(BEARER_TOKEN_PREFIX):])
+}
+
+// CheckRequestPermissions verifies that the request's credentials - either
+// its API token or its client certificate's operator - grant the requested
+// permissions for all given entities
+func CheckRequestPermissions(c echo.Context, ents ...*accessprotos.AccessControl_Entity) error {
+	if token := RequestBearerToken(c); len(token) > 0 {
+		_, err := accessd.CheckTokenPermissions(token, ents...)
+		return err
+	}
+	oper, err := RequestOperator(c)
+	if err != nil {
+		return err
+	}
+	return accessd.CheckPermissions(oper, ents...)
+}
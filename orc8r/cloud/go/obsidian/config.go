@@ -8,6 +8,8 @@
 
 package obsidian
 
+import "net"
+
 const (
 	Product              = "Obsidian Server"
 	Version              = "0.1"
@@ -30,4 +32,8 @@ var (
 	ClientCAPoolPath   string
 	AllowAnyClientCert bool
 	StaticFolder       string
+	// TrustedProxies are the networks of the reverse proxies in front of the
+	// server. Client IPs are only taken from the forwarding headers of
+	// requests coming from these networks.
+	TrustedProxies []*net.IPNet
 )
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+var errPreconditionFailed = errors.New("resource has been modified since the provided ETag was issued")
+
+// SetETag sets the ETag header of the response. etag should be the unquoted
+// entity tag.
+func SetETag(c echo.Context, etag string) {
+	c.Response().Header().Set(ETagHeader, fmt.Sprintf("%q", etag))
+}
+
+// HasIfMatch returns true if the request carries an If-Match header which
+// restricts the write to a particular version of the resource.
+func HasIfMatch(c echo.Context) bool {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(IfMatchHeader))
+	return ifMatch != "" && ifMatch != "*"
+}
+
+// CheckIfMatch compares the request's If-Match header against the current
+// entity tag of the resource. A 412 error is returned if the request has an
+// If-Match header which doesn't match etag. Requests without an If-Match
+// header or with a wildcard If-Match always pass.
+func CheckIfMatch(c echo.Context, etag string) *echo.HTTPError {
+	if !HasIfMatch(c) {
+		return nil
+	}
+	for _, candidate := range strings.Split(c.Request().Header.Get(IfMatchHeader), ",") {
+		candidate = strings.TrimSpace(candidate)
+		// Weak tags never match under the strong comparison If-Match uses
+		if strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		if strings.Trim(candidate, `"`) == etag {
+			return nil
+		}
+	}
+	return HttpError(errPreconditionFailed, http.StatusPreconditionFailed)
+}
+
+// ConditionalWriteHttpError converts an error from a configurator write which
+// was conditioned on entity versions to an HTTP error. Version conflicts are
+// reported as failed preconditions.
+func ConditionalWriteHttpError(err error) *echo.HTTPError {
+	if status.Code(err) == codes.FailedPrecondition {
+		return HttpError(errPreconditionFailed, http.StatusPreconditionFailed)
+	}
+	return HttpError(err, http.StatusInternalServerError)
+}
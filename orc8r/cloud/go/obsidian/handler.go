@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"magma/orc8r/cloud/go/util"
 
@@ -259,6 +260,21 @@ func GetParamValues(c echo.Context, paramNames ...string) ([]string, *echo.HTTPE
 	return ret, nil
 }
 
+// GetUintQueryParam parses an optional unsigned integer query parameter of the
+// given bit size, returning 0 if it's missing. Returns a status bad request
+// HTTP error if the value isn't a valid integer.
+func GetUintQueryParam(c echo.Context, name string, bitSize int) (uint64, *echo.HTTPError) {
+	paramStr := c.QueryParam(name)
+	if paramStr == "" {
+		return 0, nil
+	}
+	ret, err := strconv.ParseUint(paramStr, 10, bitSize)
+	if err != nil {
+		return 0, HttpError(fmt.Errorf("invalid value for %s: %s", name, paramStr), http.StatusBadRequest)
+	}
+	return ret, nil
+}
+
 func GetOperatorId(c echo.Context) (string, *echo.HTTPError) {
 	operId := c.Param("operator_id")
 	if operId == "" {
@@ -11,6 +11,8 @@ package main
 import (
 	"flag"
 	"log"
+	"net"
+	"strings"
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/obsidian"
@@ -49,11 +51,22 @@ func main() {
 		"Folder containing the static files served",
 	)
 
+	trustedProxies := flag.String(
+		"trusted_proxies", "",
+		"Comma separated CIDRs of the reverse proxies whose X-Forwarded-For and X-Real-IP headers are trusted. "+
+			"Default is no trusted proxies: audit records hold the address requests come from.",
+	)
+
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, obsidian.ServiceName)
 	if err != nil {
 		log.Fatalf("Error creating service: %s", err)
 	}
 
+	obsidian.TrustedProxies, err = parseCIDRs(*trustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid trusted proxies: %s", err)
+	}
+
 	if obsidian.Port == -1 {
 		obsidian.Port = obsidian.DefaultPort
 		if obsidian.TLS {
@@ -64,3 +77,19 @@ func main() {
 	go srv.Run()
 	server.Start()
 }
+
+func parseCIDRs(cidrs string) ([]*net.IPNet, error) {
+	var ret []*net.IPNet
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ipNet)
+	}
+	return ret, nil
+}
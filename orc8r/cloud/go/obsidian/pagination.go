@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PageSizeParam  = "page_size"
+	PageTokenParam = "page_token"
+
+	// DefaultPageSize is the page size used for requests which provide a
+	// page token without a page size
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page size a request can ask for
+	MaxPageSize = 1000
+)
+
+// GetPaginationParams returns the page size and page token query params of a
+// list request. A page size of 0 is returned if the request doesn't ask for a
+// paginated response, in which case the handler should return the full list.
+func GetPaginationParams(c echo.Context) (uint32, string, *echo.HTTPError) {
+	pageSizeParam := c.QueryParam(PageSizeParam)
+	pageToken := c.QueryParam(PageTokenParam)
+	if pageSizeParam == "" {
+		if pageToken == "" {
+			return 0, "", nil
+		}
+		return DefaultPageSize, pageToken, nil
+	}
+
+	pageSize, err := strconv.ParseUint(pageSizeParam, 10, 32)
+	if err != nil || pageSize == 0 || pageSize > MaxPageSize {
+		return 0, "", HttpError(
+			fmt.Errorf("%s must be an integer between 1 and %d", PageSizeParam, MaxPageSize),
+			http.StatusBadRequest,
+		)
+	}
+	return uint32(pageSize), pageToken, nil
+}
+
+// PaginatedLoadHttpError converts an error from a paginated configurator
+// load to an HTTP error. Invalid page tokens are reported as bad requests.
+func PaginatedLoadHttpError(err error) *echo.HTTPError {
+	if status.Code(err) == codes.InvalidArgument {
+		return HttpError(err, http.StatusBadRequest)
+	}
+	return HttpError(err, http.StatusInternalServerError)
+}
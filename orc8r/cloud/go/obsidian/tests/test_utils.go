@@ -17,6 +17,7 @@ import (
 	"magma/orc8r/cloud/go/obsidian/access"
 	access_tests "magma/orc8r/cloud/go/obsidian/access/tests"
 	"magma/orc8r/cloud/go/obsidian/server"
+	audit_test_init "magma/orc8r/cloud/go/services/audit/test_init"
 	"magma/orc8r/cloud/go/util"
 )
 
@@ -76,6 +77,8 @@ func StartObsidian(t *testing.T) int {
 	// unit tests
 	TestOperatorSerialNumber =
 		access_tests.StartMockAccessControl(t, TEST_ADMIN_OPERATOR_ID)
+	// mutating requests fail unless they're recorded in the audit log
+	audit_test_init.StartTestService(t)
 
 	obsidian.Port = util.GetFreeTcpPort(obsidian.Port)
 	if obsidian.Port == 0 {
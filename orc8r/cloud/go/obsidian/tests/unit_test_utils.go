@@ -28,6 +28,7 @@ type Test struct {
 	URL     string
 	Payload encoding.BinaryMarshaler
 	Handler echo.HandlerFunc
+	Headers map[string]string
 
 	ParamNames  []string
 	ParamValues []string
@@ -36,6 +37,9 @@ type Test struct {
 	ExpectedResult encoding.BinaryMarshaler
 
 	ExpectedError string
+
+	// ExpectedHeaders are checked against the response headers if set
+	ExpectedHeaders map[string]string
 }
 
 // RunUnitTest runs a test case using the given Echo instance. This function
@@ -52,6 +56,9 @@ func RunUnitTest(t *testing.T, e *echo.Echo, test Test) {
 	} else {
 		req = httptest.NewRequest(test.Method, test.URL, bytes.NewReader([]byte{}))
 	}
+	for header, value := range test.Headers {
+		req.Header.Set(header, value)
+	}
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -63,6 +70,9 @@ func RunUnitTest(t *testing.T, e *echo.Echo, test Test) {
 		c.Error(err)
 	}
 	assert.Equal(t, test.ExpectedStatus, rec.Code)
+	for header, value := range test.ExpectedHeaders {
+		assert.Equal(t, value, rec.Header().Get(header))
+	}
 
 	if test.ExpectedError != "" {
 		if httpErr, ok := err.(*echo.HTTPError); ok {
@@ -19,6 +19,7 @@ const (
 
 	UpgradeTierEntityType           = "upgrade_tier"
 	UpgradeReleaseChannelEntityType = "upgrade_release_channel"
+	UpgradeRolloutEntityType        = "upgrade_rollout"
 
 	DnsdNetworkType = "dnsd_network"
 )
@@ -10,6 +10,7 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -51,6 +52,22 @@ func (_m *OrchestratorPlugin) GetMetricsProfiles(metricsConfig *config.ConfigMap
 	return r0
 }
 
+// GetMigrations provides a mock function with given fields:
+func (_m *OrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	ret := _m.Called()
+
+	var r0 []sqorc.Migration
+	if rf, ok := ret.Get(0).(func() []sqorc.Migration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqorc.Migration)
+		}
+	}
+
+	return r0
+}
+
 // GetName provides a mock function with given fields:
 func (_m *OrchestratorPlugin) GetName() string {
 	ret := _m.Called()
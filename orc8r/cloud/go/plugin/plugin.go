@@ -24,6 +24,7 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 )
@@ -68,6 +69,11 @@ type OrchestratorPlugin interface {
 	// These stream providers are the primary mechanism by which gateways
 	// receive data from the orchestrator (e.g. configuration).
 	GetStreamerProviders() []providers.StreamProvider
+
+	// GetMigrations returns the SQL schema migrations of the plugin's
+	// services. These migrations are applied in version order by the
+	// migration runner, which tracks the applied versions.
+	GetMigrations() []sqorc.Migration
 }
 
 // LoadAllPluginsFatalOnError loads and registers all orchestrator plugins
@@ -177,6 +183,9 @@ func registerPlugin(orc8rPlugin OrchestratorPlugin, metricsConfig *config.Config
 	if err := providers.RegisterStreamProviders(orc8rPlugin.GetStreamerProviders()...); err != nil {
 		return err
 	}
+	if err := sqorc.RegisterMigrations(orc8rPlugin.GetMigrations()...); err != nil {
+		return err
+	}
 	configurator.RegisterMconfigBuilders(orc8rPlugin.GetMconfigBuilders()...)
 
 	return nil
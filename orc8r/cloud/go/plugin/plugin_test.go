@@ -20,6 +20,7 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -48,6 +49,7 @@ func TestLoadAllPlugins(t *testing.T) {
 	mockPlugin.On("GetMetricsProfiles", mock.Anything).Times(1).Return([]metricsd.MetricsProfile{})
 	mockPlugin.On("GetObsidianHandlers", mock.Anything).Return([]obsidian.Handler{})
 	mockPlugin.On("GetStreamerProviders").Return([]providers.StreamProvider{})
+	mockPlugin.On("GetMigrations").Return([]sqorc.Migration{})
 	err := plugin.LoadAllPlugins(mockLoader{ret: mockPlugin})
 	assert.NoError(t, err)
 	mockPlugin.AssertNumberOfCalls(t, "GetServices", 1)
@@ -56,6 +58,7 @@ func TestLoadAllPlugins(t *testing.T) {
 	mockPlugin.AssertNumberOfCalls(t, "GetObsidianHandlers", 1)
 	mockPlugin.AssertNumberOfCalls(t, "GetStreamerProviders", 1)
 	mockPlugin.AssertNumberOfCalls(t, "GetMconfigBuilders", 1)
+	mockPlugin.AssertNumberOfCalls(t, "GetMigrations", 1)
 	mockPlugin.AssertExpectations(t)
 
 	// Error in the middle of registration - duplicate metrics profile
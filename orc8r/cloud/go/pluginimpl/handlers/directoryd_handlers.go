@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/directoryd"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+// GetGatewayIMSIsHandler returns the IMSIs currently attached to the gateway
+func GetGatewayIMSIsHandler(c echo.Context) error {
+	networkID, gatewayID, nerr := obsidian.GetNetworkAndGatewayIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	physicalID, err := configurator.GetPhysicalIDOfEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	// The physical ID is empty if the gateway doesn't exist
+	if physicalID == "" {
+		return obsidian.HttpError(merrors.ErrNotFound, http.StatusNotFound)
+	}
+
+	imsis, err := directoryd.GetIMSIsByHardwareId(physicalID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if imsis == nil {
+		imsis = []string{}
+	}
+	return c.JSON(http.StatusOK, imsis)
+}
+
+// GetIMSILocationHistoryHandler returns the attachments of the IMSI to the
+// gateways of the network, most recent first. Attachments to gateways of
+// other networks or to deleted gateways are omitted.
+func GetIMSILocationHistoryHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	imsi := c.Param("imsi")
+	if imsi == "" {
+		return obsidian.HttpError(errors.New("missing IMSI"), http.StatusBadRequest)
+	}
+
+	history, err := directoryd.GetIMSILocationHistory(imsi)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if len(history) == 0 {
+		return c.JSON(http.StatusOK, []*models.ImsiLocationHistoryEntry{})
+	}
+
+	gatewayEnts, _, err := configurator.LoadEntities(
+		networkID, swag.String(orc8r.MagmadGatewayType), nil, nil, nil,
+		configurator.EntityLoadCriteria{},
+	)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	gatewayIDsByHwID := make(map[string]string, len(gatewayEnts))
+	for _, ent := range gatewayEnts {
+		gatewayIDsByHwID[ent.PhysicalID] = ent.Key
+	}
+
+	ret := make([]*models.ImsiLocationHistoryEntry, 0, len(history))
+	for _, entry := range history {
+		gatewayID, ok := gatewayIDsByHwID[entry.Location]
+		if !ok {
+			continue
+		}
+		attachedAt := strfmt.DateTime(time.Unix(0, entry.TimeMs*int64(time.Millisecond)))
+		ret = append(ret, &models.ImsiLocationHistoryEntry{
+			GatewayID:  models1.GatewayID(gatewayID),
+			HardwareID: swag.String(entry.Location),
+			Time:       &attachedAt,
+		})
+	}
+	return c.JSON(http.StatusOK, ret)
+}
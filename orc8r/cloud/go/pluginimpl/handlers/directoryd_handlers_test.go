@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/identity"
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/handlers"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/protos"
+	unaryTestUtils "magma/orc8r/cloud/go/service/middleware/unary/test_utils"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/device"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+	"magma/orc8r/cloud/go/services/directoryd"
+	directorydTestInit "magma/orc8r/cloud/go/services/directoryd/test_init"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGetGatewayIMSIsAndIMSILocationHistory(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+
+	// The gateway certificates are issued at the frozen time
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+
+	test_init.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	directorydTestInit.StartTestService(t)
+
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n1"}))
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n2"}))
+	for _, gw := range []struct{ networkID, gatewayID, hwID string }{
+		{"n1", "g1", "hw1"},
+		{"n1", "g2", "hw2"},
+		{"n2", "g3", "hw3"},
+	} {
+		_, err := configurator.CreateEntity(gw.networkID, configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: gw.gatewayID, PhysicalID: gw.hwID})
+		assert.NoError(t, err)
+		err = device.RegisterDevice(gw.networkID, orc8r.AccessGatewayRecordType, gw.hwID, &models.GatewayDevice{HardwareID: gw.hwID, Key: &models.ChallengeKey{KeyType: "ECHO"}})
+		assert.NoError(t, err)
+	}
+	certSNs := unaryTestUtils.StartMockGwAccessControl(t, []string{"hw1", "hw2", "hw3"})
+
+	client, err := directoryd.GetDirectorydClient()
+	assert.NoError(t, err)
+	attach := func(certSN string, imsi string, at int64) {
+		clock.SetAndFreezeClock(t, time.Unix(at, 0))
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(identity.CLIENT_CERT_SN_KEY, certSN))
+		_, err := client.UpdateLocation(ctx, &protos.UpdateDirectoryLocationRequest{
+			Table:  protos.TableID_IMSI_TO_HWID,
+			Id:     imsi,
+			Record: &protos.LocationRecord{},
+		})
+		assert.NoError(t, err)
+	}
+	attach(certSNs[0], "imsi2", 1000001)
+	attach(certSNs[0], "imsi1", 1000001)
+	attach(certSNs[1], "imsi1", 1000002)
+	attach(certSNs[2], "imsi1", 1000003)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	getIMSIs := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/gateways/:gateway_id/imsis", obsidian.GET).HandlerFunc
+	getLocationHistory := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/imsis/:imsi/location_history", obsidian.GET).HandlerFunc
+
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/gateways/g1/imsis",
+		Handler:        getIMSIs,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]string{"imsi2"}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/n1/gateways/g2/imsis"
+	tc.ParamValues = []string{"n1", "g2"}
+	tc.ExpectedResult = tests.JSONMarshaler([]string{})
+	tests.RunUnitTest(t, e, tc)
+
+	// gateways are network-scoped
+	tc.URL = "/magma/v1/networks/n1/gateways/g3/imsis"
+	tc.ParamValues = []string{"n1", "g3"}
+	tc.ExpectedStatus = 404
+	tc.ExpectedResult = nil
+	tc.ExpectedError = "Not found"
+	tests.RunUnitTest(t, e, tc)
+
+	// attachments to other networks' gateways are omitted
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/imsis/imsi1/location_history",
+		Handler:        getLocationHistory,
+		ParamNames:     []string{"network_id", "imsi"},
+		ParamValues:    []string{"n1", "imsi1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.ImsiLocationHistoryEntry{
+			newLocationHistoryEntry("g2", "hw2", 1000002),
+			newLocationHistoryEntry("g1", "hw1", 1000001),
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/n2/imsis/imsi1/location_history"
+	tc.ParamValues = []string{"n2", "imsi1"}
+	tc.ExpectedResult = tests.JSONMarshaler([]*models.ImsiLocationHistoryEntry{
+		newLocationHistoryEntry("g3", "hw3", 1000003),
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/n1/imsis/imsi3/location_history"
+	tc.ParamValues = []string{"n1", "imsi3"}
+	tc.ExpectedResult = tests.JSONMarshaler([]*models.ImsiLocationHistoryEntry{})
+	tests.RunUnitTest(t, e, tc)
+}
+
+func newLocationHistoryEntry(gatewayID string, hwID string, attachedAt int64) *models.ImsiLocationHistoryEntry {
+	attachedAtDT := strfmt.DateTime(time.Unix(attachedAt, 0))
+	return &models.ImsiLocationHistoryEntry{
+		GatewayID:  models1.GatewayID(gatewayID),
+		HardwareID: swag.String(hwID),
+		Time:       &attachedAtDT,
+	}
+}
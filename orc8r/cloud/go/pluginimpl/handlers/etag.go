@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/labstack/echo"
+)
+
+// GetEntitiesETag computes an entity tag for an API resource which is backed
+// by the given network entities. The tag changes whenever any of the
+// entities is updated.
+func GetEntitiesETag(entities ...configurator.NetworkEntity) string {
+	sorted := make([]configurator.NetworkEntity, len(entities))
+	copy(sorted, entities)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetTypeAndKey().String() < sorted[j].GetTypeAndKey().String()
+	})
+
+	hash := sha256.New()
+	for _, ent := range sorted {
+		_, _ = fmt.Fprintf(hash, "%s\x00%s\x00%d\n", ent.Type, ent.Key, ent.Version)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// ApplyIfMatch checks the request's If-Match header against the ETag of the
+// entities backing the resource being written. If the request has an
+// If-Match header, the returned writes are conditioned on the versions of
+// those entities so a concurrent modification between the load and the write
+// fails the whole transaction.
+// Errors from writing the returned operations should be converted with
+// obsidian.ConditionalWriteHttpError.
+func ApplyIfMatch(
+	c echo.Context,
+	loadedEntities []configurator.NetworkEntity,
+	writes []configurator.EntityWriteOperation,
+) ([]configurator.EntityWriteOperation, *echo.HTTPError) {
+	if nerr := obsidian.CheckIfMatch(c, GetEntitiesETag(loadedEntities...)); nerr != nil {
+		return nil, nerr
+	}
+	if !obsidian.HasIfMatch(c) {
+		return writes, nil
+	}
+
+	versionsByTK := map[storage.TypeAndKey]uint64{}
+	for _, ent := range loadedEntities {
+		versionsByTK[ent.GetTypeAndKey()] = ent.Version
+	}
+
+	pinnedWrites := make([]configurator.EntityWriteOperation, 0, len(writes))
+	for _, write := range writes {
+		update, isUpdate := write.(configurator.EntityUpdateCriteria)
+		if !isUpdate {
+			pinnedWrites = append(pinnedWrites, write)
+			continue
+		}
+		// Only the first write to an entity is conditioned on the loaded
+		// version since every write increments it
+		if version, found := versionsByTK[update.GetTypeAndKey()]; found {
+			update.ExpectedVersion = &version
+			delete(versionsByTK, update.GetTypeAndKey())
+		}
+		pinnedWrites = append(pinnedWrites, update)
+	}
+
+	// Entities which contribute to the ETag but aren't otherwise written to
+	// still have to be checked. These version checks don't change the
+	// entities. They go first in case one of the other writes deletes the
+	// entity.
+	ret := make([]configurator.EntityWriteOperation, 0, len(versionsByTK)+len(pinnedWrites))
+	for _, ent := range loadedEntities {
+		version, found := versionsByTK[ent.GetTypeAndKey()]
+		if !found {
+			continue
+		}
+		ret = append(ret, configurator.EntityUpdateCriteria{
+			Type:            ent.Type,
+			Key:             ent.Key,
+			ExpectedVersion: &version,
+		})
+		delete(versionsByTK, ent.GetTypeAndKey())
+	}
+	return append(ret, pinnedWrites...), nil
+}
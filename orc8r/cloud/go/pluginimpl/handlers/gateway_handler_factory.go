@@ -277,26 +277,36 @@ func GetDeleteGatewayHandler(path string, gatewayType string) obsidian.Handler {
 				return nerr
 			}
 
-			existingEnt, err := configurator.LoadEntity(
-				nid, orc8r.MagmadGatewayType, gid,
+			existingEnts, _, err := configurator.LoadEntities(
+				nid, nil, nil, nil,
+				[]storage.TypeAndKey{
+					{Type: orc8r.MagmadGatewayType, Key: gid},
+					{Type: gatewayType, Key: gid},
+				},
 				configurator.EntityLoadCriteria{LoadMetadata: true},
 			)
-			switch {
-			case err == merrors.ErrNotFound:
-				return echo.ErrNotFound
-			case err != nil:
+			if err != nil {
 				return obsidian.HttpError(errors.Wrap(err, "failed to load gateway"), http.StatusInternalServerError)
 			}
+			existingEnt, found := existingEnts.ToEntitiesByID()[storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: gid}]
+			if !found {
+				return echo.ErrNotFound
+			}
 
-			err = configurator.DeleteEntities(
-				nid,
-				[]storage.TypeAndKey{
-					{Type: orc8r.MagmadGatewayType, Key: gid},
-					{Type: gatewayType, Key: gid},
+			writes, nerr := ApplyIfMatch(
+				c,
+				existingEnts,
+				[]configurator.EntityWriteOperation{
+					configurator.EntityUpdateCriteria{Type: orc8r.MagmadGatewayType, Key: gid, DeleteEntity: true},
+					configurator.EntityUpdateCriteria{Type: gatewayType, Key: gid, DeleteEntity: true},
 				},
 			)
+			if nerr != nil {
+				return nerr
+			}
+			err = configurator.WriteEntities(nid, writes...)
 			if err != nil {
-				return obsidian.HttpError(errors.Wrap(err, "failed to delete gateway"), http.StatusInternalServerError)
+				return obsidian.ConditionalWriteHttpError(err)
 			}
 
 			// Now we delete the associated device. Even though we error out
@@ -21,7 +21,6 @@ import (
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/storage"
 
-	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/pkg/errors"
 )
@@ -74,11 +73,23 @@ func ListGatewaysHandler(c echo.Context) error {
 		return nerr
 	}
 
-	ents, _, err := configurator.LoadEntities(nid, swag.String(orc8r.MagmadGatewayType), nil, nil, nil, configurator.FullEntityLoadCriteria())
+	pageSize, pageToken, nerr := obsidian.GetPaginationParams(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	var ents []configurator.NetworkEntity
+	var nextPageToken string
+	var err error
+	if pageSize == 0 {
+		ents, err = configurator.LoadAllEntitiesInNetwork(nid, orc8r.MagmadGatewayType, configurator.FullEntityLoadCriteria())
+	} else {
+		ents, nextPageToken, err = configurator.LoadAllEntitiesInNetworkPage(nid, orc8r.MagmadGatewayType, configurator.FullEntityLoadCriteria(), pageSize, pageToken)
+	}
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return obsidian.PaginatedLoadHttpError(err)
 	}
-	entsByTK := ents.ToEntitiesByID()
+	entsByTK := configurator.NetworkEntities(ents).ToEntitiesByID()
 
 	// for each magmad gateway, we have to load its corresponding device and
 	// its reported status
@@ -97,7 +108,16 @@ func ListGatewaysHandler(c echo.Context) error {
 	if err != nil {
 		return obsidian.HttpError(errors.Wrap(err, "failed to load statuses"), http.StatusInternalServerError)
 	}
-	return c.JSON(http.StatusOK, makeGateways(entsByTK, devicesByID, statusesByID))
+	gateways := makeGateways(entsByTK, devicesByID, statusesByID)
+	if pageSize == 0 {
+		return c.JSON(http.StatusOK, gateways)
+	}
+
+	ret := &models.PaginatedGateways{Gateways: make(map[string]models.MagmadGateway, len(gateways)), NextPageToken: nextPageToken}
+	for id, gateway := range gateways {
+		ret.Gateways[id] = *gateway
+	}
+	return c.JSON(http.StatusOK, ret)
 }
 
 func CreateGatewayHandler(c echo.Context) error {
@@ -186,14 +206,23 @@ func GetGatewayHandler(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	ret, nerr := LoadMagmadGatewayModel(nid, gid)
+	ret, ent, nerr := LoadMagmadGatewayModelAndEntity(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
+	obsidian.SetETag(c, GetEntitiesETag(ent))
 	return c.JSON(http.StatusOK, ret)
 }
 
 func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadGateway, *echo.HTTPError) {
+	ret, _, nerr := LoadMagmadGatewayModelAndEntity(networkID, gatewayID)
+	return ret, nerr
+}
+
+// LoadMagmadGatewayModelAndEntity loads the magmad gateway API model along
+// with the magmad gateway entity backing it. The entity can be used to
+// compute the gateway's ETag.
+func LoadMagmadGatewayModelAndEntity(networkID string, gatewayID string) (*models.MagmadGateway, configurator.NetworkEntity, *echo.HTTPError) {
 	ent, err := configurator.LoadEntity(
 		networkID, orc8r.MagmadGatewayType, gatewayID,
 		configurator.EntityLoadCriteria{
@@ -204,19 +233,19 @@ func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadG
 		},
 	)
 	if err == merrors.ErrNotFound {
-		return nil, echo.ErrNotFound
+		return nil, ent, echo.ErrNotFound
 	}
 	if err != nil {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, ent, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
 	dev, err := device.GetDevice(networkID, orc8r.AccessGatewayRecordType, ent.PhysicalID)
 	if err != nil && err != merrors.ErrNotFound {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, ent, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 	status, err := state.GetGatewayStatus(networkID, ent.PhysicalID)
 	if err != nil && err != merrors.ErrNotFound {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, ent, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
 	// If the gateway/network is malformed, we could get no corresponding
@@ -225,7 +254,7 @@ func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadG
 	if dev != nil {
 		devCasted = dev.(*models.GatewayDevice)
 	}
-	return (&models.MagmadGateway{}).FromBackendModels(ent, devCasted, status), nil
+	return (&models.MagmadGateway{}).FromBackendModels(ent, devCasted, status), ent, nil
 }
 
 func UpdateGatewayHandler(c echo.Context) error {
@@ -271,10 +300,14 @@ func UpdateMagmadGatewayFromModel(c echo.Context, nid string, gid string, model
 	if nerr != nil {
 		return nerr
 	}
+	writes, nerr = ApplyIfMatch(c, loadedEnts, writes)
+	if nerr != nil {
+		return nerr
+	}
 
 	err = configurator.WriteEntities(nid, writes...)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return obsidian.ConditionalWriteHttpError(err)
 	}
 
 	// device info is cheap to update, so just do it all the time if
@@ -335,9 +368,19 @@ func DeleteGatewayHandler(c echo.Context) error {
 		return obsidian.HttpError(errors.Wrap(err, "failed to load gateway"), http.StatusInternalServerError)
 	}
 
-	err = configurator.DeleteEntity(nid, orc8r.MagmadGatewayType, gid)
+	writes, nerr := ApplyIfMatch(
+		c,
+		configurator.NetworkEntities{existingEnt},
+		[]configurator.EntityWriteOperation{
+			configurator.EntityUpdateCriteria{Type: orc8r.MagmadGatewayType, Key: gid, DeleteEntity: true},
+		},
+	)
+	if nerr != nil {
+		return nerr
+	}
+	err = configurator.WriteEntities(nid, writes...)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return obsidian.ConditionalWriteHttpError(err)
 	}
 
 	if existingEnt.PhysicalID != "" {
@@ -373,6 +416,47 @@ func GetStateHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, st)
 }
 
+// GetStateHistoryHandler returns the previously reported statuses of a
+// gateway, newest first. The optional start and end query parameters bound
+// the checkin time of the returned statuses, in unix milliseconds, and limit
+// caps the number of statuses returned.
+func GetStateHistoryHandler(c echo.Context) error {
+	networkID, gatewayID, nerr := obsidian.GetNetworkAndGatewayIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	startMs, nerr := obsidian.GetUintQueryParam(c, queryParamStart, 64)
+	if nerr != nil {
+		return nerr
+	}
+	endMs, nerr := obsidian.GetUintQueryParam(c, queryParamEnd, 64)
+	if nerr != nil {
+		return nerr
+	}
+	limit, nerr := obsidian.GetUintQueryParam(c, queryParamLimit, 32)
+	if nerr != nil {
+		return nerr
+	}
+	if endMs != 0 && endMs < startMs {
+		return obsidian.HttpError(errors.New("end must not be before start"), http.StatusBadRequest)
+	}
+
+	physicalID, err := configurator.GetPhysicalIDOfEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	// The physical ID is empty if the gateway doesn't exist
+	if physicalID == "" {
+		return obsidian.HttpError(merrors.ErrNotFound, http.StatusNotFound)
+	}
+
+	statuses, err := state.GetGatewayStatusHistory(networkID, physicalID, startMs, endMs, uint32(limit))
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, statuses)
+}
+
 func makeGateways(
 	entsByTK map[storage.TypeAndKey]configurator.NetworkEntity,
 	devicesByID map[string]interface{},
@@ -9,7 +9,10 @@
 package handlers_test
 
 import (
+	"crypto/rand"
 	"crypto/x509"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -22,10 +25,13 @@ import (
 	"magma/orc8r/cloud/go/pluginimpl/handlers"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/security/key"
+	"magma/orc8r/cloud/go/security/tpm"
+	tpm_test_utils "magma/orc8r/cloud/go/security/tpm/test_utils"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/services/device"
 	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+	"magma/orc8r/cloud/go/services/state/history"
 	stateTestInit "magma/orc8r/cloud/go/services/state/test_init"
 	"magma/orc8r/cloud/go/services/state/test_utils"
 	"magma/orc8r/cloud/go/storage"
@@ -97,6 +103,32 @@ func TestListGateways(t *testing.T) {
 	}
 	tc.ExpectedResult = tests.JSONMarshaler(expectedResult)
 	tests.RunUnitTest(t, e, tc)
+
+	// paginated
+	_, expectedPageToken, err := configurator.ListEntityKeysPage("n1", orc8r.MagmadGatewayType, 1, "")
+	assert.NoError(t, err)
+	tc.URL = testURLRoot + "?page_size=1"
+	tc.ExpectedResult = &models.PaginatedGateways{
+		Gateways:      map[string]models.MagmadGateway{"g1": expectedResult["g1"]},
+		NextPageToken: expectedPageToken,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?page_size=1&page_token=" + expectedPageToken
+	tc.ExpectedResult = &models.PaginatedGateways{
+		Gateways: map[string]models.MagmadGateway{"g2": expectedResult["g2"]},
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// bad pagination params
+	tc.URL = testURLRoot + "?page_size=0"
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "page_size must be an integer between 1 and 1000"
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?page_token=foo"
+	tc.ExpectedError = "invalid page token"
+	tests.RunUnitTest(t, e, tc)
 }
 
 func TestCreateGateway(t *testing.T) {
@@ -307,6 +339,75 @@ func TestCreateGateway(t *testing.T) {
 		ExpectedError:  "device foo-bar-baz-123-42 is already mapped to gateway g1",
 	}
 	tests.RunUnitTest(t, e, tc)
+
+	// TPM attestation key and endorsement key certificate
+	tpmKey := newTestTPMChallengeKey(t, tpm_test_utils.AttestationKeyAttrs)
+	payload = &models.MagmadGateway{
+		Device: &models.GatewayDevice{
+			HardwareID: "tpm-gateway",
+			Key:        &models.ChallengeKey{KeyType: "HARDWARE_TPM2_SHA256", Key: &tpmKey},
+		},
+		ID:          "g5",
+		Name:        "foobar",
+		Description: "foo bar",
+		Magmad: &models.MagmadGatewayConfigs{
+			CheckinInterval:         15,
+			CheckinTimeout:          10,
+			AutoupgradePollInterval: 300,
+			AutoupgradeEnabled:      swag.Bool(true),
+		},
+		Tier: "t1",
+	}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot,
+		Handler:        createGateway,
+		Payload:        payload,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	actualDevice, err = device.GetDevice("n1", orc8r.AccessGatewayRecordType, "tpm-gateway")
+	assert.NoError(t, err)
+	assert.Equal(t, payload.Device, actualDevice)
+
+	// TPM keys which aren't attestation keys, and garbage
+	decryptKey := newTestTPMChallengeKey(t, tpm_test_utils.AttestationKeyAttrs|1<<17)
+	payload.ID = "g6"
+	payload.Device = &models.GatewayDevice{
+		HardwareID: "tpm-gateway-2",
+		Key:        &models.ChallengeKey{KeyType: "HARDWARE_TPM2_SHA256", Key: &decryptKey},
+	}
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "Failed to parse key: attestation key isn't a restricted signing key generated by the TPM: attributes 0x00070072"
+	tests.RunUnitTest(t, e, tc)
+
+	payload.Device.Key = &models.ChallengeKey{KeyType: "HARDWARE_TPM2_SHA256"}
+	tc.ExpectedError = "No key supplied"
+	tests.RunUnitTest(t, e, tc)
+}
+
+// newTestTPMChallengeKey returns a TPM challenge key with a P256 attestation
+// key with the given attributes and a self-signed RSA EK certificate
+func newTestTPMChallengeKey(t *testing.T, akAttrs uint32) strfmt.Base64 {
+	ak, err := key.GenerateKey("P256", 0)
+	assert.NoError(t, err)
+	akPublic, err := tpm_test_utils.MarshalAttestationKey(key.PublicKey(ak), akAttrs)
+	assert.NoError(t, err)
+	ek, err := key.GenerateKey("", 2048)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	ekCert, err := x509.CreateCertificate(rand.Reader, template, template, key.PublicKey(ek), ek)
+	assert.NoError(t, err)
+	challengeKey, err := tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic, EKCert: ekCert})
+	assert.NoError(t, err)
+	return strfmt.Base64(challengeKey)
 }
 
 func TestGetGateway(t *testing.T) {
@@ -387,15 +488,18 @@ func TestGetGateway(t *testing.T) {
 	}
 	expected.Status.CheckinTime = uint64(time.Unix(1000000, 0).UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond)))
 	expected.Status.CertExpirationTime = time.Unix(1000000, 0).Add(time.Hour * 4).Unix()
+	g1Ent, err := configurator.LoadEntity("n1", orc8r.MagmadGatewayType, "g1", configurator.EntityLoadCriteria{})
+	assert.NoError(t, err)
 
 	tc := tests.Test{
-		Method:         "GET",
-		URL:            testURLRoot + "/g1",
-		Handler:        getGateway,
-		ParamNames:     []string{"network_id", "gateway_id"},
-		ParamValues:    []string{"n1", "g1"},
-		ExpectedStatus: 200,
-		ExpectedResult: expected,
+		Method:          "GET",
+		URL:             testURLRoot + "/g1",
+		Handler:         getGateway,
+		ParamNames:      []string{"network_id", "gateway_id"},
+		ParamValues:     []string{"n1", "g1"},
+		ExpectedStatus:  200,
+		ExpectedResult:  expected,
+		ExpectedHeaders: map[string]string{"ETag": fmt.Sprintf("%q", handlers.GetEntitiesETag(g1Ent))},
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -545,6 +649,46 @@ func TestUpdateGateway(t *testing.T) {
 	assert.Equal(t, expectedEnts, actualEnts)
 	assert.Equal(t, payload.Device, actualDevice)
 
+	// stale If-Match
+	staleETag := handlers.GetEntitiesETag(configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g1", Version: 0})
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot + "/g1",
+		Handler:        updateGateway,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": fmt.Sprintf("%q", staleETag)},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "resource has been modified since the provided ETag was issued",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// current If-Match
+	currentETag := handlers.GetEntitiesETag(expectedEnts[0])
+	payload.Name = "foobaz"
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot + "/g1",
+		Handler:        updateGateway,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": fmt.Sprintf("%q", currentETag)},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	actualEnt, err := configurator.LoadEntity("n1", orc8r.MagmadGatewayType, "g1", configurator.EntityLoadCriteria{LoadMetadata: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "foobaz", actualEnt.Name)
+	assert.Equal(t, uint64(2), actualEnt.Version)
+
+	// the tag from before the last update is now stale
+	tc.ExpectedStatus = 412
+	tc.ExpectedError = "resource has been modified since the provided ETag was issued"
+	tests.RunUnitTest(t, e, tc)
+
 	// 404
 	tc = tests.Test{
 		Method:         "PUT",
@@ -597,7 +741,20 @@ func TestDeleteGateway(t *testing.T) {
 	obsidianHandlers := handlers.GetObsidianHandlers()
 	deleteGateway := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/gateways/:gateway_id", obsidian.DELETE).HandlerFunc
 
+	// stale If-Match
 	tc := tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot + "/g1",
+		Handler:        deleteGateway,
+		Headers:        map[string]string{"If-Match": `"deadbeef"`},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "resource has been modified since the provided ETag was issued",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
 		Method:         "DELETE",
 		URL:            testURLRoot + "/g1",
 		Handler:        deleteGateway,
@@ -778,6 +935,123 @@ func TestGetPartialReadHandlers(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 }
 
+func TestGetGatewayStateHistory(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+
+	test_init.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	stateTestInit.StartTestServiceWithHistory(t, map[string]history.RetentionPolicy{
+		orc8r.GatewayStateType: {MaxEntries: 10},
+	})
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{Type: orc8r.MagmadGatewayType, Key: "g1", PhysicalID: "hw1"},
+			{Type: orc8r.MagmadGatewayType, Key: "g2", PhysicalID: "hw2"},
+		},
+	)
+	assert.NoError(t, err)
+	err = device.RegisterDevice("n1", orc8r.AccessGatewayRecordType, "hw1", &models.GatewayDevice{HardwareID: "hw1", Key: &models.ChallengeKey{KeyType: "ECHO"}})
+	assert.NoError(t, err)
+	ctx := test_utils.GetContextWithCertificate(t, "hw1")
+
+	var expectedStatuses []*models.GatewayStatus
+	for i := 0; i < 3; i++ {
+		reportTime := time.Unix(int64(1000000+i), 0)
+		clock.SetAndFreezeClock(t, reportTime)
+		status := models.NewDefaultGatewayStatus("hw1")
+		status.Meta = map[string]string{"report": fmt.Sprintf("%d", i)}
+		test_utils.ReportGatewayStatus(t, ctx, status)
+
+		status.CheckinTime = uint64(reportTime.UnixNano() / int64(time.Millisecond))
+		status.CertExpirationTime = time.Unix(1000000, 0).Add(time.Hour * 4).Unix()
+		expectedStatuses = append([]*models.GatewayStatus{status}, expectedStatuses...)
+	}
+
+	e := echo.New()
+	testURLRoot := "/magma/v1/networks/n1/gateways"
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	getStateHistory := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/gateways/:gateway_id/status/history", obsidian.GET).HandlerFunc
+
+	// happy path, newest first
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g1/status/history",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(expectedStatuses),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// time range and limit
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g1/status/history?start=1000000000&end=1000001000&limit=1",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(expectedStatuses[1:2]),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// no history
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g2/status/history",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g2"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.GatewayStatus{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// bad time range
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g1/status/history?start=2000&end=1000",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "end must not be before start",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// malformed limit
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g1/status/history?limit=abc",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "invalid value for limit: abc",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// 404 gateway
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/g3/status/history",
+		Handler:        getStateHistory,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g3"},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not found",
+	}
+	tests.RunUnitTest(t, e, tc)
+}
+
 func TestGetGatewayTierHandler(t *testing.T) {
 	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
 
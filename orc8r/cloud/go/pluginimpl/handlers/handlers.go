@@ -33,16 +33,24 @@ const (
 	ManageNetworkDNSPath               = ManageNetworkPath + obsidian.UrlSep + "dns"
 	ManageNetworkDNSRecordsPath        = ManageNetworkDNSPath + obsidian.UrlSep + "records"
 	ManageNetworkDNSRecordByDomainPath = ManageNetworkDNSRecordsPath + obsidian.UrlSep + ":domain"
-
-	Gateways                     = "gateways"
-	ListGatewaysPath             = ManageNetworkPath + obsidian.UrlSep + Gateways
-	ManageGatewayPath            = ListGatewaysPath + obsidian.UrlSep + ":gateway_id"
-	ManageGatewayNamePath        = ManageGatewayPath + obsidian.UrlSep + "name"
-	ManageGatewayDescriptionPath = ManageGatewayPath + obsidian.UrlSep + "description"
-	ManageGatewayConfigPath      = ManageGatewayPath + obsidian.UrlSep + "magmad"
-	ManageGatewayDevicePath      = ManageGatewayPath + obsidian.UrlSep + "device"
-	ManageGatewayStatePath       = ManageGatewayPath + obsidian.UrlSep + "status"
-	ManageGatewayTierPath        = ManageGatewayPath + obsidian.UrlSep + "tier"
+	ExportNetworkPath                  = ManageNetworkPath + obsidian.UrlSep + "export"
+	ImportNetworkPath                  = ManageNetworkPath + obsidian.UrlSep + "import"
+
+	Gateways                      = "gateways"
+	ListGatewaysPath              = ManageNetworkPath + obsidian.UrlSep + Gateways
+	ManageGatewayPath             = ListGatewaysPath + obsidian.UrlSep + ":gateway_id"
+	ManageGatewayNamePath         = ManageGatewayPath + obsidian.UrlSep + "name"
+	ManageGatewayDescriptionPath  = ManageGatewayPath + obsidian.UrlSep + "description"
+	ManageGatewayConfigPath       = ManageGatewayPath + obsidian.UrlSep + "magmad"
+	ManageGatewayDevicePath       = ManageGatewayPath + obsidian.UrlSep + "device"
+	ManageGatewayStatePath        = ManageGatewayPath + obsidian.UrlSep + "status"
+	ManageGatewayStateHistoryPath = ManageGatewayStatePath + obsidian.UrlSep + "history"
+	ManageGatewayTierPath         = ManageGatewayPath + obsidian.UrlSep + "tier"
+	ManageGatewayIMSIsPath        = ManageGatewayPath + obsidian.UrlSep + "imsis"
+
+	IMSIs                   = "imsis"
+	ManageIMSIPath          = ManageNetworkPath + obsidian.UrlSep + IMSIs + obsidian.UrlSep + ":imsi"
+	IMSILocationHistoryPath = ManageIMSIPath + obsidian.UrlSep + "location_history"
 
 	Channels               = "channels"
 	ListChannelsPath       = obsidian.V1Root + Channels
@@ -56,8 +64,18 @@ const (
 	ManageTierImagePath    = ManageTierImagesPath + obsidian.UrlSep + ":image_name"
 	ManageTierGatewaysPath = ManageTiersPath + obsidian.UrlSep + "gateways"
 	ManageTierGatewayPath  = ManageTierGatewaysPath + obsidian.UrlSep + ":gateway_id"
-
-	LogQueryPath = ManageNetworkPath + obsidian.UrlSep + "logs"
+	Rollouts               = "rollouts"
+	ListRolloutsPath       = ManageNetworkPath + obsidian.UrlSep + Rollouts
+	ManageRolloutPath      = ListRolloutsPath + obsidian.UrlSep + ":rollout_id"
+	PauseRolloutPath       = ManageRolloutPath + obsidian.UrlSep + "pause"
+	ResumeRolloutPath      = ManageRolloutPath + obsidian.UrlSep + "resume"
+	AbortRolloutPath       = ManageRolloutPath + obsidian.UrlSep + "abort"
+
+	LogQueryPath       = ManageNetworkPath + obsidian.UrlSep + "logs"
+	LogAggregationPath = LogQueryPath + obsidian.UrlSep + "aggregations"
+
+	overwriteParam       = "overwrite"
+	dropPhysicalIDsParam = "drop_physical_ids"
 )
 
 // GetObsidianHandlers returns all plugin-level obsidian handlers for orc8r
@@ -69,6 +87,8 @@ func GetObsidianHandlers() []obsidian.Handler {
 		{Path: ManageNetworkPath, Methods: obsidian.GET, HandlerFunc: getNetwork},
 		{Path: ManageNetworkPath, Methods: obsidian.PUT, HandlerFunc: updateNetwork},
 		{Path: ManageNetworkPath, Methods: obsidian.DELETE, HandlerFunc: deleteNetwork},
+		{Path: ExportNetworkPath, Methods: obsidian.GET, HandlerFunc: exportNetwork},
+		{Path: ImportNetworkPath, Methods: obsidian.POST, HandlerFunc: importNetwork},
 
 		{Path: ManageNetworkDNSRecordByDomainPath, Methods: obsidian.POST, HandlerFunc: CreateDNSRecord},
 		{Path: ManageNetworkDNSRecordByDomainPath, Methods: obsidian.GET, HandlerFunc: ReadDNSRecord},
@@ -82,6 +102,9 @@ func GetObsidianHandlers() []obsidian.Handler {
 		{Path: ManageGatewayPath, Methods: obsidian.PUT, HandlerFunc: UpdateGatewayHandler},
 		{Path: ManageGatewayPath, Methods: obsidian.DELETE, HandlerFunc: DeleteGatewayHandler},
 		{Path: ManageGatewayStatePath, Methods: obsidian.GET, HandlerFunc: GetStateHandler},
+		{Path: ManageGatewayStateHistoryPath, Methods: obsidian.GET, HandlerFunc: GetStateHistoryHandler},
+		{Path: ManageGatewayIMSIsPath, Methods: obsidian.GET, HandlerFunc: GetGatewayIMSIsHandler},
+		{Path: IMSILocationHistoryPath, Methods: obsidian.GET, HandlerFunc: GetIMSILocationHistoryHandler},
 
 		// Upgrades
 		{Path: ListChannelsPath, Methods: obsidian.GET, HandlerFunc: listChannelsHandler},
@@ -98,6 +121,13 @@ func GetObsidianHandlers() []obsidian.Handler {
 		{Path: ManageTierImagePath, Methods: obsidian.DELETE, HandlerFunc: deleteImage},
 		{Path: ManageTierGatewaysPath, Methods: obsidian.POST, HandlerFunc: createTierGateway},
 		{Path: ManageTierGatewayPath, Methods: obsidian.DELETE, HandlerFunc: deleteTierGateway},
+		{Path: ListRolloutsPath, Methods: obsidian.GET, HandlerFunc: listRolloutsHandler},
+		{Path: ListRolloutsPath, Methods: obsidian.POST, HandlerFunc: createRolloutHandler},
+		{Path: ManageRolloutPath, Methods: obsidian.GET, HandlerFunc: readRolloutHandler},
+		{Path: ManageRolloutPath, Methods: obsidian.DELETE, HandlerFunc: deleteRolloutHandler},
+		{Path: PauseRolloutPath, Methods: obsidian.POST, HandlerFunc: pauseRolloutHandler},
+		{Path: ResumeRolloutPath, Methods: obsidian.POST, HandlerFunc: resumeRolloutHandler},
+		{Path: AbortRolloutPath, Methods: obsidian.POST, HandlerFunc: abortRolloutHandler},
 	}
 	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkNamePath, new(models.NetworkName), "")...)
 	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkTypePath, new(models.NetworkType), "")...)
@@ -121,6 +151,7 @@ func GetObsidianHandlers() []obsidian.Handler {
 	elasticConfig, err := config.GetServiceConfig(orc8r.ModuleName, "elastic")
 	if err != nil {
 		ret = append(ret, obsidian.Handler{Path: LogQueryPath, Methods: obsidian.GET, HandlerFunc: getInitErrorHandler(err)})
+		ret = append(ret, obsidian.Handler{Path: LogAggregationPath, Methods: obsidian.GET, HandlerFunc: getInitErrorHandler(err)})
 	} else {
 		elasticHost := elasticConfig.GetRequiredStringParam("elasticHost")
 		elasticPort := elasticConfig.GetRequiredIntParam("elasticPort")
@@ -128,8 +159,10 @@ func GetObsidianHandlers() []obsidian.Handler {
 		client, err := elastic.NewSimpleClient(elastic.SetURL(fmt.Sprintf("http://%s:%d", elasticHost, elasticPort)))
 		if err != nil {
 			ret = append(ret, obsidian.Handler{Path: LogQueryPath, Methods: obsidian.GET, HandlerFunc: getInitErrorHandler(err)})
+			ret = append(ret, obsidian.Handler{Path: LogAggregationPath, Methods: obsidian.GET, HandlerFunc: getInitErrorHandler(err)})
 		} else {
 			ret = append(ret, obsidian.Handler{Path: LogQueryPath, Methods: obsidian.GET, HandlerFunc: GetQueryLogHandler(client)})
+			ret = append(ret, obsidian.Handler{Path: LogAggregationPath, Methods: obsidian.GET, HandlerFunc: GetLogAggregationHandler(client)})
 		}
 	}
 
@@ -1,16 +1,18 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/pluginimpl/models"
 
-	"github.com/golang/glog"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/olivere/elastic/v7"
 )
@@ -19,8 +21,21 @@ const (
 	NetworkLogLabel = "network_id"
 
 	defaultSearchSize = 10
-	defaultLogField   = "message"
-	sortTag           = "@timestamp"
+	// Elasticsearch's default index.max_result_window
+	maxSearchSize   = 10000
+	defaultLogField = "message"
+	sortTag         = "@timestamp"
+	// tieBreakerSortTag orders hits with the same timestamp so that
+	// search_after pages don't skip or repeat hits. Every document has an
+	// ID, whether it was indexed by fluentd or by the logger's Elasticsearch
+	// exporter.
+	tieBreakerSortTag = "_id"
+	timeFormat        = "strict_date_optional_time"
+	// maxHistogramBuckets is Elasticsearch's default search.max_buckets
+	maxHistogramBuckets = 10000
+
+	groupByAggregationName   = "group_by"
+	histogramAggregationName = "histogram"
 
 	urlListDelimiter = ","
 
@@ -30,6 +45,10 @@ const (
 	queryParamSimpleQuery = "simple_query"
 	queryParamStart       = "start"
 	queryParamEnd         = "end"
+	queryParamLimit       = "limit"
+	queryParamSearchAfter = "search_after"
+	queryParamGroupBy     = "group_by"
+	queryParamInterval    = "interval"
 )
 
 func GetQueryLogHandler(client *elastic.Client) func(c echo.Context) error {
@@ -50,14 +69,18 @@ func queryLogs(c echo.Context, client *elastic.Client) error {
 	}
 	query := secureElasticQuery(networkID, params)
 
-	result, err := client.Search().
-		Index("").
+	// no index, search across all indices
+	search := client.Search().
 		Size(params.Size).
 		Sort(sortTag, false).
-		Query(query).
-		Do(context.Background())
+		Sort(tieBreakerSortTag, true).
+		Query(query)
+	if len(params.SearchAfter) != 0 {
+		search = search.SearchAfter(params.SearchAfter...)
+	}
+	result, err := search.Do(c.Request().Context())
 	if err != nil {
-		glog.Fatalf("Error getting response: %s", err)
+		return obsidian.HttpError(fmt.Errorf("Elastic search error: %s", err), http.StatusInternalServerError)
 	}
 	if result.Error != nil {
 		return obsidian.HttpError(fmt.Errorf("Elastic Error Type: %s, Reason: %s", result.Error.Type, result.Error.Reason))
@@ -65,6 +88,67 @@ func queryLogs(c echo.Context, client *elastic.Client) error {
 	return c.JSON(http.StatusOK, result.Hits.Hits)
 }
 
+func GetLogAggregationHandler(client *elastic.Client) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		return aggregateLogs(c, client)
+	}
+}
+
+// aggregateLogs counts the network's logs matching the query, grouped by
+// the values of a field and/or bucketed over time
+func aggregateLogs(c echo.Context, client *elastic.Client) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	params, err := getAggregationParameters(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	query := secureElasticQuery(networkID, params.logQueryParams)
+
+	// no index, search across all indices
+	search := client.Search().
+		Size(0).
+		TrackTotalHits(true).
+		Query(query)
+	histogram := params.toElasticHistogram()
+	if len(params.GroupBy) != 0 {
+		terms := elastic.NewTermsAggregation().Field(params.GroupBy).Size(params.Size)
+		if histogram != nil {
+			terms.SubAggregation(histogramAggregationName, histogram)
+		}
+		search = search.Aggregation(groupByAggregationName, terms)
+	}
+	if histogram != nil {
+		search = search.Aggregation(histogramAggregationName, histogram)
+	}
+	result, err := search.Do(c.Request().Context())
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Elastic search error: %s", err), http.StatusInternalServerError)
+	}
+	if result.Error != nil {
+		return obsidian.HttpError(fmt.Errorf("Elastic Error Type: %s, Reason: %s", result.Error.Type, result.Error.Reason))
+	}
+
+	ret := &models.LogAggregation{
+		Total:     swag.Int64(result.TotalHits()),
+		Histogram: getHistogramBuckets(result.Aggregations),
+	}
+	if groups, found := result.Aggregations.Terms(groupByAggregationName); found {
+		ret.Buckets = make([]*models.LogAggregationBucket, 0, len(groups.Buckets))
+		for _, group := range groups.Buckets {
+			ret.Buckets = append(ret.Buckets, &models.LogAggregationBucket{
+				Key:       swag.String(getBucketKey(group)),
+				Count:     swag.Int64(group.DocCount),
+				Histogram: getHistogramBuckets(group.Aggregations),
+			})
+		}
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
 func getQueryParameters(c echo.Context) (logQueryParams, error) {
 	filters, err := getFilterPairs(c.QueryParam(queryParamFilters))
 	if err != nil {
@@ -76,6 +160,11 @@ func getQueryParameters(c echo.Context) (logQueryParams, error) {
 		fields = strings.Split(fieldsStr, urlListDelimiter)
 	}
 
+	searchAfter, err := getSearchAfter(c.QueryParam(queryParamSearchAfter))
+	if err != nil {
+		return logQueryParams{}, err
+	}
+
 	params := logQueryParams{
 		SimpleQuery: c.QueryParam(queryParamSimpleQuery),
 		Fields:      fields,
@@ -83,6 +172,7 @@ func getQueryParameters(c echo.Context) (logQueryParams, error) {
 		StartTime:   c.QueryParam(queryParamStart),
 		EndTime:     c.QueryParam(queryParamEnd),
 		Size:        defaultSearchSize,
+		SearchAfter: searchAfter,
 	}
 	sizeStr := c.QueryParam(queryParamSize)
 	if sizeStr == "" {
@@ -93,10 +183,112 @@ func getQueryParameters(c echo.Context) (logQueryParams, error) {
 	if err != nil {
 		return logQueryParams{}, err
 	}
+	if size < 0 || size > maxSearchSize {
+		return logQueryParams{}, fmt.Errorf("size must be between 0 and %d", maxSearchSize)
+	}
 	params.Size = size
 	return params, nil
 }
 
+// getSearchAfter parses the sort values of a hit, <timestamp>,<id>, from
+// which to continue a search
+func getSearchAfter(searchAfterStr string) ([]interface{}, error) {
+	if searchAfterStr == "" {
+		return nil, nil
+	}
+	values := strings.SplitN(searchAfterStr, urlListDelimiter, 2)
+	if len(values) != 2 || values[1] == "" {
+		return nil, fmt.Errorf("malformed search_after: %s, expected <timestamp>,<id>", searchAfterStr)
+	}
+	timestamp, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed search_after timestamp: %s", values[0])
+	}
+	return []interface{}{timestamp, values[1]}, nil
+}
+
+var (
+	fieldNameRegex = regexp.MustCompile(`^[\w.@-]+$`)
+	intervalRegex  = regexp.MustCompile(`^[1-9]\d*[smhd]$`)
+)
+
+func getAggregationParameters(c echo.Context) (logAggregationParams, error) {
+	queryParams, err := getQueryParameters(c)
+	if err != nil {
+		return logAggregationParams{}, err
+	}
+	params := logAggregationParams{
+		logQueryParams: queryParams,
+		GroupBy:        c.QueryParam(queryParamGroupBy),
+		Interval:       c.QueryParam(queryParamInterval),
+	}
+	if params.GroupBy != "" && !fieldNameRegex.MatchString(params.GroupBy) {
+		return logAggregationParams{}, fmt.Errorf("malformed group_by field: %s", params.GroupBy)
+	}
+	if params.Interval == "" {
+		return params, nil
+	}
+	if !intervalRegex.MatchString(params.Interval) {
+		return logAggregationParams{}, fmt.Errorf("malformed interval: %s, expected e.g. 30s, 5m, 1h or 1d", params.Interval)
+	}
+	if err := params.checkHistogramBuckets(); err != nil {
+		return logAggregationParams{}, err
+	}
+	return params, nil
+}
+
+// checkHistogramBuckets returns an error if the histograms of the time range
+// would have more buckets than Elasticsearch allows. A histogram per group_by
+// value is computed in addition to the overall histogram.
+func (b *logAggregationParams) checkHistogramBuckets() error {
+	if b.StartTime == "" || b.EndTime == "" {
+		return fmt.Errorf("interval requires start and end")
+	}
+	start, err := parseLogTime(b.StartTime)
+	if err != nil {
+		return fmt.Errorf("malformed start: %s", b.StartTime)
+	}
+	end, err := parseLogTime(b.EndTime)
+	if err != nil {
+		return fmt.Errorf("malformed end: %s", b.EndTime)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("end must not be before start")
+	}
+	interval := parseInterval(b.Interval)
+	buckets := int64(end.Sub(start)/interval) + 1
+	histograms := int64(1)
+	if b.GroupBy != "" {
+		histograms += int64(b.Size)
+	}
+	if buckets*histograms > maxHistogramBuckets {
+		return fmt.Errorf(
+			"interval %s is too small for the time range, the histograms would have more than %d buckets",
+			b.Interval, maxHistogramBuckets)
+	}
+	return nil
+}
+
+// parseInterval returns the duration of an interval matching intervalRegex
+func parseInterval(interval string) time.Duration {
+	n, _ := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	units := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}
+	return time.Duration(n) * units[interval[len(interval)-1]]
+}
+
+// parseLogTime parses the common forms of strict_date_optional_time times
+func parseLogTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04", "2006-01-02"} {
+		var t time.Time
+		t, err = time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
 var (
 	keyValRegex = regexp.MustCompile(`(?P<key>\w+):(?P<value>\w+)`)
 )
@@ -131,13 +323,23 @@ type logQueryParams struct {
 	StartTime   string
 	EndTime     string
 	Size        int
+	SearchAfter []interface{}
+}
+
+type logAggregationParams struct {
+	logQueryParams
+	// GroupBy is the field to count logs by, Size is the maximum number of
+	// its values returned
+	GroupBy string
+	// Interval of the count histogram, no histogram is computed if empty
+	Interval string
 }
 
 func (b *logQueryParams) ToElasticBoolQuery() *elastic.BoolQuery {
 	query := elastic.NewBoolQuery()
 
 	if b.StartTime != "" || b.EndTime != "" {
-		timeRangeQuery := elastic.NewRangeQuery(sortTag).Format("strict_date_optional_time")
+		timeRangeQuery := elastic.NewRangeQuery(sortTag).Format(timeFormat)
 		if b.StartTime != "" {
 			timeRangeQuery.Gte(b.StartTime)
 		}
@@ -164,3 +366,64 @@ func (b *logQueryParams) ToElasticBoolQuery() *elastic.BoolQuery {
 	}
 	return query
 }
+
+// toElasticHistogram returns the date histogram of the interval, bounded by
+// the query's time range so that empty intervals are included
+func (b *logAggregationParams) toElasticHistogram() elastic.Aggregation {
+	if b.Interval == "" {
+		return nil
+	}
+	histogram := elastic.NewDateHistogramAggregation().
+		Field(sortTag).
+		Format(timeFormat).
+		MinDocCount(0)
+	if b.StartTime != "" && b.EndTime != "" {
+		histogram.ExtendedBounds(b.StartTime, b.EndTime)
+	}
+	return &fixedIntervalDateHistogram{DateHistogramAggregation: histogram, fixedInterval: b.Interval}
+}
+
+// fixedIntervalDateHistogram is a date histogram with a fixed_interval. The
+// elastic client only sets the deprecated interval parameter.
+type fixedIntervalDateHistogram struct {
+	*elastic.DateHistogramAggregation
+	fixedInterval string
+}
+
+func (a *fixedIntervalDateHistogram) Source() (interface{}, error) {
+	source, err := a.DateHistogramAggregation.Source()
+	if err != nil {
+		return nil, err
+	}
+	opts := source.(map[string]interface{})["date_histogram"].(map[string]interface{})
+	delete(opts, "interval")
+	opts["fixed_interval"] = a.fixedInterval
+	return source, nil
+}
+
+func getHistogramBuckets(aggs elastic.Aggregations) []*models.LogHistogramBucket {
+	histogram, found := aggs.DateHistogram(histogramAggregationName)
+	if !found {
+		return nil
+	}
+	ret := make([]*models.LogHistogramBucket, 0, len(histogram.Buckets))
+	for _, bucket := range histogram.Buckets {
+		// keys are epoch milliseconds
+		bucketTime := strfmt.DateTime(time.Unix(0, int64(bucket.Key)*int64(time.Millisecond)).UTC())
+		ret = append(ret, &models.LogHistogramBucket{
+			Time:  &bucketTime,
+			Count: swag.Int64(bucket.DocCount),
+		})
+	}
+	return ret
+}
+
+func getBucketKey(bucket *elastic.AggregationBucketKeyItem) string {
+	if bucket.KeyAsString != nil {
+		return *bucket.KeyAsString
+	}
+	if key, ok := bucket.Key.(string); ok {
+		return key
+	}
+	return bucket.KeyNumber.String()
+}
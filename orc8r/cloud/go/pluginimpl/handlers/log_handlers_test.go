@@ -1,13 +1,22 @@
 package handlers
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/pluginimpl/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -81,6 +90,15 @@ var (
 				Filters: map[string]string{"test": "result", "key": "value", "foo": "baz"},
 			},
 		},
+		{
+			name:      "search after",
+			urlString: "?size=50&search_after=1569581996248,abc,123",
+			expectedParams: logQueryParams{
+				Size:        50,
+				Fields:      []string{},
+				SearchAfter: []interface{}{int64(1569581996248), "abc,123"},
+			},
+		},
 	}
 )
 
@@ -235,3 +253,220 @@ func runQueryParamTestCase(t *testing.T, tc queryParamTestCase) {
 	assert.NoError(t, err)
 	assert.Equal(t, tc.expectedParams, params)
 }
+
+func TestGetQueryParams_Invalid(t *testing.T) {
+	for _, urlString := range []string{
+		"?size=abc",
+		"?size=-1",
+		"?size=10001",
+		"?search_after=1569581996248",
+		"?search_after=abc,123",
+		"?filters=test",
+	} {
+		req := httptest.NewRequest(echo.GET, fmt.Sprintf("/%s", urlString), nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		_, err := getQueryParameters(c)
+		assert.Error(t, err, urlString)
+	}
+}
+
+func TestGetAggregationParams(t *testing.T) {
+	req := httptest.NewRequest(echo.GET, "/?group_by=gateway_id&interval=5m&size=3&simple_query=error&start=2019-09-27&end=2019-09-28", nil)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	params, err := getAggregationParameters(c)
+	assert.NoError(t, err)
+	expected := logAggregationParams{
+		logQueryParams: logQueryParams{
+			SimpleQuery: "error",
+			Fields:      []string{},
+			StartTime:   "2019-09-27",
+			EndTime:     "2019-09-28",
+			Size:        3,
+		},
+		GroupBy:  "gateway_id",
+		Interval: "5m",
+	}
+	assert.Equal(t, expected, params)
+
+	for _, urlString := range []string{
+		"?group_by=gateway%20id",
+		"?interval=5",
+		"?interval=0m",
+		"?interval=1w",
+		// histograms need a time range
+		"?interval=5m&start=2019-09-27T11:00:00Z",
+		"?interval=5m&start=yesterday&end=2019-09-27T11:00:00Z",
+		"?interval=5m&start=2019-09-28T11:00:00Z&end=2019-09-27T11:00:00Z",
+		// 10081 buckets
+		"?interval=1m&start=2019-09-20T00:00:00Z&end=2019-09-27T00:00:00Z",
+		// 2 histograms of 5761 buckets
+		"?interval=1m&group_by=gateway_id&size=1&start=2019-09-27T00:00:00Z&end=2019-10-01T00:00:00Z",
+	} {
+		req := httptest.NewRequest(echo.GET, "/"+urlString, nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		_, err := getAggregationParameters(c)
+		assert.Error(t, err, urlString)
+	}
+}
+
+func TestQueryLogs(t *testing.T) {
+	var searchRequest map[string]interface{}
+	response := `{
+		"took": 1,
+		"hits": {
+			"total": {"value": 1, "relation": "eq"},
+			"hits": [{"_index": "magma-2019.09.27", "_id": "abc", "sort": [1569581996248, "abc"], "_source": {"message": "hello"}}]
+		}
+	}`
+	client, server := newTestElasticClient(t, &searchRequest, &response)
+	defer server.Close()
+
+	rec := httptest.NewRecorder()
+	c := newTestLogContext("/?size=1&search_after=1569581996249,def", rec)
+	require.NoError(t, GetQueryLogHandler(client)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var hits []map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &hits))
+	require.Len(t, hits, 1)
+	assert.Equal(t, "abc", hits[0]["_id"])
+	assert.Equal(t, []interface{}{1569581996248.0, "abc"}, hits[0]["sort"])
+
+	assert.Equal(t, 1.0, searchRequest["size"])
+	assert.Equal(t, []interface{}{1569581996249.0, "def"}, searchRequest["search_after"])
+	assert.Equal(
+		t,
+		[]interface{}{
+			map[string]interface{}{"@timestamp": map[string]interface{}{"order": "desc"}},
+			map[string]interface{}{"_id": map[string]interface{}{"order": "asc"}},
+		},
+		searchRequest["sort"],
+	)
+	assertNetworkFilter(t, searchRequest)
+
+	// invalid params
+	rec = httptest.NewRecorder()
+	c = newTestLogContext("/?search_after=abc", rec)
+	err := GetQueryLogHandler(client)(c)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+}
+
+func TestAggregateLogs(t *testing.T) {
+	var searchRequest map[string]interface{}
+	response := `{
+		"took": 1,
+		"hits": {"total": {"value": 3, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"group_by": {
+				"buckets": [
+					{
+						"key": "gw1",
+						"doc_count": 2,
+						"histogram": {"buckets": [
+							{"key_as_string": "2019-09-27T11:00:00.000Z", "key": 1569582000000, "doc_count": 2},
+							{"key_as_string": "2019-09-27T11:05:00.000Z", "key": 1569582300000, "doc_count": 0}
+						]}
+					},
+					{"key": 443, "doc_count": 1, "histogram": {"buckets": []}}
+				]
+			},
+			"histogram": {"buckets": [
+				{"key_as_string": "2019-09-27T11:00:00.000Z", "key": 1569582000000, "doc_count": 3}
+			]}
+		}
+	}`
+	client, server := newTestElasticClient(t, &searchRequest, &response)
+	defer server.Close()
+
+	rec := httptest.NewRecorder()
+	c := newTestLogContext("/?group_by=gateway_id&interval=5m&size=5&start=2019-09-27T11:00:00Z&end=2019-09-27T11:10:00Z", rec)
+	require.NoError(t, GetLogAggregationHandler(client)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	actual := &models.LogAggregation{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), actual))
+	t0 := strfmt.DateTime(time.Unix(1569582000, 0).UTC())
+	t1 := strfmt.DateTime(time.Unix(1569582300, 0).UTC())
+	expected := &models.LogAggregation{
+		Total: swag.Int64(3),
+		Histogram: []*models.LogHistogramBucket{
+			{Time: &t0, Count: swag.Int64(3)},
+		},
+		Buckets: []*models.LogAggregationBucket{
+			{
+				Key:   swag.String("gw1"),
+				Count: swag.Int64(2),
+				Histogram: []*models.LogHistogramBucket{
+					{Time: &t0, Count: swag.Int64(2)},
+					{Time: &t1, Count: swag.Int64(0)},
+				},
+			},
+			{Key: swag.String("443"), Count: swag.Int64(1), Histogram: []*models.LogHistogramBucket{}},
+		},
+	}
+	assert.Equal(t, expected, actual)
+	assert.NoError(t, actual.Validate(strfmt.Default))
+
+	assert.Equal(t, 0.0, searchRequest["size"])
+	assert.Equal(t, true, searchRequest["track_total_hits"])
+	expectedHistogram := map[string]interface{}{
+		"date_histogram": map[string]interface{}{
+			"field":           "@timestamp",
+			"fixed_interval":  "5m",
+			"format":          "strict_date_optional_time",
+			"min_doc_count":   0.0,
+			"extended_bounds": map[string]interface{}{"min": "2019-09-27T11:00:00Z", "max": "2019-09-27T11:10:00Z"},
+		},
+	}
+	expectedAggs := map[string]interface{}{
+		"group_by": map[string]interface{}{
+			"terms":        map[string]interface{}{"field": "gateway_id", "size": 5.0},
+			"aggregations": map[string]interface{}{"histogram": expectedHistogram},
+		},
+		"histogram": expectedHistogram,
+	}
+	assert.Equal(t, expectedAggs, searchRequest["aggregations"])
+	assertNetworkFilter(t, searchRequest)
+
+	// without group_by and interval only the total is returned
+	rec = httptest.NewRecorder()
+	c = newTestLogContext("/", rec)
+	require.NoError(t, GetLogAggregationHandler(client)(c))
+	assert.Nil(t, searchRequest["aggregations"])
+}
+
+// newTestElasticClient returns a client of a fake Elasticsearch, which
+// stores the last search request and responds with the response
+func newTestElasticClient(
+	t *testing.T,
+	request *map[string]interface{},
+	response *string,
+) (*elastic.Client, *httptest.Server) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_search", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*request = map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(body, request))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(*response))
+	}))
+	client, err := elastic.NewSimpleClient(elastic.SetURL(server.URL))
+	require.NoError(t, err)
+	return client, server
+}
+
+func newTestLogContext(urlString string, rec *httptest.ResponseRecorder) echo.Context {
+	req := httptest.NewRequest(echo.GET, urlString, nil)
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("network_id")
+	c.SetParamValues("n1")
+	return c
+}
+
+func assertNetworkFilter(t *testing.T, searchRequest map[string]interface{}) {
+	boolQuery := searchRequest["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	assert.Equal(
+		t,
+		map[string]interface{}{"term": map[string]interface{}{NetworkLogLabel: "n1"}},
+		boolQuery["filter"],
+	)
+}
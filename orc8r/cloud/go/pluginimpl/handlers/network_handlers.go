@@ -10,7 +10,9 @@ package handlers
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
@@ -19,6 +21,9 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func listNetworks(c echo.Context) error {
@@ -86,6 +91,85 @@ func deleteNetwork(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+func exportNetwork(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	archive, err := configurator.ExportNetwork(networkID)
+	if err == merrors.ErrNotFound {
+		return echo.ErrNotFound
+	}
+	if err != nil {
+		return obsidian.HttpError(errors.Wrap(err, "failed to export network"), http.StatusInternalServerError)
+	}
+	archiveBytes, err := configurator.MarshalNetworkArchive(archive)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSONBlob(http.StatusOK, archiveBytes)
+}
+
+func importNetwork(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	overwrite, nerr := getBoolQueryParam(c, overwriteParam)
+	if nerr != nil {
+		return nerr
+	}
+	dropPhysicalIDs, nerr := getBoolQueryParam(c, dropPhysicalIDsParam)
+	if nerr != nil {
+		return nerr
+	}
+
+	archiveBytes, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	archive, err := configurator.UnmarshalNetworkArchive(archiveBytes)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	if !overwrite {
+		exists, err := configurator.DoesNetworkExist(networkID)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to check if network exists"), http.StatusInternalServerError)
+		}
+		if exists {
+			return obsidian.HttpError(fmt.Errorf("network %s already exists", networkID), http.StatusConflict)
+		}
+	}
+
+	err = configurator.ImportNetwork(archive, networkID, overwrite, dropPhysicalIDs)
+	if status.Code(err) == codes.InvalidArgument {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if errors.Cause(err) == merrors.ErrAlreadyExists {
+		return obsidian.HttpError(err, http.StatusConflict)
+	}
+	if err != nil {
+		return obsidian.HttpError(errors.Wrap(err, "failed to import network"), http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+// getBoolQueryParam returns the value of a boolean query parameter, or false
+// if the parameter isn't set.
+func getBoolQueryParam(c echo.Context, name string) (bool, *echo.HTTPError) {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return false, obsidian.HttpError(errors.Wrapf(err, "invalid %s parameter", name), http.StatusBadRequest)
+	}
+	return value, nil
+}
+
 func CreateDNSRecord(c echo.Context) error {
 	networkID, domain, nerr := getNetworkIDAndDomain(c)
 	if nerr != nil {
@@ -9,9 +9,12 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	models1 "magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/tests"
@@ -1033,6 +1036,149 @@ func Test_DeleteNetworkDNSHandlers(t *testing.T) {
 
 }
 
+func Test_ExportImportNetworkHandlers(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+
+	e := echo.New()
+	testURLRoot := "/magma/v1/networks"
+
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	exportNetwork := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/export", obsidian.GET).HandlerFunc
+	importNetwork := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/import", obsidian.POST).HandlerFunc
+
+	seedNetworks(t)
+	_, err := configurator.CreateEntities("n1", []configurator.NetworkEntity{
+		{Type: orc8r.UpgradeTierEntityType, Key: "t1", Name: "tier 1"},
+		{Type: orc8r.UpgradeTierEntityType, Key: "t2", Name: "tier 2"},
+	})
+	assert.NoError(t, err)
+	archive, err := configurator.ExportNetwork("n1")
+	assert.NoError(t, err)
+	archiveBytes, err := configurator.MarshalNetworkArchive(archive)
+	assert.NoError(t, err)
+	// Configs are archived as JSON
+	archiveJSON := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(archiveBytes, &archiveJSON))
+	archivedDNSConfig := archiveJSON["network"].(map[string]interface{})["configs"].(map[string]interface{})[orc8r.DnsdNetworkType]
+	archivedDNSJSON := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(archivedDNSConfig.(map[string]interface{})["json"].(string)), &archivedDNSJSON))
+	assert.Equal(t, true, archivedDNSJSON["enable_caching"])
+
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/n1/export",
+		Handler:        exportNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: rawPayload(archiveBytes),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/n3/export",
+		Handler:        exportNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n3"},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// import into a new network
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/n3/import",
+		Payload:        rawPayload(archiveBytes),
+		Handler:        importNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n3"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	actualNetwork, err := configurator.LoadNetwork("n3", true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "network1", actualNetwork.Name)
+	assert.Equal(t, models.NewDefaultDNSConfig(), actualNetwork.Configs[orc8r.DnsdNetworkType])
+	actualKeys, err := configurator.ListEntityKeys("n3", orc8r.UpgradeTierEntityType)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t2"}, actualKeys)
+
+	// existing network requires overwrite
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/n2/import",
+		Payload:        rawPayload(archiveBytes),
+		Handler:        importNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n2"},
+		ExpectedStatus: 409,
+		ExpectedError:  "network n2 already exists",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "/n2/import?overwrite=true"
+	tc.ExpectedStatus = 201
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+	actualNetwork, err = configurator.LoadNetwork("n2", true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "network1", actualNetwork.Name)
+
+	// bad archives
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/n4/import",
+		Payload:        rawPayload([]byte(`{"format_version": 100, "network": {"id": "n4"}}`)),
+		Handler:        importNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n4"},
+		ExpectedStatus: 400,
+		ExpectedError:  "unsupported network archive format version 100, expected at most 2",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// physical IDs registered in the exported network conflict
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g1", PhysicalID: "hw1"})
+	assert.NoError(t, err)
+	archive, err = configurator.ExportNetwork("n1")
+	assert.NoError(t, err)
+	archiveBytes, err = configurator.MarshalNetworkArchive(archive)
+	assert.NoError(t, err)
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/n5/import",
+		Payload:        rawPayload(archiveBytes),
+		Handler:        importNetwork,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n5"},
+		ExpectedStatus: 409,
+		ExpectedError:  "physical IDs are already registered: hw1 (network n1); import without physical IDs or remove the existing entities first: Already exists",
+	}
+	tests.RunUnitTest(t, e, tc)
+	exists, err := configurator.DoesNetworkExist("n5")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	tc.URL = testURLRoot + "/n5/import?drop_physical_ids=true"
+	tc.ExpectedStatus = 201
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+	actualGateway, err := configurator.LoadEntity("n5", orc8r.MagmadGatewayType, "g1", configurator.EntityLoadCriteria{LoadMetadata: true})
+	assert.NoError(t, err)
+	assert.Empty(t, actualGateway.PhysicalID)
+}
+
+type rawPayload []byte
+
+func (r rawPayload) MarshalBinary() ([]byte, error) {
+	return r, nil
+}
+
 func seedNetworks(t *testing.T) {
 	_, err := configurator.CreateNetworks(
 		[]configurator.Network{
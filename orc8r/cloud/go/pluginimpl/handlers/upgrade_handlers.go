@@ -19,6 +19,7 @@ import (
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/upgrade/rollouts"
 
 	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
@@ -271,6 +272,101 @@ func deleteTierGateway(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+func listRolloutsHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	rolloutIDs, err := configurator.ListEntityKeys(networkID, orc8r.UpgradeRolloutEntityType)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	sort.Strings(rolloutIDs)
+	return c.JSON(http.StatusOK, rolloutIDs)
+}
+
+func createRolloutHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	payload, nerr := GetAndValidatePayload(c, &models.Rollout{})
+	if nerr != nil {
+		return nerr
+	}
+	err := rollouts.Start(networkID, payload.(*models.Rollout))
+	if err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func readRolloutHandler(c echo.Context) error {
+	networkID, rolloutID, nerr := getNetworkAndRolloutIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	rollout, err := rollouts.Get(networkID, rolloutID)
+	if err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.JSON(http.StatusOK, rollout)
+}
+
+func deleteRolloutHandler(c echo.Context) error {
+	networkID, rolloutID, nerr := getNetworkAndRolloutIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	if err := rollouts.Delete(networkID, rolloutID); err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func pauseRolloutHandler(c echo.Context) error {
+	networkID, rolloutID, nerr := getNetworkAndRolloutIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	if err := rollouts.Pause(networkID, rolloutID, "paused by operator"); err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func resumeRolloutHandler(c echo.Context) error {
+	networkID, rolloutID, nerr := getNetworkAndRolloutIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	if err := rollouts.Resume(networkID, rolloutID); err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func abortRolloutHandler(c echo.Context) error {
+	networkID, rolloutID, nerr := getNetworkAndRolloutIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+	if err := rollouts.Abort(networkID, rolloutID); err != nil {
+		return rolloutHttpError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func rolloutHttpError(err error) *echo.HTTPError {
+	if err == merrors.ErrNotFound {
+		return obsidian.HttpError(err, http.StatusNotFound)
+	}
+	if _, ok := err.(*rollouts.ConflictError); ok {
+		return obsidian.HttpError(err, http.StatusConflict)
+	}
+	return obsidian.HttpError(err, http.StatusInternalServerError)
+}
+
 func getChannelID(c echo.Context) (string, *echo.HTTPError) {
 	channelID := c.Param("channel_id")
 	if channelID == "" {
@@ -286,3 +382,11 @@ func getNetworkAndTierIDs(c echo.Context) (string, string, *echo.HTTPError) {
 	}
 	return vals[0], vals[1], nil
 }
+
+func getNetworkAndRolloutIDs(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, err := obsidian.GetParamValues(c, "network_id", "rollout_id")
+	if err != nil {
+		return "", "", err
+	}
+	return vals[0], vals[1], nil
+}
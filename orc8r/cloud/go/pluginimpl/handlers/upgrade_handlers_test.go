@@ -11,6 +11,7 @@ package handlers_test
 import (
 	"testing"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	models1 "magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/tests"
@@ -820,3 +821,160 @@ func TestPartialTierUpdates(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTier, actualTier)
 }
+
+func Test_Rollouts(t *testing.T) {
+	plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	listRollouts := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts", obsidian.GET).HandlerFunc
+	createRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts", obsidian.POST).HandlerFunc
+	readRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts/:rollout_id", obsidian.GET).HandlerFunc
+	deleteRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts/:rollout_id", obsidian.DELETE).HandlerFunc
+	pauseRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts/:rollout_id/pause", obsidian.POST).HandlerFunc
+	resumeRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts/:rollout_id/resume", obsidian.POST).HandlerFunc
+	abortRollout := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/rollouts/:rollout_id/abort", obsidian.POST).HandlerFunc
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{
+		Type: orc8r.UpgradeTierEntityType, Key: "tier1",
+		Config: &models.Tier{ID: "tier1", Version: "1-1-1-1", Images: models.TierImages{}, Gateways: models.TierGateways{}},
+	})
+	assert.NoError(t, err)
+
+	testURLRoot := "/magma/v1/networks/n1/rollouts"
+	rollout := &models.Rollout{
+		ID:                  "rollout1",
+		Tier:                "tier1",
+		Version:             "2-2-2-2",
+		Waves:               []uint32{10, 100},
+		WaveIntervalSeconds: swag.Uint32(3600),
+	}
+
+	// Waves must increase up to 100%
+	invalidRollout := *rollout
+	invalidRollout.Waves = []uint32{50, 10, 100}
+	tc := tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot,
+		Payload:        &invalidRollout,
+		Handler:        createRollout,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "wave percentages must increase, got 10 after 50",
+	}
+	tests.RunUnitTest(t, e, tc)
+	invalidRollout.Waves = []uint32{10, 50}
+	tc.ExpectedError = "last wave must upgrade 100% of the gateways"
+	tests.RunUnitTest(t, e, tc)
+
+	// Unknown tier
+	invalidRollout.Waves = rollout.Waves
+	invalidRollout.Tier = "tier2"
+	tc.ExpectedStatus = 409
+	tc.ExpectedError = "tier tier2 does not exist"
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot,
+		Payload:        rollout,
+		Handler:        createRollout,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot,
+		Handler:        listRollouts,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]string{"rollout1"}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	expected := *rollout
+	expected.Status = &models.RolloutStatus{State: models.RolloutStatusStateInProgress, Gateways: []models1.GatewayID{}}
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "/rollout1",
+		Handler:        readRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 200,
+		ExpectedResult: &expected,
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc.ParamValues = []string{"n1", "rollout2"}
+	tc.ExpectedStatus = 404
+	tc.ExpectedError = "Not found"
+	tests.RunUnitTest(t, e, tc)
+
+	// Pause, resume and abort
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/rollout1/pause",
+		Handler:        pauseRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc.ExpectedStatus = 409
+	tc.ExpectedError = "rollout rollout1 is paused"
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/rollout1/resume",
+		Handler:        resumeRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Active rollouts can't be deleted
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot + "/rollout1",
+		Handler:        deleteRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 409,
+		ExpectedError:  "rollout rollout1 is in_progress, abort it first",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            testURLRoot + "/rollout1/abort",
+		Handler:        abortRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	actual, err := configurator.LoadEntityConfig("n1", orc8r.UpgradeRolloutEntityType, "rollout1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutStatusStateAborted, actual.(*models.Rollout).Status.State)
+
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot + "/rollout1",
+		Handler:        deleteRollout,
+		ParamNames:     []string{"network_id", "rollout_id"},
+		ParamValues:    []string{"n1", "rollout1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	_, err = configurator.LoadEntity("n1", orc8r.UpgradeRolloutEntityType, "rollout1", configurator.EntityLoadCriteria{})
+	assert.Equal(t, merrors.ErrNotFound, err)
+}
@@ -91,9 +91,25 @@ func getPackageVersionAndImages(magmadGateway configurator.NetworkEntity, graph
 	for _, image := range tierConfig.Images {
 		retImages = append(retImages, &mconfig.ImageSpec{Name: swag.StringValue(image.Name), Order: swag.Int64Value(image.Order)})
 	}
+	if rollout := getActiveRollout(tier, graph); rollout != nil && rollout.HasGateway(magmadGateway.Key) {
+		return rollout.Version.ToString(), retImages, nil
+	}
 	return tierConfig.Version.ToString(), retImages, nil
 }
 
+// getActiveRollout returns the in progress or paused rollout of the tier, or
+// nil if there isn't one. Gateways which a rollout's waves have reached run
+// the rollout's version instead of the tier's.
+func getActiveRollout(tier configurator.NetworkEntity, graph *configurator.EntityGraph) *models.Rollout {
+	for _, ent := range graph.GetEntitiesOfType(orc8r.UpgradeRolloutEntityType) {
+		rollout, ok := ent.Config.(*models.Rollout)
+		if ok && string(rollout.Tier) == tier.Key && rollout.IsActive() {
+			return rollout
+		}
+	}
+	return nil
+}
+
 func (*DnsdMconfigBuilder) Build(networkID string, gatewayID string, graph configurator.EntityGraph, network configurator.Network, mconfigOut map[string]proto.Message) error {
 	iConfig, found := network.Configs[orc8r.DnsdNetworkType]
 	if !found {
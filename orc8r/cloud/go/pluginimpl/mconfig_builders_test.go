@@ -11,6 +11,7 @@ package pluginimpl_test
 import (
 	"testing"
 
+	models1 "magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl"
 	"magma/orc8r/cloud/go/pluginimpl/models"
@@ -117,6 +118,38 @@ func TestBaseOrchestratorMconfigBuilder_Build(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expected, actual)
+
+	// Gateways reached by an active rollout of the tier get its version
+	rollout := &models.Rollout{
+		ID:      "rollout1",
+		Tier:    "default",
+		Version: "2.0.0-0",
+		Status: &models.RolloutStatus{
+			State:    models.RolloutStatusStatePaused,
+			Gateways: []models1.GatewayID{"gw1"},
+		},
+	}
+	rolloutEnt := configurator.NetworkEntity{Type: orc8r.UpgradeRolloutEntityType, Key: "rollout1", Config: rollout}
+	graph = configurator.EntityGraph{
+		Entities: []configurator.NetworkEntity{gw, tier, rolloutEnt},
+		Edges: []configurator.GraphEdge{
+			{From: tier.GetTypeAndKey(), To: gw.GetTypeAndKey()},
+			{From: rolloutEnt.GetTypeAndKey(), To: tier.GetTypeAndKey()},
+		},
+	}
+	actual = map[string]proto.Message{}
+	err = builder.Build("n1", "gw1", graph, nw, actual)
+	assert.NoError(t, err)
+	expected["magmad"].(*mconfig.MagmaD).PackageVersion = "2.0.0-0"
+	assert.Equal(t, expected, actual)
+
+	// Aborted rollouts don't apply
+	rollout.Status.State = models.RolloutStatusStateAborted
+	actual = map[string]proto.Message{}
+	err = builder.Build("n1", "gw1", graph, nw, actual)
+	assert.NoError(t, err)
+	expected["magmad"].(*mconfig.MagmaD).PackageVersion = "1.0.0-0"
+	assert.Equal(t, expected, actual)
 }
 
 func TestDnsdMconfigBuilder_Build(t *testing.T) {
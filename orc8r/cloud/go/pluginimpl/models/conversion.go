@@ -364,6 +364,35 @@ func (m *Tier) FromBackendModel(entity configurator.NetworkEntity) *Tier {
 	return tier
 }
 
+func (m *Rollout) ToNetworkEntity() configurator.NetworkEntity {
+	return configurator.NetworkEntity{
+		Type: orc8r.UpgradeRolloutEntityType, Key: string(m.ID),
+		Config:       m,
+		Associations: []storage.TypeAndKey{{Type: orc8r.UpgradeTierEntityType, Key: string(m.Tier)}},
+	}
+}
+
+// IsActive returns true if the rollout is in progress or paused
+func (m *Rollout) IsActive() bool {
+	if m.Status == nil {
+		return false
+	}
+	return m.Status.State == RolloutStatusStateInProgress || m.Status.State == RolloutStatusStatePaused
+}
+
+// HasGateway returns true if the rollout's waves reached the gateway
+func (m *Rollout) HasGateway(gatewayID string) bool {
+	if m.Status == nil {
+		return false
+	}
+	for _, gw := range m.Status.Gateways {
+		if string(gw) == gatewayID {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *TierName) ToUpdateCriteria(networkID string, key string) ([]configurator.EntityUpdateCriteria, error) {
 	return []configurator.EntityUpdateCriteria{
 		configurator.EntityUpdateCriteria{
@@ -13,11 +13,14 @@ import (
 	"errors"
 	"fmt"
 
+	"magma/orc8r/cloud/go/security/tpm"
+
 	"github.com/go-openapi/strfmt"
 )
 
 const echoKeyType = "ECHO"
 const ecdsaKeyType = "SOFTWARE_ECDSA_SHA256"
+const tpmKeyType = "HARDWARE_TPM2_SHA256"
 
 func (m *Network) ValidateModel() error {
 	return m.Validate(strfmt.Default)
@@ -40,7 +43,10 @@ func (m DNSConfigRecord) ValidateModel() error {
 }
 
 func (m *MagmadGateway) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Device.ValidateModel()
 }
 
 func (m *GatewayDevice) ValidateModel() error {
@@ -66,6 +72,15 @@ func (m *ChallengeKey) ValidateModel() error {
 			return fmt.Errorf("Failed to parse key: %s", err)
 		}
 		return nil
+	case tpmKeyType:
+		if m.Key == nil {
+			return fmt.Errorf("No key supplied")
+		}
+		_, _, err := tpm.ParseChallengeKey(*m.Key)
+		if err != nil {
+			return fmt.Errorf("Failed to parse key: %s", err)
+		}
+		return nil
 	default:
 		return fmt.Errorf("Unknown key type %s", m.KeyType)
 	}
@@ -110,6 +125,21 @@ func (m *TierImage) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
 
+func (m *Rollout) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	for i := 1; i < len(m.Waves); i++ {
+		if m.Waves[i] <= m.Waves[i-1] {
+			return fmt.Errorf("wave percentages must increase, got %d after %d", m.Waves[i], m.Waves[i-1])
+		}
+	}
+	if m.Waves[len(m.Waves)-1] != 100 {
+		return errors.New("last wave must upgrade 100% of the gateways")
+	}
+	return nil
+}
+
 func (m *GatewayStatus) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
@@ -21,6 +21,7 @@ import (
 	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/serviceregistry"
 	accessdh "magma/orc8r/cloud/go/services/accessd/obsidian/handlers"
+	audith "magma/orc8r/cloud/go/services/audit/obsidian/handlers"
 	checkinh "magma/orc8r/cloud/go/services/checkind/obsidian/handlers"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/device"
@@ -38,7 +39,9 @@ import (
 	"magma/orc8r/cloud/go/services/streamer/mconfig"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 	upgradeh "magma/orc8r/cloud/go/services/upgrade/obsidian/handlers"
+	"magma/orc8r/cloud/go/sqorc"
 
+	"github.com/golang/glog"
 	"github.com/labstack/echo"
 )
 
@@ -76,6 +79,7 @@ func (*BaseOrchestratorPlugin) GetSerdes() []serde.Serde {
 		configurator.NewNetworkEntityConfigSerde(orc8r.MagmadGatewayType, &models.MagmadGatewayConfigs{}),
 		configurator.NewNetworkEntityConfigSerde(orc8r.UpgradeReleaseChannelEntityType, &models.ReleaseChannel{}),
 		configurator.NewNetworkEntityConfigSerde(orc8r.UpgradeTierEntityType, &models.Tier{}),
+		configurator.NewNetworkEntityConfigSerde(orc8r.UpgradeRolloutEntityType, &models.Rollout{}),
 	}
 }
 
@@ -102,6 +106,7 @@ func (*BaseOrchestratorPlugin) GetObsidianHandlers(metricsConfig *config.ConfigM
 		stateh.GetObsidianHandlers(),
 		// v1 handlers
 		handlers.GetObsidianHandlers(),
+		audith.GetObsidianHandlers(),
 		[]obsidian.Handler{{
 			Path:    "/",
 			Methods: obsidian.GET,
@@ -122,9 +127,15 @@ func (*BaseOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider
 	}
 }
 
+func (*BaseOrchestratorPlugin) GetMigrations() []sqorc.Migration {
+	return []sqorc.Migration{}
+}
+
 const (
-	ProfileNamePrometheus = "prometheus"
-	ProfileNameExportAll  = "exportall"
+	ProfileNamePrometheus      = "prometheus"
+	ProfileNameRemoteWrite     = "remotewrite"
+	ProfileNameOpenMetricsFile = "openmetricsfile"
+	ProfileNameExportAll       = "exportall"
 )
 
 func getMetricsProfiles(metricsConfig *config.ConfigMap) []metricsd.MetricsProfile {
@@ -143,21 +154,62 @@ func getMetricsProfiles(metricsConfig *config.ConfigMap) []metricsd.MetricsProfi
 	// Prometheus profile - Exports all service metric to Prometheus
 	prometheusAddresses := metricsConfig.GetRequiredStringArrayParam(confignames.PrometheusPushAddresses)
 	prometheusCustomPushExporter := promeExp.NewCustomPushExporter(prometheusAddresses)
+	if _, ok := metricsConfig.RawMap[confignames.PrometheusPushShardIDs]; ok {
+		shardIDs, err := metricsConfig.GetStringArrayParam(confignames.PrometheusPushShardIDs)
+		if err != nil {
+			glog.Fatalf("Invalid metricsd prometheus push shard IDs: %s", err)
+		}
+		prometheusCustomPushExporter, err = promeExp.NewShardedCustomPushExporter(prometheusAddresses, shardIDs)
+		if err != nil {
+			glog.Fatalf("Invalid metricsd prometheus push shard IDs: %s", err)
+		}
+	}
 	prometheusProfile := metricsd.MetricsProfile{
 		Name:       ProfileNamePrometheus,
 		Collectors: controllerCollectors,
 		Exporters:  []exporters.Exporter{prometheusCustomPushExporter},
 	}
 
+	profiles := []metricsd.MetricsProfile{prometheusProfile}
+	allExporters := []exporters.Exporter{prometheusCustomPushExporter}
+
+	// Remote write profile - Exports to a prometheus remote write endpoint,
+	// if configured
+	remoteWriteConfig, err := promeExp.GetRemoteWriteConfig(metricsConfig)
+	if err != nil {
+		glog.Fatalf("Invalid metricsd remote write config: %s", err)
+	}
+	if remoteWriteConfig != nil {
+		remoteWriteExporter := promeExp.NewRemoteWriteExporter(*remoteWriteConfig)
+		profiles = append(profiles, metricsd.MetricsProfile{
+			Name:       ProfileNameRemoteWrite,
+			Collectors: controllerCollectors,
+			Exporters:  []exporters.Exporter{remoteWriteExporter},
+		})
+		allExporters = append(allExporters, remoteWriteExporter)
+	}
+
+	// OpenMetrics file profile - Writes metrics to a local file, if configured
+	openMetricsFileConfig, err := promeExp.GetOpenMetricsFileConfig(metricsConfig)
+	if err != nil {
+		glog.Fatalf("Invalid metricsd OpenMetrics file config: %s", err)
+	}
+	if openMetricsFileConfig != nil {
+		openMetricsFileExporter := promeExp.NewOpenMetricsFileExporter(*openMetricsFileConfig)
+		profiles = append(profiles, metricsd.MetricsProfile{
+			Name:       ProfileNameOpenMetricsFile,
+			Collectors: controllerCollectors,
+			Exporters:  []exporters.Exporter{openMetricsFileExporter},
+		})
+		allExporters = append(allExporters, openMetricsFileExporter)
+	}
+
 	// ExportAllProfile - Exports to all exporters
 	exportAllProfile := metricsd.MetricsProfile{
 		Name:       ProfileNameExportAll,
 		Collectors: controllerCollectors,
-		Exporters:  []exporters.Exporter{prometheusCustomPushExporter},
+		Exporters:  allExporters,
 	}
 
-	return []metricsd.MetricsProfile{
-		prometheusProfile,
-		exportAllProfile,
-	}
+	return append(profiles, exportAllProfile)
 }
@@ -30,18 +30,22 @@ const (
 	ChallengeKey_ECHO                  ChallengeKey_KeyType = 0
 	ChallengeKey_SOFTWARE_RSA_SHA256   ChallengeKey_KeyType = 1
 	ChallengeKey_SOFTWARE_ECDSA_SHA256 ChallengeKey_KeyType = 2
+	// TPM 2.0 attestation key, answers challenges with a quote
+	ChallengeKey_HARDWARE_TPM2_SHA256 ChallengeKey_KeyType = 3
 )
 
 var ChallengeKey_KeyType_name = map[int32]string{
 	0: "ECHO",
 	1: "SOFTWARE_RSA_SHA256",
 	2: "SOFTWARE_ECDSA_SHA256",
+	3: "HARDWARE_TPM2_SHA256",
 }
 
 var ChallengeKey_KeyType_value = map[string]int32{
 	"ECHO":                  0,
 	"SOFTWARE_RSA_SHA256":   1,
 	"SOFTWARE_ECDSA_SHA256": 2,
+	"HARDWARE_TPM2_SHA256":  3,
 }
 
 func (x ChallengeKey_KeyType) String() string {
@@ -53,11 +57,12 @@ func (ChallengeKey_KeyType) EnumDescriptor() ([]byte, []int) {
 }
 
 type Challenge struct {
-	KeyType              ChallengeKey_KeyType `protobuf:"varint,1,opt,name=key_type,json=keyType,proto3,enum=magma.orc8r.ChallengeKey_KeyType" json:"key_type,omitempty"`
-	Challenge            []byte               `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	KeyType              ChallengeKey_KeyType     `protobuf:"varint,1,opt,name=key_type,json=keyType,proto3,enum=magma.orc8r.ChallengeKey_KeyType" json:"key_type,omitempty"`
+	Challenge            []byte                   `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	TpmCredential        *Challenge_TPMCredential `protobuf:"bytes,3,opt,name=tpm_credential,json=tpmCredential,proto3" json:"tpm_credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *Challenge) Reset()         { *m = Challenge{} }
@@ -99,12 +104,73 @@ func (m *Challenge) GetChallenge() []byte {
 	return nil
 }
 
+func (m *Challenge) GetTpmCredential() *Challenge_TPMCredential {
+	if m != nil {
+		return m.TpmCredential
+	}
+	return nil
+}
+
+// TPMCredential is a credential made with TPM2_MakeCredential for the
+// registered endorsement and attestation keys of a HARDWARE_TPM2_SHA256
+// gateway. The gateway recovers its secret with TPM2_ActivateCredential,
+// which only succeeds if the attestation key is in the same TPM as the
+// endorsement key.
+type Challenge_TPMCredential struct {
+	CredentialBlob       []byte   `protobuf:"bytes,1,opt,name=credential_blob,json=credentialBlob,proto3" json:"credential_blob,omitempty"`
+	EncryptedSecret      []byte   `protobuf:"bytes,2,opt,name=encrypted_secret,json=encryptedSecret,proto3" json:"encrypted_secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Challenge_TPMCredential) Reset()         { *m = Challenge_TPMCredential{} }
+func (m *Challenge_TPMCredential) String() string { return proto.CompactTextString(m) }
+func (*Challenge_TPMCredential) ProtoMessage()    {}
+func (*Challenge_TPMCredential) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b592b3c4e9ae6813, []int{0, 0}
+}
+
+func (m *Challenge_TPMCredential) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Challenge_TPMCredential.Unmarshal(m, b)
+}
+func (m *Challenge_TPMCredential) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Challenge_TPMCredential.Marshal(b, m, deterministic)
+}
+func (m *Challenge_TPMCredential) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Challenge_TPMCredential.Merge(m, src)
+}
+func (m *Challenge_TPMCredential) XXX_Size() int {
+	return xxx_messageInfo_Challenge_TPMCredential.Size(m)
+}
+func (m *Challenge_TPMCredential) XXX_DiscardUnknown() {
+	xxx_messageInfo_Challenge_TPMCredential.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Challenge_TPMCredential proto.InternalMessageInfo
+
+func (m *Challenge_TPMCredential) GetCredentialBlob() []byte {
+	if m != nil {
+		return m.CredentialBlob
+	}
+	return nil
+}
+
+func (m *Challenge_TPMCredential) GetEncryptedSecret() []byte {
+	if m != nil {
+		return m.EncryptedSecret
+	}
+	return nil
+}
+
 // --------------------------------------------------------------------------
 // Challenge key stores the key used for challenge-response during bootstrap.
 // --------------------------------------------------------------------------
 type ChallengeKey struct {
 	KeyType ChallengeKey_KeyType `protobuf:"varint,1,opt,name=key_type,json=keyType,proto3,enum=magma.orc8r.ChallengeKey_KeyType" json:"key_type,omitempty"`
-	// Public key encoded in DER format
+	// Public key encoded in DER format. For HARDWARE_TPM2_SHA256, the DER
+	// encoded SEQUENCE of the TPMT_PUBLIC of the TPM's attestation key and the
+	// DER encoded certificate of its endorsement key, as OCTET STRINGs.
 	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -157,6 +223,7 @@ type Response struct {
 	//	*Response_EchoResponse
 	//	*Response_RsaResponse
 	//	*Response_EcdsaResponse
+	//	*Response_TpmResponse
 	Response             isResponse_Response `protobuf_oneof:"response"`
 	Csr                  *CSR                `protobuf:"bytes,6,opt,name=csr,proto3" json:"csr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
@@ -219,12 +286,18 @@ type Response_EcdsaResponse struct {
 	EcdsaResponse *Response_ECDSA `protobuf:"bytes,5,opt,name=ecdsa_response,json=ecdsaResponse,proto3,oneof"`
 }
 
+type Response_TpmResponse struct {
+	TpmResponse *Response_TPM `protobuf:"bytes,7,opt,name=tpm_response,json=tpmResponse,proto3,oneof"`
+}
+
 func (*Response_EchoResponse) isResponse_Response() {}
 
 func (*Response_RsaResponse) isResponse_Response() {}
 
 func (*Response_EcdsaResponse) isResponse_Response() {}
 
+func (*Response_TpmResponse) isResponse_Response() {}
+
 func (m *Response) GetResponse() isResponse_Response {
 	if m != nil {
 		return m.Response
@@ -253,6 +326,13 @@ func (m *Response) GetEcdsaResponse() *Response_ECDSA {
 	return nil
 }
 
+func (m *Response) GetTpmResponse() *Response_TPM {
+	if x, ok := m.GetResponse().(*Response_TpmResponse); ok {
+		return x.TpmResponse
+	}
+	return nil
+}
+
 func (m *Response) GetCsr() *CSR {
 	if m != nil {
 		return m.Csr
@@ -266,6 +346,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_EchoResponse)(nil),
 		(*Response_RsaResponse)(nil),
 		(*Response_EcdsaResponse)(nil),
+		(*Response_TpmResponse)(nil),
 	}
 }
 
@@ -394,52 +475,125 @@ func (m *Response_ECDSA) GetS() []byte {
 	return nil
 }
 
+type Response_TPM struct {
+	// TPMS_ATTEST quote structure generated by the TPM, with the SHA-256
+	// digest of the challenge concatenated with the secret of the activated
+	// tpm_credential as qualifying data
+	Quote []byte `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	// TPMT_SIGNATURE of the quote by the attestation key
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// intermediate CA certificates of the registered endorsement key
+	// certificate, in DER encoding
+	EkIntermediates      [][]byte `protobuf:"bytes,3,rep,name=ek_intermediates,json=ekIntermediates,proto3" json:"ek_intermediates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Response_TPM) Reset()         { *m = Response_TPM{} }
+func (m *Response_TPM) String() string { return proto.CompactTextString(m) }
+func (*Response_TPM) ProtoMessage()    {}
+func (*Response_TPM) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b592b3c4e9ae6813, []int{2, 3}
+}
+
+func (m *Response_TPM) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Response_TPM.Unmarshal(m, b)
+}
+func (m *Response_TPM) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Response_TPM.Marshal(b, m, deterministic)
+}
+func (m *Response_TPM) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response_TPM.Merge(m, src)
+}
+func (m *Response_TPM) XXX_Size() int {
+	return xxx_messageInfo_Response_TPM.Size(m)
+}
+func (m *Response_TPM) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response_TPM.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Response_TPM proto.InternalMessageInfo
+
+func (m *Response_TPM) GetQuote() []byte {
+	if m != nil {
+		return m.Quote
+	}
+	return nil
+}
+
+func (m *Response_TPM) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Response_TPM) GetEkIntermediates() [][]byte {
+	if m != nil {
+		return m.EkIntermediates
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("magma.orc8r.ChallengeKey_KeyType", ChallengeKey_KeyType_name, ChallengeKey_KeyType_value)
 	proto.RegisterType((*Challenge)(nil), "magma.orc8r.Challenge")
+	proto.RegisterType((*Challenge_TPMCredential)(nil), "magma.orc8r.Challenge.TPMCredential")
 	proto.RegisterType((*ChallengeKey)(nil), "magma.orc8r.ChallengeKey")
 	proto.RegisterType((*Response)(nil), "magma.orc8r.Response")
 	proto.RegisterType((*Response_Echo)(nil), "magma.orc8r.Response.Echo")
 	proto.RegisterType((*Response_RSA)(nil), "magma.orc8r.Response.RSA")
 	proto.RegisterType((*Response_ECDSA)(nil), "magma.orc8r.Response.ECDSA")
+	proto.RegisterType((*Response_TPM)(nil), "magma.orc8r.Response.TPM")
 }
 
 func init() { proto.RegisterFile("orc8r/protos/bootstrapper.proto", fileDescriptor_b592b3c4e9ae6813) }
 
 var fileDescriptor_b592b3c4e9ae6813 = []byte{
-	// 509 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x5d, 0x6f, 0xda, 0x30,
-	0x14, 0x4d, 0x0a, 0x6d, 0xe9, 0x25, 0xad, 0x90, 0xa7, 0x6e, 0x34, 0x30, 0xad, 0x4b, 0x5f, 0xfa,
-	0x14, 0x34, 0xa6, 0x4d, 0x7b, 0x98, 0xa6, 0x85, 0x8f, 0x42, 0xd5, 0x87, 0x4a, 0x4e, 0xa5, 0x49,
-	0x7b, 0x41, 0xa9, 0xb9, 0x0b, 0x11, 0x14, 0x67, 0xb6, 0x11, 0xca, 0x3f, 0xd9, 0x3f, 0xd8, 0xff,
-	0xd8, 0x2f, 0x9b, 0x62, 0x42, 0x42, 0x24, 0xd6, 0x97, 0x3d, 0xc5, 0xf6, 0x39, 0xf7, 0x9c, 0x9b,
-	0x63, 0x5f, 0x78, 0xc3, 0x05, 0xfb, 0x24, 0x3a, 0xb1, 0xe0, 0x8a, 0xcb, 0xce, 0x23, 0xe7, 0x4a,
-	0x2a, 0x11, 0xc4, 0x31, 0x0a, 0x57, 0x9f, 0x91, 0xfa, 0x53, 0x10, 0x3e, 0x05, 0xae, 0xa6, 0xd9,
-	0xed, 0x12, 0x9b, 0xa1, 0x50, 0xd1, 0x8f, 0x68, 0x4b, 0xb5, 0x5b, 0x25, 0x34, 0x9a, 0xe2, 0x52,
-	0x45, 0x2a, 0xd9, 0x80, 0x4e, 0x08, 0x27, 0xfd, 0x59, 0xb0, 0x58, 0xe0, 0x32, 0x44, 0xf2, 0x19,
-	0x6a, 0x73, 0x4c, 0x26, 0x2a, 0x89, 0xb1, 0x69, 0x5e, 0x9a, 0xd7, 0x67, 0xdd, 0xb7, 0xee, 0x8e,
-	0x8f, 0x9b, 0x33, 0xef, 0x30, 0x71, 0xef, 0x30, 0x79, 0x48, 0x62, 0xa4, 0xc7, 0xf3, 0xcd, 0x82,
-	0xb4, 0xe1, 0x84, 0x6d, 0x09, 0xcd, 0x83, 0x4b, 0xf3, 0xda, 0xa2, 0xc5, 0x81, 0xf3, 0xdb, 0x04,
-	0x6b, 0xb7, 0xfe, 0x3f, 0xcd, 0x1a, 0x50, 0x99, 0x63, 0x92, 0xd9, 0xa4, 0x4b, 0x67, 0x04, 0xc7,
-	0x19, 0x8b, 0xd4, 0xa0, 0x3a, 0xec, 0x8f, 0xef, 0x1b, 0x06, 0x79, 0x05, 0x2f, 0xfc, 0xfb, 0x9b,
-	0x87, 0x6f, 0x1e, 0x1d, 0x4e, 0xa8, 0xef, 0x4d, 0xfc, 0xb1, 0xd7, 0xfd, 0xf0, 0xb1, 0x61, 0x92,
-	0x0b, 0x38, 0xcf, 0x81, 0x61, 0x7f, 0x50, 0x40, 0x07, 0xce, 0x9f, 0x0a, 0xd4, 0x28, 0xca, 0x98,
-	0x2f, 0x25, 0x92, 0x77, 0x70, 0x38, 0x5b, 0x4f, 0xa2, 0xa9, 0x6e, 0xb1, 0xde, 0x6d, 0x97, 0x5a,
-	0xf4, 0x18, 0x43, 0x29, 0x47, 0x81, 0xc2, 0x75, 0x90, 0xdc, 0x0e, 0x68, 0x75, 0xb6, 0xbe, 0x9d,
-	0x3e, 0x9f, 0x03, 0xf1, 0xe0, 0x14, 0xd9, 0x8c, 0x4f, 0x44, 0xe6, 0xd0, 0xac, 0x68, 0x61, 0xbb,
-	0x24, 0xbc, 0xb5, 0x77, 0x87, 0x6c, 0xc6, 0xc7, 0x06, 0xb5, 0xd2, 0x92, 0xbc, 0xa7, 0x2f, 0x60,
-	0x09, 0x19, 0x14, 0x0a, 0x55, 0xad, 0x70, 0xb1, 0x5f, 0x81, 0xfa, 0xde, 0xd8, 0xa0, 0x75, 0x21,
-	0x83, 0xbc, 0x7e, 0x00, 0x67, 0xc8, 0xa6, 0xbb, 0x0a, 0x87, 0x5a, 0xa1, 0xf5, 0x8f, 0x1e, 0xd2,
-	0x78, 0xc6, 0x06, 0x3d, 0xd5, 0x45, 0xb9, 0x8a, 0x03, 0x15, 0x26, 0x45, 0xf3, 0x48, 0x97, 0x36,
-	0xca, 0x57, 0xe7, 0x53, 0x9a, 0x82, 0xb6, 0x03, 0xd5, 0xf4, 0x0f, 0x88, 0x0d, 0xb5, 0xdc, 0xcb,
-	0xd4, 0x89, 0xe4, 0x7b, 0xfb, 0x0a, 0x2a, 0xd4, 0xf7, 0xd2, 0xd4, 0x64, 0x14, 0x2e, 0x03, 0xb5,
-	0x12, 0x5b, 0x4e, 0x71, 0x60, 0x5f, 0xc1, 0xa1, 0x6e, 0x83, 0x58, 0x60, 0x8a, 0x0c, 0x36, 0x45,
-	0xba, 0x93, 0x59, 0xc4, 0xa6, 0xec, 0x41, 0xe1, 0xd2, 0xfd, 0x65, 0x82, 0xd5, 0xdb, 0x19, 0x1b,
-	0x72, 0x03, 0xd6, 0x08, 0x55, 0xf1, 0xd6, 0x9f, 0xbd, 0x49, 0xfb, 0xe5, 0xfe, 0xa7, 0xe8, 0x18,
-	0xe4, 0x2b, 0xd4, 0x29, 0xfe, 0x5c, 0xa1, 0x54, 0x7e, 0x14, 0x2e, 0xc9, 0xf9, 0xde, 0xcc, 0xec,
-	0x66, 0xb9, 0x7e, 0x33, 0x91, 0x2c, 0x50, 0xe8, 0x18, 0xbd, 0xd7, 0xdf, 0x5b, 0x1a, 0xec, 0x6c,
-	0xe6, 0x92, 0x2d, 0xf8, 0x6a, 0xda, 0x09, 0x79, 0x36, 0xa0, 0x8f, 0x47, 0xfa, 0xfb, 0xfe, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x97, 0xaa, 0x0d, 0x91, 0x03, 0x04, 0x00, 0x00,
+	// 664 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xda, 0x48,
+	0x14, 0xc6, 0x18, 0x12, 0x72, 0x30, 0x04, 0xcd, 0x26, 0xbb, 0xc4, 0xc9, 0x6a, 0x59, 0xa7, 0x52,
+	0xd3, 0x1b, 0x50, 0xa9, 0x5a, 0xf5, 0xa2, 0xaa, 0x6a, 0x7e, 0x12, 0xa2, 0x08, 0x05, 0x8d, 0x91,
+	0x2a, 0xf5, 0xc6, 0x32, 0xe3, 0x29, 0x58, 0xfc, 0xd8, 0x99, 0x19, 0x14, 0xf9, 0x4d, 0xda, 0xc7,
+	0xe9, 0xdb, 0xf4, 0x31, 0x2a, 0x0f, 0xc6, 0xc6, 0x15, 0x4d, 0x2f, 0x7a, 0x65, 0xcf, 0xf9, 0xbe,
+	0xf3, 0x9d, 0x6f, 0x66, 0xce, 0x1c, 0xf8, 0xcf, 0x67, 0xe4, 0x2d, 0x6b, 0x05, 0xcc, 0x17, 0x3e,
+	0x6f, 0x4d, 0x7c, 0x5f, 0x70, 0xc1, 0x9c, 0x20, 0xa0, 0xac, 0x29, 0x63, 0xa8, 0xbc, 0x74, 0xa6,
+	0x4b, 0xa7, 0x29, 0x69, 0xfa, 0x45, 0x86, 0x4d, 0x28, 0x13, 0xde, 0x67, 0x6f, 0x4b, 0xd5, 0xcf,
+	0x33, 0xa8, 0xe7, 0xd2, 0x95, 0xf0, 0x44, 0xb8, 0x01, 0x8d, 0xaf, 0x79, 0x38, 0xea, 0xce, 0x9c,
+	0xc5, 0x82, 0xae, 0xa6, 0x14, 0xbd, 0x83, 0xd2, 0x9c, 0x86, 0xb6, 0x08, 0x03, 0x5a, 0x57, 0x1a,
+	0xca, 0x55, 0xb5, 0xfd, 0x7f, 0x73, 0xa7, 0x50, 0x33, 0x61, 0xde, 0xd1, 0xb0, 0x79, 0x47, 0xc3,
+	0x71, 0x18, 0x50, 0x7c, 0x38, 0xdf, 0xfc, 0xa0, 0x0b, 0x38, 0x22, 0x5b, 0x42, 0x3d, 0xdf, 0x50,
+	0xae, 0x34, 0x9c, 0x06, 0xd0, 0x1d, 0x54, 0x45, 0xb0, 0xb4, 0x09, 0xa3, 0xd2, 0x81, 0xb3, 0xa8,
+	0xab, 0x0d, 0xe5, 0xaa, 0xdc, 0x7e, 0xb6, 0xbf, 0x42, 0x73, 0x3c, 0x1a, 0x76, 0x13, 0x2e, 0xae,
+	0x88, 0x60, 0x99, 0x2e, 0x75, 0x02, 0x95, 0x0c, 0x8e, 0x9e, 0xc3, 0x71, 0xaa, 0x6c, 0x4f, 0x16,
+	0xfe, 0x44, 0x6e, 0x40, 0xc3, 0xd5, 0x34, 0xdc, 0x59, 0xf8, 0x13, 0xf4, 0x02, 0x6a, 0x74, 0x45,
+	0x58, 0x18, 0x08, 0xea, 0xda, 0x9c, 0x12, 0x46, 0x45, 0xec, 0xf5, 0x38, 0x89, 0x5b, 0x32, 0x6c,
+	0x7c, 0x53, 0x40, 0xdb, 0xdd, 0xf1, 0x1f, 0x1e, 0x4f, 0x0d, 0xd4, 0x39, 0x0d, 0xe3, 0x62, 0xd1,
+	0xaf, 0xe1, 0xc0, 0x61, 0xcc, 0x42, 0x25, 0x28, 0xf4, 0xbb, 0x83, 0xfb, 0x5a, 0x0e, 0xfd, 0x03,
+	0x7f, 0x59, 0xf7, 0xd7, 0xe3, 0x8f, 0x26, 0xee, 0xdb, 0xd8, 0x32, 0x6d, 0x6b, 0x60, 0xb6, 0x5f,
+	0xbf, 0xa9, 0x29, 0xe8, 0x0c, 0x4e, 0x13, 0xa0, 0xdf, 0xed, 0xa5, 0x50, 0x1e, 0xd5, 0xe1, 0x64,
+	0x60, 0xe2, 0x9e, 0x84, 0xc6, 0xa3, 0x61, 0x7b, 0x8b, 0xa8, 0xc6, 0xf7, 0x02, 0x94, 0x30, 0xe5,
+	0x81, 0xbf, 0xe2, 0x14, 0xbd, 0x84, 0xe2, 0xec, 0xd1, 0xf6, 0x5c, 0x69, 0xbe, 0xdc, 0xbe, 0xc8,
+	0x98, 0x37, 0x09, 0xa1, 0x9c, 0xdf, 0x38, 0x82, 0x3e, 0x3a, 0xe1, 0x6d, 0x0f, 0x17, 0x66, 0x8f,
+	0xb7, 0xee, 0x6f, 0xee, 0xd4, 0x84, 0x0a, 0x25, 0x33, 0xdf, 0x66, 0x71, 0x85, 0xf8, 0x4a, 0xf5,
+	0x8c, 0xf0, 0xb6, 0x7c, 0xb3, 0x4f, 0x66, 0xfe, 0x20, 0x87, 0xb5, 0x28, 0x25, 0xf1, 0xf4, 0x1e,
+	0x34, 0xc6, 0x9d, 0x54, 0xa1, 0x20, 0x15, 0xce, 0xf6, 0x2b, 0x60, 0xcb, 0x1c, 0xe4, 0x70, 0x99,
+	0x71, 0x27, 0xc9, 0xef, 0x41, 0x95, 0x12, 0x77, 0x57, 0xa1, 0x28, 0x15, 0xce, 0x7f, 0xe1, 0x21,
+	0x3a, 0xb8, 0x41, 0x0e, 0x57, 0x64, 0xd2, 0xae, 0x8b, 0xa8, 0x39, 0x13, 0x8d, 0xc3, 0xa7, 0x5c,
+	0x8c, 0x47, 0xc3, 0xc8, 0x85, 0x08, 0x96, 0x49, 0xbe, 0x01, 0x2a, 0xe1, 0xac, 0x7e, 0x20, 0xd3,
+	0x6a, 0xd9, 0xa6, 0xb0, 0x30, 0x8e, 0x40, 0xdd, 0x80, 0x42, 0x74, 0x02, 0x48, 0x87, 0x52, 0x52,
+	0x67, 0xd3, 0xa3, 0xc9, 0x5a, 0xbf, 0x04, 0x15, 0x5b, 0x66, 0x74, 0xea, 0xdc, 0x9b, 0xae, 0x1c,
+	0xb1, 0x66, 0x5b, 0x4e, 0x1a, 0xd0, 0x2f, 0xa1, 0x28, 0xb7, 0x81, 0x34, 0x50, 0x58, 0x0c, 0x2b,
+	0x2c, 0x5a, 0xf1, 0xf8, 0x8a, 0x14, 0xae, 0xbb, 0xa0, 0x8e, 0x47, 0x43, 0x74, 0x02, 0xc5, 0x87,
+	0xb5, 0x2f, 0xb6, 0x2a, 0x9b, 0x45, 0x56, 0x3f, 0xff, 0x93, 0xbe, 0x7c, 0x22, 0x73, 0xdb, 0x5b,
+	0x09, 0xca, 0x96, 0xd4, 0xf5, 0x1c, 0x41, 0x79, 0x5d, 0x6d, 0xa8, 0xf2, 0x89, 0xcc, 0x6f, 0x77,
+	0xc3, 0x1d, 0x48, 0xf7, 0xd2, 0xfe, 0xa2, 0x80, 0xd6, 0xd9, 0x99, 0x54, 0xe8, 0x1a, 0xb4, 0x1b,
+	0x2a, 0xd2, 0xe9, 0xf2, 0x64, 0xbf, 0xe9, 0x7f, 0xef, 0x7f, 0x4a, 0x46, 0x0e, 0x7d, 0x80, 0x32,
+	0xa6, 0x0f, 0x6b, 0xca, 0x85, 0xe5, 0x4d, 0x57, 0xe8, 0x74, 0xef, 0xad, 0xe8, 0xf5, 0x6c, 0xfe,
+	0x66, 0x08, 0x12, 0x47, 0x50, 0x23, 0xd7, 0xf9, 0xf7, 0xd3, 0xb9, 0x04, 0x5b, 0x9b, 0x51, 0x48,
+	0x16, 0xfe, 0xda, 0x6d, 0x4d, 0xfd, 0x78, 0x26, 0x4e, 0x0e, 0xe4, 0xf7, 0xd5, 0x8f, 0x01, 0x00,
+	0x56, 0xc0, 0xfc, 0x8d, 0x76, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
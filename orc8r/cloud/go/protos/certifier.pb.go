@@ -213,7 +213,10 @@ func (m *Certificate_SN) GetSn() string {
 }
 
 type CACert struct {
-	Cert                 []byte   `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"`
+	Cert []byte `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"`
+	// all trusted CA certificates of the cert type in DER encoding, newest
+	// first. Includes cert.
+	Bundle               [][]byte `protobuf:"bytes,2,rep,name=bundle,proto3" json:"bundle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -251,6 +254,13 @@ func (m *CACert) GetCert() []byte {
 	return nil
 }
 
+func (m *CACert) GetBundle() [][]byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("magma.orc8r.CertType", CertType_name, CertType_value)
 	proto.RegisterType((*CSR)(nil), "magma.orc8r.CSR")
@@ -262,30 +272,31 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/certifier.proto", fileDescriptor_309897dc79f61bc0) }
 
 var fileDescriptor_309897dc79f61bc0 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
+	// 409 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x5d, 0xab, 0xd3, 0x30,
-	0x18, 0xc7, 0x6d, 0x37, 0xd6, 0xf5, 0xe9, 0xe1, 0x70, 0x08, 0x8a, 0x3d, 0xdd, 0x51, 0xcb, 0x40,
-	0x18, 0x0a, 0x29, 0x1c, 0x2f, 0x3c, 0x5e, 0x6e, 0xad, 0x82, 0x20, 0x43, 0xb2, 0xea, 0x85, 0x37,
-	0xa5, 0x6b, 0xd3, 0x12, 0x58, 0x9b, 0x92, 0x66, 0xc2, 0x3e, 0x97, 0x1f, 0xc8, 0xaf, 0x22, 0x49,
-	0x53, 0xd1, 0x0d, 0xce, 0x55, 0x93, 0x3e, 0xbf, 0xf0, 0x7f, 0xe1, 0x81, 0x3b, 0x2e, 0x8a, 0x07,
-	0x11, 0x75, 0x82, 0x4b, 0xde, 0x47, 0x05, 0x15, 0x92, 0x55, 0x8c, 0x0a, 0xac, 0x7f, 0x20, 0xaf,
-	0xc9, 0xeb, 0x26, 0xc7, 0x9a, 0x09, 0x16, 0xff, 0xa1, 0xac, 0xa4, 0xad, 0x64, 0xf2, 0x34, 0x90,
-	0xc1, 0xab, 0x9a, 0xf3, 0xfa, 0x40, 0x87, 0xe9, 0xfe, 0x58, 0x45, 0x92, 0x35, 0xb4, 0x97, 0x79,
-	0xd3, 0x19, 0xe0, 0xe5, 0x39, 0x50, 0x1e, 0x45, 0x2e, 0x19, 0x6f, 0x87, 0xf9, 0xf2, 0x97, 0x05,
-	0x93, 0x78, 0x47, 0xd0, 0x6b, 0xb0, 0x59, 0xe9, 0x5b, 0xa1, 0xb5, 0xf2, 0xee, 0x9f, 0xe1, 0x7f,
-	0xf4, 0xf1, 0x67, 0xa3, 0x48, 0x6c, 0x56, 0xa2, 0x07, 0x80, 0x9f, 0xf9, 0x81, 0x95, 0x99, 0xd2,
-	0xf1, 0x6d, 0x8d, 0xdf, 0xe2, 0x41, 0x03, 0x8f, 0x1a, 0x38, 0x31, 0x1a, 0xc4, 0xd5, 0x70, 0xca,
-	0x1a, 0x8a, 0x9e, 0x83, 0x53, 0xf4, 0x22, 0x2b, 0xa9, 0xf0, 0x27, 0xa1, 0xb5, 0xba, 0x22, 0xb3,
-	0xa2, 0x17, 0x09, 0x15, 0xe8, 0x1e, 0x5c, 0x95, 0x3f, 0x93, 0xa7, 0x8e, 0xfa, 0xd3, 0xd0, 0x5a,
-	0x5d, 0x9f, 0x19, 0x88, 0xa9, 0x90, 0xe9, 0xa9, 0xa3, 0x64, 0x5e, 0x98, 0xd3, 0xf2, 0xb7, 0x05,
-	0x5e, 0x3c, 0x94, 0x56, 0xe4, 0x92, 0xa2, 0xb7, 0x60, 0xf7, 0xad, 0x71, 0xbf, 0xb8, 0x78, 0x6c,
-	0x28, 0xbc, 0xdb, 0x12, 0xbb, 0x6f, 0xd1, 0x07, 0x80, 0x96, 0xcb, 0x6c, 0x4f, 0x2b, 0x2e, 0xc6,
-	0x0c, 0xc1, 0x45, 0x86, 0x74, 0x2c, 0x92, 0xb8, 0x2d, 0x97, 0x1b, 0x0d, 0xa3, 0xf7, 0xa0, 0x2e,
-	0x59, 0x5e, 0x49, 0x13, 0xe3, 0xf1, 0x97, 0xf3, 0x96, 0xcb, 0xb5, 0x62, 0xd1, 0x2d, 0x68, 0xf3,
-	0x3a, 0xfe, 0x54, 0xc7, 0x77, 0xd4, 0x3d, 0xa1, 0x22, 0x78, 0x0a, 0xf6, 0x6e, 0x8b, 0xae, 0xff,
-	0x26, 0x70, 0x95, 0xc9, 0xe5, 0x1d, 0xcc, 0xe2, 0xb5, 0x32, 0x8f, 0x10, 0x4c, 0x15, 0xaa, 0x67,
-	0x57, 0x44, 0x9f, 0xdf, 0x84, 0x30, 0x1f, 0x5b, 0x41, 0x1e, 0x38, 0xc9, 0xc7, 0x4f, 0xeb, 0x6f,
-	0x5f, 0xd2, 0x9b, 0x27, 0xc8, 0x81, 0xc9, 0xf7, 0xaf, 0xdb, 0x1b, 0x6b, 0xf3, 0xe2, 0xc7, 0x42,
-	0xd7, 0x10, 0x0d, 0xdb, 0x53, 0x1c, 0xf8, 0xb1, 0x8c, 0x6a, 0x6e, 0xd6, 0x68, 0x3f, 0xd3, 0xdf,
-	0x77, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf5, 0xa8, 0xb0, 0xa6, 0x88, 0x02, 0x00, 0x00,
+	0x18, 0xc7, 0x6d, 0x37, 0xf6, 0xf2, 0xec, 0x70, 0x38, 0x04, 0x5f, 0x76, 0x36, 0x5f, 0xc6, 0x40,
+	0x18, 0x0a, 0x29, 0x1c, 0x05, 0x8f, 0x97, 0x3b, 0xad, 0x82, 0x20, 0x43, 0xb2, 0xea, 0x85, 0x37,
+	0xa5, 0x6d, 0xd2, 0x12, 0x68, 0x93, 0x92, 0xa6, 0xc2, 0x3e, 0x97, 0x1f, 0xc8, 0xaf, 0x22, 0x49,
+	0x53, 0xd1, 0x33, 0xf0, 0xaa, 0x79, 0xfa, 0xfc, 0xc2, 0xff, 0x85, 0xc0, 0x53, 0xa9, 0xf2, 0x5b,
+	0x15, 0x34, 0x4a, 0x6a, 0xd9, 0x06, 0x39, 0x53, 0x9a, 0x17, 0x9c, 0x29, 0x6c, 0x7f, 0xa0, 0x45,
+	0x9d, 0x96, 0x75, 0x8a, 0x2d, 0xb3, 0x5a, 0xff, 0x83, 0x72, 0xca, 0x84, 0xe6, 0xfa, 0xd4, 0x93,
+	0xab, 0x17, 0xa5, 0x94, 0x65, 0xc5, 0xfa, 0x6d, 0xd6, 0x15, 0x81, 0xe6, 0x35, 0x6b, 0x75, 0x5a,
+	0x37, 0x0e, 0x78, 0x7e, 0x1f, 0xa0, 0x9d, 0x4a, 0x35, 0x97, 0xa2, 0xdf, 0x6f, 0x7f, 0x7a, 0x30,
+	0x0a, 0x8f, 0x04, 0xbd, 0x04, 0x9f, 0xd3, 0xa5, 0xb7, 0xf1, 0x76, 0x8b, 0x9b, 0x47, 0xf8, 0x2f,
+	0x7d, 0xfc, 0xc9, 0x29, 0x12, 0x9f, 0x53, 0x74, 0x0b, 0xf0, 0x23, 0xad, 0x38, 0x4d, 0x8c, 0xce,
+	0xd2, 0xb7, 0xf8, 0x35, 0xee, 0x35, 0xf0, 0xa0, 0x81, 0x23, 0xa7, 0x41, 0xe6, 0x16, 0x8e, 0x79,
+	0xcd, 0xd0, 0x13, 0x98, 0xe6, 0xad, 0x4a, 0x28, 0x53, 0xcb, 0xd1, 0xc6, 0xdb, 0x5d, 0x90, 0x49,
+	0xde, 0xaa, 0x88, 0x29, 0x74, 0x03, 0x73, 0x93, 0x3f, 0xd1, 0xa7, 0x86, 0x2d, 0xc7, 0x1b, 0x6f,
+	0x77, 0x79, 0xcf, 0x40, 0xc8, 0x94, 0x8e, 0x4f, 0x0d, 0x23, 0xb3, 0xdc, 0x9d, 0xb6, 0xbf, 0x3c,
+	0x58, 0x84, 0x7d, 0x69, 0x79, 0xaa, 0x19, 0x7a, 0x0d, 0x7e, 0x2b, 0x9c, 0xfb, 0xf5, 0xd9, 0x65,
+	0x47, 0xe1, 0xe3, 0x81, 0xf8, 0xad, 0x40, 0xef, 0x01, 0x84, 0xd4, 0x49, 0xc6, 0x0a, 0xa9, 0x86,
+	0x0c, 0xab, 0xb3, 0x0c, 0xf1, 0x50, 0x24, 0x99, 0x0b, 0xa9, 0xef, 0x2c, 0x8c, 0xde, 0x81, 0x19,
+	0x92, 0xb4, 0xd0, 0x2e, 0xc6, 0xff, 0x6f, 0xce, 0x84, 0xd4, 0x7b, 0xc3, 0xa2, 0x6b, 0xb0, 0xe6,
+	0x6d, 0xfc, 0xb1, 0x8d, 0x3f, 0x35, 0x73, 0xc4, 0xd4, 0xea, 0x21, 0xf8, 0xc7, 0x03, 0xba, 0xfc,
+	0x93, 0x60, 0x6e, 0x4c, 0x6e, 0xdf, 0xc2, 0x24, 0xdc, 0x1b, 0xf3, 0x08, 0xc1, 0xd8, 0xa0, 0x76,
+	0x77, 0x41, 0xec, 0x19, 0x3d, 0x86, 0x49, 0xd6, 0x09, 0x5a, 0x19, 0xfb, 0x23, 0xd3, 0x65, 0x3f,
+	0xbd, 0xda, 0xc0, 0x6c, 0x68, 0x0b, 0x2d, 0x60, 0x1a, 0x7d, 0xf8, 0xb8, 0xff, 0xfa, 0x39, 0xbe,
+	0x7a, 0x80, 0xa6, 0x30, 0xfa, 0xf6, 0xe5, 0x70, 0xe5, 0xdd, 0x3d, 0xfb, 0xbe, 0xb6, 0xf5, 0x04,
+	0xfd, 0xab, 0xca, 0x2b, 0xd9, 0xd1, 0xa0, 0x94, 0xee, 0x79, 0x65, 0x13, 0xfb, 0x7d, 0xf3, 0x7b,
+	0x00, 0xe1, 0x1a, 0xe6, 0xca, 0xa0, 0x02, 0x00, 0x00,
 }
@@ -254,6 +254,227 @@ func (m *UpdateDirectoryLocationRequest) GetTable() TableID {
 	return TableID_IMSI_TO_HWID
 }
 
+type GetLocationHistoryRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Table                TableID  `protobuf:"varint,2,opt,name=table,proto3,enum=magma.orc8r.TableID" json:"table,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLocationHistoryRequest) Reset()         { *m = GetLocationHistoryRequest{} }
+func (m *GetLocationHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLocationHistoryRequest) ProtoMessage()    {}
+func (*GetLocationHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f02336ef077163fd, []int{4}
+}
+
+func (m *GetLocationHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLocationHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetLocationHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLocationHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLocationHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLocationHistoryRequest.Merge(m, src)
+}
+func (m *GetLocationHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLocationHistoryRequest.Size(m)
+}
+func (m *GetLocationHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLocationHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLocationHistoryRequest proto.InternalMessageInfo
+
+func (m *GetLocationHistoryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetLocationHistoryRequest) GetTable() TableID {
+	if m != nil {
+		return m.Table
+	}
+	return TableID_IMSI_TO_HWID
+}
+
+type LocationHistoryEntry struct {
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// Unix time in milliseconds at which the object moved to the location
+	TimeMs               int64    `protobuf:"varint,2,opt,name=timeMs,proto3" json:"timeMs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocationHistoryEntry) Reset()         { *m = LocationHistoryEntry{} }
+func (m *LocationHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*LocationHistoryEntry) ProtoMessage()    {}
+func (*LocationHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f02336ef077163fd, []int{5}
+}
+
+func (m *LocationHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocationHistoryEntry.Unmarshal(m, b)
+}
+func (m *LocationHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocationHistoryEntry.Marshal(b, m, deterministic)
+}
+func (m *LocationHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocationHistoryEntry.Merge(m, src)
+}
+func (m *LocationHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_LocationHistoryEntry.Size(m)
+}
+func (m *LocationHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocationHistoryEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocationHistoryEntry proto.InternalMessageInfo
+
+func (m *LocationHistoryEntry) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *LocationHistoryEntry) GetTimeMs() int64 {
+	if m != nil {
+		return m.TimeMs
+	}
+	return 0
+}
+
+type LocationHistory struct {
+	// Most recent first
+	Entries              []*LocationHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *LocationHistory) Reset()         { *m = LocationHistory{} }
+func (m *LocationHistory) String() string { return proto.CompactTextString(m) }
+func (*LocationHistory) ProtoMessage()    {}
+func (*LocationHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f02336ef077163fd, []int{6}
+}
+
+func (m *LocationHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocationHistory.Unmarshal(m, b)
+}
+func (m *LocationHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocationHistory.Marshal(b, m, deterministic)
+}
+func (m *LocationHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocationHistory.Merge(m, src)
+}
+func (m *LocationHistory) XXX_Size() int {
+	return xxx_messageInfo_LocationHistory.Size(m)
+}
+func (m *LocationHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocationHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocationHistory proto.InternalMessageInfo
+
+func (m *LocationHistory) GetEntries() []*LocationHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type GetIDsByLocationRequest struct {
+	Location             string   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Table                TableID  `protobuf:"varint,2,opt,name=table,proto3,enum=magma.orc8r.TableID" json:"table,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetIDsByLocationRequest) Reset()         { *m = GetIDsByLocationRequest{} }
+func (m *GetIDsByLocationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetIDsByLocationRequest) ProtoMessage()    {}
+func (*GetIDsByLocationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f02336ef077163fd, []int{7}
+}
+
+func (m *GetIDsByLocationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIDsByLocationRequest.Unmarshal(m, b)
+}
+func (m *GetIDsByLocationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIDsByLocationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetIDsByLocationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIDsByLocationRequest.Merge(m, src)
+}
+func (m *GetIDsByLocationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetIDsByLocationRequest.Size(m)
+}
+func (m *GetIDsByLocationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIDsByLocationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIDsByLocationRequest proto.InternalMessageInfo
+
+func (m *GetIDsByLocationRequest) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *GetIDsByLocationRequest) GetTable() TableID {
+	if m != nil {
+		return m.Table
+	}
+	return TableID_IMSI_TO_HWID
+}
+
+type RecordIDs struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordIDs) Reset()         { *m = RecordIDs{} }
+func (m *RecordIDs) String() string { return proto.CompactTextString(m) }
+func (*RecordIDs) ProtoMessage()    {}
+func (*RecordIDs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f02336ef077163fd, []int{8}
+}
+
+func (m *RecordIDs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordIDs.Unmarshal(m, b)
+}
+func (m *RecordIDs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordIDs.Marshal(b, m, deterministic)
+}
+func (m *RecordIDs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordIDs.Merge(m, src)
+}
+func (m *RecordIDs) XXX_Size() int {
+	return xxx_messageInfo_RecordIDs.Size(m)
+}
+func (m *RecordIDs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordIDs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordIDs proto.InternalMessageInfo
+
+func (m *RecordIDs) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
 type UpdateRecordRequest struct {
 	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Location             string            `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
@@ -267,7 +488,7 @@ func (m *UpdateRecordRequest) Reset()         { *m = UpdateRecordRequest{} }
 func (m *UpdateRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRecordRequest) ProtoMessage()    {}
 func (*UpdateRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{4}
+	return fileDescriptor_f02336ef077163fd, []int{9}
 }
 
 func (m *UpdateRecordRequest) XXX_Unmarshal(b []byte) error {
@@ -321,7 +542,7 @@ func (m *DirectoryField) Reset()         { *m = DirectoryField{} }
 func (m *DirectoryField) String() string { return proto.CompactTextString(m) }
 func (*DirectoryField) ProtoMessage()    {}
 func (*DirectoryField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{5}
+	return fileDescriptor_f02336ef077163fd, []int{10}
 }
 
 func (m *DirectoryField) XXX_Unmarshal(b []byte) error {
@@ -367,7 +588,7 @@ func (m *DeleteRecordRequest) Reset()         { *m = DeleteRecordRequest{} }
 func (m *DeleteRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRecordRequest) ProtoMessage()    {}
 func (*DeleteRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{6}
+	return fileDescriptor_f02336ef077163fd, []int{11}
 }
 
 func (m *DeleteRecordRequest) XXX_Unmarshal(b []byte) error {
@@ -407,7 +628,7 @@ func (m *GetDirectoryFieldRequest) Reset()         { *m = GetDirectoryFieldReque
 func (m *GetDirectoryFieldRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDirectoryFieldRequest) ProtoMessage()    {}
 func (*GetDirectoryFieldRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{7}
+	return fileDescriptor_f02336ef077163fd, []int{12}
 }
 
 func (m *GetDirectoryFieldRequest) XXX_Unmarshal(b []byte) error {
@@ -455,7 +676,7 @@ func (m *DirectoryRecord) Reset()         { *m = DirectoryRecord{} }
 func (m *DirectoryRecord) String() string { return proto.CompactTextString(m) }
 func (*DirectoryRecord) ProtoMessage()    {}
 func (*DirectoryRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{8}
+	return fileDescriptor_f02336ef077163fd, []int{13}
 }
 
 func (m *DirectoryRecord) XXX_Unmarshal(b []byte) error {
@@ -508,7 +729,7 @@ func (m *AllDirectoryRecords) Reset()         { *m = AllDirectoryRecords{} }
 func (m *AllDirectoryRecords) String() string { return proto.CompactTextString(m) }
 func (*AllDirectoryRecords) ProtoMessage()    {}
 func (*AllDirectoryRecords) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f02336ef077163fd, []int{9}
+	return fileDescriptor_f02336ef077163fd, []int{14}
 }
 
 func (m *AllDirectoryRecords) XXX_Unmarshal(b []byte) error {
@@ -542,6 +763,11 @@ func init() {
 	proto.RegisterType((*DeleteLocationRequest)(nil), "magma.orc8r.DeleteLocationRequest")
 	proto.RegisterType((*LocationRecord)(nil), "magma.orc8r.LocationRecord")
 	proto.RegisterType((*UpdateDirectoryLocationRequest)(nil), "magma.orc8r.UpdateDirectoryLocationRequest")
+	proto.RegisterType((*GetLocationHistoryRequest)(nil), "magma.orc8r.GetLocationHistoryRequest")
+	proto.RegisterType((*LocationHistoryEntry)(nil), "magma.orc8r.LocationHistoryEntry")
+	proto.RegisterType((*LocationHistory)(nil), "magma.orc8r.LocationHistory")
+	proto.RegisterType((*GetIDsByLocationRequest)(nil), "magma.orc8r.GetIDsByLocationRequest")
+	proto.RegisterType((*RecordIDs)(nil), "magma.orc8r.RecordIDs")
 	proto.RegisterType((*UpdateRecordRequest)(nil), "magma.orc8r.UpdateRecordRequest")
 	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.UpdateRecordRequest.FieldsEntry")
 	proto.RegisterType((*DirectoryField)(nil), "magma.orc8r.DirectoryField")
@@ -555,47 +781,54 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/directoryd.proto", fileDescriptor_f02336ef077163fd) }
 
 var fileDescriptor_f02336ef077163fd = []byte{
-	// 630 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x41, 0x6f, 0xd3, 0x4c,
-	0x10, 0x8d, 0x6d, 0xb5, 0xfd, 0x32, 0xa9, 0x5c, 0x77, 0xdb, 0x0f, 0x8c, 0xdb, 0x42, 0xb4, 0x52,
-	0xa5, 0x50, 0xaa, 0x44, 0xa4, 0x12, 0x2a, 0x9c, 0x28, 0x72, 0x48, 0x2d, 0x08, 0x95, 0xdc, 0x00,
-	0x82, 0x4b, 0xe4, 0xda, 0x4b, 0xb1, 0xea, 0x64, 0x5b, 0x7b, 0xd3, 0x2a, 0x37, 0xfe, 0x02, 0x7f,
-	0x89, 0x1b, 0x67, 0xfe, 0x10, 0xf2, 0xda, 0x49, 0xbc, 0x8e, 0x93, 0x70, 0x40, 0x9c, 0x62, 0x4f,
-	0x9e, 0xdf, 0x7b, 0xf3, 0x3c, 0xb3, 0x86, 0x3d, 0x1a, 0xba, 0xc7, 0x61, 0xe3, 0x3a, 0xa4, 0x8c,
-	0x46, 0x0d, 0xcf, 0x0f, 0x89, 0xcb, 0x68, 0x38, 0xf2, 0xea, 0xbc, 0x82, 0x2a, 0x7d, 0xe7, 0xb2,
-	0xef, 0xd4, 0x39, 0xc8, 0x78, 0x20, 0x60, 0x5d, 0xda, 0xef, 0xd3, 0x41, 0x82, 0xc3, 0x03, 0x40,
-	0x6d, 0xc2, 0xde, 0x52, 0xd7, 0x61, 0x3e, 0x1d, 0xd8, 0xe4, 0x66, 0x48, 0x22, 0x86, 0x54, 0x90,
-	0x7d, 0x4f, 0x97, 0xaa, 0x52, 0xad, 0x6c, 0xcb, 0xbe, 0x87, 0x0e, 0x60, 0x85, 0x39, 0x17, 0x01,
-	0xd1, 0xe5, 0xaa, 0x54, 0x53, 0x9b, 0xdb, 0xf5, 0x0c, 0x7b, 0xbd, 0x1b, 0xff, 0x63, 0x99, 0x76,
-	0x02, 0x41, 0xbb, 0x50, 0x1e, 0x10, 0x76, 0x47, 0xc3, 0x2b, 0xcb, 0xd4, 0x15, 0x4e, 0x31, 0x2d,
-	0xe0, 0x1b, 0xf8, 0xdf, 0x24, 0x01, 0x61, 0xe4, 0xdf, 0x49, 0x1e, 0x82, 0x3a, 0x15, 0x73, 0x69,
-	0xe8, 0x21, 0x03, 0xfe, 0x0b, 0xd2, 0x4a, 0xaa, 0x38, 0xb9, 0xc7, 0xdf, 0x25, 0x78, 0xf8, 0xfe,
-	0xda, 0x73, 0x18, 0x31, 0xc7, 0x99, 0x2e, 0xb3, 0x7a, 0x04, 0xab, 0x21, 0x27, 0xe6, 0x5e, 0x2b,
-	0xcd, 0x1d, 0xc1, 0xab, 0xa8, 0x6d, 0xa7, 0xd0, 0x69, 0x7f, 0xca, 0xd2, 0xfe, 0xf0, 0x0f, 0x09,
-	0xb6, 0x12, 0x4f, 0x29, 0xc9, 0x1c, 0x23, 0xd9, 0xbe, 0x64, 0xb1, 0x2f, 0x64, 0xc2, 0xea, 0x17,
-	0x9f, 0x04, 0x5e, 0xa4, 0x2b, 0x55, 0xa5, 0x56, 0x69, 0x1e, 0x0a, 0x82, 0x05, 0xec, 0xf5, 0xd7,
-	0x1c, 0xde, 0x1a, 0xb0, 0x70, 0x64, 0xa7, 0xcf, 0x1a, 0xcf, 0xa1, 0x92, 0x29, 0x23, 0x0d, 0x94,
-	0x2b, 0x32, 0x4a, 0x1d, 0xc4, 0x97, 0x68, 0x1b, 0x56, 0x6e, 0x9d, 0x60, 0x48, 0x52, 0xfd, 0xe4,
-	0xe6, 0x85, 0x7c, 0x2c, 0xe1, 0x63, 0x50, 0x27, 0x89, 0x72, 0x8e, 0x3f, 0x7d, 0x1a, 0xef, 0xc3,
-	0x56, 0x32, 0x33, 0x0b, 0xbb, 0xc7, 0x6d, 0xd0, 0xdb, 0x84, 0x89, 0x1a, 0xf3, 0x92, 0xda, 0x81,
-	0x32, 0xef, 0xa8, 0x17, 0x1b, 0x48, 0xa3, 0xe2, 0x85, 0x37, 0x64, 0x84, 0x7f, 0x4a, 0xb0, 0x31,
-	0xa1, 0x49, 0x47, 0x26, 0x4f, 0xf0, 0x18, 0xb4, 0x71, 0xb4, 0xbd, 0xaf, 0x7e, 0x14, 0x23, 0x75,
-	0xb9, 0xaa, 0xd4, 0xca, 0xf6, 0xc6, 0xb8, 0x7e, 0x9a, 0x94, 0xd1, 0xcb, 0x5c, 0xf2, 0x35, 0x21,
-	0xf9, 0x9c, 0xd0, 0xdf, 0x4e, 0xbd, 0x03, 0x5b, 0x27, 0x41, 0x90, 0x13, 0x89, 0xd0, 0x33, 0x58,
-	0x4b, 0xe6, 0x30, 0xd2, 0x25, 0x6e, 0x6a, 0x77, 0x91, 0x29, 0x7b, 0x0c, 0x3e, 0x78, 0x0a, 0x6b,
-	0xe9, 0x6c, 0x22, 0x0d, 0xd6, 0xad, 0xce, 0xb9, 0xd5, 0xeb, 0x9e, 0xf5, 0x4e, 0x3f, 0x5a, 0xa6,
-	0x56, 0x42, 0xdb, 0xa0, 0xc5, 0x57, 0xbc, 0x72, 0x76, 0xde, 0x7d, 0x77, 0xd2, 0x69, 0x69, 0x52,
-	0xf3, 0x9b, 0x0c, 0xda, 0x84, 0xef, 0x9c, 0x84, 0xb7, 0xbe, 0x4b, 0x50, 0x07, 0x2a, 0x99, 0x63,
-	0x07, 0x3d, 0x12, 0xd4, 0x67, 0x0f, 0x24, 0x63, 0xd1, 0x4a, 0xe1, 0x12, 0xb2, 0x41, 0x4d, 0x26,
-	0x78, 0xc2, 0xf8, 0xa4, 0x60, 0xbc, 0xe7, 0x2d, 0xb4, 0xb1, 0x29, 0x80, 0x3f, 0x50, 0x3f, 0xe6,
-	0xb4, 0x40, 0x15, 0x4f, 0x2a, 0x84, 0xc5, 0x8c, 0x8a, 0x8e, 0xb1, 0x42, 0xaa, 0xe6, 0x2f, 0x19,
-	0xee, 0xb7, 0x1d, 0x46, 0xee, 0x9c, 0xd1, 0x4c, 0x12, 0x2d, 0x58, 0xcf, 0x2e, 0x1f, 0xaa, 0x2e,
-	0xdb, 0xcb, 0x62, 0xb7, 0x2d, 0x58, 0xcf, 0xee, 0x48, 0x8e, 0xa6, 0x60, 0x7d, 0x8a, 0x69, 0x3e,
-	0xc1, 0xe6, 0xcc, 0x0e, 0xa1, 0xfd, 0xfc, 0xdb, 0x29, 0xdc, 0xb1, 0xdc, 0x3b, 0x12, 0x31, 0xb8,
-	0x84, 0xce, 0xe0, 0x5e, 0x9b, 0xb0, 0xa2, 0x61, 0x9c, 0x75, 0x62, 0x88, 0xf6, 0x0b, 0x1e, 0xc2,
-	0xa5, 0x57, 0x7b, 0x9f, 0x77, 0x38, 0xa8, 0x91, 0x7c, 0xdd, 0xdc, 0x80, 0x0e, 0xbd, 0xc6, 0x25,
-	0x4d, 0x3f, 0x73, 0x17, 0xab, 0xfc, 0xf7, 0xe8, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x46, 0x94,
-	0x46, 0x01, 0x29, 0x07, 0x00, 0x00,
+	// 750 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x6f, 0x4f, 0xd3, 0x5e,
+	0x14, 0x5e, 0xdb, 0x30, 0x7e, 0x3b, 0x23, 0xa3, 0x5c, 0xf6, 0x83, 0x52, 0x40, 0xe7, 0x8d, 0x98,
+	0x89, 0x64, 0x8b, 0x23, 0x31, 0xa8, 0x6f, 0x84, 0x74, 0x8e, 0xaa, 0x83, 0xa4, 0xa0, 0x44, 0x63,
+	0xb2, 0x94, 0xf6, 0x8a, 0x0d, 0xdd, 0x0a, 0xed, 0x05, 0xb2, 0x8f, 0xe1, 0x57, 0xf1, 0x23, 0xf8,
+	0xce, 0xd7, 0x7e, 0x21, 0xd3, 0xdb, 0x6e, 0xf4, 0x76, 0xdd, 0xa6, 0x09, 0xf1, 0xd5, 0xda, 0xb3,
+	0x73, 0x9f, 0xf3, 0x9c, 0xe7, 0xfc, 0xb9, 0x85, 0x75, 0xcf, 0xb7, 0x76, 0xfc, 0xfa, 0x85, 0xef,
+	0x51, 0x2f, 0xa8, 0xdb, 0x8e, 0x4f, 0x2c, 0xea, 0xf9, 0x7d, 0xbb, 0xc6, 0x2c, 0xa8, 0xd8, 0x35,
+	0xcf, 0xba, 0x66, 0x8d, 0x39, 0xa9, 0x2b, 0x9c, 0xaf, 0xe5, 0x75, 0xbb, 0x5e, 0x2f, 0xf2, 0xc3,
+	0x3d, 0x40, 0x2d, 0x42, 0xdf, 0x79, 0x96, 0x49, 0x1d, 0xaf, 0x67, 0x90, 0xcb, 0x2b, 0x12, 0x50,
+	0x54, 0x02, 0xd1, 0xb1, 0x15, 0xa1, 0x22, 0x54, 0x0b, 0x86, 0xe8, 0xd8, 0x68, 0x13, 0x66, 0xa8,
+	0x79, 0xea, 0x12, 0x45, 0xac, 0x08, 0xd5, 0x52, 0xa3, 0x5c, 0x4b, 0xa0, 0xd7, 0x8e, 0xc3, 0x7f,
+	0x74, 0xcd, 0x88, 0x5c, 0xd0, 0x1a, 0x14, 0x7a, 0x84, 0xde, 0x78, 0xfe, 0xb9, 0xae, 0x29, 0x12,
+	0x83, 0xb8, 0x35, 0xe0, 0x4b, 0xf8, 0x5f, 0x23, 0x2e, 0xa1, 0xe4, 0xdf, 0x85, 0xdc, 0x82, 0xd2,
+	0x6d, 0x30, 0xcb, 0xf3, 0x6d, 0xa4, 0xc2, 0x7f, 0x6e, 0x6c, 0x89, 0x23, 0x0e, 0xdf, 0xf1, 0x37,
+	0x01, 0xee, 0xbd, 0xbf, 0xb0, 0x4d, 0x4a, 0xb4, 0x81, 0xa6, 0xd3, 0xa8, 0x6e, 0x43, 0xde, 0x67,
+	0xc0, 0x8c, 0x6b, 0xb1, 0xb1, 0xca, 0x71, 0xe5, 0x63, 0x1b, 0xb1, 0xeb, 0x6d, 0x7e, 0xd2, 0xd4,
+	0xfc, 0xf0, 0x09, 0xac, 0x24, 0x8a, 0xb4, 0xef, 0x04, 0x21, 0xab, 0x3b, 0x10, 0x0e, 0xbf, 0x81,
+	0x72, 0x0a, 0xb5, 0xd9, 0xa3, 0x7e, 0x7f, 0x92, 0x40, 0x68, 0x09, 0xf2, 0xd4, 0xe9, 0x92, 0x76,
+	0xc0, 0x02, 0x48, 0x46, 0xfc, 0x86, 0x0f, 0x60, 0x3e, 0x85, 0x85, 0x5e, 0xc2, 0x2c, 0xe9, 0x51,
+	0xdf, 0x21, 0x81, 0x22, 0x54, 0xa4, 0x6a, 0xb1, 0xf1, 0x20, 0x53, 0x99, 0x64, 0x68, 0x63, 0x70,
+	0x02, 0x9b, 0xb0, 0xdc, 0x22, 0x54, 0xd7, 0x82, 0xbd, 0x91, 0x02, 0x4c, 0xa2, 0xf7, 0x37, 0xe9,
+	0xaf, 0x43, 0x21, 0xaa, 0x8a, 0xae, 0x05, 0x48, 0x06, 0xc9, 0xb1, 0x23, 0xa2, 0x05, 0x23, 0x7c,
+	0xc4, 0x3f, 0x04, 0x58, 0x8c, 0x5a, 0x21, 0xae, 0xdd, 0x18, 0xc5, 0x93, 0x74, 0xc4, 0x14, 0x1d,
+	0x0d, 0xf2, 0x5f, 0x1c, 0xe2, 0xda, 0x81, 0x22, 0x31, 0x05, 0xb6, 0x38, 0x3e, 0x19, 0xe8, 0xb5,
+	0xd7, 0xcc, 0x3d, 0x12, 0x23, 0x3e, 0xab, 0x3e, 0x87, 0x62, 0xc2, 0x1c, 0x52, 0x3d, 0x27, 0xfd,
+	0x98, 0x41, 0xf8, 0x88, 0xca, 0x30, 0x73, 0x6d, 0xba, 0x57, 0x24, 0x8e, 0x1f, 0xbd, 0xbc, 0x10,
+	0x77, 0x04, 0xbc, 0x03, 0xa5, 0x61, 0x23, 0x33, 0x8c, 0x3f, 0x3d, 0x8d, 0x37, 0x60, 0x31, 0x1a,
+	0xd5, 0x89, 0xd9, 0xe3, 0x16, 0x28, 0x2d, 0x42, 0xf9, 0x18, 0xe3, 0x94, 0x5a, 0x85, 0x02, 0xcb,
+	0xa8, 0x13, 0x12, 0x88, 0xa5, 0x62, 0x86, 0xb7, 0xa4, 0x8f, 0x7f, 0x0a, 0x30, 0x3f, 0x84, 0x89,
+	0x27, 0x35, 0x0d, 0xf0, 0x18, 0xe4, 0x81, 0xb4, 0x9d, 0xaf, 0x51, 0xdb, 0x28, 0x22, 0xab, 0xd8,
+	0xbc, 0x9b, 0x6a, 0xbe, 0x57, 0x29, 0xe5, 0xab, 0x9c, 0xf2, 0xa9, 0x40, 0x77, 0xad, 0x7a, 0x1b,
+	0x16, 0x77, 0x5d, 0x37, 0x15, 0x24, 0x40, 0xcf, 0x60, 0x36, 0x1a, 0xff, 0xc1, 0x40, 0xac, 0x4d,
+	0x22, 0x65, 0x0c, 0x9c, 0x37, 0x9f, 0xc2, 0x6c, 0xdc, 0xba, 0x48, 0x86, 0x39, 0xbd, 0x7d, 0xa4,
+	0x77, 0x8e, 0x0f, 0x3b, 0xfb, 0x27, 0xba, 0x26, 0xe7, 0x50, 0x19, 0xe4, 0xf0, 0x89, 0x59, 0x0e,
+	0x8f, 0x8e, 0x0f, 0x76, 0xdb, 0x4d, 0x59, 0x68, 0x7c, 0x97, 0x40, 0x1e, 0xe2, 0x1d, 0x11, 0xff,
+	0xda, 0xb1, 0x08, 0x6a, 0x43, 0x31, 0xb1, 0x48, 0xd0, 0x7d, 0x2e, 0xfa, 0xe8, 0x3d, 0xa0, 0x4e,
+	0xda, 0x64, 0x38, 0x87, 0x0c, 0x28, 0x45, 0x1d, 0x3c, 0x44, 0x7c, 0x92, 0xd1, 0xde, 0xe3, 0xf6,
+	0xa8, 0xba, 0xc0, 0x39, 0x7f, 0xf0, 0x9c, 0x10, 0x53, 0x87, 0x12, 0x7f, 0x41, 0x20, 0xcc, 0x6b,
+	0x94, 0x75, 0x7b, 0x64, 0x43, 0x7d, 0xe6, 0xee, 0xb6, 0x41, 0x5f, 0x3c, 0x1a, 0x97, 0x34, 0xbf,
+	0x57, 0xd5, 0xb5, 0x49, 0xbb, 0x8a, 0x25, 0x2f, 0xa7, 0xf7, 0x13, 0x7a, 0x98, 0xc6, 0xce, 0x5a,
+	0x5f, 0xea, 0x12, 0xe7, 0x35, 0xdc, 0x40, 0x38, 0xd7, 0xf8, 0x25, 0xc2, 0x72, 0xcb, 0xa4, 0xe4,
+	0xc6, 0xec, 0x8f, 0xd4, 0xae, 0x09, 0x73, 0xc9, 0x75, 0x81, 0x2a, 0xd3, 0x36, 0x49, 0xb6, 0x28,
+	0x4d, 0x98, 0x4b, 0x4e, 0x75, 0x0a, 0x26, 0x63, 0xe0, 0xb3, 0x61, 0x3e, 0xc2, 0xc2, 0xc8, 0xd4,
+	0xa3, 0x8d, 0x74, 0xfa, 0x99, 0x5b, 0x21, 0xd5, 0x55, 0xbc, 0x0f, 0xce, 0xa1, 0x43, 0x58, 0x6a,
+	0x11, 0x9a, 0x35, 0x3e, 0xa3, 0x4c, 0x54, 0x9e, 0x7e, 0xc6, 0x21, 0x9c, 0xdb, 0x5b, 0xff, 0xb4,
+	0xca, 0x9c, 0xea, 0xd1, 0x67, 0x90, 0xe5, 0x7a, 0x57, 0x76, 0xfd, 0xcc, 0x8b, 0xbf, 0x87, 0x4e,
+	0xf3, 0xec, 0x77, 0xfb, 0xf7, 0x00, 0xcd, 0xd8, 0x3d, 0x4a, 0x52, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -618,6 +851,12 @@ type DirectoryServiceClient interface {
 	// Delete location record of an object from the directory service
 	// Throws UNKNOWN if object ID does not exist
 	DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*Void, error)
+	// Get the bounded history of the locations of an object, most recent first
+	// Only locations updated with UpdateLocation are recorded
+	GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*LocationHistory, error)
+	// Get the IDs of all objects currently at a location, e.g. the IMSIs
+	// attached to a gateway
+	GetIDsByLocation(ctx context.Context, in *GetIDsByLocationRequest, opts ...grpc.CallOption) (*RecordIDs, error)
 }
 
 type directoryServiceClient struct {
@@ -655,6 +894,24 @@ func (c *directoryServiceClient) DeleteLocation(ctx context.Context, in *DeleteL
 	return out, nil
 }
 
+func (c *directoryServiceClient) GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*LocationHistory, error) {
+	out := new(LocationHistory)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.DirectoryService/GetLocationHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *directoryServiceClient) GetIDsByLocation(ctx context.Context, in *GetIDsByLocationRequest, opts ...grpc.CallOption) (*RecordIDs, error) {
+	out := new(RecordIDs)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.DirectoryService/GetIDsByLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DirectoryServiceServer is the server API for DirectoryService service.
 type DirectoryServiceServer interface {
 	// Get location of an object from the directory service
@@ -665,6 +922,12 @@ type DirectoryServiceServer interface {
 	// Delete location record of an object from the directory service
 	// Throws UNKNOWN if object ID does not exist
 	DeleteLocation(context.Context, *DeleteLocationRequest) (*Void, error)
+	// Get the bounded history of the locations of an object, most recent first
+	// Only locations updated with UpdateLocation are recorded
+	GetLocationHistory(context.Context, *GetLocationHistoryRequest) (*LocationHistory, error)
+	// Get the IDs of all objects currently at a location, e.g. the IMSIs
+	// attached to a gateway
+	GetIDsByLocation(context.Context, *GetIDsByLocationRequest) (*RecordIDs, error)
 }
 
 // UnimplementedDirectoryServiceServer can be embedded to have forward compatible implementations.
@@ -680,6 +943,12 @@ func (*UnimplementedDirectoryServiceServer) UpdateLocation(ctx context.Context,
 func (*UnimplementedDirectoryServiceServer) DeleteLocation(ctx context.Context, req *DeleteLocationRequest) (*Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLocation not implemented")
 }
+func (*UnimplementedDirectoryServiceServer) GetLocationHistory(ctx context.Context, req *GetLocationHistoryRequest) (*LocationHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocationHistory not implemented")
+}
+func (*UnimplementedDirectoryServiceServer) GetIDsByLocation(ctx context.Context, req *GetIDsByLocationRequest) (*RecordIDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIDsByLocation not implemented")
+}
 
 func RegisterDirectoryServiceServer(s *grpc.Server, srv DirectoryServiceServer) {
 	s.RegisterService(&_DirectoryService_serviceDesc, srv)
@@ -739,6 +1008,42 @@ func _DirectoryService_DeleteLocation_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DirectoryService_GetLocationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DirectoryServiceServer).GetLocationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.DirectoryService/GetLocationHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DirectoryServiceServer).GetLocationHistory(ctx, req.(*GetLocationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DirectoryService_GetIDsByLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIDsByLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DirectoryServiceServer).GetIDsByLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.DirectoryService/GetIDsByLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DirectoryServiceServer).GetIDsByLocation(ctx, req.(*GetIDsByLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DirectoryService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.DirectoryService",
 	HandlerType: (*DirectoryServiceServer)(nil),
@@ -755,6 +1060,14 @@ var _DirectoryService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLocation",
 			Handler:    _DirectoryService_DeleteLocation_Handler,
 		},
+		{
+			MethodName: "GetLocationHistory",
+			Handler:    _DirectoryService_GetLocationHistory_Handler,
+		},
+		{
+			MethodName: "GetIDsByLocation",
+			Handler:    _DirectoryService_GetIDsByLocation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orc8r/protos/directoryd.proto",
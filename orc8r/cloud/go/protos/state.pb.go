@@ -462,6 +462,342 @@ func (m *SyncStatesResponse) GetUnsyncedStates() []*IDAndVersion {
 	return nil
 }
 
+type GetStateHistoryRequest struct {
+	NetworkID string   `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Id        *StateID `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Inclusive time range of the returned states, in unix milliseconds.
+	// An endTimeMs of 0 leaves the range unbounded above.
+	StartTimeMs uint64 `protobuf:"varint,3,opt,name=startTimeMs,proto3" json:"startTimeMs,omitempty"`
+	EndTimeMs   uint64 `protobuf:"varint,4,opt,name=endTimeMs,proto3" json:"endTimeMs,omitempty"`
+	// Maximum number of states to return. 0 returns all states in range.
+	Limit                uint32   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateHistoryRequest) Reset()         { *m = GetStateHistoryRequest{} }
+func (m *GetStateHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateHistoryRequest) ProtoMessage()    {}
+func (*GetStateHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{10}
+}
+
+func (m *GetStateHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetStateHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHistoryRequest.Merge(m, src)
+}
+func (m *GetStateHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateHistoryRequest.Size(m)
+}
+func (m *GetStateHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateHistoryRequest proto.InternalMessageInfo
+
+func (m *GetStateHistoryRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *GetStateHistoryRequest) GetId() *StateID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *GetStateHistoryRequest) GetStartTimeMs() uint64 {
+	if m != nil {
+		return m.StartTimeMs
+	}
+	return 0
+}
+
+func (m *GetStateHistoryRequest) GetEndTimeMs() uint64 {
+	if m != nil {
+		return m.EndTimeMs
+	}
+	return 0
+}
+
+func (m *GetStateHistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetStateHistoryResponse struct {
+	// Reported values of the state, newest first
+	States               []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateHistoryResponse) Reset()         { *m = GetStateHistoryResponse{} }
+func (m *GetStateHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateHistoryResponse) ProtoMessage()    {}
+func (*GetStateHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{11}
+}
+
+func (m *GetStateHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetStateHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHistoryResponse.Merge(m, src)
+}
+func (m *GetStateHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateHistoryResponse.Size(m)
+}
+func (m *GetStateHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateHistoryResponse proto.InternalMessageInfo
+
+func (m *GetStateHistoryResponse) GetStates() []*State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+type WatchExpiredStatesRequest struct {
+	// If non-empty, only events for states in this network are streamed
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If non-empty, only events for states of these types are streamed
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchExpiredStatesRequest) Reset()         { *m = WatchExpiredStatesRequest{} }
+func (m *WatchExpiredStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchExpiredStatesRequest) ProtoMessage()    {}
+func (*WatchExpiredStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{12}
+}
+
+func (m *WatchExpiredStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchExpiredStatesRequest.Unmarshal(m, b)
+}
+func (m *WatchExpiredStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchExpiredStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchExpiredStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchExpiredStatesRequest.Merge(m, src)
+}
+func (m *WatchExpiredStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchExpiredStatesRequest.Size(m)
+}
+func (m *WatchExpiredStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchExpiredStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchExpiredStatesRequest proto.InternalMessageInfo
+
+func (m *WatchExpiredStatesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *WatchExpiredStatesRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type StateExpiredEvent struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// The expired state as it was last reported
+	State                *State   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateExpiredEvent) Reset()         { *m = StateExpiredEvent{} }
+func (m *StateExpiredEvent) String() string { return proto.CompactTextString(m) }
+func (*StateExpiredEvent) ProtoMessage()    {}
+func (*StateExpiredEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{13}
+}
+
+func (m *StateExpiredEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateExpiredEvent.Unmarshal(m, b)
+}
+func (m *StateExpiredEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateExpiredEvent.Marshal(b, m, deterministic)
+}
+func (m *StateExpiredEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateExpiredEvent.Merge(m, src)
+}
+func (m *StateExpiredEvent) XXX_Size() int {
+	return xxx_messageInfo_StateExpiredEvent.Size(m)
+}
+func (m *StateExpiredEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateExpiredEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateExpiredEvent proto.InternalMessageInfo
+
+func (m *StateExpiredEvent) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *StateExpiredEvent) GetState() *State {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type SearchStatesRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If non-empty, only states of these types are returned
+	Types []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	// If non-empty, only states whose device ID starts with this prefix are
+	// returned
+	KeyPrefix string `protobuf:"bytes,3,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// If non-empty, only states reported by this hardware ID are returned
+	ReporterID string `protobuf:"bytes,4,opt,name=reporterID,proto3" json:"reporterID,omitempty"`
+	// Inclusive range of the time the states were last reported, in unix
+	// milliseconds. A bound of 0 leaves the range unbounded on that side.
+	UpdatedAfterMs       uint64   `protobuf:"varint,5,opt,name=updatedAfterMs,proto3" json:"updatedAfterMs,omitempty"`
+	UpdatedBeforeMs      uint64   `protobuf:"varint,6,opt,name=updatedBeforeMs,proto3" json:"updatedBeforeMs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchStatesRequest) Reset()         { *m = SearchStatesRequest{} }
+func (m *SearchStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchStatesRequest) ProtoMessage()    {}
+func (*SearchStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{14}
+}
+
+func (m *SearchStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStatesRequest.Unmarshal(m, b)
+}
+func (m *SearchStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStatesRequest.Merge(m, src)
+}
+func (m *SearchStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchStatesRequest.Size(m)
+}
+func (m *SearchStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStatesRequest proto.InternalMessageInfo
+
+func (m *SearchStatesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *SearchStatesRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetReporterID() string {
+	if m != nil {
+		return m.ReporterID
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetUpdatedAfterMs() uint64 {
+	if m != nil {
+		return m.UpdatedAfterMs
+	}
+	return 0
+}
+
+func (m *SearchStatesRequest) GetUpdatedBeforeMs() uint64 {
+	if m != nil {
+		return m.UpdatedBeforeMs
+	}
+	return 0
+}
+
+type SearchStatesResponse struct {
+	// Matching states, sorted by type then device ID
+	States               []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchStatesResponse) Reset()         { *m = SearchStatesResponse{} }
+func (m *SearchStatesResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchStatesResponse) ProtoMessage()    {}
+func (*SearchStatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{15}
+}
+
+func (m *SearchStatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStatesResponse.Unmarshal(m, b)
+}
+func (m *SearchStatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStatesResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchStatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStatesResponse.Merge(m, src)
+}
+func (m *SearchStatesResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchStatesResponse.Size(m)
+}
+func (m *SearchStatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStatesResponse proto.InternalMessageInfo
+
+func (m *SearchStatesResponse) GetStates() []*State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*StateID)(nil), "magma.orc8r.StateID")
 	proto.RegisterType((*GetStatesRequest)(nil), "magma.orc8r.GetStatesRequest")
@@ -473,42 +809,64 @@ func init() {
 	proto.RegisterType((*SyncStatesRequest)(nil), "magma.orc8r.SyncStatesRequest")
 	proto.RegisterType((*IDAndVersion)(nil), "magma.orc8r.IDAndVersion")
 	proto.RegisterType((*SyncStatesResponse)(nil), "magma.orc8r.SyncStatesResponse")
+	proto.RegisterType((*GetStateHistoryRequest)(nil), "magma.orc8r.GetStateHistoryRequest")
+	proto.RegisterType((*GetStateHistoryResponse)(nil), "magma.orc8r.GetStateHistoryResponse")
+	proto.RegisterType((*WatchExpiredStatesRequest)(nil), "magma.orc8r.WatchExpiredStatesRequest")
+	proto.RegisterType((*StateExpiredEvent)(nil), "magma.orc8r.StateExpiredEvent")
+	proto.RegisterType((*SearchStatesRequest)(nil), "magma.orc8r.SearchStatesRequest")
+	proto.RegisterType((*SearchStatesResponse)(nil), "magma.orc8r.SearchStatesResponse")
 }
 
 func init() { proto.RegisterFile("orc8r/protos/state.proto", fileDescriptor_645e93724c8b4dfe) }
 
 var fileDescriptor_645e93724c8b4dfe = []byte{
-	// 471 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x5f, 0xd3, 0xb1, 0xd1, 0xb7, 0x0a, 0xad, 0x6f, 0x95, 0xc8, 0x02, 0x85, 0x62, 0x21, 0x34,
-	0x71, 0x68, 0x61, 0xbd, 0xc0, 0x09, 0x15, 0x32, 0x50, 0x25, 0x04, 0x92, 0x2b, 0x06, 0x62, 0xa7,
-	0x12, 0x3f, 0x4d, 0x11, 0xab, 0x5d, 0x6c, 0x77, 0xa8, 0x9f, 0x91, 0x2f, 0x85, 0xea, 0x64, 0x6d,
-	0x9c, 0xac, 0x93, 0x76, 0xd8, 0xa9, 0x79, 0xfe, 0xfd, 0xe9, 0xcf, 0xcf, 0xcf, 0x86, 0x50, 0xe9,
-	0xe4, 0x8d, 0xee, 0xcf, 0xb4, 0xb2, 0xca, 0xf4, 0x8d, 0x9d, 0x58, 0xea, 0xb9, 0x02, 0xf7, 0xa6,
-	0x93, 0xf3, 0xe9, 0xa4, 0xe7, 0xf0, 0xe8, 0xd0, 0xa3, 0x25, 0x6a, 0x3a, 0x55, 0x32, 0xe3, 0x45,
-	0x1d, 0xdf, 0x81, 0xf4, 0x65, 0x9a, 0xd0, 0xe0, 0xd5, 0x20, 0x83, 0xd9, 0x5b, 0xd8, 0x1d, 0x2f,
-	0x5d, 0x47, 0x31, 0x22, 0x6c, 0xdb, 0xc5, 0x8c, 0xc2, 0x5a, 0xb7, 0x76, 0xd4, 0xe0, 0xee, 0x1b,
-	0x23, 0xb8, 0x2f, 0x68, 0xa9, 0x18, 0xc5, 0x61, 0xe0, 0xd6, 0x57, 0x35, 0xfb, 0x01, 0xfb, 0x9f,
-	0xc8, 0x3a, 0xb5, 0xe1, 0xf4, 0x67, 0x4e, 0xc6, 0xe2, 0x63, 0x68, 0x48, 0xb2, 0x7f, 0x95, 0xfe,
-	0x3d, 0x8a, 0x73, 0xa3, 0xf5, 0x02, 0xbe, 0x80, 0x7a, 0x2a, 0x4c, 0x18, 0x74, 0xeb, 0x47, 0x7b,
-	0xc7, 0xed, 0x5e, 0x61, 0x07, 0xbd, 0x3c, 0x04, 0x5f, 0x12, 0xd8, 0x3b, 0x68, 0x15, 0x9c, 0xcd,
-	0x4c, 0x49, 0x43, 0xf8, 0x12, 0x76, 0xdc, 0xfe, 0x4d, 0x58, 0x73, 0x7a, 0xac, 0xea, 0x79, 0xce,
-	0x60, 0x43, 0x38, 0xe0, 0x34, 0x53, 0xba, 0x94, 0xee, 0x36, 0x16, 0x67, 0xd0, 0xf6, 0x2d, 0xf2,
-	0x18, 0x1f, 0x60, 0x7f, 0x2e, 0xb5, 0x43, 0x48, 0x8c, 0x8b, 0x6e, 0x0f, 0x3d, 0xb7, 0x51, 0x3c,
-	0x94, 0xe2, 0x44, 0x6b, 0xa5, 0x79, 0x45, 0xc0, 0x38, 0xc0, 0x1a, 0xbf, 0x6d, 0xe3, 0xb1, 0x0d,
-	0xf7, 0x68, 0x29, 0x0c, 0xeb, 0x0e, 0xc8, 0x0a, 0x76, 0x06, 0x07, 0x31, 0x5d, 0x90, 0xa5, 0xbb,
-	0x38, 0x91, 0x8f, 0xd0, 0x1a, 0x2f, 0x64, 0xe2, 0x5b, 0xbf, 0x2e, 0xb5, 0xf3, 0xb0, 0xda, 0x80,
-	0x53, 0xd2, 0x26, 0x55, 0x72, 0xd5, 0xd5, 0x2f, 0xd0, 0x2c, 0xae, 0xe3, 0x73, 0x08, 0x52, 0xe1,
-	0x62, 0x6d, 0xfa, 0xfb, 0x20, 0x15, 0x18, 0xc2, 0xee, 0x65, 0x26, 0x70, 0xbd, 0xd8, 0xe6, 0x57,
-	0x25, 0xfb, 0x0e, 0x58, 0xcc, 0x95, 0x9f, 0xd1, 0x10, 0x1e, 0xcc, 0xa5, 0x59, 0xc8, 0xa4, 0x74,
-	0x42, 0x37, 0x04, 0x2c, 0x09, 0x8e, 0xff, 0x05, 0xd0, 0x74, 0x9f, 0xe3, 0xec, 0xc6, 0xe0, 0x67,
-	0x68, 0xac, 0x66, 0x12, 0x3b, 0x9e, 0x51, 0xf9, 0x16, 0x44, 0x4f, 0x36, 0xc1, 0x59, 0x3e, 0xb6,
-	0x85, 0xdf, 0xa0, 0x59, 0x9c, 0x2e, 0xec, 0x7a, 0x8a, 0x6b, 0x66, 0x37, 0x7a, 0x76, 0x03, 0x63,
-	0x65, 0x7b, 0x02, 0xcd, 0xe2, 0x0c, 0x94, 0x6c, 0xaf, 0x19, 0x8f, 0xa8, 0xe5, 0x31, 0x4e, 0x55,
-	0x2a, 0xd8, 0x16, 0x7e, 0x05, 0x58, 0x77, 0x15, 0xfd, 0xdd, 0x54, 0xc6, 0x20, 0x7a, 0xba, 0x11,
-	0xbf, 0xca, 0xf5, 0xbe, 0xf3, 0xf3, 0x91, 0xe3, 0xf4, 0xb3, 0xc7, 0x28, 0xb9, 0x50, 0x73, 0xd1,
-	0x3f, 0x57, 0xf9, 0xab, 0xf4, 0x6b, 0xc7, 0xfd, 0x0e, 0xfe, 0x07, 0x00, 0x00, 0xff, 0xff, 0x25,
-	0x92, 0x8a, 0xcd, 0xee, 0x04, 0x00, 0x00,
+	// 722 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5d, 0x4f, 0x13, 0x4d,
+	0x14, 0x66, 0x4b, 0x0b, 0x6f, 0x0f, 0x7d, 0x81, 0x0e, 0x8d, 0x2c, 0xab, 0x60, 0x19, 0x09, 0x69,
+	0xbc, 0x68, 0x11, 0x6e, 0xf4, 0xca, 0x14, 0x5b, 0xb5, 0x89, 0x88, 0xd9, 0x2a, 0x18, 0x49, 0x8c,
+	0xeb, 0xee, 0x01, 0x36, 0xd0, 0x9d, 0x3a, 0x33, 0x45, 0xfa, 0xb7, 0xfc, 0x37, 0xde, 0xfb, 0x43,
+	0xcc, 0xce, 0x2e, 0xed, 0x7e, 0xb4, 0x85, 0x6a, 0xbc, 0x82, 0x39, 0xe7, 0x3c, 0x4f, 0x9f, 0xf3,
+	0x99, 0x05, 0x9d, 0x71, 0xfb, 0x29, 0xaf, 0x75, 0x39, 0x93, 0x4c, 0xd4, 0x84, 0xb4, 0x24, 0x56,
+	0xd5, 0x83, 0x2c, 0x74, 0xac, 0xb3, 0x8e, 0x55, 0x55, 0x7e, 0x63, 0x2d, 0x16, 0x66, 0xb3, 0x4e,
+	0x87, 0x79, 0x41, 0x9c, 0xb1, 0x1e, 0x67, 0x40, 0x7e, 0xe5, 0xda, 0xb8, 0xb7, 0xb3, 0x17, 0xb8,
+	0xe9, 0x33, 0x98, 0x6f, 0xfb, 0xac, 0xad, 0x06, 0x21, 0x90, 0x95, 0xfd, 0x2e, 0xea, 0x5a, 0x59,
+	0xab, 0xe4, 0x4d, 0xf5, 0x3f, 0x31, 0xe0, 0x3f, 0x07, 0x7d, 0x44, 0xab, 0xa1, 0x67, 0x94, 0x7d,
+	0xf0, 0xa6, 0x1f, 0x61, 0xf9, 0x15, 0x4a, 0x85, 0x16, 0x26, 0x7e, 0xeb, 0xa1, 0x90, 0xe4, 0x01,
+	0xe4, 0x3d, 0x94, 0xdf, 0x19, 0xbf, 0x68, 0x35, 0x42, 0xa2, 0xa1, 0x81, 0x6c, 0xc3, 0xac, 0xeb,
+	0x08, 0x3d, 0x53, 0x9e, 0xad, 0x2c, 0xec, 0x96, 0xaa, 0x91, 0x0c, 0xaa, 0xa1, 0x08, 0xd3, 0x0f,
+	0xa0, 0xcf, 0xa1, 0x18, 0x61, 0x16, 0x5d, 0xe6, 0x09, 0x24, 0x8f, 0x61, 0x4e, 0xe5, 0x2f, 0x74,
+	0x4d, 0xe1, 0x49, 0x1a, 0x6f, 0x86, 0x11, 0xb4, 0x0e, 0x2b, 0x26, 0x76, 0x19, 0x4f, 0xa8, 0x9b,
+	0x86, 0xe2, 0x04, 0x4a, 0x71, 0x8a, 0x50, 0xc6, 0x0b, 0x58, 0xee, 0x79, 0x5c, 0x79, 0xd0, 0x69,
+	0x47, 0xd9, 0x56, 0x63, 0x6c, 0xad, 0x46, 0xdd, 0x73, 0x9a, 0x9c, 0x33, 0x6e, 0xa6, 0x00, 0xd4,
+	0x04, 0x18, 0xfa, 0xa7, 0x2d, 0x3c, 0x29, 0x41, 0x0e, 0x7d, 0xa0, 0x3e, 0xab, 0x1c, 0xc1, 0x83,
+	0x9e, 0xc0, 0x4a, 0x03, 0x2f, 0x51, 0xe2, 0xbf, 0xe8, 0xc8, 0x4b, 0x28, 0xb6, 0xfb, 0x9e, 0x1d,
+	0xa7, 0x7e, 0x92, 0x28, 0xe7, 0x5a, 0xba, 0x00, 0x47, 0xc8, 0x85, 0xcb, 0xbc, 0x41, 0x55, 0xdf,
+	0x42, 0x21, 0x6a, 0x27, 0x5b, 0x90, 0x71, 0x1d, 0x25, 0x6b, 0xdc, 0xcf, 0x67, 0x5c, 0x87, 0xe8,
+	0x30, 0x7f, 0x15, 0x00, 0x54, 0x2d, 0xb2, 0xe6, 0xcd, 0x93, 0x1e, 0x03, 0x89, 0xea, 0x0a, 0x7b,
+	0x54, 0x87, 0xc5, 0x9e, 0x27, 0xfa, 0x9e, 0x9d, 0xe8, 0xd0, 0x04, 0x81, 0x09, 0x00, 0xfd, 0xa1,
+	0xc1, 0xbd, 0x9b, 0x19, 0x7c, 0xed, 0x0a, 0xc9, 0x78, 0xff, 0x6e, 0x15, 0x0d, 0x32, 0xca, 0xdc,
+	0x92, 0x51, 0x19, 0x16, 0x84, 0xb4, 0xb8, 0x7c, 0xef, 0x76, 0xf0, 0x40, 0xa8, 0x46, 0x66, 0xcd,
+	0xa8, 0xc9, 0xff, 0x15, 0xf4, 0x9c, 0xd0, 0x9f, 0x55, 0xfe, 0xa1, 0xc1, 0x1f, 0x81, 0x4b, 0xb7,
+	0xe3, 0x4a, 0x3d, 0x57, 0xd6, 0x2a, 0xff, 0x9b, 0xc1, 0x83, 0x36, 0x61, 0x35, 0xa5, 0xf9, 0x0f,
+	0xb6, 0xe7, 0x10, 0xd6, 0x8e, 0x2d, 0x69, 0x9f, 0x37, 0xaf, 0xbb, 0x2e, 0xbf, 0xa9, 0xc8, 0xdd,
+	0xb2, 0x2f, 0x41, 0xce, 0x1f, 0xdf, 0x60, 0xa2, 0xf2, 0x66, 0xf0, 0xa0, 0x27, 0x50, 0x54, 0x24,
+	0x21, 0x61, 0xf3, 0x0a, 0xbd, 0xdb, 0x88, 0x2a, 0x90, 0x53, 0x6a, 0xc2, 0x4a, 0x8e, 0x92, 0x1b,
+	0x04, 0xd0, 0x9f, 0x1a, 0xac, 0xb4, 0xd1, 0xe2, 0xf6, 0xf9, 0x5f, 0x0b, 0xf5, 0x31, 0x17, 0xd8,
+	0x7f, 0xc7, 0xf1, 0xd4, 0xbd, 0x0e, 0xb7, 0x6b, 0x68, 0x20, 0x1b, 0x00, 0xe1, 0x1e, 0xf3, 0x56,
+	0x43, 0xf5, 0x24, 0x6f, 0x46, 0x2c, 0x64, 0x1b, 0x16, 0x7b, 0x5d, 0xc7, 0x92, 0xe8, 0xd4, 0x4f,
+	0x25, 0xf2, 0x03, 0xa1, 0xba, 0x93, 0x35, 0x13, 0x56, 0x52, 0x81, 0xa5, 0xd0, 0xb2, 0x8f, 0xa7,
+	0x8c, 0xfb, 0x0d, 0x9e, 0x53, 0x81, 0x49, 0x33, 0xdd, 0x87, 0x52, 0x3c, 0xb5, 0xe9, 0xbb, 0xb9,
+	0xfb, 0x2b, 0x0b, 0x05, 0x65, 0x69, 0x07, 0xb7, 0x9f, 0xbc, 0x81, 0xfc, 0xe0, 0xba, 0x92, 0xf5,
+	0x18, 0x32, 0x79, 0xcf, 0x8d, 0x8d, 0x71, 0xee, 0x40, 0x08, 0x9d, 0x21, 0x1f, 0xa0, 0x10, 0xbd,
+	0x93, 0xa4, 0x1c, 0x43, 0x8c, 0xb8, 0xc2, 0xc6, 0xe6, 0x84, 0x88, 0x01, 0x6d, 0x13, 0x0a, 0xd1,
+	0x6b, 0x96, 0xa0, 0x1d, 0x71, 0xe8, 0x8c, 0x62, 0x2c, 0xe2, 0x88, 0xb9, 0x0e, 0x9d, 0x21, 0x87,
+	0x00, 0xc3, 0xfb, 0x40, 0xe2, 0xd9, 0xa4, 0x0e, 0x9a, 0xf1, 0x70, 0xac, 0x3f, 0x9a, 0x6e, 0xb4,
+	0x23, 0x09, 0x5d, 0x23, 0xe6, 0xd0, 0xd8, 0x9c, 0x10, 0x31, 0xa0, 0xfd, 0x0c, 0x4b, 0x89, 0xcd,
+	0x25, 0x8f, 0x46, 0x96, 0x3e, 0x7e, 0x8b, 0x8c, 0xad, 0xc9, 0x41, 0x03, 0xfe, 0x2f, 0x40, 0xd2,
+	0x2b, 0x4d, 0xb6, 0x63, 0xe8, 0xb1, 0x3b, 0x9f, 0x98, 0x82, 0xd4, 0x2a, 0xd3, 0x99, 0x1d, 0x6d,
+	0x7f, 0xfd, 0xd3, 0x7d, 0x15, 0x54, 0x0b, 0xbe, 0x37, 0xec, 0x4b, 0xd6, 0x73, 0x6a, 0x67, 0x2c,
+	0xfc, 0xf0, 0xf8, 0x3a, 0xa7, 0xfe, 0xee, 0xfd, 0x1e, 0x00, 0x16, 0x40, 0xe1, 0xac, 0xd1, 0x08,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -527,6 +885,17 @@ type StateServiceClient interface {
 	ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*ReportStatesResponse, error)
 	DeleteStates(ctx context.Context, in *DeleteStatesRequest, opts ...grpc.CallOption) (*Void, error)
 	SyncStates(ctx context.Context, in *SyncStatesRequest, opts ...grpc.CallOption) (*SyncStatesResponse, error)
+	// SearchStates returns all states in a network which match the filters
+	// in the request.
+	SearchStates(ctx context.Context, in *SearchStatesRequest, opts ...grpc.CallOption) (*SearchStatesResponse, error)
+	// GetStateHistory returns the previously reported values of a state
+	// whose type has a history retention policy configured.
+	GetStateHistory(ctx context.Context, in *GetStateHistoryRequest, opts ...grpc.CallOption) (*GetStateHistoryResponse, error)
+	// WatchExpiredStates streams an event for every state deleted because it
+	// wasn't reported within the TTL of its type, whichever state service
+	// instance reaped it. The response headers are sent once the watch is
+	// established.
+	WatchExpiredStates(ctx context.Context, in *WatchExpiredStatesRequest, opts ...grpc.CallOption) (StateService_WatchExpiredStatesClient, error)
 }
 
 type stateServiceClient struct {
@@ -573,12 +942,73 @@ func (c *stateServiceClient) SyncStates(ctx context.Context, in *SyncStatesReque
 	return out, nil
 }
 
+func (c *stateServiceClient) SearchStates(ctx context.Context, in *SearchStatesRequest, opts ...grpc.CallOption) (*SearchStatesResponse, error) {
+	out := new(SearchStatesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/SearchStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) GetStateHistory(ctx context.Context, in *GetStateHistoryRequest, opts ...grpc.CallOption) (*GetStateHistoryResponse, error) {
+	out := new(GetStateHistoryResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/GetStateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateServiceClient) WatchExpiredStates(ctx context.Context, in *WatchExpiredStatesRequest, opts ...grpc.CallOption) (StateService_WatchExpiredStatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StateService_serviceDesc.Streams[0], "/magma.orc8r.StateService/WatchExpiredStates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateServiceWatchExpiredStatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateService_WatchExpiredStatesClient interface {
+	Recv() (*StateExpiredEvent, error)
+	grpc.ClientStream
+}
+
+type stateServiceWatchExpiredStatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateServiceWatchExpiredStatesClient) Recv() (*StateExpiredEvent, error) {
+	m := new(StateExpiredEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StateServiceServer is the server API for StateService service.
 type StateServiceServer interface {
 	GetStates(context.Context, *GetStatesRequest) (*GetStatesResponse, error)
 	ReportStates(context.Context, *ReportStatesRequest) (*ReportStatesResponse, error)
 	DeleteStates(context.Context, *DeleteStatesRequest) (*Void, error)
 	SyncStates(context.Context, *SyncStatesRequest) (*SyncStatesResponse, error)
+	// SearchStates returns all states in a network which match the filters
+	// in the request.
+	SearchStates(context.Context, *SearchStatesRequest) (*SearchStatesResponse, error)
+	// GetStateHistory returns the previously reported values of a state
+	// whose type has a history retention policy configured.
+	GetStateHistory(context.Context, *GetStateHistoryRequest) (*GetStateHistoryResponse, error)
+	// WatchExpiredStates streams an event for every state deleted because it
+	// wasn't reported within the TTL of its type, whichever state service
+	// instance reaped it. The response headers are sent once the watch is
+	// established.
+	WatchExpiredStates(*WatchExpiredStatesRequest, StateService_WatchExpiredStatesServer) error
 }
 
 // UnimplementedStateServiceServer can be embedded to have forward compatible implementations.
@@ -597,6 +1027,15 @@ func (*UnimplementedStateServiceServer) DeleteStates(ctx context.Context, req *D
 func (*UnimplementedStateServiceServer) SyncStates(ctx context.Context, req *SyncStatesRequest) (*SyncStatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncStates not implemented")
 }
+func (*UnimplementedStateServiceServer) SearchStates(ctx context.Context, req *SearchStatesRequest) (*SearchStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchStates not implemented")
+}
+func (*UnimplementedStateServiceServer) GetStateHistory(ctx context.Context, req *GetStateHistoryRequest) (*GetStateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateHistory not implemented")
+}
+func (*UnimplementedStateServiceServer) WatchExpiredStates(req *WatchExpiredStatesRequest, srv StateService_WatchExpiredStatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchExpiredStates not implemented")
+}
 
 func RegisterStateServiceServer(s *grpc.Server, srv StateServiceServer) {
 	s.RegisterService(&_StateService_serviceDesc, srv)
@@ -674,6 +1113,63 @@ func _StateService_SyncStates_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StateService_SearchStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).SearchStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.StateService/SearchStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).SearchStates(ctx, req.(*SearchStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_GetStateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).GetStateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.StateService/GetStateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).GetStateHistory(ctx, req.(*GetStateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateService_WatchExpiredStates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchExpiredStatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateServiceServer).WatchExpiredStates(m, &stateServiceWatchExpiredStatesServer{stream})
+}
+
+type StateService_WatchExpiredStatesServer interface {
+	Send(*StateExpiredEvent) error
+	grpc.ServerStream
+}
+
+type stateServiceWatchExpiredStatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateServiceWatchExpiredStatesServer) Send(m *StateExpiredEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _StateService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.StateService",
 	HandlerType: (*StateServiceServer)(nil),
@@ -694,7 +1190,21 @@ var _StateService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SyncStates",
 			Handler:    _StateService_SyncStates_Handler,
 		},
+		{
+			MethodName: "SearchStates",
+			Handler:    _StateService_SearchStates_Handler,
+		},
+		{
+			MethodName: "GetStateHistory",
+			Handler:    _StateService_GetStateHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchExpiredStates",
+			Handler:       _StateService_WatchExpiredStates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "orc8r/protos/state.proto",
 }
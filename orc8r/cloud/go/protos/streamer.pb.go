@@ -33,14 +33,19 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // between the cloud and the gateway while abstracting the details of how
 // its implemented in the cloud and what the gateway does with the updates.
 //
-// - The gateways call the GetUpdates() streaming API with a StreamRequest
-//   indicating the stream name and the offset to continue streaming from.
-// - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
-// - If resync is true, then the gateway can cleanup all its data and add
-//   all the keys (the batch is guaranteed to contain only unique keys).
-// - If resync is false, then the gateway can update the keys, or add new
-//   ones if the key is not already present.
-// - Key deletions are not yet supported (#15109350)
+//   - The gateways call the GetUpdates() streaming API with a StreamRequest
+//     indicating the stream name and the offset to continue streaming from.
+//   - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
+//   - If resync is true, then the gateway can cleanup all its data and add
+//     all the keys (the batch is guaranteed to contain only unique keys).
+//   - If resync is false, then the gateway can update the keys, or add new
+//     ones if the key is not already present.
+//   - If the gateway sends the digest of the last batch it applied, the cloud
+//     may instead send only the keys which were added or changed since then,
+//     with resync set to false, and list deleted keys in removed_keys.
+//   - If keep_open is set, the stream is held open after the first batch and
+//     further delta batches are pushed as the underlying data changes.
+//
 // --------------------------------------------------------------------------
 type StreamRequest struct {
 	GatewayId string `protobuf:"bytes,1,opt,name=gatewayId,proto3" json:"gatewayId,omitempty"`
@@ -48,7 +53,13 @@ type StreamRequest struct {
 	StreamName string `protobuf:"bytes,2,opt,name=stream_name,json=streamName,proto3" json:"stream_name,omitempty"`
 	// Any extra data to send up with the stream request. This value will be
 	// different per stream provider.
-	ExtraArgs            *any.Any `protobuf:"bytes,3,opt,name=extra_args,json=extraArgs,proto3" json:"extra_args,omitempty"`
+	ExtraArgs *any.Any `protobuf:"bytes,3,opt,name=extra_args,json=extraArgs,proto3" json:"extra_args,omitempty"`
+	// Digest of the last DataUpdateBatch the gateway applied for this stream.
+	// If empty, the cloud will always respond with a full resync.
+	PreviousDigest string `protobuf:"bytes,4,opt,name=previous_digest,json=previousDigest,proto3" json:"previous_digest,omitempty"`
+	// If true, the cloud keeps the stream open and pushes delta batches
+	// whenever the contents of the stream change.
+	KeepOpen             bool     `protobuf:"varint,5,opt,name=keep_open,json=keepOpen,proto3" json:"keep_open,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -100,6 +111,20 @@ func (m *StreamRequest) GetExtraArgs() *any.Any {
 	return nil
 }
 
+func (m *StreamRequest) GetPreviousDigest() string {
+	if m != nil {
+		return m.PreviousDigest
+	}
+	return ""
+}
+
+func (m *StreamRequest) GetKeepOpen() bool {
+	if m != nil {
+		return m.KeepOpen
+	}
+	return false
+}
+
 type DataUpdate struct {
 	// Unique key for each item
 	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -154,7 +179,13 @@ type DataUpdateBatch struct {
 	Updates []*DataUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
 	// If resync is true, the updates would be a snapshot of all the
 	// contents in the cloud.
-	Resync               bool     `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	Resync bool `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	// Digest of the full contents of the stream after this batch has been
+	// applied. Gateways should echo this back as previous_digest.
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// Keys which have been deleted since the previous digest. Only populated
+	// if resync is false.
+	RemovedKeys          []string `protobuf:"bytes,4,rep,name=removed_keys,json=removedKeys,proto3" json:"removed_keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -199,6 +230,20 @@ func (m *DataUpdateBatch) GetResync() bool {
 	return false
 }
 
+func (m *DataUpdateBatch) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *DataUpdateBatch) GetRemovedKeys() []string {
+	if m != nil {
+		return m.RemovedKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*StreamRequest)(nil), "magma.orc8r.StreamRequest")
 	proto.RegisterType((*DataUpdate)(nil), "magma.orc8r.DataUpdate")
@@ -208,27 +253,32 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/streamer.proto", fileDescriptor_acdce76608ae0d01) }
 
 var fileDescriptor_acdce76608ae0d01 = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x4f, 0xf2, 0x40,
-	0x10, 0xc5, 0xbf, 0x7e, 0x44, 0x84, 0xa9, 0x46, 0xb3, 0x21, 0x5a, 0x01, 0x23, 0xe9, 0x89, 0xd3,
-	0x56, 0xc1, 0x83, 0x57, 0x88, 0x89, 0xd1, 0x83, 0x87, 0x25, 0x78, 0x30, 0x26, 0x64, 0x28, 0xe3,
-	0x9a, 0x48, 0xbb, 0xb8, 0xbb, 0x55, 0x7b, 0xf6, 0x1f, 0x37, 0xee, 0x96, 0x20, 0x07, 0x4f, 0xed,
-	0x9b, 0xfd, 0xcd, 0xbc, 0xc9, 0x1b, 0xe8, 0x28, 0x9d, 0x5e, 0xe9, 0x64, 0xa5, 0x95, 0x55, 0x26,
-	0x31, 0x56, 0x13, 0x66, 0xa4, 0xb9, 0xd3, 0x2c, 0xcc, 0x50, 0x66, 0xc8, 0x1d, 0xd2, 0x3e, 0x91,
-	0x4a, 0xc9, 0x25, 0x79, 0x74, 0x5e, 0x3c, 0x27, 0x98, 0x97, 0x9e, 0x8b, 0xbf, 0x02, 0xd8, 0x9f,
-	0xb8, 0x56, 0x41, 0x6f, 0x05, 0x19, 0xcb, 0xba, 0xd0, 0x94, 0x68, 0xe9, 0x03, 0xcb, 0xdb, 0x45,
-	0x14, 0xf4, 0x82, 0x7e, 0x53, 0x6c, 0x0a, 0xec, 0x0c, 0x42, 0xef, 0x34, 0xcb, 0x31, 0xa3, 0xe8,
-	0xbf, 0x7b, 0x07, 0x5f, 0xba, 0xc7, 0x8c, 0xd8, 0x10, 0x80, 0x3e, 0xad, 0xc6, 0x19, 0x6a, 0x69,
-	0xa2, 0x5a, 0x2f, 0xe8, 0x87, 0x83, 0x16, 0xf7, 0x0b, 0xf0, 0xf5, 0x02, 0x7c, 0x94, 0x97, 0xa2,
-	0xe9, 0xb8, 0x91, 0x96, 0x26, 0xbe, 0x04, 0xb8, 0x46, 0x8b, 0xd3, 0xd5, 0x02, 0x2d, 0xb1, 0x43,
-	0xa8, 0xbd, 0x52, 0x59, 0x79, 0xff, 0xfc, 0xb2, 0x16, 0xec, 0xbc, 0xe3, 0xb2, 0xf0, 0x7e, 0x7b,
-	0xc2, 0x8b, 0xf8, 0x09, 0x0e, 0x36, 0x5d, 0x63, 0xb4, 0xe9, 0x0b, 0xbb, 0x80, 0xdd, 0xc2, 0x49,
-	0x13, 0x05, 0xbd, 0x5a, 0x3f, 0x1c, 0x1c, 0xf3, 0x5f, 0x41, 0xf0, 0x0d, 0x2e, 0xd6, 0x1c, 0x3b,
-	0x82, 0xba, 0x26, 0x53, 0xe6, 0xa9, 0x1b, 0xde, 0x10, 0x95, 0x1a, 0x3c, 0x40, 0x63, 0x52, 0x65,
-	0xca, 0xee, 0x00, 0x6e, 0xc8, 0x4e, 0xab, 0x8e, 0xf6, 0xd6, 0xcc, 0xad, 0xf4, 0xda, 0xdd, 0x3f,
-	0xfc, 0xdc, 0x7a, 0xf1, 0xbf, 0xf3, 0x60, 0x7c, 0xfa, 0xd8, 0x71, 0x48, 0xe2, 0xcf, 0x97, 0x2e,
-	0x55, 0xb1, 0x48, 0xa4, 0xaa, 0xee, 0x38, 0xaf, 0xbb, 0xef, 0xf0, 0x3b, 0x00, 0x00, 0xff, 0xff,
-	0x75, 0x62, 0xfb, 0xc6, 0xde, 0x01, 0x00, 0x00,
+	// 390 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0xc1, 0x6e, 0xd4, 0x30,
+	0x10, 0xc5, 0xa4, 0x2d, 0xc9, 0xa4, 0x50, 0x64, 0x55, 0x10, 0x76, 0x8b, 0x08, 0xb9, 0x90, 0x53,
+	0x02, 0x5b, 0x0e, 0x5c, 0x5b, 0x55, 0x42, 0x80, 0x04, 0x92, 0xab, 0xe5, 0xc0, 0x25, 0xf2, 0x26,
+	0x83, 0x59, 0xed, 0x26, 0x0e, 0xb6, 0xb3, 0x90, 0x2f, 0xe1, 0x9b, 0xf8, 0x2b, 0xb4, 0xb6, 0x57,
+	0xcb, 0x1e, 0x7a, 0xb2, 0xdf, 0xf3, 0x9b, 0x99, 0x37, 0x9e, 0x81, 0xa9, 0x54, 0xf5, 0x3b, 0x55,
+	0xf6, 0x4a, 0x1a, 0xa9, 0x4b, 0x6d, 0x14, 0xf2, 0x16, 0x55, 0x61, 0x31, 0x8d, 0x5b, 0x2e, 0x5a,
+	0x5e, 0x58, 0xc9, 0xe4, 0x99, 0x90, 0x52, 0xac, 0xd1, 0x49, 0x17, 0xc3, 0xf7, 0x92, 0x77, 0xa3,
+	0xd3, 0x65, 0x7f, 0x09, 0x3c, 0xbc, 0xb5, 0xa1, 0x0c, 0x7f, 0x0e, 0xa8, 0x0d, 0xbd, 0x80, 0x48,
+	0x70, 0x83, 0xbf, 0xf8, 0xf8, 0xa1, 0x49, 0x48, 0x4a, 0xf2, 0x88, 0xed, 0x09, 0xfa, 0x02, 0x62,
+	0x57, 0xa9, 0xea, 0x78, 0x8b, 0xc9, 0x7d, 0xfb, 0x0e, 0x8e, 0xfa, 0xcc, 0x5b, 0xa4, 0x97, 0x00,
+	0xf8, 0xdb, 0x28, 0x5e, 0x71, 0x25, 0x74, 0x12, 0xa4, 0x24, 0x8f, 0x67, 0xe7, 0x85, 0x33, 0x50,
+	0xec, 0x0c, 0x14, 0x57, 0xdd, 0xc8, 0x22, 0xab, 0xbb, 0x52, 0x42, 0xd3, 0x57, 0x70, 0xd6, 0x2b,
+	0xdc, 0x2c, 0xe5, 0xa0, 0xab, 0x66, 0x29, 0x50, 0x9b, 0xe4, 0xc8, 0x66, 0x7e, 0xb4, 0xa3, 0x6f,
+	0x2c, 0x4b, 0xa7, 0x10, 0xad, 0x10, 0xfb, 0x4a, 0xf6, 0xd8, 0x25, 0xc7, 0x29, 0xc9, 0x43, 0x16,
+	0x6e, 0x89, 0x2f, 0x3d, 0x76, 0xd9, 0x5b, 0x80, 0x1b, 0x6e, 0xf8, 0xbc, 0x6f, 0xb8, 0x41, 0xfa,
+	0x18, 0x82, 0x15, 0x8e, 0xbe, 0x83, 0xed, 0x95, 0x9e, 0xc3, 0xf1, 0x86, 0xaf, 0x07, 0xe7, 0xfa,
+	0x94, 0x39, 0x90, 0xfd, 0x21, 0x70, 0xb6, 0x0f, 0xbb, 0xe6, 0xa6, 0xfe, 0x41, 0xdf, 0xc0, 0x83,
+	0xc1, 0x42, 0x9d, 0x90, 0x34, 0xc8, 0xe3, 0xd9, 0xd3, 0xe2, 0xbf, 0xff, 0x2c, 0xf6, 0x72, 0xb6,
+	0xd3, 0xd1, 0x27, 0x70, 0xa2, 0x50, 0x8f, 0x5d, 0x6d, 0xb3, 0x87, 0xcc, 0xa3, 0x2d, 0xef, 0x3b,
+	0x0a, 0xac, 0x13, 0x8f, 0xe8, 0x4b, 0x38, 0x55, 0xd8, 0xca, 0x0d, 0x36, 0xd5, 0x0a, 0x47, 0x9d,
+	0x1c, 0xa5, 0x41, 0x1e, 0xb1, 0xd8, 0x73, 0x9f, 0x70, 0xd4, 0xb3, 0xaf, 0x10, 0xde, 0xfa, 0xa9,
+	0xd2, 0x8f, 0x00, 0xef, 0xd1, 0xcc, 0x7d, 0xb1, 0xc9, 0x81, 0x9d, 0x83, 0xf9, 0x4d, 0x2e, 0xee,
+	0xb0, 0x6a, 0x3b, 0xcb, 0xee, 0xbd, 0x26, 0xd7, 0xcf, 0xbf, 0x4d, 0xad, 0xa4, 0x74, 0x0b, 0x54,
+	0xaf, 0xe5, 0xd0, 0x94, 0x42, 0xfa, 0x4d, 0x5a, 0x9c, 0xd8, 0xf3, 0xf2, 0xdf, 0x00, 0x06, 0x41,
+	0x78, 0x82, 0x60, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
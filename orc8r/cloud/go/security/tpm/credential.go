@@ -0,0 +1,95 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tpm
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	// symKeySize is the size of the AES-128 key of the standard RSA 2048 EK
+	// template, see TCG EK Credential Profile
+	symKeySize = 16
+
+	labelIdentity  = "IDENTITY"
+	labelStorage   = "STORAGE"
+	labelIntegrity = "INTEGRITY"
+)
+
+// MakeCredential protects a secret for the TPM holding the endorsement key,
+// like TPM2_MakeCredential. The TPM only releases the secret with
+// TPM2_ActivateCredential if the key with the given name is loaded in the
+// same TPM, which binds the key to the endorsement key.
+// It returns the TPM2B_ID_OBJECT and TPM2B_ENCRYPTED_SECRET to pass to
+// TPM2_ActivateCredential. The endorsement key must use the standard RSA 2048
+// EK template, with SHA-256 and AES-128-CFB.
+// See TPM 2.0 Library Part 1: Architecture, section 24.
+func MakeCredential(random io.Reader, ekPub *rsa.PublicKey, name, secret []byte) ([]byte, []byte, error) {
+	if len(secret) > sha256.Size {
+		return nil, nil, fmt.Errorf("credential secret is too long")
+	}
+	seed := make([]byte, symKeySize)
+	if _, err := io.ReadFull(random, seed); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate seed: %s", err)
+	}
+	encSeed, err := rsa.EncryptOAEP(sha256.New(), random, ekPub, seed, append([]byte(labelIdentity), 0))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt seed: %s", err)
+	}
+
+	symKey := KDFa(seed, labelStorage, name, nil, symKeySize*8)
+	block, err := aes.NewCipher(symKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	credential := &bytes.Buffer{}
+	WriteTPM2B(credential, secret)
+	encIdentity := make([]byte, credential.Len())
+	cipher.NewCFBEncrypter(block, make([]byte, aes.BlockSize)).XORKeyStream(encIdentity, credential.Bytes())
+
+	mac := hmac.New(sha256.New, KDFa(seed, labelIntegrity, nil, nil, sha256.Size*8))
+	mac.Write(encIdentity)
+	mac.Write(name)
+	idObject := &bytes.Buffer{}
+	WriteTPM2B(idObject, mac.Sum(nil))
+	idObject.Write(encIdentity)
+
+	credentialBlob := &bytes.Buffer{}
+	WriteTPM2B(credentialBlob, idObject.Bytes())
+	encSecret := &bytes.Buffer{}
+	WriteTPM2B(encSecret, encSeed)
+	return credentialBlob.Bytes(), encSecret.Bytes(), nil
+}
+
+// KDFa is the SP 800-108 counter mode KDF of the TPM with HMAC-SHA256,
+// returning bits / 8 bytes.
+// See TPM 2.0 Library Part 1: Architecture, section 11.4.10.2.
+func KDFa(key []byte, label string, contextU, contextV []byte, bits int) []byte {
+	size := (bits + 7) / 8
+	var out []byte
+	for counter := uint32(1); len(out) < size; counter++ {
+		mac := hmac.New(sha256.New, key)
+		binary.Write(mac, binary.BigEndian, counter)
+		mac.Write([]byte(label))
+		mac.Write([]byte{0})
+		mac.Write(contextU)
+		mac.Write(contextV)
+		binary.Write(mac, binary.BigEndian, uint32(bits))
+		out = mac.Sum(out)
+	}
+	return out[:size]
+}
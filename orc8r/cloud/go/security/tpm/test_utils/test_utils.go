@@ -0,0 +1,109 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package test_utils stands in for the gateway's TPM in tests: it encodes
+// keys the way a TPM would and activates credentials with an endorsement key
+// held in memory. Nothing here comes from a real TPM.
+package test_utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"magma/orc8r/cloud/go/security/tpm"
+)
+
+// AttestationKeyAttrs are the TPMA_OBJECT attributes of an attestation key:
+// fixedTPM, fixedParent, sensitiveDataOrigin, userWithAuth, restricted, sign
+const AttestationKeyAttrs = 0x00050072
+
+// MarshalAttestationKey returns the TPMT_PUBLIC of a restricted signing key
+// with the given public key and attributes
+func MarshalAttestationKey(pub crypto.PublicKey, attrs uint32) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgRSA))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		binary.Write(buf, binary.BigEndian, attrs)
+		tpm.WriteTPM2B(buf, nil)
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgNull))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgRSASSA))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		binary.Write(buf, binary.BigEndian, uint16(key.N.BitLen()))
+		binary.Write(buf, binary.BigEndian, uint32(0))
+		tpm.WriteTPM2B(buf, key.N.Bytes())
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgECC))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		binary.Write(buf, binary.BigEndian, attrs)
+		tpm.WriteTPM2B(buf, nil)
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgNull))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgECDSA))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		binary.Write(buf, binary.BigEndian, uint16(0x0003)) // TPM_ECC_NIST_P256
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgNull))
+		tpm.WriteTPM2B(buf, key.X.Bytes())
+		tpm.WriteTPM2B(buf, key.Y.Bytes())
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return buf.Bytes(), nil
+}
+
+// ActivateCredential recovers the secret of a credential made for the
+// endorsement key and the key with the given name, like
+// TPM2_ActivateCredential
+func ActivateCredential(ek *rsa.PrivateKey, name, credentialBlob, encSecret []byte) ([]byte, error) {
+	idObject, err := tpm.ReadTPM2B(bytes.NewReader(credentialBlob))
+	if err != nil {
+		return nil, err
+	}
+	encSeed, err := tpm.ReadTPM2B(bytes.NewReader(encSecret))
+	if err != nil {
+		return nil, err
+	}
+	seed, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, ek, encSeed, []byte("IDENTITY\x00"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt seed: %s", err)
+	}
+
+	r := bytes.NewReader(idObject)
+	integrity, err := tpm.ReadTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	encIdentity := idObject[len(idObject)-r.Len():]
+	mac := hmac.New(sha256.New, tpm.KDFa(seed, "INTEGRITY", nil, nil, sha256.Size*8))
+	mac.Write(encIdentity)
+	mac.Write(name)
+	if !hmac.Equal(integrity, mac.Sum(nil)) {
+		return nil, fmt.Errorf("integrity check failed")
+	}
+
+	block, err := aes.NewCipher(tpm.KDFa(seed, "STORAGE", name, nil, 128))
+	if err != nil {
+		return nil, err
+	}
+	credential := make([]byte, len(encIdentity))
+	cipher.NewCFBDecrypter(block, make([]byte, aes.BlockSize)).XORKeyStream(credential, encIdentity)
+	return tpm.ReadTPM2B(bytes.NewReader(credential))
+}
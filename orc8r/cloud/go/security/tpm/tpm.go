@@ -0,0 +1,257 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package tpm parses the TPM 2.0 keys registered for HARDWARE_TPM2_SHA256
+// gateways, and binds their attestation keys to their endorsement keys with
+// credential activation. See TPM 2.0 Library Part 1: Architecture and
+// Part 2: Structures.
+package tpm
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// TPM 2.0 constants, see TPM 2.0 Library Part 2: Structures
+const (
+	AlgRSA    = 0x0001 // TPM_ALG_RSA
+	AlgSHA256 = 0x000B // TPM_ALG_SHA256
+	AlgNull   = 0x0010 // TPM_ALG_NULL
+	AlgRSASSA = 0x0014 // TPM_ALG_RSASSA
+	AlgECDSA  = 0x0018 // TPM_ALG_ECDSA
+	AlgECC    = 0x0023 // TPM_ALG_ECC
+
+	eccNISTP256 = 0x0003 // TPM_ECC_NIST_P256
+	eccNISTP384 = 0x0004 // TPM_ECC_NIST_P384
+
+	// TPMA_OBJECT bits
+	attrFixedTPM            = 1 << 1
+	attrFixedParent         = 1 << 4
+	attrSensitiveDataOrigin = 1 << 5
+	attrRestricted          = 1 << 16
+	attrDecrypt             = 1 << 17
+	attrSign                = 1 << 18
+
+	// attestationKeyAttrs are the attributes of a key generated by the TPM,
+	// which can't be duplicated to another TPM and only signs data generated
+	// by the TPM, such as quotes
+	attestationKeyAttrs = attrFixedTPM | attrFixedParent | attrSensitiveDataOrigin | attrRestricted | attrSign
+
+	minEKBits = 2048
+)
+
+// ChallengeKey is the registered key of a HARDWARE_TPM2_SHA256 gateway. It's
+// stored DER encoded as:
+//
+//	TPMChallengeKey ::= SEQUENCE {
+//	    akPublic OCTET STRING, -- TPMT_PUBLIC of the attestation key
+//	    ekCert   OCTET STRING  -- DER encoded endorsement key certificate
+//	}
+type ChallengeKey struct {
+	AKPublic []byte
+	EKCert   []byte
+}
+
+// AttestationKey is a parsed TPM attestation key
+type AttestationKey struct {
+	// Public is the *rsa.PublicKey or *ecdsa.PublicKey of the key
+	Public interface{}
+	// Name is the TPM name of the key, which the TPM checks during
+	// credential activation
+	Name []byte
+}
+
+// MarshalChallengeKey returns the DER encoding of the challenge key
+func MarshalChallengeKey(key *ChallengeKey) ([]byte, error) {
+	return asn1.Marshal(*key)
+}
+
+// ParseChallengeKey parses and validates a DER encoded challenge key. The
+// attestation key must be a restricted signing key generated by the TPM, and
+// the endorsement key must be an RSA key of at least 2048 bits.
+func ParseChallengeKey(der []byte) (*AttestationKey, *x509.Certificate, error) {
+	key := ChallengeKey{}
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse TPM challenge key: %s", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, fmt.Errorf("trailing data after TPM challenge key")
+	}
+	ak, err := ParseAttestationKey(key.AKPublic)
+	if err != nil {
+		return nil, nil, err
+	}
+	ekCert, err := x509.ParseCertificate(key.EKCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse endorsement key certificate: %s", err)
+	}
+	ekPub, ok := ekCert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported endorsement key type %T, expected RSA", ekCert.PublicKey)
+	}
+	if ekPub.N.BitLen() < minEKBits {
+		return nil, nil, fmt.Errorf("endorsement key is too short")
+	}
+	return ak, ekCert, nil
+}
+
+// ParseAttestationKey parses the TPMT_PUBLIC of an attestation key
+func ParseAttestationKey(tpmtPublic []byte) (*AttestationKey, error) {
+	r := bytes.NewReader(tpmtPublic)
+	var keyType, nameAlg uint16
+	var attrs uint32
+	if err := readAll(r, &keyType, &nameAlg, &attrs); err != nil {
+		return nil, fmt.Errorf("failed to parse attestation key: %s", err)
+	}
+	if nameAlg != AlgSHA256 {
+		return nil, fmt.Errorf("unsupported attestation key name algorithm: %#04x", nameAlg)
+	}
+	if attrs&attestationKeyAttrs != attestationKeyAttrs || attrs&attrDecrypt != 0 {
+		return nil, fmt.Errorf("attestation key isn't a restricted signing key generated by the TPM: attributes %#08x", attrs)
+	}
+	// authPolicy
+	if _, err := ReadTPM2B(r); err != nil {
+		return nil, fmt.Errorf("failed to parse attestation key: %s", err)
+	}
+	// Restricted signing keys have no symmetric algorithm
+	var symAlg, scheme, schemeHash uint16
+	if err := readAll(r, &symAlg, &scheme); err != nil {
+		return nil, fmt.Errorf("failed to parse attestation key: %s", err)
+	}
+	if symAlg != AlgNull {
+		return nil, fmt.Errorf("attestation key has a symmetric algorithm")
+	}
+	if scheme != AlgNull {
+		if err := readAll(r, &schemeHash); err != nil {
+			return nil, fmt.Errorf("failed to parse attestation key: %s", err)
+		}
+	}
+
+	var public interface{}
+	var err error
+	switch keyType {
+	case AlgRSA:
+		if scheme != AlgRSASSA || schemeHash != AlgSHA256 {
+			return nil, fmt.Errorf("unsupported RSA attestation key scheme %#04x/%#04x", scheme, schemeHash)
+		}
+		public, err = readRSAPublic(r)
+	case AlgECC:
+		if scheme != AlgECDSA || schemeHash != AlgSHA256 {
+			return nil, fmt.Errorf("unsupported ECC attestation key scheme %#04x/%#04x", scheme, schemeHash)
+		}
+		public, err = readECCPublic(r)
+	default:
+		return nil, fmt.Errorf("unsupported attestation key type: %#04x", keyType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attestation key: %s", err)
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("trailing data after attestation key")
+	}
+
+	digest := sha256.Sum256(tpmtPublic)
+	name := make([]byte, 2, 2+len(digest))
+	binary.BigEndian.PutUint16(name, AlgSHA256)
+	name = append(name, digest[:]...)
+	return &AttestationKey{Public: public, Name: name}, nil
+}
+
+// readRSAPublic reads the keyBits and exponent of TPMS_RSA_PARMS, and the
+// modulus
+func readRSAPublic(r *bytes.Reader) (*rsa.PublicKey, error) {
+	var keyBits uint16
+	var exponent uint32
+	if err := readAll(r, &keyBits, &exponent); err != nil {
+		return nil, err
+	}
+	modulus, err := ReadTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(modulus)
+	if n.BitLen() != int(keyBits) {
+		return nil, fmt.Errorf("RSA modulus doesn't have %d bits", keyBits)
+	}
+	if exponent == 0 {
+		exponent = 65537
+	}
+	return &rsa.PublicKey{N: n, E: int(exponent)}, nil
+}
+
+// readECCPublic reads the curveID and kdf of TPMS_ECC_PARMS, and the point
+func readECCPublic(r *bytes.Reader) (*ecdsa.PublicKey, error) {
+	var curveID, kdf uint16
+	if err := readAll(r, &curveID, &kdf); err != nil {
+		return nil, err
+	}
+	if kdf != AlgNull {
+		return nil, fmt.Errorf("ECC attestation key has a KDF")
+	}
+	var curve elliptic.Curve
+	switch curveID {
+	case eccNISTP256:
+		curve = elliptic.P256()
+	case eccNISTP384:
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("unsupported ECC curve: %#04x", curveID)
+	}
+	x, err := ReadTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	y, err := ReadTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, fmt.Errorf("ECC point isn't on the curve")
+	}
+	return pub, nil
+}
+
+// ReadTPM2B reads a TPM2B sized buffer
+func ReadTPM2B(r *bytes.Reader) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if int(size) > r.Len() {
+		return nil, io.ErrUnexpectedEOF
+	}
+	buf := make([]byte, size)
+	_, err := io.ReadFull(r, buf)
+	return buf, err
+}
+
+// WriteTPM2B writes a TPM2B sized buffer
+func WriteTPM2B(buf *bytes.Buffer, data []byte) {
+	binary.Write(buf, binary.BigEndian, uint16(len(data)))
+	buf.Write(data)
+}
+
+func readAll(r io.Reader, values ...interface{}) error {
+	for _, v := range values {
+		if err := binary.Read(r, binary.BigEndian, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tpm_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/security/tpm"
+	tpm_test_utils "magma/orc8r/cloud/go/security/tpm/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChallengeKey(t *testing.T) {
+	ek, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ekCert := createEKCert(t, &ek.PublicKey, ek)
+
+	ecdsaAK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaAK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	for _, pub := range []interface{}{&ecdsaAK.PublicKey, &rsaAK.PublicKey} {
+		akPublic, err := tpm_test_utils.MarshalAttestationKey(pub, tpm_test_utils.AttestationKeyAttrs)
+		require.NoError(t, err)
+		der, err := tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic, EKCert: ekCert})
+		require.NoError(t, err)
+		ak, parsedEKCert, err := tpm.ParseChallengeKey(der)
+		require.NoError(t, err)
+		assert.Equal(t, pub, ak.Public)
+		assert.Equal(t, ekCert, parsedEKCert.Raw)
+		// nameAlg followed by the SHA-256 digest of the TPMT_PUBLIC
+		assert.Len(t, ak.Name, 34)
+		assert.Equal(t, []byte{0x00, 0x0B}, ak.Name[:2])
+	}
+
+	// Keys which can be duplicated or decrypt aren't attestation keys
+	for _, attrs := range []uint32{
+		tpm_test_utils.AttestationKeyAttrs &^ (1 << 1),  // fixedTPM
+		tpm_test_utils.AttestationKeyAttrs &^ (1 << 16), // restricted
+		tpm_test_utils.AttestationKeyAttrs | (1 << 17),  // decrypt
+	} {
+		akPublic, err := tpm_test_utils.MarshalAttestationKey(&ecdsaAK.PublicKey, attrs)
+		require.NoError(t, err)
+		der, err := tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic, EKCert: ekCert})
+		require.NoError(t, err)
+		_, _, err = tpm.ParseChallengeKey(der)
+		assert.Contains(t, err.Error(), "attestation key isn't a restricted signing key generated by the TPM")
+	}
+
+	akPublic, err := tpm_test_utils.MarshalAttestationKey(&ecdsaAK.PublicKey, tpm_test_utils.AttestationKeyAttrs)
+	require.NoError(t, err)
+	der, err := tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic[:len(akPublic)-1], EKCert: ekCert})
+	require.NoError(t, err)
+	_, _, err = tpm.ParseChallengeKey(der)
+	assert.Contains(t, err.Error(), "failed to parse attestation key")
+
+	// ECC endorsement keys aren't supported
+	eccEK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err = tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic, EKCert: createEKCert(t, &eccEK.PublicKey, eccEK)})
+	require.NoError(t, err)
+	_, _, err = tpm.ParseChallengeKey(der)
+	assert.Contains(t, err.Error(), "unsupported endorsement key type")
+
+	_, _, err = tpm.ParseChallengeKey([]byte("garbage"))
+	assert.Contains(t, err.Error(), "failed to parse TPM challenge key")
+}
+
+func TestMakeCredential(t *testing.T) {
+	ek, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ak, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	akPublic, err := tpm_test_utils.MarshalAttestationKey(&ak.PublicKey, tpm_test_utils.AttestationKeyAttrs)
+	require.NoError(t, err)
+	parsedAK, err := tpm.ParseAttestationKey(akPublic)
+	require.NoError(t, err)
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	credentialBlob, encSecret, err := tpm.MakeCredential(rand.Reader, &ek.PublicKey, parsedAK.Name, secret)
+	require.NoError(t, err)
+	activated, err := tpm_test_utils.ActivateCredential(ek, parsedAK.Name, credentialBlob, encSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, activated)
+
+	// The credential can't be activated for another key, or by another TPM
+	otherAK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherAKPublic, err := tpm_test_utils.MarshalAttestationKey(&otherAK.PublicKey, tpm_test_utils.AttestationKeyAttrs)
+	require.NoError(t, err)
+	parsedOtherAK, err := tpm.ParseAttestationKey(otherAKPublic)
+	require.NoError(t, err)
+	_, err = tpm_test_utils.ActivateCredential(ek, parsedOtherAK.Name, credentialBlob, encSecret)
+	assert.EqualError(t, err, "integrity check failed")
+
+	otherEK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = tpm_test_utils.ActivateCredential(otherEK, parsedAK.Name, credentialBlob, encSecret)
+	assert.Contains(t, err.Error(), "failed to decrypt seed")
+
+	_, _, err = tpm.MakeCredential(rand.Reader, &ek.PublicKey, parsedAK.Name, make([]byte, 33))
+	assert.EqualError(t, err, "credential secret is too long")
+}
+
+func createEKCert(t *testing.T, pub, priv interface{}) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "EK"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(t, err)
+	return der
+}
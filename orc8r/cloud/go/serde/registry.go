@@ -95,6 +95,18 @@ func Deserialize(domain string, typeVal string, data []byte) (interface{}, error
 	return subregistry.deserialize(typeVal, data)
 }
 
+// GetSerdesForDomain returns all Serdes registered for the domain, sorted by
+// type. This function is thread-safe.
+func GetSerdesForDomain(domain string) []Serde {
+	registry.RLock()
+	defer registry.RUnlock()
+	subregistry, ok := registry.serdeRegistriesByDomain[domain]
+	if !ok {
+		return []Serde{}
+	}
+	return subregistry.list()
+}
+
 func getSerdesByDomain(serdesToGroup []Serde) map[string][]Serde {
 	ret := map[string][]Serde{}
 	for _, s := range serdesToGroup {
@@ -203,6 +215,17 @@ func (s *serdes) deserialize(t string, data []byte) (interface{}, error) {
 	return serde.Deserialize(data)
 }
 
+func (s *serdes) list() []Serde {
+	s.RLock()
+	defer s.RUnlock()
+	ret := make([]Serde, 0, len(s.serdesByKey))
+	for _, serde := range s.serdesByKey {
+		ret = append(ret, serde)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetType() < ret[j].GetType() })
+	return ret
+}
+
 func (s *serdes) getSerdeUnsafe(t string) (Serde, error) {
 	serde, ok := s.serdesByKey[t]
 	if !ok {
@@ -66,3 +66,28 @@ func TestDeserialize(t *testing.T) {
 	assert.EqualError(t, err, "No Serde found for type baz")
 
 }
+
+func TestGetSerdesForDomain(t *testing.T) {
+	serde.UnregisterAllSerdes(t)
+	defer func() {
+		serde.UnregisterAllSerdes(t)
+	}()
+
+	assert.Empty(t, serde.GetSerdesForDomain("foo"))
+
+	mockSerde1 := &mocks.Serde{}
+	mockSerde1.On("GetDomain").Return("foo")
+	mockSerde1.On("GetType").Return("b")
+	mockSerde2 := &mocks.Serde{}
+	mockSerde2.On("GetDomain").Return("foo")
+	mockSerde2.On("GetType").Return("a")
+	mockSerde3 := &mocks.Serde{}
+	mockSerde3.On("GetDomain").Return("bar")
+	mockSerde3.On("GetType").Return("c")
+
+	err := serde.RegisterSerdes(mockSerde1, mockSerde2, mockSerde3)
+	assert.NoError(t, err)
+	assert.Equal(t, []serde.Serde{mockSerde2, mockSerde1}, serde.GetSerdesForDomain("foo"))
+	assert.Equal(t, []serde.Serde{mockSerde3}, serde.GetSerdesForDomain("bar"))
+	assert.Empty(t, serde.GetSerdesForDomain("baz"))
+}
@@ -68,6 +68,18 @@ func (srv *testStateServer) SyncStates(ctx context.Context, req *protos.SyncStat
 	return &protos.SyncStatesResponse{UnsyncedStates: []*protos.IDAndVersion{}}, nil
 }
 
+func (srv *testStateServer) SearchStates(ctx context.Context, req *protos.SearchStatesRequest) (*protos.SearchStatesResponse, error) {
+	return &protos.SearchStatesResponse{}, nil
+}
+
+func (srv *testStateServer) GetStateHistory(ctx context.Context, req *protos.GetStateHistoryRequest) (*protos.GetStateHistoryResponse, error) {
+	return &protos.GetStateHistoryResponse{}, nil
+}
+
+func (srv *testStateServer) WatchExpiredStates(req *protos.WatchExpiredStatesRequest, stream protos.StateService_WatchExpiredStatesServer) error {
+	return nil
+}
+
 func TestIdentityInjector(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	deviceTestInit.StartTestService(t)
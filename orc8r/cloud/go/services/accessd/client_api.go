@@ -12,8 +12,10 @@ package accessd
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
 	"golang.org/x/net/context"
 
 	merrors "magma/orc8r/cloud/go/errors"
@@ -184,3 +186,193 @@ func ListOperators() ([]*protos.Identity, error) {
 	}
 	return opslist.List, nil
 }
+
+// SetRole creates a new Role or overwrites the existing Role with the same
+// name
+func SetRole(role *accessprotos.Role) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.SetRole(context.Background(), role)
+	if err != nil {
+		errMsg := fmt.Sprintf("Set Role %s error: %s", role.GetName(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// CreateRole creates a new Role, it returns an AlreadyExists error if a Role
+// with the same name exists
+func CreateRole(role *accessprotos.Role) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.CreateRole(context.Background(), role)
+	return err
+}
+
+// UpdateRole overwrites the existing Role with the same name, it returns
+// a NotFound error if the Role doesn't exist
+func UpdateRole(role *accessprotos.Role) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.UpdateRole(context.Background(), role)
+	return err
+}
+
+// GetRole returns the Role with the given name
+func GetRole(name string) (*accessprotos.Role, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	role, err := client.GetRole(context.Background(), &accessprotos.RoleName{Name: name})
+	if err != nil {
+		errMsg := fmt.Sprintf("Get Role %s error: %s", name, err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return role, nil
+}
+
+// DeleteRole removes the Role, the Role must not be bound to any operator
+func DeleteRole(name string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteRole(context.Background(), &accessprotos.RoleName{Name: name})
+	if err != nil {
+		errMsg := fmt.Sprintf("Delete Role %s error: %s", name, err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// ListRoles returns all Roles
+func ListRoles() ([]*accessprotos.Role, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ListRoles(context.Background(), &protos.Void{})
+	if err != nil {
+		errMsg := fmt.Sprintf("List Roles error: %s", err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp.Roles, nil
+}
+
+// SetOperatorRoles overwrites the Roles bound to the operator
+func SetOperatorRoles(operator *protos.Identity, roles []string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.SetOperatorRoles(
+		context.Background(),
+		&accessprotos.RoleBindingRequest{Operator: operator, Roles: roles})
+	if err != nil {
+		errMsg := fmt.Sprintf("Set Roles for Operator %s error: %s", operator.HashString(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// GetOperatorRoles returns the names of the Roles bound to the operator
+func GetOperatorRoles(operator *protos.Identity) ([]string, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetOperatorACL(context.Background(), operator)
+	if err != nil {
+		errMsg := fmt.Sprintf("Get Roles for Operator %s error: %s", operator.HashString(), err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp.Roles, nil
+}
+
+// IssueToken issues a new API token for the operator with the given scope,
+// which must be a subset of the operator's ACL. The returned bearer token
+// can't be retrieved later
+func IssueToken(
+	operator *protos.Identity,
+	entities []*accessprotos.AccessControl_Entity,
+	description string,
+	expiresAt time.Time,
+) (*accessprotos.IssuedToken, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	expiresAtProto, err := ptypes.TimestampProto(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.IssueToken(
+		context.Background(),
+		&accessprotos.IssueTokenRequest{
+			Operator:    operator,
+			Entities:    entities,
+			Description: description,
+			ExpiresAt:   expiresAtProto,
+		})
+	if err != nil {
+		errMsg := fmt.Sprintf("Issue Token for Operator %s error: %s", operator.HashString(), err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp, nil
+}
+
+// RevokeToken revokes the API token with the given ID
+func RevokeToken(tokenID string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.RevokeToken(context.Background(), &accessprotos.TokenID{Id: tokenID})
+	if err != nil {
+		errMsg := fmt.Sprintf("Revoke Token %s error: %s", tokenID, err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// ListTokens returns all API tokens of the operator
+func ListTokens(operator *protos.Identity) ([]*accessprotos.APIToken, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ListTokens(context.Background(), operator)
+	if err != nil {
+		errMsg := fmt.Sprintf("List Tokens for Operator %s error: %s", operator.HashString(), err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp.Tokens, nil
+}
+
+// CheckTokenPermissions verifies the bearer token's permissions for given
+// entities, with the same semantics as CheckPermissions, and returns the
+// token's operator Identity
+func CheckTokenPermissions(bearer string, ents ...*accessprotos.AccessControl_Entity) (*protos.Identity, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.CheckTokenPermissions(
+		context.Background(), &accessprotos.TokenPermissionsRequest{Bearer: bearer, Entities: ents})
+}
@@ -19,6 +19,9 @@ const (
 	operatorNetworkPath     = operatorEntitiesPath + "/network/:network_id"
 	operatorPermissionsPath = operatorNetworkPath + "/permissions"
 	operatorCertificatePath = operatorsDetailPath + "/certificate"
+	operatorRolesPath       = operatorsDetailPath + "/roles"
+	rolesRootPath           = obsidian.RestRoot + obsidian.UrlSep + "roles"
+	roleDetailPath          = rolesRootPath + "/:role_name"
 )
 
 // GetObsidianHandlers returns all the handlers for accessd
@@ -84,5 +87,42 @@ func GetObsidianHandlers() []obsidian.Handler {
 			Methods:     obsidian.DELETE,
 			HandlerFunc: DeleteOperatorCertificateHandler,
 		},
+
+		// role_handlers.go
+		{
+			Path:        operatorRolesPath,
+			Methods:     obsidian.GET,
+			HandlerFunc: GetOperatorRolesHandler,
+		},
+		{
+			Path:        operatorRolesPath,
+			Methods:     obsidian.PUT,
+			HandlerFunc: PutOperatorRolesHandler,
+		},
+		{
+			Path:        rolesRootPath,
+			Methods:     obsidian.GET,
+			HandlerFunc: GetRolesRootHandler,
+		},
+		{
+			Path:        rolesRootPath,
+			Methods:     obsidian.POST,
+			HandlerFunc: PostRolesRootHandler,
+		},
+		{
+			Path:        roleDetailPath,
+			Methods:     obsidian.GET,
+			HandlerFunc: GetRoleHandler,
+		},
+		{
+			Path:        roleDetailPath,
+			Methods:     obsidian.PUT,
+			HandlerFunc: PutRoleHandler,
+		},
+		{
+			Path:        roleDetailPath,
+			Methods:     obsidian.DELETE,
+			HandlerFunc: DeleteRoleHandler,
+		},
 	}
 }
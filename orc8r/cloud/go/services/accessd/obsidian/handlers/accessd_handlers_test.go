@@ -54,6 +54,8 @@ func cleanup(t *testing.T) {
 	assert.NoError(t, err)
 	err = test_utils.GetMockDatastoreInstance().DeleteTable("certificate_info_db")
 	assert.NoError(t, err)
+	err = test_utils.GetMockDatastoreInstance().DeleteTable("access_roles")
+	assert.NoError(t, err)
 }
 
 func TestListOperators(t *testing.T) {
@@ -391,6 +393,160 @@ func TestPostDeleteCertificate(t *testing.T) {
 	assert.Equal(t, "[]", rec.Body.String())
 }
 
+func TestRoles(t *testing.T) {
+	defer cleanup(t)
+	testOperatorSN, _, _ := testInit(t)
+	role := &models.Role{
+		Name:        "net3-reader",
+		Description: "Read network 3",
+		Entities: models.ACLType{
+			&models.ACLEntity{
+				EntityType: models.ACLEntityEntityTypeNETWORK,
+				NetworkID:  network3ID,
+				Permissions: models.PermissionsMask{
+					models.PermissionTypeREAD,
+					models.PermissionTypeNONE,
+				},
+			},
+		},
+	}
+	roleBytes, err := role.MarshalBinary()
+	assert.NoError(t, err)
+	e := echo.New()
+	req := httptest.NewRequest(echo.POST, "/", strings.NewReader(string(roleBytes)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err = handlers.PostRolesRootHandler(e.NewContext(req, rec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// Role names are unique
+	req = httptest.NewRequest(echo.POST, "/", strings.NewReader(string(roleBytes)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err = handlers.PostRolesRootHandler(e.NewContext(req, httptest.NewRecorder()))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	rec = httptest.NewRecorder()
+	err = handlers.GetRolesRootHandler(e.NewContext(req, rec))
+	assert.NoError(t, err)
+	var roles []*models.Role
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &roles))
+	assert.Equal(t, []*models.Role{role}, roles)
+
+	// Bind the role to operator 1
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(`["net3-reader"]`))
+	req.Header.Set(access.CLIENT_CERT_SN_KEY, testOperatorSN)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("operator_id")
+	c.SetParamValues(string(operator1ID))
+	err = handlers.PutOperatorRolesHandler(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	req.Header.Set(access.CLIENT_CERT_SN_KEY, testOperatorSN)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("operator_id")
+	c.SetParamValues(string(operator1ID))
+	err = handlers.GetOperatorRolesHandler(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `["net3-reader"]`, strings.TrimSpace(rec.Body.String()))
+
+	// Role permissions apply to the operator
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	req.Header.Set(access.CLIENT_CERT_SN_KEY, testOperatorSN)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("operator_id", "network_id")
+	c.SetParamValues(string(operator1ID), string(network3ID))
+	err = handlers.GetOperatorPermissionsHandler(c)
+	assert.NoError(t, err)
+	var permissions models.PermissionsMask
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &permissions))
+	assert.ElementsMatch(t, models.PermissionsMask{models.PermissionTypeREAD, models.PermissionTypeNONE}, permissions)
+
+	// Update the role
+	role.Entities[0].Permissions = models.PermissionsMask{models.PermissionTypeREAD, models.PermissionTypeWRITE}
+	roleBytes, err = role.MarshalBinary()
+	assert.NoError(t, err)
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(string(roleBytes)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	err = handlers.PutRoleHandler(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Updates are validated and only apply to existing roles
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(`{"entities":[{"entity_type":"foo"}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	err = handlers.PutRoleHandler(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(`{"name":"unknown"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("role_name")
+	c.SetParamValues("unknown")
+	err = handlers.PutRoleHandler(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	err = handlers.GetRoleHandler(c)
+	assert.NoError(t, err)
+	actualRole := &models.Role{}
+	assert.NoError(t, actualRole.UnmarshalBinary(rec.Body.Bytes()))
+	assert.Equal(t, role, actualRole)
+
+	// Bound roles can't be deleted
+	req = httptest.NewRequest(echo.DELETE, "/", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	assert.Error(t, handlers.DeleteRoleHandler(c))
+
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(`[]`))
+	req.Header.Set(access.CLIENT_CERT_SN_KEY, testOperatorSN)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("operator_id")
+	c.SetParamValues(string(operator1ID))
+	assert.NoError(t, handlers.PutOperatorRolesHandler(c))
+
+	req = httptest.NewRequest(echo.DELETE, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	assert.NoError(t, handlers.DeleteRoleHandler(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("role_name")
+	c.SetParamValues("net3-reader")
+	err = handlers.GetRoleHandler(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
 // Helpers
 
 func assertOperatorRecordResponse(t *testing.T, expectedRecord *models.OperatorRecord, response string) {
@@ -15,38 +15,34 @@ import (
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/protos"
-	"magma/orc8r/cloud/go/services/accessd"
 	"magma/orc8r/cloud/go/services/accessd/obsidian/models"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
 	"magma/orc8r/cloud/go/services/certifier"
 
 	"github.com/labstack/echo"
 )
 
 func getOperatorForRead(c echo.Context) (*protos.Identity, *echo.HTTPError) {
-	caller, err := access.RequestOperator(c)
-	if err != nil {
-		return nil, obsidian.HttpError(err)
-	}
 	operator, httpErr := getOperator(c)
 	if httpErr != nil {
 		return nil, httpErr
 	}
-	if err := accessd.CheckReadPermission(caller, operator); err != nil {
+	err := access.CheckRequestPermissions(
+		c, &accessprotos.AccessControl_Entity{Id: operator, Permissions: accessprotos.AccessControl_READ})
+	if err != nil {
 		return nil, obsidian.HttpError(err, http.StatusForbidden)
 	}
 	return operator, nil
 }
 
 func getOperatorForWrite(c echo.Context) (*protos.Identity, *echo.HTTPError) {
-	caller, err := access.RequestOperator(c)
-	if err != nil {
-		return nil, obsidian.HttpError(err)
-	}
 	operator, httpErr := getOperator(c)
 	if httpErr != nil {
 		return nil, httpErr
 	}
-	if err := accessd.CheckWritePermission(caller, operator); err != nil {
+	err := access.CheckRequestPermissions(
+		c, &accessprotos.AccessControl_Entity{Id: operator, Permissions: accessprotos.AccessControl_WRITE})
+	if err != nil {
 		return nil, obsidian.HttpError(err, http.StatusForbidden)
 	}
 	return operator, nil
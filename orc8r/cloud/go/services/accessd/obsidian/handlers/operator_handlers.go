@@ -78,9 +78,12 @@ func GetOperatorsDetailHandler(c echo.Context) error {
 		accessControlList = append(accessControlList, entity)
 	}
 	acl := models.ACLFromProto(accessControlList)
+	// as for the ACL, an operator without an ACL record has no roles
+	roles, _ := accessd.GetOperatorRoles(operator)
 	operatorRecord := &models.OperatorRecord{
 		CertificateSns: certificateSNs,
 		Entities:       acl,
+		Roles:          models.RoleNamesFromProto(roles),
 	}
 	return c.JSON(http.StatusOK, operatorRecord)
 }
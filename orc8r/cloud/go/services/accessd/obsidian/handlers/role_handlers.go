@@ -0,0 +1,143 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/accessd"
+	"magma/orc8r/cloud/go/services/accessd/obsidian/models"
+
+	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles aren't network scoped, so role management requires supervisor
+// permissions (see access.FindRequestedIdentities)
+
+func GetRolesRootHandler(c echo.Context) error {
+	roles, err := accessd.ListRoles()
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to list roles: %s", err))
+	}
+	modelRoles := make([]*models.Role, len(roles))
+	for i, role := range roles {
+		modelRoles[i] = models.RoleFromProto(role)
+	}
+	return c.JSON(http.StatusOK, modelRoles)
+}
+
+func PostRolesRootHandler(c echo.Context) error {
+	role := &models.Role{}
+	if err := c.Bind(role); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := role.Validate(nil); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	err := accessd.CreateRole(models.RoleToProto(role))
+	if status.Code(err) == codes.AlreadyExists {
+		return obsidian.HttpError(fmt.Errorf("Role already exists"), http.StatusBadRequest)
+	}
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to create role %s: %s", role.Name, err))
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func GetRoleHandler(c echo.Context) error {
+	name, httpErr := getRoleName(c)
+	if httpErr != nil {
+		return httpErr
+	}
+	role, err := accessd.GetRole(name)
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to get role %s: %s", name, err), http.StatusNotFound)
+	}
+	return c.JSON(http.StatusOK, models.RoleFromProto(role))
+}
+
+func PutRoleHandler(c echo.Context) error {
+	name, httpErr := getRoleName(c)
+	if httpErr != nil {
+		return httpErr
+	}
+	role := &models.Role{}
+	if err := c.Bind(role); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if len(role.Name) == 0 {
+		role.Name = models.RoleName(name)
+	}
+	if string(role.Name) != name {
+		return obsidian.HttpError(fmt.Errorf("Role name can't be changed"), http.StatusBadRequest)
+	}
+	if err := role.Validate(nil); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	err := accessd.UpdateRole(models.RoleToProto(role))
+	if status.Code(err) == codes.NotFound {
+		return obsidian.HttpError(fmt.Errorf("Failed to get role %s: %s", name, err), http.StatusNotFound)
+	}
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to update role %s: %s", name, err))
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func DeleteRoleHandler(c echo.Context) error {
+	name, httpErr := getRoleName(c)
+	if httpErr != nil {
+		return httpErr
+	}
+	if err := accessd.DeleteRole(name); err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to delete role %s: %s", name, err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func GetOperatorRolesHandler(c echo.Context) error {
+	operator, httpErr := getOperatorForRead(c)
+	if httpErr != nil {
+		return httpErr
+	}
+	roles, err := accessd.GetOperatorRoles(operator)
+	if err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to get roles for %s: %s",
+			operator.String(), err.Error()))
+	}
+	return c.JSON(http.StatusOK, models.RoleNamesFromProto(roles))
+}
+
+func PutOperatorRolesHandler(c echo.Context) error {
+	operator, httpErr := getOperatorForWrite(c)
+	if httpErr != nil {
+		return httpErr
+	}
+	var names []models.RoleName
+	if err := c.Bind(&names); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := accessd.SetOperatorRoles(operator, models.RoleNamesToProto(names)); err != nil {
+		return obsidian.HttpError(fmt.Errorf("Failed to set roles for %s: %s",
+			operator.String(), err.Error()))
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func getRoleName(c echo.Context) (string, *echo.HTTPError) {
+	name := c.Param("role_name")
+	if name == "" {
+		return name, obsidian.HttpError(fmt.Errorf("Invalid/Missing Role Name"), http.StatusBadRequest)
+	}
+	return name, nil
+}
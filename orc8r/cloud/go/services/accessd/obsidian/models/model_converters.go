@@ -80,6 +80,38 @@ func ACLFromProto(accessControlList []*accessprotos.AccessControl_Entity) ACLTyp
 	return ACLType(aclEntities)
 }
 
+func RoleToProto(role *Role) *accessprotos.Role {
+	return &accessprotos.Role{
+		Name:        string(role.Name),
+		Description: role.Description,
+		Entities:    ACLToProto(role.Entities),
+	}
+}
+
+func RoleFromProto(role *accessprotos.Role) *Role {
+	return &Role{
+		Name:        RoleName(role.Name),
+		Description: role.Description,
+		Entities:    ACLFromProto(role.Entities),
+	}
+}
+
+func RoleNamesToProto(names []RoleName) []string {
+	roles := make([]string, len(names))
+	for i, name := range names {
+		roles[i] = string(name)
+	}
+	return roles
+}
+
+func RoleNamesFromProto(roles []string) []RoleName {
+	names := make([]RoleName, len(roles))
+	for i, role := range roles {
+		names[i] = RoleName(role)
+	}
+	return names
+}
+
 func CSRToProto(csr *CsrType, operator *protos.Identity) *protos.CSR {
 	return &protos.CSR{
 		Id: operator,
@@ -23,6 +23,9 @@ type OperatorRecord struct {
 
 	// entities
 	Entities ACLType `json:"entities,omitempty"`
+
+	// roles
+	Roles []RoleName `json:"roles,omitempty"`
 }
 
 // Validate validates this operator record
@@ -37,6 +40,10 @@ func (m *OperatorRecord) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRoles(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -79,6 +86,26 @@ func (m *OperatorRecord) validateEntities(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *OperatorRecord) validateRoles(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Roles) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Roles); i++ {
+
+		if err := m.Roles[i].Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("roles" + "." + strconv.Itoa(i))
+			}
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *OperatorRecord) MarshalBinary() ([]byte, error) {
 	if m == nil {
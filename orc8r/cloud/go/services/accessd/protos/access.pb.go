@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -136,11 +137,14 @@ func (m *AccessControl_Entity) GetPermissions() AccessControl_Permission {
 
 // Operator's Access Control List (map)
 type AccessControl_List struct {
-	Operator             *protos.Identity                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
-	Entities             map[string]*AccessControl_Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+	Operator *protos.Identity                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Entities map[string]*AccessControl_Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Names of the Roles bound to the operator, permissions of the roles'
+	// entities are added to the operator's own entities' permissions
+	Roles                []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AccessControl_List) Reset()         { *m = AccessControl_List{} }
@@ -182,6 +186,13 @@ func (m *AccessControl_List) GetEntities() map[string]*AccessControl_Entity {
 	return nil
 }
 
+func (m *AccessControl_List) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
 // RPC Request/Responce used to 1) manage AND 2) check permissions
 // 1. When Adding or Modifying permissions entities will represent managed
 // entities Operator's permissions
@@ -312,11 +323,529 @@ func (m *AccessControl_Lists) XXX_DiscardUnknown() {
 	xxx_messageInfo_AccessControl_Lists.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AccessControl_Lists proto.InternalMessageInfo
-
-func (m *AccessControl_Lists) GetAcls() []*AccessControl_List {
+var xxx_messageInfo_AccessControl_Lists proto.InternalMessageInfo
+
+func (m *AccessControl_Lists) GetAcls() []*AccessControl_List {
+	if m != nil {
+		return m.Acls
+	}
+	return nil
+}
+
+// Named set of entities & their permissions, which can be bound to operators
+type Role struct {
+	Name                 string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string                  `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Entities             []*AccessControl_Entity `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *Role) Reset()         { *m = Role{} }
+func (m *Role) String() string { return proto.CompactTextString(m) }
+func (*Role) ProtoMessage()    {}
+func (*Role) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{1}
+}
+
+func (m *Role) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Role.Unmarshal(m, b)
+}
+func (m *Role) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Role.Marshal(b, m, deterministic)
+}
+func (m *Role) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Role.Merge(m, src)
+}
+func (m *Role) XXX_Size() int {
+	return xxx_messageInfo_Role.Size(m)
+}
+func (m *Role) XXX_DiscardUnknown() {
+	xxx_messageInfo_Role.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Role proto.InternalMessageInfo
+
+func (m *Role) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Role) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Role) GetEntities() []*AccessControl_Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+type Roles struct {
+	Roles                []*Role  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Roles) Reset()         { *m = Roles{} }
+func (m *Roles) String() string { return proto.CompactTextString(m) }
+func (*Roles) ProtoMessage()    {}
+func (*Roles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{2}
+}
+
+func (m *Roles) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Roles.Unmarshal(m, b)
+}
+func (m *Roles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Roles.Marshal(b, m, deterministic)
+}
+func (m *Roles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Roles.Merge(m, src)
+}
+func (m *Roles) XXX_Size() int {
+	return xxx_messageInfo_Roles.Size(m)
+}
+func (m *Roles) XXX_DiscardUnknown() {
+	xxx_messageInfo_Roles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Roles proto.InternalMessageInfo
+
+func (m *Roles) GetRoles() []*Role {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type RoleName struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleName) Reset()         { *m = RoleName{} }
+func (m *RoleName) String() string { return proto.CompactTextString(m) }
+func (*RoleName) ProtoMessage()    {}
+func (*RoleName) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{3}
+}
+
+func (m *RoleName) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleName.Unmarshal(m, b)
+}
+func (m *RoleName) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleName.Marshal(b, m, deterministic)
+}
+func (m *RoleName) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleName.Merge(m, src)
+}
+func (m *RoleName) XXX_Size() int {
+	return xxx_messageInfo_RoleName.Size(m)
+}
+func (m *RoleName) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleName.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleName proto.InternalMessageInfo
+
+func (m *RoleName) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// RPC Request used to set the Roles bound to an operator
+type RoleBindingRequest struct {
+	Operator             *protos.Identity `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Roles                []string         `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RoleBindingRequest) Reset()         { *m = RoleBindingRequest{} }
+func (m *RoleBindingRequest) String() string { return proto.CompactTextString(m) }
+func (*RoleBindingRequest) ProtoMessage()    {}
+func (*RoleBindingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{4}
+}
+
+func (m *RoleBindingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleBindingRequest.Unmarshal(m, b)
+}
+func (m *RoleBindingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleBindingRequest.Marshal(b, m, deterministic)
+}
+func (m *RoleBindingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleBindingRequest.Merge(m, src)
+}
+func (m *RoleBindingRequest) XXX_Size() int {
+	return xxx_messageInfo_RoleBindingRequest.Size(m)
+}
+func (m *RoleBindingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleBindingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleBindingRequest proto.InternalMessageInfo
+
+func (m *RoleBindingRequest) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *RoleBindingRequest) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+// API Token Definitions:
+//
+//	An API token is a revocable bearer credential bound to an operator for
+//	clients which can't hold a client certificate. Its scope is a subset of the
+//	operator's ACL: a request made with the token is granted only if both the
+//	token scope and the operator's current ACL allow it.
+//
+//	Tokens are handed out once as "<id>.<secret>", only the SHA-256 hash of
+//	the secret is stored, keyed by the token ID.
+type APIToken struct {
+	Id       string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operator *protos.Identity `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	// Token scope, entities & permissions as in the operator's ACL
+	Entities             []*AccessControl_Entity `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	Description          string                  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt            *timestamp.Timestamp    `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt            *timestamp.Timestamp    `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	SecretHash           []byte                  `protobuf:"bytes,7,opt,name=secret_hash,json=secretHash,proto3" json:"secret_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *APIToken) Reset()         { *m = APIToken{} }
+func (m *APIToken) String() string { return proto.CompactTextString(m) }
+func (*APIToken) ProtoMessage()    {}
+func (*APIToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{5}
+}
+
+func (m *APIToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIToken.Unmarshal(m, b)
+}
+func (m *APIToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIToken.Marshal(b, m, deterministic)
+}
+func (m *APIToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIToken.Merge(m, src)
+}
+func (m *APIToken) XXX_Size() int {
+	return xxx_messageInfo_APIToken.Size(m)
+}
+func (m *APIToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIToken proto.InternalMessageInfo
+
+func (m *APIToken) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *APIToken) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *APIToken) GetEntities() []*AccessControl_Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *APIToken) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *APIToken) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *APIToken) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *APIToken) GetSecretHash() []byte {
+	if m != nil {
+		return m.SecretHash
+	}
+	return nil
+}
+
+type APITokens struct {
+	Tokens               []*APIToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *APITokens) Reset()         { *m = APITokens{} }
+func (m *APITokens) String() string { return proto.CompactTextString(m) }
+func (*APITokens) ProtoMessage()    {}
+func (*APITokens) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{6}
+}
+
+func (m *APITokens) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APITokens.Unmarshal(m, b)
+}
+func (m *APITokens) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APITokens.Marshal(b, m, deterministic)
+}
+func (m *APITokens) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APITokens.Merge(m, src)
+}
+func (m *APITokens) XXX_Size() int {
+	return xxx_messageInfo_APITokens.Size(m)
+}
+func (m *APITokens) XXX_DiscardUnknown() {
+	xxx_messageInfo_APITokens.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APITokens proto.InternalMessageInfo
+
+func (m *APITokens) GetTokens() []*APIToken {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type IssueTokenRequest struct {
+	Operator             *protos.Identity        `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Entities             []*AccessControl_Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	Description          string                  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt            *timestamp.Timestamp    `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *IssueTokenRequest) Reset()         { *m = IssueTokenRequest{} }
+func (m *IssueTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueTokenRequest) ProtoMessage()    {}
+func (*IssueTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{7}
+}
+
+func (m *IssueTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTokenRequest.Unmarshal(m, b)
+}
+func (m *IssueTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *IssueTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTokenRequest.Merge(m, src)
+}
+func (m *IssueTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueTokenRequest.Size(m)
+}
+func (m *IssueTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTokenRequest proto.InternalMessageInfo
+
+func (m *IssueTokenRequest) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *IssueTokenRequest) GetEntities() []*AccessControl_Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *IssueTokenRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *IssueTokenRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type IssuedToken struct {
+	Token *APIToken `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Bearer token to present in the Authorization header, not recoverable
+	// after issuance
+	Bearer               string   `protobuf:"bytes,2,opt,name=bearer,proto3" json:"bearer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedToken) Reset()         { *m = IssuedToken{} }
+func (m *IssuedToken) String() string { return proto.CompactTextString(m) }
+func (*IssuedToken) ProtoMessage()    {}
+func (*IssuedToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{8}
+}
+
+func (m *IssuedToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedToken.Unmarshal(m, b)
+}
+func (m *IssuedToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedToken.Marshal(b, m, deterministic)
+}
+func (m *IssuedToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedToken.Merge(m, src)
+}
+func (m *IssuedToken) XXX_Size() int {
+	return xxx_messageInfo_IssuedToken.Size(m)
+}
+func (m *IssuedToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedToken proto.InternalMessageInfo
+
+func (m *IssuedToken) GetToken() *APIToken {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+func (m *IssuedToken) GetBearer() string {
+	if m != nil {
+		return m.Bearer
+	}
+	return ""
+}
+
+type TokenID struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenID) Reset()         { *m = TokenID{} }
+func (m *TokenID) String() string { return proto.CompactTextString(m) }
+func (*TokenID) ProtoMessage()    {}
+func (*TokenID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{9}
+}
+
+func (m *TokenID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenID.Unmarshal(m, b)
+}
+func (m *TokenID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenID.Marshal(b, m, deterministic)
+}
+func (m *TokenID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenID.Merge(m, src)
+}
+func (m *TokenID) XXX_Size() int {
+	return xxx_messageInfo_TokenID.Size(m)
+}
+func (m *TokenID) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenID proto.InternalMessageInfo
+
+func (m *TokenID) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// RPC Request used to verify token's permissions for a list of entities.
+// Entities may be empty to only validate the token
+type TokenPermissionsRequest struct {
+	Bearer               string                  `protobuf:"bytes,1,opt,name=bearer,proto3" json:"bearer,omitempty"`
+	Entities             []*AccessControl_Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *TokenPermissionsRequest) Reset()         { *m = TokenPermissionsRequest{} }
+func (m *TokenPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*TokenPermissionsRequest) ProtoMessage()    {}
+func (*TokenPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{10}
+}
+
+func (m *TokenPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenPermissionsRequest.Unmarshal(m, b)
+}
+func (m *TokenPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *TokenPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenPermissionsRequest.Merge(m, src)
+}
+func (m *TokenPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_TokenPermissionsRequest.Size(m)
+}
+func (m *TokenPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenPermissionsRequest proto.InternalMessageInfo
+
+func (m *TokenPermissionsRequest) GetBearer() string {
+	if m != nil {
+		return m.Bearer
+	}
+	return ""
+}
+
+func (m *TokenPermissionsRequest) GetEntities() []*AccessControl_Entity {
 	if m != nil {
-		return m.Acls
+		return m.Entities
 	}
 	return nil
 }
@@ -330,46 +859,85 @@ func init() {
 	proto.RegisterType((*AccessControl_ListRequest)(nil), "magma.orc8r.accessd.AccessControl.ListRequest")
 	proto.RegisterType((*AccessControl_PermissionsRequest)(nil), "magma.orc8r.accessd.AccessControl.PermissionsRequest")
 	proto.RegisterType((*AccessControl_Lists)(nil), "magma.orc8r.accessd.AccessControl.Lists")
+	proto.RegisterType((*Role)(nil), "magma.orc8r.accessd.Role")
+	proto.RegisterType((*Roles)(nil), "magma.orc8r.accessd.Roles")
+	proto.RegisterType((*RoleName)(nil), "magma.orc8r.accessd.RoleName")
+	proto.RegisterType((*RoleBindingRequest)(nil), "magma.orc8r.accessd.RoleBindingRequest")
+	proto.RegisterType((*APIToken)(nil), "magma.orc8r.accessd.APIToken")
+	proto.RegisterType((*APITokens)(nil), "magma.orc8r.accessd.APITokens")
+	proto.RegisterType((*IssueTokenRequest)(nil), "magma.orc8r.accessd.IssueTokenRequest")
+	proto.RegisterType((*IssuedToken)(nil), "magma.orc8r.accessd.IssuedToken")
+	proto.RegisterType((*TokenID)(nil), "magma.orc8r.accessd.TokenID")
+	proto.RegisterType((*TokenPermissionsRequest)(nil), "magma.orc8r.accessd.TokenPermissionsRequest")
 }
 
 func init() { proto.RegisterFile("access.proto", fileDescriptor_a098e900d2c3a6f2) }
 
 var fileDescriptor_a098e900d2c3a6f2 = []byte{
-	// 531 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xe1, 0x6b, 0xd3, 0x40,
-	0x14, 0x4f, 0xba, 0xb5, 0x74, 0x2f, 0x6b, 0x89, 0x4f, 0x85, 0x7a, 0x7e, 0x19, 0x01, 0xb1, 0x22,
-	0xcb, 0xb0, 0x32, 0x18, 0x53, 0xd0, 0xda, 0x06, 0x19, 0xd4, 0x55, 0x4f, 0xe7, 0x60, 0xdf, 0x62,
-	0x72, 0xce, 0xb0, 0x26, 0x57, 0x73, 0xb7, 0x41, 0xbf, 0xf9, 0xcd, 0x7f, 0xd8, 0x8f, 0x22, 0x92,
-	0xbb, 0xac, 0x4d, 0x58, 0x37, 0x52, 0xdd, 0xa7, 0x5c, 0xee, 0xfd, 0x7e, 0xbf, 0xf7, 0x7b, 0xef,
-	0x5d, 0x2e, 0xb0, 0xe9, 0x07, 0x01, 0x13, 0xc2, 0x9d, 0xa6, 0x5c, 0x72, 0xbc, 0x1b, 0xfb, 0xa7,
-	0xb1, 0xef, 0xf2, 0x34, 0xd8, 0x4b, 0x5d, 0x1d, 0x09, 0xc9, 0x03, 0xf5, 0xba, 0xa3, 0x10, 0x62,
-	0x27, 0xe0, 0x71, 0xcc, 0x13, 0x8d, 0x27, 0x0f, 0x4b, 0xa1, 0x28, 0x64, 0x89, 0x8c, 0xe4, 0x4c,
-	0x07, 0x9d, 0x3f, 0x75, 0x68, 0xf5, 0x95, 0xc6, 0x80, 0x27, 0x32, 0xe5, 0x13, 0xf2, 0xc3, 0x84,
-	0x86, 0xa7, 0x20, 0xf8, 0x08, 0x6a, 0x51, 0xd8, 0x31, 0xb7, 0xcc, 0xae, 0xd5, 0xbb, 0xef, 0x16,
-	0xd3, 0x1e, 0xe4, 0x2a, 0xb4, 0x16, 0x85, 0x38, 0x06, 0x6b, 0xca, 0xd2, 0x38, 0x12, 0x22, 0xe2,
-	0x89, 0xe8, 0xd4, 0xb6, 0xcc, 0x6e, 0xbb, 0xb7, 0xed, 0x2e, 0xb1, 0xe9, 0x96, 0x52, 0xb9, 0xef,
-	0xe7, 0x2c, 0x5a, 0x54, 0x20, 0xbf, 0x4c, 0x58, 0x1f, 0x45, 0x42, 0xe2, 0x33, 0x68, 0xf2, 0x29,
-	0x4b, 0x7d, 0xc9, 0xd3, 0x9b, 0x6d, 0xcc, 0x61, 0xf8, 0x01, 0x9a, 0x6a, 0x2f, 0x62, 0x99, 0x93,
-	0xb5, 0xae, 0xd5, 0xdb, 0xad, 0xe0, 0x24, 0xcb, 0xe6, 0x7a, 0x39, 0xcf, 0x4b, 0x64, 0x3a, 0xa3,
-	0x73, 0x19, 0xf2, 0x15, 0x5a, 0xa5, 0x10, 0xda, 0xb0, 0x76, 0xc6, 0x66, 0xca, 0xd1, 0x06, 0xcd,
-	0x96, 0xf8, 0x0a, 0xea, 0x17, 0xfe, 0xe4, 0x9c, 0xa9, 0xe2, 0xad, 0xde, 0x93, 0x0a, 0x29, 0x75,
-	0x8f, 0xa9, 0xe6, 0xed, 0xd7, 0xf6, 0x4c, 0xf2, 0xd3, 0x04, 0x2b, 0x33, 0x42, 0xd9, 0xf7, 0x73,
-	0xf6, 0x6f, 0xd5, 0x7b, 0x57, 0xaa, 0x5f, 0xc1, 0xca, 0xa2, 0xe2, 0x0b, 0xc0, 0xc5, 0x6c, 0xc4,
-	0x7f, 0xf8, 0xd9, 0x86, 0x86, 0xde, 0xcb, 0x1b, 0x73, 0x0d, 0x21, 0x07, 0x91, 0x21, 0xd4, 0xb3,
-	0x06, 0x08, 0x7c, 0x01, 0xeb, 0x7e, 0x30, 0x11, 0x1d, 0x53, 0xd5, 0xf0, 0xb8, 0xe2, 0x04, 0xa9,
-	0x22, 0x39, 0x4f, 0x01, 0x16, 0xee, 0xb1, 0x09, 0xeb, 0x87, 0xe3, 0x43, 0xcf, 0x36, 0xb2, 0x15,
-	0xf5, 0xfa, 0x43, 0xdb, 0xc4, 0x0d, 0xa8, 0x1f, 0xd3, 0x83, 0x4f, 0x9e, 0x5d, 0xeb, 0xfd, 0xae,
-	0xc3, 0xbd, 0x92, 0xd2, 0x3b, 0x3f, 0xf1, 0x4f, 0x59, 0x8a, 0x14, 0xac, 0x8f, 0x4c, 0x8e, 0x2f,
-	0x2b, 0x71, 0xab, 0x7a, 0xd0, 0xcd, 0x22, 0x77, 0x4a, 0xf8, 0xcf, 0x3c, 0x0a, 0x1d, 0x03, 0x8f,
-	0xa0, 0x7d, 0x34, 0x0d, 0x7d, 0xc9, 0x6e, 0x57, 0xf6, 0x25, 0xb4, 0x87, 0x6c, 0xc2, 0x0a, 0xb2,
-	0xcb, 0xfb, 0xbc, 0x9c, 0x4d, 0xa1, 0xfd, 0x76, 0x51, 0x68, 0x7f, 0x30, 0xba, 0x8e, 0x5d, 0x75,
-	0x0c, 0x8e, 0x81, 0x27, 0x60, 0x17, 0x34, 0x45, 0x7f, 0x30, 0x12, 0x48, 0x96, 0xaa, 0x2a, 0x06,
-	0xe9, 0x56, 0x94, 0x16, 0x8e, 0x81, 0x52, 0xf9, 0x2d, 0x9c, 0x4f, 0xdc, 0x5d, 0xe9, 0xae, 0xb9,
-	0x3c, 0xcf, 0xa4, 0xfa, 0xa7, 0xe1, 0x18, 0x78, 0x0c, 0xf6, 0xe0, 0x1b, 0x0b, 0xce, 0x8a, 0x79,
-	0x6f, 0x65, 0x78, 0xaf, 0xa1, 0x95, 0x61, 0xe6, 0xbd, 0xc2, 0xab, 0x28, 0x72, 0x43, 0xeb, 0x1c,
-	0x03, 0xf7, 0x61, 0x53, 0x8f, 0x3f, 0xbf, 0xb6, 0x57, 0x18, 0xfe, 0x9b, 0xe6, 0x49, 0x43, 0xff,
-	0x18, 0xbe, 0xe8, 0xe7, 0xf3, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x04, 0xce, 0x33, 0x6d,
-	0x06, 0x00, 0x00,
+	// 1007 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xf7, 0x9d, 0xff, 0xc4, 0x9e, 0x4b, 0x2c, 0x77, 0x69, 0xc1, 0x39, 0x68, 0x6b, 0xad, 0x04,
+	0x35, 0x82, 0x5e, 0x84, 0xab, 0x48, 0x69, 0x41, 0x02, 0xc7, 0xb1, 0x8a, 0xa5, 0x90, 0x84, 0x6d,
+	0x4a, 0x51, 0x24, 0xa8, 0x2e, 0xbe, 0xad, 0x73, 0x8a, 0xef, 0xd6, 0xdc, 0x6e, 0xa2, 0xe6, 0x0d,
+	0x89, 0x07, 0x24, 0x5e, 0x78, 0xe1, 0x6b, 0xf1, 0x45, 0xf8, 0x14, 0xe8, 0x76, 0xd7, 0xf6, 0x39,
+	0xb9, 0x4b, 0x6c, 0x37, 0x52, 0x9f, 0x7c, 0xb7, 0x3b, 0xbf, 0xd9, 0xdf, 0xfc, 0x66, 0x76, 0xe6,
+	0x0c, 0xab, 0x6e, 0xbf, 0x4f, 0x39, 0x77, 0x46, 0x11, 0x13, 0x0c, 0x7d, 0x10, 0xb8, 0x83, 0xc0,
+	0x75, 0x58, 0xd4, 0xdf, 0x8a, 0x1c, 0xb5, 0xe3, 0xd9, 0xeb, 0xf2, 0x75, 0x43, 0x5a, 0xf0, 0x8d,
+	0x3e, 0x0b, 0x02, 0x16, 0x2a, 0x7b, 0xfb, 0xe3, 0x99, 0x2d, 0xdf, 0xa3, 0xa1, 0xf0, 0xc5, 0x85,
+	0xde, 0x7c, 0x38, 0x60, 0x6c, 0x30, 0xa4, 0x6a, 0xf7, 0xf8, 0xec, 0xcd, 0x86, 0xf0, 0x03, 0xca,
+	0x85, 0x1b, 0x8c, 0x94, 0x01, 0xfe, 0xa7, 0x04, 0x6b, 0x6d, 0x79, 0x48, 0x87, 0x85, 0x22, 0x62,
+	0x43, 0xfb, 0x77, 0x03, 0x4a, 0x5d, 0xe9, 0x03, 0x7d, 0x0a, 0xa6, 0xef, 0xd5, 0x8d, 0x86, 0xd1,
+	0xb4, 0x5a, 0xf7, 0x9c, 0x24, 0xaf, 0x9e, 0x3e, 0x86, 0x98, 0xbe, 0x87, 0xf6, 0xc1, 0x1a, 0xd1,
+	0x28, 0xf0, 0x39, 0xf7, 0x59, 0xc8, 0xeb, 0x66, 0xc3, 0x68, 0x56, 0x5b, 0x8f, 0x9d, 0x94, 0x38,
+	0x9c, 0x99, 0xa3, 0x9c, 0x83, 0x09, 0x8a, 0x24, 0x3d, 0xd8, 0x7f, 0x99, 0x50, 0xd8, 0xf5, 0xb9,
+	0x40, 0x5f, 0x41, 0x99, 0x8d, 0x68, 0xe4, 0x0a, 0x16, 0x5d, 0x4f, 0x63, 0x62, 0x86, 0x7e, 0x84,
+	0xb2, 0x5c, 0xf3, 0x69, 0xcc, 0x24, 0xdf, 0xb4, 0x5a, 0x9b, 0x73, 0x30, 0x89, 0x4f, 0x73, 0xba,
+	0x1a, 0xd7, 0x0d, 0x45, 0x74, 0x41, 0x26, 0x6e, 0xd0, 0x5d, 0x28, 0x46, 0x6c, 0x48, 0x79, 0x3d,
+	0xdf, 0xc8, 0x37, 0x2b, 0x44, 0xbd, 0xd8, 0x6f, 0x60, 0x6d, 0x06, 0x80, 0x6a, 0x90, 0x3f, 0xa5,
+	0x17, 0x92, 0x67, 0x85, 0xc4, 0x8f, 0xe8, 0x5b, 0x28, 0x9e, 0xbb, 0xc3, 0x33, 0x2a, 0x25, 0xb1,
+	0x5a, 0x9f, 0xcf, 0x41, 0x44, 0x29, 0x4f, 0x14, 0xee, 0x99, 0xb9, 0x65, 0xd8, 0x7f, 0x1a, 0x60,
+	0xc5, 0xf4, 0x08, 0xfd, 0xed, 0x8c, 0x2e, 0xa7, 0x49, 0xf7, 0x8a, 0x26, 0x0b, 0x50, 0x99, 0x40,
+	0xed, 0x73, 0x40, 0xd3, 0x8c, 0xf1, 0x77, 0xe0, 0xf3, 0x18, 0x4a, 0x6a, 0xad, 0x6e, 0x5e, 0x07,
+	0xd0, 0x46, 0xf6, 0x0e, 0x14, 0x63, 0x01, 0x38, 0xfa, 0x1a, 0x0a, 0x6e, 0x7f, 0xc8, 0xeb, 0x86,
+	0x8c, 0xe1, 0xd1, 0x9c, 0x79, 0x25, 0x12, 0x84, 0xbf, 0x00, 0x98, 0xb2, 0x47, 0x65, 0x28, 0xec,
+	0xed, 0xef, 0x75, 0x6b, 0xb9, 0xf8, 0x89, 0x74, 0xdb, 0x3b, 0x35, 0x03, 0x55, 0xa0, 0xf8, 0x8a,
+	0xf4, 0x0e, 0xbb, 0x35, 0x13, 0xff, 0x61, 0x40, 0x81, 0xb0, 0x21, 0x45, 0x08, 0x0a, 0xa1, 0x1b,
+	0x50, 0x9d, 0x55, 0xf9, 0x8c, 0x1a, 0x60, 0x79, 0x94, 0xf7, 0x23, 0x7f, 0x24, 0x7c, 0x16, 0xca,
+	0x18, 0x2a, 0x24, 0xb9, 0x34, 0x23, 0x78, 0x7e, 0x69, 0xc1, 0xf1, 0x16, 0x14, 0x63, 0x12, 0x1c,
+	0x6d, 0x8c, 0x2b, 0x50, 0x45, 0xbe, 0x9e, 0xea, 0x2c, 0x36, 0xd5, 0xc5, 0x89, 0x1f, 0x40, 0x39,
+	0x7e, 0xdd, 0x73, 0x83, 0xd4, 0x10, 0xf0, 0x2f, 0x80, 0xe2, 0xfd, 0x6d, 0x3f, 0xf4, 0xfc, 0x70,
+	0xf0, 0x0e, 0xa9, 0x9c, 0xdc, 0x0d, 0x33, 0x71, 0x37, 0xf0, 0xbf, 0x26, 0x94, 0xdb, 0x07, 0xbd,
+	0x43, 0x76, 0x4a, 0x43, 0x54, 0x9d, 0x74, 0x91, 0x8a, 0x6c, 0x17, 0xc9, 0x53, 0xcc, 0xc5, 0x0b,
+	0x78, 0x79, 0x3d, 0x2f, 0x27, 0xae, 0x70, 0x35, 0x71, 0x4f, 0x01, 0xfa, 0x11, 0x75, 0x05, 0xf5,
+	0x5e, 0xbb, 0xa2, 0x5e, 0x94, 0xec, 0x6c, 0x47, 0x35, 0x51, 0x67, 0xdc, 0x44, 0x9d, 0xc3, 0x71,
+	0x13, 0x25, 0x15, 0x6d, 0xdd, 0x16, 0x31, 0x94, 0xbe, 0x1d, 0xf9, 0x11, 0xe5, 0x31, 0xb4, 0x74,
+	0x33, 0x54, 0x5b, 0xb7, 0x05, 0x7a, 0x08, 0x16, 0xa7, 0xfd, 0x88, 0x8a, 0xd7, 0x27, 0x2e, 0x3f,
+	0xa9, 0xaf, 0x34, 0x8c, 0xe6, 0x2a, 0x01, 0xb5, 0xf4, 0xbd, 0xcb, 0x4f, 0xf0, 0x36, 0x54, 0xc6,
+	0x72, 0x72, 0xb4, 0x09, 0x25, 0x21, 0x9f, 0x74, 0x35, 0xdc, 0x4f, 0x97, 0x42, 0xdb, 0x13, 0x6d,
+	0x8c, 0xff, 0x33, 0xe0, 0x4e, 0x8f, 0xf3, 0x33, 0xaa, 0x96, 0xdf, 0x77, 0x37, 0xb9, 0x9c, 0x8c,
+	0x7c, 0x6a, 0x32, 0x12, 0x8a, 0x16, 0x16, 0x50, 0x14, 0x1f, 0x81, 0x25, 0x63, 0xf5, 0x54, 0x09,
+	0x3e, 0x81, 0xa2, 0x54, 0x41, 0x87, 0x78, 0x83, 0x62, 0xca, 0x16, 0x7d, 0x08, 0xa5, 0x63, 0xea,
+	0x46, 0x34, 0xd2, 0x37, 0x5c, 0xbf, 0xe1, 0x75, 0x58, 0x91, 0x76, 0xbd, 0x9d, 0xcb, 0xa5, 0x8d,
+	0xdf, 0xc2, 0x47, 0x72, 0x2b, 0xa5, 0x4d, 0x4e, 0xbd, 0x19, 0x49, 0x6f, 0xb7, 0xa4, 0x66, 0xeb,
+	0xef, 0x55, 0xb8, 0x3b, 0x63, 0xf2, 0x83, 0x1b, 0xba, 0x03, 0x1a, 0x21, 0x02, 0xd6, 0x0b, 0x2a,
+	0xf6, 0xc7, 0xc9, 0x73, 0xe6, 0x6d, 0x9a, 0x8a, 0xb6, 0x7d, 0x67, 0xc6, 0xfe, 0x27, 0xe6, 0x7b,
+	0x38, 0x87, 0x5e, 0x42, 0xf5, 0xe5, 0xc8, 0x73, 0x05, 0xbd, 0x5d, 0xb7, 0xdf, 0x40, 0x75, 0x87,
+	0x0e, 0x69, 0xc2, 0x6d, 0x7a, 0x2d, 0xa6, 0xa3, 0x09, 0x54, 0x9f, 0x4f, 0x03, 0x6d, 0x77, 0x76,
+	0xb3, 0xd0, 0xf3, 0xce, 0x0d, 0x9c, 0x43, 0x47, 0x50, 0x4b, 0xf8, 0xe4, 0xed, 0xce, 0x2e, 0x47,
+	0x76, 0xaa, 0x57, 0x89, 0xb0, 0x9b, 0x73, 0xba, 0xe6, 0x38, 0x87, 0x84, 0xe4, 0x9b, 0xa8, 0x14,
+	0xb4, 0xb9, 0xd0, 0x27, 0xd3, 0xb8, 0xb2, 0xec, 0xf9, 0xeb, 0x05, 0xe7, 0xd0, 0x2b, 0xa8, 0x75,
+	0x4e, 0x68, 0xff, 0x34, 0x79, 0xee, 0xad, 0x24, 0xef, 0x3b, 0x58, 0x8b, 0x6d, 0x26, 0x5a, 0xa1,
+	0xab, 0x56, 0xf6, 0x35, 0xd2, 0xe1, 0x1c, 0x7a, 0x06, 0xab, 0x2a, 0xfd, 0xfa, 0xeb, 0x73, 0x91,
+	0xe4, 0x3f, 0x85, 0x95, 0x17, 0x54, 0xc8, 0x89, 0x9d, 0x3d, 0x1c, 0xb3, 0xaa, 0x0e, 0x3a, 0xb2,
+	0x89, 0x2f, 0x8b, 0x56, 0x57, 0x61, 0x29, 0x74, 0x17, 0x56, 0x9e, 0x6b, 0xda, 0xf7, 0x33, 0xa1,
+	0xf1, 0x10, 0xb7, 0xb3, 0x3d, 0x4b, 0xed, 0x41, 0x29, 0x37, 0x8f, 0xa7, 0x8c, 0x30, 0x2a, 0x32,
+	0xc3, 0xf2, 0x6b, 0xe3, 0xc6, 0xcc, 0x25, 0x7d, 0xc6, 0xa5, 0x7c, 0x00, 0xb5, 0x44, 0x8f, 0x51,
+	0x4e, 0x1e, 0x65, 0x22, 0x66, 0x3f, 0x3a, 0xd2, 0xf9, 0xfc, 0x0c, 0x30, 0x9d, 0x55, 0xe8, 0xb3,
+	0x54, 0x5f, 0x57, 0x86, 0x99, 0xdd, 0xc8, 0xb6, 0x53, 0x83, 0x40, 0x6a, 0x65, 0x11, 0x7a, 0xce,
+	0x4e, 0xb5, 0xeb, 0x4f, 0x52, 0x21, 0xba, 0xbf, 0x67, 0x25, 0x0d, 0x62, 0xad, 0xf4, 0x34, 0xce,
+	0xa8, 0xd2, 0x07, 0xd7, 0x8e, 0x98, 0x58, 0xb4, 0x5f, 0xe1, 0x9e, 0xbc, 0x89, 0x97, 0x07, 0x06,
+	0xfa, 0x32, 0x9b, 0x52, 0xca, 0xed, 0x4f, 0x3f, 0x1f, 0xe7, 0xb6, 0xcb, 0x47, 0x25, 0xf5, 0x9f,
+	0xf0, 0x58, 0xfd, 0x3e, 0xf9, 0x7f, 0x00, 0x54, 0x07, 0x0a, 0x69, 0x68, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -387,6 +955,7 @@ type AccessControlManagerClient interface {
 	// Overwrites Permissions for operator Identity to manage others
 	// Request includes ACL to set for the Operator
 	// If the Operator doesn't exist - creates a new operator with the given ACL
+	// The Roles bound to an existing Operator are kept
 	SetOperator(ctx context.Context, in *AccessControl_ListRequest, opts ...grpc.CallOption) (*protos.Void, error)
 	// Adds Permissions for one Identity to manage others
 	// Request includes ACL to add (append to the existing ACL) for the Operator
@@ -399,6 +968,7 @@ type AccessControlManagerClient interface {
 	GetOperatorsACLs(ctx context.Context, in *protos.Identity_List, opts ...grpc.CallOption) (*AccessControl_Lists, error)
 	// Returns the managing Identity's permissions for a given entity
 	// NOTE: Takes into account wildcards for the entity's type in the ACL
+	// and the operator's Roles
 	GetPermissions(ctx context.Context, in *AccessControl_PermissionsRequest, opts ...grpc.CallOption) (*AccessControl_Entity, error)
 	// CheckPermissions verifies Operator permissions for a list of given
 	// Identities. AccessControl.ListRequest.entities is a list of
@@ -412,6 +982,34 @@ type AccessControlManagerClient interface {
 	ListOperators(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*protos.Identity_List, error)
 	// Cleanup a given entity from all Operators' ACLs
 	DeleteEntity(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*protos.Void, error)
+	// Creates a new Role or overwrites the existing Role with the same name
+	SetRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error)
+	// Creates a new Role, fails if a Role with the same name exists
+	CreateRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error)
+	// Overwrites the existing Role with the same name, fails if it doesn't
+	// exist
+	UpdateRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns the Role with the given name
+	GetRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*Role, error)
+	// Removes the Role, fails if the Role is still bound to any operator
+	DeleteRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*protos.Void, error)
+	// Lists all Roles
+	ListRoles(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*Roles, error)
+	// Overwrites the Roles bound to the operator, all Roles must exist
+	// If the Operator doesn't exist - creates a new operator with an empty ACL
+	SetOperatorRoles(ctx context.Context, in *RoleBindingRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Issues a new API token for the operator, the token scope must be
+	// a subset of the operator's ACL
+	IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*IssuedToken, error)
+	// Revokes the API token with the given ID
+	RevokeToken(ctx context.Context, in *TokenID, opts ...grpc.CallOption) (*protos.Void, error)
+	// Lists all API tokens of the operator
+	ListTokens(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*APITokens, error)
+	// CheckTokenPermissions verifies the bearer token and its permissions for
+	// a list of given entities with the same AND logic as CheckPermissions,
+	// against both the token scope and the operator's ACL.
+	// Returns the token's operator Identity
+	CheckTokenPermissions(ctx context.Context, in *TokenPermissionsRequest, opts ...grpc.CallOption) (*protos.Identity, error)
 }
 
 type accessControlManagerClient struct {
@@ -503,11 +1101,111 @@ func (c *accessControlManagerClient) DeleteEntity(ctx context.Context, in *proto
 	return out, nil
 }
 
+func (c *accessControlManagerClient) SetRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/SetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) CreateRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/CreateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) UpdateRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/UpdateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) GetRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/GetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) DeleteRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/DeleteRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) ListRoles(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*Roles, error) {
+	out := new(Roles)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/ListRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) SetOperatorRoles(ctx context.Context, in *RoleBindingRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/SetOperatorRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*IssuedToken, error) {
+	out := new(IssuedToken)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/IssueToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) RevokeToken(ctx context.Context, in *TokenID, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/RevokeToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) ListTokens(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*APITokens, error) {
+	out := new(APITokens)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/ListTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) CheckTokenPermissions(ctx context.Context, in *TokenPermissionsRequest, opts ...grpc.CallOption) (*protos.Identity, error) {
+	out := new(protos.Identity)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/CheckTokenPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccessControlManagerServer is the server API for AccessControlManager service.
 type AccessControlManagerServer interface {
 	// Overwrites Permissions for operator Identity to manage others
 	// Request includes ACL to set for the Operator
 	// If the Operator doesn't exist - creates a new operator with the given ACL
+	// The Roles bound to an existing Operator are kept
 	SetOperator(context.Context, *AccessControl_ListRequest) (*protos.Void, error)
 	// Adds Permissions for one Identity to manage others
 	// Request includes ACL to add (append to the existing ACL) for the Operator
@@ -520,6 +1218,7 @@ type AccessControlManagerServer interface {
 	GetOperatorsACLs(context.Context, *protos.Identity_List) (*AccessControl_Lists, error)
 	// Returns the managing Identity's permissions for a given entity
 	// NOTE: Takes into account wildcards for the entity's type in the ACL
+	// and the operator's Roles
 	GetPermissions(context.Context, *AccessControl_PermissionsRequest) (*AccessControl_Entity, error)
 	// CheckPermissions verifies Operator permissions for a list of given
 	// Identities. AccessControl.ListRequest.entities is a list of
@@ -533,6 +1232,34 @@ type AccessControlManagerServer interface {
 	ListOperators(context.Context, *protos.Void) (*protos.Identity_List, error)
 	// Cleanup a given entity from all Operators' ACLs
 	DeleteEntity(context.Context, *protos.Identity) (*protos.Void, error)
+	// Creates a new Role or overwrites the existing Role with the same name
+	SetRole(context.Context, *Role) (*protos.Void, error)
+	// Creates a new Role, fails if a Role with the same name exists
+	CreateRole(context.Context, *Role) (*protos.Void, error)
+	// Overwrites the existing Role with the same name, fails if it doesn't
+	// exist
+	UpdateRole(context.Context, *Role) (*protos.Void, error)
+	// Returns the Role with the given name
+	GetRole(context.Context, *RoleName) (*Role, error)
+	// Removes the Role, fails if the Role is still bound to any operator
+	DeleteRole(context.Context, *RoleName) (*protos.Void, error)
+	// Lists all Roles
+	ListRoles(context.Context, *protos.Void) (*Roles, error)
+	// Overwrites the Roles bound to the operator, all Roles must exist
+	// If the Operator doesn't exist - creates a new operator with an empty ACL
+	SetOperatorRoles(context.Context, *RoleBindingRequest) (*protos.Void, error)
+	// Issues a new API token for the operator, the token scope must be
+	// a subset of the operator's ACL
+	IssueToken(context.Context, *IssueTokenRequest) (*IssuedToken, error)
+	// Revokes the API token with the given ID
+	RevokeToken(context.Context, *TokenID) (*protos.Void, error)
+	// Lists all API tokens of the operator
+	ListTokens(context.Context, *protos.Identity) (*APITokens, error)
+	// CheckTokenPermissions verifies the bearer token and its permissions for
+	// a list of given entities with the same AND logic as CheckPermissions,
+	// against both the token scope and the operator's ACL.
+	// Returns the token's operator Identity
+	CheckTokenPermissions(context.Context, *TokenPermissionsRequest) (*protos.Identity, error)
 }
 
 // UnimplementedAccessControlManagerServer can be embedded to have forward compatible implementations.
@@ -566,6 +1293,39 @@ func (*UnimplementedAccessControlManagerServer) ListOperators(ctx context.Contex
 func (*UnimplementedAccessControlManagerServer) DeleteEntity(ctx context.Context, req *protos.Identity) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
 }
+func (*UnimplementedAccessControlManagerServer) SetRole(ctx context.Context, req *Role) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) CreateRole(ctx context.Context, req *Role) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) UpdateRole(ctx context.Context, req *Role) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) GetRole(ctx context.Context, req *RoleName) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) DeleteRole(ctx context.Context, req *RoleName) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) ListRoles(ctx context.Context, req *protos.Void) (*Roles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) SetOperatorRoles(ctx context.Context, req *RoleBindingRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOperatorRoles not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) IssueToken(ctx context.Context, req *IssueTokenRequest) (*IssuedToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueToken not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) RevokeToken(ctx context.Context, req *TokenID) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) ListTokens(ctx context.Context, req *protos.Identity) (*APITokens, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) CheckTokenPermissions(ctx context.Context, req *TokenPermissionsRequest) (*protos.Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTokenPermissions not implemented")
+}
 
 func RegisterAccessControlManagerServer(s *grpc.Server, srv AccessControlManagerServer) {
 	s.RegisterService(&_AccessControlManager_serviceDesc, srv)
@@ -733,6 +1493,204 @@ func _AccessControlManager_DeleteEntity_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccessControlManager_SetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Role)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).SetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/SetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).SetRole(ctx, req.(*Role))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_CreateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Role)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).CreateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/CreateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).CreateRole(ctx, req.(*Role))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_UpdateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Role)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).UpdateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/UpdateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).UpdateRole(ctx, req.(*Role))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/GetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).GetRole(ctx, req.(*RoleName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/DeleteRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).DeleteRole(ctx, req.(*RoleName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/ListRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).ListRoles(ctx, req.(*protos.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_SetOperatorRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).SetOperatorRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/SetOperatorRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).SetOperatorRoles(ctx, req.(*RoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_IssueToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).IssueToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/IssueToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).IssueToken(ctx, req.(*IssueTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/RevokeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).RevokeToken(ctx, req.(*TokenID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Identity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/ListTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).ListTokens(ctx, req.(*protos.Identity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_CheckTokenPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).CheckTokenPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/CheckTokenPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).CheckTokenPermissions(ctx, req.(*TokenPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AccessControlManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.accessd.AccessControlManager",
 	HandlerType: (*AccessControlManagerServer)(nil),
@@ -773,6 +1731,50 @@ var _AccessControlManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _AccessControlManager_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "SetRole",
+			Handler:    _AccessControlManager_SetRole_Handler,
+		},
+		{
+			MethodName: "CreateRole",
+			Handler:    _AccessControlManager_CreateRole_Handler,
+		},
+		{
+			MethodName: "UpdateRole",
+			Handler:    _AccessControlManager_UpdateRole_Handler,
+		},
+		{
+			MethodName: "GetRole",
+			Handler:    _AccessControlManager_GetRole_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _AccessControlManager_DeleteRole_Handler,
+		},
+		{
+			MethodName: "ListRoles",
+			Handler:    _AccessControlManager_ListRoles_Handler,
+		},
+		{
+			MethodName: "SetOperatorRoles",
+			Handler:    _AccessControlManager_SetOperatorRoles_Handler,
+		},
+		{
+			MethodName: "IssueToken",
+			Handler:    _AccessControlManager_IssueToken_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _AccessControlManager_RevokeToken_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _AccessControlManager_ListTokens_Handler,
+		},
+		{
+			MethodName: "CheckTokenPermissions",
+			Handler:    _AccessControlManager_CheckTokenPermissions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "access.proto",
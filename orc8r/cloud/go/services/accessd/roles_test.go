@@ -0,0 +1,143 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package accessd_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	accessd_test_service "magma/orc8r/cloud/go/services/accessd/test_init"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessManager_Roles(t *testing.T) {
+	accessd_test_service.StartTestService(t)
+
+	op := identity.NewOperator("role_operator")
+	net1 := identity.NewNetwork("network1")
+	net2 := identity.NewNetwork("network2")
+	defer accessd.DeleteOperator(op)
+
+	readOnly := &accessprotos.Role{
+		Name:        "read-only",
+		Description: "Read access to all networks",
+		Entities: []*accessprotos.AccessControl_Entity{
+			{Id: identity.NewNetworkWildcard(), Permissions: accessprotos.AccessControl_READ},
+		},
+	}
+	net2Admin := &accessprotos.Role{
+		Name: "network2-admin",
+		Entities: []*accessprotos.AccessControl_Entity{
+			{Id: net2, Permissions: accessprotos.AccessControl_WRITE},
+		},
+	}
+	assert.NoError(t, accessd.SetRole(readOnly))
+	assert.NoError(t, accessd.SetRole(net2Admin))
+	assert.Error(t, accessd.SetRole(&accessprotos.Role{Name: "invalid name"}))
+	assert.Error(t, accessd.SetRole(&accessprotos.Role{
+		Name:     "no-permissions",
+		Entities: []*accessprotos.AccessControl_Entity{{Id: net1}},
+	}))
+
+	roles, err := accessd.ListRoles()
+	assert.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, "network2-admin", roles[0].Name)
+	assert.Equal(t, "read-only", roles[1].Name)
+	role, err := accessd.GetRole("read-only")
+	assert.NoError(t, err)
+	assert.Equal(t, readOnly.Description, role.Description)
+	_, err = accessd.GetRole("unknown")
+	assert.Error(t, err)
+
+	// Roles are only created if they don't exist, and only updated if they do
+	err = accessd.CreateRole(readOnly)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	err = accessd.UpdateRole(&accessprotos.Role{Name: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	err = accessd.CreateRole(&accessprotos.Role{Name: "invalid name"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, accessd.CreateRole(&accessprotos.Role{Name: "created"}))
+	assert.NoError(t, accessd.UpdateRole(&accessprotos.Role{Name: "created", Description: "updated"}))
+	role, err = accessd.GetRole("created")
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", role.Description)
+	assert.NoError(t, accessd.DeleteRole("created"))
+
+	// Roles can be bound before the operator has its own ACL
+	assert.Error(t, accessd.SetOperatorRoles(op, []string{"read-only", "unknown"}))
+	assert.NoError(t, accessd.SetOperatorRoles(op, []string{"read-only"}))
+	assert.NoError(t, accessd.CheckReadPermission(op, net1, net2))
+	assert.Error(t, accessd.CheckWritePermission(op, net1))
+	opers, err := accessd.ListOperators()
+	assert.NoError(t, err)
+	var operKeys []string
+	for _, oper := range opers {
+		operKeys = append(operKeys, oper.HashString())
+	}
+	assert.Contains(t, operKeys, op.HashString())
+
+	// Role permissions are ORed with the operator's own permissions
+	err = accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{
+		{Id: net1, Permissions: accessprotos.AccessControl_WRITE},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, accessd.SetOperatorRoles(op, []string{"read-only", "network2-admin"}))
+	perm, err := accessd.GetPermissions(op, net1)
+	assert.NoError(t, err)
+	assert.Equal(t, accessprotos.ACCESS_CONTROL_ALL_PERMISSIONS, perm)
+	perm, err = accessd.GetPermissions(op, net2)
+	assert.NoError(t, err)
+	assert.Equal(t, accessprotos.ACCESS_CONTROL_ALL_PERMISSIONS, perm)
+	assert.NoError(t, accessd.CheckReadPermission(op, identity.NewNetwork("network3")))
+
+	// Overwriting the operator's ACL keeps its roles
+	err = accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{})
+	assert.NoError(t, err)
+	boundRoles, err := accessd.GetOperatorRoles(op)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"network2-admin", "read-only"}, boundRoles)
+	assert.NoError(t, accessd.CheckWritePermission(op, net2))
+	assert.Error(t, accessd.CheckWritePermission(op, net1))
+
+	// Role changes apply to all bound operators
+	net2Admin.Entities = append(
+		net2Admin.Entities,
+		&accessprotos.AccessControl_Entity{Id: net1, Permissions: accessprotos.AccessControl_WRITE})
+	assert.NoError(t, accessd.SetRole(net2Admin))
+	assert.NoError(t, accessd.CheckWritePermission(op, net1))
+
+	// Tokens are scoped within the effective ACL
+	_, err = accessd.IssueToken(
+		op,
+		[]*accessprotos.AccessControl_Entity{{Id: net2, Permissions: accessprotos.AccessControl_WRITE}},
+		"",
+		time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	// Bound roles can't be deleted
+	assert.Error(t, accessd.DeleteRole("read-only"))
+	assert.NoError(t, accessd.SetOperatorRoles(op, []string{"network2-admin"}))
+	assert.Error(t, accessd.CheckReadPermission(op, identity.NewNetwork("network3")))
+	assert.NoError(t, accessd.DeleteRole("read-only"))
+	assert.Error(t, accessd.DeleteRole("read-only"))
+	assert.NoError(t, accessd.SetOperatorRoles(op, nil))
+	assert.NoError(t, accessd.DeleteRole("network2-admin"))
+	assert.Error(t, accessd.CheckWritePermission(op, net2))
+	roles, err = accessd.ListRoles()
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+}
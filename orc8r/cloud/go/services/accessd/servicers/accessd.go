@@ -27,15 +27,16 @@ const (
 )
 
 type AccessControlServer struct {
-	store datastore.Api
+	store datastore.TxApi
 }
 
-func NewAccessdServer(store datastore.Api) *AccessControlServer {
+func NewAccessdServer(store datastore.TxApi) *AccessControlServer {
 	return &AccessControlServer{store}
 }
 
 // SetOperator Overwrites Permissions for operator Identity to manage others
-// Request includes ACL to add for the Operator
+// Request includes ACL to add for the Operator, Roles bound to the existing
+// Operator are kept
 func (srv *AccessControlServer) SetOperator(ctx context.Context, req *accessprotos.AccessControl_ListRequest) (*protos.Void, error) {
 
 	err := verifyACLRequest(req)
@@ -45,6 +46,10 @@ func (srv *AccessControlServer) SetOperator(ctx context.Context, req *accessprot
 
 	acl := &accessprotos.AccessControl_List{Operator: req.Operator,
 		Entities: map[string]*accessprotos.AccessControl_Entity{}}
+	// keep Roles bound to the existing Operator
+	if oldACL, err := srv.getACL(req.Operator); err == nil {
+		acl.Roles = oldACL.Roles
+	}
 	err = addToACL(req.Operator, acl, req.Entities)
 	if err != nil {
 		return &protos.Void{}, err
@@ -81,13 +86,20 @@ func (srv *AccessControlServer) UpdateOperator(ctx context.Context, req *accessp
 }
 
 // DeleteOperator Removes all operator's permissions (the entire operator's ACL)
+// and all tokens issued to the operator
 func (srv *AccessControlServer) DeleteOperator(ctx context.Context, oper *protos.Identity) (*protos.Void, error) {
 
 	if oper == nil {
 		return &protos.Void{}, status.Errorf(codes.InvalidArgument, "Nil Operator")
 	}
+	// Delete the tokens first, so a failed delete can be retried and never
+	// leaves tokens of a deleted operator behind
+	err := srv.deleteOperatorTokens(oper)
+	if err != nil {
+		return &protos.Void{}, err
+	}
 	opkey, table := getKeyTablePair(oper)
-	err := srv.store.Delete(table, opkey)
+	err = srv.store.Delete(table, opkey)
 	if err != nil {
 		return &protos.Void{}, status.Errorf(codes.NotFound, "Operator %s Delete from table %s error: %s", opkey, table, err)
 	}
@@ -116,8 +128,9 @@ func (srv *AccessControlServer) GetPermissions(
 	return srv.getACLEntity(req)
 }
 
-// Returns the managing Identity's permissions for a given entity
-// NOTE: Takes into account wildcards for the entity's type in the ACL
+// CheckPermissions verifies Operator permissions for a list of given entities
+// NOTE: Takes into account wildcards for the entity's type in the ACL and
+// the Operator's Roles
 func (srv *AccessControlServer) CheckPermissions(
 	ctx context.Context,
 	req *accessprotos.AccessControl_ListRequest,
@@ -128,7 +141,7 @@ func (srv *AccessControlServer) CheckPermissions(
 	if err != nil {
 		return voidRes, err
 	}
-	acl, err := srv.getACL(req.Operator)
+	acl, err := srv.getEffectiveACL(req.Operator)
 	if err != nil {
 		return voidRes, err
 	}
@@ -10,13 +10,28 @@ package servicers
 
 // Internal accessd related utility functions
 import (
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/protos"
 	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
 
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// doInTx calls fn with a server whose datastore reads and writes of the ACL
+// and role tables all run in one transaction
+func (srv *AccessControlServer) doInTx(fn func(srv *AccessControlServer) error) error {
+	err := srv.store.DoInTx([]string{ACCESS_TABLE, ROLE_TABLE}, func(store datastore.TxApi) error {
+		return fn(&AccessControlServer{store})
+	})
+	if _, ok := status.FromError(err); !ok {
+		// fn returns gRPC errors, other errors are transaction errors
+		return protos.Errorf(codes.Aborted, "Transaction error: %s", err)
+	}
+	return err
+}
+
 // storeParamsPair returns a pair of strings to aid querying Access Control
 // related datastore tables - (key, table)
 // key: id's Identity Hash String to be used as the query key and
@@ -47,8 +62,12 @@ func (srv *AccessControlServer) getACLForKey(
 	acl := &accessprotos.AccessControl_List{}
 	marshaledAcl, _, err := srv.store.Get(table, opkey)
 	if err != nil {
+		code := codes.Internal
+		if datastore.IsErrNotFound(err) {
+			code = codes.NotFound
+		}
 		return acl,
-			protos.Errorf(codes.NotFound,
+			protos.Errorf(code,
 				"Get ACL error '%s' for Operator %s, table %s",
 				err, opkey, table)
 	}
@@ -208,7 +227,7 @@ func (srv *AccessControlServer) getACLEntity(
 	if err != nil {
 		return res, err
 	}
-	acl, err := srv.getACL(req.Operator)
+	acl, err := srv.getEffectiveACL(req.Operator)
 	if err != nil {
 		return res, err
 	}
@@ -0,0 +1,300 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"regexp"
+	"sort"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ROLE_TABLE = "access_roles"
+)
+
+// Role names are used in REST URLs, e.g. network-admin, read_only
+var roleNameRegex = regexp.MustCompile(`^[a-zA-Z][\w-]*$`)
+
+// SetRole creates a new Role or overwrites the existing Role with the same name
+func (srv *AccessControlServer) SetRole(ctx context.Context, role *accessprotos.Role) (*protos.Void, error) {
+	if err := verifyRole(role); err != nil {
+		return &protos.Void{}, err
+	}
+	return &protos.Void{}, srv.putRole(role)
+}
+
+// CreateRole creates a new Role, it fails if a Role with the same name exists
+func (srv *AccessControlServer) CreateRole(ctx context.Context, role *accessprotos.Role) (*protos.Void, error) {
+	if err := verifyRole(role); err != nil {
+		return &protos.Void{}, err
+	}
+	err := srv.doInTx(func(srv *AccessControlServer) error {
+		exists, err := srv.store.DoesKeyExist(ROLE_TABLE, role.Name)
+		if err != nil {
+			return protos.Errorf(codes.Internal, "Error '%s' checking Role %s, table %s", err, role.Name, ROLE_TABLE)
+		}
+		if exists {
+			return protos.Errorf(codes.AlreadyExists, "Role %s already exists", role.Name)
+		}
+		return srv.putRole(role)
+	})
+	return &protos.Void{}, err
+}
+
+// UpdateRole overwrites the existing Role with the same name, it fails if the
+// Role doesn't exist
+func (srv *AccessControlServer) UpdateRole(ctx context.Context, role *accessprotos.Role) (*protos.Void, error) {
+	if err := verifyRole(role); err != nil {
+		return &protos.Void{}, err
+	}
+	err := srv.doInTx(func(srv *AccessControlServer) error {
+		if _, err := srv.getRole(role.Name); err != nil {
+			return err
+		}
+		return srv.putRole(role)
+	})
+	return &protos.Void{}, err
+}
+
+// GetRole returns the Role with the given name
+func (srv *AccessControlServer) GetRole(ctx context.Context, req *accessprotos.RoleName) (*accessprotos.Role, error) {
+	if req == nil {
+		return &accessprotos.Role{}, protos.Errorf(codes.InvalidArgument, "Nil Role Name")
+	}
+	return srv.getRole(req.Name)
+}
+
+// DeleteRole removes the Role, it fails if the Role is still bound to any
+// operator
+func (srv *AccessControlServer) DeleteRole(ctx context.Context, req *accessprotos.RoleName) (*protos.Void, error) {
+	if req == nil {
+		return &protos.Void{}, protos.Errorf(codes.InvalidArgument, "Nil Role Name")
+	}
+	// The Role must not be bound concurrently, so the bindings are checked
+	// in the transaction deleting the Role
+	err := srv.doInTx(func(srv *AccessControlServer) error {
+		if _, err := srv.getRole(req.Name); err != nil {
+			return err
+		}
+		keys, err := srv.store.ListKeys(ACCESS_TABLE)
+		if err != nil {
+			return protos.Errorf(codes.Internal, "Error %s listing table %s keys", err, ACCESS_TABLE)
+		}
+		acls, err := srv.getACLsForKeys(ACCESS_TABLE, keys)
+		if err != nil {
+			return err
+		}
+		for _, acl := range acls {
+			for _, name := range acl.Roles {
+				if name == req.Name {
+					return protos.Errorf(
+						codes.FailedPrecondition,
+						"Role %s is bound to Operator %s", req.Name, acl.Operator.HashString())
+				}
+			}
+		}
+		err = srv.store.Delete(ROLE_TABLE, req.Name)
+		if err != nil {
+			return protos.Errorf(
+				codes.Internal, "Role %s Delete from table %s error: %s", req.Name, ROLE_TABLE, err)
+		}
+		return nil
+	})
+	return &protos.Void{}, err
+}
+
+// ListRoles returns all Roles sorted by name
+func (srv *AccessControlServer) ListRoles(ctx context.Context, _ *protos.Void) (*accessprotos.Roles, error) {
+	res := &accessprotos.Roles{}
+	keys, err := srv.store.ListKeys(ROLE_TABLE)
+	if err != nil {
+		return res, protos.Errorf(codes.Internal, "Error %s listing table %s keys", err, ROLE_TABLE)
+	}
+	roles, err := srv.getRoles(keys)
+	if err != nil {
+		return res, err
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	res.Roles = roles
+	return res, nil
+}
+
+// SetOperatorRoles overwrites the Roles bound to the operator
+func (srv *AccessControlServer) SetOperatorRoles(
+	ctx context.Context,
+	req *accessprotos.RoleBindingRequest,
+) (*protos.Void, error) {
+	if req == nil || req.Operator == nil {
+		return &protos.Void{}, protos.Errorf(codes.InvalidArgument, "Nil Operator")
+	}
+	// The Roles must not be deleted concurrently, so they're checked in the
+	// transaction binding them
+	err := srv.doInTx(func(srv *AccessControlServer) error {
+		roles, err := srv.getRoles(req.Roles)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(roles))
+		for _, role := range roles {
+			names = append(names, role.Name)
+		}
+		for _, name := range req.Roles {
+			if !containsString(names, name) {
+				return protos.Errorf(codes.NotFound, "Role %s not found", name)
+			}
+		}
+		sort.Strings(names)
+
+		acl, err := srv.getACL(req.Operator)
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				return err
+			}
+			acl = &accessprotos.AccessControl_List{
+				Operator: req.Operator,
+				Entities: map[string]*accessprotos.AccessControl_Entity{},
+			}
+		}
+		acl.Roles = names
+		return srv.putACL(req.Operator, acl)
+	})
+	return &protos.Void{}, err
+}
+
+// getEffectiveACL returns Operator's ACL with the entities of its Roles added
+// to its own entities. It's the ACL all operator's permissions are evaluated
+// against
+func (srv *AccessControlServer) getEffectiveACL(
+	oper *protos.Identity,
+) (*accessprotos.AccessControl_List, error) {
+	acl, err := srv.getACL(oper)
+	if err != nil || len(acl.Roles) == 0 {
+		return acl, err
+	}
+	roles, err := srv.getRoles(acl.Roles)
+	if err != nil {
+		return acl, err
+	}
+	if len(roles) != len(acl.Roles) {
+		glog.Errorf("Missing Roles for Operator %s, bound Roles: %v", oper.HashString(), acl.Roles)
+	}
+	effective := &accessprotos.AccessControl_List{
+		Operator: acl.Operator,
+		Entities: make(map[string]*accessprotos.AccessControl_Entity, len(acl.Entities)),
+		Roles:    acl.Roles,
+	}
+	for hash, ent := range acl.Entities {
+		effective.Entities[hash] = ent
+	}
+	for _, role := range roles {
+		for _, ent := range role.Entities {
+			hash := ent.Id.HashString()
+			if existing, ok := effective.Entities[hash]; ok {
+				ent = &accessprotos.AccessControl_Entity{
+					Id:          existing.Id,
+					Permissions: existing.Permissions | ent.Permissions,
+				}
+			}
+			effective.Entities[hash] = ent
+		}
+	}
+	return effective, nil
+}
+
+func (srv *AccessControlServer) putRole(role *accessprotos.Role) error {
+	marshaledRole, err := proto.Marshal(role)
+	if err != nil {
+		return protos.Errorf(codes.Internal, "Role Marshal error '%s' for Role %s", err, role.Name)
+	}
+	err = srv.store.Put(ROLE_TABLE, role.Name, marshaledRole)
+	if err != nil {
+		return protos.Errorf(
+			codes.Internal, "Role PUT error '%s' for Role %s, table %s", err, role.Name, ROLE_TABLE)
+	}
+	return nil
+}
+
+func (srv *AccessControlServer) getRole(name string) (*accessprotos.Role, error) {
+	marshaledRole, _, err := srv.store.Get(ROLE_TABLE, name)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return nil, protos.Errorf(codes.NotFound, "Role %s not found", name)
+		}
+		return nil, protos.Errorf(codes.Internal, "Get Role error '%s' for Role %s", err, name)
+	}
+	role := &accessprotos.Role{}
+	err = proto.Unmarshal(marshaledRole, role)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Role Unmarshal error '%s' for Role %s", err, name)
+	}
+	return role, nil
+}
+
+// getRoles returns the existing Roles of the given names
+func (srv *AccessControlServer) getRoles(names []string) ([]*accessprotos.Role, error) {
+	if len(names) == 0 {
+		return []*accessprotos.Role{}, nil
+	}
+	marshaledRoles, err := srv.store.GetMany(ROLE_TABLE, names)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Get Roles error '%s' for Roles %v", err, names)
+	}
+	roles := make([]*accessprotos.Role, 0, len(marshaledRoles))
+	for name, marshaledRole := range marshaledRoles {
+		role := &accessprotos.Role{}
+		err = proto.Unmarshal(marshaledRole.Value, role)
+		if err != nil {
+			return nil, protos.Errorf(codes.Internal, "Role Unmarshal error '%s' for Role %s", err, name)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func verifyRole(role *accessprotos.Role) error {
+	if role == nil {
+		return protos.Errorf(codes.InvalidArgument, "Nil Role")
+	}
+	err := verifyRoleName(role.Name)
+	if err != nil {
+		return err
+	}
+	for i, ent := range role.Entities {
+		if ent == nil || ent.Id == nil || ent.Permissions == accessprotos.AccessControl_NONE {
+			return protos.Errorf(
+				codes.InvalidArgument, "Invalid Role Entity @ index: %d ", i)
+		}
+	}
+	return nil
+}
+
+func verifyRoleName(name string) error {
+	if !roleNameRegex.MatchString(name) {
+		return protos.Errorf(codes.InvalidArgument, "Invalid Role Name: '%s'", name)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	TOKEN_TABLE = "access_tokens"
+
+	tokenIdLen     = 16
+	tokenSecretLen = 32
+	// bearer token's ID & secret separator
+	tokenSeparator = "."
+)
+
+// IssueToken issues a new API token for the operator, the token scope must be
+// a subset of the operator's ACL
+func (srv *AccessControlServer) IssueToken(
+	ctx context.Context,
+	req *accessprotos.IssueTokenRequest,
+) (*accessprotos.IssuedToken, error) {
+	res := &accessprotos.IssuedToken{}
+	err := verifyIssueTokenRequest(req)
+	if err != nil {
+		return res, err
+	}
+	acl, err := srv.getEffectiveACL(req.Operator)
+	if err != nil {
+		return res, err
+	}
+	// a token can't grant more than its operator has
+	err = checkEntitiesPermissions(acl, req.Entities)
+	if err != nil {
+		return res, err
+	}
+
+	id := make([]byte, tokenIdLen)
+	secret := make([]byte, tokenSecretLen)
+	if _, err = rand.Read(id); err != nil {
+		return res, protos.Errorf(codes.Internal, "Failed to generate token ID: %s", err)
+	}
+	if _, err = rand.Read(secret); err != nil {
+		return res, protos.Errorf(codes.Internal, "Failed to generate token secret: %s", err)
+	}
+	secretStr := base64.RawURLEncoding.EncodeToString(secret)
+	secretHash := sha256.Sum256([]byte(secretStr))
+	createdAt, err := ptypes.TimestampProto(clock.Now())
+	if err != nil {
+		return res, protos.Errorf(codes.Internal, "Invalid token creation time: %s", err)
+	}
+	token := &accessprotos.APIToken{
+		Id:          hex.EncodeToString(id),
+		Operator:    req.Operator,
+		Entities:    req.Entities,
+		Description: req.Description,
+		CreatedAt:   createdAt,
+		ExpiresAt:   req.ExpiresAt,
+		SecretHash:  secretHash[:],
+	}
+	err = srv.putToken(token)
+	if err != nil {
+		return res, err
+	}
+	token.SecretHash = nil
+	res.Token = token
+	res.Bearer = token.Id + tokenSeparator + secretStr
+	return res, nil
+}
+
+// RevokeToken revokes the API token with the given ID
+func (srv *AccessControlServer) RevokeToken(ctx context.Context, req *accessprotos.TokenID) (*protos.Void, error) {
+	if req == nil || len(req.Id) == 0 {
+		return &protos.Void{}, protos.Errorf(codes.InvalidArgument, "Missing Token ID")
+	}
+	if _, err := srv.getToken(req.Id); err != nil {
+		return &protos.Void{}, err
+	}
+	err := srv.store.Delete(TOKEN_TABLE, req.Id)
+	if err != nil {
+		return &protos.Void{}, protos.Errorf(
+			codes.Internal, "Token %s Delete from table %s error: %s", req.Id, TOKEN_TABLE, err)
+	}
+	return &protos.Void{}, nil
+}
+
+// ListTokens lists all API tokens of the operator, including expired ones
+func (srv *AccessControlServer) ListTokens(ctx context.Context, oper *protos.Identity) (*accessprotos.APITokens, error) {
+	res := &accessprotos.APITokens{}
+	if oper == nil {
+		return res, protos.Errorf(codes.InvalidArgument, "Nil Operator")
+	}
+	tokens, err := srv.getOperatorTokens(oper)
+	if err != nil {
+		return res, err
+	}
+	for _, token := range tokens {
+		token.SecretHash = nil
+		res.Tokens = append(res.Tokens, token)
+	}
+	return res, nil
+}
+
+// getOperatorTokens returns all tokens issued to the Operator keyed by their
+// IDs
+func (srv *AccessControlServer) getOperatorTokens(oper *protos.Identity) (map[string]*accessprotos.APIToken, error) {
+	keys, err := srv.store.ListKeys(TOKEN_TABLE)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Error %s listing table %s keys", err, TOKEN_TABLE)
+	}
+	marshaledTokens, err := srv.store.GetMany(TOKEN_TABLE, keys)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Get Tokens error '%s', table %s", err, TOKEN_TABLE)
+	}
+	opkey := oper.HashString()
+	res := map[string]*accessprotos.APIToken{}
+	for id, marshaledToken := range marshaledTokens {
+		token := &accessprotos.APIToken{}
+		err = proto.Unmarshal(marshaledToken.Value, token)
+		if err != nil {
+			return nil, protos.Errorf(codes.Internal, "Token Unmarshal error '%s' for Token %s", err, id)
+		}
+		if token.Operator.HashString() == opkey {
+			res[id] = token
+		}
+	}
+	return res, nil
+}
+
+// deleteOperatorTokens deletes all tokens issued to the Operator
+func (srv *AccessControlServer) deleteOperatorTokens(oper *protos.Identity) error {
+	tokens, err := srv.getOperatorTokens(oper)
+	if err != nil || len(tokens) == 0 {
+		return err
+	}
+	ids := make([]string, 0, len(tokens))
+	for id := range tokens {
+		ids = append(ids, id)
+	}
+	failed, err := srv.store.DeleteMany(TOKEN_TABLE, ids)
+	if err == nil && len(failed) > 0 {
+		err = fmt.Errorf("%v", failed)
+	}
+	if err != nil {
+		return protos.Errorf(codes.Internal, "Error deleting Tokens of Operator %s: %s", oper.HashString(), err)
+	}
+	return nil
+}
+
+// CheckTokenPermissions verifies the bearer token and its permissions for
+// a list of given entities against both the token scope and the operator's
+// ACL and returns the token's operator Identity
+func (srv *AccessControlServer) CheckTokenPermissions(
+	ctx context.Context,
+	req *accessprotos.TokenPermissionsRequest,
+) (*protos.Identity, error) {
+	if req == nil {
+		return &protos.Identity{}, protos.Errorf(codes.InvalidArgument, "Nil TokenPermissionsRequest")
+	}
+	token, err := srv.authenticateToken(req.Bearer)
+	if err != nil {
+		return &protos.Identity{}, err
+	}
+	scope := &accessprotos.AccessControl_List{
+		Operator: token.Operator,
+		Entities: map[string]*accessprotos.AccessControl_Entity{},
+	}
+	err = addToACL(token.Operator, scope, token.Entities)
+	if err != nil {
+		return &protos.Identity{}, err
+	}
+	err = checkEntitiesPermissions(scope, req.Entities)
+	if err != nil {
+		return &protos.Identity{}, err
+	}
+	// the operator's ACL may have been reduced since the token was issued
+	acl, err := srv.getEffectiveACL(token.Operator)
+	if err != nil {
+		return &protos.Identity{}, err
+	}
+	err = checkEntitiesPermissions(acl, req.Entities)
+	if err != nil {
+		return &protos.Identity{}, err
+	}
+	return token.Operator, nil
+}
+
+// authenticateToken returns the stored token of the bearer if its secret
+// matches & it's not expired
+func (srv *AccessControlServer) authenticateToken(bearer string) (*accessprotos.APIToken, error) {
+	sepIdx := strings.Index(bearer, tokenSeparator)
+	if sepIdx <= 0 {
+		return nil, protos.Errorf(codes.Unauthenticated, "Malformed Token")
+	}
+	id, secret := bearer[:sepIdx], bearer[sepIdx+1:]
+	token, err := srv.getToken(id)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, protos.Errorf(codes.Unauthenticated, "Unknown or revoked Token %s", id)
+		}
+		return nil, err
+	}
+	secretHash := sha256.Sum256([]byte(secret))
+	if subtle.ConstantTimeCompare(secretHash[:], token.SecretHash) != 1 {
+		return nil, protos.Errorf(codes.Unauthenticated, "Invalid Token %s secret", id)
+	}
+	expiresAt, err := ptypes.Timestamp(token.ExpiresAt)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Invalid Token %s expiration: %s", id, err)
+	}
+	if !clock.Now().Before(expiresAt) {
+		return nil, protos.Errorf(codes.Unauthenticated, "Token %s expired at %s", id, expiresAt)
+	}
+	if token.Operator == nil {
+		return nil, protos.Errorf(codes.Internal, "Nil Operator for Token %s", id)
+	}
+	return token, nil
+}
+
+func (srv *AccessControlServer) getToken(id string) (*accessprotos.APIToken, error) {
+	marshaledToken, _, err := srv.store.Get(TOKEN_TABLE, id)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return nil, protos.Errorf(codes.NotFound, "Token %s not found", id)
+		}
+		return nil, protos.Errorf(codes.Internal, "Get Token error '%s' for Token %s", err, id)
+	}
+	token := &accessprotos.APIToken{}
+	err = proto.Unmarshal(marshaledToken, token)
+	if err != nil {
+		return nil, protos.Errorf(codes.Internal, "Token Unmarshal error '%s' for Token %s", err, id)
+	}
+	return token, nil
+}
+
+func (srv *AccessControlServer) putToken(token *accessprotos.APIToken) error {
+	marshaledToken, err := proto.Marshal(token)
+	if err != nil {
+		return protos.Errorf(codes.Internal, "Token Marshal error '%s' for Token %s", err, token.Id)
+	}
+	err = srv.store.Put(TOKEN_TABLE, token.Id, marshaledToken)
+	if err != nil {
+		return protos.Errorf(
+			codes.Internal, "Token PUT error '%s' for Token %s, table %s", err, token.Id, TOKEN_TABLE)
+	}
+	return nil
+}
+
+func verifyIssueTokenRequest(req *accessprotos.IssueTokenRequest) error {
+	if req == nil {
+		return protos.Errorf(codes.InvalidArgument, "Nil IssueTokenRequest")
+	}
+	if req.Operator == nil {
+		return protos.Errorf(codes.InvalidArgument, "Nil Operator")
+	}
+	if len(req.Entities) == 0 {
+		return protos.Errorf(codes.InvalidArgument, "Token scope must have at least one Entity")
+	}
+	for i, ent := range req.Entities {
+		if ent == nil || ent.Id == nil || ent.Permissions == accessprotos.AccessControl_NONE {
+			return protos.Errorf(codes.InvalidArgument, "Invalid Entity @ index: %d ", i)
+		}
+	}
+	expiresAt, err := ptypes.Timestamp(req.ExpiresAt)
+	if err != nil {
+		return protos.Errorf(codes.InvalidArgument, "Invalid Token expiration: %s", err)
+	}
+	if !clock.Now().Before(expiresAt) {
+		return protos.Errorf(codes.InvalidArgument, "Token expiration %s is in the past", expiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package accessd_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	accessd_test_service "magma/orc8r/cloud/go/services/accessd/test_init"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessManager_Tokens(t *testing.T) {
+	accessd_test_service.StartTestService(t)
+
+	op := identity.NewOperator("token_operator")
+	net1 := identity.NewNetwork("network1")
+	net2 := identity.NewNetwork("network2")
+	err := accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{
+		{Id: identity.NewNetworkWildcard(), Permissions: accessprotos.AccessControl_READ},
+		{Id: net1, Permissions: accessprotos.ACCESS_CONTROL_ALL_PERMISSIONS},
+	})
+	require.NoError(t, err)
+	defer accessd.DeleteOperator(op)
+
+	readNet1 := &accessprotos.AccessControl_Entity{Id: net1, Permissions: accessprotos.AccessControl_READ}
+	writeNet1 := &accessprotos.AccessControl_Entity{Id: net1, Permissions: accessprotos.AccessControl_WRITE}
+	readNet2 := &accessprotos.AccessControl_Entity{Id: net2, Permissions: accessprotos.AccessControl_READ}
+	writeNet2 := &accessprotos.AccessControl_Entity{Id: net2, Permissions: accessprotos.AccessControl_WRITE}
+	expiresAt := time.Now().Add(time.Hour)
+
+	// The token scope must be a subset of the operator's ACL
+	_, err = accessd.IssueToken(op, []*accessprotos.AccessControl_Entity{writeNet2}, "", expiresAt)
+	assert.Error(t, err)
+	_, err = accessd.IssueToken(op, []*accessprotos.AccessControl_Entity{readNet1}, "", time.Now().Add(-time.Hour))
+	assert.Error(t, err)
+	_, err = accessd.IssueToken(op, nil, "", expiresAt)
+	assert.Error(t, err)
+
+	issued, err := accessd.IssueToken(op, []*accessprotos.AccessControl_Entity{readNet1}, "automation", expiresAt)
+	require.NoError(t, err)
+	assert.NotEmpty(t, issued.Bearer)
+	assert.Empty(t, issued.Token.SecretHash)
+	assert.Equal(t, op.HashString(), issued.Token.Operator.HashString())
+
+	// The token only grants its scope, which may be narrower than the ACL
+	oper, err := accessd.CheckTokenPermissions(issued.Bearer, readNet1)
+	assert.NoError(t, err)
+	assert.Equal(t, op.HashString(), oper.HashString())
+	_, err = accessd.CheckTokenPermissions(issued.Bearer)
+	assert.NoError(t, err)
+	_, err = accessd.CheckTokenPermissions(issued.Bearer, writeNet1)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = accessd.CheckTokenPermissions(issued.Bearer, readNet2)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Wildcard scopes are evaluated as in ACLs
+	wildcard, err := accessd.IssueToken(
+		op,
+		[]*accessprotos.AccessControl_Entity{
+			{Id: identity.NewNetworkWildcard(), Permissions: accessprotos.AccessControl_READ},
+		},
+		"",
+		expiresAt)
+	require.NoError(t, err)
+	_, err = accessd.CheckTokenPermissions(wildcard.Bearer, readNet1, readNet2)
+	assert.NoError(t, err)
+
+	// Reducing the operator's ACL reduces its tokens' permissions
+	err = accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{
+		{Id: net1, Permissions: accessprotos.ACCESS_CONTROL_ALL_PERMISSIONS},
+	})
+	require.NoError(t, err)
+	_, err = accessd.CheckTokenPermissions(wildcard.Bearer, readNet2)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = accessd.CheckTokenPermissions(wildcard.Bearer, readNet1)
+	assert.NoError(t, err)
+
+	// Wrong secrets & unknown tokens
+	_, err = accessd.CheckTokenPermissions(issued.Token.Id+".wrong", readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = accessd.CheckTokenPermissions("unknown.secret", readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = accessd.CheckTokenPermissions(issued.Token.Id, readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Expired tokens
+	clock.SetAndFreezeClock(t, expiresAt)
+	_, err = accessd.CheckTokenPermissions(issued.Bearer, readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	clock.UnfreezeClock(t)
+
+	tokens, err := accessd.ListTokens(op)
+	assert.NoError(t, err)
+	require.Len(t, tokens, 2)
+	for _, token := range tokens {
+		assert.Empty(t, token.SecretHash)
+		assert.Contains(t, []string{issued.Token.Id, wildcard.Token.Id}, token.Id)
+	}
+	tokens, err = accessd.ListTokens(identity.NewOperator("other_operator"))
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	// Revoked tokens
+	assert.NoError(t, accessd.RevokeToken(issued.Token.Id))
+	_, err = accessd.CheckTokenPermissions(issued.Bearer, readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Error(t, accessd.RevokeToken(issued.Token.Id))
+	assert.NoError(t, accessd.RevokeToken(wildcard.Token.Id))
+	tokens, err = accessd.ListTokens(op)
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestAccessManager_DeleteOperatorTokens(t *testing.T) {
+	accessd_test_service.StartTestService(t)
+
+	op := identity.NewOperator("deleted_operator")
+	net1 := identity.NewNetwork("network1")
+	readNet1 := &accessprotos.AccessControl_Entity{Id: net1, Permissions: accessprotos.AccessControl_READ}
+	err := accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{readNet1})
+	require.NoError(t, err)
+	issued, err := accessd.IssueToken(op, []*accessprotos.AccessControl_Entity{readNet1}, "", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Deleting the operator deletes its tokens, which must not come back to
+	// life when an operator with the same name is created again
+	require.NoError(t, accessd.DeleteOperator(op))
+	err = accessd.SetOperator(op, []*accessprotos.AccessControl_Entity{readNet1})
+	require.NoError(t, err)
+	defer accessd.DeleteOperator(op)
+	_, err = accessd.CheckTokenPermissions(issued.Bearer, readNet1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	tokens, err := accessd.ListTokens(op)
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/servicers"
+	"magma/orc8r/cloud/go/services/audit/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// default for how long audit records are kept
+	defaultRetention = time.Hour * 24 * 90
+	// default for how often to delete expired audit records
+	defaultPruneInterval = time.Hour
+)
+
+func main() {
+	srv, err := service.NewOrchestratorService(orc8r.ModuleName, audit.ServiceName)
+	if err != nil {
+		glog.Fatalf("Error creating audit service %s", err)
+	}
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		glog.Fatalf("Failed to connect to database: %s", err)
+	}
+	store := storage.NewSQLStore(db, sqorc.GetSqlBuilder())
+	err = store.Initialize()
+	if err != nil {
+		glog.Fatalf("Error initializing audit database: %s", err)
+	}
+
+	server, err := servicers.NewAuditServicer(store)
+	if err != nil {
+		glog.Fatalf("Error creating audit server: %s", err)
+	}
+	protos.RegisterAuditLogServer(srv.GrpcServer, server)
+
+	auditConfig, err := config.GetServiceConfig(orc8r.ModuleName, audit.ServiceName)
+	if err != nil {
+		glog.Errorf("Failed to load audit config, using defaults: %v", err)
+	}
+	retention := getDurationSecsParam(auditConfig, "retention_secs", defaultRetention)
+	interval := getDurationSecsParam(auditConfig, "prune_interval_secs", defaultPruneInterval)
+	go servicers.NewRetentionEnforcer(store, retention).Run(interval)
+
+	err = srv.Run()
+	if err != nil {
+		glog.Fatalf("Error running service: %s", err)
+	}
+}
+
+func getDurationSecsParam(auditConfig *config.ConfigMap, key string, defaultValue time.Duration) time.Duration {
+	if auditConfig == nil {
+		return defaultValue
+	}
+	secs, err := auditConfig.GetIntParam(key)
+	if err != nil || secs <= 0 {
+		return defaultValue
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/registry"
+	"magma/orc8r/cloud/go/services/audit/protos"
+
+	"github.com/golang/glog"
+)
+
+func getAuditClient() (protos.AuditLogClient, error) {
+	conn, err := registry.GetConnection(ServiceName)
+	if err != nil {
+		initErr := merrors.NewInitError(err, ServiceName)
+		glog.Error(initErr)
+		return nil, initErr
+	}
+	return protos.NewAuditLogClient(conn), err
+}
+
+// recordTimeout bounds how long a request waits on the audit service
+const recordTimeout = 10 * time.Second
+
+// Record stores an audit record of a mutating northbound request
+func Record(record *protos.AuditRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancel()
+	return recordWithContext(ctx, record)
+}
+
+func recordWithContext(ctx context.Context, record *protos.AuditRecord) error {
+	client, err := getAuditClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.Record(ctx, record)
+	return err
+}
+
+// Query returns the audit records matching the given filter, newest first
+func Query(filter *protos.QueryRequest) ([]*protos.AuditRecord, error) {
+	records, _, err := QueryPage(filter)
+	return records, err
+}
+
+// QueryPage returns the audit records matching the given filter, newest
+// first, and the token of the next page if the filter has a limit and more
+// records match it. It returns an InvalidArgument error if the filter's page
+// token is invalid.
+func QueryPage(filter *protos.QueryRequest) ([]*protos.AuditRecord, string, error) {
+	client, err := getAuditClient()
+	if err != nil {
+		return nil, "", err
+	}
+	res, err := client.Query(context.Background(), filter)
+	if err != nil {
+		return nil, "", err
+	}
+	return res.Records, res.NextPageToken, nil
+}
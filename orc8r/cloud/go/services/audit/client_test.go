@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ */
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/servicers"
+	"magma/orc8r/cloud/go/services/audit/test_init"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditService(t *testing.T) {
+	store := test_init.StartTestService(t)
+
+	records, err := audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// Method and path are required
+	assert.Error(t, audit.Record(&protos.AuditRecord{Method: "PUT"}))
+
+	// Records are stamped with the current time
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	assert.NoError(t, audit.Record(&protos.AuditRecord{Operator: "admin", Method: "POST", Path: "/magma/v1/networks", ResultCode: 201}))
+	clock.SetAndFreezeClock(t, time.Unix(2000, 0))
+	assert.NoError(t, audit.Record(&protos.AuditRecord{Operator: "bob", NetworkId: "n1", Method: "DELETE", Path: "/magma/v1/networks/n1", ResultCode: 403}))
+	clock.UnfreezeClock(t)
+
+	records, err = audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.NotEmpty(t, records[0].Id)
+	assert.NotEqual(t, records[0].Id, records[1].Id)
+	assert.Equal(t, uint64(2000000), records[0].TimeMs)
+	assert.Equal(t, "bob", records[0].Operator)
+	assert.Equal(t, uint64(1000000), records[1].TimeMs)
+	assert.Equal(t, "admin", records[1].Operator)
+
+	records, err = audit.Query(&protos.QueryRequest{Operator: "admin"})
+	assert.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "/magma/v1/networks", records[0].Path)
+	_, err = audit.Query(&protos.QueryRequest{StartMs: 2000, EndMs: 1000})
+	assert.Error(t, err)
+
+	// Records older than the retention period are pruned
+	enforcer := servicers.NewRetentionEnforcer(store, time.Hour)
+	clock.SetAndFreezeClock(t, time.Unix(1000+3600+1, 0))
+	assert.NoError(t, enforcer.PruneExpiredRecords())
+	clock.UnfreezeClock(t)
+	records, err = audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "bob", records[0].Operator)
+
+	// Non-positive retention keeps records forever
+	assert.NoError(t, servicers.NewRetentionEnforcer(store, 0).PruneExpiredRecords())
+	records, err = audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
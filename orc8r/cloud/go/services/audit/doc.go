@@ -0,0 +1,19 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package audit contains the audit service.
+// The audit service durably stores a record of every mutating northbound
+// (REST) request: who made it, from where, what it changed, and its result.
+// Records are written by the obsidian audit middleware and are kept according
+// to the service's retention policy.
+package audit
+
+const (
+	// ServiceName is the name of this service
+	ServiceName = "AUDIT"
+)
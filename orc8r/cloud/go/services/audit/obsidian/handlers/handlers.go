@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/obsidian/models"
+	"magma/orc8r/cloud/go/services/audit/protos"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	AuditRootPath = obsidian.V1Root + "audit"
+
+	queryParamStart     = "start"
+	queryParamEnd       = "end"
+	queryParamOperator  = "operator"
+	queryParamNetworkID = "network_id"
+	queryParamLimit     = "limit"
+
+	defaultQueryLimit = 100
+)
+
+// GetObsidianHandlers returns all obsidian handlers for the audit service
+func GetObsidianHandlers() []obsidian.Handler {
+	return []obsidian.Handler{
+		{Path: AuditRootPath, Methods: obsidian.GET, HandlerFunc: GetAuditRecordsHandler},
+	}
+}
+
+// GetAuditRecordsHandler returns the audit records of mutating requests,
+// newest first. The records can be filtered by time range (in unix
+// milliseconds), operator and network. If page_size or page_token is set,
+// a page of records is returned along with the token of the next page.
+func GetAuditRecordsHandler(c echo.Context) error {
+	startMs, nerr := obsidian.GetUintQueryParam(c, queryParamStart, 64)
+	if nerr != nil {
+		return nerr
+	}
+	endMs, nerr := obsidian.GetUintQueryParam(c, queryParamEnd, 64)
+	if nerr != nil {
+		return nerr
+	}
+	limit, nerr := obsidian.GetUintQueryParam(c, queryParamLimit, 32)
+	if nerr != nil {
+		return nerr
+	}
+	if endMs != 0 && endMs < startMs {
+		return obsidian.HttpError(errors.New("end must not be before start"), http.StatusBadRequest)
+	}
+	pageSize, pageToken, nerr := obsidian.GetPaginationParams(c)
+	if nerr != nil {
+		return nerr
+	}
+	if pageSize != 0 && limit != 0 {
+		return obsidian.HttpError(errors.New("limit can't be used with pagination"), http.StatusBadRequest)
+	}
+	if limit == 0 {
+		limit = defaultQueryLimit
+	}
+
+	filter := &protos.QueryRequest{
+		StartMs:   startMs,
+		EndMs:     endMs,
+		Operator:  c.QueryParam(queryParamOperator),
+		NetworkId: c.QueryParam(queryParamNetworkID),
+		Limit:     uint32(limit),
+	}
+	if pageSize == 0 {
+		records, err := audit.Query(filter)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, models.AuditRecordsFromProtos(records))
+	}
+
+	filter.Limit = pageSize
+	filter.PageToken = pageToken
+	records, nextPageToken, err := audit.QueryPage(filter)
+	if err != nil {
+		return obsidian.PaginatedLoadHttpError(err)
+	}
+	return c.JSON(http.StatusOK, &models.PaginatedAuditRecords{
+		Records:       models.AuditRecordsFromProtos(records),
+		NextPageToken: nextPageToken,
+	})
+}
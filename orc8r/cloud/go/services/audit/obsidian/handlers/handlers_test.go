@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"magma/orc8r/cloud/go/services/audit/obsidian/handlers"
+	"magma/orc8r/cloud/go/services/audit/obsidian/models"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/test_init"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditRecordsHandler(t *testing.T) {
+	store := test_init.StartTestService(t)
+	assert.NoError(t, store.Write(&protos.AuditRecord{Id: "r1", TimeMs: 1000, Operator: "admin", Method: "POST", Path: "/magma/v1/networks", ResultCode: 201}))
+	assert.NoError(t, store.Write(&protos.AuditRecord{Id: "r2", TimeMs: 2000, Operator: "bob", NetworkId: "n1", Method: "PUT", Path: "/magma/v1/networks/n1/name", ResultCode: 204}))
+	assert.NoError(t, store.Write(&protos.AuditRecord{Id: "r3", TimeMs: 3000, Operator: "admin", NetworkId: "n1", Method: "DELETE", Path: "/magma/v1/networks/n1", ResultCode: 204}))
+
+	records, err := getRecords(t, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r3", "r2", "r1"}, getIDs(records))
+	assert.Equal(t, &models.AuditRecord{
+		ID:         "r2",
+		Time:       2000,
+		Operator:   "bob",
+		NetworkID:  "n1",
+		Method:     "PUT",
+		Path:       "/magma/v1/networks/n1/name",
+		ResultCode: 204,
+	}, records[1])
+
+	records, err = getRecords(t, "?start=1500&end=2500")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r2"}, getIDs(records))
+	records, err = getRecords(t, "?operator=admin&limit=1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r3"}, getIDs(records))
+	records, err = getRecords(t, "?network_id=n1&operator=admin")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r3"}, getIDs(records))
+
+	// Pagination
+	page, err := getPage(t, "?page_size=2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r3", "r2"}, getIDs(page.Records))
+	assert.NotEmpty(t, page.NextPageToken)
+	page, err = getPage(t, "?page_size=2&page_token="+page.NextPageToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r1"}, getIDs(page.Records))
+	assert.Empty(t, page.NextPageToken)
+	_, err = getPage(t, "?page_token=!!!")
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	_, err = getPage(t, "?page_size=2&limit=1")
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	_, err = getRecords(t, "?start=foo")
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	_, err = getRecords(t, "?start=2000&end=1000")
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+}
+
+func getRecords(t *testing.T, query string) ([]*models.AuditRecord, error) {
+	req := httptest.NewRequest(echo.GET, handlers.AuditRootPath+query, nil)
+	rec := httptest.NewRecorder()
+	err := handlers.GetAuditRecordsHandler(echo.New().NewContext(req, rec))
+	if err != nil {
+		return nil, err
+	}
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var records []*models.AuditRecord
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &records))
+	return records, nil
+}
+
+func getPage(t *testing.T, query string) (*models.PaginatedAuditRecords, error) {
+	req := httptest.NewRequest(echo.GET, handlers.AuditRootPath+query, nil)
+	rec := httptest.NewRecorder()
+	err := handlers.GetAuditRecordsHandler(echo.New().NewContext(req, rec))
+	if err != nil {
+		return nil, err
+	}
+	assert.Equal(t, http.StatusOK, rec.Code)
+	page := &models.PaginatedAuditRecords{}
+	assert.NoError(t, page.UnmarshalBinary(rec.Body.Bytes()))
+	return page, nil
+}
+
+func getIDs(records []*models.AuditRecord) []string {
+	ids := []string{}
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
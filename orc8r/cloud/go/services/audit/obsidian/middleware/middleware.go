@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package middleware contains the obsidian middleware which records every
+// mutating northbound request with the audit service
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/protos"
+
+	"github.com/golang/glog"
+	"github.com/labstack/echo"
+)
+
+// maxBodySize is the size of the largest request body the middleware reads
+// into memory to digest it
+const maxBodySize = 8 << 20
+
+var errBodyTooLarge = errors.New("request body is too large")
+
+// Middleware records every POST, PUT, PATCH & DELETE request with the audit
+// service once the request is handled.
+// It must be used ahead of the access Middleware, so that requests rejected by
+// the access checks are recorded as well, along with the operator the access
+// Middleware authenticated (if any).
+// The response is held back until the request is recorded. Requests which
+// can't be recorded fail with a status service unavailable error instead.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c == nil || c.Request() == nil || !isMutation(c.Request().Method) {
+			return next(c)
+		}
+		req := c.Request()
+		digest, err := digestBody(req)
+		if err == errBodyTooLarge {
+			return obsidian.HttpError(err, http.StatusRequestEntityTooLarge)
+		}
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+
+		timeMs := uint64(clock.Now().UnixNano()) / uint64(time.Millisecond)
+		res := c.Response()
+		writer := res.Writer
+		buffer := newBufferedResponseWriter(writer)
+		res.Writer = buffer
+		err = next(c)
+		res.Writer = writer
+
+		record := &protos.AuditRecord{
+			TimeMs:     timeMs,
+			SourceIp:   getSourceIP(req),
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			NetworkId:  c.Param("network_id"),
+			BodyDigest: digest,
+			ResultCode: int32(getResultCode(c, err)),
+		}
+		if oper := access.AuthenticatedOperator(c); oper != nil {
+			record.Operator = oper.GetOperator()
+		}
+		if recordErr := audit.Record(record); recordErr != nil {
+			glog.Errorf("Failed to record audit record of %s %s: %s", record.Method, record.Path, recordErr)
+			// Discard the held back response
+			res.Committed = false
+			res.Status = http.StatusOK
+			res.Size = 0
+			return obsidian.HttpError(errors.New("failed to record request in the audit log"), http.StatusServiceUnavailable)
+		}
+		if res.Committed {
+			if flushErr := buffer.flush(); flushErr != nil {
+				return flushErr
+			}
+		}
+		return err
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// getSourceIP returns the IP of the client which made the request. The
+// forwarding headers are only trusted if the request comes from one of the
+// trusted proxies, otherwise any client could spoof its IP.
+func getSourceIP(req *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remoteIP = req.RemoteAddr
+	}
+	if !isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+	// Each proxy appends the address it got the request from, so the client
+	// is the rightmost address which isn't a trusted proxy
+	if forwardedFor := req.Header.Get(echo.HeaderXForwardedFor); forwardedFor != "" {
+		ips := strings.Split(forwardedFor, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if !isTrustedProxy(ip) {
+				return ip
+			}
+		}
+		return strings.TrimSpace(ips[0])
+	}
+	if realIP := req.Header.Get(echo.HeaderXRealIP); realIP != "" {
+		return realIP
+	}
+	return remoteIP
+}
+
+func isTrustedProxy(ipStr string) bool {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return false
+	}
+	for _, proxies := range obsidian.TrustedProxies {
+		if proxies.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// digestBody returns the hex encoded SHA-256 digest of the request body, and
+// replaces the consumed body with a copy for the following handlers. Bodies
+// larger than maxBodySize are rejected.
+func digestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxBodySize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxBodySize {
+		return "", errBodyTooLarge
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return "", nil
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getResultCode returns the status code of the response, including responses
+// which will only be written by echo's error handler
+func getResultCode(c echo.Context, err error) int {
+	if c.Response().Committed || err == nil {
+		return c.Response().Status
+	}
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// bufferedResponseWriter holds back a response until it's flushed
+type bufferedResponseWriter struct {
+	writer http.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter(writer http.ResponseWriter) *bufferedResponseWriter {
+	header := http.Header{}
+	for key, values := range writer.Header() {
+		header[key] = append([]string(nil), values...)
+	}
+	return &bufferedResponseWriter{writer: writer, header: header, status: http.StatusOK}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// flush writes the held back response to the underlying writer
+func (w *bufferedResponseWriter) flush() error {
+	header := w.writer.Header()
+	for key := range header {
+		delete(header, key)
+	}
+	for key, values := range w.header {
+		header[key] = values
+	}
+	w.writer.WriteHeader(w.status)
+	if w.body.Len() == 0 {
+		return nil
+	}
+	_, err := w.writer.Write(w.body.Bytes())
+	return err
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package middleware_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/obsidian/middleware"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/test_init"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	test_init.StartTestService(t)
+	e := echo.New()
+
+	var handledBody string
+	handler := middleware.Middleware(func(c echo.Context) error {
+		body, err := ioutil.ReadAll(c.Request().Body)
+		assert.NoError(t, err)
+		handledBody = string(body)
+		if c.Request().Method == http.MethodDelete {
+			return echo.NewHTTPError(http.StatusForbidden, "Access Denied")
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Reads aren't recorded
+	req := httptest.NewRequest(echo.GET, "/magma/v1/networks/n1", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	assert.NoError(t, handler(c))
+	records, err := audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// The handler still gets the request body. Forwarding headers are only
+	// trusted from trusted proxies
+	_, proxies, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+	obsidian.TrustedProxies = []*net.IPNet{proxies}
+	defer func() { obsidian.TrustedProxies = nil }()
+	req = httptest.NewRequest(echo.PUT, "/magma/v1/networks/n1/description", strings.NewReader(`"foo"`))
+	req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.9, 10.0.0.1, 192.0.2.2")
+	recorder := httptest.NewRecorder()
+	c = e.NewContext(req, recorder)
+	c.SetParamNames("network_id")
+	c.SetParamValues("n1")
+	c.Set(access.AUTHENTICATED_OPERATOR_KEY, identity.NewOperator("admin"))
+	assert.NoError(t, handler(c))
+	assert.Equal(t, `"foo"`, handledBody)
+	// The response is written once the request is recorded
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	records, err = audit.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	// Rejected requests are recorded with the error's status code. Without
+	// trusted proxies, the default, the forwarding headers are ignored
+	obsidian.TrustedProxies = nil
+	req = httptest.NewRequest(echo.DELETE, "/magma/v1/channels/stable", nil)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.Error(t, handler(c))
+
+	// Oversized bodies are rejected
+	req = httptest.NewRequest(echo.POST, "/magma/v1/networks", bytes.NewReader(make([]byte, 8<<20+1)))
+	c = e.NewContext(req, httptest.NewRecorder())
+	err = handler(c)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, err.(*echo.HTTPError).Code)
+
+	// Requests which can't be recorded fail without the handler's response
+	req = httptest.NewRequest(echo.PUT, "/magma/v1/networks/n1/description", strings.NewReader(`"bar"`))
+	req.URL.Path = ""
+	recorder = httptest.NewRecorder()
+	c = e.NewContext(req, recorder)
+	err = handler(c)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.(*echo.HTTPError).Code)
+	assert.Equal(t, `"bar"`, handledBody)
+	assert.False(t, c.Response().Committed)
+	assert.Zero(t, recorder.Body.Len())
+
+	records, err = audit.Query(&protos.QueryRequest{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.NotEmpty(t, record.Id)
+		record.Id = ""
+		assert.NotZero(t, record.TimeMs)
+		record.TimeMs = 0
+	}
+	assert.Contains(t, records, &protos.AuditRecord{
+		Operator:   "admin",
+		SourceIp:   "10.0.0.1",
+		Method:     "PUT",
+		Path:       "/magma/v1/networks/n1/description",
+		NetworkId:  "n1",
+		BodyDigest: "b2213295d564916f89a6a42455567c87c3f480fcd7a1c15e220f17d7169a790b",
+		ResultCode: http.StatusNoContent,
+	})
+	assert.Contains(t, records, &protos.AuditRecord{
+		SourceIp:   "192.0.2.1",
+		Method:     "DELETE",
+		Path:       "/magma/v1/channels/stable",
+		ResultCode: http.StatusForbidden,
+	})
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package models
+
+import (
+	"magma/orc8r/cloud/go/services/audit/protos"
+)
+
+// AuditRecordsFromProtos converts audit records returned by the audit service
+// to their REST model
+func AuditRecordsFromProtos(records []*protos.AuditRecord) []*AuditRecord {
+	ret := make([]*AuditRecord, 0, len(records))
+	for _, record := range records {
+		ret = append(ret, &AuditRecord{
+			ID:         record.Id,
+			Time:       record.TimeMs,
+			Operator:   record.Operator,
+			SourceIP:   record.SourceIp,
+			Method:     record.Method,
+			Path:       record.Path,
+			NetworkID:  record.NetworkId,
+			BodyDigest: record.BodyDigest,
+			ResultCode: record.ResultCode,
+		})
+	}
+	return ret
+}
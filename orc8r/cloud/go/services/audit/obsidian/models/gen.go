@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+//go:generate bash -c "swaggergen --target=swagger.v1.yml --root=$MAGMA_ROOT --template=$SWAGGER_V1_TEMPLATE"
+package models
@@ -0,0 +1,487 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: audit.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protos "magma/orc8r/cloud/go/protos"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// AuditRecord describes a single mutating northbound (REST) request
+type AuditRecord struct {
+	// Unique ID of the record, assigned by the audit service
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Time the request was handled, in unix milliseconds
+	TimeMs uint64 `protobuf:"varint,2,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"`
+	// ID of the operator which made the request, empty if the request's
+	// credentials couldn't be authenticated
+	Operator string `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	SourceIp string `protobuf:"bytes,4,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	Method   string `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	Path     string `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	// Network the request applies to, empty for requests which are not
+	// network scoped
+	NetworkId string `protobuf:"bytes,7,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// Hex encoded SHA-256 digest of the request body, empty if the request
+	// has no body
+	BodyDigest string `protobuf:"bytes,8,opt,name=body_digest,json=bodyDigest,proto3" json:"body_digest,omitempty"`
+	// HTTP status code of the response
+	ResultCode           int32    `protobuf:"varint,9,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditRecord) Reset()         { *m = AuditRecord{} }
+func (m *AuditRecord) String() string { return proto.CompactTextString(m) }
+func (*AuditRecord) ProtoMessage()    {}
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{0}
+}
+
+func (m *AuditRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecord.Unmarshal(m, b)
+}
+func (m *AuditRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecord.Marshal(b, m, deterministic)
+}
+func (m *AuditRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecord.Merge(m, src)
+}
+func (m *AuditRecord) XXX_Size() int {
+	return xxx_messageInfo_AuditRecord.Size(m)
+}
+func (m *AuditRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecord proto.InternalMessageInfo
+
+func (m *AuditRecord) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetTimeMs() uint64 {
+	if m != nil {
+		return m.TimeMs
+	}
+	return 0
+}
+
+func (m *AuditRecord) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetBodyDigest() string {
+	if m != nil {
+		return m.BodyDigest
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetResultCode() int32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
+}
+
+type AuditRecords struct {
+	Records []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// NextPageToken is set for limited queries when there are more records
+	// matching the query. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditRecords) Reset()         { *m = AuditRecords{} }
+func (m *AuditRecords) String() string { return proto.CompactTextString(m) }
+func (*AuditRecords) ProtoMessage()    {}
+func (*AuditRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{1}
+}
+
+func (m *AuditRecords) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecords.Unmarshal(m, b)
+}
+func (m *AuditRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecords.Marshal(b, m, deterministic)
+}
+func (m *AuditRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecords.Merge(m, src)
+}
+func (m *AuditRecords) XXX_Size() int {
+	return xxx_messageInfo_AuditRecords.Size(m)
+}
+func (m *AuditRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecords proto.InternalMessageInfo
+
+func (m *AuditRecords) GetRecords() []*AuditRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *AuditRecords) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// QueryRequest filters audit records. Zero valued fields don't filter
+type QueryRequest struct {
+	// Time range of the records, in unix milliseconds. An end_ms of 0 leaves
+	// the range unbounded above
+	StartMs   uint64 `protobuf:"varint,1,opt,name=start_ms,json=startMs,proto3" json:"start_ms,omitempty"`
+	EndMs     uint64 `protobuf:"varint,2,opt,name=end_ms,json=endMs,proto3" json:"end_ms,omitempty"`
+	Operator  string `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	NetworkId string `protobuf:"bytes,4,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// Maximum number of records to return. If more records match the query,
+	// AuditRecords.NextPageToken is set
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// PageToken is the NextPageToken of a previous query with the same
+	// filter. If provided, the query continues after the last record returned
+	// by that query.
+	PageToken            string   `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
+func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRequest) ProtoMessage()    {}
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{2}
+}
+
+func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryRequest.Unmarshal(m, b)
+}
+func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRequest.Merge(m, src)
+}
+func (m *QueryRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryRequest.Size(m)
+}
+func (m *QueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
+
+func (m *QueryRequest) GetStartMs() uint64 {
+	if m != nil {
+		return m.StartMs
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetEndMs() uint64 {
+	if m != nil {
+		return m.EndMs
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// AuditPageToken is the decoded form of a pagination token. Tokens are
+// opaque to clients.
+type AuditPageToken struct {
+	LastIncludedTimeMs   uint64   `protobuf:"varint,1,opt,name=last_included_time_ms,json=lastIncludedTimeMs,proto3" json:"last_included_time_ms,omitempty"`
+	LastIncludedId       string   `protobuf:"bytes,2,opt,name=last_included_id,json=lastIncludedId,proto3" json:"last_included_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditPageToken) Reset()         { *m = AuditPageToken{} }
+func (m *AuditPageToken) String() string { return proto.CompactTextString(m) }
+func (*AuditPageToken) ProtoMessage()    {}
+func (*AuditPageToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{3}
+}
+
+func (m *AuditPageToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditPageToken.Unmarshal(m, b)
+}
+func (m *AuditPageToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditPageToken.Marshal(b, m, deterministic)
+}
+func (m *AuditPageToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditPageToken.Merge(m, src)
+}
+func (m *AuditPageToken) XXX_Size() int {
+	return xxx_messageInfo_AuditPageToken.Size(m)
+}
+func (m *AuditPageToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditPageToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditPageToken proto.InternalMessageInfo
+
+func (m *AuditPageToken) GetLastIncludedTimeMs() uint64 {
+	if m != nil {
+		return m.LastIncludedTimeMs
+	}
+	return 0
+}
+
+func (m *AuditPageToken) GetLastIncludedId() string {
+	if m != nil {
+		return m.LastIncludedId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*AuditRecord)(nil), "magma.orc8r.audit.AuditRecord")
+	proto.RegisterType((*AuditRecords)(nil), "magma.orc8r.audit.AuditRecords")
+	proto.RegisterType((*QueryRequest)(nil), "magma.orc8r.audit.QueryRequest")
+	proto.RegisterType((*AuditPageToken)(nil), "magma.orc8r.audit.AuditPageToken")
+}
+
+func init() { proto.RegisterFile("audit.proto", fileDescriptor_5594839dd8e38a1b) }
+
+var fileDescriptor_5594839dd8e38a1b = []byte{
+	// 474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
+	0x14, 0x5f, 0xba, 0x26, 0x4d, 0x5e, 0xb7, 0xc2, 0x2c, 0x06, 0x5e, 0x11, 0xb4, 0xca, 0x01, 0xe5,
+	0x94, 0x89, 0x71, 0xd9, 0x85, 0x03, 0x7f, 0x2e, 0x15, 0x4c, 0x82, 0x68, 0xe2, 0xc0, 0x25, 0xca,
+	0xe2, 0xa7, 0xcc, 0x5a, 0x13, 0x07, 0xdb, 0x11, 0xec, 0x8b, 0xf0, 0x39, 0xf8, 0x80, 0x1c, 0x90,
+	0xed, 0xac, 0xa4, 0x80, 0xe8, 0x29, 0xfe, 0xfd, 0x79, 0x89, 0xdf, 0xef, 0xbd, 0xc0, 0xb4, 0xe8,
+	0x18, 0xd7, 0x69, 0x2b, 0x85, 0x16, 0xe4, 0xa8, 0x2e, 0xaa, 0xba, 0x48, 0x85, 0x2c, 0xcf, 0x65,
+	0x6a, 0x85, 0xf9, 0x89, 0x05, 0xa7, 0x56, 0x57, 0xa7, 0xa5, 0xa8, 0x6b, 0xd1, 0x38, 0x77, 0xfc,
+	0xd3, 0x83, 0xe9, 0x2b, 0x63, 0xca, 0xb0, 0x14, 0x92, 0x91, 0x19, 0x8c, 0x38, 0xa3, 0xde, 0xd2,
+	0x4b, 0xa2, 0x6c, 0xc4, 0x19, 0x79, 0x04, 0x13, 0xcd, 0x6b, 0xcc, 0x6b, 0x45, 0x47, 0x4b, 0x2f,
+	0x19, 0x67, 0x81, 0x81, 0x17, 0x8a, 0xcc, 0x21, 0x14, 0x2d, 0xca, 0x42, 0x0b, 0x49, 0xf7, 0xad,
+	0x7d, 0x83, 0xc9, 0x63, 0x88, 0x94, 0xe8, 0x64, 0x89, 0x39, 0x6f, 0xe9, 0xd8, 0x89, 0x8e, 0x58,
+	0xb5, 0xe4, 0x21, 0x04, 0x35, 0xea, 0x6b, 0xc1, 0xa8, 0x6f, 0x95, 0x1e, 0x11, 0x02, 0xe3, 0xb6,
+	0xd0, 0xd7, 0x34, 0xb0, 0xac, 0x3d, 0x93, 0x27, 0x00, 0x0d, 0xea, 0xaf, 0x42, 0xde, 0xe4, 0x9c,
+	0xd1, 0x89, 0x55, 0xa2, 0x9e, 0x59, 0x31, 0xb2, 0x80, 0xe9, 0x95, 0x60, 0xb7, 0x39, 0xe3, 0x15,
+	0x2a, 0x4d, 0x43, 0xab, 0x83, 0xa1, 0xde, 0x5a, 0xc6, 0x18, 0x24, 0xaa, 0x6e, 0xad, 0xf3, 0x52,
+	0x30, 0xa4, 0xd1, 0xd2, 0x4b, 0xfc, 0x0c, 0x1c, 0xf5, 0x46, 0x30, 0x8c, 0x5b, 0x38, 0x18, 0x74,
+	0xaf, 0xc8, 0x39, 0x4c, 0xa4, 0x3b, 0x52, 0x6f, 0xb9, 0x9f, 0x4c, 0xcf, 0x9e, 0xa6, 0x7f, 0xc5,
+	0x99, 0x0e, 0x2a, 0xb2, 0x3b, 0x3b, 0x79, 0x06, 0xf7, 0x1a, 0xfc, 0xa6, 0xf3, 0xb6, 0xa8, 0x30,
+	0xd7, 0xe2, 0x06, 0x1b, 0x1b, 0x58, 0x94, 0x1d, 0x1a, 0xfa, 0x43, 0x51, 0xe1, 0xa5, 0x21, 0xe3,
+	0x1f, 0x1e, 0x1c, 0x7c, 0xec, 0x50, 0xde, 0x66, 0xf8, 0xa5, 0x33, 0x77, 0x3c, 0x81, 0x50, 0xe9,
+	0x42, 0x6a, 0x13, 0xb1, 0x67, 0x23, 0x9e, 0x58, 0x7c, 0xa1, 0xc8, 0x31, 0x04, 0xd8, 0xb0, 0xdf,
+	0xd9, 0xfb, 0xd8, 0xb0, 0x1d, 0xd1, 0x6f, 0x27, 0x36, 0xfe, 0x33, 0xb1, 0x07, 0xe0, 0xaf, 0x79,
+	0xcd, 0xb5, 0xcd, 0xfe, 0x30, 0x73, 0xc0, 0x14, 0x0d, 0xae, 0xed, 0x06, 0x10, 0xb5, 0x9b, 0x2b,
+	0xd7, 0x30, 0xb3, 0x2d, 0x6f, 0x9a, 0x20, 0xcf, 0xe1, 0x78, 0x5d, 0x28, 0x9d, 0xf3, 0xa6, 0x5c,
+	0x77, 0x0c, 0x59, 0x7e, 0xb7, 0x23, 0xae, 0x01, 0x62, 0xc4, 0x55, 0xaf, 0x5d, 0xba, 0x7d, 0x49,
+	0xe0, 0xfe, 0x76, 0x09, 0x67, 0x7d, 0x40, 0xb3, 0xa1, 0x7b, 0xc5, 0xce, 0xbe, 0x7b, 0x10, 0xda,
+	0xef, 0xbd, 0x17, 0x15, 0x79, 0x09, 0x41, 0xbf, 0x99, 0x3b, 0x26, 0x31, 0x3f, 0xda, 0xd2, 0x3f,
+	0x09, 0xce, 0xe2, 0x3d, 0xf2, 0x0e, 0x7c, 0x1b, 0x36, 0x59, 0xfc, 0xa3, 0x7a, 0x38, 0x86, 0xf9,
+	0xe2, 0xff, 0xaf, 0x57, 0xf1, 0xde, 0xeb, 0xf0, 0x73, 0xe0, 0x7e, 0xa1, 0x2b, 0xf7, 0x7c, 0xf1,
+	0x6b, 0x00, 0x51, 0xa4, 0xd7, 0x7b, 0x79, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuditLogClient is the client API for AuditLog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuditLogClient interface {
+	// Record stores a new audit record
+	Record(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*protos.Void, error)
+	// Query returns the audit records matching the request, newest first
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*AuditRecords, error)
+}
+
+type auditLogClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditLogClient(cc *grpc.ClientConn) AuditLogClient {
+	return &auditLogClient{cc}
+}
+
+func (c *auditLogClient) Record(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.audit.AuditLog/Record", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditLogClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*AuditRecords, error) {
+	out := new(AuditRecords)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.audit.AuditLog/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogServer is the server API for AuditLog service.
+type AuditLogServer interface {
+	// Record stores a new audit record
+	Record(context.Context, *AuditRecord) (*protos.Void, error)
+	// Query returns the audit records matching the request, newest first
+	Query(context.Context, *QueryRequest) (*AuditRecords, error)
+}
+
+// UnimplementedAuditLogServer can be embedded to have forward compatible implementations.
+type UnimplementedAuditLogServer struct {
+}
+
+func (*UnimplementedAuditLogServer) Record(ctx context.Context, req *AuditRecord) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Record not implemented")
+}
+func (*UnimplementedAuditLogServer) Query(ctx context.Context, req *QueryRequest) (*AuditRecords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+
+func RegisterAuditLogServer(s *grpc.Server, srv AuditLogServer) {
+	s.RegisterService(&_AuditLog_serviceDesc, srv)
+}
+
+func _AuditLog_Record_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRecord)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogServer).Record(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.audit.AuditLog/Record",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogServer).Record(ctx, req.(*AuditRecord))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditLog_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.audit.AuditLog/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AuditLog_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.orc8r.audit.AuditLog",
+	HandlerType: (*AuditLogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Record",
+			Handler:    _AuditLog_Record_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _AuditLog_Query_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "audit.proto",
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"time"
+
+	"magma/orc8r/cloud/go/services/audit/storage"
+
+	"github.com/golang/glog"
+)
+
+// RetentionEnforcer deletes audit records which are older than the retention
+// period.
+type RetentionEnforcer struct {
+	store     storage.Store
+	retention time.Duration
+}
+
+func NewRetentionEnforcer(store storage.Store, retention time.Duration) *RetentionEnforcer {
+	return &RetentionEnforcer{store: store, retention: retention}
+}
+
+// Run prunes expired records every interval. Run never returns.
+func (r *RetentionEnforcer) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := r.PruneExpiredRecords(); err != nil {
+			glog.Errorf("Failed to prune expired audit records: %s", err)
+		}
+	}
+}
+
+// PruneExpiredRecords deletes all records older than the retention period.
+// A non-positive retention period keeps records forever.
+func (r *RetentionEnforcer) PruneExpiredRecords() error {
+	if r.retention <= 0 {
+		return nil
+	}
+	retentionMs := uint64(r.retention / time.Millisecond)
+	now := nowMs()
+	if now <= retentionMs {
+		return nil
+	}
+	pruned, err := r.store.Prune(now - retentionMs)
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		glog.V(2).Infof("Pruned %d expired audit records", pruned)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/storage"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type auditServicer struct {
+	store storage.Store
+}
+
+func NewAuditServicer(store storage.Store) (protos.AuditLogServer, error) {
+	if store == nil {
+		return nil, fmt.Errorf("Storage is nil")
+	}
+	return &auditServicer{store: store}, nil
+}
+
+// Record stores the record under a newly assigned ID. Records without a time
+// are stamped with the current time.
+func (srv *auditServicer) Record(ctx context.Context, record *protos.AuditRecord) (*commonProtos.Void, error) {
+	void := &commonProtos.Void{}
+	if record == nil {
+		return void, status.Error(codes.InvalidArgument, "audit record is nil")
+	}
+	if record.Method == "" || record.Path == "" {
+		return void, status.Error(codes.InvalidArgument, "audit record method and path must be set")
+	}
+	record.Id = uuid.New().String()
+	if record.TimeMs == 0 {
+		record.TimeMs = nowMs()
+	}
+	if err := srv.store.Write(record); err != nil {
+		return void, status.Error(codes.Internal, err.Error())
+	}
+	return void, nil
+}
+
+func (srv *auditServicer) Query(ctx context.Context, req *protos.QueryRequest) (*protos.AuditRecords, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "query request is nil")
+	}
+	if req.EndMs != 0 && req.EndMs < req.StartMs {
+		return nil, status.Error(codes.InvalidArgument, "query end must not be before start")
+	}
+	records, nextPageToken, err := srv.store.Query(req)
+	if err == storage.ErrInvalidPageToken {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &protos.AuditRecords{Records: records, NextPageToken: nextPageToken}, nil
+}
+
+func nowMs() uint64 {
+	return uint64(clock.Now().UnixNano()) / uint64(time.Millisecond)
+}
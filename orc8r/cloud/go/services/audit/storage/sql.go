@@ -0,0 +1,196 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"encoding/base64"
+
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TableName is the name of the SQL table storing audit records
+	TableName = "audit_records"
+
+	idCol         = "id"
+	timeCol       = "time_ms"
+	operatorCol   = "operator"
+	sourceIPCol   = "source_ip"
+	methodCol     = "method"
+	pathCol       = "path"
+	nidCol        = "network_id"
+	bodyDigestCol = "body_digest"
+	resultCol     = "result_code"
+)
+
+type sqlStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// NewSQLStore returns an audit record Store backed by the given SQL database.
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder) Store {
+	return &sqlStore{db: db, builder: builder}
+}
+
+func (store *sqlStore) Initialize() error {
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		_, err := store.builder.CreateTable(TableName).
+			IfNotExists().
+			Column(idCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+			Column(timeCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(operatorCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(sourceIPCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(methodCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(pathCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(bodyDigestCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(resultCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create audit records table")
+		}
+		// Queries and retention are both by time
+		_, err = store.builder.CreateIndex("audit_time_idx").
+			IfNotExists().
+			On(TableName).
+			Columns(timeCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create audit records time index")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlStore) Write(record *protos.AuditRecord) error {
+	_, err := store.builder.Insert(TableName).
+		Columns(idCol, timeCol, operatorCol, sourceIPCol, methodCol, pathCol, nidCol, bodyDigestCol, resultCol).
+		Values(
+			record.Id,
+			record.TimeMs,
+			record.Operator,
+			record.SourceIp,
+			record.Method,
+			record.Path,
+			record.NetworkId,
+			record.BodyDigest,
+			record.ResultCode,
+		).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to write audit record %s", record.Id)
+	}
+	return nil
+}
+
+func (store *sqlStore) Query(filter *protos.QueryRequest) ([]*protos.AuditRecord, string, error) {
+	where := sq.And{sq.GtOrEq{timeCol: filter.StartMs}}
+	if filter.EndMs != 0 {
+		where = append(where, sq.LtOrEq{timeCol: filter.EndMs})
+	}
+	if filter.Operator != "" {
+		where = append(where, sq.Eq{operatorCol: filter.Operator})
+	}
+	if filter.NetworkId != "" {
+		where = append(where, sq.Eq{nidCol: filter.NetworkId})
+	}
+	if filter.PageToken != "" {
+		token, err := decodePageToken(filter.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		// Continue after the last record of the previous page in
+		// (time DESC, id) order
+		where = append(where, sq.Or{
+			sq.Lt{timeCol: token.LastIncludedTimeMs},
+			sq.And{sq.Eq{timeCol: token.LastIncludedTimeMs}, sq.Gt{idCol: token.LastIncludedId}},
+		})
+	}
+	query := store.builder.Select(idCol, timeCol, operatorCol, sourceIPCol, methodCol, pathCol, nidCol, bodyDigestCol, resultCol).
+		From(TableName).
+		Where(where).
+		OrderBy(timeCol+" DESC", idCol)
+	if filter.Limit > 0 {
+		// Select one extra row to find out if there is another page
+		query = query.Limit(uint64(filter.Limit) + 1)
+	}
+	rows, err := query.RunWith(store.db).Query()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to query audit records")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "Query")
+
+	ret := []*protos.AuditRecord{}
+	for rows.Next() {
+		record := &protos.AuditRecord{}
+		err = rows.Scan(
+			&record.Id,
+			&record.TimeMs,
+			&record.Operator,
+			&record.SourceIp,
+			&record.Method,
+			&record.Path,
+			&record.NetworkId,
+			&record.BodyDigest,
+			&record.ResultCode,
+		)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to scan audit record row")
+		}
+		if filter.Limit > 0 && uint32(len(ret)) == filter.Limit {
+			return ret, encodePageToken(ret[len(ret)-1]), nil
+		}
+		ret = append(ret, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", errors.Wrap(err, "failed to iterate over audit record rows")
+	}
+	return ret, "", nil
+}
+
+func (store *sqlStore) Prune(cutoffMs uint64) (int64, error) {
+	res, err := store.builder.Delete(TableName).
+		Where(sq.Lt{timeCol: cutoffMs}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to prune audit records")
+	}
+	return res.RowsAffected()
+}
+
+func encodePageToken(lastIncluded *protos.AuditRecord) string {
+	token := &protos.AuditPageToken{LastIncludedTimeMs: lastIncluded.TimeMs, LastIncludedId: lastIncluded.Id}
+	// Marshaling a message of an integer and a string can't fail
+	marshaled, _ := proto.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(marshaled)
+}
+
+func decodePageToken(encoded string) (*protos.AuditPageToken, error) {
+	marshaled, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	token := &protos.AuditPageToken{}
+	if err := proto.Unmarshal(marshaled, token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return token, nil
+}
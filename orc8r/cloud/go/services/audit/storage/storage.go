@@ -0,0 +1,37 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package storage contains the storage for audit records.
+package storage
+
+import (
+	"errors"
+
+	"magma/orc8r/cloud/go/services/audit/protos"
+)
+
+// ErrInvalidPageToken is returned by Query if the page token can't be decoded
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Store persists audit records.
+type Store interface {
+	// Initialize creates any tables or resources needed by the store.
+	Initialize() error
+
+	// Write adds a record. Records are unique by ID.
+	Write(record *protos.AuditRecord) error
+
+	// Query returns the records matching the filter, newest first. If the
+	// filter has a limit and more records match it, the token of the next
+	// page is returned as well.
+	Query(filter *protos.QueryRequest) ([]*protos.AuditRecord, string, error)
+
+	// Prune removes all records older than cutoffMs, in unix milliseconds,
+	// and returns the number of removed records.
+	Prune(cutoffMs uint64) (int64, error)
+}
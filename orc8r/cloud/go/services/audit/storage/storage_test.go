@@ -0,0 +1,104 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLStore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+	// Initialize is idempotent
+	assert.NoError(t, store.Initialize())
+
+	records, _, err := store.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	r1 := makeRecord("r1", 1000, "admin", "n1")
+	r2 := makeRecord("r2", 2000, "operator1", "n1")
+	r3 := makeRecord("r3", 3000, "admin", "")
+	r4 := makeRecord("r4", 4000, "admin", "n2")
+	for _, r := range []*protos.AuditRecord{r2, r1, r4, r3} {
+		assert.NoError(t, store.Write(r))
+	}
+	// IDs are unique
+	assert.Error(t, store.Write(r1))
+
+	records, _, err = store.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r4, r3, r2, r1}, records)
+
+	// Time range and limit
+	records, _, err = store.Query(&protos.QueryRequest{StartMs: 1500, EndMs: 3000})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r3, r2}, records)
+	records, nextPageToken, err := store.Query(&protos.QueryRequest{StartMs: 1500, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r4, r3}, records)
+	assert.NotEmpty(t, nextPageToken)
+
+	// Pages continue after the last record of the previous page, records
+	// with the same time are ordered by ID
+	r5 := makeRecord("r5", 2000, "admin", "n1")
+	assert.NoError(t, store.Write(r5))
+	records, nextPageToken, err = store.Query(&protos.QueryRequest{StartMs: 1500, Limit: 2, PageToken: nextPageToken})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r2, r5}, records)
+	assert.Empty(t, nextPageToken)
+	records, nextPageToken, err = store.Query(&protos.QueryRequest{Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r4, r3, r2}, records)
+	records, nextPageToken, err = store.Query(&protos.QueryRequest{Limit: 3, PageToken: nextPageToken})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r5, r1}, records)
+	assert.Empty(t, nextPageToken)
+	_, _, err = store.Query(&protos.QueryRequest{PageToken: "!!!"})
+	assert.Equal(t, storage.ErrInvalidPageToken, err)
+
+	// Operator and network filters
+	records, _, err = store.Query(&protos.QueryRequest{Operator: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r4, r3, r5, r1}, records)
+	records, _, err = store.Query(&protos.QueryRequest{Operator: "admin", NetworkId: "n1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r5, r1}, records)
+
+	// Retention
+	pruned, err := store.Prune(3000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pruned)
+	records, _, err = store.Query(&protos.QueryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AuditRecord{r4, r3}, records)
+}
+
+func makeRecord(id string, timeMs uint64, operator string, networkID string) *protos.AuditRecord {
+	return &protos.AuditRecord{
+		Id:         id,
+		TimeMs:     timeMs,
+		Operator:   operator,
+		SourceIp:   "10.0.0.1",
+		Method:     "PUT",
+		Path:       "/magma/v1/networks/" + networkID,
+		NetworkId:  networkID,
+		BodyDigest: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		ResultCode: 200,
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ */
+
+package test_init
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/services/audit"
+	"magma/orc8r/cloud/go/services/audit/protos"
+	"magma/orc8r/cloud/go/services/audit/servicers"
+	"magma/orc8r/cloud/go/services/audit/storage"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/test_utils"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// StartTestService instantiates a service backed by an in-memory SQLite
+// storage and returns the storage
+func StartTestService(t *testing.T) storage.Store {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+
+	srv, lis := test_utils.NewTestService(t, orc8r.ModuleName, audit.ServiceName)
+	server, err := servicers.NewAuditServicer(store)
+	assert.NoError(t, err)
+	protos.RegisterAuditLogServer(srv.GrpcServer, server)
+	go srv.RunTest(lis)
+	return store
+}
@@ -10,7 +10,9 @@ package main
 
 import (
 	"crypto/rsa"
+	"crypto/x509"
 	"flag"
+	"io/ioutil"
 	"log"
 
 	"magma/orc8r/cloud/go/orc8r"
@@ -22,7 +24,8 @@ import (
 )
 
 var (
-	keyFile = flag.String("cak", "bootstrapper.key.pem", "Bootstrapper's Private Key file")
+	keyFile      = flag.String("cak", "bootstrapper.key.pem", "Bootstrapper's Private Key file")
+	tpmRootsFile = flag.String("tpm-roots", "", "PEM file of trusted TPM manufacturer root certificates")
 )
 
 func main() {
@@ -41,6 +44,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create bootstrapper servicer: %s", err)
 	}
+	if len(*tpmRootsFile) > 0 {
+		rootsPEM, err := ioutil.ReadFile(*tpmRootsFile)
+		if err != nil {
+			log.Fatalf("Failed to read TPM roots: %s", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(rootsPEM) {
+			log.Fatalf("No certificates found in TPM roots file %s", *tpmRootsFile)
+		}
+		servicer.SetTPMRoots(roots)
+	}
 	protos.RegisterBootstrapperServer(srv.GrpcServer, servicer)
 	srv.GrpcServer.RegisterService(protos.GetLegacyBootstrapperDesc(), servicer)
 
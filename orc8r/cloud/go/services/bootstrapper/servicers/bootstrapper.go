@@ -12,6 +12,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -43,6 +44,11 @@ const GatewayCertificateDuration = time.Hour * 97 // 4 days, lifetime of GW Cert
 
 type BootstrapperServer struct {
 	privKey *rsa.PrivateKey
+	// tpmRoots are the trusted TPM manufacturer roots for the endorsement
+	// key certificates of HARDWARE_TPM2_SHA256 gateways
+	tpmRoots *x509.CertPool
+	// credentialKey derives the secrets of the TPM credentials of challenges
+	credentialKey []byte
 }
 
 func NewBootstrapperServer(privKey *rsa.PrivateKey) (*BootstrapperServer, error) {
@@ -51,24 +57,35 @@ func NewBootstrapperServer(privKey *rsa.PrivateKey) (*BootstrapperServer, error)
 		return nil, errorLogger(fmt.Errorf("Private key is too short"))
 	}
 	srv.privKey = privKey
+	mac := hmac.New(sha256.New, x509.MarshalPKCS1PrivateKey(privKey))
+	mac.Write([]byte("tpm credential"))
+	srv.credentialKey = mac.Sum(nil)
 	return srv, nil
 }
 
+// SetTPMRoots sets the trusted TPM manufacturer roots. Gateways with
+// HARDWARE_TPM2_SHA256 keys can't bootstrap until roots are set.
+func (srv *BootstrapperServer) SetTPMRoots(roots *x509.CertPool) {
+	srv.tpmRoots = roots
+}
+
 // generate challenge in the format of [randomText : timestamp : signature]
 // the format is designed mainly for demo/interface design, subjects to change in the future
 func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.AccessGatewayID) (*protos.Challenge, error) {
 	var keyType protos.ChallengeKey_KeyType
 
 	// case based on the env variable whether to use magmad or configurator
+	var key []byte
 	var err error
-	keyType, _, err = getChallengeKey(hwId.Id)
+	keyType, key, err = getChallengeKey(hwId.Id)
 	if err != nil {
 		return nil, err
 	}
 
 	if keyType != protos.ChallengeKey_ECHO &&
 		keyType != protos.ChallengeKey_SOFTWARE_RSA_SHA256 &&
-		keyType != protos.ChallengeKey_SOFTWARE_ECDSA_SHA256 {
+		keyType != protos.ChallengeKey_SOFTWARE_ECDSA_SHA256 &&
+		keyType != protos.ChallengeKey_HARDWARE_TPM2_SHA256 {
 		return nil, errorLogger(status.Errorf(codes.Aborted, "Unsupported key type: %s", keyType))
 	}
 
@@ -91,7 +108,14 @@ func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.Ac
 	}
 	challenge = append(challenge, signature...)
 
-	return &protos.Challenge{KeyType: keyType, Challenge: challenge}, nil
+	ret := &protos.Challenge{KeyType: keyType, Challenge: challenge}
+	if keyType == protos.ChallengeKey_HARDWARE_TPM2_SHA256 {
+		ret.TpmCredential, err = srv.makeTPMCredential(challenge, key)
+		if err != nil {
+			return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to make TPM credential: %s", err))
+		}
+	}
+	return ret, nil
 }
 
 // verify the response by client and return signed certificate if response is correct
@@ -118,6 +142,8 @@ func (srv *BootstrapperServer) RequestSign(
 		err = verifySoftwareRSASHA256(resp, key)
 	case protos.ChallengeKey_SOFTWARE_ECDSA_SHA256:
 		err = verifySoftwareECDSASHA256(resp, key)
+	case protos.ChallengeKey_HARDWARE_TPM2_SHA256:
+		err = verifyHardwareTPM2SHA256(resp, key, srv.tpmRoots, srv.getCredentialSecret(resp.Challenge))
 	default:
 		err = fmt.Errorf("Unsupported key type: %s", keyType)
 	}
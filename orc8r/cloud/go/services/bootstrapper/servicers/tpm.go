@@ -0,0 +1,210 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/tpm"
+)
+
+// TPM 2.0 constants, see TPM 2.0 Library Part 2: Structures
+const (
+	tpmGeneratedValue = 0xff544347 // TPM_GENERATED_VALUE
+	tpmSTAttestQuote  = 0x8018     // TPM_ST_ATTEST_QUOTE
+)
+
+// subjectAltNameOID is the SAN extension, which EK certificates mark
+// critical when their subject is empty. It holds the TPM manufacturer,
+// model and version as a directory name, which crypto/x509 doesn't handle.
+var subjectAltNameOID = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// makeTPMCredential makes the credential sent with a challenge to a
+// HARDWARE_TPM2_SHA256 gateway. Only the TPM of the registered endorsement
+// key can activate it, and only while the registered attestation key is
+// loaded in it, so a quote carrying the credential secret proves the
+// attestation key is in the TPM the endorsement key certificate vouches for.
+func (srv *BootstrapperServer) makeTPMCredential(challenge, key []byte) (*protos.Challenge_TPMCredential, error) {
+	ak, ekCert, err := tpm.ParseChallengeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	credentialBlob, encryptedSecret, err := tpm.MakeCredential(
+		rand.Reader, ekCert.PublicKey.(*rsa.PublicKey), ak.Name, srv.getCredentialSecret(challenge))
+	if err != nil {
+		return nil, err
+	}
+	return &protos.Challenge_TPMCredential{CredentialBlob: credentialBlob, EncryptedSecret: encryptedSecret}, nil
+}
+
+// getCredentialSecret derives the credential secret of a challenge from the
+// bootstrapper's key, so it doesn't need to be kept until the response
+func (srv *BootstrapperServer) getCredentialSecret(challenge []byte) []byte {
+	mac := hmac.New(sha256.New, srv.credentialKey)
+	mac.Write(challenge)
+	return mac.Sum(nil)
+}
+
+// verify response with a TPM 2.0 quote: the quote must be signed by the
+// gateway's registered attestation key and carry the SHA-256 digest of the
+// challenge and the secret of its credential as qualifying data, and the
+// registered endorsement key certificate must chain to one of the trusted TPM
+// manufacturer roots.
+// The attestation key never leaves the TPM it was registered from, so a
+// copy of the gateway's storage can't bootstrap on other hardware.
+func verifyHardwareTPM2SHA256(resp *protos.Response, key []byte, tpmRoots *x509.CertPool, credentialSecret []byte) error {
+	if tpmRoots == nil {
+		return fmt.Errorf("No trusted TPM manufacturer roots configured")
+	}
+	response := resp.GetTpmResponse()
+	if response == nil {
+		return fmt.Errorf("Wrong type of response, expected TPM")
+	}
+	ak, ekCert, err := tpm.ParseChallengeKey(key)
+	if err != nil {
+		return fmt.Errorf("Invalid TPM challenge key: %s", err)
+	}
+	if err = verifyEKCert(ekCert, response.EkIntermediates, tpmRoots); err != nil {
+		return err
+	}
+	if err = verifyTPMSignature(ak.Public, response.Quote, response.Signature); err != nil {
+		return err
+	}
+	nonce, err := getQuoteQualifyingData(response.Quote)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256(append(append([]byte{}, resp.Challenge...), credentialSecret...))
+	if !bytes.Equal(nonce, hashed[:]) {
+		return fmt.Errorf("Quote isn't for the challenge")
+	}
+	return nil
+}
+
+// verifyEKCert verifies the endorsement key certificate with its
+// intermediates against the trusted TPM manufacturer roots
+func verifyEKCert(ekCert *x509.Certificate, intermediateDERs [][]byte, roots *x509.CertPool) error {
+	intermediates := x509.NewCertPool()
+	for _, der := range intermediateDERs {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("Failed to parse endorsement key certificate chain: %s", err)
+		}
+		intermediates.AddCert(cert)
+	}
+
+	unhandled := ekCert.UnhandledCriticalExtensions[:0]
+	for _, ext := range ekCert.UnhandledCriticalExtensions {
+		if !ext.Equal(subjectAltNameOID) {
+			unhandled = append(unhandled, ext)
+		}
+	}
+	ekCert.UnhandledCriticalExtensions = unhandled
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// EK certificates have the TCG EK certificate extended key usage
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err := ekCert.Verify(opts); err != nil {
+		return fmt.Errorf("Untrusted endorsement key certificate: %s", err)
+	}
+	return nil
+}
+
+// verifyTPMSignature verifies a TPMT_SIGNATURE over data with SHA-256
+func verifyTPMSignature(publicKey interface{}, data, signature []byte) error {
+	r := bytes.NewReader(signature)
+	var sigAlg, hashAlg uint16
+	if err := binary.Read(r, binary.BigEndian, &sigAlg); err != nil {
+		return fmt.Errorf("Failed to parse quote signature: %s", err)
+	}
+	if err := binary.Read(r, binary.BigEndian, &hashAlg); err != nil {
+		return fmt.Errorf("Failed to parse quote signature: %s", err)
+	}
+	if hashAlg != tpm.AlgSHA256 {
+		return fmt.Errorf("Unsupported quote signature hash algorithm: %#04x", hashAlg)
+	}
+	hashed := sha256.Sum256(data)
+
+	switch sigAlg {
+	case tpm.AlgRSASSA:
+		pub, ok := publicKey.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("RSA quote signature for a non RSA attestation key")
+		}
+		sig, err := tpm.ReadTPM2B(r)
+		if err != nil {
+			return fmt.Errorf("Failed to parse quote signature: %s", err)
+		}
+		if err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig); err != nil {
+			return fmt.Errorf("Wrong quote signature")
+		}
+	case tpm.AlgECDSA:
+		pub, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("ECDSA quote signature for a non ECDSA attestation key")
+		}
+		sigR, err := tpm.ReadTPM2B(r)
+		if err != nil {
+			return fmt.Errorf("Failed to parse quote signature: %s", err)
+		}
+		sigS, err := tpm.ReadTPM2B(r)
+		if err != nil {
+			return fmt.Errorf("Failed to parse quote signature: %s", err)
+		}
+		if !ecdsa.Verify(pub, hashed[:], new(big.Int).SetBytes(sigR), new(big.Int).SetBytes(sigS)) {
+			return fmt.Errorf("Wrong quote signature")
+		}
+	default:
+		return fmt.Errorf("Unsupported quote signature algorithm: %#04x", sigAlg)
+	}
+	return nil
+}
+
+// getQuoteQualifyingData parses a TPMS_ATTEST quote up to its extraData,
+// the qualifying data passed to TPM2_Quote
+func getQuoteQualifyingData(quote []byte) ([]byte, error) {
+	r := bytes.NewReader(quote)
+	var magic uint32
+	var attestType uint16
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return nil, fmt.Errorf("Failed to parse quote: %s", err)
+	}
+	if magic != tpmGeneratedValue {
+		return nil, fmt.Errorf("Quote wasn't generated by a TPM")
+	}
+	if err := binary.Read(r, binary.BigEndian, &attestType); err != nil {
+		return nil, fmt.Errorf("Failed to parse quote: %s", err)
+	}
+	if attestType != tpmSTAttestQuote {
+		return nil, fmt.Errorf("Wrong attestation type: %#04x", attestType)
+	}
+	// qualifiedSigner
+	if _, err := tpm.ReadTPM2B(r); err != nil {
+		return nil, fmt.Errorf("Failed to parse quote: %s", err)
+	}
+	extraData, err := tpm.ReadTPM2B(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse quote: %s", err)
+	}
+	return extraData, nil
+}
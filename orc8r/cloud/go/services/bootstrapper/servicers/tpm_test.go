@@ -0,0 +1,307 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/tpm"
+	tpm_test_utils "magma/orc8r/cloud/go/security/tpm/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHardwareTPM2SHA256(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	srv, err := NewBootstrapperServer(privKey)
+	require.NoError(t, err)
+	manufacturer := newTestTPMManufacturer(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(manufacturer.root)
+	challenge := []byte("challenge")
+	secret := srv.getCredentialSecret(challenge)
+
+	// ECDSA and RSA attestation keys
+	ecdsaAK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	gw := manufacturer.newTestTPM(t, ecdsaAK)
+	resp := gw.respond(t, srv, challenge)
+	assert.NoError(t, verifyHardwareTPM2SHA256(resp, gw.challengeKey, roots, secret))
+
+	rsaAK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaGW := manufacturer.newTestTPM(t, rsaAK)
+	assert.NoError(t, verifyHardwareTPM2SHA256(rsaGW.respond(t, srv, challenge), rsaGW.challengeKey, roots, secret))
+
+	// No trusted roots
+	err = verifyHardwareTPM2SHA256(resp, gw.challengeKey, nil, secret)
+	assert.EqualError(t, err, "No trusted TPM manufacturer roots configured")
+
+	// EK of another manufacturer
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(newTestTPMManufacturer(t).root)
+	err = verifyHardwareTPM2SHA256(resp, gw.challengeKey, otherRoots, secret)
+	assert.Contains(t, err.Error(), "Untrusted endorsement key certificate")
+
+	// Missing intermediate
+	noIntermediate := gw.respond(t, srv, challenge)
+	noIntermediate.GetTpmResponse().EkIntermediates = nil
+	err = verifyHardwareTPM2SHA256(noIntermediate, gw.challengeKey, roots, secret)
+	assert.Contains(t, err.Error(), "Untrusted endorsement key certificate")
+
+	// Attestation key outside of the TPM of the registered EK, e.g. a
+	// software key registered along with the EK certificate of a real TPM:
+	// the credential can't be activated, so the quote lacks its secret
+	softwareAK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	unbound := manufacturer.newTestTPM(t, softwareAK)
+	unbound.challengeKey = marshalTestChallengeKey(t, softwareAK, gw.ekCert)
+	unboundResp := unbound.respond(t, srv, challenge)
+	_, err = tpm_test_utils.ActivateCredential(
+		unbound.ek, unbound.akName(t), unbound.lastCredential.CredentialBlob, unbound.lastCredential.EncryptedSecret)
+	assert.Error(t, err)
+	err = verifyHardwareTPM2SHA256(unboundResp, unbound.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Quote isn't for the challenge")
+
+	// Quote by a key other than the registered attestation key, e.g. the
+	// same storage on another TPM
+	err = verifyHardwareTPM2SHA256(resp, rsaGW.challengeKey, roots, secret)
+	assert.EqualError(t, err, "ECDSA quote signature for a non ECDSA attestation key")
+	otherAK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherGW := manufacturer.newTestTPM(t, otherAK)
+	err = verifyHardwareTPM2SHA256(otherGW.respond(t, srv, challenge), gw.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Wrong quote signature")
+
+	// Quote for another challenge
+	replayed := gw.respond(t, srv, []byte("old challenge"))
+	replayed.Challenge = challenge
+	err = verifyHardwareTPM2SHA256(replayed, gw.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Quote isn't for the challenge")
+
+	// Tampered quote
+	tampered := gw.respond(t, srv, challenge)
+	tampered.GetTpmResponse().Quote[len(tampered.GetTpmResponse().Quote)-1] ^= 1
+	err = verifyHardwareTPM2SHA256(tampered, gw.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Wrong quote signature")
+
+	// Signed data which isn't a quote
+	notQuote := gw.respond(t, srv, challenge)
+	notQuote.GetTpmResponse().Quote = []byte("not a quote")
+	notQuote.GetTpmResponse().Signature = signTPM(t, ecdsaAK, notQuote.GetTpmResponse().Quote)
+	err = verifyHardwareTPM2SHA256(notQuote, gw.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Quote wasn't generated by a TPM")
+
+	// Invalid registered key
+	err = verifyHardwareTPM2SHA256(resp, []byte("garbage"), roots, secret)
+	assert.Contains(t, err.Error(), "Invalid TPM challenge key")
+
+	// Wrong response type
+	err = verifyHardwareTPM2SHA256(&protos.Response{
+		Challenge: challenge,
+		Response:  &protos.Response_EcdsaResponse{EcdsaResponse: &protos.Response_ECDSA{}},
+	}, gw.challengeKey, roots, secret)
+	assert.EqualError(t, err, "Wrong type of response, expected TPM")
+}
+
+// testTPMManufacturer issues EK certificates the way TPM manufacturers do:
+// from an intermediate CA, with an empty subject and a critical SAN holding
+// the TPM manufacturer and model
+type testTPMManufacturer struct {
+	root            *x509.Certificate
+	intermediate    *x509.Certificate
+	intermediateKey *ecdsa.PrivateKey
+}
+
+func newTestTPMManufacturer(t *testing.T) *testTPMManufacturer {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	root := createTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "TPM Root CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, &rootKey.PublicKey, rootKey)
+
+	intermediateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	intermediate := createTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "TPM EK CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, root, &intermediateKey.PublicKey, rootKey)
+	return &testTPMManufacturer{root: root, intermediate: intermediate, intermediateKey: intermediateKey}
+}
+
+// testTPM stands in for the TPM of a gateway. Its quotes, signatures and
+// credential activations are built in Go following the TPM 2.0 structures,
+// they aren't recorded from a TPM or a TPM simulator.
+type testTPM struct {
+	ek             *rsa.PrivateKey
+	ekCert         []byte
+	intermediate   []byte
+	ak             crypto.Signer
+	challengeKey   []byte
+	lastCredential *protos.Challenge_TPMCredential
+}
+
+// newTestTPM returns a TPM with a new EK certified by the manufacturer and
+// the attestation key
+func (m *testTPMManufacturer) newTestTPM(t *testing.T, ak crypto.Signer) *testTPM {
+	ek, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	manufacturerName, err := asn1.Marshal(pkix.Name{
+		ExtraNames: []pkix.AttributeTypeAndValue{
+			{Type: asn1.ObjectIdentifier{2, 23, 133, 2, 1}, Value: "id:54455354"}, // tcg-at-tpmManufacturer
+			{Type: asn1.ObjectIdentifier{2, 23, 133, 2, 2}, Value: "TEST"},        // tcg-at-tpmModel
+		},
+	}.ToRDNSequence())
+	require.NoError(t, err)
+	san, err := asn1.Marshal([]asn1.RawValue{
+		{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: manufacturerName},
+	})
+	require.NoError(t, err)
+	ekCert := createTestCert(t, &x509.Certificate{
+		ExtraExtensions: []pkix.Extension{{Id: subjectAltNameOID, Critical: true, Value: san}},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{
+			{2, 23, 133, 8, 1}, // tcg-kp-EKCertificate
+		},
+		KeyUsage: x509.KeyUsageKeyEncipherment,
+	}, m.intermediate, &ek.PublicKey, m.intermediateKey)
+
+	return &testTPM{
+		ek:           ek,
+		ekCert:       ekCert.Raw,
+		intermediate: m.intermediate.Raw,
+		ak:           ak,
+		challengeKey: marshalTestChallengeKey(t, ak, ekCert.Raw),
+	}
+}
+
+// respond gets the credential of the challenge from the bootstrapper, and
+// returns a quote of the challenge and the credential secret signed by the
+// attestation key. If the credential can't be activated, the quote has an
+// empty secret.
+func (tt *testTPM) respond(t *testing.T, srv *BootstrapperServer, challenge []byte) *protos.Response {
+	credential, err := srv.makeTPMCredential(challenge, tt.challengeKey)
+	require.NoError(t, err)
+	tt.lastCredential = credential
+	secret, err := tpm_test_utils.ActivateCredential(tt.ek, tt.akName(t), credential.CredentialBlob, credential.EncryptedSecret)
+	if err != nil {
+		secret = nil
+	}
+
+	quote := newTestQuote(append(append([]byte{}, challenge...), secret...))
+	return &protos.Response{
+		Challenge: challenge,
+		Response: &protos.Response_TpmResponse{
+			TpmResponse: &protos.Response_TPM{
+				Quote:           quote,
+				Signature:       signTPM(t, tt.ak, quote),
+				EkIntermediates: [][]byte{tt.intermediate},
+			},
+		},
+	}
+}
+
+func (tt *testTPM) akName(t *testing.T) []byte {
+	ak, _, err := tpm.ParseChallengeKey(tt.challengeKey)
+	require.NoError(t, err)
+	return ak.Name
+}
+
+func marshalTestChallengeKey(t *testing.T, ak crypto.Signer, ekCert []byte) []byte {
+	akPublic, err := tpm_test_utils.MarshalAttestationKey(ak.Public(), tpm_test_utils.AttestationKeyAttrs)
+	require.NoError(t, err)
+	key, err := tpm.MarshalChallengeKey(&tpm.ChallengeKey{AKPublic: akPublic, EKCert: ekCert})
+	require.NoError(t, err)
+	return key
+}
+
+func createTestCert(t *testing.T, template, parent *x509.Certificate, pub interface{}, signer crypto.Signer) *x509.Certificate {
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	require.NoError(t, err)
+	template.SerialNumber = sn
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// newTestQuote returns a TPMS_ATTEST quote of PCR 0 with the SHA-256
+// digest of the data as qualifying data
+func newTestQuote(data []byte) []byte {
+	hashed := sha256.Sum256(data)
+	pcrDigest := sha256.Sum256([]byte("pcr 0"))
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.BigEndian, uint32(tpmGeneratedValue))
+	binary.Write(buf, binary.BigEndian, uint16(tpmSTAttestQuote))
+	tpm.WriteTPM2B(buf, []byte("qualified signer"))
+	tpm.WriteTPM2B(buf, hashed[:])
+	// clockInfo: clock, resetCount, restartCount, safe
+	binary.Write(buf, binary.BigEndian, uint64(1234))
+	binary.Write(buf, binary.BigEndian, uint32(1))
+	binary.Write(buf, binary.BigEndian, uint32(0))
+	buf.WriteByte(1)
+	// firmwareVersion
+	binary.Write(buf, binary.BigEndian, uint64(0x20170619))
+	// TPMS_QUOTE_INFO: PCR selection of SHA-256 PCR 0 and the PCR digest
+	binary.Write(buf, binary.BigEndian, uint32(1))
+	binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+	buf.Write([]byte{3, 1, 0, 0})
+	tpm.WriteTPM2B(buf, pcrDigest[:])
+	return buf.Bytes()
+}
+
+// signTPM returns the TPMT_SIGNATURE of data by the key
+func signTPM(t *testing.T, signer crypto.Signer, data []byte) []byte {
+	hashed := sha256.Sum256(data)
+	buf := &bytes.Buffer{}
+	switch key := signer.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, hashed[:])
+		require.NoError(t, err)
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgECDSA))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		tpm.WriteTPM2B(buf, r.Bytes())
+		tpm.WriteTPM2B(buf, s.Bytes())
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+		require.NoError(t, err)
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgRSASSA))
+		binary.Write(buf, binary.BigEndian, uint16(tpm.AlgSHA256))
+		tpm.WriteTPM2B(buf, sig)
+	default:
+		t.Fatalf("Unsupported key type %T", signer)
+	}
+	return buf.Bytes()
+}
@@ -10,6 +10,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"magma/orc8r/cloud/go/security/cert"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/certifier"
+	"magma/orc8r/cloud/go/services/certifier/httpserver"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
 	"magma/orc8r/cloud/go/sqorc"
@@ -34,7 +36,11 @@ var (
 	vpnCertFile = flag.String("vpnc", "vpn_ca.crt", "VPN CA's Certificate file")
 	vpnKeyFile  = flag.String("vpnk", "vpn_ca.key", "VPN CA's Private Key file")
 
+	caKeyDir = flag.String("ca-key-dir", "/var/opt/magma/certs/ca", "Directory of the private key files of staged CAs")
+
 	gcHours = flag.Int64("gc-hours", 12, "Garbage Collection time interval (in hours)")
+
+	revocationPort = flag.Int("revocation-port", 9087, "Port of the HTTP server for CRLs and OCSP")
 )
 
 func main() {
@@ -73,8 +79,12 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create certifier server: %s", err)
 	}
+	servicer.CAKeyDir = *caKeyDir
 	certprotos.RegisterCertifierServer(srv.GrpcServer, servicer)
 
+	// Serve CRLs and OCSP responses over HTTP
+	go httpserver.NewRevocationHttpServer(servicer).Run(fmt.Sprintf(":%d", *revocationPort))
+
 	// Start Garbage Collector Ticker
 	gc := time.Tick(time.Hour * time.Duration(*gcHours))
 	go func() {
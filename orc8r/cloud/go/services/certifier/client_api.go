@@ -188,6 +188,101 @@ func RevokeCertificateSN(sn string) error {
 	return RevokeCertificate(&protos.Certificate_SN{Sn: sn})
 }
 
+// RevokeCertificateWithReason revokes the certificate with the given SN and
+// publishes the revocation with the reason in the CRL and OCSP responses of
+// its CA
+func RevokeCertificateWithReason(sn string, reason certifierprotos.RevocationReason) error {
+	client, err := getCertifierClient()
+	if err != nil {
+		return err
+	}
+
+	glog.V(2).Infof("Certifier: revoking certificate with SN: %s, reason: %s", sn, reason)
+
+	_, err = client.RevokeCertificateWithReason(
+		context.Background(), &certifierprotos.RevokeCertificateRequest{Sn: sn, Reason: reason})
+	if err != nil {
+		glog.Errorf("Failed to revoke certificate with SN: %s, %s", sn, err)
+		return err
+	}
+	return nil
+}
+
+// GetCRL returns the current DER encoded CRL of the CA of the cert type with
+// the given SN, or of the CA which signs new certificates if the SN is empty
+func GetCRL(certType protos.CertType, caSN string) ([]byte, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+
+	crl, err := client.GetCRL(context.Background(), &certifierprotos.GetCRLRequest{CertType: certType, CaSn: caSN})
+	if err != nil {
+		glog.Errorf("Failed to get CRL: %s", err)
+		return nil, err
+	}
+	return crl.CrlDer, nil
+}
+
+// StageCA adds a new trusted CA for the cert type from its DER encoded
+// certificate and the name of its private key file, which must be in the CA
+// key directory of the certifier. The CA doesn't sign new certificates until
+// it's activated.
+func StageCA(certType protos.CertType, certDER []byte, keyFile string) error {
+	client, err := getCertifierClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.StageCA(
+		context.Background(), &certifierprotos.StageCARequest{CertType: certType, CertDer: certDER, KeyFile: keyFile})
+	if err != nil {
+		glog.Errorf("Failed to stage %s CA: %s", certType, err)
+	}
+	return err
+}
+
+// ActivateCA makes the staged CA with the given SN sign new certificates of
+// the cert type, if it's the newest active CA of the type
+func ActivateCA(certType protos.CertType, sn string) error {
+	client, err := getCertifierClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.ActivateCA(context.Background(), &certifierprotos.CARequest{CertType: certType, Sn: sn})
+	if err != nil {
+		glog.Errorf("Failed to activate %s CA %s: %s", certType, sn, err)
+	}
+	return err
+}
+
+// RetireCA removes the CA with the given SN from the trusted CAs of the cert
+// type
+func RetireCA(certType protos.CertType, sn string) error {
+	client, err := getCertifierClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.RetireCA(context.Background(), &certifierprotos.CARequest{CertType: certType, Sn: sn})
+	if err != nil {
+		glog.Errorf("Failed to retire %s CA %s: %s", certType, sn, err)
+	}
+	return err
+}
+
+// ListCAs returns all CAs of the cert type, without their private keys
+func ListCAs(certType protos.CertType) ([]*certifierprotos.CertificateAuthority, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	cas, err := client.ListCAs(context.Background(), &certifierprotos.ListCAsRequest{CertType: certType})
+	if err != nil {
+		glog.Errorf("Failed to list %s CAs: %s", certType, err)
+		return nil, err
+	}
+	return cas.Cas, nil
+}
+
 // Let certifier to remove expired certificates
 func CollectGarbage() error {
 	client, err := getCertifierClient()
@@ -0,0 +1,140 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package httpserver publishes the revocation status of the certificates
+// issued by the certifier over HTTP, so TLS terminators and standard tooling
+// can check it without calling GetIdentity. It's run within the certifier
+// process and serves:
+//
+//	GET  /crl/<cert type>       the signed CRL of the signing CA, e.g. /crl/default
+//	GET  /crl/<cert type>/<sn>  the signed CRL of the trusted CA with the SN
+//	POST /ocsp             OCSP responder for a DER encoded request body
+//	GET  /ocsp/<request>   OCSP responder for a base64 encoded request
+package httpserver
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+const (
+	CRLPath  = "/crl/"
+	OCSPPath = "/ocsp"
+
+	crlContentType          = "application/pkix-crl"
+	ocspResponseContentType = "application/ocsp-response"
+
+	// maxOCSPRequestSize bounds the size of a POSTed OCSP request body
+	maxOCSPRequestSize = 1 << 16
+)
+
+type RevocationHttpServer struct {
+	certifier *servicers.CertifierServer
+}
+
+func NewRevocationHttpServer(certifier *servicers.CertifierServer) *RevocationHttpServer {
+	return &RevocationHttpServer{certifier: certifier}
+}
+
+// Handler returns the handler for all revocation endpoints. Requests aren't
+// routed by a ServeMux, which cleans the request paths and so breaks base64
+// encoded OCSP requests containing "//".
+func (server *RevocationHttpServer) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := req.URL.EscapedPath()
+		switch {
+		case strings.HasPrefix(path, CRLPath):
+			server.crlHandler(w, req)
+		case path == OCSPPath || strings.HasPrefix(path, OCSPPath+"/"):
+			server.ocspHandler(w, req)
+		default:
+			http.NotFound(w, req)
+		}
+	})
+}
+
+// Run serves the revocation endpoints on addr. Run never returns.
+func (server *RevocationHttpServer) Run(addr string) {
+	glog.Fatal(http.ListenAndServe(addr, server.Handler()))
+}
+
+func (server *RevocationHttpServer) crlHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pathParts := strings.Split(strings.TrimPrefix(req.URL.Path, CRLPath), "/")
+	if len(pathParts) > 2 {
+		http.NotFound(w, req)
+		return
+	}
+	certTypeName := strings.ToUpper(pathParts[0])
+	certType, ok := protos.CertType_value[certTypeName]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	caSN := ""
+	if len(pathParts) == 2 {
+		caSN = pathParts[1]
+	}
+
+	crl, err := server.certifier.GetCRL(
+		context.Background(), &certprotos.GetCRLRequest{CertType: protos.CertType(certType), CaSn: caSN})
+	if err != nil {
+		glog.Errorf("Failed to get CRL for cert type %s: %s", certTypeName, err)
+		http.Error(w, err.Error(), merrors.GetHttpStatusCode(err))
+		return
+	}
+	w.Header().Set("Content-Type", crlContentType)
+	w.Write(crl.CrlDer)
+}
+
+func (server *RevocationHttpServer) ocspHandler(w http.ResponseWriter, req *http.Request) {
+	var requestDER []byte
+	var err error
+	switch req.Method {
+	case http.MethodPost:
+		requestDER, err = ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxOCSPRequestSize))
+	case http.MethodGet:
+		// The request is base64 encoded and then URL encoded (RFC 6960
+		// appendix A.1), though clients don't always URL encode it
+		var encoded string
+		encoded, err = url.PathUnescape(strings.TrimPrefix(req.URL.EscapedPath(), OCSPPath+"/"))
+		if err == nil {
+			requestDER, err = base64.StdEncoding.DecodeString(encoded)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "invalid OCSP request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := server.certifier.CreateOCSPResponse(requestDER)
+	if err != nil {
+		glog.Errorf("Failed to create OCSP response: %s", err)
+		http.Error(w, "failed to create OCSP response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ocspResponseContentType)
+	w.Write(response)
+}
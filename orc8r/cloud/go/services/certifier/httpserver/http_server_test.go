@@ -0,0 +1,127 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package httpserver_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	"magma/orc8r/cloud/go/services/certifier/httpserver"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/net/context"
+)
+
+func TestRevocationHttpServer(t *testing.T) {
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24)
+	require.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+	}
+	srv, err := servicers.NewCertifierServer(test_utils.NewMockDatastore(), caMap)
+	require.NoError(t, err)
+
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	require.NoError(t, err)
+	certMsg, err := srv.SignAddCertificate(context.Background(), csrMsg)
+	require.NoError(t, err)
+	_, err = srv.RevokeCertificate(context.Background(), certMsg.Sn)
+	require.NoError(t, err)
+	revokedCert, err := x509.ParseCertificate(certMsg.CertDer)
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(httpserver.NewRevocationHttpServer(srv).Handler())
+	defer testServer.Close()
+
+	// CRL
+	resp, err := http.Get(testServer.URL + "/crl/default")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pkix-crl", resp.Header.Get("Content-Type"))
+	body := readBody(t, resp)
+	crl, err := x509.ParseCRL(body)
+	require.NoError(t, err)
+	assert.NoError(t, caCert.CheckCRLSignature(crl))
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+	assert.Equal(t, 0, revokedCert.SerialNumber.Cmp(crl.TBSCertList.RevokedCertificates[0].SerialNumber))
+
+	// CRL of a CA by SN
+	resp, err = http.Get(testServer.URL + "/crl/default/" + cert.SerialToString(caCert.SerialNumber))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, body, readBody(t, resp))
+	resp, err = http.Get(testServer.URL + "/crl/default/42")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Cert types without a CA, and unknown cert types
+	resp, err = http.Get(testServer.URL + "/crl/vpn")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, err = http.Get(testServer.URL + "/crl/foo")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// OCSP over POST and GET
+	reqDER, err := ocsp.CreateRequest(revokedCert, caCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	require.NoError(t, err)
+	resp, err = http.Post(testServer.URL+"/ocsp", "application/ocsp-request", bytes.NewReader(reqDER))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/ocsp-response", resp.Header.Get("Content-Type"))
+	ocspResp, err := ocsp.ParseResponseForCert(readBody(t, resp), revokedCert, caCert)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, ocspResp.Status)
+
+	resp, err = http.Get(testServer.URL + "/ocsp/" + base64.StdEncoding.EncodeToString(reqDER))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	ocspResp, err = ocsp.ParseResponseForCert(readBody(t, resp), revokedCert, caCert)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, ocspResp.Status)
+
+	// URL encoded requests, and requests containing "//"
+	resp, err = http.Get(testServer.URL + "/ocsp/" + url.QueryEscape(base64.StdEncoding.EncodeToString(reqDER)))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	ocspResp, err = ocsp.ParseResponseForCert(readBody(t, resp), revokedCert, caCert)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, ocspResp.Status)
+
+	resp, err = http.Get(testServer.URL + "/ocsp/MA//")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, ocsp.MalformedRequestErrorResponse, readBody(t, resp))
+
+	resp, err = http.Get(testServer.URL + "/ocsp/not-base64!")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func readBody(t *testing.T, resp *http.Response) []byte {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return body
+}
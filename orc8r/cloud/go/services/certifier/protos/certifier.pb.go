@@ -26,14 +26,93 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// RevocationReason mirrors the CRLReason codes of RFC 5280 section 5.3.1.
+// Revocation is permanent, so certificateHold (6) and removeFromCRL (8)
+// aren't supported.
+type RevocationReason int32
+
+const (
+	RevocationReason_UNSPECIFIED            RevocationReason = 0
+	RevocationReason_KEY_COMPROMISE         RevocationReason = 1
+	RevocationReason_CA_COMPROMISE          RevocationReason = 2
+	RevocationReason_AFFILIATION_CHANGED    RevocationReason = 3
+	RevocationReason_SUPERSEDED             RevocationReason = 4
+	RevocationReason_CESSATION_OF_OPERATION RevocationReason = 5
+	RevocationReason_PRIVILEGE_WITHDRAWN    RevocationReason = 9
+	RevocationReason_AA_COMPROMISE          RevocationReason = 10
+)
+
+var RevocationReason_name = map[int32]string{
+	0:  "UNSPECIFIED",
+	1:  "KEY_COMPROMISE",
+	2:  "CA_COMPROMISE",
+	3:  "AFFILIATION_CHANGED",
+	4:  "SUPERSEDED",
+	5:  "CESSATION_OF_OPERATION",
+	9:  "PRIVILEGE_WITHDRAWN",
+	10: "AA_COMPROMISE",
+}
+
+var RevocationReason_value = map[string]int32{
+	"UNSPECIFIED":            0,
+	"KEY_COMPROMISE":         1,
+	"CA_COMPROMISE":          2,
+	"AFFILIATION_CHANGED":    3,
+	"SUPERSEDED":             4,
+	"CESSATION_OF_OPERATION": 5,
+	"PRIVILEGE_WITHDRAWN":    9,
+	"AA_COMPROMISE":          10,
+}
+
+func (x RevocationReason) String() string {
+	return proto.EnumName(RevocationReason_name, int32(x))
+}
+
+func (RevocationReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{0}
+}
+
+// CAState is the rotation state of a CA. Staged and active CAs are trusted
+// and returned in the CA bundle, the newest active CA signs new certificates.
+type CAState int32
+
+const (
+	CAState_STAGED  CAState = 0
+	CAState_ACTIVE  CAState = 1
+	CAState_RETIRED CAState = 2
+)
+
+var CAState_name = map[int32]string{
+	0: "STAGED",
+	1: "ACTIVE",
+	2: "RETIRED",
+}
+
+var CAState_value = map[string]int32{
+	"STAGED":  0,
+	"ACTIVE":  1,
+	"RETIRED": 2,
+}
+
+func (x CAState) String() string {
+	return proto.EnumName(CAState_name, int32(x))
+}
+
+func (CAState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{1}
+}
+
 type CertificateInfo struct {
-	Id                   *protos.Identity     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	NotBefore            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
-	NotAfter             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
-	CertType             protos.CertType      `protobuf:"varint,4,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Id        *protos.Identity     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NotBefore *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	CertType  protos.CertType      `protobuf:"varint,4,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	// SN of the CA which issued the certificate. Empty for certificates
+	// issued before CA rotation, which were issued by the CA loaded from files.
+	IssuerSn             string   `protobuf:"bytes,5,opt,name=issuer_sn,json=issuerSn,proto3" json:"issuer_sn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CertificateInfo) Reset()         { *m = CertificateInfo{} }
@@ -89,6 +168,13 @@ func (m *CertificateInfo) GetCertType() protos.CertType {
 	return protos.CertType_DEFAULT
 }
 
+func (m *CertificateInfo) GetIssuerSn() string {
+	if m != nil {
+		return m.IssuerSn
+	}
+	return ""
+}
+
 type CertificateInfoMap struct {
 	Certificates         map[string]*CertificateInfo `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
@@ -261,56 +347,584 @@ func (m *GetCARequest) GetCertType() protos.CertType {
 	return protos.CertType_DEFAULT
 }
 
+type RevokeCertificateRequest struct {
+	Sn                   string           `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
+	Reason               RevocationReason `protobuf:"varint,2,opt,name=reason,proto3,enum=magma.orc8r.certifier.RevocationReason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RevokeCertificateRequest) Reset()         { *m = RevokeCertificateRequest{} }
+func (m *RevokeCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeCertificateRequest) ProtoMessage()    {}
+func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{5}
+}
+
+func (m *RevokeCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCertificateRequest.Unmarshal(m, b)
+}
+func (m *RevokeCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCertificateRequest.Merge(m, src)
+}
+func (m *RevokeCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeCertificateRequest.Size(m)
+}
+func (m *RevokeCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCertificateRequest proto.InternalMessageInfo
+
+func (m *RevokeCertificateRequest) GetSn() string {
+	if m != nil {
+		return m.Sn
+	}
+	return ""
+}
+
+func (m *RevokeCertificateRequest) GetReason() RevocationReason {
+	if m != nil {
+		return m.Reason
+	}
+	return RevocationReason_UNSPECIFIED
+}
+
+// RevokedCertificate is the record kept for a revoked certificate until it
+// expires
+type RevokedCertificate struct {
+	Sn        string               `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
+	CertType  protos.CertType      `protobuf:"varint,2,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	Reason    RevocationReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=magma.orc8r.certifier.RevocationReason" json:"reason,omitempty"`
+	RevokedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// not_after of the revoked certificate
+	NotAfter *timestamp.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// issuer_sn of the revoked certificate, see CertificateInfo
+	IssuerSn             string   `protobuf:"bytes,6,opt,name=issuer_sn,json=issuerSn,proto3" json:"issuer_sn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokedCertificate) Reset()         { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()    {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{6}
+}
+
+func (m *RevokedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificate.Unmarshal(m, b)
+}
+func (m *RevokedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificate.Marshal(b, m, deterministic)
+}
+func (m *RevokedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificate.Merge(m, src)
+}
+func (m *RevokedCertificate) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificate.Size(m)
+}
+func (m *RevokedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificate proto.InternalMessageInfo
+
+func (m *RevokedCertificate) GetSn() string {
+	if m != nil {
+		return m.Sn
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *RevokedCertificate) GetReason() RevocationReason {
+	if m != nil {
+		return m.Reason
+	}
+	return RevocationReason_UNSPECIFIED
+}
+
+func (m *RevokedCertificate) GetRevokedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return nil
+}
+
+func (m *RevokedCertificate) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+func (m *RevokedCertificate) GetIssuerSn() string {
+	if m != nil {
+		return m.IssuerSn
+	}
+	return ""
+}
+
+type GetCRLRequest struct {
+	CertType protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	// SN of the CA whose CRL is requested, defaults to the CA which signs new
+	// certificates of the cert type
+	CaSn                 string   `protobuf:"bytes,2,opt,name=ca_sn,json=caSn,proto3" json:"ca_sn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCRLRequest) Reset()         { *m = GetCRLRequest{} }
+func (m *GetCRLRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCRLRequest) ProtoMessage()    {}
+func (*GetCRLRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{7}
+}
+
+func (m *GetCRLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCRLRequest.Unmarshal(m, b)
+}
+func (m *GetCRLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCRLRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCRLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCRLRequest.Merge(m, src)
+}
+func (m *GetCRLRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCRLRequest.Size(m)
+}
+func (m *GetCRLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCRLRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCRLRequest proto.InternalMessageInfo
+
+func (m *GetCRLRequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *GetCRLRequest) GetCaSn() string {
+	if m != nil {
+		return m.CaSn
+	}
+	return ""
+}
+
+type CRL struct {
+	CrlDer               []byte   `protobuf:"bytes,1,opt,name=crl_der,json=crlDer,proto3" json:"crl_der,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CRL) Reset()         { *m = CRL{} }
+func (m *CRL) String() string { return proto.CompactTextString(m) }
+func (*CRL) ProtoMessage()    {}
+func (*CRL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{8}
+}
+
+func (m *CRL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CRL.Unmarshal(m, b)
+}
+func (m *CRL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CRL.Marshal(b, m, deterministic)
+}
+func (m *CRL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CRL.Merge(m, src)
+}
+func (m *CRL) XXX_Size() int {
+	return xxx_messageInfo_CRL.Size(m)
+}
+func (m *CRL) XXX_DiscardUnknown() {
+	xxx_messageInfo_CRL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CRL proto.InternalMessageInfo
+
+func (m *CRL) GetCrlDer() []byte {
+	if m != nil {
+		return m.CrlDer
+	}
+	return nil
+}
+
+type CertificateAuthority struct {
+	CertType       protos.CertType      `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	Sn             string               `protobuf:"bytes,2,opt,name=sn,proto3" json:"sn,omitempty"`
+	CertDer        []byte               `protobuf:"bytes,3,opt,name=cert_der,json=certDer,proto3" json:"cert_der,omitempty"`
+	State          CAState              `protobuf:"varint,5,opt,name=state,proto3,enum=magma.orc8r.certifier.CAState" json:"state,omitempty"`
+	StateChangedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=state_changed_at,json=stateChangedAt,proto3" json:"state_changed_at,omitempty"`
+	// Name of the PEM encoded private key file of the CA in the CA key
+	// directory of the certifier. Private keys are never stored or sent over
+	// RPC. Dropped when the CA is retired.
+	KeyFile              string   `protobuf:"bytes,7,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CertificateAuthority) Reset()         { *m = CertificateAuthority{} }
+func (m *CertificateAuthority) String() string { return proto.CompactTextString(m) }
+func (*CertificateAuthority) ProtoMessage()    {}
+func (*CertificateAuthority) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{9}
+}
+
+func (m *CertificateAuthority) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateAuthority.Unmarshal(m, b)
+}
+func (m *CertificateAuthority) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateAuthority.Marshal(b, m, deterministic)
+}
+func (m *CertificateAuthority) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateAuthority.Merge(m, src)
+}
+func (m *CertificateAuthority) XXX_Size() int {
+	return xxx_messageInfo_CertificateAuthority.Size(m)
+}
+func (m *CertificateAuthority) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateAuthority.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateAuthority proto.InternalMessageInfo
+
+func (m *CertificateAuthority) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *CertificateAuthority) GetSn() string {
+	if m != nil {
+		return m.Sn
+	}
+	return ""
+}
+
+func (m *CertificateAuthority) GetCertDer() []byte {
+	if m != nil {
+		return m.CertDer
+	}
+	return nil
+}
+
+func (m *CertificateAuthority) GetState() CAState {
+	if m != nil {
+		return m.State
+	}
+	return CAState_STAGED
+}
+
+func (m *CertificateAuthority) GetStateChangedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StateChangedAt
+	}
+	return nil
+}
+
+func (m *CertificateAuthority) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+type StageCARequest struct {
+	CertType protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	CertDer  []byte          `protobuf:"bytes,2,opt,name=cert_der,json=certDer,proto3" json:"cert_der,omitempty"`
+	// Name of the PEM encoded private key file of the CA, which must already
+	// be in the CA key directory of every certifier instance
+	KeyFile              string   `protobuf:"bytes,4,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StageCARequest) Reset()         { *m = StageCARequest{} }
+func (m *StageCARequest) String() string { return proto.CompactTextString(m) }
+func (*StageCARequest) ProtoMessage()    {}
+func (*StageCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{10}
+}
+
+func (m *StageCARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StageCARequest.Unmarshal(m, b)
+}
+func (m *StageCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StageCARequest.Marshal(b, m, deterministic)
+}
+func (m *StageCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StageCARequest.Merge(m, src)
+}
+func (m *StageCARequest) XXX_Size() int {
+	return xxx_messageInfo_StageCARequest.Size(m)
+}
+func (m *StageCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StageCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StageCARequest proto.InternalMessageInfo
+
+func (m *StageCARequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *StageCARequest) GetCertDer() []byte {
+	if m != nil {
+		return m.CertDer
+	}
+	return nil
+}
+
+func (m *StageCARequest) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+type CARequest struct {
+	CertType             protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	Sn                   string          `protobuf:"bytes,2,opt,name=sn,proto3" json:"sn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CARequest) Reset()         { *m = CARequest{} }
+func (m *CARequest) String() string { return proto.CompactTextString(m) }
+func (*CARequest) ProtoMessage()    {}
+func (*CARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{11}
+}
+
+func (m *CARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CARequest.Unmarshal(m, b)
+}
+func (m *CARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CARequest.Marshal(b, m, deterministic)
+}
+func (m *CARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CARequest.Merge(m, src)
+}
+func (m *CARequest) XXX_Size() int {
+	return xxx_messageInfo_CARequest.Size(m)
+}
+func (m *CARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CARequest proto.InternalMessageInfo
+
+func (m *CARequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *CARequest) GetSn() string {
+	if m != nil {
+		return m.Sn
+	}
+	return ""
+}
+
+type ListCAsRequest struct {
+	CertType             protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListCAsRequest) Reset()         { *m = ListCAsRequest{} }
+func (m *ListCAsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCAsRequest) ProtoMessage()    {}
+func (*ListCAsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{12}
+}
+
+func (m *ListCAsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCAsRequest.Unmarshal(m, b)
+}
+func (m *ListCAsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCAsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCAsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCAsRequest.Merge(m, src)
+}
+func (m *ListCAsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCAsRequest.Size(m)
+}
+func (m *ListCAsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCAsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCAsRequest proto.InternalMessageInfo
+
+func (m *ListCAsRequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+type CertificateAuthorities struct {
+	Cas                  []*CertificateAuthority `protobuf:"bytes,1,rep,name=cas,proto3" json:"cas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *CertificateAuthorities) Reset()         { *m = CertificateAuthorities{} }
+func (m *CertificateAuthorities) String() string { return proto.CompactTextString(m) }
+func (*CertificateAuthorities) ProtoMessage()    {}
+func (*CertificateAuthorities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{13}
+}
+
+func (m *CertificateAuthorities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateAuthorities.Unmarshal(m, b)
+}
+func (m *CertificateAuthorities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateAuthorities.Marshal(b, m, deterministic)
+}
+func (m *CertificateAuthorities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateAuthorities.Merge(m, src)
+}
+func (m *CertificateAuthorities) XXX_Size() int {
+	return xxx_messageInfo_CertificateAuthorities.Size(m)
+}
+func (m *CertificateAuthorities) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateAuthorities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateAuthorities proto.InternalMessageInfo
+
+func (m *CertificateAuthorities) GetCas() []*CertificateAuthority {
+	if m != nil {
+		return m.Cas
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("magma.orc8r.certifier.RevocationReason", RevocationReason_name, RevocationReason_value)
+	proto.RegisterEnum("magma.orc8r.certifier.CAState", CAState_name, CAState_value)
 	proto.RegisterType((*CertificateInfo)(nil), "magma.orc8r.certifier.CertificateInfo")
 	proto.RegisterType((*CertificateInfoMap)(nil), "magma.orc8r.certifier.CertificateInfoMap")
 	proto.RegisterMapType((map[string]*CertificateInfo)(nil), "magma.orc8r.certifier.CertificateInfoMap.CertificatesEntry")
 	proto.RegisterType((*AddCertRequest)(nil), "magma.orc8r.certifier.AddCertRequest")
 	proto.RegisterType((*SerialNumbers)(nil), "magma.orc8r.certifier.SerialNumbers")
 	proto.RegisterType((*GetCARequest)(nil), "magma.orc8r.certifier.GetCARequest")
+	proto.RegisterType((*RevokeCertificateRequest)(nil), "magma.orc8r.certifier.RevokeCertificateRequest")
+	proto.RegisterType((*RevokedCertificate)(nil), "magma.orc8r.certifier.RevokedCertificate")
+	proto.RegisterType((*GetCRLRequest)(nil), "magma.orc8r.certifier.GetCRLRequest")
+	proto.RegisterType((*CRL)(nil), "magma.orc8r.certifier.CRL")
+	proto.RegisterType((*CertificateAuthority)(nil), "magma.orc8r.certifier.CertificateAuthority")
+	proto.RegisterType((*StageCARequest)(nil), "magma.orc8r.certifier.StageCARequest")
+	proto.RegisterType((*CARequest)(nil), "magma.orc8r.certifier.CARequest")
+	proto.RegisterType((*ListCAsRequest)(nil), "magma.orc8r.certifier.ListCAsRequest")
+	proto.RegisterType((*CertificateAuthorities)(nil), "magma.orc8r.certifier.CertificateAuthorities")
 }
 
 func init() { proto.RegisterFile("certifier.proto", fileDescriptor_515f9a7ba5ef1ab9) }
 
 var fileDescriptor_515f9a7ba5ef1ab9 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x8e, 0xd2, 0x40,
-	0x14, 0xc6, 0x5b, 0x90, 0x15, 0x0e, 0xc8, 0xc2, 0x98, 0x4d, 0xba, 0x5d, 0x13, 0xb1, 0xba, 0x06,
-	0x6f, 0x4a, 0x82, 0x17, 0xe2, 0x9f, 0x1b, 0xc0, 0x15, 0x37, 0xd9, 0xdd, 0xc4, 0x42, 0xbc, 0xf0,
-	0x86, 0x94, 0xf6, 0xd0, 0x4c, 0xb6, 0xed, 0xe0, 0x74, 0xd8, 0x84, 0x17, 0xf0, 0x4d, 0x7c, 0x2d,
-	0xe3, 0xa3, 0x98, 0xfe, 0x5b, 0x29, 0x14, 0xed, 0x15, 0x33, 0x73, 0xbe, 0xf9, 0x7d, 0x67, 0xbe,
-	0x19, 0x0a, 0xc7, 0x16, 0x72, 0x41, 0x97, 0x14, 0xb9, 0xbe, 0xe2, 0x4c, 0x30, 0x72, 0xe2, 0x99,
-	0x8e, 0x67, 0xea, 0x8c, 0x5b, 0x03, 0xae, 0xdf, 0x17, 0xd5, 0x27, 0xd1, 0x42, 0x2f, 0xd2, 0x04,
-	0xbd, 0x9d, 0x4d, 0xea, 0x69, 0xb6, 0xca, 0x3c, 0x8f, 0xf9, 0x49, 0xe9, 0x2c, 0x53, 0xa2, 0x36,
-	0xfa, 0x82, 0x8a, 0x4d, 0x52, 0x7c, 0xea, 0x30, 0xe6, 0xb8, 0x18, 0x57, 0x17, 0xeb, 0x65, 0x4f,
-	0x50, 0x0f, 0x03, 0x61, 0x7a, 0xab, 0x58, 0xa0, 0xfd, 0x96, 0xe1, 0x78, 0x1c, 0x9b, 0x59, 0xa6,
-	0xc0, 0x4b, 0x7f, 0xc9, 0xc8, 0x39, 0x94, 0xa8, 0xad, 0xc8, 0x1d, 0xb9, 0x5b, 0xef, 0x9f, 0xe8,
-	0xdb, 0xed, 0x5e, 0x26, 0x74, 0xa3, 0x44, 0x6d, 0xf2, 0x16, 0xc0, 0x67, 0x62, 0xbe, 0xc0, 0x25,
-	0xe3, 0xa8, 0x94, 0x22, 0xb9, 0xaa, 0xc7, 0x86, 0x7a, 0x6a, 0xa8, 0xcf, 0x52, 0x43, 0xa3, 0xe6,
-	0x33, 0x31, 0x8a, 0xc4, 0xe4, 0x0d, 0x84, 0x93, 0xb9, 0xb9, 0x14, 0xc8, 0x95, 0xf2, 0x7f, 0x77,
-	0x56, 0x7d, 0x26, 0x86, 0xa1, 0x96, 0xf4, 0xa1, 0x16, 0x46, 0x33, 0x17, 0x9b, 0x15, 0x2a, 0x0f,
-	0x3a, 0x72, 0xb7, 0xb9, 0xd3, 0x61, 0x78, 0x96, 0xd9, 0x66, 0x85, 0x46, 0xd5, 0x4a, 0x46, 0xda,
-	0x2f, 0x19, 0xc8, 0xce, 0x11, 0xaf, 0xcd, 0x15, 0x99, 0x43, 0xc3, 0xfa, 0xbb, 0x1a, 0x28, 0x72,
-	0xa7, 0xdc, 0xad, 0xf7, 0xdf, 0xeb, 0xb9, 0xd7, 0xa3, 0xef, 0x03, 0xb6, 0x97, 0x82, 0x0b, 0x5f,
-	0xf0, 0x8d, 0x91, 0x01, 0xaa, 0x0e, 0xb4, 0xf7, 0x24, 0xa4, 0x05, 0xe5, 0x5b, 0xdc, 0x44, 0xe1,
-	0xd6, 0x8c, 0x70, 0x48, 0x3e, 0x40, 0xe5, 0xce, 0x74, 0xd7, 0x69, 0x82, 0x2f, 0x8b, 0x35, 0x60,
-	0xc4, 0x9b, 0xde, 0x95, 0x06, 0xb2, 0xf6, 0x43, 0x86, 0xe6, 0xd0, 0xb6, 0x43, 0x85, 0x81, 0xdf,
-	0xd7, 0x18, 0x88, 0xa2, 0x57, 0x78, 0x0a, 0x51, 0x4c, 0x73, 0x1b, 0x79, 0x64, 0xdf, 0x30, 0x1e,
-	0x86, 0xf3, 0x8f, 0xbb, 0x49, 0x97, 0x8b, 0x25, 0xfd, 0x0c, 0x1e, 0x4d, 0x91, 0x53, 0xd3, 0xbd,
-	0x59, 0x7b, 0x0b, 0xe4, 0x41, 0x78, 0xda, 0xc0, 0x8f, 0xa3, 0xad, 0x19, 0xe1, 0x50, 0x1b, 0x41,
-	0x63, 0x82, 0x62, 0x3c, 0x4c, 0x1b, 0xcd, 0xd8, 0xc8, 0x85, 0x6c, 0xfa, 0x3f, 0x2b, 0x50, 0x1b,
-	0xa7, 0xc1, 0x90, 0x31, 0x54, 0x22, 0x22, 0x79, 0x7e, 0x20, 0xb9, 0x6d, 0x3f, 0xf5, 0x71, 0x16,
-	0x3e, 0x0c, 0x39, 0x9a, 0x44, 0x46, 0x40, 0xa6, 0xd4, 0xf1, 0x93, 0x14, 0x93, 0x9c, 0x49, 0x2b,
-	0x2b, 0x9e, 0x1a, 0xaa, 0xb2, 0xd7, 0x5b, 0xa2, 0xd5, 0x24, 0x32, 0x83, 0xfa, 0x04, 0x45, 0x9a,
-	0x2f, 0x39, 0x3b, 0x24, 0xd5, 0xa7, 0x37, 0x6a, 0xc1, 0x5b, 0xd6, 0x24, 0x72, 0x01, 0x6d, 0x03,
-	0xef, 0xd8, 0x2d, 0x6e, 0x37, 0xf6, 0x4f, 0x76, 0x3b, 0x53, 0xfc, 0xca, 0xa8, 0xad, 0x49, 0xe4,
-	0xea, 0xfe, 0x89, 0xa4, 0x8c, 0xf3, 0x03, 0x2d, 0x64, 0x5f, 0x52, 0x3e, 0xed, 0x0b, 0xb4, 0x3e,
-	0x51, 0x7f, 0x1b, 0x17, 0x90, 0xfc, 0x67, 0xa6, 0xbe, 0x38, 0x60, 0x93, 0x79, 0x28, 0x9a, 0x44,
-	0xae, 0xa1, 0x75, 0x45, 0x03, 0x91, 0x41, 0xee, 0x7b, 0x17, 0xc6, 0x7d, 0x86, 0xa3, 0x09, 0x8a,
-	0xa1, 0xeb, 0xe6, 0x41, 0x5e, 0x15, 0xfe, 0x93, 0x6b, 0x12, 0x19, 0x40, 0x73, 0xcc, 0x5c, 0x17,
-	0x2d, 0x31, 0x31, 0xf9, 0xc2, 0x74, 0x30, 0x8f, 0x98, 0x97, 0xd2, 0xa8, 0xfa, 0xed, 0x28, 0xfe,
-	0x2a, 0x2f, 0xe2, 0xdf, 0xd7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xfb, 0xa9, 0xfc, 0xb5, 0x0d,
-	0x06, 0x00, 0x00,
+	// 1152 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5d, 0x6f, 0xe3, 0x44,
+	0x17, 0x8e, 0x9d, 0xef, 0xd3, 0xd6, 0x75, 0xa7, 0xef, 0xee, 0x66, 0xdd, 0x57, 0x4b, 0x31, 0x2c,
+	0x94, 0x45, 0xa4, 0x52, 0x40, 0xa2, 0x7c, 0x09, 0xb9, 0x8e, 0x93, 0x1a, 0xd2, 0xa4, 0x8c, 0xb3,
+	0x2d, 0x70, 0x63, 0xb9, 0xce, 0x24, 0x1d, 0x35, 0xb1, 0x8b, 0x3d, 0xa9, 0x94, 0x3b, 0xae, 0xb8,
+	0xe0, 0x92, 0x9f, 0xc1, 0x5f, 0xe0, 0xbf, 0xf0, 0x4f, 0x90, 0x90, 0xc7, 0x4e, 0x37, 0xce, 0x47,
+	0x1b, 0xb6, 0x57, 0x19, 0xcf, 0x9c, 0x79, 0xce, 0x99, 0xe7, 0x39, 0x67, 0xe6, 0x04, 0xb6, 0x5d,
+	0x12, 0x30, 0xda, 0xa7, 0x24, 0xa8, 0xde, 0x04, 0x3e, 0xf3, 0xd1, 0x93, 0x91, 0x33, 0x18, 0x39,
+	0x55, 0x3f, 0x70, 0x8f, 0x82, 0xea, 0xdd, 0xa2, 0xf2, 0x7f, 0x3e, 0x71, 0xc8, 0x6d, 0xc2, 0xc3,
+	0xb9, 0x4d, 0xca, 0xf3, 0xf4, 0xaa, 0x3f, 0x1a, 0xf9, 0x5e, 0xb2, 0xb4, 0x97, 0x5a, 0xa2, 0x3d,
+	0xe2, 0x31, 0xca, 0x26, 0xc9, 0xe2, 0x3b, 0x03, 0xdf, 0x1f, 0x0c, 0x49, 0xbc, 0x7a, 0x39, 0xee,
+	0x1f, 0x32, 0x3a, 0x22, 0x21, 0x73, 0x46, 0x37, 0xb1, 0x81, 0xfa, 0x8f, 0x00, 0xdb, 0x7a, 0xec,
+	0xcc, 0x75, 0x18, 0x31, 0xbd, 0xbe, 0x8f, 0x5e, 0x82, 0x48, 0x7b, 0x15, 0x61, 0x5f, 0x38, 0xd8,
+	0xa8, 0x3d, 0xa9, 0xce, 0x86, 0x6b, 0x26, 0xe8, 0x58, 0xa4, 0x3d, 0xf4, 0x05, 0x80, 0xe7, 0x33,
+	0xfb, 0x92, 0xf4, 0xfd, 0x80, 0x54, 0x44, 0x6e, 0xae, 0x54, 0x63, 0x87, 0xd5, 0xa9, 0xc3, 0x6a,
+	0x77, 0xea, 0x10, 0x97, 0x3d, 0x9f, 0x1d, 0x73, 0x63, 0xf4, 0x39, 0x44, 0x1f, 0xb6, 0xd3, 0x67,
+	0x24, 0xa8, 0x64, 0x1f, 0xdc, 0x59, 0xf2, 0x7c, 0xa6, 0x45, 0xb6, 0xa8, 0x06, 0xe5, 0x88, 0x1a,
+	0x9b, 0x4d, 0x6e, 0x48, 0x25, 0xb7, 0x2f, 0x1c, 0x48, 0x73, 0x11, 0x46, 0x67, 0xe9, 0x4e, 0x6e,
+	0x08, 0x2e, 0xb9, 0xc9, 0x08, 0xed, 0x41, 0x99, 0x86, 0xe1, 0x98, 0x04, 0x76, 0xe8, 0x55, 0xf2,
+	0xfb, 0xc2, 0x41, 0x19, 0x97, 0xe2, 0x09, 0xcb, 0x53, 0xff, 0x16, 0x00, 0xcd, 0x9d, 0xff, 0xd4,
+	0xb9, 0x41, 0x36, 0x6c, 0xba, 0x6f, 0x66, 0xc3, 0x8a, 0xb0, 0x9f, 0x3d, 0xd8, 0xa8, 0x7d, 0x55,
+	0x5d, 0xaa, 0x5d, 0x75, 0x11, 0x60, 0x76, 0x2a, 0x34, 0x3c, 0x16, 0x4c, 0x70, 0x0a, 0x50, 0x19,
+	0xc0, 0xce, 0x82, 0x09, 0x92, 0x21, 0x7b, 0x4d, 0x26, 0x9c, 0xf9, 0x32, 0x8e, 0x86, 0xe8, 0x6b,
+	0xc8, 0xdf, 0x3a, 0xc3, 0xf1, 0x94, 0xde, 0x0f, 0xd6, 0x0b, 0x00, 0xc7, 0x9b, 0xbe, 0x14, 0x8f,
+	0x04, 0xf5, 0x37, 0x01, 0x24, 0xad, 0xd7, 0x8b, 0x2c, 0x30, 0xf9, 0x65, 0x4c, 0x42, 0xb6, 0xae,
+	0xbe, 0xcf, 0x81, 0x73, 0x68, 0xf7, 0x48, 0xc0, 0xdd, 0x6f, 0xe2, 0x62, 0xf4, 0x5d, 0x9f, 0x97,
+	0x21, 0xbb, 0x96, 0x0c, 0xea, 0xbb, 0xb0, 0x65, 0x91, 0x80, 0x3a, 0xc3, 0xf6, 0x78, 0x74, 0x49,
+	0x82, 0x30, 0x3a, 0x6d, 0xe8, 0xc5, 0xd4, 0x96, 0x71, 0x34, 0x54, 0x8f, 0x61, 0xb3, 0x49, 0x98,
+	0xae, 0x4d, 0x03, 0x4d, 0xb9, 0x11, 0xd6, 0x73, 0x73, 0x0d, 0x15, 0x4c, 0x6e, 0xfd, 0x6b, 0x32,
+	0xc3, 0xc9, 0x14, 0x4f, 0x02, 0x31, 0xf4, 0x12, 0x7a, 0xc5, 0xd0, 0x43, 0xdf, 0x42, 0x21, 0x20,
+	0x4e, 0xe8, 0x7b, 0xfc, 0x7c, 0x52, 0xed, 0xc3, 0x15, 0xf4, 0x46, 0x80, 0xae, 0xc3, 0xa8, 0xef,
+	0x61, 0x6e, 0x8e, 0x93, 0x6d, 0xea, 0x9f, 0x22, 0xa0, 0xd8, 0x5b, 0x6f, 0xc6, 0xdd, 0x82, 0x9f,
+	0xd4, 0x39, 0xc4, 0xf5, 0xb2, 0xf6, 0x4d, 0x6c, 0xd9, 0xb7, 0x8a, 0x2d, 0x2a, 0xcf, 0x20, 0x0e,
+	0xcd, 0x76, 0x58, 0x25, 0xf7, 0x60, 0x91, 0x95, 0x13, 0x6b, 0x8d, 0xa5, 0xcb, 0x33, 0xff, 0x1f,
+	0xca, 0x33, 0x55, 0x6a, 0x85, 0xb9, 0x52, 0xfb, 0x11, 0xb6, 0x22, 0x75, 0x71, 0xeb, 0x11, 0xf2,
+	0xa2, 0x5d, 0xc8, 0xbb, 0x4e, 0x84, 0x2e, 0x72, 0xf4, 0x9c, 0xeb, 0x58, 0x9e, 0xfa, 0x02, 0xb2,
+	0x3a, 0x6e, 0xa1, 0x67, 0x50, 0x74, 0x83, 0x21, 0xcf, 0x57, 0x81, 0xe7, 0x6b, 0xc1, 0x0d, 0x86,
+	0x75, 0x12, 0xa8, 0x7f, 0x88, 0xf0, 0xbf, 0x19, 0x7d, 0xb4, 0x31, 0xbb, 0xf2, 0x03, 0xca, 0x26,
+	0x6f, 0x15, 0x41, 0x2c, 0xae, 0x78, 0x27, 0xee, 0x6c, 0x99, 0x64, 0xd3, 0x65, 0xf2, 0x19, 0xe4,
+	0x43, 0xe6, 0x30, 0xc2, 0x39, 0x94, 0x6a, 0x2f, 0x56, 0x55, 0xaf, 0x66, 0x45, 0x56, 0x38, 0x36,
+	0x46, 0x75, 0x90, 0xf9, 0xc0, 0x76, 0xaf, 0x1c, 0x6f, 0x10, 0xcb, 0x57, 0x78, 0x50, 0x04, 0x89,
+	0xef, 0xd1, 0xe3, 0x2d, 0x1a, 0x8b, 0xc2, 0xba, 0x26, 0x13, 0xbb, 0x4f, 0x87, 0xa4, 0x52, 0xe4,
+	0xc1, 0x16, 0xaf, 0xc9, 0xa4, 0x41, 0x87, 0xe4, 0xbb, 0x5c, 0x29, 0x27, 0xe7, 0xd5, 0x5f, 0x05,
+	0x90, 0x2c, 0xe6, 0x0c, 0xc8, 0xa3, 0xea, 0xed, 0xbe, 0x5b, 0x62, 0x36, 0x84, 0xdc, 0x7c, 0x08,
+	0x59, 0x39, 0xa7, 0x76, 0xa0, 0xfc, 0x38, 0xe7, 0x73, 0x5a, 0xa8, 0x75, 0x90, 0x5a, 0x34, 0x64,
+	0xba, 0x16, 0x3e, 0xe6, 0x0a, 0xb9, 0x80, 0xa7, 0x4b, 0xb2, 0x85, 0x92, 0x10, 0x7d, 0x03, 0x59,
+	0xd7, 0x99, 0xbe, 0x06, 0x1f, 0x3f, 0x7c, 0x19, 0xdf, 0x65, 0x1a, 0x8e, 0xf6, 0xbd, 0xfa, 0x4b,
+	0x00, 0x79, 0xbe, 0x5e, 0xd1, 0x36, 0x6c, 0xbc, 0x6e, 0x5b, 0x67, 0x86, 0x6e, 0x36, 0x4c, 0xa3,
+	0x2e, 0x67, 0x10, 0x02, 0xe9, 0x7b, 0xe3, 0x27, 0x5b, 0xef, 0x9c, 0x9e, 0xe1, 0xce, 0xa9, 0x69,
+	0x19, 0xb2, 0x80, 0x76, 0x60, 0x4b, 0xd7, 0x66, 0xa7, 0x44, 0xf4, 0x0c, 0x76, 0xb5, 0x46, 0xc3,
+	0x6c, 0x99, 0x5a, 0xd7, 0xec, 0xb4, 0x6d, 0xfd, 0x44, 0x6b, 0x37, 0x8d, 0xba, 0x9c, 0x45, 0x12,
+	0x80, 0xf5, 0xfa, 0xcc, 0xc0, 0x96, 0x51, 0x37, 0xea, 0x72, 0x0e, 0x29, 0xf0, 0x54, 0x37, 0x2c,
+	0x2b, 0x36, 0xeb, 0x34, 0xec, 0xce, 0x99, 0x81, 0xf9, 0x87, 0x9c, 0x8f, 0x40, 0xce, 0xb0, 0x79,
+	0x6e, 0xb6, 0x8c, 0xa6, 0x61, 0x5f, 0x98, 0xdd, 0x93, 0x3a, 0xd6, 0x2e, 0xda, 0x72, 0x39, 0x72,
+	0xa8, 0xa5, 0x1c, 0xc2, 0xab, 0x2a, 0x14, 0x93, 0x4c, 0x45, 0x00, 0x05, 0xab, 0xab, 0x35, 0x79,
+	0xb8, 0x00, 0x05, 0x4d, 0xef, 0x9a, 0xe7, 0x51, 0x98, 0x1b, 0x50, 0xc4, 0x46, 0xd7, 0xc4, 0x46,
+	0x5d, 0x16, 0x6b, 0xbf, 0x97, 0xa1, 0xac, 0x4f, 0x59, 0x41, 0x3a, 0xe4, 0xf9, 0xdd, 0x8e, 0xde,
+	0x5b, 0x41, 0xdb, 0xec, 0xcd, 0xaf, 0xec, 0xa6, 0x35, 0xd2, 0x22, 0x1c, 0x35, 0x83, 0x0c, 0x28,
+	0x26, 0x29, 0x8b, 0x5e, 0xae, 0x80, 0x49, 0xa7, 0xb4, 0xb2, 0x93, 0x32, 0x3b, 0xf7, 0x69, 0x4f,
+	0xcd, 0x20, 0x1d, 0x40, 0x73, 0x19, 0xbd, 0x8d, 0x0a, 0x46, 0x43, 0xfb, 0x2b, 0xcb, 0xf2, 0x5e,
+	0x10, 0x0d, 0x4a, 0x98, 0x30, 0x1a, 0x3c, 0x02, 0xc2, 0x81, 0x62, 0x92, 0xae, 0x2b, 0x8f, 0x93,
+	0x4e, 0x67, 0xe5, 0x93, 0xf5, 0x73, 0x8e, 0x92, 0x50, 0xcd, 0xa0, 0x63, 0x40, 0x16, 0x1d, 0x78,
+	0x49, 0x07, 0x90, 0x98, 0x20, 0x39, 0x4d, 0xaf, 0x85, 0x95, 0xca, 0x42, 0x51, 0x24, 0xb6, 0x6a,
+	0x06, 0x75, 0x61, 0xa3, 0x49, 0xd8, 0xb4, 0x37, 0x40, 0x7b, 0xab, 0x4c, 0xab, 0x56, 0x5b, 0x59,
+	0xb3, 0x43, 0xe1, 0x5a, 0xee, 0x2c, 0x3c, 0xd4, 0xf7, 0x63, 0x2f, 0xe5, 0xd0, 0x85, 0xbd, 0x05,
+	0x98, 0x0b, 0xca, 0xae, 0x92, 0xea, 0x3a, 0xbc, 0xe7, 0xd9, 0x5c, 0xd6, 0x23, 0x2c, 0x77, 0xd2,
+	0x82, 0x42, 0xfc, 0x74, 0xa1, 0xf7, 0xef, 0xc9, 0xde, 0xbb, 0x97, 0x4d, 0x51, 0x56, 0xb1, 0x80,
+	0x5b, 0x1c, 0x4d, 0x9a, 0xd3, 0x63, 0x95, 0xfa, 0xe9, 0xc6, 0x6d, 0x79, 0x6c, 0x3f, 0x80, 0xdc,
+	0xa0, 0xde, 0x2c, 0x5c, 0x88, 0x96, 0x77, 0x75, 0xca, 0xaa, 0xe0, 0x53, 0x7d, 0x99, 0x9a, 0x41,
+	0xa7, 0x20, 0xf3, 0xbc, 0x9b, 0x85, 0x5c, 0xf4, 0xbd, 0x36, 0xdc, 0x09, 0x67, 0x4f, 0x1b, 0x0e,
+	0x97, 0x81, 0x7c, 0xb4, 0x76, 0x4f, 0xad, 0x66, 0xd0, 0x11, 0x48, 0xba, 0x3f, 0x1c, 0x12, 0x97,
+	0x35, 0x9d, 0xe0, 0xd2, 0x19, 0x90, 0x65, 0x88, 0xcb, 0x58, 0x3a, 0x2e, 0xfd, 0x5c, 0x88, 0xff,
+	0x21, 0x5d, 0xc6, 0xbf, 0x9f, 0xfe, 0x3b, 0x00, 0xba, 0xcb, 0xf4, 0x56, 0x99, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -325,8 +939,25 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type CertifierClient interface {
-	// Returns the cert of the requested CA
+	// Returns the cert of the CA which signs new certificates of the requested
+	// type, along with the bundle of all trusted CAs of the type
 	GetCA(ctx context.Context, in *GetCARequest, opts ...grpc.CallOption) (*protos.CACert, error)
+	// Adds a new CA for the cert type. Staged CAs are trusted but don't sign
+	// new certificates until activated.
+	// Throws ALREADY_EXISTS if the CA was already added.
+	//
+	StageCA(ctx context.Context, in *StageCARequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Makes a staged CA sign new certificates, if it's the newest active CA
+	// of its type.
+	//
+	ActivateCA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Removes a CA from the trusted CAs of its type.
+	// Throws FAILED_PRECONDITION if it's the last active CA of the type.
+	//
+	RetireCA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns all CAs of the cert type, without their private keys
+	//
+	ListCAs(ctx context.Context, in *ListCAsRequest, opts ...grpc.CallOption) (*CertificateAuthorities, error)
 	// Signs and adds a new certificate to the store.
 	// Returns signed certificate.
 	//
@@ -339,6 +970,14 @@ type CertifierClient interface {
 	// If the certificate does not exist or is expired, this request is ignored.
 	//
 	RevokeCertificate(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*protos.Void, error)
+	// Revoke an existing certificate with the given reason, and add it to the
+	// CRL of its CA.
+	// Throws NOT_FOUND if the certificate is missing.
+	//
+	RevokeCertificateWithReason(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns the current signed CRL of the requested CA
+	//
+	GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
 	// associates its Serial Number with given Identity (AddCertRequest.id)
 	AddCertificate(ctx context.Context, in *AddCertRequest, opts ...grpc.CallOption) (*protos.Void, error)
@@ -371,6 +1010,42 @@ func (c *certifierClient) GetCA(ctx context.Context, in *GetCARequest, opts ...g
 	return out, nil
 }
 
+func (c *certifierClient) StageCA(ctx context.Context, in *StageCARequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/StageCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) ActivateCA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/ActivateCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) RetireCA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/RetireCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) ListCAs(ctx context.Context, in *ListCAsRequest, opts ...grpc.CallOption) (*CertificateAuthorities, error) {
+	out := new(CertificateAuthorities)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/ListCAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *certifierClient) SignAddCertificate(ctx context.Context, in *protos.CSR, opts ...grpc.CallOption) (*protos.Certificate, error) {
 	out := new(protos.Certificate)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/SignAddCertificate", in, out, opts...)
@@ -398,6 +1073,24 @@ func (c *certifierClient) RevokeCertificate(ctx context.Context, in *protos.Cert
 	return out, nil
 }
 
+func (c *certifierClient) RevokeCertificateWithReason(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/RevokeCertificateWithReason", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error) {
+	out := new(CRL)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/GetCRL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *certifierClient) AddCertificate(ctx context.Context, in *AddCertRequest, opts ...grpc.CallOption) (*protos.Void, error) {
 	out := new(protos.Void)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/AddCertificate", in, out, opts...)
@@ -445,8 +1138,25 @@ func (c *certifierClient) CollectGarbage(ctx context.Context, in *protos.Void, o
 
 // CertifierServer is the server API for Certifier service.
 type CertifierServer interface {
-	// Returns the cert of the requested CA
+	// Returns the cert of the CA which signs new certificates of the requested
+	// type, along with the bundle of all trusted CAs of the type
 	GetCA(context.Context, *GetCARequest) (*protos.CACert, error)
+	// Adds a new CA for the cert type. Staged CAs are trusted but don't sign
+	// new certificates until activated.
+	// Throws ALREADY_EXISTS if the CA was already added.
+	//
+	StageCA(context.Context, *StageCARequest) (*protos.Void, error)
+	// Makes a staged CA sign new certificates, if it's the newest active CA
+	// of its type.
+	//
+	ActivateCA(context.Context, *CARequest) (*protos.Void, error)
+	// Removes a CA from the trusted CAs of its type.
+	// Throws FAILED_PRECONDITION if it's the last active CA of the type.
+	//
+	RetireCA(context.Context, *CARequest) (*protos.Void, error)
+	// Returns all CAs of the cert type, without their private keys
+	//
+	ListCAs(context.Context, *ListCAsRequest) (*CertificateAuthorities, error)
 	// Signs and adds a new certificate to the store.
 	// Returns signed certificate.
 	//
@@ -459,6 +1169,14 @@ type CertifierServer interface {
 	// If the certificate does not exist or is expired, this request is ignored.
 	//
 	RevokeCertificate(context.Context, *protos.Certificate_SN) (*protos.Void, error)
+	// Revoke an existing certificate with the given reason, and add it to the
+	// CRL of its CA.
+	// Throws NOT_FOUND if the certificate is missing.
+	//
+	RevokeCertificateWithReason(context.Context, *RevokeCertificateRequest) (*protos.Void, error)
+	// Returns the current signed CRL of the requested CA
+	//
+	GetCRL(context.Context, *GetCRLRequest) (*CRL, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
 	// associates its Serial Number with given Identity (AddCertRequest.id)
 	AddCertificate(context.Context, *AddCertRequest) (*protos.Void, error)
@@ -481,6 +1199,18 @@ type UnimplementedCertifierServer struct {
 func (*UnimplementedCertifierServer) GetCA(ctx context.Context, req *GetCARequest) (*protos.CACert, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCA not implemented")
 }
+func (*UnimplementedCertifierServer) StageCA(ctx context.Context, req *StageCARequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageCA not implemented")
+}
+func (*UnimplementedCertifierServer) ActivateCA(ctx context.Context, req *CARequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateCA not implemented")
+}
+func (*UnimplementedCertifierServer) RetireCA(ctx context.Context, req *CARequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireCA not implemented")
+}
+func (*UnimplementedCertifierServer) ListCAs(ctx context.Context, req *ListCAsRequest) (*CertificateAuthorities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCAs not implemented")
+}
 func (*UnimplementedCertifierServer) SignAddCertificate(ctx context.Context, req *protos.CSR) (*protos.Certificate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignAddCertificate not implemented")
 }
@@ -490,6 +1220,12 @@ func (*UnimplementedCertifierServer) GetIdentity(ctx context.Context, req *proto
 func (*UnimplementedCertifierServer) RevokeCertificate(ctx context.Context, req *protos.Certificate_SN) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificate not implemented")
 }
+func (*UnimplementedCertifierServer) RevokeCertificateWithReason(ctx context.Context, req *RevokeCertificateRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificateWithReason not implemented")
+}
+func (*UnimplementedCertifierServer) GetCRL(ctx context.Context, req *GetCRLRequest) (*CRL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCRL not implemented")
+}
 func (*UnimplementedCertifierServer) AddCertificate(ctx context.Context, req *AddCertRequest) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddCertificate not implemented")
 }
@@ -528,6 +1264,78 @@ func _Certifier_GetCA_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Certifier_StageCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StageCARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).StageCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/StageCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).StageCA(ctx, req.(*StageCARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_ActivateCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).ActivateCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/ActivateCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).ActivateCA(ctx, req.(*CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_RetireCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).RetireCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/RetireCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).RetireCA(ctx, req.(*CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_ListCAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCAsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).ListCAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/ListCAs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).ListCAs(ctx, req.(*ListCAsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Certifier_SignAddCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(protos.CSR)
 	if err := dec(in); err != nil {
@@ -582,6 +1390,42 @@ func _Certifier_RevokeCertificate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Certifier_RevokeCertificateWithReason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).RevokeCertificateWithReason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/RevokeCertificateWithReason",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).RevokeCertificateWithReason(ctx, req.(*RevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_GetCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).GetCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/GetCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).GetCRL(ctx, req.(*GetCRLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Certifier_AddCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddCertRequest)
 	if err := dec(in); err != nil {
@@ -680,6 +1524,22 @@ var _Certifier_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetCA",
 			Handler:    _Certifier_GetCA_Handler,
 		},
+		{
+			MethodName: "StageCA",
+			Handler:    _Certifier_StageCA_Handler,
+		},
+		{
+			MethodName: "ActivateCA",
+			Handler:    _Certifier_ActivateCA_Handler,
+		},
+		{
+			MethodName: "RetireCA",
+			Handler:    _Certifier_RetireCA_Handler,
+		},
+		{
+			MethodName: "ListCAs",
+			Handler:    _Certifier_ListCAs_Handler,
+		},
 		{
 			MethodName: "SignAddCertificate",
 			Handler:    _Certifier_SignAddCertificate_Handler,
@@ -692,6 +1552,14 @@ var _Certifier_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeCertificate",
 			Handler:    _Certifier_RevokeCertificate_Handler,
 		},
+		{
+			MethodName: "RevokeCertificateWithReason",
+			Handler:    _Certifier_RevokeCertificateWithReason_Handler,
+		},
+		{
+			MethodName: "GetCRL",
+			Handler:    _Certifier_GetCRL_Handler,
+		},
 		{
 			MethodName: "AddCertificate",
 			Handler:    _Certifier_AddCertificate_Handler,
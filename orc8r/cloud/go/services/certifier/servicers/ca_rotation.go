@@ -0,0 +1,417 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CARefreshInterval is how long the CAs of a cert type are cached before
+// they're reloaded. Rotations through this certifier instance take effect
+// immediately, other instances pick them up within this interval.
+var CARefreshInterval = time.Minute
+
+// caSet is the set of trusted CAs of a cert type
+type caSet struct {
+	// trusted are the staged and active CAs, newest first
+	trusted []*CAInfo
+	// signing is the newest active CA
+	signing *CAInfo
+}
+
+type loadedCASets struct {
+	sets     map[protos.CertType]*caSet
+	loadedAt time.Time
+}
+
+// StageCA adds a new trusted CA for the cert type. The CA doesn't sign new
+// certificates until it's activated, which gives gateways the time to fetch
+// the CA bundle with the new CA before any certificate signed by it is used.
+// The private key of the CA is loaded from the named file in the CA key
+// directory, so it's never sent over RPC or stored in the datastore.
+func (srv *CertifierServer) StageCA(ctx context.Context, req *certprotos.StageCARequest) (*protos.Void, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid stage CA request")
+	}
+	if !isValidKeyFileName(req.KeyFile) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CA key file name: %q", req.KeyFile)
+	}
+	ca, err := srv.loadCA(req.CertDer, req.KeyFile)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CA: %s", err)
+	}
+	sn := cert.SerialToString(ca.Cert.SerialNumber)
+	if srv.isFileCA(req.CertType, sn) {
+		return nil, status.Errorf(codes.AlreadyExists, "CA %s already exists", sn)
+	}
+	if _, err = srv.getCA(req.CertType, sn); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "CA %s already exists", sn)
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	record := &certprotos.CertificateAuthority{
+		CertType: req.CertType,
+		Sn:       sn,
+		CertDer:  req.CertDer,
+		KeyFile:  req.KeyFile,
+		State:    certprotos.CAState_STAGED,
+	}
+	glog.Infof("Staging %s CA %s with key file %s", req.CertType, sn, req.KeyFile)
+	return &protos.Void{}, srv.putCA(record)
+}
+
+// ActivateCA makes a staged CA eligible to sign new certificates. The newest
+// active CA of a cert type signs its new certificates.
+func (srv *CertifierServer) ActivateCA(ctx context.Context, req *certprotos.CARequest) (*protos.Void, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid activate CA request")
+	}
+	sn := normalizeSN(req.Sn)
+	record, err := srv.getCA(req.CertType, sn)
+	if status.Code(err) == codes.NotFound && srv.isFileCA(req.CertType, sn) {
+		// CAs loaded from files are active unless retired
+		return &protos.Void{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch record.State {
+	case certprotos.CAState_ACTIVE:
+		return &protos.Void{}, nil
+	case certprotos.CAState_RETIRED:
+		return nil, status.Errorf(codes.FailedPrecondition, "CA %s is retired", sn)
+	}
+	record.State = certprotos.CAState_ACTIVE
+	glog.Infof("Activating %s CA %s", req.CertType, sn)
+	return &protos.Void{}, srv.putCA(record)
+}
+
+// RetireCA removes a CA from the trusted CAs of its cert type. Certificates
+// signed by the CA fail verification once it's retired, so it should only be
+// retired once they've been renewed by a newer CA.
+func (srv *CertifierServer) RetireCA(ctx context.Context, req *certprotos.CARequest) (*protos.Void, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid retire CA request")
+	}
+	sn := normalizeSN(req.Sn)
+	record, err := srv.getCA(req.CertType, sn)
+	if status.Code(err) == codes.NotFound && srv.isFileCA(req.CertType, sn) {
+		// Keep a record of the retirement, the CA is still loaded from its
+		// files on restart
+		record = &certprotos.CertificateAuthority{
+			CertType: req.CertType,
+			Sn:       sn,
+			CertDer:  srv.CAs[req.CertType].Cert.Raw,
+			State:    certprotos.CAState_ACTIVE,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if record.State == certprotos.CAState_RETIRED {
+		return &protos.Void{}, nil
+	}
+
+	cas, err := srv.listCAs(req.CertType)
+	if err != nil {
+		return nil, err
+	}
+	otherActive := false
+	for _, ca := range cas {
+		if ca.Sn != sn && ca.State == certprotos.CAState_ACTIVE {
+			otherActive = true
+			break
+		}
+	}
+	if !otherActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "Can't retire CA %s, it's the last active %s CA", sn, req.CertType)
+	}
+
+	record.State = certprotos.CAState_RETIRED
+	record.KeyFile = ""
+	glog.Infof("Retiring %s CA %s", req.CertType, sn)
+	return &protos.Void{}, srv.putCA(record)
+}
+
+// ListCAs returns the CAs of the cert type, including the CA loaded from
+// files
+func (srv *CertifierServer) ListCAs(ctx context.Context, req *certprotos.ListCAsRequest) (*certprotos.CertificateAuthorities, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid list CAs request")
+	}
+	cas, err := srv.listCAs(req.CertType)
+	if err != nil {
+		return nil, err
+	}
+	return &certprotos.CertificateAuthorities{Cas: cas}, nil
+}
+
+// getTrustedCAs returns the staged and active CAs of the cert type
+func (srv *CertifierServer) getTrustedCAs(certType protos.CertType) ([]*CAInfo, error) {
+	set, err := srv.getCASet(certType)
+	if err != nil {
+		return nil, err
+	}
+	return set.trusted, nil
+}
+
+// getSigningCA returns the CA which signs new certificates of the cert type
+func (srv *CertifierServer) getSigningCA(certType protos.CertType) (*CAInfo, error) {
+	set, err := srv.getCASet(certType)
+	if err != nil {
+		return nil, err
+	}
+	return set.signing, nil
+}
+
+func (srv *CertifierServer) getCASet(certType protos.CertType) (*caSet, error) {
+	sets, err := srv.getCASets()
+	if err != nil {
+		return nil, err
+	}
+	set, ok := sets[certType]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "No CA found for given cert type: %s", certType.String())
+	}
+	return set, nil
+}
+
+// getCASets returns the CA sets of all cert types with an active CA
+func (srv *CertifierServer) getCASets() (map[protos.CertType]*caSet, error) {
+	srv.caLock.Lock()
+	defer srv.caLock.Unlock()
+	now := clock.Now()
+	if srv.caSets == nil || !now.Before(srv.caSets.loadedAt.Add(CARefreshInterval)) {
+		sets, err := srv.loadCASets()
+		if err != nil {
+			return nil, err
+		}
+		srv.caSets = &loadedCASets{sets: sets, loadedAt: now}
+	}
+	return srv.caSets.sets, nil
+}
+
+// loadCASets builds the CA sets of all cert types from the CAs loaded from
+// files and the stored CAs
+func (srv *CertifierServer) loadCASets() (map[protos.CertType]*caSet, error) {
+	records, err := srv.listStoredCAs()
+	if err != nil {
+		return nil, err
+	}
+	retired := map[string]bool{}
+	trusted := map[protos.CertType][]*CAInfo{}
+	active := map[protos.CertType][]*CAInfo{}
+	for _, record := range records {
+		if record.State == certprotos.CAState_RETIRED {
+			retired[caKey(record.CertType, record.Sn)] = true
+			continue
+		}
+		ca, err := srv.loadCA(record.CertDer, record.KeyFile)
+		if err != nil {
+			glog.Errorf("Failed to load %s CA %s: %s", record.CertType, record.Sn, err)
+			continue
+		}
+		trusted[record.CertType] = append(trusted[record.CertType], ca)
+		if record.State == certprotos.CAState_ACTIVE {
+			active[record.CertType] = append(active[record.CertType], ca)
+		}
+	}
+	for certType, ca := range srv.CAs {
+		if retired[caKey(certType, cert.SerialToString(ca.Cert.SerialNumber))] {
+			continue
+		}
+		trusted[certType] = append(trusted[certType], ca)
+		active[certType] = append(active[certType], ca)
+	}
+
+	sets := map[protos.CertType]*caSet{}
+	for certType, cas := range active {
+		sortNewestFirst(cas)
+		sortNewestFirst(trusted[certType])
+		sets[certType] = &caSet{trusted: trusted[certType], signing: cas[0]}
+	}
+	return sets, nil
+}
+
+// invalidateCAs makes the next call reload the CAs, and regenerates the CRLs
+// so they're signed by the current signing CAs
+func (srv *CertifierServer) invalidateCAs(certType protos.CertType) {
+	srv.caLock.Lock()
+	srv.caSets = nil
+	srv.caLock.Unlock()
+	srv.invalidateCRL(certType)
+}
+
+// listCAs returns the stored CAs of the cert type along with the CA loaded
+// from files
+func (srv *CertifierServer) listCAs(certType protos.CertType) ([]*certprotos.CertificateAuthority, error) {
+	records, err := srv.listStoredCAs()
+	if err != nil {
+		return nil, err
+	}
+	var ret []*certprotos.CertificateAuthority
+	fileCA, hasFileCA := srv.CAs[certType]
+	fileCASN := ""
+	if hasFileCA {
+		fileCASN = cert.SerialToString(fileCA.Cert.SerialNumber)
+	}
+	for _, record := range records {
+		if record.CertType != certType {
+			continue
+		}
+		if record.Sn == fileCASN {
+			hasFileCA = false
+		}
+		ret = append(ret, record)
+	}
+	if hasFileCA {
+		ret = append(ret, &certprotos.CertificateAuthority{
+			CertType: certType,
+			Sn:       fileCASN,
+			CertDer:  fileCA.Cert.Raw,
+			State:    certprotos.CAState_ACTIVE,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Sn < ret[j].Sn })
+	return ret, nil
+}
+
+func (srv *CertifierServer) listStoredCAs() ([]*certprotos.CertificateAuthority, error) {
+	keys, err := srv.store.ListKeys(CERTIFICATE_AUTHORITY_TABLE)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list CAs: %s", err)
+	}
+	values, err := srv.store.GetMany(CERTIFICATE_AUTHORITY_TABLE, keys)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load CAs: %s", err)
+	}
+	ret := make([]*certprotos.CertificateAuthority, 0, len(values))
+	for k, val := range values {
+		record := &certprotos.CertificateAuthority{}
+		if err = proto.Unmarshal(val.Value, record); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to unmarshal CA %s: %s", k, err)
+		}
+		ret = append(ret, record)
+	}
+	return ret, nil
+}
+
+func (srv *CertifierServer) getCA(certType protos.CertType, sn string) (*certprotos.CertificateAuthority, error) {
+	marshaledRecord, _, err := srv.store.Get(CERTIFICATE_AUTHORITY_TABLE, caKey(certType, sn))
+	if err != nil && datastore.IsErrNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "Cannot find %s CA with SN: %s", certType, sn)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load CA: %s", err)
+	}
+	record := &certprotos.CertificateAuthority{}
+	if err = proto.Unmarshal(marshaledRecord, record); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to unmarshal CA: %s", err)
+	}
+	return record, nil
+}
+
+func (srv *CertifierServer) putCA(record *certprotos.CertificateAuthority) error {
+	record.StateChangedAt, _ = ptypes.TimestampProto(clock.Now())
+	marshaledRecord, err := proto.Marshal(record)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Marshalling error in CertificateAuthority: %s", err)
+	}
+	err = srv.store.Put(CERTIFICATE_AUTHORITY_TABLE, caKey(record.CertType, record.Sn), marshaledRecord)
+	if err != nil {
+		return status.Errorf(codes.Aborted, "Failed to store CA: %s", err)
+	}
+	srv.invalidateCAs(record.CertType)
+	return nil
+}
+
+func (srv *CertifierServer) isFileCA(certType protos.CertType, sn string) bool {
+	ca, ok := srv.CAs[certType]
+	return ok && cert.SerialToString(ca.Cert.SerialNumber) == sn
+}
+
+// loadCA parses a CA certificate and loads its private key from the key file
+// in the CA key directory
+func (srv *CertifierServer) loadCA(certDER []byte, keyFile string) (*CAInfo, error) {
+	if len(srv.CAKeyDir) == 0 {
+		return nil, fmt.Errorf("no CA key directory configured")
+	}
+	keyPEM, err := ioutil.ReadFile(filepath.Join(srv.CAKeyDir, keyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %s", err)
+	}
+	return parseCA(certDER, keyPEM)
+}
+
+// parseCA parses a CA certificate and its PEM encoded private key, and checks
+// the key matches the certificate and the certificate can sign certificates
+func parseCA(certDER, keyPEM []byte) (*CAInfo, error) {
+	caCert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %s", err)
+	}
+	if !caCert.IsCA || caCert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, fmt.Errorf("certificate %s isn't a CA", cert.SerialToString(caCert.SerialNumber))
+	}
+	if clock.Now().After(caCert.NotAfter) {
+		return nil, fmt.Errorf("certificate %s expired", cert.SerialToString(caCert.SerialNumber))
+	}
+	// X509KeyPair parses PKCS #1, PKCS #8 and EC private keys, and checks the
+	// key matches the certificate
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key for certificate %s: %s", cert.SerialToString(caCert.SerialNumber), err)
+	}
+	return &CAInfo{Cert: caCert, PrivKey: keyPair.PrivateKey}, nil
+}
+
+// isValidKeyFileName checks the key file name doesn't refer to a file outside
+// of the CA key directory
+func isValidKeyFileName(name string) bool {
+	return len(name) > 0 && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+func sortNewestFirst(cas []*CAInfo) {
+	sort.Slice(cas, func(i, j int) bool {
+		if !cas[i].Cert.NotBefore.Equal(cas[j].Cert.NotBefore) {
+			return cas[i].Cert.NotBefore.After(cas[j].Cert.NotBefore)
+		}
+		return cert.SerialToString(cas[i].Cert.SerialNumber) < cert.SerialToString(cas[j].Cert.SerialNumber)
+	})
+}
+
+func caKey(certType protos.CertType, sn string) string {
+	return fmt.Sprintf("%s/%s", certType, sn)
+}
+
+func normalizeSN(sn string) string {
+	return strings.ToUpper(strings.TrimLeft(sn, "0"))
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCertifier_CARotation(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	// The next CA is newer than the current one
+	now := time.Now()
+	clock.SetAndFreezeClock(t, now.Add(-2*time.Hour))
+	currentCA, currentKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24 * 10)
+	require.NoError(t, err)
+	clock.SetAndFreezeClock(t, now.Add(-time.Hour))
+	nextCA, nextKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24 * 10)
+	require.NoError(t, err)
+	clock.UnfreezeClock(t)
+	currentSN := cert.SerialToString(currentCA.SerialNumber)
+	nextSN := cert.SerialToString(nextCA.SerialNumber)
+
+	fileCAs := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: currentCA, PrivKey: currentKey},
+	}
+	keyDir, err := ioutil.TempDir("", "ca_keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(keyDir)
+	writeKeyFile(t, keyDir, "current.key", currentKey)
+	writeKeyFile(t, keyDir, "next.key", nextKey)
+	srv, err := servicers.NewCertifierServer(ds, fileCAs)
+	require.NoError(t, err)
+	srv.CAKeyDir = keyDir
+	assertCA(t, srv, currentCA, currentCA)
+	currentSigned := signCert(t, srv)
+	assert.NoError(t, currentSigned.CheckSignatureFrom(currentCA))
+
+	// Staged CAs are in the bundle, but don't sign
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_DEFAULT, CertDer: nextCA.Raw, KeyFile: "next.key"})
+	assert.NoError(t, err)
+	assertCA(t, srv, currentCA, nextCA, currentCA)
+	assert.NoError(t, signCert(t, srv).CheckSignatureFrom(currentCA))
+
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_DEFAULT, CertDer: nextCA.Raw, KeyFile: "next.key"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_VPN, CertDer: nextCA.Raw, KeyFile: "current.key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_VPN, CertDer: currentSigned.Raw, KeyFile: "next.key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_VPN, CertDer: nextCA.Raw, KeyFile: "missing.key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Key files must be in the CA key directory
+	subDir := filepath.Join(keyDir, "sub")
+	require.NoError(t, os.Mkdir(subDir, 0700))
+	writeKeyFile(t, subDir, "next.key", nextKey)
+	otherSrv, err := servicers.NewCertifierServer(ds, fileCAs)
+	require.NoError(t, err)
+	otherSrv.CAKeyDir = subDir
+	_, err = otherSrv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_VPN, CertDer: nextCA.Raw, KeyFile: "../next.key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.StageCA(ctx, &certprotos.StageCARequest{CertType: protos.CertType_VPN, CertDer: nextCA.Raw, KeyFile: "sub/next.key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The newest active CA signs, certificates of both CAs are trusted
+	_, err = srv.ActivateCA(ctx, &certprotos.CARequest{CertType: protos.CertType_DEFAULT, Sn: "0" + nextSN})
+	assert.NoError(t, err)
+	assertCA(t, srv, nextCA, nextCA, currentCA)
+	nextSigned := signCert(t, srv)
+	assert.NoError(t, nextSigned.CheckSignatureFrom(nextCA))
+	_, err = srv.AddCertificate(ctx, &certprotos.AddCertRequest{CertDer: currentSigned.Raw})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Each CA has its own CRL, listing the certificates it issued
+	_, err = srv.RevokeCertificate(ctx, &protos.Certificate_SN{Sn: cert.SerialToString(currentSigned.SerialNumber)})
+	require.NoError(t, err)
+	_, err = srv.RevokeCertificate(ctx, &protos.Certificate_SN{Sn: cert.SerialToString(nextSigned.SerialNumber)})
+	require.NoError(t, err)
+	crl := getCRL(t, srv, nextCA)
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+	assert.Equal(t, 0, nextSigned.SerialNumber.Cmp(crl.TBSCertList.RevokedCertificates[0].SerialNumber))
+	crl = getCAsCRL(t, srv, currentCA)
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+	assert.Equal(t, 0, currentSigned.SerialNumber.Cmp(crl.TBSCertList.RevokedCertificates[0].SerialNumber))
+	_, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT, CaSn: "42"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// OCSP responses are signed by the issuing CA
+	assert.Equal(t, ocsp.Revoked, getOCSPResponse(t, srv, currentSigned, currentCA).Status)
+	assert.Equal(t, ocsp.Revoked, getOCSPResponse(t, srv, nextSigned, nextCA).Status)
+
+	// Other instances load the rotated CAs, with the keys in their CA key
+	// directory
+	otherSrv, err = servicers.NewCertifierServer(ds, fileCAs)
+	require.NoError(t, err)
+	otherSrv.CAKeyDir = keyDir
+	assertCA(t, otherSrv, nextCA, nextCA, currentCA)
+	assert.NoError(t, signCert(t, otherSrv).CheckSignatureFrom(nextCA))
+
+	// Certificates of retired CAs aren't trusted
+	_, err = srv.RetireCA(ctx, &certprotos.CARequest{CertType: protos.CertType_DEFAULT, Sn: currentSN})
+	assert.NoError(t, err)
+	assertCA(t, srv, nextCA, nextCA)
+	_, err = srv.AddCertificate(ctx, &certprotos.AddCertRequest{CertDer: currentSigned.Raw})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT, CaSn: currentSN})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = srv.ActivateCA(ctx, &certprotos.CARequest{CertType: protos.CertType_DEFAULT, Sn: currentSN})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The last active CA can't be retired
+	_, err = srv.RetireCA(ctx, &certprotos.CARequest{CertType: protos.CertType_DEFAULT, Sn: nextSN})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = srv.ActivateCA(ctx, &certprotos.CARequest{CertType: protos.CertType_DEFAULT, Sn: "42"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cas, err := srv.ListCAs(ctx, &certprotos.ListCAsRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	require.Len(t, cas.Cas, 2)
+	states := map[string]certprotos.CAState{}
+	keyFiles := map[string]string{}
+	for _, ca := range cas.Cas {
+		states[ca.Sn] = ca.State
+		keyFiles[ca.Sn] = ca.KeyFile
+	}
+	assert.Equal(t, map[string]certprotos.CAState{
+		currentSN: certprotos.CAState_RETIRED,
+		nextSN:    certprotos.CAState_ACTIVE,
+	}, states)
+	assert.Equal(t, map[string]string{currentSN: "", nextSN: "next.key"}, keyFiles)
+
+	// The retirement of the file CA persists across restarts
+	otherSrv, err = servicers.NewCertifierServer(ds, fileCAs)
+	require.NoError(t, err)
+	otherSrv.CAKeyDir = keyDir
+	assertCA(t, otherSrv, nextCA, nextCA)
+}
+
+// assertCA checks the signing CA and the CA bundle returned by GetCA
+func assertCA(t *testing.T, srv *servicers.CertifierServer, signing *x509.Certificate, bundle ...*x509.Certificate) {
+	caMsg, err := srv.GetCA(context.Background(), &certprotos.GetCARequest{CertType: protos.CertType_DEFAULT})
+	require.NoError(t, err)
+	assert.Equal(t, signing.Raw, caMsg.Cert)
+	var expectedBundle [][]byte
+	for _, ca := range bundle {
+		expectedBundle = append(expectedBundle, ca.Raw)
+	}
+	assert.Equal(t, expectedBundle, caMsg.Bundle)
+}
+
+// getCAsCRL returns the CRL of the given CA, which isn't necessarily the
+// signing CA
+func getCAsCRL(t *testing.T, srv *servicers.CertifierServer, caCert *x509.Certificate) *pkix.CertificateList {
+	crlMsg, err := srv.GetCRL(context.Background(), &certprotos.GetCRLRequest{
+		CertType: protos.CertType_DEFAULT,
+		CaSn:     cert.SerialToString(caCert.SerialNumber),
+	})
+	require.NoError(t, err)
+	crl, err := x509.ParseCRL(crlMsg.CrlDer)
+	require.NoError(t, err)
+	require.NoError(t, caCert.CheckCRLSignature(crl))
+	return crl
+}
+
+func writeKeyFile(t *testing.T, dir, name string, key interface{}) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), keyPEM, 0600))
+}
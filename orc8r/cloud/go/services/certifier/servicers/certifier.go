@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"magma/orc8r/cloud/go/clock"
@@ -47,13 +48,26 @@ type CAInfo struct {
 }
 
 type CertifierServer struct {
-	store datastore.Api
+	store datastore.TxApi
 	CAs   map[protos.CertType]*CAInfo
+	// CAKeyDir is the directory holding the private key files of the CAs
+	// staged through StageCA
+	CAKeyDir string
+
+	// caSets caches the trusted and signing CAs of each cert type, see
+	// ca_rotation.go
+	caLock sync.Mutex
+	caSets *loadedCASets
+
+	// crls caches the last CRL generated for each CA, by cert type and CA SN
+	crlLock sync.Mutex
+	crls    map[protos.CertType]map[string]*generatedCRL
 }
 
-func NewCertifierServer(store datastore.Api, CAs map[protos.CertType]*CAInfo) (srv *CertifierServer, err error) {
+func NewCertifierServer(store datastore.TxApi, CAs map[protos.CertType]*CAInfo) (srv *CertifierServer, err error) {
 	srv = new(CertifierServer)
 	srv.store = store
+	srv.crls = map[protos.CertType]map[string]*generatedCRL{}
 	if CAs == nil {
 		return nil, fmt.Errorf("CA info not provided to certifier")
 	}
@@ -99,14 +113,11 @@ func (srv *CertifierServer) signCSR(
 	sn *big.Int,
 	certType protos.CertType,
 	validTime time.Duration,
-) ([]byte, time.Time, time.Time, error) {
+) ([]byte, *CAInfo, time.Time, time.Time, error) {
 
-	if srv.CAs == nil {
-		return nil, time.Time{}, time.Time{}, fmt.Errorf("CAInfo not found")
-	}
-	ca, ok := srv.CAs[certType]
-	if !ok {
-		return nil, time.Time{}, time.Time{}, fmt.Errorf("No CA found for given cert type: %s", certType.String())
+	ca, err := srv.getSigningCA(certType)
+	if err != nil {
+		return nil, nil, time.Time{}, time.Time{}, err
 	}
 	signingCert := ca.Cert
 	signingKey := ca.PrivKey
@@ -132,10 +143,10 @@ func (srv *CertifierServer) signCSR(
 	clientCertDER, err := x509.CreateCertificate(
 		rand.Reader, &template, signingCert, csr.PublicKey, signingKey)
 	if err != nil {
-		return nil, time.Time{}, time.Time{}, fmt.Errorf("Failed to sign csr: %s", err)
+		return nil, nil, time.Time{}, time.Time{}, fmt.Errorf("Failed to sign csr: %s", err)
 	}
 
-	return clientCertDER, notBefore, notAfter, nil
+	return clientCertDER, ca, notBefore, notAfter, nil
 }
 
 func checkOrOverwriteCN(csr *x509.CertificateRequest, csrMsg *protos.CSR) error {
@@ -165,8 +176,12 @@ func checkOrOverwriteCN(csr *x509.CertificateRequest, csrMsg *protos.CSR) error
 }
 
 func (srv *CertifierServer) getCertInfo(sn string) (*certprotos.CertificateInfo, error) {
+	return loadCertInfo(srv.store, sn)
+}
+
+func loadCertInfo(store datastore.Api, sn string) (*certprotos.CertificateInfo, error) {
 	certInfo := &certprotos.CertificateInfo{}
-	marshalledCertInfo, _, err := srv.store.Get(CERTIFICATE_INFO_TABLE, sn)
+	marshalledCertInfo, _, err := store.Get(CERTIFICATE_INFO_TABLE, sn)
 	if err != nil {
 		return certInfo, status.Errorf(
 			codes.NotFound, "Failed to load certificate: %s", err)
@@ -179,29 +194,31 @@ func (srv *CertifierServer) getCertInfo(sn string) (*certprotos.CertificateInfo,
 	return certInfo, err
 }
 
-// Verify that the certificate is signed by our CA
-func (srv *CertifierServer) verifyCert(clientCert *x509.Certificate, certType protos.CertType) error {
+// Verify that the certificate is signed by one of our trusted CAs, and return
+// the SN of the CA
+func (srv *CertifierServer) verifyCert(clientCert *x509.Certificate, certType protos.CertType) (string, error) {
 	// Check if CAInfo / cert exists for requested cert type
-	if srv.CAs == nil {
-		return fmt.Errorf("CAInfo not found")
-	}
-	ca, ok := srv.CAs[certType]
-	if !ok {
-		return fmt.Errorf("No CA found for given cert type: %s", certType.String())
+	cas, err := srv.getTrustedCAs(certType)
+	if err != nil {
+		return "", err
 	}
 
 	caPool := x509.NewCertPool()
-	caPool.AddCert(ca.Cert) // Use appropriate cert to check against
+	for _, ca := range cas {
+		caPool.AddCert(ca.Cert) // Use appropriate cert to check against
+	}
 	opts := x509.VerifyOptions{
 		Roots:         caPool,
 		Intermediates: x509.NewCertPool(),
 		// Make sure client cert has ExtKeyUsageClientAuth
 		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
-	if _, err := clientCert.Verify(opts); err != nil {
-		return fmt.Errorf("Certificate Verification Failure: %s", err)
+	chains, err := clientCert.Verify(opts)
+	if err != nil {
+		return "", fmt.Errorf("Certificate Verification Failure: %s", err)
 	}
-	return nil
+	// Trusted CAs are roots, so they directly follow the certificate
+	return cert.SerialToString(chains[0][1].SerialNumber), nil
 }
 
 func (srv *CertifierServer) GetCA(ctx context.Context, getCAReqMsg *certprotos.GetCARequest) (*protos.CACert, error) {
@@ -209,12 +226,15 @@ func (srv *CertifierServer) GetCA(ctx context.Context, getCAReqMsg *certprotos.G
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid CA request")
 	}
 
-	ca, ok := srv.CAs[getCAReqMsg.CertType]
-	if !ok {
-		return nil, fmt.Errorf("no CA found for given CA type: %s", getCAReqMsg.CertType.String())
+	set, err := srv.getCASet(getCAReqMsg.CertType)
+	if err != nil {
+		return nil, err
 	}
 
-	caCertMsg := &protos.CACert{Cert: ca.Cert.Raw}
+	caCertMsg := &protos.CACert{Cert: set.signing.Cert.Raw}
+	for _, ca := range set.trusted {
+		caCertMsg.Bundle = append(caCertMsg.Bundle, ca.Cert.Raw)
+	}
 
 	return caCertMsg, nil
 }
@@ -241,7 +261,7 @@ func (srv *CertifierServer) SignAddCertificate(ctx context.Context, csrMsg *prot
 		return nil, status.Errorf(codes.Aborted, "Invalid requested certificate duration: %s", err)
 	}
 
-	certDER, notBefore, notAfter, err := srv.signCSR(csr, sn, csrMsg.CertType, validTime)
+	certDER, ca, notBefore, notAfter, err := srv.signCSR(csr, sn, csrMsg.CertType, validTime)
 	if err != nil {
 		return nil, status.Errorf(codes.Aborted, "Error signing CSR: %s", err)
 	}
@@ -255,6 +275,7 @@ func (srv *CertifierServer) SignAddCertificate(ctx context.Context, csrMsg *prot
 		CertType:  csrMsg.CertType,
 		NotBefore: notBeforeProto,
 		NotAfter:  notAfterProto,
+		IssuerSn:  cert.SerialToString(ca.Cert.SerialNumber),
 	}
 	// marshal
 	marshaledCertInfo, err := proto.Marshal(&certInfo)
@@ -314,17 +335,24 @@ func (srv *CertifierServer) RevokeCertificate(
 
 	var certSN string
 	if snMsg != nil {
-		certSN = strings.TrimLeft(snMsg.Sn, "0")
+		certSN = snMsg.Sn
 	}
-	_, _, err := srv.store.Get(CERTIFICATE_INFO_TABLE, certSN)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "Cannot find certificate with SN: %s", certSN)
+	return srv.revokeCertificate(certSN, certprotos.RevocationReason_UNSPECIFIED)
+}
+
+// RevokeCertificateWithReason deletes the certificate record and keeps a
+// revocation record with the reason, which is published in the CRL and OCSP
+// responses of the certificate's CA until the certificate expires
+func (srv *CertifierServer) RevokeCertificateWithReason(
+	ctx context.Context, req *certprotos.RevokeCertificateRequest) (*protos.Void, error) {
+
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid revocation request")
 	}
-	err = srv.store.Delete(CERTIFICATE_INFO_TABLE, certSN)
-	if err != nil {
-		return nil, status.Errorf(codes.Aborted, "Failed to delete certificate: %s", err)
+	if _, ok := certprotos.RevocationReason_name[int32(req.Reason)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid revocation reason: %d", req.Reason)
 	}
-	return &protos.Void{}, nil
+	return srv.revokeCertificate(req.Sn, req.Reason)
 }
 
 func (srv *CertifierServer) AddCertificate(ctx context.Context, req *certprotos.AddCertRequest) (*protos.Void, error) {
@@ -340,7 +368,8 @@ func (srv *CertifierServer) AddCertificate(ctx context.Context, req *certprotos.
 	}
 	snStr := cert.SerialToString(x509Cert.SerialNumber)
 	// Verify that the certificate is signed by our CA
-	if err = srv.verifyCert(x509Cert, req.CertType); err != nil {
+	issuerSN, err := srv.verifyCert(x509Cert, req.CertType)
+	if err != nil {
 		return res, status.Errorf(
 			codes.InvalidArgument, "%s for Certificate SN %s", err, snStr)
 	}
@@ -358,6 +387,7 @@ func (srv *CertifierServer) AddCertificate(ctx context.Context, req *certprotos.
 		CertType:  req.CertType,
 		NotBefore: notBeforeProto,
 		NotAfter:  notAfterProto,
+		IssuerSn:  issuerSN,
 	}
 	// marshal
 	marshaledCertInfo, err := proto.Marshal(&certInfo)
@@ -468,6 +498,12 @@ func (srv *CertifierServer) CollectGarbage(ctx context.Context, void *protos.Voi
 	if count > 0 {
 		glog.V(2).Infof("Removed %d stale certificates", count)
 	}
+	if err = srv.collectRevokedCertificates(); err != nil {
+		errorList = append(errorList, struct {
+			sn  string
+			err error
+		}{"revoked certificates", err})
+	}
 	if len(errorList) > 0 {
 		msg := "Failed to delete certificate[s]:"
 		for _, e := range errorList {
@@ -0,0 +1,379 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// CRLValidity is the time between the thisUpdate and nextUpdate of a
+	// generated CRL
+	CRLValidity = 24 * time.Hour
+	// CRLRefreshInterval is how long a generated CRL is served before it's
+	// regenerated. Revocations through this certifier instance regenerate
+	// the CRL of the CA immediately, other instances pick them up within
+	// this interval.
+	CRLRefreshInterval = time.Minute
+	// OCSPValidity is the time between the thisUpdate and nextUpdate of an
+	// OCSP response
+	OCSPValidity = time.Hour
+)
+
+type generatedCRL struct {
+	der         []byte
+	generatedAt time.Time
+}
+
+// GetCRL returns the current CRL of the requested CA, signed by the CA. Each
+// trusted CA has its own CRL, which lists the revoked certificates it issued.
+// The CA certificate must have the cRLSign key usage.
+func (srv *CertifierServer) GetCRL(ctx context.Context, req *certprotos.GetCRLRequest) (*certprotos.CRL, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CRL request")
+	}
+	crlDER, err := srv.getCRL(req.CertType, normalizeSN(req.CaSn))
+	if err != nil {
+		return nil, err
+	}
+	return &certprotos.CRL{CrlDer: crlDER}, nil
+}
+
+// CreateOCSPResponse returns a signed OCSP response to a DER encoded OCSP
+// request, as defined in RFC 6960. The response is signed by the CA which
+// issued the certificate. Malformed requests and requests for certificates
+// of unknown CAs get the corresponding OCSP error responses.
+func (srv *CertifierServer) CreateOCSPResponse(requestDER []byte) ([]byte, error) {
+	req, err := ocsp.ParseRequest(requestDER)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+	certType, ca, err := srv.getOCSPIssuer(req)
+	if err != nil {
+		return nil, err
+	}
+	if ca == nil {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+	signer, ok := ca.PrivKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("private key of CA %s can't sign", certType)
+	}
+
+	now := clock.Now().UTC()
+	template := ocsp.Response{
+		Status:       ocsp.Unknown,
+		SerialNumber: req.SerialNumber,
+		IssuerHash:   req.HashAlgorithm,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(OCSPValidity),
+	}
+	sn := strings.TrimLeft(cert.SerialToString(req.SerialNumber), "0")
+	revoked, err := srv.getRevokedCertificate(sn)
+	if err != nil {
+		return nil, err
+	}
+	caSN := cert.SerialToString(ca.Cert.SerialNumber)
+	if revoked != nil && revoked.CertType == certType && srv.getIssuerSN(certType, revoked.IssuerSn) == caSN {
+		template.Status = ocsp.Revoked
+		template.RevokedAt, _ = ptypes.Timestamp(revoked.RevokedAt)
+		template.RevocationReason = int(revoked.Reason)
+	} else if revoked == nil {
+		certInfo, err := srv.getCertInfo(sn)
+		if err == nil && certInfo.CertType == certType && srv.getIssuerSN(certType, certInfo.IssuerSn) == caSN {
+			template.Status = ocsp.Good
+		}
+	}
+	return ocsp.CreateResponse(ca.Cert, ca.Cert, template, signer)
+}
+
+func (srv *CertifierServer) revokeCertificate(sn string, reason certprotos.RevocationReason) (*protos.Void, error) {
+	sn = strings.TrimLeft(sn, "0")
+	var certType protos.CertType
+	// Add the revocation record and delete the certificate in one
+	// transaction, so a certificate is never deleted without being revoked
+	err := srv.store.DoInTx([]string{CERTIFICATE_INFO_TABLE, REVOKED_CERTIFICATE_TABLE}, func(store datastore.TxApi) error {
+		certInfo, err := loadCertInfo(store, sn)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "Cannot find certificate with SN: %s", sn)
+		}
+		certType = certInfo.CertType
+
+		revoked := &certprotos.RevokedCertificate{
+			Sn:       sn,
+			CertType: certInfo.CertType,
+			Reason:   reason,
+			NotAfter: certInfo.NotAfter,
+			IssuerSn: certInfo.IssuerSn,
+		}
+		revoked.RevokedAt, _ = ptypes.TimestampProto(clock.Now())
+		marshaledRevoked, err := proto.Marshal(revoked)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Marshalling error in RevokedCertificate: %s", err)
+		}
+		err = store.Put(REVOKED_CERTIFICATE_TABLE, sn, marshaledRevoked)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "Failed to add revocation record: %s", err)
+		}
+		err = store.Delete(CERTIFICATE_INFO_TABLE, sn)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "Failed to delete certificate: %s", err)
+		}
+		return nil
+	})
+	if _, ok := status.FromError(err); !ok {
+		return nil, status.Errorf(codes.Aborted, "Failed to revoke certificate: %s", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	srv.invalidateCRL(certType)
+	return &protos.Void{}, nil
+}
+
+// getRevokedCertificate returns the revocation record of the certificate, or
+// nil if the certificate isn't revoked
+func (srv *CertifierServer) getRevokedCertificate(sn string) (*certprotos.RevokedCertificate, error) {
+	marshaledRevoked, _, err := srv.store.Get(REVOKED_CERTIFICATE_TABLE, sn)
+	if err != nil && datastore.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load revocation record: %s", err)
+	}
+	revoked := &certprotos.RevokedCertificate{}
+	if err = proto.Unmarshal(marshaledRevoked, revoked); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to unmarshal revocation record: %s", err)
+	}
+	return revoked, nil
+}
+
+func (srv *CertifierServer) listRevokedCertificates() ([]*certprotos.RevokedCertificate, error) {
+	snList, err := srv.store.ListKeys(REVOKED_CERTIFICATE_TABLE)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list revoked certificates: %s", err)
+	}
+	values, err := srv.store.GetMany(REVOKED_CERTIFICATE_TABLE, snList)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load revoked certificates: %s", err)
+	}
+	ret := make([]*certprotos.RevokedCertificate, 0, len(values))
+	for sn, val := range values {
+		revoked := &certprotos.RevokedCertificate{}
+		if err = proto.Unmarshal(val.Value, revoked); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to unmarshal revocation record %s: %s", sn, err)
+		}
+		ret = append(ret, revoked)
+	}
+	return ret, nil
+}
+
+// collectRevokedCertificates removes the revocation records of certificates
+// which expired more than CollectGarbageAfter ago. Expired certificates
+// don't need to be listed in CRLs.
+func (srv *CertifierServer) collectRevokedCertificates() error {
+	revokedList, err := srv.listRevokedCertificates()
+	if err != nil {
+		return err
+	}
+	now := clock.Now().UTC()
+	for _, revoked := range revokedList {
+		notAfter, _ := ptypes.Timestamp(revoked.NotAfter)
+		if now.After(notAfter.Add(CollectGarbageAfter)) {
+			if err = srv.store.Delete(REVOKED_CERTIFICATE_TABLE, revoked.Sn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getCRL returns the CRL of the trusted CA of the cert type with the given
+// SN, or of the signing CA if the SN is empty
+func (srv *CertifierServer) getCRL(certType protos.CertType, caSN string) ([]byte, error) {
+	set, err := srv.getCASet(certType)
+	if err != nil {
+		return nil, err
+	}
+	ca := set.signing
+	if len(caSN) > 0 {
+		ca = nil
+		for _, trusted := range set.trusted {
+			if cert.SerialToString(trusted.Cert.SerialNumber) == caSN {
+				ca = trusted
+				break
+			}
+		}
+		if ca == nil {
+			return nil, status.Errorf(codes.NotFound, "No trusted %s CA with SN: %s", certType, caSN)
+		}
+	}
+	caSN = cert.SerialToString(ca.Cert.SerialNumber)
+
+	srv.crlLock.Lock()
+	defer srv.crlLock.Unlock()
+	now := clock.Now().UTC()
+	if crl, ok := srv.crls[certType][caSN]; ok && now.Before(crl.generatedAt.Add(CRLRefreshInterval)) {
+		return crl.der, nil
+	}
+	crlDER, err := srv.generateCRL(certType, ca, now)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := srv.crls[certType]; !ok {
+		srv.crls[certType] = map[string]*generatedCRL{}
+	}
+	srv.crls[certType][caSN] = &generatedCRL{der: crlDER, generatedAt: now}
+	return crlDER, nil
+}
+
+// invalidateCRL drops the cached CRLs of all CAs of the cert type
+func (srv *CertifierServer) invalidateCRL(certType protos.CertType) {
+	srv.crlLock.Lock()
+	defer srv.crlLock.Unlock()
+	delete(srv.crls, certType)
+}
+
+// getIssuerSN returns the SN of the CA which issued a certificate, given the
+// issuer SN of its record. Records without an issuer SN predate CA rotation,
+// so their certificates were issued by the CA loaded from files.
+func (srv *CertifierServer) getIssuerSN(certType protos.CertType, recordIssuerSN string) string {
+	if len(recordIssuerSN) > 0 {
+		return recordIssuerSN
+	}
+	if ca, ok := srv.CAs[certType]; ok {
+		return cert.SerialToString(ca.Cert.SerialNumber)
+	}
+	return ""
+}
+
+// generateCRL generates the CRL of a CA, which lists the unexpired revoked
+// certificates issued by the CA
+func (srv *CertifierServer) generateCRL(certType protos.CertType, ca *CAInfo, now time.Time) ([]byte, error) {
+	if ca.Cert.KeyUsage&x509.KeyUsageCRLSign == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "CA for cert type %s doesn't have the cRLSign key usage", certType)
+	}
+	signer, ok := ca.PrivKey.(crypto.Signer)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "Private key of CA for cert type %s can't sign", certType)
+	}
+	revokedList, err := srv.listRevokedCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	caSN := cert.SerialToString(ca.Cert.SerialNumber)
+	var entries []pkix.RevokedCertificate
+	for _, revoked := range revokedList {
+		notAfter, _ := ptypes.Timestamp(revoked.NotAfter)
+		if revoked.CertType != certType || srv.getIssuerSN(certType, revoked.IssuerSn) != caSN || now.After(notAfter) {
+			continue
+		}
+		sn, ok := new(big.Int).SetString(revoked.Sn, 16)
+		if !ok {
+			glog.Errorf("Invalid serial number %s in revocation record", revoked.Sn)
+			continue
+		}
+		revokedAt, _ := ptypes.Timestamp(revoked.RevokedAt)
+		entry := pkix.RevokedCertificate{SerialNumber: sn, RevocationTime: revokedAt}
+		// The reason code extension is omitted for unspecified reasons
+		if revoked.Reason != certprotos.RevocationReason_UNSPECIFIED {
+			reasonExt, err := getReasonCodeExtension(revoked.Reason)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Failed to marshal reason code: %s", err)
+			}
+			entry.Extensions = []pkix.Extension{reasonExt}
+		}
+		entries = append(entries, entry)
+	}
+
+	crlDER, err := ca.Cert.CreateCRL(rand.Reader, signer, entries, now, now.Add(CRLValidity))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to sign CRL: %s", err)
+	}
+	return crlDER, nil
+}
+
+// oidReasonCode is the object identifier of the CRL entry reason code
+// extension, as defined in RFC 5280 section 5.3.1
+var oidReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+func getReasonCodeExtension(reason certprotos.RevocationReason) (pkix.Extension, error) {
+	value, err := asn1.Marshal(asn1.Enumerated(reason))
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{Id: oidReasonCode, Value: value}, nil
+}
+
+// getOCSPIssuer returns the trusted CA whose name and key hashes match the
+// request, or nil if the certificate wasn't issued by any of the CAs
+func (srv *CertifierServer) getOCSPIssuer(req *ocsp.Request) (protos.CertType, *CAInfo, error) {
+	if !req.HashAlgorithm.Available() {
+		return 0, nil, nil
+	}
+	sets, err := srv.getCASets()
+	if err != nil {
+		return 0, nil, err
+	}
+	for certType, set := range sets {
+		for _, ca := range set.trusted {
+			nameHash, keyHash, err := getIssuerHashes(ca.Cert, req.HashAlgorithm)
+			if err != nil {
+				return 0, nil, err
+			}
+			if bytes.Equal(nameHash, req.IssuerNameHash) && bytes.Equal(keyHash, req.IssuerKeyHash) {
+				return certType, ca, nil
+			}
+		}
+	}
+	return 0, nil, nil
+}
+
+// getIssuerHashes returns the hashes of the subject name and the public key
+// of the issuer, as used to identify the issuer in an OCSP CertID
+func getIssuerHashes(issuer *x509.Certificate, hash crypto.Hash) ([]byte, []byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA public key: %s", err)
+	}
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+	h.Reset()
+	h.Write(publicKeyInfo.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+	return nameHash, keyHash, nil
+}
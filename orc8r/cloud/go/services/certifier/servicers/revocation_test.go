@@ -0,0 +1,233 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCertifier_Revocation(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24 * 10)
+	require.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	require.NoError(t, err)
+
+	revokedCert := signCert(t, srv)
+	goodCert := signCert(t, srv)
+	plainRevokedCert := signCert(t, srv)
+
+	// Empty CRL before any revocation
+	crl := getCRL(t, srv, caCert)
+	assert.Empty(t, crl.TBSCertList.RevokedCertificates)
+
+	// Invalid reason
+	_, err = srv.RevokeCertificateWithReason(ctx, &certprotos.RevokeCertificateRequest{
+		Sn:     cert.SerialToString(revokedCert.SerialNumber),
+		Reason: certprotos.RevocationReason(6),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Revoke with and without a reason
+	_, err = srv.RevokeCertificateWithReason(ctx, &certprotos.RevokeCertificateRequest{
+		Sn:     cert.SerialToString(revokedCert.SerialNumber),
+		Reason: certprotos.RevocationReason_KEY_COMPROMISE,
+	})
+	assert.NoError(t, err)
+	_, err = srv.RevokeCertificate(ctx, &protos.Certificate_SN{Sn: cert.SerialToString(plainRevokedCert.SerialNumber)})
+	assert.NoError(t, err)
+
+	// Revoked certificates can't be revoked again
+	_, err = srv.RevokeCertificateWithReason(ctx, &certprotos.RevokeCertificateRequest{
+		Sn:     cert.SerialToString(revokedCert.SerialNumber),
+		Reason: certprotos.RevocationReason_SUPERSEDED,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Revocations are immediately in the CRL
+	crl = getCRL(t, srv, caCert)
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 2)
+	reasons := map[string]int{}
+	for _, entry := range crl.TBSCertList.RevokedCertificates {
+		reasons[cert.SerialToString(entry.SerialNumber)] = getReasonCode(t, entry)
+	}
+	assert.Equal(t, map[string]int{
+		cert.SerialToString(revokedCert.SerialNumber):      int(certprotos.RevocationReason_KEY_COMPROMISE),
+		cert.SerialToString(plainRevokedCert.SerialNumber): int(certprotos.RevocationReason_UNSPECIFIED),
+	}, reasons)
+
+	// Unknown cert type
+	_, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_VPN})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// OCSP
+	resp := getOCSPResponse(t, srv, revokedCert, caCert)
+	assert.Equal(t, ocsp.Revoked, resp.Status)
+	assert.Equal(t, ocsp.KeyCompromise, resp.RevocationReason)
+	assert.Equal(t, 0, revokedCert.SerialNumber.Cmp(resp.SerialNumber))
+
+	resp = getOCSPResponse(t, srv, goodCert, caCert)
+	assert.Equal(t, ocsp.Good, resp.Status)
+
+	unknownCert := *goodCert
+	unknownCert.SerialNumber = big.NewInt(42)
+	resp = getOCSPResponse(t, srv, &unknownCert, caCert)
+	assert.Equal(t, ocsp.Unknown, resp.Status)
+
+	// Requests for certificates of other CAs are unauthorized
+	otherCA, _, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour)
+	require.NoError(t, err)
+	reqDER, err := ocsp.CreateRequest(goodCert, otherCA, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	require.NoError(t, err)
+	respDER, err := srv.CreateOCSPResponse(reqDER)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.UnauthorizedErrorResponse, respDER)
+
+	respDER, err = srv.CreateOCSPResponse([]byte("garbage"))
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.MalformedRequestErrorResponse, respDER)
+
+	// Garbage collection removes revocation records of expired certificates
+	servicers.CollectGarbageAfter = time.Duration(0)
+	defer func() { servicers.CollectGarbageAfter = time.Hour * 24 }()
+	csrMsg, err := certifier_test_utils.CreateCSR(0, "cn", "cn")
+	require.NoError(t, err)
+	certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+	require.NoError(t, err)
+	_, err = srv.RevokeCertificate(ctx, certMsg.Sn)
+	require.NoError(t, err)
+	revokedSNs, err := ds.ListKeys(servicers.REVOKED_CERTIFICATE_TABLE)
+	require.NoError(t, err)
+	assert.Len(t, revokedSNs, 3)
+
+	_, err = srv.CollectGarbage(ctx, &protos.Void{})
+	assert.NoError(t, err)
+	revokedSNs, err = ds.ListKeys(servicers.REVOKED_CERTIFICATE_TABLE)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		cert.SerialToString(revokedCert.SerialNumber),
+		cert.SerialToString(plainRevokedCert.SerialNumber),
+	}, revokedSNs)
+}
+
+func TestCertifier_GetCRLWithoutCRLSign(t *testing.T) {
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour)
+	require.NoError(t, err)
+	caCert.KeyUsage &^= x509.KeyUsageCRLSign
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+	}
+	srv, err := servicers.NewCertifierServer(test_utils.NewMockDatastore(), caMap)
+	require.NoError(t, err)
+
+	_, err = srv.GetCRL(context.Background(), &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+// The revocation record isn't kept if the certificate can't be deleted
+func TestCertifier_RevocationRollback(t *testing.T) {
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour)
+	require.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+	}
+	ds := test_utils.NewMockDatastore()
+	srv, err := servicers.NewCertifierServer(failingDeleteDatastore{ds}, caMap)
+	require.NoError(t, err)
+
+	signedCert := signCert(t, srv)
+	_, err = srv.RevokeCertificate(context.Background(), &protos.Certificate_SN{Sn: cert.SerialToString(signedCert.SerialNumber)})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	revokedSNs, err := ds.ListKeys(servicers.REVOKED_CERTIFICATE_TABLE)
+	require.NoError(t, err)
+	assert.Empty(t, revokedSNs)
+	assert.Equal(t, ocsp.Good, getOCSPResponse(t, srv, signedCert, caCert).Status)
+}
+
+type failingDeleteDatastore struct {
+	*test_utils.MockDatastore
+}
+
+func (d failingDeleteDatastore) Delete(table string, key string) error {
+	return errors.New("delete failed")
+}
+
+func (d failingDeleteDatastore) DoInTx(tables []string, fn func(store datastore.TxApi) error) error {
+	return d.MockDatastore.DoInTx(tables, func(datastore.TxApi) error { return fn(d) })
+}
+
+func signCert(t *testing.T, srv *servicers.CertifierServer) *x509.Certificate {
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	require.NoError(t, err)
+	certMsg, err := srv.SignAddCertificate(context.Background(), csrMsg)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certMsg.CertDer)
+	require.NoError(t, err)
+	return cert
+}
+
+func getCRL(t *testing.T, srv *servicers.CertifierServer, caCert *x509.Certificate) *pkix.CertificateList {
+	crlMsg, err := srv.GetCRL(context.Background(), &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	require.NoError(t, err)
+	crl, err := x509.ParseCRL(crlMsg.CrlDer)
+	require.NoError(t, err)
+	require.NoError(t, caCert.CheckCRLSignature(crl))
+	return crl
+}
+
+// getReasonCode returns the reason code of a CRL entry, 0 (unspecified) if it
+// has no reason code extension
+func getReasonCode(t *testing.T, entry pkix.RevokedCertificate) int {
+	for _, ext := range entry.Extensions {
+		if ext.Id.Equal(asn1.ObjectIdentifier{2, 5, 29, 21}) {
+			var reason asn1.Enumerated
+			_, err := asn1.Unmarshal(ext.Value, &reason)
+			require.NoError(t, err)
+			return int(reason)
+		}
+	}
+	return 0
+}
+
+func getOCSPResponse(t *testing.T, srv *servicers.CertifierServer, leaf, caCert *x509.Certificate) *ocsp.Response {
+	reqDER, err := ocsp.CreateRequest(leaf, caCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	require.NoError(t, err)
+	respDER, err := srv.CreateOCSPResponse(reqDER)
+	require.NoError(t, err)
+	resp, err := ocsp.ParseResponseForCert(respDER, leaf, caCert)
+	require.NoError(t, err)
+	return resp
+}
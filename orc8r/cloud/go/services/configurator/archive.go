@@ -0,0 +1,107 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package configurator
+
+import (
+	"bytes"
+	"encoding/json"
+	"unicode/utf8"
+
+	"magma/orc8r/cloud/go/services/configurator/protos"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+)
+
+// NetworkArchiveFormatVersion is the version of the network archive format
+// produced by ExportNetwork. It must be incremented whenever a change to the
+// archive would prevent an older configurator from importing it correctly.
+const NetworkArchiveFormatVersion = 2
+
+// Format version 1 archived JSON configs as google.protobuf.Values, which
+// don't preserve integers above 2^53
+const minNetworkArchiveFormatVersion = 2
+
+// MarshalNetworkArchive encodes a network archive as indented JSON for
+// storing on disk or returning over HTTP.
+func MarshalNetworkArchive(archive *protos.NetworkArchive) ([]byte, error) {
+	marshaler := jsonpb.Marshaler{OrigName: true}
+	compact := &bytes.Buffer{}
+	if err := marshaler.Marshal(compact, archive); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal network archive")
+	}
+	// jsonpb doesn't indent the JSON configs consistently, so indent the
+	// whole document afterwards
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, compact.Bytes(), "", "  "); err != nil {
+		return nil, errors.Wrap(err, "failed to indent network archive")
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalNetworkArchive decodes a network archive encoded by
+// MarshalNetworkArchive and checks that its format version is supported.
+func UnmarshalNetworkArchive(archiveBytes []byte) (*protos.NetworkArchive, error) {
+	archive := &protos.NetworkArchive{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(archiveBytes), archive); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal network archive")
+	}
+	if err := ValidateNetworkArchive(archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// ValidateNetworkArchive checks that an archive can be imported by this
+// version of configurator. Configs are validated by the configurator
+// service against the registered serdes during import.
+func ValidateNetworkArchive(archive *protos.NetworkArchive) error {
+	if archive == nil || archive.Network == nil {
+		return errors.New("network archive is missing the network")
+	}
+	if archive.FormatVersion < minNetworkArchiveFormatVersion {
+		return errors.Errorf("network archive format version %d is no longer supported, export the network again", archive.FormatVersion)
+	}
+	if archive.FormatVersion > NetworkArchiveFormatVersion {
+		return errors.Errorf("unsupported network archive format version %d, expected at most %d", archive.FormatVersion, NetworkArchiveFormatVersion)
+	}
+	return nil
+}
+
+// NewArchivedConfig wraps a serialized config for archiving. Configs which
+// are serialized as JSON are archived as JSON text, anything else is archived
+// as raw bytes. Either way the serialized config is kept byte for byte.
+// Returns nil for an empty config.
+func NewArchivedConfig(serializedConfig []byte) *protos.ArchivedConfig {
+	if len(serializedConfig) == 0 {
+		return nil
+	}
+	if json.Valid(serializedConfig) && utf8.Valid(serializedConfig) {
+		return &protos.ArchivedConfig{Config: &protos.ArchivedConfig_Json{Json: string(serializedConfig)}}
+	}
+	return &protos.ArchivedConfig{Config: &protos.ArchivedConfig_Raw{Raw: serializedConfig}}
+}
+
+// GetArchivedConfigBytes returns the serialized config wrapped by an
+// archived config, or nil if the config is empty.
+func GetArchivedConfigBytes(config *protos.ArchivedConfig) ([]byte, error) {
+	switch c := config.GetConfig().(type) {
+	case nil:
+		return nil, nil
+	case *protos.ArchivedConfig_Raw:
+		return c.Raw, nil
+	case *protos.ArchivedConfig_Json:
+		if !json.Valid([]byte(c.Json)) {
+			return nil, errors.New("archived JSON config is not valid JSON")
+		}
+		return []byte(c.Json), nil
+	default:
+		return nil, errors.Errorf("unrecognized archived config %T", c)
+	}
+}
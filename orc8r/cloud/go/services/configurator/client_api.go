@@ -11,6 +11,7 @@ package configurator
 import (
 	"context"
 	"fmt"
+	"io"
 
 	merrors "magma/orc8r/cloud/go/errors"
 	commonProtos "magma/orc8r/cloud/go/protos"
@@ -23,8 +24,15 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// defaultLoadPageSize is the page size used by client functions which page
+// through all matching entities to keep each response well below the gRPC
+// message size limit.
+const defaultLoadPageSize = 500
+
 func getNBConfiguratorClient() (protos.NorthboundConfiguratorClient, error) {
 	conn, err := registry.GetConnection(ServiceName)
 	if err != nil {
@@ -275,6 +283,50 @@ func GetNetworkConfigsByType(networkID string, configType string) (interface{},
 	return networks[0].Configs[configType], nil
 }
 
+// ExportNetwork returns a point-in-time archive of the network's
+// configuration, including all of its entities, associations and ACLs.
+// merrors.ErrNotFound is returned if the network doesn't exist.
+func ExportNetwork(networkID string) (*protos.NetworkArchive, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+	archive, err := client.ExportNetwork(context.Background(), &protos.ExportNetworkRequest{NetworkID: networkID})
+	if status.Code(err) == codes.NotFound {
+		return nil, merrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// ImportNetwork creates a network from an archive returned by ExportNetwork.
+// The network is imported under networkID, or under the archived network's
+// ID if networkID is empty. If overwrite is true, an existing network with
+// the same ID is replaced by the archived network.
+// Physical IDs are unique across networks, so if any of the archived physical
+// IDs is registered in another network the import fails with
+// merrors.ErrAlreadyExists, unless dropPhysicalIDs is set to import the
+// entities without their physical IDs.
+func ImportNetwork(archive *protos.NetworkArchive, networkID string, overwrite bool, dropPhysicalIDs bool) error {
+	if err := ValidateNetworkArchive(archive); err != nil {
+		return err
+	}
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.ImportNetwork(
+		context.Background(),
+		&protos.ImportNetworkRequest{Archive: archive, NetworkID: networkID, Overwrite: overwrite, DropPhysicalIds: dropPhysicalIDs},
+	)
+	if status.Code(err) == codes.AlreadyExists {
+		return errors.Wrap(merrors.ErrAlreadyExists, status.Convert(err).Message())
+	}
+	return err
+}
+
 // WriteEntities executes a series of entity writes (creation or update) to be
 // executed in order within a single transaction.
 // This function is all-or-nothing - any failure or error encountered during
@@ -471,13 +523,28 @@ func GetPhysicalIDOfEntity(networkID, entityType, entityKey string) (string, err
 
 // ListEntityKeys returns all keys for an entity type in a network.
 func ListEntityKeys(networkID string, entityType string) ([]string, error) {
-	client, err := getNBConfiguratorClient()
+	ents, _, err := streamEntities(networkID, &storage.EntityLoadFilter{TypeFilter: &wrappers.StringValue{Value: entityType}}, EntityLoadCriteria{})
+	if status.Code(err) == codes.NotFound {
+		return []string{}, merrors.ErrNotFound
+	}
 	if err != nil {
 		return []string{}, err
 	}
+	return funk.Map(ents, func(ent NetworkEntity) string { return ent.Key }).([]string), nil
+}
+
+// ListEntityKeysPage returns a page of at most pageSize keys for an entity
+// type in a network, ordered by key, along with the token to pass to load the
+// next page. The returned token is empty if this is the last page.
+// An empty pageToken loads the first page.
+func ListEntityKeysPage(networkID string, entityType string, pageSize uint32, pageToken string) ([]string, string, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return []string{}, "", err
+	}
 	networkExists, _ := DoesNetworkExist(networkID)
 	if !networkExists {
-		return []string{}, merrors.ErrNotFound
+		return []string{}, "", merrors.ErrNotFound
 	}
 
 	resp, err := client.LoadEntities(
@@ -486,15 +553,17 @@ func ListEntityKeys(networkID string, entityType string) ([]string, error) {
 			NetworkID: networkID,
 			Filter: &storage.EntityLoadFilter{
 				TypeFilter: &wrappers.StringValue{Value: entityType},
+				PageSize:   pageSize,
+				PageToken:  pageToken,
 			},
 			Criteria: EntityLoadCriteria{}.toStorageProto(),
 		},
 	)
 	if err != nil {
-		return []string{}, err
+		return []string{}, "", err
 	}
 
-	return funk.Map(resp.Entities, func(ent *storage.NetworkEntity) string { return ent.Key }).([]string), nil
+	return funk.Map(resp.Entities, func(ent *storage.NetworkEntity) string { return ent.Key }).([]string), resp.NextPageToken, nil
 }
 
 // ListInternalEntityKeys calls ListEntityKeys with the internal networkID
@@ -562,7 +631,9 @@ func GetNetworkAndEntityIDForPhysicalID(physicalID string) (string, string, erro
 
 // LoadEntities loads entities specified by the parameters.
 // typeFilter, keyFilter, physicalID, and ids are all used to define a filter to
-// filter out results - if they are all nil, it will return all network entities
+// filter out results - if they are all nil, it will return all network entities.
+// Entities are loaded in pages from a single consistent snapshot, so that
+// no single response exceeds the gRPC message size limit.
 func LoadEntities(
 	networkID string,
 	typeFilter *string,
@@ -571,37 +642,57 @@ func LoadEntities(
 	ids []storage2.TypeAndKey,
 	criteria EntityLoadCriteria,
 ) (NetworkEntities, []storage2.TypeAndKey, error) {
+	filter := &storage.EntityLoadFilter{
+		TypeFilter: protos.GetStringWrapper(typeFilter),
+		KeyFilter:  protos.GetStringWrapper(keyFilter),
+		PhysicalID: protos.GetStringWrapper(physicalID),
+		IDs:        tksToEntIDs(ids),
+	}
+	ret, notFound, err := streamEntities(networkID, filter, criteria)
+	if status.Code(err) == codes.NotFound {
+		// Nothing can be found in a network which doesn't exist
+		return NetworkEntities{}, ids, nil
+	}
+	return ret, notFound, err
+}
+
+// streamEntities loads all entities matching the filter in pages of
+// defaultLoadPageSize entities. All pages are read from a single consistent
+// snapshot. Returns a NOT_FOUND status error if the network doesn't exist.
+func streamEntities(networkID string, filter *storage.EntityLoadFilter, criteria EntityLoadCriteria) (NetworkEntities, []storage2.TypeAndKey, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
 		return nil, nil, err
 	}
-
-	resp, err := client.LoadEntities(
+	filter.PageSize = defaultLoadPageSize
+	stream, err := client.StreamEntities(
 		context.Background(),
-		&protos.LoadEntitiesRequest{
-			NetworkID: networkID,
-			Filter: &storage.EntityLoadFilter{
-				TypeFilter: protos.GetStringWrapper(typeFilter),
-				KeyFilter:  protos.GetStringWrapper(keyFilter),
-				PhysicalID: protos.GetStringWrapper(physicalID),
-				IDs:        tksToEntIDs(ids),
-			},
-			Criteria: criteria.toStorageProto(),
-		},
+		&protos.LoadEntitiesRequest{NetworkID: networkID, Filter: filter, Criteria: criteria.toStorageProto()},
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ret := make([]NetworkEntity, len(resp.Entities))
-	for i, protoEnt := range resp.Entities {
-		ent, err := ret[i].fromStorageProto(protoEnt)
+	ret := NetworkEntities{}
+	for {
+		page, err := stream.Recv()
+		if err == io.EOF {
+			return ret, nil, errors.New("entity stream ended before the last page")
+		}
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "request succeeded but deserialization failed")
+			return nil, nil, err
+		}
+		for _, protoEnt := range page.Entities {
+			ent, err := NetworkEntity{}.fromStorageProto(protoEnt)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "request succeeded but deserialization failed")
+			}
+			ret = append(ret, ent)
+		}
+		if page.NextPageToken == "" {
+			return ret, entIDsToTKs(page.EntitiesNotFound), nil
 		}
-		ret[i] = ent
 	}
-	return ret, entIDsToTKs(resp.EntitiesNotFound), nil
 }
 
 // LoadInternalEntity calls LoadEntity with the internal networkID
@@ -652,10 +743,25 @@ func DoesInternalEntityExist(entityType, entityKey string) (bool, error) {
 
 // LoadAllEntitiesInNetwork fetches all entities of specified type in a network
 func LoadAllEntitiesInNetwork(networkID string, entityType string, criteria EntityLoadCriteria) ([]NetworkEntity, error) {
-	client, err := getNBConfiguratorClient()
+	ents, _, err := streamEntities(networkID, &storage.EntityLoadFilter{TypeFilter: &wrappers.StringValue{Value: entityType}}, criteria)
+	if status.Code(err) == codes.NotFound {
+		return []NetworkEntity{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
+	return ents, nil
+}
+
+// LoadAllEntitiesInNetworkPage fetches a page of at most pageSize entities of
+// specified type in a network, ordered by key, along with the token to pass to
+// load the next page. The returned token is empty if this is the last page.
+// An empty pageToken loads the first page.
+func LoadAllEntitiesInNetworkPage(networkID string, entityType string, criteria EntityLoadCriteria, pageSize uint32, pageToken string) ([]NetworkEntity, string, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, "", err
+	}
 
 	resp, err := client.LoadEntities(
 		context.Background(),
@@ -663,23 +769,159 @@ func LoadAllEntitiesInNetwork(networkID string, entityType string, criteria Enti
 			NetworkID: networkID,
 			Filter: &storage.EntityLoadFilter{
 				TypeFilter: &wrappers.StringValue{Value: entityType},
+				PageSize:   pageSize,
+				PageToken:  pageToken,
 			},
 			Criteria: criteria.toStorageProto(),
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	ret := make([]NetworkEntity, len(resp.Entities))
 	for i, protoEnt := range resp.Entities {
 		ent, err := ret[i].fromStorageProto(protoEnt)
 		if err != nil {
-			return nil, errors.Wrapf(err, "request succeeded but deserialization failed")
+			return nil, "", errors.Wrapf(err, "request succeeded but deserialization failed")
 		}
 		ret[i] = ent
 	}
-	return ret, nil
+	return ret, resp.NextPageToken, nil
+}
+
+// EntityWatch is an open stream of entity events, as returned by
+// OpenEntityWatch.
+type EntityWatch struct {
+	stream protos.NorthboundConfigurator_WatchEntitiesClient
+}
+
+// Recv blocks until the next event is received.
+func (w *EntityWatch) Recv() (EntityEvent, error) {
+	protoEvent, err := w.stream.Recv()
+	if err != nil {
+		return EntityEvent{}, err
+	}
+	event, err := EntityEvent{}.fromProto(protoEvent)
+	if err != nil {
+		return EntityEvent{}, errors.Wrap(err, "failed to deserialize entity event")
+	}
+	return event, nil
+}
+
+// OpenEntityWatch opens a stream of the changes to entities in a network,
+// optionally filtered to the given entity types. OpenEntityWatch returns once
+// the watch is established: every change committed after it returns will be
+// received. The stream is closed when the context is cancelled.
+// Changes made through any configurator replica are received.
+func OpenEntityWatch(ctx context.Context, networkID string, types []string) (*EntityWatch, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := client.WatchEntities(ctx, &protos.WatchEntitiesRequest{NetworkID: networkID, Types: types})
+	if err != nil {
+		return nil, err
+	}
+	// The service sends the headers once the watch is established
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+	return &EntityWatch{stream: stream}, nil
+}
+
+// WatchEntities streams every change to entities in a network to the
+// callback, optionally filtered to the given entity types. Only changes made
+// after the stream is opened are streamed, see OpenEntityWatch.
+// WatchEntities blocks until the context is cancelled, in which case it
+// returns nil, or until the stream or the callback returns an error.
+func WatchEntities(ctx context.Context, networkID string, types []string, callback func(EntityEvent) error) error {
+	watch, err := OpenEntityWatch(ctx, networkID, types)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	for {
+		event, err := watch.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+}
+
+// NetworkWatch is an open stream of network events, as returned by
+// OpenNetworkWatch.
+type NetworkWatch struct {
+	stream protos.NorthboundConfigurator_WatchNetworksClient
+}
+
+// Recv blocks until the next event is received.
+func (w *NetworkWatch) Recv() (NetworkEvent, error) {
+	protoEvent, err := w.stream.Recv()
+	if err != nil {
+		return NetworkEvent{}, err
+	}
+	event, err := NetworkEvent{}.fromProto(protoEvent)
+	if err != nil {
+		return NetworkEvent{}, errors.Wrap(err, "failed to deserialize network event")
+	}
+	return event, nil
+}
+
+// OpenNetworkWatch opens a stream of the changes to networks, optionally
+// filtered to the given network IDs. OpenNetworkWatch returns once the watch
+// is established: every change committed after it returns will be received.
+// The stream is closed when the context is cancelled.
+// Changes made through any configurator replica are received.
+func OpenNetworkWatch(ctx context.Context, networkIDs []string) (*NetworkWatch, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := client.WatchNetworks(ctx, &protos.WatchNetworksRequest{NetworkIDs: networkIDs})
+	if err != nil {
+		return nil, err
+	}
+	// The service sends the headers once the watch is established
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+	return &NetworkWatch{stream: stream}, nil
+}
+
+// WatchNetworks streams every change to networks to the callback, optionally
+// filtered to the given network IDs. Only changes made after the stream is
+// opened are streamed, see OpenNetworkWatch.
+// WatchNetworks blocks until the context is cancelled, in which case it
+// returns nil, or until the stream or the callback returns an error.
+func WatchNetworks(ctx context.Context, networkIDs []string, callback func(NetworkEvent) error) error {
+	watch, err := OpenNetworkWatch(ctx, networkIDs)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	for {
+		event, err := watch.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
 }
 
 func getSBConfiguratorClient() (protos.SouthboundConfiguratorClient, error) {
@@ -8,16 +8,25 @@ LICENSE file in the root directory of this source tree.
 package configurator_test
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	cfgstorage "magma/orc8r/cloud/go/services/configurator/storage"
 	"magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/storage"
 
 	"github.com/go-openapi/swag"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -263,6 +272,176 @@ func TestConfiguratorService(t *testing.T) {
 	assert.Equal(t, "foobar", entities[0].Name)
 }
 
+func TestConfiguratorService_Pagination(t *testing.T) {
+	test_init.StartTestService(t)
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "paged_network"}))
+	_, err := configurator.CreateEntities("paged_network", []configurator.NetworkEntity{
+		{Type: "paged", Key: "c"},
+		{Type: "paged", Key: "a"},
+		{Type: "paged", Key: "b"},
+		{Type: "other", Key: "a"},
+	})
+	assert.NoError(t, err)
+
+	keys, token, err := configurator.ListEntityKeysPage("paged_network", "paged", 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.NotEmpty(t, token)
+	keys, token, err = configurator.ListEntityKeysPage("paged_network", "paged", 2, token)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, keys)
+	assert.Empty(t, token)
+
+	ents, token, err := configurator.LoadAllEntitiesInNetworkPage("paged_network", "paged", configurator.EntityLoadCriteria{}, 3, "")
+	assert.NoError(t, err)
+	assert.Len(t, ents, 3)
+	assert.Empty(t, token)
+
+	_, _, err = configurator.LoadAllEntitiesInNetworkPage("paged_network", "paged", configurator.EntityLoadCriteria{}, 3, "bad token")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	keys, err = configurator.ListEntityKeys("paged_network", "paged")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	// Full loads span multiple pages of the client's page size
+	bulk := make([]configurator.NetworkEntity, 0, 501)
+	for i := 0; i < 501; i++ {
+		bulk = append(bulk, configurator.NetworkEntity{Type: "bulk", Key: fmt.Sprintf("bulk%03d", i)})
+	}
+	_, err = configurator.CreateEntities("paged_network", bulk)
+	assert.NoError(t, err)
+	keys, err = configurator.ListEntityKeys("paged_network", "bulk")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 501)
+	assert.Equal(t, "bulk500", keys[500])
+	ents, err = configurator.LoadAllEntitiesInNetwork("paged_network", "bulk", configurator.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Len(t, ents, 501)
+	ents, notFound, err := configurator.LoadEntities(
+		"paged_network", nil, nil, nil,
+		[]storage.TypeAndKey{{Type: "bulk", Key: "bulk000"}, {Type: "bulk", Key: "nope"}},
+		configurator.EntityLoadCriteria{},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, ents, 1)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "bulk", Key: "nope"}}, notFound)
+
+	// Missing networks
+	_, err = configurator.ListEntityKeys("missing_network", "paged")
+	assert.Equal(t, merrors.ErrNotFound, err)
+	ents, err = configurator.LoadAllEntitiesInNetwork("missing_network", "paged", configurator.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Empty(t, ents)
+	ents, notFound, err = configurator.LoadEntities(
+		"missing_network", nil, nil, nil,
+		[]storage.TypeAndKey{{Type: "paged", Key: "a"}},
+		configurator.EntityLoadCriteria{},
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, ents)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "paged", Key: "a"}}, notFound)
+}
+
+func TestConfiguratorService_Watch(t *testing.T) {
+	test_init.StartTestService(t)
+	err := serde.RegisterSerdes(
+		&mockSerde{domain: configurator.NetworkConfigSerdeDomain, serdeType: "watched"},
+		&mockSerde{domain: configurator.NetworkEntitySerdeDomain, serdeType: "watched"},
+	)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	entityWatch, err := configurator.OpenEntityWatch(ctx, "watch_network", []string{"watched"})
+	assert.NoError(t, err)
+	networkWatch, err := configurator.OpenNetworkWatch(ctx, []string{"watch_network"})
+	assert.NoError(t, err)
+	entityEvents := make(chan configurator.EntityEvent, 10)
+	networkEvents := make(chan configurator.NetworkEvent, 10)
+	watchDone := make(chan error, 2)
+	go func() {
+		for {
+			event, err := entityWatch.Recv()
+			if err != nil {
+				watchDone <- err
+				return
+			}
+			entityEvents <- event
+		}
+	}()
+	go func() {
+		for {
+			event, err := networkWatch.Recv()
+			if err != nil {
+				watchDone <- err
+				return
+			}
+			networkEvents <- event
+		}
+	}()
+
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "watch_network", Configs: map[string]interface{}{"watched": "a"}}))
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "other_network"}))
+	networkEvent := <-networkEvents
+	assert.Equal(t, configurator.ChangeTypeCreated, networkEvent.Type)
+	assert.Equal(t, "watch_network", networkEvent.Network.ID)
+
+	assert.NoError(t, configurator.UpdateNetworkConfig("watch_network", "watched", "b"))
+	networkEvent = <-networkEvents
+	assert.Equal(t, configurator.ChangeTypeUpdated, networkEvent.Type)
+	assert.Equal(t, "b", networkEvent.Network.Configs["watched"])
+
+	// Entities of other types and in other networks are filtered out
+	_, err = configurator.CreateEntities("watch_network", []configurator.NetworkEntity{
+		{Type: "unwatched", Key: "k1"},
+		{Type: "watched", Key: "k1", Config: "hello"},
+	})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("other_network", configurator.NetworkEntity{Type: "watched", Key: "k1"})
+	assert.NoError(t, err)
+	entityEvent := <-entityEvents
+	assert.Equal(t, configurator.ChangeTypeCreated, entityEvent.Type)
+	assert.Equal(t, "watch_network", entityEvent.NetworkID)
+	assert.Equal(t, "k1", entityEvent.Entity.Key)
+	assert.Equal(t, "hello", entityEvent.Entity.Config)
+
+	assert.NoError(t, configurator.CreateOrUpdateEntityConfig("watch_network", "watched", "k1", "world"))
+	entityEvent = <-entityEvents
+	assert.Equal(t, configurator.ChangeTypeUpdated, entityEvent.Type)
+	assert.Equal(t, "world", entityEvent.Entity.Config)
+	assert.Equal(t, uint64(1), entityEvent.Entity.Version)
+
+	assert.NoError(t, configurator.DeleteEntity("watch_network", "watched", "k1"))
+	entityEvent = <-entityEvents
+	assert.Equal(t, configurator.ChangeTypeDeleted, entityEvent.Type)
+	assert.Equal(t, storage.TypeAndKey{Type: "watched", Key: "k1"}, entityEvent.Entity.GetTypeAndKey())
+
+	// Deleting a network deletes its entities
+	_, err = configurator.CreateEntity("watch_network", configurator.NetworkEntity{Type: "watched", Key: "k2"})
+	assert.NoError(t, err)
+	entityEvent = <-entityEvents
+	assert.Equal(t, configurator.ChangeTypeCreated, entityEvent.Type)
+	assert.NoError(t, configurator.DeleteNetwork("watch_network"))
+	networkEvent = <-networkEvents
+	assert.Equal(t, configurator.ChangeTypeDeleted, networkEvent.Type)
+	assert.Equal(t, "watch_network", networkEvent.Network.ID)
+	entityEvent = <-entityEvents
+	assert.Equal(t, configurator.ChangeTypeDeleted, entityEvent.Type)
+	assert.Equal(t, storage.TypeAndKey{Type: "watched", Key: "k2"}, entityEvent.Entity.GetTypeAndKey())
+
+	assert.Empty(t, entityEvents)
+	assert.Empty(t, networkEvents)
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(<-watchDone))
+	assert.Equal(t, codes.Canceled, status.Code(<-watchDone))
+
+	// WatchEntities returns nil once its context is cancelled
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, configurator.WatchEntities(ctx, "watch_network", nil, func(configurator.EntityEvent) error { return nil }))
+}
+
 func strPointer(str string) *string {
 	return &str
 }
@@ -290,3 +469,167 @@ func (m *mockSerde) Serialize(in interface{}) ([]byte, error) {
 func (m *mockSerde) Deserialize(in []byte) (interface{}, error) {
 	return string(in), nil
 }
+
+func TestConfiguratorService_ExportImport(t *testing.T) {
+	test_init.StartTestService(t)
+	err := serde.RegisterSerdes(
+		&mockSerde{domain: configurator.NetworkConfigSerdeDomain, serdeType: "archived"},
+		&mockSerde{domain: configurator.NetworkEntitySerdeDomain, serdeType: "archived"},
+	)
+	assert.NoError(t, err)
+
+	network := configurator.Network{
+		ID:          "archived_network",
+		Type:        "lte",
+		Name:        "archived",
+		Description: "network to archive",
+		Configs:     map[string]interface{}{"archived": "network config"},
+	}
+	assert.NoError(t, configurator.CreateNetwork(network))
+	_, err = configurator.CreateEntities("archived_network", []configurator.NetworkEntity{
+		{Type: "archived", Key: "child", Config: "child config"},
+		{
+			Type: "archived", Key: "parent",
+			Name: "parent", Description: "parent entity",
+			PhysicalID:   "hw1",
+			Config:       `{"parent":"config"}`,
+			Associations: []storage.TypeAndKey{{Type: "archived", Key: "child"}},
+		},
+	})
+	assert.NoError(t, err)
+	expectedEnts := configurator.NetworkEntities{
+		{NetworkID: "archived_network", Type: "archived", Key: "child", Config: "child config", ParentAssociations: []storage.TypeAndKey{{Type: "archived", Key: "parent"}}},
+		{
+			NetworkID: "archived_network", Type: "archived", Key: "parent",
+			Name: "parent", Description: "parent entity",
+			PhysicalID:   "hw1",
+			Config:       `{"parent":"config"}`,
+			Associations: []storage.TypeAndKey{{Type: "archived", Key: "child"}},
+		},
+	}
+	loadEnts := func(networkID string) configurator.NetworkEntities {
+		ents, err := configurator.LoadAllEntitiesInNetwork(networkID, "archived", configurator.FullEntityLoadCriteria())
+		assert.NoError(t, err)
+		for i := range ents {
+			ents[i].GraphID = ""
+			ents[i].Version = 0
+		}
+		return ents
+	}
+
+	archive, err := configurator.ExportNetwork("archived_network")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(configurator.NetworkArchiveFormatVersion), archive.FormatVersion)
+	assert.Equal(t, "archived_network", archive.Network.Id)
+	assert.Len(t, archive.Entities, 2)
+
+	// JSON configs are archived as JSON text, anything else as raw bytes
+	archiveBytes, err := configurator.MarshalNetworkArchive(archive)
+	assert.NoError(t, err)
+	assert.Contains(t, string(archiveBytes), `"json": "{\"parent\":\"config\"}"`)
+	assert.Contains(t, string(archiveBytes), fmt.Sprintf(`"raw": "%s"`, base64.StdEncoding.EncodeToString([]byte("child config"))))
+	archive, err = configurator.UnmarshalNetworkArchive(archiveBytes)
+	assert.NoError(t, err)
+
+	// Make some bad edits, then roll them back
+	_, err = configurator.UpdateEntity("archived_network", configurator.EntityUpdateCriteria{
+		Type: "archived", Key: "parent",
+		NewConfig:            "bad config",
+		AssociationsToDelete: []storage.TypeAndKey{{Type: "archived", Key: "child"}},
+	})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("archived_network", configurator.NetworkEntity{Type: "archived", Key: "extra"})
+	assert.NoError(t, err)
+
+	err = configurator.ImportNetwork(archive, "", false, false)
+	assert.Error(t, err)
+	err = configurator.ImportNetwork(archive, "", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEnts, loadEnts("archived_network"))
+
+	// Cloning the network conflicts with the physical IDs of the original
+	err = configurator.ImportNetwork(archive, "cloned_network", false, false)
+	assert.Equal(t, merrors.ErrAlreadyExists, errors.Cause(err))
+	assert.EqualError(t, err, "physical IDs are already registered: hw1 (network archived_network); import without physical IDs or remove the existing entities first: Already exists")
+	exists, err := configurator.DoesNetworkExist("cloned_network")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Clone the network under a different ID without the physical IDs
+	err = configurator.ImportNetwork(archive, "cloned_network", false, true)
+	assert.NoError(t, err)
+	clonedNetwork, err := configurator.LoadNetwork("cloned_network", true, true)
+	assert.NoError(t, err)
+	network.ID = "cloned_network"
+	network.Version = clonedNetwork.Version
+	assert.Equal(t, network, clonedNetwork)
+	for i := range expectedEnts {
+		expectedEnts[i].NetworkID = "cloned_network"
+		expectedEnts[i].PhysicalID = ""
+	}
+	assert.Equal(t, expectedEnts, loadEnts("cloned_network"))
+
+	assert.NoError(t, configurator.DeleteNetwork("archived_network"))
+	_, err = configurator.ExportNetwork("archived_network")
+	assert.Equal(t, merrors.ErrNotFound, err)
+
+	// ACLs are archived
+	acl := &cfgstorage.ACL{
+		Scope:      &cfgstorage.ACL_ScopeNetworkIDs{ScopeNetworkIDs: &cfgstorage.ACL_NetworkIDs{IDs: []string{"acl_network"}}},
+		Permission: cfgstorage.ACL_WRITE,
+		Type:       &cfgstorage.ACL_EntityType{EntityType: "archived"},
+		IDFilter:   []string{"child"},
+	}
+	archive.Entities[0].Permissions = []*cfgstorage.ACL{proto.Clone(acl).(*cfgstorage.ACL)}
+	err = configurator.ImportNetwork(archive, "acl_network", false, true)
+	assert.NoError(t, err)
+	aclArchive, err := configurator.ExportNetwork("acl_network")
+	assert.NoError(t, err)
+	assert.Len(t, aclArchive.Entities[0].Permissions, 1)
+	assert.NotEmpty(t, aclArchive.Entities[0].Permissions[0].ID)
+	aclArchive.Entities[0].Permissions[0].ID = ""
+	aclArchive.Entities[0].Permissions[0].Version = 0
+	assert.Equal(t, acl, aclArchive.Entities[0].Permissions[0])
+	assert.Empty(t, aclArchive.Entities[1].Permissions)
+
+	archive.FormatVersion = configurator.NetworkArchiveFormatVersion + 1
+	err = configurator.ImportNetwork(archive, "newer_network", false, false)
+	assert.EqualError(t, err, "unsupported network archive format version 3, expected at most 2")
+	archive.FormatVersion = 1
+	err = configurator.ImportNetwork(archive, "older_network", false, false)
+	assert.EqualError(t, err, "network archive format version 1 is no longer supported, export the network again")
+}
+
+func TestArchivedConfig(t *testing.T) {
+	// Serialized configs are archived byte for byte, including integers
+	// which don't fit in a float64
+	for _, config := range [][]byte{
+		[]byte(`{"id":9007199254740993,"rate":1.50,"nested":{"b":1,"a":[2, 3]}}`),
+		[]byte(`9223372036854775807`),
+		[]byte("not json"),
+		{0xff, 0xfe, '"'},
+	} {
+		archived := configurator.NewArchivedConfig(config)
+		actual, err := configurator.GetArchivedConfigBytes(archived)
+		assert.NoError(t, err)
+		assert.Equal(t, config, actual)
+
+		archive := &protos.NetworkArchive{
+			FormatVersion: configurator.NetworkArchiveFormatVersion,
+			Network:       &protos.ArchivedNetwork{Id: "n1", Configs: map[string]*protos.ArchivedConfig{"config": archived}},
+		}
+		archiveBytes, err := configurator.MarshalNetworkArchive(archive)
+		assert.NoError(t, err)
+		archive, err = configurator.UnmarshalNetworkArchive(archiveBytes)
+		assert.NoError(t, err)
+		actual, err = configurator.GetArchivedConfigBytes(archive.Network.Configs["config"])
+		assert.NoError(t, err)
+		assert.Equal(t, config, actual)
+	}
+	_, isJSON := configurator.NewArchivedConfig([]byte(`9223372036854775807`)).Config.(*protos.ArchivedConfig_Json)
+	assert.True(t, isJSON)
+	assert.Nil(t, configurator.NewArchivedConfig(nil))
+
+	_, err := configurator.GetArchivedConfigBytes(&protos.ArchivedConfig{Config: &protos.ArchivedConfig_Json{Json: "{"}})
+	assert.EqualError(t, err, "archived JSON config is not valid JSON")
+}
@@ -14,6 +14,9 @@ and meta data for the network and network entity structures.
 package main
 
 import (
+	"context"
+	"time"
+
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/service"
@@ -26,6 +29,10 @@ import (
 	"github.com/golang/glog"
 )
 
+// migrationTimeout bounds waiting for the migration lock and applying the
+// migrations
+const migrationTimeout = 10 * time.Minute
+
 func main() {
 	// Create the service
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, configurator.ServiceName)
@@ -43,6 +50,15 @@ func main() {
 		glog.Fatalf("Failed to initialize configurator database: %s", err)
 	}
 
+	// Apply the migrations of the configurator tables registered by the
+	// plugins
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	err = sqorc.MigrateUp(ctx, db, configurator.ServiceName)
+	cancel()
+	if err != nil {
+		glog.Fatalf("Failed to apply migrations: %s", err)
+	}
+
 	nbServicer, err := servicers.NewNorthboundConfiguratorServicer(factory)
 	if err != nil {
 		glog.Fatalf("Failed to instantiate the user-facing configurator servicer: %v", nbServicer)
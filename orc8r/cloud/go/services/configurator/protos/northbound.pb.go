@@ -26,6 +26,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ChangeType int32
+
+const (
+	ChangeType_CREATED ChangeType = 0
+	ChangeType_UPDATED ChangeType = 1
+	ChangeType_DELETED ChangeType = 2
+)
+
+var ChangeType_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "DELETED",
+}
+
+var ChangeType_value = map[string]int32{
+	"CREATED": 0,
+	"UPDATED": 1,
+	"DELETED": 2,
+}
+
+func (x ChangeType) String() string {
+	return proto.EnumName(ChangeType_name, int32(x))
+}
+
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{0}
+}
+
 type ListNetworkIDsResponse struct {
 	NetworkIDs           []string `protobuf:"bytes,1,rep,name=networkIDs,proto3" json:"networkIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -716,7 +744,715 @@ func (m *DeleteEntitiesRequest) GetID() []*storage.EntityID {
 	return nil
 }
 
+type WatchEntitiesRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If non-empty, only events for entities of these types are streamed
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEntitiesRequest) Reset()         { *m = WatchEntitiesRequest{} }
+func (m *WatchEntitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEntitiesRequest) ProtoMessage()    {}
+func (*WatchEntitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{15}
+}
+
+func (m *WatchEntitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEntitiesRequest.Unmarshal(m, b)
+}
+func (m *WatchEntitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEntitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEntitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEntitiesRequest.Merge(m, src)
+}
+func (m *WatchEntitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEntitiesRequest.Size(m)
+}
+func (m *WatchEntitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEntitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEntitiesRequest proto.InternalMessageInfo
+
+func (m *WatchEntitiesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *WatchEntitiesRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type EntityEvent struct {
+	Type      ChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=magma.orc8r.configurator.ChangeType" json:"type,omitempty"`
+	NetworkID string     `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// The entity after the change. For deletions, only the type and key of
+	// the entity are set.
+	Entity               *storage.NetworkEntity `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *EntityEvent) Reset()         { *m = EntityEvent{} }
+func (m *EntityEvent) String() string { return proto.CompactTextString(m) }
+func (*EntityEvent) ProtoMessage()    {}
+func (*EntityEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{16}
+}
+
+func (m *EntityEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntityEvent.Unmarshal(m, b)
+}
+func (m *EntityEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntityEvent.Marshal(b, m, deterministic)
+}
+func (m *EntityEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntityEvent.Merge(m, src)
+}
+func (m *EntityEvent) XXX_Size() int {
+	return xxx_messageInfo_EntityEvent.Size(m)
+}
+func (m *EntityEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntityEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntityEvent proto.InternalMessageInfo
+
+func (m *EntityEvent) GetType() ChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return ChangeType_CREATED
+}
+
+func (m *EntityEvent) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *EntityEvent) GetEntity() *storage.NetworkEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type WatchNetworksRequest struct {
+	// If non-empty, only events for these networks are streamed
+	NetworkIDs           []string `protobuf:"bytes,1,rep,name=networkIDs,proto3" json:"networkIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchNetworksRequest) Reset()         { *m = WatchNetworksRequest{} }
+func (m *WatchNetworksRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchNetworksRequest) ProtoMessage()    {}
+func (*WatchNetworksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{17}
+}
+
+func (m *WatchNetworksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchNetworksRequest.Unmarshal(m, b)
+}
+func (m *WatchNetworksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchNetworksRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchNetworksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchNetworksRequest.Merge(m, src)
+}
+func (m *WatchNetworksRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchNetworksRequest.Size(m)
+}
+func (m *WatchNetworksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchNetworksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchNetworksRequest proto.InternalMessageInfo
+
+func (m *WatchNetworksRequest) GetNetworkIDs() []string {
+	if m != nil {
+		return m.NetworkIDs
+	}
+	return nil
+}
+
+type NetworkEvent struct {
+	Type ChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=magma.orc8r.configurator.ChangeType" json:"type,omitempty"`
+	// The network after the change. For deletions, only the ID of the
+	// network is set.
+	Network              *storage.Network `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NetworkEvent) Reset()         { *m = NetworkEvent{} }
+func (m *NetworkEvent) String() string { return proto.CompactTextString(m) }
+func (*NetworkEvent) ProtoMessage()    {}
+func (*NetworkEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{18}
+}
+
+func (m *NetworkEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkEvent.Unmarshal(m, b)
+}
+func (m *NetworkEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkEvent.Marshal(b, m, deterministic)
+}
+func (m *NetworkEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkEvent.Merge(m, src)
+}
+func (m *NetworkEvent) XXX_Size() int {
+	return xxx_messageInfo_NetworkEvent.Size(m)
+}
+func (m *NetworkEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkEvent proto.InternalMessageInfo
+
+func (m *NetworkEvent) GetType() ChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return ChangeType_CREATED
+}
+
+func (m *NetworkEvent) GetNetwork() *storage.Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+// ConfigChange is an event of the configurator's change log, from which every
+// configurator replica streams the events of its watches.
+type ConfigChange struct {
+	// Types that are valid to be assigned to Event:
+	//	*ConfigChange_Entity
+	//	*ConfigChange_Network
+	Event                isConfigChange_Event `protobuf_oneof:"event"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfigChange) Reset()         { *m = ConfigChange{} }
+func (m *ConfigChange) String() string { return proto.CompactTextString(m) }
+func (*ConfigChange) ProtoMessage()    {}
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{19}
+}
+
+func (m *ConfigChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigChange.Unmarshal(m, b)
+}
+func (m *ConfigChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigChange.Marshal(b, m, deterministic)
+}
+func (m *ConfigChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigChange.Merge(m, src)
+}
+func (m *ConfigChange) XXX_Size() int {
+	return xxx_messageInfo_ConfigChange.Size(m)
+}
+func (m *ConfigChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigChange proto.InternalMessageInfo
+
+type isConfigChange_Event interface {
+	isConfigChange_Event()
+}
+
+type ConfigChange_Entity struct {
+	Entity *EntityEvent `protobuf:"bytes,1,opt,name=entity,proto3,oneof"`
+}
+
+type ConfigChange_Network struct {
+	Network *NetworkEvent `protobuf:"bytes,2,opt,name=network,proto3,oneof"`
+}
+
+func (*ConfigChange_Entity) isConfigChange_Event() {}
+
+func (*ConfigChange_Network) isConfigChange_Event() {}
+
+func (m *ConfigChange) GetEvent() isConfigChange_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ConfigChange) GetEntity() *EntityEvent {
+	if x, ok := m.GetEvent().(*ConfigChange_Entity); ok {
+		return x.Entity
+	}
+	return nil
+}
+
+func (m *ConfigChange) GetNetwork() *NetworkEvent {
+	if x, ok := m.GetEvent().(*ConfigChange_Network); ok {
+		return x.Network
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConfigChange) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConfigChange_Entity)(nil),
+		(*ConfigChange_Network)(nil),
+	}
+}
+
+// NetworkArchive is a point-in-time snapshot of a network's configuration.
+type NetworkArchive struct {
+	// Version of the archive format. Archives with a format version newer
+	// than the importing configurator understands are rejected.
+	FormatVersion uint32 `protobuf:"varint,1,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"`
+	// Unix time in seconds at which the archive was exported
+	ExportedAt int64            `protobuf:"varint,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	Network    *ArchivedNetwork `protobuf:"bytes,10,opt,name=network,proto3" json:"network,omitempty"`
+	// All entities in the network along with the associations originating
+	// from them
+	Entities             []*ArchivedEntity `protobuf:"bytes,11,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NetworkArchive) Reset()         { *m = NetworkArchive{} }
+func (m *NetworkArchive) String() string { return proto.CompactTextString(m) }
+func (*NetworkArchive) ProtoMessage()    {}
+func (*NetworkArchive) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{20}
+}
+
+func (m *NetworkArchive) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkArchive.Unmarshal(m, b)
+}
+func (m *NetworkArchive) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkArchive.Marshal(b, m, deterministic)
+}
+func (m *NetworkArchive) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkArchive.Merge(m, src)
+}
+func (m *NetworkArchive) XXX_Size() int {
+	return xxx_messageInfo_NetworkArchive.Size(m)
+}
+func (m *NetworkArchive) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkArchive.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkArchive proto.InternalMessageInfo
+
+func (m *NetworkArchive) GetFormatVersion() uint32 {
+	if m != nil {
+		return m.FormatVersion
+	}
+	return 0
+}
+
+func (m *NetworkArchive) GetExportedAt() int64 {
+	if m != nil {
+		return m.ExportedAt
+	}
+	return 0
+}
+
+func (m *NetworkArchive) GetNetwork() *ArchivedNetwork {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *NetworkArchive) GetEntities() []*ArchivedEntity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+// ArchivedConfig is a config serialized with the Serde registered for its
+// type, byte for byte as it is in storage. Configs serialized as JSON are
+// archived as JSON text so that archives can be read and edited by hand.
+type ArchivedConfig struct {
+	// Types that are valid to be assigned to Config:
+	//	*ArchivedConfig_Json
+	//	*ArchivedConfig_Raw
+	Config               isArchivedConfig_Config `protobuf_oneof:"config"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ArchivedConfig) Reset()         { *m = ArchivedConfig{} }
+func (m *ArchivedConfig) String() string { return proto.CompactTextString(m) }
+func (*ArchivedConfig) ProtoMessage()    {}
+func (*ArchivedConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{21}
+}
+
+func (m *ArchivedConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchivedConfig.Unmarshal(m, b)
+}
+func (m *ArchivedConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchivedConfig.Marshal(b, m, deterministic)
+}
+func (m *ArchivedConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchivedConfig.Merge(m, src)
+}
+func (m *ArchivedConfig) XXX_Size() int {
+	return xxx_messageInfo_ArchivedConfig.Size(m)
+}
+func (m *ArchivedConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchivedConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchivedConfig proto.InternalMessageInfo
+
+type isArchivedConfig_Config interface {
+	isArchivedConfig_Config()
+}
+
+type ArchivedConfig_Json struct {
+	Json string `protobuf:"bytes,3,opt,name=json,proto3,oneof"`
+}
+
+type ArchivedConfig_Raw struct {
+	Raw []byte `protobuf:"bytes,2,opt,name=raw,proto3,oneof"`
+}
+
+func (*ArchivedConfig_Json) isArchivedConfig_Config() {}
+
+func (*ArchivedConfig_Raw) isArchivedConfig_Config() {}
+
+func (m *ArchivedConfig) GetConfig() isArchivedConfig_Config {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *ArchivedConfig) GetJson() string {
+	if x, ok := m.GetConfig().(*ArchivedConfig_Json); ok {
+		return x.Json
+	}
+	return ""
+}
+
+func (m *ArchivedConfig) GetRaw() []byte {
+	if x, ok := m.GetConfig().(*ArchivedConfig_Raw); ok {
+		return x.Raw
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ArchivedConfig) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ArchivedConfig_Json)(nil),
+		(*ArchivedConfig_Raw)(nil),
+	}
+}
+
+type ArchivedNetwork struct {
+	Id                   string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string                     `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name                 string                     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string                     `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Configs              map[string]*ArchivedConfig `protobuf:"bytes,5,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ArchivedNetwork) Reset()         { *m = ArchivedNetwork{} }
+func (m *ArchivedNetwork) String() string { return proto.CompactTextString(m) }
+func (*ArchivedNetwork) ProtoMessage()    {}
+func (*ArchivedNetwork) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{22}
+}
+
+func (m *ArchivedNetwork) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchivedNetwork.Unmarshal(m, b)
+}
+func (m *ArchivedNetwork) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchivedNetwork.Marshal(b, m, deterministic)
+}
+func (m *ArchivedNetwork) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchivedNetwork.Merge(m, src)
+}
+func (m *ArchivedNetwork) XXX_Size() int {
+	return xxx_messageInfo_ArchivedNetwork.Size(m)
+}
+func (m *ArchivedNetwork) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchivedNetwork.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchivedNetwork proto.InternalMessageInfo
+
+func (m *ArchivedNetwork) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ArchivedNetwork) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ArchivedNetwork) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArchivedNetwork) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ArchivedNetwork) GetConfigs() map[string]*ArchivedConfig {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+// ArchivedEntity is a network entity without its system-generated fields
+// (graph ID, version)
+type ArchivedEntity struct {
+	Type         string              `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Key          string              `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Name         string              `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string              `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	PhysicalId   string              `protobuf:"bytes,5,opt,name=physical_id,json=physicalId,proto3" json:"physical_id,omitempty"`
+	Config       *ArchivedConfig     `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
+	Associations []*storage.EntityID `protobuf:"bytes,7,rep,name=associations,proto3" json:"associations,omitempty"`
+	// ACL IDs are generated again on import
+	Permissions          []*storage.ACL `protobuf:"bytes,8,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ArchivedEntity) Reset()         { *m = ArchivedEntity{} }
+func (m *ArchivedEntity) String() string { return proto.CompactTextString(m) }
+func (*ArchivedEntity) ProtoMessage()    {}
+func (*ArchivedEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{23}
+}
+
+func (m *ArchivedEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchivedEntity.Unmarshal(m, b)
+}
+func (m *ArchivedEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchivedEntity.Marshal(b, m, deterministic)
+}
+func (m *ArchivedEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchivedEntity.Merge(m, src)
+}
+func (m *ArchivedEntity) XXX_Size() int {
+	return xxx_messageInfo_ArchivedEntity.Size(m)
+}
+func (m *ArchivedEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchivedEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchivedEntity proto.InternalMessageInfo
+
+func (m *ArchivedEntity) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ArchivedEntity) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ArchivedEntity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArchivedEntity) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ArchivedEntity) GetPhysicalId() string {
+	if m != nil {
+		return m.PhysicalId
+	}
+	return ""
+}
+
+func (m *ArchivedEntity) GetConfig() *ArchivedConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *ArchivedEntity) GetAssociations() []*storage.EntityID {
+	if m != nil {
+		return m.Associations
+	}
+	return nil
+}
+
+func (m *ArchivedEntity) GetPermissions() []*storage.ACL {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type ExportNetworkRequest struct {
+	NetworkID            string   `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportNetworkRequest) Reset()         { *m = ExportNetworkRequest{} }
+func (m *ExportNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportNetworkRequest) ProtoMessage()    {}
+func (*ExportNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{24}
+}
+
+func (m *ExportNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportNetworkRequest.Unmarshal(m, b)
+}
+func (m *ExportNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportNetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportNetworkRequest.Merge(m, src)
+}
+func (m *ExportNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportNetworkRequest.Size(m)
+}
+func (m *ExportNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportNetworkRequest proto.InternalMessageInfo
+
+func (m *ExportNetworkRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+type ImportNetworkRequest struct {
+	Archive *NetworkArchive `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	// ID to import the network under. Defaults to the ID of the archived
+	// network.
+	NetworkID string `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If true, an existing network with the same ID is deleted along with
+	// all of its entities and replaced by the archived network. Otherwise
+	// the import fails if the network already exists.
+	Overwrite bool `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	// If true, entities are imported without their physical IDs. Otherwise
+	// the import fails with ALREADY_EXISTS if a physical ID in the archive
+	// is already registered in another network.
+	DropPhysicalIds      bool     `protobuf:"varint,4,opt,name=drop_physical_ids,json=dropPhysicalIds,proto3" json:"drop_physical_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportNetworkRequest) Reset()         { *m = ImportNetworkRequest{} }
+func (m *ImportNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportNetworkRequest) ProtoMessage()    {}
+func (*ImportNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{25}
+}
+
+func (m *ImportNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportNetworkRequest.Unmarshal(m, b)
+}
+func (m *ImportNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportNetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportNetworkRequest.Merge(m, src)
+}
+func (m *ImportNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportNetworkRequest.Size(m)
+}
+func (m *ImportNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportNetworkRequest proto.InternalMessageInfo
+
+func (m *ImportNetworkRequest) GetArchive() *NetworkArchive {
+	if m != nil {
+		return m.Archive
+	}
+	return nil
+}
+
+func (m *ImportNetworkRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *ImportNetworkRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *ImportNetworkRequest) GetDropPhysicalIds() bool {
+	if m != nil {
+		return m.DropPhysicalIds
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("magma.orc8r.configurator.ChangeType", ChangeType_name, ChangeType_value)
 	proto.RegisterType((*ListNetworkIDsResponse)(nil), "magma.orc8r.configurator.ListNetworkIDsResponse")
 	proto.RegisterType((*LoadNetworksRequest)(nil), "magma.orc8r.configurator.LoadNetworksRequest")
 	proto.RegisterType((*CreateNetworksRequest)(nil), "magma.orc8r.configurator.CreateNetworksRequest")
@@ -734,63 +1470,114 @@ func init() {
 	proto.RegisterType((*UpdateEntitiesResponse)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse")
 	proto.RegisterMapType((map[string]*storage.NetworkEntity)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse.UpdatedEntitiesEntry")
 	proto.RegisterType((*DeleteEntitiesRequest)(nil), "magma.orc8r.configurator.DeleteEntitiesRequest")
+	proto.RegisterType((*WatchEntitiesRequest)(nil), "magma.orc8r.configurator.WatchEntitiesRequest")
+	proto.RegisterType((*EntityEvent)(nil), "magma.orc8r.configurator.EntityEvent")
+	proto.RegisterType((*WatchNetworksRequest)(nil), "magma.orc8r.configurator.WatchNetworksRequest")
+	proto.RegisterType((*NetworkEvent)(nil), "magma.orc8r.configurator.NetworkEvent")
+	proto.RegisterType((*ConfigChange)(nil), "magma.orc8r.configurator.ConfigChange")
+	proto.RegisterType((*NetworkArchive)(nil), "magma.orc8r.configurator.NetworkArchive")
+	proto.RegisterType((*ArchivedConfig)(nil), "magma.orc8r.configurator.ArchivedConfig")
+	proto.RegisterType((*ArchivedNetwork)(nil), "magma.orc8r.configurator.ArchivedNetwork")
+	proto.RegisterMapType((map[string]*ArchivedConfig)(nil), "magma.orc8r.configurator.ArchivedNetwork.ConfigsEntry")
+	proto.RegisterType((*ArchivedEntity)(nil), "magma.orc8r.configurator.ArchivedEntity")
+	proto.RegisterType((*ExportNetworkRequest)(nil), "magma.orc8r.configurator.ExportNetworkRequest")
+	proto.RegisterType((*ImportNetworkRequest)(nil), "magma.orc8r.configurator.ImportNetworkRequest")
 }
 
 func init() { proto.RegisterFile("northbound.proto", fileDescriptor_90b042c70967f647) }
 
 var fileDescriptor_90b042c70967f647 = []byte{
-	// 809 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0x8d, 0x53, 0x7d, 0x69, 0x72, 0xfb, 0xd1, 0x86, 0xa1, 0x89, 0xa2, 0x08, 0x41, 0x35, 0xab,
-	0x82, 0x20, 0xae, 0x52, 0xa0, 0x55, 0x57, 0xa8, 0x75, 0x10, 0xa1, 0x55, 0xd5, 0x5a, 0xd0, 0x4a,
-	0x5d, 0x80, 0x5c, 0x67, 0x1a, 0x4c, 0x13, 0x4f, 0x3a, 0x1e, 0xb7, 0x0a, 0x4b, 0x36, 0x3c, 0x11,
-	0x3b, 0x78, 0x08, 0x96, 0x3c, 0x0d, 0x28, 0x9e, 0xb1, 0x63, 0x3b, 0x4e, 0x62, 0x77, 0x81, 0x58,
-	0xc5, 0x9a, 0x99, 0x7b, 0xce, 0xfd, 0x39, 0x73, 0xe7, 0x06, 0xca, 0x36, 0x65, 0xfc, 0xe3, 0x39,
-	0x75, 0xed, 0x4e, 0x63, 0xc0, 0x28, 0xa7, 0xa8, 0xd6, 0x37, 0xba, 0x7d, 0xa3, 0x41, 0x99, 0xb9,
-	0xcd, 0x1a, 0x26, 0xb5, 0x2f, 0xac, 0xae, 0xcb, 0x0c, 0x4e, 0x59, 0xfd, 0xa1, 0xb7, 0xa3, 0x7a,
-	0x3b, 0xaa, 0x77, 0xd8, 0x51, 0x4d, 0xda, 0xef, 0x53, 0x5b, 0x98, 0xd6, 0x5f, 0x86, 0x0f, 0x98,
-	0x3d, 0xea, 0x76, 0xd4, 0x2e, 0x55, 0x1d, 0xc2, 0xae, 0x2d, 0x93, 0x8c, 0xce, 0x8e, 0xc1, 0x54,
-	0x87, 0x53, 0x66, 0x74, 0x89, 0xff, 0x2b, 0x10, 0xf0, 0x36, 0x54, 0x0f, 0x2c, 0x87, 0x1f, 0x12,
-	0x7e, 0x43, 0xd9, 0x65, 0x5b, 0x73, 0x74, 0xe2, 0x0c, 0xa8, 0xed, 0x10, 0xf4, 0x00, 0xc0, 0x0e,
-	0x56, 0x6b, 0xca, 0xda, 0xc2, 0x7a, 0x49, 0x0f, 0xad, 0xe0, 0x6f, 0x0a, 0xdc, 0x3b, 0xa0, 0x46,
-	0x47, 0x9a, 0x3a, 0x3a, 0xb9, 0x72, 0x89, 0xc3, 0xd1, 0x31, 0x14, 0x4d, 0x66, 0x71, 0xc2, 0x2c,
-	0xa3, 0x96, 0x5f, 0x53, 0xd6, 0x97, 0x9a, 0xcf, 0x1b, 0xd3, 0x22, 0x6c, 0xf8, 0xce, 0x48, 0x90,
-	0x11, 0xde, 0x9e, 0x34, 0xd6, 0x03, 0x18, 0xb4, 0x0f, 0x85, 0x0b, 0xab, 0xc7, 0x09, 0xab, 0x2d,
-	0x78, 0x80, 0x9b, 0x99, 0x00, 0x5f, 0x79, 0xa6, 0xba, 0x84, 0xc0, 0xef, 0xa1, 0xb2, 0xc7, 0x88,
-	0xc1, 0x49, 0xdc, 0xf1, 0x16, 0x14, 0x65, 0x78, 0x22, 0xdc, 0xa5, 0xe6, 0xa3, 0xd4, 0x3c, 0x7a,
-	0x60, 0x8a, 0x6d, 0xa8, 0xc6, 0xf1, 0x65, 0x46, 0xdf, 0x42, 0xd9, 0xf4, 0x76, 0x3a, 0x1f, 0x6e,
-	0x4f, 0xb4, 0x22, 0x21, 0x7c, 0x74, 0xfc, 0x09, 0x2a, 0xef, 0x06, 0x9d, 0x84, 0x78, 0x8e, 0x61,
-	0xd1, 0xf5, 0x36, 0x7c, 0x96, 0xad, 0xd4, 0x2c, 0x02, 0x30, 0xa8, 0x84, 0x8f, 0x83, 0xb7, 0xa0,
-	0xa2, 0x91, 0x1e, 0x99, 0xe4, 0x9a, 0x27, 0x96, 0x9f, 0x52, 0x2c, 0x2d, 0x9b, 0x5b, 0xdc, 0x22,
-	0x81, 0xdd, 0x7d, 0x28, 0x05, 0xa7, 0x6a, 0xca, 0x9a, 0xb2, 0x5e, 0xd2, 0xc7, 0x0b, 0xe8, 0x4d,
-	0x50, 0x77, 0x21, 0xa4, 0xe6, 0xfc, 0x00, 0x3c, 0x82, 0xe1, 0x64, 0xd9, 0xd1, 0x51, 0x48, 0x96,
-	0x42, 0x45, 0xcf, 0xb2, 0xa0, 0x4d, 0xaa, 0x12, 0x7f, 0x86, 0xd5, 0xd3, 0xd1, 0x77, 0xb6, 0x98,
-	0x34, 0x28, 0xdc, 0x8c, 0xac, 0x9c, 0x5a, 0xde, 0x2b, 0xca, 0x93, 0xe9, 0x5e, 0x8c, 0xd1, 0x87,
-	0x12, 0x5b, 0x97, 0xb6, 0xf8, 0xbb, 0x02, 0x68, 0x72, 0x1b, 0xb5, 0xa1, 0x20, 0xe4, 0xe1, 0xf1,
-	0x2e, 0x35, 0xd5, 0xd4, 0x15, 0x17, 0x38, 0xaf, 0x73, 0xba, 0x04, 0x40, 0x47, 0x50, 0x10, 0x55,
-	0x97, 0xb9, 0x7f, 0x91, 0x36, 0x5b, 0x51, 0xed, 0x8c, 0x10, 0x05, 0xce, 0x6e, 0x09, 0x16, 0x99,
-	0xf0, 0x13, 0xff, 0xca, 0x43, 0x25, 0x96, 0x3b, 0x79, 0x47, 0xce, 0xc6, 0x77, 0x84, 0xc8, 0x3d,
-	0xa9, 0xde, 0xac, 0xb1, 0x04, 0x37, 0xc5, 0xe7, 0x40, 0x14, 0xca, 0xc2, 0x95, 0x10, 0xb6, 0x28,
-	0x82, 0x96, 0xa6, 0x08, 0x21, 0x37, 0x1b, 0x22, 0xc8, 0x00, 0xba, 0x65, 0x73, 0x36, 0xd4, 0x57,
-	0xdc, 0xe8, 0x6a, 0xdd, 0x81, 0xd5, 0xa4, 0x83, 0xa8, 0x0c, 0x0b, 0x97, 0x64, 0x28, 0xb5, 0x31,
-	0xfa, 0x44, 0x2d, 0xf8, 0xef, 0xda, 0xe8, 0xb9, 0x7e, 0xb2, 0x33, 0xc7, 0x2a, 0xac, 0x77, 0xf2,
-	0xdb, 0x0a, 0xfe, 0xa2, 0xf8, 0x0d, 0x2e, 0x9b, 0x30, 0xf7, 0xa1, 0x18, 0xcb, 0x4a, 0x66, 0x2f,
-	0x02, 0x00, 0xcc, 0xfd, 0x26, 0xf8, 0x37, 0x0b, 0x8c, 0xbf, 0x2a, 0x7e, 0x2f, 0xcc, 0x16, 0xfa,
-	0xd1, 0xb8, 0x53, 0x8a, 0xc8, 0x6f, 0x29, 0xf6, 0x71, 0xa3, 0xfc, 0xad, 0x40, 0x35, 0xee, 0x89,
-	0x4c, 0xc0, 0x20, 0x41, 0x85, 0x22, 0x01, 0xad, 0xe9, 0xac, 0xc9, 0x58, 0xff, 0xb2, 0x0c, 0xaf,
-	0xfc, 0xa7, 0x22, 0x5b, 0x29, 0x76, 0x20, 0xdf, 0xd6, 0x64, 0x15, 0x1e, 0xa7, 0xad, 0x42, 0x5b,
-	0xd3, 0xf3, 0x6d, 0xad, 0xf9, 0xa3, 0x08, 0xd5, 0xc3, 0x60, 0xba, 0xda, 0x0b, 0x9d, 0x47, 0xa7,
-	0xb0, 0x1c, 0x1d, 0x73, 0xd0, 0xdd, 0x08, 0xf8, 0x09, 0xb5, 0x3a, 0xf5, 0x8d, 0xe9, 0x7c, 0xc9,
-	0x33, 0x12, 0xce, 0x21, 0x17, 0x96, 0xa3, 0xaf, 0x3d, 0x9a, 0x91, 0xb4, 0xc4, 0xb9, 0x63, 0x16,
-	0x6d, 0xf2, 0x20, 0x81, 0x73, 0xe8, 0x04, 0x96, 0xa3, 0x8f, 0xfe, 0x2c, 0xda, 0xc4, 0xf1, 0xa0,
-	0x3e, 0x99, 0x00, 0x81, 0x1b, 0x7d, 0xe0, 0x67, 0xe1, 0x26, 0x8e, 0x02, 0xc9, 0xb8, 0x1c, 0xfe,
-	0x0f, 0xcf, 0x8a, 0xe8, 0xe9, 0x8c, 0x54, 0x4f, 0xce, 0x94, 0xf5, 0x6c, 0x03, 0x9f, 0x4e, 0x1c,
-	0xb7, 0xc7, 0x71, 0x0e, 0x31, 0xb8, 0x13, 0x69, 0xdf, 0xa8, 0x91, 0xba, 0xcf, 0x0b, 0x5e, 0x35,
-	0xe3, 0xbb, 0x10, 0x16, 0x44, 0x40, 0x3a, 0x57, 0x10, 0x71, 0xd6, 0x8d, 0xf4, 0x06, 0x61, 0xda,
-	0x68, 0x8f, 0x98, 0x2f, 0x88, 0x0c, 0xb4, 0xc9, 0xed, 0x27, 0xac, 0x97, 0x34, 0xb4, 0x89, 0xfd,
-	0x20, 0x59, 0x2f, 0x8e, 0xd0, 0x4b, 0x80, 0x3a, 0x47, 0x2f, 0x71, 0xcc, 0x4c, 0x83, 0xa2, 0x2f,
-	0x97, 0xdd, 0xe2, 0x59, 0x41, 0xfc, 0xc9, 0x3a, 0x17, 0xbf, 0x9b, 0x7f, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xda, 0x6f, 0xc2, 0x9d, 0xad, 0x0d, 0x00, 0x00,
+	// 1427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x3f, 0x6f, 0xdb, 0xc6,
+	0x1b, 0x16, 0x29, 0x5b, 0x7f, 0x5e, 0xc9, 0xb2, 0x73, 0x3f, 0xd9, 0x10, 0x84, 0xe0, 0x17, 0x83,
+	0x68, 0x0a, 0x37, 0x68, 0x25, 0xc3, 0x49, 0x13, 0x23, 0x43, 0x1b, 0x5b, 0x52, 0x63, 0x39, 0x46,
+	0xe0, 0xb0, 0x4e, 0x02, 0x64, 0xa8, 0xc1, 0x88, 0x17, 0x9b, 0xb1, 0x45, 0x2a, 0x77, 0x27, 0xb9,
+	0xea, 0xd8, 0xa5, 0x53, 0xbf, 0x41, 0xb7, 0xee, 0xdd, 0xba, 0x75, 0xea, 0x37, 0x68, 0xb7, 0xce,
+	0x5d, 0xfa, 0x2d, 0x5a, 0xf0, 0xee, 0x48, 0x91, 0x14, 0x25, 0x91, 0x2e, 0x10, 0x74, 0x92, 0x78,
+	0xc7, 0xf7, 0x79, 0xde, 0x3f, 0xcf, 0xbd, 0xc7, 0x3b, 0x58, 0xb3, 0x1d, 0xc2, 0xce, 0x5f, 0x3b,
+	0x43, 0xdb, 0x6c, 0x0c, 0x88, 0xc3, 0x1c, 0x54, 0xeb, 0x1b, 0x67, 0x7d, 0xa3, 0xe1, 0x90, 0xde,
+	0x2e, 0x69, 0xf4, 0x1c, 0xfb, 0x8d, 0x75, 0x36, 0x24, 0x06, 0x73, 0x48, 0xfd, 0x16, 0x9f, 0x69,
+	0xf2, 0x99, 0x26, 0x7f, 0x99, 0x36, 0x7b, 0x4e, 0xbf, 0xef, 0xd8, 0xc2, 0xb4, 0xfe, 0x28, 0xf8,
+	0x42, 0xef, 0xd2, 0x19, 0x9a, 0xcd, 0x33, 0xa7, 0x49, 0x31, 0x19, 0x59, 0x3d, 0x4c, 0x9b, 0x41,
+	0xb0, 0x26, 0x65, 0x0e, 0x31, 0xce, 0xb0, 0xf7, 0x2b, 0x10, 0xb4, 0x5d, 0xd8, 0x38, 0xb2, 0x28,
+	0x7b, 0x8a, 0xd9, 0x95, 0x43, 0x2e, 0xba, 0x6d, 0xaa, 0x63, 0x3a, 0x70, 0x6c, 0x8a, 0xd1, 0xff,
+	0x01, 0x6c, 0x7f, 0xb4, 0xa6, 0x6c, 0x66, 0xb7, 0x8a, 0x7a, 0x60, 0x44, 0xfb, 0x59, 0x81, 0xff,
+	0x1d, 0x39, 0x86, 0x29, 0x4d, 0xa9, 0x8e, 0xdf, 0x0d, 0x31, 0x65, 0xe8, 0x19, 0x14, 0x7a, 0xc4,
+	0x62, 0x98, 0x58, 0x46, 0x4d, 0xdd, 0x54, 0xb6, 0x4a, 0x3b, 0x9f, 0x36, 0x66, 0x45, 0xd8, 0xf0,
+	0x9c, 0x91, 0x20, 0x2e, 0x5e, 0x4b, 0x1a, 0xeb, 0x3e, 0x0c, 0x7a, 0x02, 0xb9, 0x37, 0xd6, 0x25,
+	0xc3, 0xa4, 0x96, 0xe5, 0x80, 0x77, 0x53, 0x01, 0x7e, 0xc1, 0x4d, 0x75, 0x09, 0xa1, 0x7d, 0x05,
+	0xeb, 0x2d, 0x82, 0x0d, 0x86, 0xa3, 0x8e, 0x77, 0xa0, 0x20, 0xc3, 0x13, 0xe1, 0x96, 0x76, 0x3e,
+	0x4a, 0xcc, 0xa3, 0xfb, 0xa6, 0x9a, 0x0d, 0x1b, 0x51, 0x7c, 0x99, 0xd1, 0x13, 0x58, 0xeb, 0xf1,
+	0x19, 0xf3, 0xf4, 0xfa, 0x44, 0xab, 0x12, 0xc2, 0x43, 0xd7, 0xde, 0xc2, 0xfa, 0xf3, 0x81, 0x19,
+	0x13, 0xcf, 0x33, 0xc8, 0x0f, 0xf9, 0x84, 0xc7, 0xf2, 0x20, 0x31, 0x8b, 0x00, 0xf4, 0x2b, 0xe1,
+	0xe1, 0x68, 0x0f, 0x60, 0xbd, 0x8d, 0x2f, 0xf1, 0x34, 0xd7, 0x22, 0xb1, 0xfc, 0x26, 0xc5, 0xd2,
+	0xb1, 0x99, 0xc5, 0x2c, 0xec, 0xdb, 0xdd, 0x84, 0xa2, 0xff, 0x56, 0x4d, 0xd9, 0x54, 0xb6, 0x8a,
+	0xfa, 0x64, 0x00, 0x1d, 0xfa, 0x75, 0x17, 0x42, 0xda, 0x59, 0x1c, 0x00, 0x27, 0x18, 0x4f, 0x97,
+	0x1d, 0x1d, 0x07, 0x64, 0x29, 0x54, 0x74, 0x2f, 0x0d, 0xda, 0xb4, 0x2a, 0xb5, 0x6f, 0xa0, 0xfa,
+	0xd2, 0xfd, 0x9f, 0x2e, 0xa6, 0x36, 0xe4, 0xae, 0x5c, 0x2b, 0x5a, 0x53, 0x79, 0x51, 0x3e, 0x9e,
+	0xed, 0xc5, 0x04, 0x7d, 0x2c, 0xb1, 0x75, 0x69, 0xab, 0xfd, 0xa2, 0x00, 0x9a, 0x9e, 0x46, 0x5d,
+	0xc8, 0x09, 0x79, 0x70, 0xde, 0xd2, 0x4e, 0x33, 0x71, 0xc5, 0x05, 0xce, 0x41, 0x46, 0x97, 0x00,
+	0xe8, 0x18, 0x72, 0xa2, 0xea, 0x32, 0xf7, 0xf7, 0x93, 0x66, 0x2b, 0xac, 0x1d, 0x17, 0x51, 0xe0,
+	0xec, 0x17, 0x21, 0x4f, 0x84, 0x9f, 0xda, 0x1f, 0x2a, 0xac, 0x47, 0x72, 0x27, 0xd7, 0xc8, 0xab,
+	0xc9, 0x1a, 0xc1, 0x72, 0x4e, 0xaa, 0x37, 0x6d, 0x2c, 0xfe, 0x4a, 0xf1, 0x38, 0x90, 0x03, 0x6b,
+	0xc2, 0x95, 0x00, 0xb6, 0x28, 0x42, 0x3b, 0x49, 0x11, 0x02, 0x6e, 0x36, 0x44, 0x90, 0x3e, 0x74,
+	0xc7, 0x66, 0x64, 0xac, 0xaf, 0x0e, 0xc3, 0xa3, 0x75, 0x0a, 0xd5, 0xb8, 0x17, 0xd1, 0x1a, 0x64,
+	0x2f, 0xf0, 0x58, 0x6a, 0xc3, 0xfd, 0x8b, 0x3a, 0xb0, 0x3c, 0x32, 0x2e, 0x87, 0x5e, 0xb2, 0x53,
+	0xc7, 0x2a, 0xac, 0x1f, 0xaa, 0xbb, 0x8a, 0xf6, 0xad, 0xe2, 0x35, 0xb8, 0x74, 0xc2, 0x7c, 0x02,
+	0x85, 0x48, 0x56, 0x52, 0x7b, 0xe1, 0x03, 0x68, 0xcc, 0x6b, 0x82, 0xef, 0xb3, 0xc0, 0xda, 0x77,
+	0x8a, 0xd7, 0x0b, 0xd3, 0x85, 0x7e, 0x3c, 0xe9, 0x94, 0x22, 0xf2, 0x6b, 0x8a, 0x7d, 0xd2, 0x28,
+	0xff, 0x56, 0x60, 0x23, 0xea, 0x89, 0x4c, 0xc0, 0x20, 0x46, 0x85, 0x22, 0x01, 0x9d, 0xd9, 0xac,
+	0xf1, 0x58, 0xff, 0x65, 0x19, 0xbe, 0xf3, 0xb6, 0x8a, 0x74, 0xa5, 0x78, 0x08, 0x6a, 0xb7, 0x2d,
+	0xab, 0x70, 0x27, 0x69, 0x15, 0xba, 0x6d, 0x5d, 0xed, 0xb6, 0xb5, 0x43, 0xa8, 0xbe, 0x34, 0x58,
+	0xef, 0x3c, 0x1d, 0x63, 0x15, 0x96, 0xd9, 0x78, 0x20, 0x4b, 0x5f, 0xd4, 0xc5, 0x83, 0xf6, 0x93,
+	0x02, 0x25, 0x01, 0xde, 0x19, 0x61, 0x9b, 0xa1, 0x5d, 0x58, 0x72, 0x27, 0xb8, 0x79, 0x65, 0xe7,
+	0x83, 0xd9, 0x9e, 0xb5, 0xce, 0x0d, 0xfb, 0x0c, 0x9f, 0x8c, 0x07, 0x58, 0xe7, 0x16, 0x61, 0x76,
+	0x35, 0xca, 0xfe, 0x18, 0x72, 0x5c, 0x05, 0xe3, 0x5a, 0xf6, 0x7a, 0x29, 0x97, 0xe6, 0xda, 0x7d,
+	0x19, 0x7c, 0xda, 0x9d, 0xf9, 0x7b, 0x05, 0xca, 0x1e, 0xe2, 0xbf, 0x8c, 0xb4, 0x05, 0x79, 0x09,
+	0x2c, 0xf5, 0x93, 0xe2, 0xb3, 0xc6, 0xb3, 0xd4, 0x7e, 0x50, 0xa0, 0xdc, 0xe2, 0x6f, 0x0a, 0x7c,
+	0xf4, 0xb9, 0x9f, 0x21, 0xb1, 0xa7, 0xdd, 0x9e, 0x0d, 0x1a, 0x28, 0x98, 0xbb, 0xef, 0x08, 0x33,
+	0xb4, 0x1f, 0x75, 0xeb, 0xc3, 0xd9, 0x08, 0xc1, 0x4c, 0x1c, 0x64, 0x7c, 0xaf, 0xf6, 0xf3, 0xb0,
+	0x8c, 0xdd, 0x31, 0xed, 0x4f, 0x05, 0x2a, 0xf2, 0xa5, 0x3d, 0xd2, 0x3b, 0xb7, 0x46, 0x18, 0xdd,
+	0x86, 0xca, 0x1b, 0x87, 0xf4, 0x0d, 0x76, 0x3a, 0xc2, 0x84, 0x5a, 0x8e, 0xcd, 0x1d, 0x5d, 0xd1,
+	0x57, 0xc4, 0xe8, 0x0b, 0x31, 0x88, 0x6e, 0x41, 0x09, 0x7f, 0x3d, 0x70, 0x88, 0xbb, 0xf0, 0x0d,
+	0xc6, 0x5d, 0xc9, 0xea, 0xe0, 0x0d, 0xed, 0xb1, 0x60, 0xfa, 0x60, 0x51, 0xfa, 0x24, 0xb7, 0x19,
+	0x4d, 0x1f, 0x6a, 0x07, 0xba, 0x78, 0x89, 0xaf, 0xa2, 0xad, 0xc5, 0x28, 0x53, 0xed, 0xfb, 0x10,
+	0x2a, 0xde, 0x9c, 0xa8, 0x05, 0xaa, 0xc2, 0xd2, 0x5b, 0xea, 0xd8, 0x5c, 0xa5, 0xc5, 0x83, 0x8c,
+	0xce, 0x9f, 0x10, 0x82, 0x2c, 0x31, 0xae, 0x78, 0x2c, 0xe5, 0x83, 0x8c, 0xee, 0x3e, 0xec, 0x17,
+	0x20, 0x27, 0x48, 0x0e, 0x97, 0x0a, 0xca, 0x9a, 0xaa, 0xfd, 0xa8, 0xc2, 0x6a, 0xc4, 0x5d, 0x54,
+	0x01, 0xd5, 0x32, 0xe5, 0x52, 0x54, 0x2d, 0x13, 0x21, 0xa9, 0x39, 0xb1, 0x3c, 0xf8, 0x7f, 0x77,
+	0xcc, 0x36, 0xfa, 0x58, 0x30, 0xea, 0xfc, 0x3f, 0xda, 0x84, 0x92, 0x89, 0x69, 0x8f, 0x58, 0x03,
+	0xe6, 0xe6, 0x79, 0x89, 0x4f, 0x05, 0x87, 0xdc, 0x56, 0x2e, 0xd8, 0x69, 0x6d, 0x79, 0x51, 0x2b,
+	0x8f, 0x78, 0xd5, 0x10, 0xa1, 0xca, 0x2e, 0xea, 0xc1, 0xd4, 0x4d, 0x28, 0x07, 0x27, 0x62, 0xba,
+	0xe6, 0x67, 0xe1, 0xae, 0x99, 0x20, 0xe1, 0x02, 0x30, 0xd8, 0x2e, 0xff, 0x52, 0xa1, 0x12, 0x2e,
+	0x87, 0x9f, 0x14, 0x25, 0x90, 0x14, 0x49, 0xae, 0x4e, 0xc8, 0xaf, 0x97, 0xa6, 0x5b, 0x50, 0x1a,
+	0x9c, 0x8f, 0xa9, 0xd5, 0x33, 0x2e, 0x4f, 0x2d, 0xb3, 0xb6, 0xcc, 0xdf, 0x00, 0x6f, 0xa8, 0x6b,
+	0xa2, 0x47, 0x5e, 0x15, 0x6b, 0xb9, 0x94, 0x41, 0x49, 0x3b, 0xf4, 0x14, 0xca, 0x06, 0xa5, 0x4e,
+	0xcf, 0x32, 0x5c, 0x46, 0x5a, 0xcb, 0xa7, 0xee, 0xe9, 0x21, 0x7b, 0xf4, 0x18, 0x4a, 0x03, 0x4c,
+	0xfa, 0x16, 0xa5, 0x1c, 0xae, 0xb0, 0x99, 0x9d, 0xdf, 0x0c, 0x3c, 0xb8, 0xbd, 0xd6, 0x91, 0x1e,
+	0xb4, 0xd4, 0xee, 0x41, 0xb5, 0xc3, 0x57, 0x9d, 0xb7, 0x78, 0x92, 0x6c, 0x13, 0xda, 0xaf, 0x0a,
+	0x54, 0xbb, 0xfd, 0x18, 0xb3, 0x7d, 0xc8, 0x1b, 0x22, 0x03, 0x35, 0x65, 0x51, 0xaa, 0xc2, 0x9d,
+	0x43, 0xf7, 0x0c, 0x17, 0xec, 0x11, 0x37, 0xa1, 0xe8, 0x8c, 0x30, 0xe1, 0x9f, 0xfe, 0xbc, 0xce,
+	0x05, 0x7d, 0x32, 0x80, 0xee, 0xc0, 0x0d, 0x93, 0x38, 0x83, 0xd3, 0x40, 0x3d, 0x29, 0x2f, 0x79,
+	0x41, 0x5f, 0x75, 0x27, 0x8e, 0xfd, 0xa2, 0xd2, 0x3b, 0x77, 0x01, 0x26, 0x5d, 0x1b, 0x95, 0x20,
+	0xdf, 0xd2, 0x3b, 0x7b, 0x27, 0x9d, 0xf6, 0x5a, 0xc6, 0x7d, 0x78, 0x7e, 0xdc, 0xe6, 0x0f, 0x8a,
+	0xfb, 0xd0, 0xee, 0x1c, 0x75, 0xdc, 0x07, 0x75, 0xe7, 0xf7, 0x32, 0x6c, 0x3c, 0xf5, 0x2f, 0x2d,
+	0x5a, 0x81, 0x78, 0xd0, 0x4b, 0xa8, 0x84, 0x6f, 0x0f, 0xd0, 0x8d, 0x50, 0xf0, 0x2f, 0x1c, 0xcb,
+	0xac, 0x6f, 0xcf, 0xce, 0x47, 0xfc, 0xd5, 0x83, 0x96, 0x41, 0x43, 0xa8, 0x84, 0x0f, 0xd1, 0x68,
+	0xce, 0xc6, 0x18, 0x7b, 0x9c, 0xaf, 0x6f, 0x27, 0x37, 0xf0, 0x69, 0x5f, 0x40, 0x25, 0x7c, 0x96,
+	0x9e, 0x47, 0x1b, 0x7b, 0xea, 0xae, 0x4f, 0x27, 0x40, 0xe0, 0x86, 0xcf, 0xcd, 0xf3, 0x70, 0x63,
+	0x4f, 0xd8, 0xf1, 0xb8, 0x0c, 0xca, 0xc1, 0x2b, 0x18, 0xf4, 0xc9, 0x9c, 0x54, 0x4f, 0x5f, 0xd5,
+	0xd4, 0xd3, 0xdd, 0xa3, 0xe8, 0x98, 0x0e, 0x2f, 0x99, 0x96, 0x41, 0x04, 0x56, 0x42, 0xa7, 0x22,
+	0xd4, 0x48, 0x7c, 0x7c, 0x12, 0xbc, 0xcd, 0x94, 0xc7, 0xad, 0xa0, 0x20, 0x7c, 0xd2, 0x85, 0x82,
+	0x88, 0xb2, 0x6e, 0x27, 0x37, 0x08, 0xd2, 0x86, 0x3f, 0xbd, 0x17, 0x0b, 0x22, 0x05, 0x6d, 0xfc,
+	0x57, 0x7d, 0x50, 0x2f, 0x49, 0x68, 0x63, 0x3f, 0xb3, 0xe3, 0xf5, 0x42, 0x85, 0x5e, 0x7c, 0xd4,
+	0x05, 0x7a, 0x89, 0x62, 0xa6, 0xba, 0x7f, 0xf1, 0xe5, 0x72, 0x05, 0x95, 0x2f, 0x19, 0xc1, 0x46,
+	0xff, 0xbd, 0xd2, 0x6e, 0x2b, 0xe8, 0x2d, 0xac, 0x84, 0xce, 0x03, 0x73, 0x75, 0x1a, 0x73, 0x70,
+	0xa8, 0x27, 0xfb, 0xd4, 0xe4, 0x5c, 0x17, 0x92, 0xcb, 0x5f, 0x8a, 0x8b, 0xb8, 0xa2, 0x6b, 0x31,
+	0xe1, 0x47, 0xa9, 0x47, 0x16, 0xda, 0xc1, 0xe6, 0x91, 0xc5, 0x6d, 0x75, 0xf5, 0xc4, 0x5b, 0x94,
+	0x96, 0x41, 0x27, 0xb0, 0xd2, 0xed, 0x27, 0x24, 0x8b, 0xdb, 0x20, 0x63, 0x95, 0xb8, 0x5f, 0x78,
+	0x95, 0x13, 0x17, 0xda, 0xaf, 0xc5, 0xef, 0xdd, 0x7f, 0x06, 0x00, 0xbc, 0xdd, 0xfd, 0x65, 0x19,
+	0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -826,6 +1613,29 @@ type NorthboundConfiguratorClient interface {
 	DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error)
 	// LoadEntities fetches the set of Entities specified by the request
 	LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*storage.EntityLoadResult, error)
+	// StreamEntities streams all of the entities specified by the request in
+	// pages of the filter's page_size, read from a single consistent snapshot
+	// of the store. The filter's page_token is ignored. Entities not found
+	// are returned with the last page. Returns NOT_FOUND if the request's
+	// network doesn't exist, unless the entities are filtered by physical ID.
+	StreamEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_StreamEntitiesClient, error)
+	// WatchEntities streams an event for every entity created, updated or
+	// deleted in a network after the stream is opened. The response headers
+	// are sent once the watch is established.
+	// Changes made through any configurator replica are streamed.
+	WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchEntitiesClient, error)
+	// WatchNetworks streams an event for every network created, updated or
+	// deleted after the stream is opened. The response headers are sent once
+	// the watch is established.
+	// Changes made through any configurator replica are streamed.
+	WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchNetworksClient, error)
+	// ExportNetwork returns an archive of a network's full configuration:
+	// the network, its configs, and all of its entities and associations
+	ExportNetwork(ctx context.Context, in *ExportNetworkRequest, opts ...grpc.CallOption) (*NetworkArchive, error)
+	// ImportNetwork recreates a network from an archive produced by
+	// ExportNetwork in a single transaction. Returns ALREADY_EXISTS if a
+	// physical ID in the archive is registered in another network.
+	ImportNetwork(ctx context.Context, in *ImportNetworkRequest, opts ...grpc.CallOption) (*protos.Void, error)
 }
 
 type northboundConfiguratorClient struct {
@@ -926,6 +1736,120 @@ func (c *northboundConfiguratorClient) LoadEntities(ctx context.Context, in *Loa
 	return out, nil
 }
 
+func (c *northboundConfiguratorClient) StreamEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_StreamEntitiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[0], "/magma.orc8r.configurator.NorthboundConfigurator/StreamEntities", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorStreamEntitiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_StreamEntitiesClient interface {
+	Recv() (*storage.EntityLoadResult, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorStreamEntitiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorStreamEntitiesClient) Recv() (*storage.EntityLoadResult, error) {
+	m := new(storage.EntityLoadResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchEntitiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[1], "/magma.orc8r.configurator.NorthboundConfigurator/WatchEntities", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorWatchEntitiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_WatchEntitiesClient interface {
+	Recv() (*EntityEvent, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorWatchEntitiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorWatchEntitiesClient) Recv() (*EntityEvent, error) {
+	m := new(EntityEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchNetworksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[2], "/magma.orc8r.configurator.NorthboundConfigurator/WatchNetworks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorWatchNetworksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_WatchNetworksClient interface {
+	Recv() (*NetworkEvent, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorWatchNetworksClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorWatchNetworksClient) Recv() (*NetworkEvent, error) {
+	m := new(NetworkEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) ExportNetwork(ctx context.Context, in *ExportNetworkRequest, opts ...grpc.CallOption) (*NetworkArchive, error) {
+	out := new(NetworkArchive)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ExportNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) ImportNetwork(ctx context.Context, in *ImportNetworkRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ImportNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NorthboundConfiguratorServer is the server API for NorthboundConfigurator service.
 type NorthboundConfiguratorServer interface {
 	// ListNetworkIDs fetches the list of networkIDs registered
@@ -949,6 +1873,29 @@ type NorthboundConfiguratorServer interface {
 	DeleteEntities(context.Context, *DeleteEntitiesRequest) (*protos.Void, error)
 	// LoadEntities fetches the set of Entities specified by the request
 	LoadEntities(context.Context, *LoadEntitiesRequest) (*storage.EntityLoadResult, error)
+	// StreamEntities streams all of the entities specified by the request in
+	// pages of the filter's page_size, read from a single consistent snapshot
+	// of the store. The filter's page_token is ignored. Entities not found
+	// are returned with the last page. Returns NOT_FOUND if the request's
+	// network doesn't exist, unless the entities are filtered by physical ID.
+	StreamEntities(*LoadEntitiesRequest, NorthboundConfigurator_StreamEntitiesServer) error
+	// WatchEntities streams an event for every entity created, updated or
+	// deleted in a network after the stream is opened. The response headers
+	// are sent once the watch is established.
+	// Changes made through any configurator replica are streamed.
+	WatchEntities(*WatchEntitiesRequest, NorthboundConfigurator_WatchEntitiesServer) error
+	// WatchNetworks streams an event for every network created, updated or
+	// deleted after the stream is opened. The response headers are sent once
+	// the watch is established.
+	// Changes made through any configurator replica are streamed.
+	WatchNetworks(*WatchNetworksRequest, NorthboundConfigurator_WatchNetworksServer) error
+	// ExportNetwork returns an archive of a network's full configuration:
+	// the network, its configs, and all of its entities and associations
+	ExportNetwork(context.Context, *ExportNetworkRequest) (*NetworkArchive, error)
+	// ImportNetwork recreates a network from an archive produced by
+	// ExportNetwork in a single transaction. Returns ALREADY_EXISTS if a
+	// physical ID in the archive is registered in another network.
+	ImportNetwork(context.Context, *ImportNetworkRequest) (*protos.Void, error)
 }
 
 // UnimplementedNorthboundConfiguratorServer can be embedded to have forward compatible implementations.
@@ -985,6 +1932,21 @@ func (*UnimplementedNorthboundConfiguratorServer) DeleteEntities(ctx context.Con
 func (*UnimplementedNorthboundConfiguratorServer) LoadEntities(ctx context.Context, req *LoadEntitiesRequest) (*storage.EntityLoadResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadEntities not implemented")
 }
+func (*UnimplementedNorthboundConfiguratorServer) StreamEntities(req *LoadEntitiesRequest, srv NorthboundConfigurator_StreamEntitiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEntities not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) WatchEntities(req *WatchEntitiesRequest, srv NorthboundConfigurator_WatchEntitiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEntities not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) WatchNetworks(req *WatchNetworksRequest, srv NorthboundConfigurator_WatchNetworksServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNetworks not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) ExportNetwork(ctx context.Context, req *ExportNetworkRequest) (*NetworkArchive, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportNetwork not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) ImportNetwork(ctx context.Context, req *ImportNetworkRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportNetwork not implemented")
+}
 
 func RegisterNorthboundConfiguratorServer(s *grpc.Server, srv NorthboundConfiguratorServer) {
 	s.RegisterService(&_NorthboundConfigurator_serviceDesc, srv)
@@ -1170,6 +2132,105 @@ func _NorthboundConfigurator_LoadEntities_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NorthboundConfigurator_StreamEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LoadEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).StreamEntities(m, &northboundConfiguratorStreamEntitiesServer{stream})
+}
+
+type NorthboundConfigurator_StreamEntitiesServer interface {
+	Send(*storage.EntityLoadResult) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorStreamEntitiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorStreamEntitiesServer) Send(m *storage.EntityLoadResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_WatchEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).WatchEntities(m, &northboundConfiguratorWatchEntitiesServer{stream})
+}
+
+type NorthboundConfigurator_WatchEntitiesServer interface {
+	Send(*EntityEvent) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorWatchEntitiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorWatchEntitiesServer) Send(m *EntityEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_WatchNetworks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNetworksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).WatchNetworks(m, &northboundConfiguratorWatchNetworksServer{stream})
+}
+
+type NorthboundConfigurator_WatchNetworksServer interface {
+	Send(*NetworkEvent) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorWatchNetworksServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorWatchNetworksServer) Send(m *NetworkEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_ExportNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).ExportNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/ExportNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).ExportNetwork(ctx, req.(*ExportNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_ImportNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).ImportNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/ImportNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).ImportNetwork(ctx, req.(*ImportNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.configurator.NorthboundConfigurator",
 	HandlerType: (*NorthboundConfiguratorServer)(nil),
@@ -1214,7 +2275,31 @@ var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoadEntities",
 			Handler:    _NorthboundConfigurator_LoadEntities_Handler,
 		},
+		{
+			MethodName: "ExportNetwork",
+			Handler:    _NorthboundConfigurator_ExportNetwork_Handler,
+		},
+		{
+			MethodName: "ImportNetwork",
+			Handler:    _NorthboundConfigurator_ImportNetwork_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEntities",
+			Handler:       _NorthboundConfigurator_StreamEntities_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEntities",
+			Handler:       _NorthboundConfigurator_WatchEntities_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchNetworks",
+			Handler:       _NorthboundConfigurator_WatchNetworks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "northbound.proto",
 }
@@ -11,7 +11,10 @@ package servicers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
+	"magma/orc8r/cloud/go/clock"
 	commonProtos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -19,12 +22,16 @@ import (
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	orc8rStorage "magma/orc8r/cloud/go/storage"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type nbConfiguratorServicer struct {
-	factory storage.ConfiguratorStorageFactory
+	factory  storage.ConfiguratorStorageFactory
+	watchers *watchBroadcaster
 }
 
 // NewNorthboundConfiguratorServicer returns a configurator server backed by storage passed in
@@ -32,7 +39,11 @@ func NewNorthboundConfiguratorServicer(factory storage.ConfiguratorStorageFactor
 	if factory == nil {
 		return nil, fmt.Errorf("Storage factory is nil")
 	}
-	return &nbConfiguratorServicer{factory}, nil
+	watchers, err := newWatchBroadcaster(factory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize watch broadcaster")
+	}
+	return &nbConfiguratorServicer{factory: factory, watchers: watchers}, nil
 }
 
 func (srv *nbConfiguratorServicer) LoadNetworks(context context.Context, req *protos.LoadNetworksRequest) (*storage.NetworkLoadResult, error) {
@@ -89,7 +100,15 @@ func (srv *nbConfiguratorServicer) CreateNetworks(context context.Context, req *
 		}
 		createdNetworks = append(createdNetworks, &createdNetwork)
 	}
-	return &protos.CreateNetworksResponse{CreatedNetworks: createdNetworks}, store.Commit()
+	events := make([]*protos.NetworkEvent, 0, len(createdNetworks))
+	for _, network := range createdNetworks {
+		events = append(events, &protos.NetworkEvent{Type: protos.ChangeType_CREATED, Network: network})
+	}
+	err = srv.watchers.commitChanges(store, events, nil)
+	if err != nil {
+		return emptyRes, err
+	}
+	return &protos.CreateNetworksResponse{CreatedNetworks: createdNetworks}, nil
 }
 
 func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *protos.UpdateNetworksRequest) (*commonProtos.Void, error) {
@@ -107,12 +126,29 @@ func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *
 		}
 		updates = append(updates, *update)
 	}
+	entityEvents := []*protos.EntityEvent{}
+	for _, update := range updates {
+		if !update.DeleteNetwork {
+			continue
+		}
+		events, err := getNetworkEntityDeleteEvents(store, update.ID)
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+		entityEvents = append(entityEvents, events...)
+	}
 	err = store.UpdateNetworks(updates)
 	if err != nil {
 		storage.RollbackLogOnError(store)
 		return void, err
 	}
-	return void, store.Commit()
+	networkEvents, err := getNetworkUpdateEvents(store, updates)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return void, err
+	}
+	return void, srv.watchers.commitChanges(store, networkEvents, entityEvents)
 }
 
 func (srv *nbConfiguratorServicer) DeleteNetworks(context context.Context, req *protos.DeleteNetworksRequest) (*commonProtos.Void, error) {
@@ -123,15 +159,24 @@ func (srv *nbConfiguratorServicer) DeleteNetworks(context context.Context, req *
 	}
 
 	deleteRequests := []storage.NetworkUpdateCriteria{}
+	networkEvents := []*protos.NetworkEvent{}
+	entityEvents := []*protos.EntityEvent{}
 	for _, networkID := range req.NetworkIDs {
 		deleteRequests = append(deleteRequests, storage.NetworkUpdateCriteria{ID: networkID, DeleteNetwork: true})
+		networkEvents = append(networkEvents, &protos.NetworkEvent{Type: protos.ChangeType_DELETED, Network: &storage.Network{ID: networkID}})
+		events, err := getNetworkEntityDeleteEvents(store, networkID)
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+		entityEvents = append(entityEvents, events...)
 	}
 	err = store.UpdateNetworks(deleteRequests)
 	if err != nil {
 		storage.RollbackLogOnError(store)
 		return void, err
 	}
-	return void, store.Commit()
+	return void, srv.watchers.commitChanges(store, networkEvents, entityEvents)
 }
 
 func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *protos.LoadEntitiesRequest) (*storage.EntityLoadResult, error) {
@@ -142,6 +187,10 @@ func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *pr
 	}
 
 	loadResult, err := store.LoadEntities(req.NetworkID, *req.Filter, *req.Criteria)
+	if err == storage.ErrInvalidPageToken {
+		storage.RollbackLogOnError(store)
+		return emptyRes, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		storage.RollbackLogOnError(store)
 		return emptyRes, err
@@ -149,6 +198,60 @@ func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *pr
 	return &loadResult, store.Commit()
 }
 
+func (srv *nbConfiguratorServicer) StreamEntities(req *protos.LoadEntitiesRequest, stream protos.NorthboundConfigurator_StreamEntitiesServer) error {
+	if req.Filter == nil || req.Criteria == nil {
+		return status.Error(codes.InvalidArgument, "filter and criteria must be specified")
+	}
+	if req.Filter.PageSize == 0 {
+		return status.Error(codes.InvalidArgument, "page size must be specified")
+	}
+	// All pages are read in the same repeatable read transaction so that
+	// they're consistent with each other
+	store, err := srv.factory.StartTransaction(stream.Context(), &orc8rStorage.TxOptions{ReadOnly: true, Isolation: orc8rStorage.LevelRepeatableRead})
+	if err != nil {
+		return err
+	}
+	defer storage.RollbackLogOnError(store)
+
+	// Physical IDs are unique across networks, so lookups by physical ID
+	// aren't scoped to the request's network
+	if req.Filter.PhysicalID == nil {
+		loaded, err := store.LoadNetworks(storage.NetworkLoadFilter{Ids: []string{req.NetworkID}}, storage.NetworkLoadCriteria{})
+		if err != nil {
+			return err
+		}
+		if len(loaded.Networks) == 0 {
+			return status.Errorf(codes.NotFound, "network %s not found", req.NetworkID)
+		}
+	}
+
+	filter := *req.Filter
+	filter.PageToken = ""
+	found := map[orc8rStorage.TypeAndKey]bool{}
+	for {
+		page, err := store.LoadEntities(req.NetworkID, filter, *req.Criteria)
+		if err != nil {
+			return err
+		}
+		for _, ent := range page.Entities {
+			found[ent.GetTypeAndKey()] = true
+		}
+		if page.NextPageToken == "" {
+			page.EntitiesNotFound = []*storage.EntityID{}
+			for _, id := range req.Filter.IDs {
+				if !found[orc8rStorage.TypeAndKey{Type: id.Type, Key: id.Key}] {
+					page.EntitiesNotFound = append(page.EntitiesNotFound, id)
+				}
+			}
+			return stream.Send(&page)
+		}
+		if err := stream.Send(&page); err != nil {
+			return err
+		}
+		filter.PageToken = page.NextPageToken
+	}
+}
+
 func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *protos.WriteEntitiesRequest) (*protos.WriteEntitiesResponse, error) {
 	emptyRes := &protos.WriteEntitiesResponse{}
 	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
@@ -159,6 +262,7 @@ func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *p
 	ret := &protos.WriteEntitiesResponse{
 		UpdatedEntities: map[string]*storage.NetworkEntity{},
 	}
+	events := []*protos.EntityEvent{}
 	for _, write := range req.Writes {
 		switch op := write.Request.(type) {
 		case *protos.WriteEntityRequest_Create:
@@ -168,19 +272,30 @@ func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *p
 				return emptyRes, status.Error(codes.Internal, err.Error())
 			}
 			ret.CreatedEntities = append(ret.CreatedEntities, createdEnt)
+			events = append(events, newEntityEvent(protos.ChangeType_CREATED, req.NetworkID, createdEnt))
 		case *protos.WriteEntityRequest_Update:
 			updatedEnt, err := updateEntity(store, req.NetworkID, op.Update)
 			if err != nil {
 				storage.RollbackLogOnError(store)
+				if _, isStatus := status.FromError(err); isStatus {
+					return emptyRes, err
+				}
 				return emptyRes, status.Error(codes.Internal, err.Error())
 			}
 			ret.UpdatedEntities[updatedEnt.Key] = updatedEnt
+			if !op.Update.IsVersionCheck() {
+				events = append(events, newEntityUpdateEvent(req.NetworkID, op.Update, updatedEnt))
+			}
 		default:
 			storage.RollbackLogOnError(store)
 			return emptyRes, status.Error(codes.InvalidArgument, fmt.Sprintf("write request %T not recognized", write))
 		}
 	}
-	return ret, store.Commit()
+	err = srv.watchers.commitChanges(store, nil, events)
+	if err != nil {
+		return emptyRes, err
+	}
+	return ret, nil
 }
 
 func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *protos.CreateEntitiesRequest) (*protos.CreateEntitiesResponse, error) {
@@ -191,6 +306,7 @@ func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *
 	}
 
 	createdEntities := []*storage.NetworkEntity{}
+	events := []*protos.EntityEvent{}
 	for _, entity := range req.Entities {
 		createdEntity, err := createEntity(store, req.NetworkID, entity)
 		if err != nil {
@@ -198,8 +314,13 @@ func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *
 			return emptyRes, err
 		}
 		createdEntities = append(createdEntities, createdEntity)
+		events = append(events, newEntityEvent(protos.ChangeType_CREATED, req.NetworkID, createdEntity))
+	}
+	err = srv.watchers.commitChanges(store, nil, events)
+	if err != nil {
+		return emptyRes, err
 	}
-	return &protos.CreateEntitiesResponse{CreatedEntities: createdEntities}, store.Commit()
+	return &protos.CreateEntitiesResponse{CreatedEntities: createdEntities}, nil
 }
 
 func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *protos.UpdateEntitiesRequest) (*protos.UpdateEntitiesResponse, error) {
@@ -210,6 +331,7 @@ func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *
 	}
 
 	updatedEntities := map[string]*storage.NetworkEntity{}
+	events := []*protos.EntityEvent{}
 	for _, update := range req.Updates {
 		updatedEntity, err := updateEntity(store, req.NetworkID, update)
 		if err != nil {
@@ -217,8 +339,15 @@ func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *
 			return emptyRes, err
 		}
 		updatedEntities[update.Key] = updatedEntity
+		if !update.IsVersionCheck() {
+			events = append(events, newEntityUpdateEvent(req.NetworkID, update, updatedEntity))
+		}
+	}
+	err = srv.watchers.commitChanges(store, nil, events)
+	if err != nil {
+		return emptyRes, err
 	}
-	return &protos.UpdateEntitiesResponse{UpdatedEntities: updatedEntities}, store.Commit()
+	return &protos.UpdateEntitiesResponse{UpdatedEntities: updatedEntities}, nil
 }
 
 func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *protos.DeleteEntitiesRequest) (*commonProtos.Void, error) {
@@ -228,6 +357,7 @@ func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *
 		return void, err
 	}
 
+	events := []*protos.EntityEvent{}
 	for _, entityID := range req.ID {
 		request := storage.EntityUpdateCriteria{
 			Type:         entityID.Type,
@@ -239,8 +369,333 @@ func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *
 			storage.RollbackLogOnError(store)
 			return void, err
 		}
+		events = append(events, newEntityEvent(protos.ChangeType_DELETED, req.NetworkID, &storage.NetworkEntity{Type: entityID.Type, Key: entityID.Key}))
+	}
+	return void, srv.watchers.commitChanges(store, nil, events)
+}
+
+func (srv *nbConfiguratorServicer) WatchEntities(req *protos.WatchEntitiesRequest, stream protos.NorthboundConfigurator_WatchEntitiesServer) error {
+	if req.NetworkID == "" {
+		return status.Error(codes.InvalidArgument, "network ID must be specified")
+	}
+	watcher := srv.watchers.watchEntities(req.NetworkID, req.Types)
+	defer srv.watchers.entities.Unsubscribe(watcher)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, reload entities and watch again")
+			}
+			if err := stream.Send(event.(*protos.EntityEvent)); err != nil {
+				return err
+			}
+		}
 	}
-	return void, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) WatchNetworks(req *protos.WatchNetworksRequest, stream protos.NorthboundConfigurator_WatchNetworksServer) error {
+	watcher := srv.watchers.watchNetworks(req.NetworkIDs)
+	defer srv.watchers.networks.Unsubscribe(watcher)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, reload networks and watch again")
+			}
+			if err := stream.Send(event.(*protos.NetworkEvent)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (srv *nbConfiguratorServicer) ExportNetwork(context context.Context, req *protos.ExportNetworkRequest) (*protos.NetworkArchive, error) {
+	emptyRes := &protos.NetworkArchive{}
+	if req.NetworkID == "" {
+		return emptyRes, status.Error(codes.InvalidArgument, "network ID must be specified")
+	}
+	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	loadedNetworks, err := store.LoadNetworks(storage.NetworkLoadFilter{Ids: []string{req.NetworkID}}, storage.FullNetworkLoadCriteria)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return emptyRes, err
+	}
+	if len(loadedNetworks.Networks) == 0 {
+		storage.RollbackLogOnError(store)
+		return emptyRes, status.Errorf(codes.NotFound, "network %s not found", req.NetworkID)
+	}
+	loadedEnts, err := store.LoadEntities(
+		req.NetworkID,
+		storage.EntityLoadFilter{},
+		storage.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsFromThis: true, LoadPermissions: true},
+	)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return emptyRes, err
+	}
+	err = store.Commit()
+	if err != nil {
+		return emptyRes, err
+	}
+
+	network := loadedNetworks.Networks[0]
+	archivedNetwork := &protos.ArchivedNetwork{
+		Id:          network.ID,
+		Type:        network.Type,
+		Name:        network.Name,
+		Description: network.Description,
+		Configs:     make(map[string]*protos.ArchivedConfig, len(network.Configs)),
+	}
+	for configType, config := range network.Configs {
+		archivedNetwork.Configs[configType] = configurator.NewArchivedConfig(config)
+	}
+	entities := make([]*protos.ArchivedEntity, 0, len(loadedEnts.Entities))
+	for _, ent := range loadedEnts.Entities {
+		entities = append(entities, &protos.ArchivedEntity{
+			Type:         ent.Type,
+			Key:          ent.Key,
+			Name:         ent.Name,
+			Description:  ent.Description,
+			PhysicalId:   ent.PhysicalID,
+			Config:       configurator.NewArchivedConfig(ent.Config),
+			Associations: ent.Associations,
+			Permissions:  ent.Permissions,
+		})
+	}
+	// Keep exports of the same network diffable
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Type != entities[j].Type {
+			return entities[i].Type < entities[j].Type
+		}
+		return entities[i].Key < entities[j].Key
+	})
+	return &protos.NetworkArchive{
+		FormatVersion: configurator.NetworkArchiveFormatVersion,
+		ExportedAt:    clock.Now().Unix(),
+		Network:       archivedNetwork,
+		Entities:      entities,
+	}, nil
+}
+
+func (srv *nbConfiguratorServicer) ImportNetwork(context context.Context, req *protos.ImportNetworkRequest) (*commonProtos.Void, error) {
+	void := &commonProtos.Void{}
+	if err := configurator.ValidateNetworkArchive(req.Archive); err != nil {
+		return void, status.Error(codes.InvalidArgument, err.Error())
+	}
+	network, entities, err := getArchivedNetworkAndEntities(req.Archive)
+	if err != nil {
+		return void, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.NetworkID != "" {
+		network.ID = req.NetworkID
+	}
+	if req.DropPhysicalIds {
+		for _, ent := range entities {
+			ent.PhysicalID = ""
+		}
+	}
+	if err := networkConfigsAreValid(network.Configs); err != nil {
+		return void, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, ent := range entities {
+		if err := entityConfigIsValid(ent.Type, ent.Config); err != nil {
+			return void, status.Errorf(codes.InvalidArgument, "invalid config for entity %s: %s", ent.GetTypeAndKey(), err)
+		}
+	}
+
+	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return void, err
+	}
+	networkEvents := []*protos.NetworkEvent{}
+	entityEvents := []*protos.EntityEvent{}
+	if req.Overwrite {
+		// Entities are deleted along with the network
+		entityEvents, err = getNetworkEntityDeleteEvents(store, network.ID)
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+		err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: network.ID, DeleteNetwork: true}})
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+		networkEvents = append(networkEvents, &protos.NetworkEvent{Type: protos.ChangeType_DELETED, Network: &storage.Network{ID: network.ID}})
+	}
+	// Physical IDs are unique across networks, so an archive can't be
+	// imported next to the network it was exported from unless the physical
+	// IDs are dropped
+	if err := checkPhysicalIDsAvailable(store, entities); err != nil {
+		storage.RollbackLogOnError(store)
+		return void, err
+	}
+	createdNetwork, err := store.CreateNetwork(*network)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return void, err
+	}
+	networkEvents = append(networkEvents, &protos.NetworkEvent{Type: protos.ChangeType_CREATED, Network: &createdNetwork})
+
+	// Create all entities before any associations so we don't have to order
+	// the creations by the graph's topology
+	for _, ent := range entities {
+		entToCreate := *ent
+		entToCreate.Associations = nil
+		createdEnt, err := store.CreateEntity(network.ID, entToCreate)
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+		entityEvents = append(entityEvents, newEntityEvent(protos.ChangeType_CREATED, network.ID, &createdEnt))
+	}
+	for _, ent := range entities {
+		if len(ent.Associations) == 0 {
+			continue
+		}
+		_, err = store.UpdateEntity(network.ID, storage.EntityUpdateCriteria{
+			Type:              ent.Type,
+			Key:               ent.Key,
+			AssociationsToAdd: ent.Associations,
+		})
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return void, err
+		}
+	}
+
+	err = srv.watchers.commitChanges(store, networkEvents, entityEvents)
+	if err != nil {
+		return void, err
+	}
+	return void, nil
+}
+
+// getArchivedNetworkAndEntities converts the network and entities of an
+// archive to their storage representations.
+func getArchivedNetworkAndEntities(archive *protos.NetworkArchive) (*storage.Network, []*storage.NetworkEntity, error) {
+	archivedNetwork := archive.Network
+	network := &storage.Network{
+		ID:          archivedNetwork.Id,
+		Type:        archivedNetwork.Type,
+		Name:        archivedNetwork.Name,
+		Description: archivedNetwork.Description,
+		Configs:     make(map[string][]byte, len(archivedNetwork.Configs)),
+	}
+	for configType, archivedConfig := range archivedNetwork.Configs {
+		config, err := configurator.GetArchivedConfigBytes(archivedConfig)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid network config %s", configType)
+		}
+		network.Configs[configType] = config
+	}
+
+	entities := make([]*storage.NetworkEntity, 0, len(archive.Entities))
+	for _, archivedEnt := range archive.Entities {
+		config, err := configurator.GetArchivedConfigBytes(archivedEnt.Config)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid config for entity (%s, %s)", archivedEnt.Type, archivedEnt.Key)
+		}
+		entities = append(entities, &storage.NetworkEntity{
+			Type:         archivedEnt.Type,
+			Key:          archivedEnt.Key,
+			Name:         archivedEnt.Name,
+			Description:  archivedEnt.Description,
+			PhysicalID:   archivedEnt.PhysicalId,
+			Config:       config,
+			Associations: archivedEnt.Associations,
+			Permissions:  archivedEnt.Permissions,
+		})
+	}
+	return network, entities, nil
+}
+
+// checkPhysicalIDsAvailable returns an ALREADY_EXISTS status error if any of
+// the entities' physical IDs is already registered to an entity.
+func checkPhysicalIDsAvailable(store storage.ConfiguratorStorage, entities []*storage.NetworkEntity) error {
+	conflicts := []string{}
+	for _, ent := range entities {
+		if ent.PhysicalID == "" {
+			continue
+		}
+		loaded, err := store.LoadEntities("", storage.EntityLoadFilter{PhysicalID: &wrappers.StringValue{Value: ent.PhysicalID}}, storage.EntityLoadCriteria{})
+		if err != nil {
+			return err
+		}
+		for _, existing := range loaded.Entities {
+			conflicts = append(conflicts, fmt.Sprintf("%s (network %s)", ent.PhysicalID, existing.NetworkID))
+		}
+	}
+	if len(conflicts) > 0 {
+		return status.Errorf(codes.AlreadyExists, "physical IDs are already registered: %s; import without physical IDs or remove the existing entities first", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// getNetworkUpdateEvents returns the events to publish for a set of network
+// updates. It must be called after the updates have been applied in the
+// transaction, so the events carry the updated networks.
+func getNetworkUpdateEvents(store storage.ConfiguratorStorage, updates []storage.NetworkUpdateCriteria) ([]*protos.NetworkEvent, error) {
+	events := []*protos.NetworkEvent{}
+	updatedIDs := []string{}
+	for _, update := range updates {
+		if update.DeleteNetwork {
+			events = append(events, &protos.NetworkEvent{Type: protos.ChangeType_DELETED, Network: &storage.Network{ID: update.ID}})
+		} else {
+			updatedIDs = append(updatedIDs, update.ID)
+		}
+	}
+	if len(updatedIDs) == 0 {
+		return events, nil
+	}
+
+	loaded, err := store.LoadNetworks(storage.NetworkLoadFilter{Ids: updatedIDs}, storage.FullNetworkLoadCriteria)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range loaded.Networks {
+		events = append(events, &protos.NetworkEvent{Type: protos.ChangeType_UPDATED, Network: network})
+	}
+	return events, nil
+}
+
+// getNetworkEntityDeleteEvents returns the deletion events of all entities of
+// a network. It must be called before the network is deleted.
+func getNetworkEntityDeleteEvents(store storage.ConfiguratorStorage, networkID string) ([]*protos.EntityEvent, error) {
+	loaded, err := store.LoadEntities(networkID, storage.EntityLoadFilter{}, storage.EntityLoadCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*protos.EntityEvent, 0, len(loaded.Entities))
+	for _, ent := range loaded.Entities {
+		events = append(events, newEntityEvent(protos.ChangeType_DELETED, networkID, &storage.NetworkEntity{Type: ent.Type, Key: ent.Key}))
+	}
+	return events, nil
+}
+
+func newEntityEvent(changeType protos.ChangeType, networkID string, entity *storage.NetworkEntity) *protos.EntityEvent {
+	return &protos.EntityEvent{Type: changeType, NetworkID: networkID, Entity: entity}
+}
+
+func newEntityUpdateEvent(networkID string, update *storage.EntityUpdateCriteria, entity *storage.NetworkEntity) *protos.EntityEvent {
+	if update.DeleteEntity {
+		return newEntityEvent(protos.ChangeType_DELETED, networkID, &storage.NetworkEntity{Type: update.Type, Key: update.Key})
+	}
+	return newEntityEvent(protos.ChangeType_UPDATED, networkID, entity)
 }
 
 func networkConfigsAreValid(configs map[string][]byte) error {
@@ -277,6 +732,9 @@ func updateEntity(store storage.ConfiguratorStorage, networkID string, update *s
 	}
 
 	updatedEntity, err := store.UpdateEntity(networkID, *update)
+	if errors.Cause(err) == storage.ErrVersionMismatch {
+		return nil, status.Errorf(codes.FailedPrecondition, "entity (%s, %s) was modified concurrently", update.Type, update.Key)
+	}
 	if err != nil {
 		return nil, err
 	}
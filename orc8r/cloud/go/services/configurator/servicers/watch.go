@@ -0,0 +1,237 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"time"
+
+	"magma/orc8r/cloud/go/broadcast"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	orc8rStorage "magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+const (
+	// watchBufferSize is the number of events which can be queued for a
+	// watcher before it is considered to have fallen behind and is
+	// disconnected.
+	watchBufferSize = 1024
+
+	// changePollInterval is how often the change log is polled for the
+	// changes committed through other configurator replicas. Changes
+	// committed through this replica are polled right away.
+	changePollInterval = time.Second
+	// changePageSize is the maximum number of changes read from the change
+	// log at once.
+	changePageSize = 1000
+
+	// changeRetention is how long changes are kept in the change log. A
+	// replica which can't read the change log for longer than that drops
+	// all of its watchers.
+	changeRetention     = time.Hour
+	changePruneInterval = 10 * time.Minute
+)
+
+// watchBroadcaster fans out configurator change events to all open watch
+// streams on this configurator replica. Write transactions append their
+// events to the change log shared by all replicas, and every replica polls
+// the change log in sequence order, so watchers see the writes of every
+// replica in the order they were committed.
+type watchBroadcaster struct {
+	factory  storage.ConfiguratorStorageFactory
+	entities *broadcast.Broadcaster
+	networks *broadcast.Broadcaster
+
+	// pollNow wakes the poller up after a change is committed through this
+	// replica
+	pollNow chan struct{}
+	// lastSeq is the sequence number of the last published change. It's
+	// only accessed by the poller.
+	lastSeq uint64
+}
+
+// newWatchBroadcaster returns a watchBroadcaster which publishes the changes
+// appended to the change log from now on.
+func newWatchBroadcaster(factory storage.ConfiguratorStorageFactory) (*watchBroadcaster, error) {
+	b := &watchBroadcaster{
+		factory:  factory,
+		entities: broadcast.NewBroadcaster(watchBufferSize),
+		networks: broadcast.NewBroadcaster(watchBufferSize),
+		pollNow:  make(chan struct{}, 1),
+	}
+	store, err := factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	b.lastSeq, err = store.GetLatestChangeSeq()
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return nil, err
+	}
+	err = store.Commit()
+	if err != nil {
+		return nil, err
+	}
+	go b.run()
+	return b, nil
+}
+
+func (b *watchBroadcaster) watchEntities(networkID string, types []string) *broadcast.Subscription {
+	return b.entities.Subscribe(func(e interface{}) bool {
+		event := e.(*protos.EntityEvent)
+		if event.NetworkID != networkID {
+			return false
+		}
+		return len(types) == 0 || funk.ContainsString(types, event.Entity.Type)
+	})
+}
+
+func (b *watchBroadcaster) watchNetworks(networkIDs []string) *broadcast.Subscription {
+	return b.networks.Subscribe(func(e interface{}) bool {
+		event := e.(*protos.NetworkEvent)
+		return len(networkIDs) == 0 || funk.ContainsString(networkIDs, event.Network.ID)
+	})
+}
+
+// commitChanges appends the network and entity events to the change log, in
+// that order, and commits the transaction. The transaction is rolled back if
+// the events can't be appended.
+func (b *watchBroadcaster) commitChanges(store storage.ConfiguratorStorage, networkEvents []*protos.NetworkEvent, entityEvents []*protos.EntityEvent) error {
+	changes := make([][]byte, 0, len(networkEvents)+len(entityEvents))
+	for _, event := range networkEvents {
+		change, err := proto.Marshal(&protos.ConfigChange{Event: &protos.ConfigChange_Network{Network: event}})
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return errors.Wrap(err, "failed to marshal network event")
+		}
+		changes = append(changes, change)
+	}
+	for _, event := range entityEvents {
+		change, err := proto.Marshal(&protos.ConfigChange{Event: &protos.ConfigChange_Entity{Entity: event}})
+		if err != nil {
+			storage.RollbackLogOnError(store)
+			return errors.Wrap(err, "failed to marshal entity event")
+		}
+		changes = append(changes, change)
+	}
+	err := store.AppendChanges(changes)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return err
+	}
+	err = store.Commit()
+	if err != nil {
+		return err
+	}
+	if len(changes) != 0 {
+		select {
+		case b.pollNow <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (b *watchBroadcaster) run() {
+	pollTicker := time.NewTicker(changePollInterval)
+	defer pollTicker.Stop()
+	pruneTicker := time.NewTicker(changePruneInterval)
+	defer pruneTicker.Stop()
+	for {
+		select {
+		case <-pollTicker.C:
+		case <-b.pollNow:
+		case <-pruneTicker.C:
+			if err := b.prune(); err != nil {
+				glog.Errorf("failed to prune configurator change log: %s", err)
+			}
+			continue
+		}
+		if err := b.poll(); err != nil {
+			glog.Errorf("failed to poll configurator change log: %s", err)
+		}
+	}
+}
+
+// poll publishes the changes appended to the change log since the last poll.
+func (b *watchBroadcaster) poll() error {
+	for {
+		changes, err := b.loadChanges()
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		// The changes following the last published one were pruned before
+		// they could be published: the watchers have to reload
+		if changes[0].Seq != b.lastSeq+1 {
+			glog.Errorf("configurator changes %d to %d were pruned before being published, dropping all watchers", b.lastSeq+1, changes[0].Seq-1)
+			b.entities.DropAll()
+			b.networks.DropAll()
+		}
+		b.publish(changes)
+		b.lastSeq = changes[len(changes)-1].Seq
+		if len(changes) < changePageSize {
+			return nil
+		}
+	}
+}
+
+func (b *watchBroadcaster) loadChanges() ([]storage.Change, error) {
+	store, err := b.factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	changes, err := store.LoadChanges(b.lastSeq, changePageSize)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return nil, err
+	}
+	return changes, store.Commit()
+}
+
+func (b *watchBroadcaster) publish(changes []storage.Change) {
+	var entityEvents, networkEvents []interface{}
+	for _, change := range changes {
+		configChange := &protos.ConfigChange{}
+		if err := proto.Unmarshal(change.Event, configChange); err != nil {
+			glog.Errorf("failed to unmarshal configurator change %d: %s", change.Seq, err)
+			continue
+		}
+		switch event := configChange.Event.(type) {
+		case *protos.ConfigChange_Entity:
+			entityEvents = append(entityEvents, event.Entity)
+		case *protos.ConfigChange_Network:
+			networkEvents = append(networkEvents, event.Network)
+		}
+	}
+	b.networks.Publish(networkEvents...)
+	b.entities.Publish(entityEvents...)
+}
+
+func (b *watchBroadcaster) prune() error {
+	store, err := b.factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return err
+	}
+	err = store.DeleteChangesBefore(clock.Now().Add(-changeRetention).Unix())
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return err
+	}
+	return store.Commit()
+}
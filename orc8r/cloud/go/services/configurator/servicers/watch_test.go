@@ -0,0 +1,101 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/broadcast"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Watchers of a replica receive the changes committed through every replica
+// sharing the database
+func TestWatchBroadcaster_Replicas(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	require.NoError(t, err)
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &storage.DefaultIDGenerator{}, sqorc.GetSqlBuilder())
+	require.NoError(t, factory.InitializeServiceStorage())
+
+	replicaA, err := NewNorthboundConfiguratorServicer(factory)
+	require.NoError(t, err)
+	replicaB, err := NewNorthboundConfiguratorServicer(factory)
+	require.NoError(t, err)
+	watchers := replicaB.(*nbConfiguratorServicer).watchers
+	networkWatcher := watchers.watchNetworks(nil)
+	entityWatcher := watchers.watchEntities("n1", nil)
+
+	ctx := context.Background()
+	_, err = replicaA.CreateNetworks(ctx, &protos.CreateNetworksRequest{Networks: []*storage.Network{{ID: "n1"}}})
+	require.NoError(t, err)
+	_, err = replicaA.CreateEntities(ctx, &protos.CreateEntitiesRequest{
+		NetworkID: "n1",
+		Entities:  []*storage.NetworkEntity{{Type: "foo", Key: "k1"}, {Type: "bar", Key: "k2"}},
+	})
+	require.NoError(t, err)
+	networkEvent := nextEvent(t, networkWatcher).(*protos.NetworkEvent)
+	assert.Equal(t, protos.ChangeType_CREATED, networkEvent.Type)
+	assert.Equal(t, "n1", networkEvent.Network.ID)
+	for _, key := range []string{"k1", "k2"} {
+		entityEvent := nextEvent(t, entityWatcher).(*protos.EntityEvent)
+		assert.Equal(t, protos.ChangeType_CREATED, entityEvent.Type)
+		assert.Equal(t, key, entityEvent.Entity.Key)
+	}
+
+	// Deleting a network deletes all of its entities
+	_, err = replicaA.DeleteNetworks(ctx, &protos.DeleteNetworksRequest{NetworkIDs: []string{"n1"}})
+	require.NoError(t, err)
+	networkEvent = nextEvent(t, networkWatcher).(*protos.NetworkEvent)
+	assert.Equal(t, protos.ChangeType_DELETED, networkEvent.Type)
+	assert.Equal(t, "n1", networkEvent.Network.ID)
+	deleted := map[string]string{}
+	for i := 0; i < 2; i++ {
+		entityEvent := nextEvent(t, entityWatcher).(*protos.EntityEvent)
+		assert.Equal(t, protos.ChangeType_DELETED, entityEvent.Type)
+		deleted[entityEvent.Entity.Key] = entityEvent.Entity.Type
+	}
+	assert.Equal(t, map[string]string{"k1": "foo", "k2": "bar"}, deleted)
+
+	// Watchers are dropped when the changes they missed were pruned
+	lagging := &watchBroadcaster{
+		factory:  factory,
+		entities: broadcast.NewBroadcaster(watchBufferSize),
+		networks: broadcast.NewBroadcaster(watchBufferSize),
+	}
+	laggingWatcher := lagging.watchNetworks(nil)
+	store, err := factory.StartTransaction(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteChangesBefore(time.Now().Add(time.Minute).Unix()))
+	require.NoError(t, store.Commit())
+	_, err = replicaA.CreateNetworks(ctx, &protos.CreateNetworksRequest{Networks: []*storage.Network{{ID: "n2"}}})
+	require.NoError(t, err)
+	require.NoError(t, lagging.poll())
+	_, ok := <-laggingWatcher.Events()
+	assert.False(t, ok)
+	assert.Equal(t, "n2", nextEvent(t, networkWatcher).(*protos.NetworkEvent).Network.ID)
+}
+
+func nextEvent(t *testing.T, s *broadcast.Subscription) interface{} {
+	select {
+	case event, ok := <-s.Events():
+		require.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
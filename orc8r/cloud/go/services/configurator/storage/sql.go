@@ -238,6 +238,11 @@ func (fact *sqlConfiguratorStorageFactory) InitializeServiceStorage() (err error
 		return
 	}
 
+	err = fact.initializeChangeLog(tx)
+	if err != nil {
+		return
+	}
+
 	// Create internal network(s)
 	_, err = fact.builder.Insert(networksTable).
 		Columns(nwIDCol, nwTypeCol, nwNameCol, nwDescCol).
@@ -449,7 +454,7 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 	// be smart here and only load (type, key) for PKs which we don't know.
 	// Finally, we will update the entity objects to return with their edges.
 
-	entsByPk, err := store.loadFromEntitiesTable(networkID, filter, loadCriteria)
+	entsByPk, nextPageToken, err := store.loadFromEntitiesTable(networkID, filter, loadCriteria)
 	if err != nil {
 		return ret, err
 	}
@@ -470,7 +475,11 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 	for _, ent := range entsByPk {
 		ret.Entities = append(ret.Entities, ent)
 	}
-	ret.EntitiesNotFound = calculateEntitiesNotFound(entsByPk, filter.IDs)
+	ret.NextPageToken = nextPageToken
+	// Requested IDs outside of the loaded page aren't necessarily missing
+	if filter.PageSize == 0 {
+		ret.EntitiesNotFound = calculateEntitiesNotFound(entsByPk, filter.IDs)
+	}
 
 	// Sort entities for deterministic returns
 	entComparator := func(a, b *NetworkEntity) bool {
@@ -542,22 +551,43 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 		return emptyRet, errors.Wrap(err, "failed to load entity being updated")
 	}
 	if entToUpdate == nil {
+		// The entity that the caller expected to delete is already gone
+		if update.ExpectedVersion != nil {
+			return emptyRet, ErrVersionMismatch
+		}
 		return emptyRet, nil
 	}
+	if update.ExpectedVersion != nil && entToUpdate.Version != update.ExpectedVersion.Value {
+		return emptyRet, ErrVersionMismatch
+	}
+	// Version checks don't write anything, so they don't bump the version
+	if update.IsVersionCheck() {
+		entToUpdate.NetworkID = networkID
+		return entToUpdate.NetworkEntity, nil
+	}
 
 	if update.DeleteEntity {
 		// Cascading FK relations in the schema will handle the other tables
-		_, err := store.builder.Delete(entityTable).
-			Where(sq.And{
-				sq.Eq{entNidCol: networkID},
-				sq.Eq{entTypeCol: update.Type},
-				sq.Eq{entKeyCol: update.Key},
-			}).
+		whereClause := sq.And{
+			sq.Eq{entNidCol: networkID},
+			sq.Eq{entTypeCol: update.Type},
+			sq.Eq{entKeyCol: update.Key},
+		}
+		if update.ExpectedVersion != nil {
+			whereClause = append(whereClause, sq.Eq{entVerCol: update.ExpectedVersion.Value})
+		}
+		res, err := store.builder.Delete(entityTable).
+			Where(whereClause).
 			RunWith(store.tx).
 			Exec()
 		if err != nil {
 			return emptyRet, errors.Wrapf(err, "failed to delete entity (%s, %s)", update.Type, update.Key)
 		}
+		if update.ExpectedVersion != nil {
+			if err := checkVersionedWrite(res); err != nil {
+				return emptyRet, err
+			}
+		}
 
 		// Deleting a node could partition its graph
 		err = store.fixGraph(networkID, entToUpdate.GraphID, entToUpdate)
@@ -598,7 +628,7 @@ func (store *sqlConfiguratorStorage) LoadGraphForEntity(networkID string, entity
 
 	// We just care about getting the graph ID off this entity so use an empty
 	// load criteria
-	loadResult, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: []*EntityID{&entityID}}, EntityLoadCriteria{})
+	loadResult, _, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: []*EntityID{&entityID}}, EntityLoadCriteria{})
 	if err != nil {
 		return EntityGraph{}, errors.Wrap(err, "failed to load entity for graph query")
 	}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// The change log is shared by all configurator replicas. Every write
+// transaction appends its change events to cfg_changes, numbered by the
+// single counter row of cfg_change_seq. Incrementing the counter locks its
+// row until the transaction ends, so sequence numbers are assigned in commit
+// order and a rolled back transaction leaves no gap behind.
+const (
+	changeTable    = "cfg_changes"
+	changeSeqTable = "cfg_change_seq"
+
+	chSeqCol   = "seq"
+	chTimeCol  = "created_at"
+	chEventCol = "event"
+
+	csIDCol  = "id"
+	csSeqCol = "seq"
+
+	changeSeqRowID = "changes"
+
+	// changeInsertBatchSize is the maximum number of changes inserted by a
+	// single statement, to stay below the databases' bind variable limits
+	changeInsertBatchSize = 100
+)
+
+func (fact *sqlConfiguratorStorageFactory) initializeChangeLog(tx *sql.Tx) error {
+	_, err := fact.builder.CreateTable(changeTable).
+		IfNotExists().
+		Column(chSeqCol).Type(sqorc.ColumnTypeBigInt).PrimaryKey().EndColumn().
+		Column(chTimeCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(chEventCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to create change log table")
+	}
+
+	_, err = fact.builder.CreateTable(changeSeqTable).
+		IfNotExists().
+		Column(csIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(csSeqCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to create change sequence table")
+	}
+
+	_, err = fact.builder.Insert(changeSeqTable).
+		Columns(csIDCol, csSeqCol).
+		Values(changeSeqRowID, 0).
+		OnConflict(nil, csIDCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to create change sequence")
+	}
+	return nil
+}
+
+func (store *sqlConfiguratorStorage) AppendChanges(events [][]byte) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := store.builder.Update(changeSeqTable).
+		Set(csSeqCol, sq.Expr(fmt.Sprintf("%s+?", csSeqCol), len(events))).
+		Where(sq.Eq{csIDCol: changeSeqRowID}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to increment change sequence")
+	}
+	lastSeq, err := store.GetLatestChangeSeq()
+	if err != nil {
+		return err
+	}
+
+	firstSeq := lastSeq - uint64(len(events)) + 1
+	now := clock.Now().Unix()
+	for start := 0; start < len(events); start += changeInsertBatchSize {
+		end := start + changeInsertBatchSize
+		if end > len(events) {
+			end = len(events)
+		}
+		insertBuilder := store.builder.Insert(changeTable).Columns(chSeqCol, chTimeCol, chEventCol)
+		for i := start; i < end; i++ {
+			insertBuilder = insertBuilder.Values(firstSeq+uint64(i), now, events[i])
+		}
+		_, err = insertBuilder.RunWith(store.tx).Exec()
+		if err != nil {
+			return errors.Wrap(err, "failed to insert changes")
+		}
+	}
+	return nil
+}
+
+func (store *sqlConfiguratorStorage) LoadChanges(afterSeq uint64, limit uint64) ([]Change, error) {
+	rows, err := store.builder.Select(chSeqCol, chEventCol).
+		From(changeTable).
+		Where(sq.Gt{chSeqCol: afterSeq}).
+		OrderBy(chSeqCol).
+		Limit(limit).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query changes")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadChanges")
+
+	var ret []Change
+	for rows.Next() {
+		var change Change
+		if err := rows.Scan(&change.Seq, &change.Event); err != nil {
+			return nil, errors.Wrap(err, "failed to scan change")
+		}
+		ret = append(ret, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "sql rows err")
+	}
+	return ret, nil
+}
+
+func (store *sqlConfiguratorStorage) GetLatestChangeSeq() (uint64, error) {
+	var seq uint64
+	err := store.builder.Select(csSeqCol).
+		From(changeSeqTable).
+		Where(sq.Eq{csIDCol: changeSeqRowID}).
+		RunWith(store.tx).
+		QueryRow().
+		Scan(&seq)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query change sequence")
+	}
+	return seq, nil
+}
+
+func (store *sqlConfiguratorStorage) DeleteChangesBefore(unixTime int64) error {
+	_, err := store.builder.Delete(changeTable).
+		Where(sq.Lt{chTimeCol: unixTime}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete changes")
+	}
+	return nil
+}
@@ -26,7 +26,7 @@ type internalEntityGraph struct {
 // This function will NOT fill entities with associations.
 func (store *sqlConfiguratorStorage) loadGraphInternal(networkID string, graphID string, criteria EntityLoadCriteria) (internalEntityGraph, error) {
 	loadFilter := EntityLoadFilter{GraphID: &wrappers.StringValue{Value: graphID}}
-	entsByPk, err := store.loadFromEntitiesTable(networkID, loadFilter, criteria)
+	entsByPk, _, err := store.loadFromEntitiesTable(networkID, loadFilter, criteria)
 	if err != nil {
 		return internalEntityGraph{}, errors.Wrap(err, "failed to load entities for graph")
 	}
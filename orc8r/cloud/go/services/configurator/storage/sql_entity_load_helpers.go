@@ -10,6 +10,7 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"sort"
 	"strings"
@@ -19,18 +20,35 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 )
 
-func (store *sqlConfiguratorStorage) loadFromEntitiesTable(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) (map[string]*NetworkEntity, error) {
+func (store *sqlConfiguratorStorage) loadFromEntitiesTable(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) (map[string]*NetworkEntity, string, error) {
 	// Pointer values because we're modifying entities in-place with ACLs (LEFT JOIN)
 	entsByPk := map[string]*NetworkEntity{}
 
 	selectBuilder := store.getLoadEntitiesSelectBuilder(networkID, filter, criteria)
+
+	// For paginated loads, we select the PKs of the page first so the LIMIT
+	// isn't applied to the rows multiplied by the ACL join
+	nextPageToken := ""
+	if filter.PageSize > 0 {
+		pagePks, pageToken, err := store.loadEntityPagePks(networkID, filter)
+		if err != nil {
+			return entsByPk, "", err
+		}
+		if len(pagePks) == 0 {
+			return entsByPk, "", nil
+		}
+		nextPageToken = pageToken
+		selectBuilder = selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entPkCol): pagePks})
+	}
+
 	rows, err := selectBuilder.RunWith(store.tx).Query()
 	if err != nil {
-		return entsByPk, errors.Wrap(err, "error querying for entities")
+		return entsByPk, "", errors.Wrap(err, "error querying for entities")
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -41,10 +59,10 @@ func (store *sqlConfiguratorStorage) loadFromEntitiesTable(networkID string, fil
 	for rows.Next() {
 		err = scanNextEntityRow(rows, criteria, entsByPk)
 		if err != nil {
-			return entsByPk, err
+			return entsByPk, "", err
 		}
 	}
-	return entsByPk, nil
+	return entsByPk, nextPageToken, nil
 }
 
 func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) sq.SelectBuilder {
@@ -58,7 +76,61 @@ func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID stri
 	if criteria.LoadPermissions {
 		selectBuilder = selectBuilder.LeftJoin(fmt.Sprintf("%s AS acl ON acl.%s = ent.%s", entityAclTable, aclEntCol, entPkCol))
 	}
+	return addLoadEntitiesFilter(selectBuilder, networkID, filter)
+}
 
+// loadEntityPagePks returns the PKs of the page of entities specified by the
+// pagination fields of the filter, as well as the token for the next page.
+// The returned token is empty if this is the last page.
+func (store *sqlConfiguratorStorage) loadEntityPagePks(networkID string, filter EntityLoadFilter) ([]string, string, error) {
+	// SELECT ent.pk, ent.type, ent.key FROM cfg_entities AS ent
+	// WHERE (...filter...)
+	// [[ AND (ent.type > $1 OR (ent.type = $1 AND ent.key > $2)) ]]
+	// ORDER BY ent.type, ent.key
+	// LIMIT $page_size + 1
+	typeCol, keyCol := fmt.Sprintf("ent.%s", entTypeCol), fmt.Sprintf("ent.%s", entKeyCol)
+	selectBuilder := store.builder.Select(fmt.Sprintf("ent.%s", entPkCol), typeCol, keyCol).
+		From(fmt.Sprintf("%s AS ent", entityTable))
+	selectBuilder = addLoadEntitiesFilter(selectBuilder, networkID, filter)
+	if filter.PageToken != "" {
+		token, err := decodeEntityPageToken(filter.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		selectBuilder = selectBuilder.Where(sq.Or{
+			sq.Gt{typeCol: token.LastIncludedType},
+			sq.And{sq.Eq{typeCol: token.LastIncludedType}, sq.Gt{keyCol: token.LastIncludedKey}},
+		})
+	}
+
+	// Select one extra row to find out if there is another page
+	rows, err := selectBuilder.
+		OrderBy(typeCol, keyCol).
+		Limit(uint64(filter.PageSize) + 1).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "error querying for entity page")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadEntities")
+
+	pks := []string{}
+	var lastTk storage.TypeAndKey
+	for rows.Next() {
+		var pk, entType, key string
+		if err := rows.Scan(&pk, &entType, &key); err != nil {
+			return nil, "", errors.Wrap(err, "error scanning entity page row")
+		}
+		if uint32(len(pks)) == filter.PageSize {
+			return pks, encodeEntityPageToken(lastTk), nil
+		}
+		pks = append(pks, pk)
+		lastTk = storage.TypeAndKey{Type: entType, Key: key}
+	}
+	return pks, "", rows.Err()
+}
+
+func addLoadEntitiesFilter(selectBuilder sq.SelectBuilder, networkID string, filter EntityLoadFilter) sq.SelectBuilder {
 	// The WHERE has ORs if specific IDs are provided
 	if !funk.IsEmpty(filter.IDs) {
 		orClause := make(sq.Or, 0, len(filter.IDs))
@@ -69,25 +141,42 @@ func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID stri
 				sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): id.Type},
 			})
 		})
-		selectBuilder = selectBuilder.Where(orClause)
-	} else {
-		if filter.PhysicalID != nil {
-			selectBuilder = selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entPidCol): filter.PhysicalID.Value})
-		} else if filter.GraphID != nil {
-			selectBuilder = selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entGidCol): filter.GraphID.Value})
-		} else {
-			andClause := sq.And{sq.Eq{fmt.Sprintf("ent.%s", entNidCol): networkID}}
-			if filter.KeyFilter != nil {
-				andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entKeyCol): filter.KeyFilter.Value})
-			}
-			if filter.TypeFilter != nil {
-				andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): filter.TypeFilter.Value})
-			}
-			selectBuilder = selectBuilder.Where(andClause)
-		}
+		return selectBuilder.Where(orClause)
+	}
+
+	if filter.PhysicalID != nil {
+		return selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entPidCol): filter.PhysicalID.Value})
+	}
+	if filter.GraphID != nil {
+		return selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entGidCol): filter.GraphID.Value})
+	}
+	andClause := sq.And{sq.Eq{fmt.Sprintf("ent.%s", entNidCol): networkID}}
+	if filter.KeyFilter != nil {
+		andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entKeyCol): filter.KeyFilter.Value})
 	}
+	if filter.TypeFilter != nil {
+		andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): filter.TypeFilter.Value})
+	}
+	return selectBuilder.Where(andClause)
+}
 
-	return selectBuilder
+func encodeEntityPageToken(lastIncluded storage.TypeAndKey) string {
+	token := &EntityPageToken{LastIncludedType: lastIncluded.Type, LastIncludedKey: lastIncluded.Key}
+	// Marshaling a message of two strings can't fail
+	marshaled, _ := proto.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(marshaled)
+}
+
+func decodeEntityPageToken(encoded string) (*EntityPageToken, error) {
+	marshaled, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	token := &EntityPageToken{}
+	if err := proto.Unmarshal(marshaled, token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return token, nil
 }
 
 func getLoadEntitiesColumns(criteria EntityLoadCriteria) []string {
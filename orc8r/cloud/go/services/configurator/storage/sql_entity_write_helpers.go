@@ -157,7 +157,7 @@ func (store *sqlConfiguratorStorage) loadEntsWithPksByTK(networkID string, tksTo
 			id.FromTypeAndKey(tk)
 			return id
 		}).Value().([]*EntityID)
-	loadedEntsByPk, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: uniqIDsToLoad}, EntityLoadCriteria{})
+	loadedEntsByPk, _, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: uniqIDsToLoad}, EntityLoadCriteria{})
 	if err != nil {
 		return ret, errors.WithStack(err)
 	}
@@ -218,7 +218,7 @@ func (store *sqlConfiguratorStorage) mergeGraphs(createdEntity entWithPk, allAss
 }
 
 func (store *sqlConfiguratorStorage) loadEntToUpdate(networkID string, update EntityUpdateCriteria) (*entWithPk, error) {
-	loadedEntByPk, err := store.loadFromEntitiesTable(
+	loadedEntByPk, _, err := store.loadFromEntitiesTable(
 		networkID,
 		EntityLoadFilter{IDs: []*EntityID{update.GetID()}},
 		EntityLoadCriteria{},
@@ -241,12 +241,17 @@ func (store *sqlConfiguratorStorage) loadEntToUpdate(networkID string, update En
 
 // entOut is an output parameter
 func (store *sqlConfiguratorStorage) processEntityFieldsUpdate(pk string, update EntityUpdateCriteria, entOut *NetworkEntity) error {
-	_, err := store.getEntityUpdateQueryBuilder(pk, update).
+	res, err := store.getEntityUpdateQueryBuilder(pk, update).
 		RunWith(store.tx).
 		Exec()
 	if err != nil {
 		return errors.Wrap(err, "failed to update entity fields")
 	}
+	if update.ExpectedVersion != nil {
+		if err := checkVersionedWrite(res); err != nil {
+			return err
+		}
+	}
 
 	if update.NewName != nil {
 		entOut.Name = (*update.NewName).Value
@@ -355,8 +360,11 @@ func (store *sqlConfiguratorStorage) processEdgeUpdates(networkID string, update
 
 func (store *sqlConfiguratorStorage) getEntityUpdateQueryBuilder(pk string, update EntityUpdateCriteria) sq.UpdateBuilder {
 	// UPDATE cfg_entities SET (name, description, physical_id, config, version) = ($1, $2, $3, $4, cfg_entities.version + 1)
-	// WHERE pk = $5
+	// WHERE pk = $5 [[ AND version = $6 ]]
 	updateBuilder := store.builder.Update(entityTable).Where(sq.Eq{entPkCol: pk})
+	if update.ExpectedVersion != nil {
+		updateBuilder = updateBuilder.Where(sq.Eq{entVerCol: update.ExpectedVersion.Value})
+	}
 	if update.NewName != nil {
 		updateBuilder = updateBuilder.Set(entNameCol, update.NewName.Value)
 	}
@@ -554,3 +562,17 @@ func toNullable(field interface{}) interface{} {
 		return field
 	}
 }
+
+// checkVersionedWrite returns ErrVersionMismatch if a write conditioned on the
+// entity's version didn't affect any rows, i.e. if the entity was changed by
+// a concurrent transaction after we loaded it.
+func checkVersionedWrite(res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get rows affected by versioned write")
+	}
+	if rowsAffected == 0 {
+		return ErrVersionMismatch
+	}
+	return nil
+}
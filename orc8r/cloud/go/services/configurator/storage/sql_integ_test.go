@@ -12,7 +12,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	"magma/orc8r/cloud/go/sqorc"
 	orc8rStorage "magma/orc8r/cloud/go/storage"
@@ -897,3 +899,209 @@ func TestSqlConfiguratorStorage_Integration(t *testing.T) {
 		allEnts,
 	)
 }
+
+func TestSqlConfiguratorStorage_Pagination(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	assert.NoError(t, factory.InitializeServiceStorage())
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	// Each entity has 2 ACLs so a LIMIT on the joined rows would be wrong
+	acls := []*storage.ACL{
+		{
+			Permission: storage.ACL_READ,
+			Type:       &storage.ACL_TypeWildcard{TypeWildcard: storage.ACL_WILDCARD_ALL},
+			Scope:      &storage.ACL_ScopeWildcard{ScopeWildcard: storage.ACL_WILDCARD_ALL},
+		},
+		{
+			Permission: storage.ACL_WRITE,
+			Type:       &storage.ACL_EntityType{EntityType: "foo"},
+			Scope:      &storage.ACL_ScopeNetworkIDs{ScopeNetworkIDs: &storage.ACL_NetworkIDs{IDs: []string{"n1"}}},
+		},
+	}
+	for _, tk := range []orc8rStorage.TypeAndKey{{Type: "foo", Key: "b"}, {Type: "bar", Key: "a"}, {Type: "foo", Key: "a"}, {Type: "foo", Key: "c"}, {Type: "bar", Key: "b"}} {
+		_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: tk.Type, Key: tk.Key, Permissions: acls})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	loadPage := func(filter storage.EntityLoadFilter) ([]orc8rStorage.TypeAndKey, string) {
+		res, err := store.LoadEntities("n1", filter, storage.EntityLoadCriteria{LoadPermissions: true})
+		assert.NoError(t, err)
+		var tks []orc8rStorage.TypeAndKey
+		for _, ent := range res.Entities {
+			assert.Len(t, ent.Permissions, 2)
+			tks = append(tks, ent.GetTypeAndKey())
+		}
+		return tks, res.NextPageToken
+	}
+
+	// Page through all entities
+	page, token := loadPage(storage.EntityLoadFilter{PageSize: 2})
+	assert.Equal(t, []orc8rStorage.TypeAndKey{{Type: "bar", Key: "a"}, {Type: "bar", Key: "b"}}, page)
+	assert.NotEmpty(t, token)
+	page, token = loadPage(storage.EntityLoadFilter{PageSize: 2, PageToken: token})
+	assert.Equal(t, []orc8rStorage.TypeAndKey{{Type: "foo", Key: "a"}, {Type: "foo", Key: "b"}}, page)
+	assert.NotEmpty(t, token)
+	page, token = loadPage(storage.EntityLoadFilter{PageSize: 2, PageToken: token})
+	assert.Equal(t, []orc8rStorage.TypeAndKey{{Type: "foo", Key: "c"}}, page)
+	assert.Empty(t, token)
+
+	// Page through a type filter where the last page is exactly full
+	typeFilter := &wrappers.StringValue{Value: "foo"}
+	page, token = loadPage(storage.EntityLoadFilter{TypeFilter: typeFilter, PageSize: 1})
+	assert.Equal(t, []orc8rStorage.TypeAndKey{{Type: "foo", Key: "a"}}, page)
+	page, token = loadPage(storage.EntityLoadFilter{TypeFilter: typeFilter, PageSize: 2, PageToken: token})
+	assert.Equal(t, []orc8rStorage.TypeAndKey{{Type: "foo", Key: "b"}, {Type: "foo", Key: "c"}}, page)
+	assert.Empty(t, token)
+
+	_, err = store.LoadEntities("n1", storage.EntityLoadFilter{PageSize: 2, PageToken: "!!!"}, storage.EntityLoadCriteria{})
+	assert.Equal(t, storage.ErrInvalidPageToken, err)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_VersionedUpdates(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	assert.NoError(t, factory.InitializeServiceStorage())
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+
+	// Matching version
+	updated, err := store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		NewName:         &wrappers.StringValue{Value: "foobar"},
+		ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), updated.Version)
+
+	// Stale version
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		NewName:         &wrappers.StringValue{Value: "stale"},
+		ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+	})
+	assert.Equal(t, storage.ErrVersionMismatch, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		DeleteEntity:    true,
+		ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+	})
+	assert.Equal(t, storage.ErrVersionMismatch, err)
+
+	loaded, err := store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{LoadMetadata: true})
+	assert.NoError(t, err)
+	assert.Len(t, loaded.Entities, 1)
+	assert.Equal(t, "foobar", loaded.Entities[0].Name)
+	assert.Equal(t, uint64(1), loaded.Entities[0].Version)
+
+	// Version checks compare the version without bumping it
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+	})
+	assert.Equal(t, storage.ErrVersionMismatch, err)
+	checked, err := store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		ExpectedVersion: &wrappers.UInt64Value{Value: 1},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), checked.Version)
+	loaded, err = store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), loaded.Entities[0].Version)
+
+	// Delete with matching version, then the entity is gone
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		DeleteEntity:    true,
+		ExpectedVersion: &wrappers.UInt64Value{Value: 1},
+	})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:            "foo",
+		Key:             "bar",
+		DeleteEntity:    true,
+		ExpectedVersion: &wrappers.UInt64Value{Value: 1},
+	})
+	assert.Equal(t, storage.ErrVersionMismatch, err)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_ChangeLog(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	assert.NoError(t, factory.InitializeServiceStorage())
+	defer clock.UnfreezeClock(t)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	seq, err := store.GetLatestChangeSeq()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), seq)
+	clock.SetAndFreezeClock(t, time.Unix(100, 0))
+	assert.NoError(t, store.AppendChanges([][]byte{[]byte("a"), []byte("b")}))
+	assert.NoError(t, store.Commit())
+
+	// Rolled back changes don't leave a gap in the sequence
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store.AppendChanges([][]byte{[]byte("rolled back")}))
+	assert.NoError(t, store.Rollback())
+
+	// Initializing the storage again keeps the sequence
+	assert.NoError(t, factory.InitializeServiceStorage())
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	clock.SetAndFreezeClock(t, time.Unix(200, 0))
+	assert.NoError(t, store.AppendChanges([][]byte{[]byte("c")}))
+	assert.NoError(t, store.AppendChanges(nil))
+	seq, err = store.GetLatestChangeSeq()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	changes, err := store.LoadChanges(0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{{Seq: 1, Event: []byte("a")}, {Seq: 2, Event: []byte("b")}}, changes)
+	changes, err = store.LoadChanges(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{{Seq: 3, Event: []byte("c")}}, changes)
+
+	assert.NoError(t, store.DeleteChangesBefore(200))
+	changes, err = store.LoadChanges(0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{{Seq: 3, Event: []byte("c")}}, changes)
+	seq, err = store.GetLatestChangeSeq()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+	assert.NoError(t, store.Commit())
+}
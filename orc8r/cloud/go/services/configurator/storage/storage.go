@@ -16,6 +16,7 @@ import (
 	"magma/orc8r/cloud/go/storage"
 
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 )
 
@@ -67,6 +68,9 @@ type ConfiguratorStorage interface {
 	// LoadEntities returns a set of entities corresponding to the provided
 	// load criteria. Any entities which aren't found are excluded from the
 	// returned value.
+	// If the filter specifies a page size, a single page of entities is
+	// returned along with a token for the next page. ErrInvalidPageToken is
+	// returned if the filter's page token is malformed.
 	LoadEntities(networkID string, filter EntityLoadFilter, loadCriteria EntityLoadCriteria) (EntityLoadResult, error)
 
 	// CreateEntity creates a new entity. The created entity is returned
@@ -87,6 +91,34 @@ type ConfiguratorStorage interface {
 	// entity. The load criteria fields on associations are ignored, and the
 	// returned entities will always have both association fields filled out.
 	LoadGraphForEntity(networkID string, entityID EntityID, loadCriteria EntityLoadCriteria) (EntityGraph, error)
+
+	// =======================================================================
+	// Change Log Operations
+	// =======================================================================
+
+	// AppendChanges appends serialized change events to the change log.
+	// Changes are numbered in commit order, so the change log stays locked
+	// for other writers until the transaction ends. AppendChanges should
+	// be the last write of a transaction.
+	AppendChanges(events [][]byte) error
+
+	// LoadChanges returns up to limit changes with a sequence number greater
+	// than afterSeq, ordered by sequence number.
+	LoadChanges(afterSeq uint64, limit uint64) ([]Change, error)
+
+	// GetLatestChangeSeq returns the sequence number of the latest change
+	// appended to the change log, or 0 if no change was ever appended.
+	GetLatestChangeSeq() (uint64, error)
+
+	// DeleteChangesBefore deletes the changes appended before the given
+	// Unix time in seconds.
+	DeleteChangesBefore(unixTime int64) error
+}
+
+// Change is a serialized change event read from the change log.
+type Change struct {
+	Seq   uint64
+	Event []byte
 }
 
 // RollbackLogOnError calls Rollback on the provided ConfiguratorStorage and
@@ -114,6 +146,14 @@ const internalNetworkType = "Internal"
 const internalNetworkName = "Internal Magma Network"
 const internalNetworkDescription = "Internal network to hold non-network entities"
 
+// ErrInvalidPageToken is returned by LoadEntities when the page token of the
+// filter wasn't produced by a previous load.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrVersionMismatch is returned by UpdateEntity when the update specifies an
+// expected version which doesn't match the entity's current version.
+var ErrVersionMismatch = errors.New("entity version does not match expected version")
+
 // FullNetworkLoadCriteria is a utility variable to specify a full network load
 var FullNetworkLoadCriteria = NetworkLoadCriteria{LoadMetadata: true, LoadConfigs: true}
 
@@ -153,7 +193,7 @@ func (m NetworkEntity) GetGraphEdges() []*GraphEdge {
 // IsLoadAllEntities return true if the EntityLoadFilter is specifying to load
 // all entities in a network, false if there are any filter conditions.
 func (m *EntityLoadFilter) IsLoadAllEntities() bool {
-	return m.TypeFilter == nil && m.KeyFilter == nil && m.GraphID == nil && funk.IsEmpty(m.IDs)
+	return m.TypeFilter == nil && m.KeyFilter == nil && m.GraphID == nil && funk.IsEmpty(m.IDs) && m.PageSize == 0
 }
 
 // FullEntityLoadCriteria is an EntityLoadCriteria which loads everything
@@ -173,6 +213,15 @@ func (m *EntityUpdateCriteria) GetTypeAndKey() storage.TypeAndKey {
 	return storage.TypeAndKey{Type: m.Type, Key: m.Key}
 }
 
+// IsVersionCheck returns true if the update doesn't change the entity and
+// only checks that the entity's version matches the expected version.
+func (m *EntityUpdateCriteria) IsVersionCheck() bool {
+	return m.ExpectedVersion != nil && !m.DeleteEntity &&
+		m.NewName == nil && m.NewDescription == nil && m.NewPhysicalID == nil && m.NewConfig == nil &&
+		m.AssociationsToSet == nil && funk.IsEmpty(m.AssociationsToAdd) && funk.IsEmpty(m.AssociationsToDelete) &&
+		funk.IsEmpty(m.PermissionsToCreate) && funk.IsEmpty(m.PermissionsToUpdate) && funk.IsEmpty(m.PermissionsToDelete)
+}
+
 func (m *EntityUpdateCriteria) getEdgesToCreate() []*EntityID {
 	if m.AssociationsToSet != nil {
 		return m.AssociationsToSet.AssociationsToSet
@@ -816,10 +816,18 @@ type EntityLoadFilter struct {
 	GraphID *wrappers.StringValue `protobuf:"bytes,4,opt,name=graphID,proto3" json:"graphID,omitempty"`
 	// If PhysicalID is provided, the query will return all entities matching
 	// the provided ID. All other fields are ignored if this is set.
-	PhysicalID           *wrappers.StringValue `protobuf:"bytes,5,opt,name=physicalID,proto3" json:"physicalID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	PhysicalID *wrappers.StringValue `protobuf:"bytes,5,opt,name=physicalID,proto3" json:"physicalID,omitempty"`
+	// If PageSize is non-zero, at most PageSize entities ordered by
+	// (type, key) will be returned. EntityLoadResult.NextPageToken will be set
+	// if there are more entities matching the filter.
+	PageSize uint32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// PageToken is the NextPageToken of a previous load with the same filter.
+	// If provided, the load will continue after the last entity returned by
+	// that load.
+	PageToken            string   `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EntityLoadFilter) Reset()         { *m = EntityLoadFilter{} }
@@ -882,6 +890,20 @@ func (m *EntityLoadFilter) GetPhysicalID() *wrappers.StringValue {
 	return nil
 }
 
+func (m *EntityLoadFilter) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *EntityLoadFilter) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 // EntityLoadCriteria specifies how much of an entity to load
 type EntityLoadCriteria struct {
 	// Set LoadMetadata to true to load the metadata fields (name, description)
@@ -956,11 +978,14 @@ func (m *EntityLoadCriteria) GetLoadPermissions() bool {
 }
 
 type EntityLoadResult struct {
-	Entities             []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
-	EntitiesNotFound     []*EntityID      `protobuf:"bytes,2,rep,name=entities_not_found,json=entitiesNotFound,proto3" json:"entities_not_found,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Entities         []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	EntitiesNotFound []*EntityID      `protobuf:"bytes,2,rep,name=entities_not_found,json=entitiesNotFound,proto3" json:"entities_not_found,omitempty"`
+	// NextPageToken is set for paginated loads when there are more entities
+	// to load. It is empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EntityLoadResult) Reset()         { *m = EntityLoadResult{} }
@@ -1002,6 +1027,62 @@ func (m *EntityLoadResult) GetEntitiesNotFound() []*EntityID {
 	return nil
 }
 
+func (m *EntityLoadResult) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// EntityPageToken is the decoded form of a pagination token. Tokens are
+// opaque to clients.
+type EntityPageToken struct {
+	LastIncludedType     string   `protobuf:"bytes,1,opt,name=last_included_type,json=lastIncludedType,proto3" json:"last_included_type,omitempty"`
+	LastIncludedKey      string   `protobuf:"bytes,2,opt,name=last_included_key,json=lastIncludedKey,proto3" json:"last_included_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntityPageToken) Reset()         { *m = EntityPageToken{} }
+func (m *EntityPageToken) String() string { return proto.CompactTextString(m) }
+func (*EntityPageToken) ProtoMessage()    {}
+func (*EntityPageToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{11}
+}
+
+func (m *EntityPageToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntityPageToken.Unmarshal(m, b)
+}
+func (m *EntityPageToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntityPageToken.Marshal(b, m, deterministic)
+}
+func (m *EntityPageToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntityPageToken.Merge(m, src)
+}
+func (m *EntityPageToken) XXX_Size() int {
+	return xxx_messageInfo_EntityPageToken.Size(m)
+}
+func (m *EntityPageToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntityPageToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntityPageToken proto.InternalMessageInfo
+
+func (m *EntityPageToken) GetLastIncludedType() string {
+	if m != nil {
+		return m.LastIncludedType
+	}
+	return ""
+}
+
+func (m *EntityPageToken) GetLastIncludedKey() string {
+	if m != nil {
+		return m.LastIncludedKey
+	}
+	return ""
+}
+
 // EntityUpdateCriteria specifies a patch operation on a network entity.
 type EntityUpdateCriteria struct {
 	// (Type, Key) of the entity to update
@@ -1020,19 +1101,25 @@ type EntityUpdateCriteria struct {
 	AssociationsToAdd    []*EntityID              `protobuf:"bytes,31,rep,name=associations_to_add,json=associationsToAdd,proto3" json:"associations_to_add,omitempty"`
 	AssociationsToDelete []*EntityID              `protobuf:"bytes,32,rep,name=associations_to_delete,json=associationsToDelete,proto3" json:"associations_to_delete,omitempty"`
 	// New ACLs to add. ACL IDs are ignored and generated by the system.
-	PermissionsToCreate  []*ACL   `protobuf:"bytes,40,rep,name=permissions_to_create,json=permissionsToCreate,proto3" json:"permissions_to_create,omitempty"`
-	PermissionsToUpdate  []*ACL   `protobuf:"bytes,41,rep,name=permissions_to_update,json=permissionsToUpdate,proto3" json:"permissions_to_update,omitempty"`
-	PermissionsToDelete  []string `protobuf:"bytes,42,rep,name=permissions_to_delete,json=permissionsToDelete,proto3" json:"permissions_to_delete,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PermissionsToCreate []*ACL   `protobuf:"bytes,40,rep,name=permissions_to_create,json=permissionsToCreate,proto3" json:"permissions_to_create,omitempty"`
+	PermissionsToUpdate []*ACL   `protobuf:"bytes,41,rep,name=permissions_to_update,json=permissionsToUpdate,proto3" json:"permissions_to_update,omitempty"`
+	PermissionsToDelete []string `protobuf:"bytes,42,rep,name=permissions_to_delete,json=permissionsToDelete,proto3" json:"permissions_to_delete,omitempty"`
+	// If ExpectedVersion is provided, the update (or deletion) will only be
+	// applied if the entity's current version matches it. Otherwise, the
+	// update fails with a version mismatch error. An update which sets
+	// nothing but ExpectedVersion only checks the version and doesn't
+	// change the entity.
+	ExpectedVersion      *wrappers.UInt64Value `protobuf:"bytes,50,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *EntityUpdateCriteria) Reset()         { *m = EntityUpdateCriteria{} }
 func (m *EntityUpdateCriteria) String() string { return proto.CompactTextString(m) }
 func (*EntityUpdateCriteria) ProtoMessage()    {}
 func (*EntityUpdateCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{11}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{12}
 }
 
 func (m *EntityUpdateCriteria) XXX_Unmarshal(b []byte) error {
@@ -1144,6 +1231,13 @@ func (m *EntityUpdateCriteria) GetPermissionsToDelete() []string {
 	return nil
 }
 
+func (m *EntityUpdateCriteria) GetExpectedVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
 type EntityAssociationsToSet struct {
 	AssociationsToSet    []*EntityID `protobuf:"bytes,1,rep,name=associations_to_set,json=associationsToSet,proto3" json:"associations_to_set,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -1155,7 +1249,7 @@ func (m *EntityAssociationsToSet) Reset()         { *m = EntityAssociationsToSet
 func (m *EntityAssociationsToSet) String() string { return proto.CompactTextString(m) }
 func (*EntityAssociationsToSet) ProtoMessage()    {}
 func (*EntityAssociationsToSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{12}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{13}
 }
 
 func (m *EntityAssociationsToSet) XXX_Unmarshal(b []byte) error {
@@ -1200,7 +1294,7 @@ func (m *EntityGraph) Reset()         { *m = EntityGraph{} }
 func (m *EntityGraph) String() string { return proto.CompactTextString(m) }
 func (*EntityGraph) ProtoMessage()    {}
 func (*EntityGraph) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{13}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{14}
 }
 
 func (m *EntityGraph) XXX_Unmarshal(b []byte) error {
@@ -1255,7 +1349,7 @@ func (m *GraphEdge) Reset()         { *m = GraphEdge{} }
 func (m *GraphEdge) String() string { return proto.CompactTextString(m) }
 func (*GraphEdge) ProtoMessage()    {}
 func (*GraphEdge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{14}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{15}
 }
 
 func (m *GraphEdge) XXX_Unmarshal(b []byte) error {
@@ -1307,6 +1401,7 @@ func init() {
 	proto.RegisterType((*EntityLoadFilter)(nil), "magma.orc8r.configurator.storage.EntityLoadFilter")
 	proto.RegisterType((*EntityLoadCriteria)(nil), "magma.orc8r.configurator.storage.EntityLoadCriteria")
 	proto.RegisterType((*EntityLoadResult)(nil), "magma.orc8r.configurator.storage.EntityLoadResult")
+	proto.RegisterType((*EntityPageToken)(nil), "magma.orc8r.configurator.storage.EntityPageToken")
 	proto.RegisterType((*EntityUpdateCriteria)(nil), "magma.orc8r.configurator.storage.EntityUpdateCriteria")
 	proto.RegisterType((*EntityAssociationsToSet)(nil), "magma.orc8r.configurator.storage.EntityAssociationsToSet")
 	proto.RegisterType((*EntityGraph)(nil), "magma.orc8r.configurator.storage.EntityGraph")
@@ -1316,94 +1411,102 @@ func init() {
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 1421 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0xdb, 0xc6,
-	0x12, 0x36, 0x25, 0xd9, 0x92, 0x86, 0x92, 0xad, 0xac, 0xe5, 0x84, 0xc7, 0x27, 0x70, 0x14, 0x1e,
-	0x04, 0x70, 0x72, 0x10, 0x25, 0x55, 0x80, 0x24, 0x75, 0xd3, 0x02, 0xb2, 0x28, 0x27, 0x42, 0x1d,
-	0xd9, 0x65, 0x94, 0xba, 0x4d, 0x11, 0xb0, 0x8c, 0xb8, 0x96, 0x09, 0x4b, 0x5c, 0x81, 0x5c, 0x45,
-	0xd0, 0x13, 0xb4, 0x45, 0xfb, 0x42, 0xbd, 0xed, 0xcb, 0xb4, 0x40, 0x81, 0xde, 0xf5, 0xbe, 0xd8,
-	0x1f, 0x52, 0x94, 0x9c, 0xc0, 0x64, 0x5a, 0xa0, 0x77, 0xe4, 0xec, 0x7e, 0xdf, 0xee, 0xfc, 0xec,
-	0x37, 0x03, 0xe5, 0x80, 0x12, 0xdf, 0x1e, 0xe0, 0xfa, 0xd8, 0x27, 0x94, 0xa0, 0xda, 0xc8, 0x1e,
-	0x8c, 0xec, 0x3a, 0xf1, 0xfb, 0x8f, 0xfd, 0x7a, 0x9f, 0x78, 0xa7, 0xee, 0x60, 0xe2, 0xdb, 0x94,
-	0xf8, 0x75, 0xb9, 0x6f, 0x7b, 0x67, 0x40, 0xc8, 0x60, 0x88, 0xef, 0xf1, 0xfd, 0x6f, 0x26, 0xa7,
-	0xf7, 0xa6, 0xbe, 0x3d, 0x1e, 0x63, 0x3f, 0x10, 0x0c, 0xfa, 0x8f, 0x19, 0xc8, 0x77, 0x31, 0x9d,
-	0x12, 0xff, 0x1c, 0xad, 0x43, 0xa6, 0x63, 0x68, 0x4a, 0x4d, 0xd9, 0x2d, 0x9a, 0x99, 0x8e, 0x81,
-	0x10, 0xe4, 0x7a, 0xb3, 0x31, 0xd6, 0x32, 0xdc, 0xc2, 0xbf, 0x99, 0xcd, 0xb3, 0x47, 0x58, 0x03,
-	0x61, 0x63, 0xdf, 0xa8, 0x06, 0xaa, 0x83, 0x83, 0xbe, 0xef, 0x8e, 0xa9, 0x4b, 0x3c, 0x4d, 0xe5,
-	0x4b, 0x71, 0x13, 0x3a, 0x86, 0xbc, 0xb8, 0x5d, 0xa0, 0x55, 0x6b, 0xd9, 0x5d, 0xb5, 0xf1, 0xb0,
-	0x7e, 0xd9, 0xcd, 0xeb, 0xf2, 0x56, 0xf5, 0x96, 0x00, 0xb6, 0x3d, 0xea, 0xcf, 0xcc, 0x90, 0x06,
-	0x69, 0x90, 0x7f, 0x8b, 0xfd, 0x80, 0x9d, 0xb7, 0x53, 0x53, 0x76, 0x73, 0x66, 0xf8, 0xbb, 0xbd,
-	0x07, 0xa5, 0x38, 0x04, 0x55, 0x20, 0x7b, 0x8e, 0x67, 0xd2, 0x2d, 0xf6, 0x89, 0xaa, 0xb0, 0xfa,
-	0xd6, 0x1e, 0x4e, 0x84, 0x63, 0x25, 0x53, 0xfc, 0xec, 0x65, 0x1e, 0x2b, 0xba, 0x03, 0x57, 0xe4,
-	0xb1, 0x87, 0xc4, 0x76, 0x0e, 0xdc, 0x21, 0xc5, 0x3e, 0x23, 0x70, 0x9d, 0x40, 0x53, 0x6a, 0x59,
-	0x46, 0xe0, 0x3a, 0x01, 0xfa, 0x14, 0x54, 0x3a, 0x1b, 0x63, 0xeb, 0x94, 0x6f, 0xe0, 0x34, 0x6a,
-	0xe3, 0x7a, 0x5d, 0x84, 0xba, 0x1e, 0x86, 0xba, 0xfe, 0x82, 0xfa, 0xae, 0x37, 0xf8, 0x92, 0xb1,
-	0x9b, 0xc0, 0x00, 0x82, 0x50, 0x7f, 0x0d, 0x9b, 0xb1, 0x53, 0x5a, 0xbe, 0x4b, 0xb1, 0xef, 0xda,
-	0xe8, 0x7f, 0x50, 0x1e, 0x12, 0xdb, 0xb1, 0x46, 0x98, 0xda, 0x8e, 0x4d, 0x6d, 0x7e, 0xe5, 0x82,
-	0x59, 0x62, 0xc6, 0xe7, 0xd2, 0x86, 0x6e, 0x02, 0xff, 0xb7, 0xc2, 0x70, 0x66, 0xf8, 0x1e, 0x95,
-	0xd9, 0xa4, 0xd7, 0xfa, 0x4f, 0xca, 0x82, 0x17, 0x26, 0x0e, 0x26, 0x43, 0x8a, 0xda, 0x50, 0xf0,
-	0x84, 0x51, 0xb8, 0xa2, 0x36, 0x6e, 0x27, 0xce, 0x81, 0x19, 0x41, 0xd1, 0x7d, 0xa8, 0xca, 0xef,
-	0x8e, 0x11, 0x58, 0x1e, 0xa1, 0xd6, 0x29, 0x99, 0x78, 0x8e, 0x96, 0xe1, 0xd1, 0x41, 0xf3, 0xb5,
-	0x2e, 0xa1, 0x07, 0x6c, 0x45, 0xff, 0x3e, 0x07, 0x5b, 0x92, 0xe7, 0xe5, 0xd8, 0xb1, 0x29, 0x8e,
-	0x1c, 0x5e, 0xae, 0xb7, 0x5b, 0xb0, 0xee, 0xe0, 0x21, 0xa6, 0xd8, 0x92, 0x34, 0xbc, 0xca, 0x0a,
-	0x66, 0x59, 0x58, 0xc3, 0x32, 0x7d, 0xc4, 0x3c, 0x99, 0x5a, 0xbc, 0x0c, 0xab, 0x09, 0x42, 0x9f,
-	0xf7, 0xf0, 0xb4, 0xcb, 0xea, 0xb4, 0x0d, 0x1b, 0x0c, 0x18, 0xaf, 0xd5, 0xad, 0x04, 0xf8, 0x75,
-	0x0f, 0x4f, 0x8d, 0x58, 0x31, 0xcb, 0xf3, 0x59, 0x42, 0xb5, 0xab, 0x09, 0xcf, 0xe7, 0x6f, 0xe7,
-	0x07, 0x05, 0x34, 0x99, 0x37, 0x8b, 0x12, 0xcb, 0x76, 0x1c, 0x8b, 0xf8, 0xd6, 0x84, 0x07, 0x45,
-	0xdb, 0xe1, 0x39, 0xf9, 0x22, 0x71, 0x4e, 0x16, 0x63, 0x19, 0xbe, 0x92, 0x1e, 0x69, 0x3a, 0xce,
-	0x91, 0x2f, 0x16, 0xc5, 0x93, 0xa9, 0xf6, 0xdf, 0xb1, 0x84, 0xee, 0xc0, 0x95, 0xd8, 0x55, 0x44,
-	0x80, 0xb5, 0x1b, 0x3c, 0x89, 0x1b, 0x11, 0xc0, 0xe0, 0xe6, 0xed, 0xa7, 0xf0, 0x9f, 0xf7, 0xd2,
-	0xa7, 0x7a, 0x5e, 0xf7, 0xa1, 0xd0, 0xf6, 0xa8, 0x4b, 0x67, 0x42, 0x5c, 0x78, 0x04, 0x05, 0x90,
-	0x7f, 0x87, 0x5c, 0x99, 0x88, 0x4b, 0xff, 0x3d, 0x0b, 0x65, 0xe9, 0xb0, 0x40, 0xa2, 0xeb, 0x50,
-	0x8c, 0x8a, 0x4c, 0x82, 0xe7, 0x86, 0x88, 0x35, 0x73, 0x91, 0x35, 0x3b, 0xbf, 0xe1, 0x87, 0x89,
-	0xd8, 0x0e, 0xc0, 0xf8, 0x6c, 0x16, 0xb8, 0x7d, 0x7b, 0xd8, 0x31, 0x78, 0xe5, 0x15, 0xcd, 0x98,
-	0x05, 0x5d, 0x85, 0x35, 0x11, 0x39, 0xae, 0x48, 0x25, 0x53, 0xfe, 0x31, 0xa9, 0x1a, 0xf8, 0xf6,
-	0xf8, 0xac, 0x63, 0x68, 0xbb, 0x1c, 0x14, 0xfe, 0xa2, 0x2e, 0x94, 0xec, 0x20, 0x20, 0x7d, 0xd7,
-	0x66, 0x07, 0x04, 0x5a, 0x83, 0xd7, 0xc0, 0x9d, 0xcb, 0x6b, 0x20, 0x8c, 0xa2, 0xb9, 0x80, 0x47,
-	0xdf, 0xc0, 0xe6, 0xd8, 0xf6, 0xb1, 0x47, 0xad, 0x05, 0xda, 0x07, 0xa9, 0x69, 0x91, 0xa0, 0x69,
-	0xc6, 0xc9, 0x9f, 0x82, 0x3a, 0xc6, 0xfe, 0xc8, 0x0d, 0x02, 0x4e, 0xfa, 0x84, 0x93, 0xde, 0xba,
-	0x9c, 0xb4, 0xd9, 0x3a, 0x34, 0xe3, 0xc8, 0xb8, 0x74, 0x1f, 0x2c, 0x48, 0xb7, 0xfe, 0x5b, 0x0e,
-	0xb2, 0xcd, 0xd6, 0xe1, 0x05, 0x61, 0x78, 0x0d, 0x95, 0xa0, 0x4f, 0xc6, 0x91, 0x2e, 0x74, 0x8c,
-	0x80, 0xe7, 0x4e, 0x6d, 0xdc, 0x4f, 0x74, 0x7e, 0xf8, 0x66, 0x3a, 0x46, 0xf0, 0x6c, 0xc5, 0xdc,
-	0xe0, 0x5c, 0x73, 0x13, 0x3a, 0x81, 0x75, 0x41, 0x3f, 0x75, 0x87, 0x4e, 0xdf, 0xf6, 0x1d, 0x9e,
-	0xfd, 0xf5, 0x46, 0x3d, 0x19, 0xf9, 0x89, 0x44, 0x3d, 0x5b, 0x31, 0xcb, 0x9c, 0x27, 0x34, 0xa0,
-	0x63, 0x80, 0xb9, 0xe3, 0xbc, 0x62, 0xd6, 0x93, 0xde, 0xf8, 0x38, 0xc2, 0x99, 0x31, 0x0e, 0x74,
-	0x13, 0x54, 0xcc, 0x93, 0x24, 0xe4, 0x87, 0x15, 0x5a, 0xf1, 0x99, 0x62, 0x82, 0x30, 0x72, 0x95,
-	0x79, 0x09, 0x65, 0xde, 0x9c, 0x22, 0x67, 0x6e, 0x7c, 0x90, 0x33, 0x8a, 0x59, 0x62, 0x34, 0x91,
-	0x2f, 0xdb, 0x50, 0xe8, 0x18, 0xa2, 0x81, 0x69, 0xbb, 0x5c, 0x27, 0xa2, 0xff, 0x78, 0x46, 0x1b,
-	0x8b, 0xcd, 0x78, 0x07, 0x20, 0x16, 0xe8, 0x0a, 0x64, 0x59, 0xea, 0x64, 0x27, 0xed, 0x18, 0x81,
-	0xfe, 0x08, 0x60, 0xee, 0x29, 0x52, 0x21, 0xdf, 0x3d, 0xb2, 0x8e, 0xdb, 0xe6, 0xf3, 0xca, 0x0a,
-	0x2a, 0x40, 0xce, 0x6c, 0x37, 0x8d, 0x8a, 0x82, 0x8a, 0xb0, 0x7a, 0x62, 0x76, 0x7a, 0xed, 0x4a,
-	0x06, 0xe5, 0x21, 0x7b, 0x74, 0xd2, 0xad, 0x64, 0xf5, 0xbb, 0x50, 0x88, 0xae, 0xb6, 0x01, 0x6a,
-	0xf7, 0xc8, 0x3a, 0xe9, 0x1c, 0x1a, 0xad, 0xa6, 0x69, 0x54, 0x56, 0x50, 0x05, 0x4a, 0xe1, 0x9f,
-	0xd5, 0x3c, 0x3c, 0xac, 0x28, 0xfb, 0x79, 0x58, 0xe5, 0xa9, 0xd9, 0x5f, 0x13, 0x02, 0xa1, 0xff,
-	0x92, 0x81, 0x8a, 0x28, 0xf7, 0x58, 0xa7, 0x5f, 0xea, 0xeb, 0x4a, 0xba, 0xbe, 0x8e, 0x3e, 0x01,
-	0x38, 0xc7, 0xb3, 0x34, 0x53, 0x41, 0xf1, 0x1c, 0xcf, 0x24, 0xf8, 0x89, 0x88, 0x4d, 0x36, 0xf5,
-	0x5b, 0x65, 0x30, 0xf4, 0x70, 0xae, 0x31, 0xb9, 0x24, 0x2d, 0x29, 0x54, 0xa0, 0x27, 0x0b, 0x9a,
-	0xb6, 0x9a, 0xc4, 0xe1, 0xf9, 0x7e, 0xfd, 0x57, 0x05, 0xd0, 0x3c, 0x88, 0xe9, 0x06, 0x99, 0x1b,
-	0xa0, 0xc6, 0x06, 0x19, 0x39, 0xc7, 0xc0, 0x7c, 0x8e, 0x41, 0x77, 0x61, 0x93, 0x6f, 0xe0, 0x52,
-	0xc6, 0xbb, 0x14, 0x3d, 0x73, 0x03, 0x2e, 0xe3, 0x05, 0xb3, 0xc2, 0x96, 0xb8, 0x3c, 0x05, 0x3d,
-	0xd2, 0x3b, 0x73, 0x03, 0xf4, 0x11, 0x6c, 0xc5, 0xb7, 0x9f, 0xfa, 0x64, 0x24, 0x00, 0x39, 0x0e,
-	0x40, 0x73, 0xc0, 0x81, 0x4f, 0x46, 0x1c, 0x72, 0x1b, 0x38, 0x8d, 0x15, 0x97, 0xb5, 0x55, 0xbe,
-	0x7b, 0x83, 0xd9, 0xe7, 0x85, 0x19, 0xe8, 0x3f, 0x2b, 0xf1, 0x72, 0x91, 0x23, 0xd5, 0xe7, 0x50,
-	0xe0, 0xef, 0xce, 0xc5, 0xe1, 0x48, 0x75, 0x2f, 0x71, 0xfb, 0x16, 0x64, 0x66, 0x44, 0x80, 0xbe,
-	0x02, 0x14, 0x7e, 0x2f, 0x8d, 0x55, 0xe9, 0xca, 0xa1, 0x12, 0xb2, 0x44, 0x03, 0xd8, 0x9f, 0x6b,
-	0x50, 0x15, 0xcb, 0x4b, 0xf3, 0x57, 0xa2, 0x16, 0xcc, 0xb2, 0x29, 0xa7, 0x32, 0x21, 0x32, 0x72,
-	0x28, 0x2b, 0x09, 0xa3, 0xec, 0xca, 0xff, 0xf6, 0x4c, 0xd6, 0x02, 0x66, 0xb1, 0x62, 0xb5, 0x9c,
-	0x64, 0x32, 0x2b, 0x7b, 0x78, 0x7a, 0x3c, 0x6f, 0xe0, 0x7b, 0x00, 0x8c, 0x44, 0x56, 0xe4, 0x35,
-	0x4e, 0xf0, 0xdf, 0x0b, 0x04, 0xfb, 0x33, 0x8a, 0x03, 0xf9, 0x7c, 0x3d, 0x3c, 0x95, 0xd5, 0xea,
-	0xc2, 0x66, 0xbc, 0xe7, 0xb2, 0x72, 0x0d, 0x30, 0xe5, 0x02, 0xad, 0x36, 0x3e, 0x4e, 0x9a, 0xbf,
-	0x78, 0xc3, 0xed, 0x91, 0x17, 0x98, 0x9a, 0x57, 0xec, 0x65, 0x13, 0x7a, 0x75, 0xf1, 0x28, 0xdb,
-	0x71, 0xf8, 0xf0, 0x96, 0xae, 0x54, 0x96, 0xb8, 0x9b, 0x8e, 0x83, 0xbe, 0x85, 0xab, 0xcb, 0xdc,
-	0x72, 0x36, 0xac, 0xa5, 0xa6, 0xaf, 0x2e, 0xd2, 0x8b, 0x61, 0x12, 0x7d, 0x0d, 0x5b, 0xb1, 0xf7,
-	0xc6, 0x0e, 0xe8, 0xfb, 0x98, 0x0d, 0xc0, 0xbb, 0x69, 0x06, 0x8a, 0xcd, 0x18, 0x47, 0x8f, 0xb4,
-	0x38, 0xc3, 0x3b, 0xa8, 0xe5, 0x6c, 0x7d, 0xfb, 0xc3, 0xa9, 0xe5, 0xb8, 0xdc, 0xb8, 0x40, 0x2d,
-	0xc3, 0x72, 0x87, 0xf7, 0xb2, 0x45, 0x8c, 0xf0, 0x54, 0x9f, 0xc0, 0xb5, 0xf7, 0x64, 0xf5, 0x5d,
-	0x29, 0x64, 0xd5, 0xa2, 0xfc, 0xdd, 0x14, 0xbe, 0xc0, 0x54, 0xff, 0x43, 0x01, 0x55, 0xac, 0x3f,
-	0x65, 0x22, 0xff, 0xcf, 0xaa, 0xd4, 0x11, 0x94, 0x7d, 0x42, 0xa8, 0x15, 0x31, 0xa6, 0x17, 0xa8,
-	0x12, 0x23, 0x68, 0x87, 0x84, 0x4d, 0x58, 0xc5, 0xce, 0x00, 0x87, 0x8d, 0xef, 0xff, 0x97, 0x13,
-	0x71, 0xaf, 0xda, 0xce, 0x00, 0x9b, 0x02, 0xa9, 0x7f, 0xa7, 0x40, 0x31, 0x32, 0xa2, 0x3d, 0xc8,
-	0x50, 0x22, 0x5b, 0x77, 0x9a, 0x6b, 0x65, 0x28, 0x41, 0x9f, 0x41, 0x8e, 0xf5, 0x0d, 0xd9, 0xba,
-	0xd3, 0xa0, 0x39, 0x6e, 0xbf, 0xf8, 0x2a, 0x2f, 0x57, 0xde, 0xac, 0x71, 0xbd, 0x78, 0xf0, 0x57,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xe9, 0x2f, 0xc4, 0xb1, 0x11, 0x00, 0x00,
+	// 1546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x7f, 0x4f, 0xdb, 0xc6,
+	0x1b, 0xc7, 0x49, 0x20, 0xc9, 0xe3, 0x84, 0x98, 0x03, 0x5a, 0x7f, 0x69, 0xbf, 0x90, 0x7a, 0xea,
+	0x44, 0xd9, 0x9a, 0x76, 0xe9, 0xd4, 0x76, 0xac, 0x9b, 0x14, 0x48, 0xa0, 0x51, 0x29, 0x30, 0x93,
+	0x96, 0xad, 0x53, 0xe5, 0xb9, 0xf1, 0x11, 0x2c, 0x82, 0x2f, 0xb2, 0x2f, 0x4d, 0xd3, 0x37, 0xb0,
+	0x4d, 0xdb, 0x6b, 0xda, 0x7b, 0xd9, 0x5f, 0x9b, 0x34, 0x69, 0x2f, 0x61, 0x9a, 0xee, 0x87, 0x1d,
+	0x07, 0xa8, 0x70, 0xba, 0x49, 0xfb, 0xcf, 0xf7, 0xdc, 0x3d, 0x9f, 0xbb, 0xe7, 0x9e, 0xcf, 0x3d,
+	0xcf, 0xc7, 0x50, 0x0c, 0x28, 0xf1, 0xed, 0x0e, 0xae, 0xf4, 0x7c, 0x42, 0x09, 0x2a, 0x9f, 0xda,
+	0x9d, 0x53, 0xbb, 0x42, 0xfc, 0xf6, 0x43, 0xbf, 0xd2, 0x26, 0xde, 0x91, 0xdb, 0xe9, 0xfb, 0x36,
+	0x25, 0x7e, 0x45, 0xae, 0x5b, 0x5a, 0xee, 0x10, 0xd2, 0xe9, 0xe2, 0x3b, 0x7c, 0xfd, 0xab, 0xfe,
+	0xd1, 0x9d, 0x81, 0x6f, 0xf7, 0x7a, 0xd8, 0x0f, 0x04, 0x82, 0xf1, 0x53, 0x0a, 0xb2, 0xbb, 0x98,
+	0x0e, 0x88, 0x7f, 0x82, 0x66, 0x21, 0xd5, 0xac, 0xeb, 0x4a, 0x59, 0x59, 0xcd, 0x9b, 0xa9, 0x66,
+	0x1d, 0x21, 0xc8, 0xb4, 0x86, 0x3d, 0xac, 0xa7, 0xb8, 0x85, 0x7f, 0x33, 0x9b, 0x67, 0x9f, 0x62,
+	0x1d, 0x84, 0x8d, 0x7d, 0xa3, 0x32, 0xa8, 0x0e, 0x0e, 0xda, 0xbe, 0xdb, 0xa3, 0x2e, 0xf1, 0x74,
+	0x95, 0x4f, 0xc5, 0x4d, 0x68, 0x1f, 0xb2, 0xe2, 0x74, 0x81, 0xbe, 0x50, 0x4e, 0xaf, 0xaa, 0xd5,
+	0xfb, 0x95, 0xcb, 0x4e, 0x5e, 0x91, 0xa7, 0xaa, 0x6c, 0x0a, 0xc7, 0x86, 0x47, 0xfd, 0xa1, 0x19,
+	0xc2, 0x20, 0x1d, 0xb2, 0xaf, 0xb1, 0x1f, 0xb0, 0xfd, 0x96, 0xcb, 0xca, 0x6a, 0xc6, 0x0c, 0x87,
+	0x4b, 0xeb, 0x50, 0x88, 0xbb, 0x20, 0x0d, 0xd2, 0x27, 0x78, 0x28, 0xc3, 0x62, 0x9f, 0x68, 0x01,
+	0xa6, 0x5f, 0xdb, 0xdd, 0xbe, 0x08, 0xac, 0x60, 0x8a, 0xc1, 0x7a, 0xea, 0xa1, 0x62, 0x38, 0x30,
+	0x27, 0xb7, 0xdd, 0x21, 0xb6, 0xb3, 0xe5, 0x76, 0x29, 0xf6, 0x19, 0x80, 0xeb, 0x04, 0xba, 0x52,
+	0x4e, 0x33, 0x00, 0xd7, 0x09, 0xd0, 0x17, 0xa0, 0xd2, 0x61, 0x0f, 0x5b, 0x47, 0x7c, 0x01, 0x87,
+	0x51, 0xab, 0xd7, 0x2b, 0xe2, 0xaa, 0x2b, 0xe1, 0x55, 0x57, 0x0e, 0xa8, 0xef, 0x7a, 0x9d, 0xe7,
+	0x0c, 0xdd, 0x04, 0xe6, 0x20, 0x00, 0x8d, 0x97, 0x30, 0x1f, 0xdb, 0x65, 0xd3, 0x77, 0x29, 0xf6,
+	0x5d, 0x1b, 0x7d, 0x00, 0xc5, 0x2e, 0xb1, 0x1d, 0xeb, 0x14, 0x53, 0xdb, 0xb1, 0xa9, 0xcd, 0x8f,
+	0x9c, 0x33, 0x0b, 0xcc, 0xf8, 0x54, 0xda, 0xd0, 0x0d, 0xe0, 0x63, 0x2b, 0xbc, 0xce, 0x14, 0x5f,
+	0xa3, 0x32, 0x9b, 0x8c, 0xda, 0xf8, 0x59, 0x19, 0x8b, 0xc2, 0xc4, 0x41, 0xbf, 0x4b, 0x51, 0x03,
+	0x72, 0x9e, 0x30, 0x8a, 0x50, 0xd4, 0xea, 0xad, 0xc4, 0x39, 0x30, 0x23, 0x57, 0x74, 0x17, 0x16,
+	0xe4, 0x77, 0xb3, 0x1e, 0x58, 0x1e, 0xa1, 0xd6, 0x11, 0xe9, 0x7b, 0x8e, 0x9e, 0xe2, 0xb7, 0x83,
+	0x46, 0x73, 0xbb, 0x84, 0x6e, 0xb1, 0x19, 0xe3, 0x87, 0x0c, 0x2c, 0x4a, 0x9c, 0x67, 0x3d, 0xc7,
+	0xa6, 0x38, 0x0a, 0xf8, 0x2c, 0xdf, 0x6e, 0xc2, 0xac, 0x83, 0xbb, 0x98, 0x62, 0x4b, 0xc2, 0x70,
+	0x96, 0xe5, 0xcc, 0xa2, 0xb0, 0x86, 0x34, 0x7d, 0xc0, 0x22, 0x19, 0x58, 0x9c, 0x86, 0x0b, 0x09,
+	0xae, 0x3e, 0xeb, 0xe1, 0xc1, 0x2e, 0xe3, 0x69, 0x03, 0x4a, 0xcc, 0x31, 0xce, 0xd5, 0xc5, 0x04,
+	0xfe, 0xb3, 0x1e, 0x1e, 0xd4, 0x63, 0x64, 0x96, 0xfb, 0xb3, 0x84, 0xea, 0x57, 0x12, 0xee, 0xcf,
+	0xdf, 0xce, 0x8f, 0x0a, 0xe8, 0x32, 0x6f, 0x16, 0x25, 0x96, 0xed, 0x38, 0x16, 0xf1, 0xad, 0x3e,
+	0xbf, 0x14, 0x7d, 0x99, 0xe7, 0xe4, 0xab, 0xc4, 0x39, 0x19, 0xbf, 0xcb, 0xf0, 0x95, 0xb4, 0x48,
+	0xcd, 0x71, 0xf6, 0x7c, 0x31, 0x29, 0x9e, 0xcc, 0x42, 0xfb, 0x82, 0x29, 0xb4, 0x06, 0x73, 0xb1,
+	0xa3, 0x88, 0x0b, 0xd6, 0x57, 0x78, 0x12, 0x4b, 0x91, 0x43, 0x9d, 0x9b, 0x97, 0xb6, 0xe1, 0x7f,
+	0xef, 0x84, 0x9f, 0xe8, 0x79, 0xdd, 0x85, 0x5c, 0xc3, 0xa3, 0x2e, 0x1d, 0x8a, 0xe2, 0xc2, 0x6f,
+	0x50, 0x38, 0xf2, 0xef, 0x10, 0x2b, 0x15, 0x61, 0x19, 0x7f, 0xa4, 0xa1, 0x28, 0x03, 0x16, 0x9e,
+	0xe8, 0x3a, 0xe4, 0x23, 0x92, 0x49, 0xe7, 0x91, 0x21, 0x42, 0x4d, 0x9d, 0x47, 0x4d, 0x8f, 0x4e,
+	0xf8, 0x7e, 0x45, 0x6c, 0x19, 0xa0, 0x77, 0x3c, 0x0c, 0xdc, 0xb6, 0xdd, 0x6d, 0xd6, 0x39, 0xf3,
+	0xf2, 0x66, 0xcc, 0x82, 0xae, 0xc0, 0x8c, 0xb8, 0x39, 0x5e, 0x91, 0x0a, 0xa6, 0x1c, 0xb1, 0x52,
+	0xd5, 0xf1, 0xed, 0xde, 0x71, 0xb3, 0xae, 0xaf, 0x72, 0xa7, 0x70, 0x88, 0x76, 0xa1, 0x60, 0x07,
+	0x01, 0x69, 0xbb, 0x36, 0xdb, 0x20, 0xd0, 0xab, 0x9c, 0x03, 0x6b, 0x97, 0x73, 0x20, 0xbc, 0x45,
+	0x73, 0xcc, 0x1f, 0x7d, 0x0b, 0xf3, 0x3d, 0xdb, 0xc7, 0x1e, 0xb5, 0xc6, 0x60, 0xef, 0x4d, 0x0c,
+	0x8b, 0x04, 0x4c, 0x2d, 0x0e, 0xbe, 0x0d, 0x6a, 0x0f, 0xfb, 0xa7, 0x6e, 0x10, 0x70, 0xd0, 0x47,
+	0x1c, 0xf4, 0xe6, 0xe5, 0xa0, 0xb5, 0xcd, 0x1d, 0x33, 0xee, 0x19, 0x2f, 0xdd, 0x5b, 0x63, 0xa5,
+	0xdb, 0xf8, 0x3d, 0x03, 0xe9, 0xda, 0xe6, 0xce, 0xb9, 0xc2, 0xf0, 0x12, 0xb4, 0xa0, 0x4d, 0x7a,
+	0x51, 0x5d, 0x68, 0xd6, 0x03, 0x9e, 0x3b, 0xb5, 0x7a, 0x37, 0xd1, 0xfe, 0xe1, 0x9b, 0x69, 0xd6,
+	0x83, 0xc7, 0x53, 0x66, 0x89, 0x63, 0x8d, 0x4c, 0xe8, 0x10, 0x66, 0x05, 0xfc, 0xc0, 0xed, 0x3a,
+	0x6d, 0xdb, 0x77, 0x78, 0xf6, 0x67, 0xab, 0x95, 0x64, 0xe0, 0x87, 0xd2, 0xeb, 0xf1, 0x94, 0x59,
+	0xe4, 0x38, 0xa1, 0x01, 0xed, 0x03, 0x8c, 0x02, 0xe7, 0x8c, 0x99, 0x4d, 0x7a, 0xe2, 0xfd, 0xc8,
+	0xcf, 0x8c, 0x61, 0xa0, 0x1b, 0xa0, 0x62, 0x9e, 0x24, 0x51, 0x7e, 0x18, 0xd1, 0xf2, 0x8f, 0x15,
+	0x13, 0x84, 0x91, 0x57, 0x99, 0x67, 0x50, 0xa4, 0xc3, 0x78, 0x30, 0x2b, 0xef, 0x15, 0x8c, 0x62,
+	0x16, 0x18, 0x4c, 0x14, 0xcb, 0x12, 0xe4, 0x9a, 0x75, 0xd1, 0xc0, 0xf4, 0x55, 0x5e, 0x27, 0xa2,
+	0x71, 0x3c, 0xa3, 0xd5, 0xf1, 0x66, 0xbc, 0x0c, 0x10, 0xbb, 0x68, 0x0d, 0xd2, 0xcd, 0xba, 0x68,
+	0x3f, 0x79, 0x93, 0x7d, 0x1a, 0x0f, 0x00, 0x46, 0x91, 0x22, 0x15, 0xb2, 0xbb, 0x7b, 0xd6, 0x7e,
+	0xc3, 0x7c, 0xaa, 0x4d, 0xa1, 0x1c, 0x64, 0xcc, 0x46, 0xad, 0xae, 0x29, 0x28, 0x0f, 0xd3, 0x87,
+	0x66, 0xb3, 0xd5, 0xd0, 0x52, 0x28, 0x0b, 0xe9, 0xbd, 0xc3, 0x5d, 0x2d, 0x6d, 0xdc, 0x86, 0x5c,
+	0x74, 0xb4, 0x12, 0xa8, 0xbb, 0x7b, 0xd6, 0x61, 0x73, 0xa7, 0xbe, 0x59, 0x33, 0xeb, 0xda, 0x14,
+	0xd2, 0xa0, 0x10, 0x8e, 0xac, 0xda, 0xce, 0x8e, 0xa6, 0x6c, 0x64, 0x61, 0x9a, 0xa7, 0x66, 0x63,
+	0x46, 0x14, 0x08, 0xe3, 0xaf, 0x14, 0x68, 0x82, 0xee, 0xb1, 0x4e, 0x7f, 0xa6, 0xaf, 0x2b, 0x93,
+	0xf5, 0x75, 0xf4, 0x39, 0xc0, 0x09, 0x1e, 0x4e, 0xa2, 0x0a, 0xf2, 0x27, 0x78, 0x28, 0x9d, 0x1f,
+	0x89, 0xbb, 0x49, 0x4f, 0xfc, 0x56, 0x99, 0x1b, 0xba, 0x3f, 0xaa, 0x31, 0x99, 0x24, 0x2d, 0x29,
+	0xac, 0x40, 0x8f, 0xc6, 0x6a, 0xda, 0x74, 0x92, 0x80, 0x47, 0xeb, 0xd1, 0x35, 0xc8, 0xf7, 0xec,
+	0x0e, 0xb6, 0x02, 0xf7, 0x2d, 0xd6, 0x67, 0xca, 0xca, 0x6a, 0xd1, 0xcc, 0x31, 0xc3, 0x81, 0xfb,
+	0x16, 0xa3, 0xff, 0x03, 0xf0, 0x49, 0x4a, 0x4e, 0xb0, 0xa7, 0x67, 0x45, 0xa5, 0x66, 0x96, 0x16,
+	0x33, 0x18, 0xbf, 0x29, 0x80, 0x46, 0x09, 0x98, 0x4c, 0x04, 0xad, 0x80, 0x1a, 0x13, 0x41, 0x52,
+	0x03, 0xc1, 0x48, 0x03, 0xa1, 0xdb, 0x30, 0xcf, 0x17, 0xf0, 0x32, 0xc8, 0x3b, 0x1c, 0x3d, 0x76,
+	0x03, 0xde, 0x02, 0x72, 0xa6, 0xc6, 0xa6, 0x78, 0x69, 0x0b, 0x5a, 0xa4, 0x75, 0xec, 0x06, 0xe8,
+	0x13, 0x58, 0x8c, 0x2f, 0x3f, 0xf2, 0xc9, 0xa9, 0x70, 0xc8, 0x70, 0x07, 0x34, 0x72, 0xd8, 0xf2,
+	0xc9, 0x29, 0x77, 0xb9, 0x05, 0x1c, 0xc6, 0x8a, 0x97, 0xc4, 0x69, 0xbe, 0xba, 0xc4, 0xec, 0x23,
+	0x52, 0x07, 0xc6, 0xaf, 0x4a, 0x9c, 0x6a, 0x52, 0x8e, 0x3d, 0x81, 0x1c, 0x7f, 0xb3, 0x2e, 0x0e,
+	0xe5, 0xd8, 0x9d, 0xc4, 0xad, 0x5f, 0x80, 0x99, 0x11, 0x00, 0xfa, 0x1a, 0x50, 0xf8, 0x7d, 0x46,
+	0x92, 0x4d, 0x46, 0x25, 0x2d, 0x44, 0x09, 0xc5, 0x1b, 0xfa, 0x90, 0x49, 0xa6, 0x37, 0xd4, 0x8a,
+	0x65, 0x52, 0xf4, 0xd1, 0x22, 0x33, 0xef, 0x47, 0xd9, 0x3c, 0x81, 0x92, 0x40, 0x89, 0x4c, 0xe8,
+	0x63, 0x40, 0x5d, 0x3b, 0xa0, 0x96, 0xeb, 0xb5, 0xbb, 0x7d, 0x07, 0x3b, 0x56, 0xac, 0xdd, 0x6b,
+	0x6c, 0xa6, 0x29, 0x27, 0x78, 0xd5, 0x5a, 0x83, 0xb9, 0xf1, 0xd5, 0x23, 0x21, 0x50, 0x8a, 0x2f,
+	0x7e, 0x82, 0x87, 0xc6, 0x2f, 0x59, 0x58, 0x10, 0xbb, 0x9d, 0x11, 0x94, 0x89, 0x34, 0x05, 0xa3,
+	0x98, 0x94, 0x99, 0xa2, 0x6a, 0x4a, 0x95, 0x59, 0x10, 0x46, 0x29, 0x33, 0xfe, 0x6b, 0x91, 0xb9,
+	0x09, 0xcc, 0x62, 0xc5, 0x1e, 0x67, 0x12, 0xa9, 0x59, 0xf4, 0xf0, 0x60, 0x7f, 0xf4, 0x3e, 0xd7,
+	0x01, 0x18, 0x88, 0x7c, 0x26, 0x57, 0x39, 0xc0, 0xb5, 0x73, 0x00, 0x1b, 0x43, 0x8a, 0x03, 0x59,
+	0x8f, 0x3c, 0x3c, 0x90, 0x4f, 0xc8, 0x85, 0xf9, 0xb8, 0x88, 0x60, 0x6f, 0x28, 0xc0, 0x94, 0x77,
+	0x1c, 0xb5, 0xfa, 0x59, 0x52, 0x52, 0xc5, 0x15, 0x44, 0x8b, 0x1c, 0x60, 0x6a, 0xce, 0xd9, 0x67,
+	0x4d, 0xe8, 0xc5, 0xf9, 0xad, 0x6c, 0xc7, 0xd1, 0x57, 0x26, 0xe6, 0xef, 0x19, 0xec, 0x9a, 0xe3,
+	0xa0, 0xef, 0xe0, 0xca, 0x59, 0x6c, 0x29, 0x76, 0xcb, 0x13, 0xc3, 0x2f, 0x8c, 0xc3, 0x0b, 0x75,
+	0x8c, 0xbe, 0x81, 0xc5, 0x58, 0x11, 0x60, 0x1b, 0xb4, 0x7d, 0xcc, 0x14, 0xfd, 0xea, 0x24, 0x0a,
+	0x69, 0x3e, 0x86, 0xd1, 0x22, 0x9b, 0x1c, 0xe1, 0x02, 0x68, 0xf9, 0xb3, 0x70, 0xeb, 0xfd, 0xa1,
+	0xa5, 0xfe, 0xaf, 0x9e, 0x83, 0x96, 0xd7, 0xb2, 0xc6, 0x9b, 0xf3, 0xb8, 0x8f, 0x8c, 0x74, 0x1b,
+	0x34, 0xfc, 0xa6, 0x87, 0xdb, 0x14, 0x3b, 0x56, 0xbc, 0xdf, 0x5f, 0xc4, 0xca, 0x67, 0x4d, 0x8f,
+	0xde, 0xff, 0x54, 0xb0, 0xaa, 0x14, 0x7a, 0x3d, 0x97, 0x3a, 0xaf, 0x0f, 0x57, 0xdf, 0x41, 0x0f,
+	0xf4, 0xe2, 0x62, 0xda, 0x29, 0xff, 0x94, 0x0b, 0x07, 0x98, 0x1a, 0x7f, 0x2a, 0xa0, 0x8a, 0xf9,
+	0x6d, 0xd6, 0xfe, 0xfe, 0xdd, 0x1a, 0xbc, 0x07, 0x45, 0x9f, 0x10, 0x6a, 0x45, 0x88, 0x93, 0x97,
+	0xdf, 0x02, 0x03, 0x68, 0x84, 0x80, 0x35, 0x98, 0xc6, 0x4e, 0x07, 0x87, 0x92, 0xe0, 0xa3, 0xcb,
+	0x81, 0x78, 0x54, 0x0d, 0xa7, 0x83, 0x4d, 0xe1, 0x69, 0x7c, 0xaf, 0x40, 0x3e, 0x32, 0xa2, 0x75,
+	0x48, 0x51, 0x22, 0x45, 0xcd, 0x24, 0xc7, 0x4a, 0x51, 0x82, 0xbe, 0x84, 0x0c, 0xeb, 0x8a, 0x7a,
+	0x6a, 0x62, 0x6f, 0xee, 0xb7, 0x91, 0x7f, 0x91, 0x95, 0x33, 0xaf, 0x66, 0x38, 0x47, 0xee, 0xfd,
+	0x3d, 0x00, 0xd2, 0x02, 0x40, 0xc8, 0xcb, 0x12, 0x00, 0x00,
 }
@@ -10,6 +10,7 @@ package configurator
 
 import (
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator/protos"
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	storage2 "magma/orc8r/cloud/go/storage"
 
@@ -205,6 +206,54 @@ func (ent NetworkEntity) GetTypeAndKey() storage2.TypeAndKey {
 
 func (ent NetworkEntity) isEntityWriteOperation() {}
 
+// ChangeType is the kind of change described by a watch event
+type ChangeType int32
+
+const (
+	ChangeTypeCreated ChangeType = ChangeType(protos.ChangeType_CREATED)
+	ChangeTypeUpdated ChangeType = ChangeType(protos.ChangeType_UPDATED)
+	ChangeTypeDeleted ChangeType = ChangeType(protos.ChangeType_DELETED)
+)
+
+// EntityEvent describes a change to a network entity, as streamed by
+// WatchEntities.
+type EntityEvent struct {
+	Type      ChangeType
+	NetworkID string
+	// Entity is the entity after the change. For deletions, only the type
+	// and key of the entity are set.
+	Entity NetworkEntity
+}
+
+func (e EntityEvent) fromProto(protoEvent *protos.EntityEvent) (EntityEvent, error) {
+	e.Type = ChangeType(protoEvent.Type)
+	e.NetworkID = protoEvent.NetworkID
+	ent, err := e.Entity.fromStorageProto(protoEvent.Entity)
+	if err != nil {
+		return e, err
+	}
+	e.Entity = ent
+	return e, nil
+}
+
+// NetworkEvent describes a change to a network, as streamed by WatchNetworks.
+type NetworkEvent struct {
+	Type ChangeType
+	// Network is the network after the change. For deletions, only the ID of
+	// the network is set.
+	Network Network
+}
+
+func (e NetworkEvent) fromProto(protoEvent *protos.NetworkEvent) (NetworkEvent, error) {
+	e.Type = ChangeType(protoEvent.Type)
+	network, err := e.Network.fromStorageProto(protoEvent.Network)
+	if err != nil {
+		return e, err
+	}
+	e.Network = network
+	return e, nil
+}
+
 type NetworkEntities []NetworkEntity
 
 func (ne NetworkEntities) ToEntitiesByID() map[storage2.TypeAndKey]NetworkEntity {
@@ -349,6 +398,12 @@ type EntityUpdateCriteria struct {
 	AssociationsToSet    []storage2.TypeAndKey
 	AssociationsToAdd    []storage2.TypeAndKey
 	AssociationsToDelete []storage2.TypeAndKey
+
+	// If ExpectedVersion is set, the update will only be applied if the
+	// entity's current version matches it. Otherwise the write will fail with
+	// a FailedPrecondition error. An update which sets nothing but
+	// ExpectedVersion only checks the version and doesn't change the entity.
+	ExpectedVersion *uint64
 }
 
 func (euc EntityUpdateCriteria) toStorageProto() (*storage.EntityUpdateCriteria, error) {
@@ -379,6 +434,9 @@ func (euc EntityUpdateCriteria) toStorageProto() (*storage.EntityUpdateCriteria,
 	if euc.DeleteConfig {
 		ret.NewConfig = &wrappers.BytesValue{Value: []byte{}}
 	}
+	if euc.ExpectedVersion != nil {
+		ret.ExpectedVersion = &wrappers.UInt64Value{Value: *euc.ExpectedVersion}
+	}
 
 	return ret, nil
 }
@@ -102,3 +102,38 @@ func deleteLocation(tableId protos.TableID, recordId string, networkId string) e
 	}
 	return nil
 }
+
+// GetIMSILocationHistory returns the gateway hardware IDs the IMSI was
+// attached to with the attachment times, most recent first
+func GetIMSILocationHistory(imsi string) ([]*protos.LocationHistoryEntry, error) {
+	client, err := GetDirectorydClient()
+	if err != nil {
+		return nil, err
+	}
+	req := &protos.GetLocationHistoryRequest{
+		Table: protos.TableID_IMSI_TO_HWID,
+		Id:    imsi,
+	}
+	history, err := client.GetLocationHistory(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return history.Entries, nil
+}
+
+// GetIMSIsByHardwareId returns the IMSIs currently attached to the gateway
+func GetIMSIsByHardwareId(hwId string) ([]string, error) {
+	client, err := GetDirectorydClient()
+	if err != nil {
+		return nil, err
+	}
+	req := &protos.GetIDsByLocationRequest{
+		Table:    protos.TableID_IMSI_TO_HWID,
+		Location: hwId,
+	}
+	ids, err := client.GetIDsByLocation(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return ids.Ids, nil
+}
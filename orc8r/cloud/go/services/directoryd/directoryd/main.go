@@ -34,7 +34,13 @@ func main() {
 	if err != nil {
 		glog.Errorf("Failed to initialize datastore: %s", err)
 	}
-	store := storage.GetDirectorydPersistenceService(db)
+	maxLocationHistory := storage.DefaultMaxLocationHistory
+	if directoryService.Config != nil {
+		if configured, err := directoryService.Config.GetIntParam("max_location_history"); err == nil {
+			maxLocationHistory = configured
+		}
+	}
+	store := storage.NewDirectorydPersistenceService(db, maxLocationHistory)
 
 	// Create directory gRPC servicer
 	directorydServicer, err := servicers.NewDirectoryServicer(store)
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/directoryd"
@@ -99,5 +100,39 @@ func (srv *DirectoryServicer) DeleteLocation(ctx context.Context, request *proto
 	if err != nil {
 		return ret, srv.storage.DeleteRecord(request.Table, request.Id)
 	}
-	return ret, err
+	// Remove any legacy record too, so that the IMSI isn't returned by
+	// GetIDsByLocation anymore
+	err = srv.storage.DeleteRecord(request.Table, request.Id)
+	if err != nil && !datastore.IsErrNotFound(err) {
+		glog.Errorf("Error deleting legacy location record of %s: %s", request.Id, err)
+	}
+	return ret, nil
+}
+
+func (srv *DirectoryServicer) GetLocationHistory(ctx context.Context, request *protos.GetLocationHistoryRequest) (*protos.LocationHistory, error) {
+	if request == nil {
+		return nil, errors.New("Empty GetLocationHistoryRequest")
+	}
+	if len(request.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Missing record ID")
+	}
+	entries, err := srv.storage.GetLocationHistory(request.Table, request.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &protos.LocationHistory{Entries: entries}, nil
+}
+
+func (srv *DirectoryServicer) GetIDsByLocation(ctx context.Context, request *protos.GetIDsByLocationRequest) (*protos.RecordIDs, error) {
+	if request == nil {
+		return nil, errors.New("Empty GetIDsByLocationRequest")
+	}
+	if len(request.Location) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Missing location")
+	}
+	ids, err := srv.storage.GetRecordIDsByLocation(request.Table, request.Location)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &protos.RecordIDs{Ids: ids}, nil
 }
@@ -176,3 +176,43 @@ func TestDirectorydDeleteLocation(t *testing.T) {
 	_, err = srv.GetLocation(ctx, &getRequest)
 	assert.Error(t, err)
 }
+
+func TestDirectorydLocationHistoryAndIDsByLocation(t *testing.T) {
+	srv := createTestDirectorydServicer(t)
+	stateTestInit.StartTestService(t)
+	id := protos.Identity{}
+	idgw := protos.Identity_Gateway{HardwareId: testGwHwId1, NetworkId: testNetworkId, LogicalId: testGwLogicalId1}
+	id.SetGateway(&idgw)
+	ctx := id.NewContextWithIdentity(context.Background())
+
+	for _, subId := range []string{testSubId2, testSubId1} {
+		_, err := srv.UpdateLocation(ctx, &protos.UpdateDirectoryLocationRequest{Id: subId, Record: &protos.LocationRecord{}})
+		assert.NoError(t, err)
+	}
+
+	ids, err := srv.GetIDsByLocation(ctx, &protos.GetIDsByLocationRequest{Location: testGwHwId1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testSubId1, testSubId2}, ids.Ids)
+	ids, err = srv.GetIDsByLocation(ctx, &protos.GetIDsByLocationRequest{Location: testGwHwId2})
+	assert.NoError(t, err)
+	assert.Empty(t, ids.Ids)
+	_, err = srv.GetIDsByLocation(ctx, &protos.GetIDsByLocationRequest{})
+	assert.Error(t, err)
+
+	history, err := srv.GetLocationHistory(ctx, &protos.GetLocationHistoryRequest{Id: testSubId1})
+	assert.NoError(t, err)
+	assert.Len(t, history.Entries, 1)
+	assert.Equal(t, testGwHwId1, history.Entries[0].Location)
+	_, err = srv.GetLocationHistory(ctx, &protos.GetLocationHistoryRequest{})
+	assert.Error(t, err)
+
+	// Deleted records leave the by-location index but keep their history
+	_, err = srv.DeleteLocation(ctx, &protos.DeleteLocationRequest{Id: testSubId1})
+	assert.NoError(t, err)
+	ids, err = srv.GetIDsByLocation(ctx, &protos.GetIDsByLocationRequest{Location: testGwHwId1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testSubId2}, ids.Ids)
+	history, err = srv.GetLocationHistory(ctx, &protos.GetLocationHistoryRequest{Id: testSubId1})
+	assert.NoError(t, err)
+	assert.Len(t, history.Entries, 1)
+}
@@ -10,22 +10,132 @@ package storage
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/protos"
 )
 
+const (
+	// DefaultMaxLocationHistory is the default number of locations kept in
+	// the history of each record
+	DefaultMaxLocationHistory = 20
+
+	historyTableSuffix    = "_HISTORY"
+	byLocationTableSuffix = "_BY_LOCATION"
+)
+
 type DirectorydPersistenceServiceImpl struct {
-	db datastore.Api
+	db                 datastore.TxApi
+	maxLocationHistory int
+}
+
+func GetDirectorydPersistenceService(db datastore.TxApi) DirectorydPersistenceService {
+	return NewDirectorydPersistenceService(db, DefaultMaxLocationHistory)
 }
 
-func GetDirectorydPersistenceService(db datastore.Api) DirectorydPersistenceService {
-	return &DirectorydPersistenceServiceImpl{db: db}
+// NewDirectorydPersistenceService returns a persistence service which keeps
+// up to maxLocationHistory locations in the history of each record
+func NewDirectorydPersistenceService(db datastore.TxApi, maxLocationHistory int) DirectorydPersistenceService {
+	if maxLocationHistory <= 0 {
+		maxLocationHistory = DefaultMaxLocationHistory
+	}
+	return &DirectorydPersistenceServiceImpl{db: db, maxLocationHistory: maxLocationHistory}
 }
 
 func (store *DirectorydPersistenceServiceImpl) GetRecord(tableId protos.TableID, recordId string) (*protos.LocationRecord, error) {
+	return getRecord(store.db, tableId, recordId)
+}
+
+// UpdateOrCreateRecord persists the record. If its location changed, the
+// new location is added to the record's history and the record is moved
+// between the by-location index entries of its old and new locations. The
+// record, its history and the index are updated in one transaction.
+func (store *DirectorydPersistenceServiceImpl) UpdateOrCreateRecord(tableId protos.TableID, recordId string, record *protos.LocationRecord) error {
+	recordTbl := tableId.String()
+
+	value, err := protos.MarshalIntern(record)
+	if err != nil {
+		return fmt.Errorf("Error marshaling location record: %s", err)
+	}
+
+	return store.db.DoInTx(getTables(tableId), func(tx datastore.TxApi) error {
+		oldLocation, err := getLocation(tx, tableId, recordId)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Put(recordTbl, recordId, value); err != nil {
+			return fmt.Errorf("Error updating new location record: %s", err)
+		}
+
+		if oldLocation == record.GetLocation() {
+			return nil
+		}
+		if err := store.addToHistory(tx, tableId, recordId, record.GetLocation()); err != nil {
+			return err
+		}
+		if err := removeFromLocation(tx, tableId, oldLocation, recordId); err != nil {
+			return err
+		}
+		return addToLocation(tx, tableId, record.GetLocation(), recordId)
+	})
+}
+
+func (store *DirectorydPersistenceServiceImpl) DeleteRecord(tableId protos.TableID, recordId string) error {
 	recordTbl := tableId.String()
-	marshaledRecord, _, err := store.db.Get(recordTbl, recordId)
+
+	return store.db.DoInTx(getTables(tableId), func(tx datastore.TxApi) error {
+		marshaledRecord, _, err := tx.Get(recordTbl, recordId)
+		if err != nil {
+			return fmt.Errorf("Error finding location record: %s", err)
+		}
+
+		err = tx.Delete(recordTbl, recordId)
+		if err != nil {
+			return fmt.Errorf("Error deleting location record: %s", err)
+		}
+
+		record := &protos.LocationRecord{}
+		if err := protos.Unmarshal(marshaledRecord, record); err != nil {
+			return fmt.Errorf("Error unmarshalling location record: %s", err)
+		}
+		return removeFromLocation(tx, tableId, record.Location, recordId)
+	})
+}
+
+func (store *DirectorydPersistenceServiceImpl) GetLocationHistory(tableId protos.TableID, recordId string) ([]*protos.LocationHistoryEntry, error) {
+	history, err := getHistory(store.db, tableId, recordId)
+	if err != nil {
+		return nil, err
+	}
+	return history.Entries, nil
+}
+
+func (store *DirectorydPersistenceServiceImpl) GetRecordIDsByLocation(tableId protos.TableID, location string) ([]string, error) {
+	prefix := getByLocationKeyPrefix(location)
+	keys, err := store.db.ListKeysWithPrefix(tableId.String()+byLocationTableSuffix, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting record IDs by location: %s", err)
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.TrimPrefix(key, prefix))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// getTables returns the tables holding the records of tableId, their history
+// and their by-location index
+func getTables(tableId protos.TableID) []string {
+	return []string{tableId.String(), tableId.String() + historyTableSuffix, tableId.String() + byLocationTableSuffix}
+}
+
+func getRecord(db datastore.Api, tableId protos.TableID, recordId string) (*protos.LocationRecord, error) {
+	marshaledRecord, _, err := db.Get(tableId.String(), recordId)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting location record: %s", err)
 	}
@@ -37,31 +147,81 @@ func (store *DirectorydPersistenceServiceImpl) GetRecord(tableId protos.TableID,
 	return ret, nil
 }
 
-func (store *DirectorydPersistenceServiceImpl) UpdateOrCreateRecord(tableId protos.TableID, recordId string, record *protos.LocationRecord) error {
-	recordTbl := tableId.String()
+// getLocation returns the current location of the record, or an empty
+// string if the record doesn't exist
+func getLocation(db datastore.Api, tableId protos.TableID, recordId string) (string, error) {
+	record, err := getRecord(db, tableId, recordId)
+	if err == nil {
+		return record.Location, nil
+	}
+	if datastore.IsErrNotFound(err) {
+		return "", nil
+	}
+	return "", err
+}
 
-	value, err := protos.MarshalIntern(record)
+func getHistory(db datastore.Api, tableId protos.TableID, recordId string) (*protos.LocationHistory, error) {
+	ret := &protos.LocationHistory{}
+	marshaledHistory, _, err := db.Get(tableId.String()+historyTableSuffix, recordId)
 	if err != nil {
-		return fmt.Errorf("Error marshaling location record: %s", err)
+		if datastore.IsErrNotFound(err) {
+			return ret, nil
+		}
+		return nil, fmt.Errorf("Error getting location history: %s", err)
 	}
+	if err := protos.Unmarshal(marshaledHistory, ret); err != nil {
+		return nil, fmt.Errorf("Error unmarshalling location history: %s", err)
+	}
+	return ret, nil
+}
 
-	if err := store.db.Put(recordTbl, recordId, value); err != nil {
-		return fmt.Errorf("Error updating new location record: %s", err)
+func (store *DirectorydPersistenceServiceImpl) addToHistory(db datastore.Api, tableId protos.TableID, recordId string, location string) error {
+	history, err := getHistory(db, tableId, recordId)
+	if err != nil {
+		return err
+	}
+	entry := &protos.LocationHistoryEntry{Location: location, TimeMs: clock.Now().UnixNano() / 1e6}
+	history.Entries = append([]*protos.LocationHistoryEntry{entry}, history.Entries...)
+	if len(history.Entries) > store.maxLocationHistory {
+		history.Entries = history.Entries[:store.maxLocationHistory]
+	}
+
+	value, err := protos.MarshalIntern(history)
+	if err != nil {
+		return fmt.Errorf("Error marshaling location history: %s", err)
+	}
+	if err := db.Put(tableId.String()+historyTableSuffix, recordId, value); err != nil {
+		return fmt.Errorf("Error updating location history: %s", err)
 	}
 	return nil
 }
 
-func (store *DirectorydPersistenceServiceImpl) DeleteRecord(tableId protos.TableID, recordId string) error {
-	recordTbl := tableId.String()
+// getByLocationKeyPrefix returns the prefix of the by-location index keys of
+// the records at the location. The index has one row per location and
+// record, keyed by the prefix followed by the record ID. The location is
+// length-prefixed so that no location's prefix is a prefix of another's.
+func getByLocationKeyPrefix(location string) string {
+	return fmt.Sprintf("%d:%s:", len(location), location)
+}
 
-	_, _, err := store.db.Get(recordTbl, recordId)
-	if err != nil {
-		return fmt.Errorf("Error finding location record: %s", err)
+func addToLocation(db datastore.Api, tableId protos.TableID, location string, recordId string) error {
+	if location == "" {
+		return nil
+	}
+	key := getByLocationKeyPrefix(location) + recordId
+	if err := db.Put(tableId.String()+byLocationTableSuffix, key, []byte{}); err != nil {
+		return fmt.Errorf("Error updating record IDs by location: %s", err)
 	}
+	return nil
+}
 
-	err = store.db.Delete(recordTbl, recordId)
-	if err != nil {
-		return fmt.Errorf("Error deleting location record: %s", err)
+func removeFromLocation(db datastore.Api, tableId protos.TableID, location string, recordId string) error {
+	if location == "" {
+		return nil
+	}
+	key := getByLocationKeyPrefix(location) + recordId
+	if err := db.Delete(tableId.String()+byLocationTableSuffix, key); err != nil {
+		return fmt.Errorf("Error deleting record IDs by location: %s", err)
 	}
-	return err
+	return nil
 }
@@ -11,7 +11,10 @@ package storage_test
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/directoryd/storage"
 	"magma/orc8r/cloud/go/test_utils"
@@ -132,6 +135,137 @@ func TestPersistenceService_UpdateOrCreateRecord(t *testing.T) {
 	assertDatastoreWritesSucceeded(t, db, location_map)
 }
 
+func TestPersistenceService_LocationHistory(t *testing.T) {
+	db := test_utils.NewMockDatastore()
+	store := storage.NewDirectorydPersistenceService(db, 2)
+	table := protos.TableID_IMSI_TO_HWID
+
+	history, err := store.GetLocationHistory(table, "sid1")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+	err = store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw1"})
+	assert.NoError(t, err)
+
+	// Updates to the same location aren't recorded
+	clock.SetAndFreezeClock(t, time.Unix(2000, 0))
+	err = store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw1"})
+	assert.NoError(t, err)
+	history, err = store.GetLocationHistory(table, "sid1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.LocationHistoryEntry{{Location: "gw1", TimeMs: 1000000}}, history)
+
+	// The most recent locations come first and the history is bounded
+	err = store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw2"})
+	assert.NoError(t, err)
+	clock.SetAndFreezeClock(t, time.Unix(3000, 0))
+	err = store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw3"})
+	assert.NoError(t, err)
+	history, err = store.GetLocationHistory(table, "sid1")
+	assert.NoError(t, err)
+	expected := []*protos.LocationHistoryEntry{
+		{Location: "gw3", TimeMs: 3000000},
+		{Location: "gw2", TimeMs: 2000000},
+	}
+	assert.Equal(t, expected, history)
+
+	// The history outlives the record
+	err = store.DeleteRecord(table, "sid1")
+	assert.NoError(t, err)
+	history, err = store.GetLocationHistory(table, "sid1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, history)
+}
+
+func TestPersistenceService_GetRecordIDsByLocation(t *testing.T) {
+	db := test_utils.NewMockDatastore()
+	store := storage.GetDirectorydPersistenceService(db)
+	table := protos.TableID_IMSI_TO_HWID
+
+	ids, err := store.GetRecordIDsByLocation(table, "gw1")
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	assert.NoError(t, store.UpdateOrCreateRecord(table, "sid2", &protos.LocationRecord{Location: "gw1"}))
+	assert.NoError(t, store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw1"}))
+	assert.NoError(t, store.UpdateOrCreateRecord(table, "sid3", &protos.LocationRecord{Location: "gw2"}))
+	ids, err = store.GetRecordIDsByLocation(table, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1", "sid2"}, ids)
+
+	// Moving a record updates both locations
+	assert.NoError(t, store.UpdateOrCreateRecord(table, "sid2", &protos.LocationRecord{Location: "gw2"}))
+	ids, err = store.GetRecordIDsByLocation(table, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1"}, ids)
+	ids, err = store.GetRecordIDsByLocation(table, "gw2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid2", "sid3"}, ids)
+
+	// Locations are per table
+	ids, err = store.GetRecordIDsByLocation(protos.TableID_HWID_TO_HOSTNAME, "gw2")
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	assert.NoError(t, store.DeleteRecord(table, "sid1"))
+	ids, err = store.GetRecordIDsByLocation(table, "gw1")
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	assert.Error(t, store.DeleteRecord(table, "sid1"))
+}
+
+func TestPersistenceService_UpdateOrCreateRecordIsAtomic(t *testing.T) {
+	db := &failingDatastore{MockDatastore: test_utils.NewMockDatastore(), failTable: "IMSI_TO_HWID_BY_LOCATION"}
+	store := storage.GetDirectorydPersistenceService(db)
+	table := protos.TableID_IMSI_TO_HWID
+
+	// A failed index update rolls back the record and its history
+	err := store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw1"})
+	assert.Error(t, err)
+	_, err = store.GetRecord(table, "sid1")
+	assert.Error(t, err)
+	history, err := store.GetLocationHistory(table, "sid1")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	db.failTable = ""
+	assert.NoError(t, store.UpdateOrCreateRecord(table, "sid1", &protos.LocationRecord{Location: "gw1"}))
+	db.failTable = "IMSI_TO_HWID_BY_LOCATION"
+	assert.Error(t, store.DeleteRecord(table, "sid1"))
+	record, err := store.GetRecord(table, "sid1")
+	assert.NoError(t, err)
+	assert.Equal(t, "gw1", record.Location)
+	ids, err := store.GetRecordIDsByLocation(table, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1"}, ids)
+}
+
+// failingDatastore fails all writes to failTable
+type failingDatastore struct {
+	*test_utils.MockDatastore
+	failTable string
+}
+
+func (db *failingDatastore) Put(table string, key string, value []byte) error {
+	if table == db.failTable {
+		return errors.New("mock put error")
+	}
+	return db.MockDatastore.Put(table, key, value)
+}
+
+func (db *failingDatastore) Delete(table string, key string) error {
+	if table == db.failTable {
+		return errors.New("mock delete error")
+	}
+	return db.MockDatastore.Delete(table, key)
+}
+
+func (db *failingDatastore) DoInTx(tables []string, fn func(store datastore.TxApi) error) error {
+	return db.MockDatastore.DoInTx(tables, func(datastore.TxApi) error { return fn(db) })
+}
+
 func assertDatastoreWritesSucceeded(t *testing.T, store *test_utils.MockDatastore, location_map map[string]interface{}) {
 	test_utils.AssertDatastoreHasRows(
 		t, store,
@@ -26,4 +26,11 @@ type DirectorydPersistenceService interface {
 
 	// Delete location record
 	DeleteRecord(tableId protos.TableID, recordId string) error
+
+	// Get the bounded location history of a record, most recent first. The
+	// history is kept when the record is deleted
+	GetLocationHistory(tableId protos.TableID, recordId string) ([]*protos.LocationHistoryEntry, error)
+
+	// Get the IDs of the records currently at the location, sorted
+	GetRecordIDsByLocation(tableId protos.TableID, location string) ([]string, error)
 }
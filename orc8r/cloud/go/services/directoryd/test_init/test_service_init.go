@@ -24,7 +24,7 @@ import (
 func StartTestService(t *testing.T) {
 	srv, lis := test_utils.NewTestService(t, orc8r.ModuleName, directoryd.ServiceName)
 
-	db := test_utils.GetMockDatastoreInstance()
+	db := test_utils.NewMockDatastore()
 	persistence_service := storage.GetDirectorydPersistenceService(db)
 
 	// Create directory gRPC servicer
@@ -0,0 +1,267 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package broker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/memstore"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/storage"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// requestTTL is how long an in-flight request is kept in the shared store
+	// before it is garbage collected. Requests are deleted once they're
+	// cancelled by the HTTP server, so this only collects the requests of
+	// replicas which died before cancelling them. This is well above the HTTP
+	// server's response timeout.
+	requestTTL = 5 * time.Minute
+
+	maxRequestIDAttempts = 5
+)
+
+// DistributedGatewayRPCBroker implements a GatewayRPCBroker which routes
+// requests through a store shared by multiple dispatcher replicas, so a
+// request received by one replica can be sent to a gateway whose SyncRPC
+// stream is held by another.
+//
+// Requests for gateways connected to this replica are enqueued directly.
+// Requests for gateways connected to other replicas are picked up by the
+// owning replica when it polls the store, and responses travel back to the
+// originating replica the same way. Because all routing state lives in the
+// store, a replica restart does not lose track of in-flight requests.
+type DistributedGatewayRPCBroker struct {
+	replicaID     string
+	store         storage.SyncRPCStore
+	responseTable *memstore.ResponseTableImpl
+	requests      memstore.RequestQueue
+}
+
+// NewDistributedGatewayRPCBroker returns a broker for the replica with the
+// given ID, which must be unique across dispatcher replicas sharing the store.
+// Run must be called to route requests and responses between replicas.
+func NewDistributedGatewayRPCBroker(replicaID string, store storage.SyncRPCStore) *DistributedGatewayRPCBroker {
+	return &DistributedGatewayRPCBroker{
+		replicaID:     replicaID,
+		store:         store,
+		responseTable: memstore.NewResponseTable(processResponseTimeout),
+		requests:      memstore.NewRequestQueue(queueLen),
+	}
+}
+
+// Run polls the shared store at the given interval, dispatching requests
+// for gateways connected to this replica and delivering responses to
+// requests which originated here. Run blocks forever.
+func (broker *DistributedGatewayRPCBroker) Run(pollInterval time.Duration) {
+	lastGC := time.Now()
+	for {
+		broker.Poll()
+		if time.Since(lastGC) > requestTTL {
+			broker.collectGarbage()
+			lastGC = time.Now()
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Poll performs a single round of request dispatching and response delivery.
+func (broker *DistributedGatewayRPCBroker) Poll() {
+	pendingRequests, err := broker.store.ClaimPendingRequests(broker.replicaID)
+	if err != nil {
+		glog.Errorf("Failed to claim pending SyncRPC requests: %v", err)
+	}
+	for _, req := range pendingRequests {
+		broker.dispatchRequest(req)
+	}
+
+	responses, err := broker.store.PopResponses(broker.replicaID)
+	if err != nil {
+		glog.Errorf("Failed to get SyncRPC responses: %v", err)
+	}
+	for _, resp := range responses {
+		err := broker.responseTable.SendResponse(&protos.SyncRPCResponse{ReqId: resp.ReqID, RespBody: resp.Response})
+		if err != nil {
+			glog.Errorf("Failed to deliver response for reqId %d: %v", resp.ReqID, err)
+		}
+	}
+}
+
+func (broker *DistributedGatewayRPCBroker) SendRequestToGateway(
+	gwReq *protos.GatewayRequest,
+) (*GatewayResponseChannel, error) {
+	if gwReq == nil || len(gwReq.GwId) == 0 {
+		return nil, errors.New("gwReq cannot be nil and gwId cannot be empty string")
+	}
+	owner, err := broker.store.GetGatewayReplica(gwReq.GwId)
+	if err == merrors.ErrNotFound {
+		return nil, fmt.Errorf("No dispatcher holds a SyncRPC stream for gwId %v", gwReq.GwId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isLocal := owner == broker.replicaID
+	routedReq := &storage.RoutedRequest{
+		GatewayID:     gwReq.GwId,
+		OriginReplica: broker.replicaID,
+		Request:       gwReq,
+		Dispatched:    isLocal,
+		CreatedAt:     time.Now().Unix(),
+	}
+	// Register the response channel before the request becomes visible to
+	// other replicas, so no response can arrive before it
+	var respChan chan *protos.GatewayResponse
+	for attempt := 0; ; attempt++ {
+		routedReq.ReqID = generateDistributedReqId()
+		respChan = broker.responseTable.InitializeResponseWithID(routedReq.ReqID)
+		err = broker.store.CreateRequest(routedReq)
+		if err == nil {
+			break
+		}
+		broker.responseTable.CleanupResponse(routedReq.ReqID)
+		if err != storage.ErrDuplicateRequestID || attempt >= maxRequestIDAttempts {
+			return nil, err
+		}
+	}
+
+	if isLocal {
+		syncRPCReq := &protos.SyncRPCRequest{ReqId: routedReq.ReqID, ReqBody: gwReq}
+		if err := broker.requests.Enqueue(syncRPCReq); err != nil {
+			broker.responseTable.CleanupResponse(routedReq.ReqID)
+			broker.deleteRequestLogOnError(routedReq.ReqID)
+			return nil, err
+		}
+	}
+	return &GatewayResponseChannel{RespChan: respChan, ReqId: routedReq.ReqID}, nil
+}
+
+func (broker *DistributedGatewayRPCBroker) ProcessGatewayResponse(response *protos.SyncRPCResponse) error {
+	if response == nil {
+		return errors.New("cannot process nil SyncRPCResponse")
+	}
+	req, err := broker.store.GetRequest(response.ReqId)
+	if err == merrors.ErrNotFound {
+		return fmt.Errorf("No in-flight request found for reqId %v", response.ReqId)
+	}
+	if err != nil {
+		return err
+	}
+	if req.OriginReplica == broker.replicaID {
+		return broker.responseTable.SendResponse(response)
+	}
+	return broker.store.AddResponse(response.ReqId, response.RespBody)
+}
+
+func (broker *DistributedGatewayRPCBroker) InitializeGateway(gwId string) chan *protos.SyncRPCRequest {
+	initializedQueue := broker.requests.InitializeQueue(gwId)
+	if err := broker.store.SetGatewayReplica(gwId, broker.replicaID); err != nil {
+		glog.Errorf("Failed to record SyncRPC stream for gwId %v: %v", gwId, err)
+	}
+	return initializedQueue.NewQueue
+}
+
+func (broker *DistributedGatewayRPCBroker) CleanupGateway(gwId string) error {
+	broker.requests.CleanupQueue(gwId)
+	return broker.store.ClearGatewayReplica(gwId, broker.replicaID)
+}
+
+func (broker *DistributedGatewayRPCBroker) CancelGatewayRequest(gwId string, reqId uint32) error {
+	broker.responseTable.CleanupResponse(reqId)
+	owner, err := broker.store.GetGatewayReplica(gwId)
+	if err == nil && owner == broker.replicaID {
+		syncRPCRequest := &protos.SyncRPCRequest{ReqId: reqId, ReqBody: &protos.GatewayRequest{GwId: gwId}, ConnClosed: true}
+		if err := broker.requests.Enqueue(syncRPCRequest); err != nil {
+			return err
+		}
+		broker.deleteRequestLogOnError(reqId)
+		return nil
+	}
+
+	if err == merrors.ErrNotFound {
+		// No replica holds the gateway's stream, so there is no one to send
+		// the cancellation to. The request is finished.
+		return broker.store.DeleteRequest(reqId)
+	}
+
+	err = broker.store.CancelRequest(reqId)
+	if err == merrors.ErrNotFound {
+		// The request has already been cleaned up, nothing to cancel
+		return nil
+	}
+	return err
+}
+
+// dispatchRequest sends a request claimed from the store down to a gateway
+// connected to this replica.
+func (broker *DistributedGatewayRPCBroker) dispatchRequest(req *storage.RoutedRequest) {
+	syncRPCReq := &protos.SyncRPCRequest{ReqId: req.ReqID, ReqBody: req.Request}
+	if req.Cancelled {
+		syncRPCReq = &protos.SyncRPCRequest{ReqId: req.ReqID, ReqBody: &protos.GatewayRequest{GwId: req.GatewayID}, ConnClosed: true}
+	}
+	err := broker.requests.Enqueue(syncRPCReq)
+	if err == nil {
+		if req.Cancelled {
+			broker.deleteRequestLogOnError(req.ReqID)
+		}
+		return
+	}
+	glog.Errorf("Failed to dispatch reqId %d to gwId %v: %v", req.ReqID, req.GatewayID, err)
+
+	// A cancellation is retried on a later poll, until the request expires.
+	// The origin of any other request is sent an error response instead, and
+	// cancels the request once it is done with it.
+	if !req.Cancelled {
+		errResp := &protos.GatewayResponse{Err: fmt.Sprintf("failed to dispatch request to gateway %s: %v", req.GatewayID, err)}
+		err = broker.sendResponseToOrigin(req, errResp)
+		if err == nil {
+			return
+		}
+		glog.Errorf("Failed to send error response for reqId %d: %v", req.ReqID, err)
+	}
+	if err := broker.store.ReleaseRequest(req.ReqID); err != nil && err != merrors.ErrNotFound {
+		glog.Errorf("Failed to release reqId %d: %v", req.ReqID, err)
+	}
+}
+
+// sendResponseToOrigin delivers a response to the replica which a request
+// originated from.
+func (broker *DistributedGatewayRPCBroker) sendResponseToOrigin(req *storage.RoutedRequest, resp *protos.GatewayResponse) error {
+	if req.OriginReplica == broker.replicaID {
+		return broker.responseTable.SendResponse(&protos.SyncRPCResponse{ReqId: req.ReqID, RespBody: resp})
+	}
+	return broker.store.AddResponse(req.ReqID, resp)
+}
+
+func (broker *DistributedGatewayRPCBroker) collectGarbage() {
+	err := broker.store.DeleteRequestsCreatedBefore(time.Now().Add(-requestTTL).Unix())
+	if err != nil {
+		glog.Errorf("Failed to delete expired SyncRPC requests: %v", err)
+	}
+}
+
+func (broker *DistributedGatewayRPCBroker) deleteRequestLogOnError(reqId uint32) {
+	if err := broker.store.DeleteRequest(reqId); err != nil {
+		glog.Errorf("Failed to delete reqId %d: %v", reqId, err)
+	}
+}
+
+// generateDistributedReqId returns a random non-zero request ID. IDs have to
+// be unique across replicas, so they can't come from a local counter. IDs
+// are kept within the range of a signed 32-bit integer.
+func generateDistributedReqId() uint32 {
+	return uint32(rand.Int31n(math.MaxInt32-1)) + 1
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package broker_test
+
+import (
+	"testing"
+	"time"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributedGatewayRPCBroker_Local(t *testing.T) {
+	store := storage.NewMemorySyncRPCStore()
+	replica1 := broker.NewDistributedGatewayRPCBroker("replica1", store)
+
+	// No replica holds a stream for the gateway
+	_, err := replica1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1"})
+	assert.EqualError(t, err, "No dispatcher holds a SyncRPC stream for gwId gw1")
+
+	queue := replica1.InitializeGateway("gw1")
+	gwReq := &protos.GatewayRequest{GwId: "gw1", Path: "/magma.Service/Method"}
+	respChannel, err := replica1.SendRequestToGateway(gwReq)
+	assert.NoError(t, err)
+
+	syncRPCReq := <-queue
+	assert.Equal(t, respChannel.ReqId, syncRPCReq.ReqId)
+	assert.Equal(t, gwReq.Path, syncRPCReq.ReqBody.Path)
+
+	gwResp := &protos.GatewayResponse{Status: "200", Payload: []byte("resp")}
+	go func() {
+		assert.NoError(t, replica1.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: syncRPCReq.ReqId, RespBody: gwResp}))
+	}()
+	actual := <-respChannel.RespChan
+	assert.Equal(t, []byte("resp"), actual.Payload)
+
+	// Cancelling sends a ConnClosed request down the stream
+	assert.NoError(t, replica1.CancelGatewayRequest("gw1", respChannel.ReqId))
+	cancelReq := <-queue
+	assert.Equal(t, respChannel.ReqId, cancelReq.ReqId)
+	assert.True(t, cancelReq.ConnClosed)
+
+	assert.NoError(t, replica1.CleanupGateway("gw1"))
+	_, err = store.GetGatewayReplica("gw1")
+	assert.Error(t, err)
+}
+
+func TestDistributedGatewayRPCBroker_CrossReplica(t *testing.T) {
+	store := storage.NewMemorySyncRPCStore()
+	replica1 := broker.NewDistributedGatewayRPCBroker("replica1", store)
+	replica2 := broker.NewDistributedGatewayRPCBroker("replica2", store)
+
+	// gw1 is connected to replica2, request comes in to replica1
+	queue := replica2.InitializeGateway("gw1")
+	gwReq := &protos.GatewayRequest{GwId: "gw1", Path: "/magma.Service/Method"}
+	respChannel, err := replica1.SendRequestToGateway(gwReq)
+	assert.NoError(t, err)
+
+	// replica2 picks up the request on its next poll
+	replica2.Poll()
+	syncRPCReq := receiveWithTimeout(t, queue)
+	assert.Equal(t, respChannel.ReqId, syncRPCReq.ReqId)
+	assert.Equal(t, gwReq.Path, syncRPCReq.ReqBody.Path)
+
+	// The gateway streams 2 responses back to replica2, which forwards them
+	// to replica1 through the store
+	assert.NoError(t, replica2.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: syncRPCReq.ReqId, RespBody: &protos.GatewayResponse{KeepConnActive: true}}))
+	assert.NoError(t, replica2.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: syncRPCReq.ReqId, RespBody: &protos.GatewayResponse{Status: "200", Payload: []byte("resp")}}))
+	go replica1.Poll()
+	first := <-respChannel.RespChan
+	assert.True(t, first.KeepConnActive)
+	second := <-respChannel.RespChan
+	assert.Equal(t, []byte("resp"), second.Payload)
+
+	// Cancellation also travels through the store
+	assert.NoError(t, replica1.CancelGatewayRequest("gw1", respChannel.ReqId))
+	replica2.Poll()
+	cancelReq := receiveWithTimeout(t, queue)
+	assert.Equal(t, respChannel.ReqId, cancelReq.ReqId)
+	assert.True(t, cancelReq.ConnClosed)
+	_, err = store.GetRequest(respChannel.ReqId)
+	assert.Error(t, err)
+}
+
+func TestDistributedGatewayRPCBroker_ReplicaRestart(t *testing.T) {
+	store := storage.NewMemorySyncRPCStore()
+	replica1 := broker.NewDistributedGatewayRPCBroker("replica1", store)
+	replica2 := broker.NewDistributedGatewayRPCBroker("replica2", store)
+	replica2.InitializeGateway("gw1")
+
+	respChannel, err := replica1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1"})
+	assert.NoError(t, err)
+
+	// replica2 restarts before dispatching the request, and the gateway
+	// reconnects to it. The pending request is still delivered.
+	restarted := broker.NewDistributedGatewayRPCBroker("replica2", store)
+	queue := restarted.InitializeGateway("gw1")
+	restarted.Poll()
+	syncRPCReq := receiveWithTimeout(t, queue)
+	assert.Equal(t, respChannel.ReqId, syncRPCReq.ReqId)
+
+	assert.NoError(t, restarted.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: syncRPCReq.ReqId, RespBody: &protos.GatewayResponse{Status: "200"}}))
+	go replica1.Poll()
+	resp := <-respChannel.RespChan
+	assert.Equal(t, "200", resp.Status)
+}
+
+func TestDistributedGatewayRPCBroker_CancelDisconnected(t *testing.T) {
+	store := storage.NewMemorySyncRPCStore()
+	replica1 := broker.NewDistributedGatewayRPCBroker("replica1", store)
+	replica2 := broker.NewDistributedGatewayRPCBroker("replica2", store)
+	replica2.InitializeGateway("gw1")
+	respChannel, err := replica1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1"})
+	assert.NoError(t, err)
+
+	// The gateway disconnects before the request completes, so cancelling
+	// the request deletes it right away
+	assert.NoError(t, replica2.CleanupGateway("gw1"))
+	assert.NoError(t, replica1.CancelGatewayRequest("gw1", respChannel.ReqId))
+	_, err = store.GetRequest(respChannel.ReqId)
+	assert.Equal(t, merrors.ErrNotFound, err)
+}
+
+func TestDistributedGatewayRPCBroker_DispatchFailure(t *testing.T) {
+	store := storage.NewMemorySyncRPCStore()
+	replica1 := broker.NewDistributedGatewayRPCBroker("replica1", store)
+	replica2 := broker.NewDistributedGatewayRPCBroker("replica2", store)
+
+	// gw1 is recorded as connected to replica2, but replica2 has no stream
+	// for it, so the request can't be dispatched. replica1 receives an error
+	// response.
+	assert.NoError(t, store.SetGatewayReplica("gw1", "replica2"))
+	respChannel, err := replica1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1"})
+	assert.NoError(t, err)
+	replica2.Poll()
+	go replica1.Poll()
+	resp := <-respChannel.RespChan
+	assert.Contains(t, resp.Err, "failed to dispatch request to gateway gw1")
+
+	// A cancellation which can't be dispatched is retried on the next poll
+	assert.NoError(t, replica1.CancelGatewayRequest("gw1", respChannel.ReqId))
+	replica2.Poll()
+	req, err := store.GetRequest(respChannel.ReqId)
+	assert.NoError(t, err)
+	assert.True(t, req.Cancelled)
+	assert.False(t, req.Dispatched)
+
+	queue := replica2.InitializeGateway("gw1")
+	replica2.Poll()
+	cancelReq := receiveWithTimeout(t, queue)
+	assert.True(t, cancelReq.ConnClosed)
+	_, err = store.GetRequest(respChannel.ReqId)
+	assert.Equal(t, merrors.ErrNotFound, err)
+}
+
+func receiveWithTimeout(t *testing.T, queue chan *protos.SyncRPCRequest) *protos.SyncRPCRequest {
+	select {
+	case req := <-queue:
+		return req
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for request")
+		return nil
+	}
+}
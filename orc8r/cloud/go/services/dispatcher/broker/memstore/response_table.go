@@ -47,6 +47,20 @@ func (table *ResponseTableImpl) InitializeResponse() (chan *protos.GatewayRespon
 	return respChan, reqId
 }
 
+// InitializeResponseWithID binds a new GatewayResponse channel to a request
+// ID which has been allocated by the caller. This is used by brokers which
+// need request IDs to be unique across multiple dispatcher replicas.
+func (table *ResponseTableImpl) InitializeResponseWithID(reqId uint32) chan *protos.GatewayResponse {
+	respChan := make(chan *protos.GatewayResponse)
+	table.respChanByReqId.Store(reqId, respChan)
+	return respChan
+}
+
+// CleanupResponse removes the response channel for a request from the table.
+func (table *ResponseTableImpl) CleanupResponse(reqId uint32) {
+	table.respChanByReqId.Delete(reqId)
+}
+
 // SendResponse sends the response to the corresponding response channel
 func (table *ResponseTableImpl) SendResponse(resp *protos.SyncRPCResponse) error {
 	if resp == nil {
@@ -0,0 +1,195 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package storage
+
+import (
+	"sort"
+	"sync"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type memorySyncRPCStore struct {
+	sync.Mutex
+	replicasByGateway map[string]string
+	requestsByID      map[uint32]*RoutedRequest
+	responsesByID     map[uint32][]*RoutedResponse
+	lastSeqByID       map[uint32]uint64
+}
+
+// NewMemorySyncRPCStore returns a SyncRPCStore which keeps its state in
+// process memory. All brokers sharing the returned store behave as if they
+// were replicas sharing a backing database, so it can be used as a test
+// double for the SQL store.
+func NewMemorySyncRPCStore() SyncRPCStore {
+	return &memorySyncRPCStore{
+		replicasByGateway: map[string]string{},
+		requestsByID:      map[uint32]*RoutedRequest{},
+		responsesByID:     map[uint32][]*RoutedResponse{},
+		lastSeqByID:       map[uint32]uint64{},
+	}
+}
+
+func (m *memorySyncRPCStore) Initialize() error {
+	return nil
+}
+
+func (m *memorySyncRPCStore) SetGatewayReplica(gatewayID string, replica string) error {
+	m.Lock()
+	defer m.Unlock()
+	m.replicasByGateway[gatewayID] = replica
+	return nil
+}
+
+func (m *memorySyncRPCStore) ClearGatewayReplica(gatewayID string, replica string) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.replicasByGateway[gatewayID] == replica {
+		delete(m.replicasByGateway, gatewayID)
+	}
+	return nil
+}
+
+func (m *memorySyncRPCStore) GetGatewayReplica(gatewayID string) (string, error) {
+	m.Lock()
+	defer m.Unlock()
+	replica, ok := m.replicasByGateway[gatewayID]
+	if !ok {
+		return "", merrors.ErrNotFound
+	}
+	return replica, nil
+}
+
+func (m *memorySyncRPCStore) CreateRequest(req *RoutedRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, exists := m.requestsByID[req.ReqID]; exists {
+		return ErrDuplicateRequestID
+	}
+	m.requestsByID[req.ReqID] = copyRequest(req)
+	return nil
+}
+
+func (m *memorySyncRPCStore) GetRequest(reqID uint32) (*RoutedRequest, error) {
+	m.Lock()
+	defer m.Unlock()
+	req, ok := m.requestsByID[reqID]
+	if !ok {
+		return nil, merrors.ErrNotFound
+	}
+	return copyRequest(req), nil
+}
+
+func (m *memorySyncRPCStore) CancelRequest(reqID uint32) error {
+	m.Lock()
+	defer m.Unlock()
+	req, ok := m.requestsByID[reqID]
+	if !ok {
+		return merrors.ErrNotFound
+	}
+	req.Cancelled = true
+	req.Dispatched = false
+	return nil
+}
+
+func (m *memorySyncRPCStore) ReleaseRequest(reqID uint32) error {
+	m.Lock()
+	defer m.Unlock()
+	req, ok := m.requestsByID[reqID]
+	if !ok {
+		return merrors.ErrNotFound
+	}
+	req.Dispatched = false
+	return nil
+}
+
+func (m *memorySyncRPCStore) ClaimPendingRequests(replica string) ([]*RoutedRequest, error) {
+	m.Lock()
+	defer m.Unlock()
+	ret := []*RoutedRequest{}
+	for _, req := range m.requestsByID {
+		if req.Dispatched || m.replicasByGateway[req.GatewayID] != replica {
+			continue
+		}
+		req.Dispatched = true
+		ret = append(ret, copyRequest(req))
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ReqID < ret[j].ReqID })
+	return ret, nil
+}
+
+func (m *memorySyncRPCStore) DeleteRequest(reqID uint32) error {
+	m.Lock()
+	defer m.Unlock()
+	m.deleteRequestUnsafe(reqID)
+	return nil
+}
+
+func (m *memorySyncRPCStore) DeleteRequestsCreatedBefore(createdAt int64) error {
+	m.Lock()
+	defer m.Unlock()
+	for id, req := range m.requestsByID {
+		if req.CreatedAt < createdAt {
+			m.deleteRequestUnsafe(id)
+		}
+	}
+	return nil
+}
+
+func (m *memorySyncRPCStore) deleteRequestUnsafe(reqID uint32) {
+	delete(m.requestsByID, reqID)
+	delete(m.responsesByID, reqID)
+	delete(m.lastSeqByID, reqID)
+}
+
+func (m *memorySyncRPCStore) AddResponse(reqID uint32, response *protos.GatewayResponse) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.requestsByID[reqID]; !ok {
+		return merrors.ErrNotFound
+	}
+	m.lastSeqByID[reqID]++
+	resp := &RoutedResponse{ReqID: reqID, Seq: m.lastSeqByID[reqID]}
+	if response != nil {
+		resp.Response = proto.Clone(response).(*protos.GatewayResponse)
+	}
+	m.responsesByID[reqID] = append(m.responsesByID[reqID], resp)
+	return nil
+}
+
+func (m *memorySyncRPCStore) PopResponses(replica string) ([]*RoutedResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	ret := []*RoutedResponse{}
+	for id, responses := range m.responsesByID {
+		if m.requestsByID[id].OriginReplica != replica {
+			continue
+		}
+		ret = append(ret, responses...)
+		delete(m.responsesByID, id)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].ReqID == ret[j].ReqID {
+			return ret[i].Seq < ret[j].Seq
+		}
+		return ret[i].ReqID < ret[j].ReqID
+	})
+	return ret, nil
+}
+
+func copyRequest(req *RoutedRequest) *RoutedRequest {
+	ret := *req
+	if req.Request != nil {
+		ret.Request = proto.Clone(req.Request).(*protos.GatewayRequest)
+	}
+	return &ret
+}
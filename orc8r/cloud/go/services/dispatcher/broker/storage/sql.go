@@ -0,0 +1,422 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	gatewaysTable  = "sync_rpc_gateways"
+	requestsTable  = "sync_rpc_requests"
+	responsesTable = "sync_rpc_responses"
+
+	gwIDCol       = "gateway_id"
+	replicaCol    = "replica"
+	reqIDCol      = "req_id"
+	originCol     = "origin_replica"
+	bodyCol       = "body"
+	dispatchedCol = "dispatched"
+	cancelledCol  = "cancelled"
+	createdAtCol  = "created_at"
+	seqCol        = "seq"
+)
+
+type sqlSyncRPCStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// NewSQLSyncRPCStore returns a SyncRPCStore backed by the given SQL database.
+func NewSQLSyncRPCStore(db *sql.DB, builder sqorc.StatementBuilder) SyncRPCStore {
+	return &sqlSyncRPCStore{db: db, builder: builder}
+}
+
+func (store *sqlSyncRPCStore) Initialize() error {
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		_, err := store.builder.CreateTable(gatewaysTable).
+			IfNotExists().
+			Column(gwIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+			Column(replicaCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gateways table")
+		}
+
+		_, err = store.builder.CreateTable(requestsTable).
+			IfNotExists().
+			Column(reqIDCol).Type(sqorc.ColumnTypeBigInt).PrimaryKey().EndColumn().
+			Column(gwIDCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(originCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(bodyCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+			Column(dispatchedCol).Type(sqorc.ColumnTypeBool).NotNull().Default(false).EndColumn().
+			Column(cancelledCol).Type(sqorc.ColumnTypeBool).NotNull().Default(false).EndColumn().
+			Column(createdAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create requests table")
+		}
+
+		_, err = store.builder.CreateTable(responsesTable).
+			IfNotExists().
+			Column(reqIDCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(seqCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(originCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(bodyCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+			PrimaryKey(reqIDCol, seqCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create responses table")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlSyncRPCStore) SetGatewayReplica(gatewayID string, replica string) error {
+	_, err := store.builder.Insert(gatewaysTable).
+		Columns(gwIDCol, replicaCol).
+		Values(gatewayID, replica).
+		OnConflict([]sqorc.UpsertValue{{Column: replicaCol, Value: replica}}, gwIDCol).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set replica for gateway %s", gatewayID)
+	}
+	return nil
+}
+
+func (store *sqlSyncRPCStore) ClearGatewayReplica(gatewayID string, replica string) error {
+	_, err := store.builder.Delete(gatewaysTable).
+		Where(sq.Eq{gwIDCol: gatewayID, replicaCol: replica}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to clear replica for gateway %s", gatewayID)
+	}
+	return nil
+}
+
+func (store *sqlSyncRPCStore) GetGatewayReplica(gatewayID string) (string, error) {
+	var replica string
+	err := store.builder.Select(replicaCol).From(gatewaysTable).
+		Where(sq.Eq{gwIDCol: gatewayID}).
+		RunWith(store.db).
+		QueryRow().
+		Scan(&replica)
+	if err == sql.ErrNoRows {
+		return "", merrors.ErrNotFound
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get replica for gateway %s", gatewayID)
+	}
+	return replica, nil
+}
+
+func (store *sqlSyncRPCStore) CreateRequest(req *RoutedRequest) error {
+	body, err := proto.Marshal(req.Request)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+	// Rely on the primary key rather than checking for an existing request
+	// first, so that concurrent callers can't race between the two
+	_, err = store.builder.Insert(requestsTable).
+		Columns(reqIDCol, gwIDCol, originCol, bodyCol, dispatchedCol, cancelledCol, createdAtCol).
+		Values(req.ReqID, req.GatewayID, req.OriginReplica, body, req.Dispatched, req.Cancelled, req.CreatedAt).
+		RunWith(store.db).
+		Exec()
+	if sqorc.IsUniqueViolation(err) {
+		return ErrDuplicateRequestID
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to insert request")
+	}
+	return nil
+}
+
+func (store *sqlSyncRPCStore) GetRequest(reqID uint32) (*RoutedRequest, error) {
+	rows, err := store.selectRequests().
+		Where(sq.Eq{reqIDCol: reqID}).
+		RunWith(store.db).
+		Query()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get request %d", reqID)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "GetRequest")
+
+	reqs, err := scanRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(reqs) == 0 {
+		return nil, merrors.ErrNotFound
+	}
+	return reqs[0], nil
+}
+
+func (store *sqlSyncRPCStore) CancelRequest(reqID uint32) error {
+	res, err := store.builder.Update(requestsTable).
+		Set(cancelledCol, true).
+		Set(dispatchedCol, false).
+		Where(sq.Eq{reqIDCol: reqID}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to cancel request %d", reqID)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return merrors.ErrNotFound
+	}
+	return nil
+}
+
+func (store *sqlSyncRPCStore) ReleaseRequest(reqID uint32) error {
+	res, err := store.builder.Update(requestsTable).
+		Set(dispatchedCol, false).
+		Where(sq.Eq{reqIDCol: reqID}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to release request %d", reqID)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return merrors.ErrNotFound
+	}
+	return nil
+}
+
+func (store *sqlSyncRPCStore) ClaimPendingRequests(replica string) ([]*RoutedRequest, error) {
+	ret, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		// Subqueries must use the default placeholder format, the outer query
+		// will rewrite all placeholders
+		ownedGateways := sq.Select(gwIDCol).From(gatewaysTable).Where(sq.Eq{replicaCol: replica})
+		ownedGatewaysSQL, ownedGatewaysArgs, err := ownedGateways.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		rows, err := store.selectRequests().
+			Where(sq.Eq{dispatchedCol: false}).
+			Where(fmt.Sprintf("%s IN (%s)", gwIDCol, ownedGatewaysSQL), ownedGatewaysArgs...).
+			OrderBy(reqIDCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query pending requests")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "ClaimPendingRequests")
+		reqs, err := scanRequests(rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(reqs) == 0 {
+			return reqs, nil
+		}
+
+		// Each request is claimed by an update guarded on the request still
+		// being pending, so that a request which is claimed concurrently by
+		// another replica is only returned by the call which changed it
+		claimedIDs := make([]uint32, 0, len(reqs))
+		for _, req := range reqs {
+			res, err := store.builder.Update(requestsTable).
+				Set(dispatchedCol, true).
+				Where(sq.Eq{reqIDCol: req.ReqID, dispatchedCol: false}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to claim request %d", req.ReqID)
+			}
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to claim request %d", req.ReqID)
+			}
+			if affected == 1 {
+				claimedIDs = append(claimedIDs, req.ReqID)
+			}
+		}
+		if len(claimedIDs) == 0 {
+			return []*RoutedRequest{}, nil
+		}
+
+		// Load the claimed requests again, they may have been cancelled since
+		// they were selected
+		claimedRows, err := store.selectRequests().
+			Where(sq.Eq{reqIDCol: claimedIDs}).
+			OrderBy(reqIDCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query claimed requests")
+		}
+		defer sqorc.CloseRowsLogOnError(claimedRows, "ClaimPendingRequests")
+		return scanRequests(claimedRows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]*RoutedRequest), nil
+}
+
+func (store *sqlSyncRPCStore) DeleteRequest(reqID uint32) error {
+	return store.deleteRequests(sq.Eq{reqIDCol: reqID})
+}
+
+func (store *sqlSyncRPCStore) DeleteRequestsCreatedBefore(createdAt int64) error {
+	return store.deleteRequests(sq.Lt{createdAtCol: createdAt})
+}
+
+func (store *sqlSyncRPCStore) deleteRequests(where sq.Sqlizer) error {
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		reqIDs := sq.Select(reqIDCol).From(requestsTable).Where(where)
+		reqIDsSQL, reqIDsArgs, err := reqIDs.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		_, err = store.builder.Delete(responsesTable).
+			Where(fmt.Sprintf("%s IN (%s)", reqIDCol, reqIDsSQL), reqIDsArgs...).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete responses")
+		}
+		_, err = store.builder.Delete(requestsTable).Where(where).RunWith(tx).Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete requests")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlSyncRPCStore) AddResponse(reqID uint32, response *protos.GatewayResponse) error {
+	var body []byte
+	if response != nil {
+		marshaled, err := proto.Marshal(response)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal response")
+		}
+		body = marshaled
+	}
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		var origin string
+		err := store.builder.Select(originCol).From(requestsTable).
+			Where(sq.Eq{reqIDCol: reqID}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&origin)
+		if err == sql.ErrNoRows {
+			return nil, merrors.ErrNotFound
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get request %d", reqID)
+		}
+
+		var lastSeq uint64
+		err = store.builder.Select(fmt.Sprintf("COALESCE(MAX(%s), 0)", seqCol)).From(responsesTable).
+			Where(sq.Eq{reqIDCol: reqID}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&lastSeq)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get last response sequence for request %d", reqID)
+		}
+
+		_, err = store.builder.Insert(responsesTable).
+			Columns(reqIDCol, seqCol, originCol, bodyCol).
+			Values(reqID, lastSeq+1, origin, body).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to insert response for request %d", reqID)
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlSyncRPCStore) PopResponses(replica string) ([]*RoutedResponse, error) {
+	ret, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		rows, err := store.builder.Select(reqIDCol, seqCol, bodyCol).From(responsesTable).
+			Where(sq.Eq{originCol: replica}).
+			OrderBy(reqIDCol, seqCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query responses")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "PopResponses")
+
+		responses := []*RoutedResponse{}
+		for rows.Next() {
+			resp := &RoutedResponse{}
+			var body []byte
+			if err := rows.Scan(&resp.ReqID, &resp.Seq, &body); err != nil {
+				return nil, errors.Wrap(err, "failed to scan response")
+			}
+			if body != nil {
+				resp.Response = &protos.GatewayResponse{}
+				if err := proto.Unmarshal(body, resp.Response); err != nil {
+					return nil, errors.Wrap(err, "failed to unmarshal response")
+				}
+			}
+			responses = append(responses, resp)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, resp := range responses {
+			_, err := store.builder.Delete(responsesTable).
+				Where(sq.Eq{reqIDCol: resp.ReqID, seqCol: resp.Seq}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to delete delivered response")
+			}
+		}
+		return responses, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]*RoutedResponse), nil
+}
+
+func (store *sqlSyncRPCStore) selectRequests() sq.SelectBuilder {
+	return store.builder.Select(reqIDCol, gwIDCol, originCol, bodyCol, dispatchedCol, cancelledCol, createdAtCol).
+		From(requestsTable)
+}
+
+func scanRequests(rows *sql.Rows) ([]*RoutedRequest, error) {
+	ret := []*RoutedRequest{}
+	for rows.Next() {
+		req := &RoutedRequest{Request: &protos.GatewayRequest{}}
+		var body []byte
+		err := rows.Scan(&req.ReqID, &req.GatewayID, &req.OriginReplica, &body, &req.Dispatched, &req.Cancelled, &req.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan request")
+		}
+		if err := proto.Unmarshal(body, req.Request); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal request")
+		}
+		ret = append(ret, req)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package storage contains the backing store shared by all dispatcher
+// replicas when the SyncRPC broker runs in distributed mode. It records which
+// replica holds each gateway's SyncRPC stream, the requests which are in
+// flight to each gateway, and the responses which still have to be delivered
+// to the replica that originated the request.
+package storage
+
+import (
+	"errors"
+
+	"magma/orc8r/cloud/go/protos"
+)
+
+// ErrDuplicateRequestID is returned by CreateRequest if a request with the
+// same ID is already in flight.
+var ErrDuplicateRequestID = errors.New("request ID already exists")
+
+// RoutedRequest is a SyncRPC request along with the routing information
+// needed to deliver it and its responses.
+type RoutedRequest struct {
+	ReqID uint32
+	// GatewayID is the hardware ID of the gateway the request is sent to
+	GatewayID string
+	// OriginReplica is the dispatcher replica which is waiting for responses
+	OriginReplica string
+	Request       *protos.GatewayRequest
+	// Dispatched is true if the request has been sent down to the gateway
+	Dispatched bool
+	// Cancelled is true if the originator has asked to cancel the request
+	Cancelled bool
+	// CreatedAt is the creation time of the request, in unix seconds
+	CreatedAt int64
+}
+
+// RoutedResponse is a response from a gateway which needs to be delivered to
+// the replica which originated the request.
+type RoutedResponse struct {
+	ReqID uint32
+	// Seq orders responses to the same request
+	Seq      uint64
+	Response *protos.GatewayResponse
+}
+
+// SyncRPCStore is the shared state of the distributed SyncRPC broker.
+// Implementations must be safe for concurrent use by multiple replicas.
+type SyncRPCStore interface {
+	// Initialize creates any tables or resources needed by the store.
+	Initialize() error
+
+	// SetGatewayReplica records that the replica holds the SyncRPC stream for
+	// the gateway, overwriting any previous owner.
+	SetGatewayReplica(gatewayID string, replica string) error
+
+	// ClearGatewayReplica removes the gateway's owner record if it is still
+	// owned by the replica.
+	ClearGatewayReplica(gatewayID string, replica string) error
+
+	// GetGatewayReplica returns the replica which holds the SyncRPC stream
+	// for the gateway. Returns errors.ErrNotFound if no replica does.
+	GetGatewayReplica(gatewayID string) (string, error)
+
+	// CreateRequest records a new in-flight request. Returns
+	// ErrDuplicateRequestID if the request's ID is already in use.
+	CreateRequest(req *RoutedRequest) error
+
+	// GetRequest returns the in-flight request with the given ID. Returns
+	// errors.ErrNotFound if there is no such request.
+	GetRequest(reqID uint32) (*RoutedRequest, error)
+
+	// CancelRequest marks a request as cancelled and schedules it to be
+	// dispatched again so the owner of the gateway sends the cancellation.
+	CancelRequest(reqID uint32) error
+
+	// ClaimPendingRequests returns all requests which have not yet been
+	// dispatched to gateways owned by the replica, and marks them dispatched.
+	// A request is only returned by the call which marked it dispatched, even
+	// if several replicas claim requests concurrently.
+	ClaimPendingRequests(replica string) ([]*RoutedRequest, error)
+
+	// ReleaseRequest marks a claimed request as not dispatched, so that it is
+	// claimed again by the replica owning its gateway. Returns
+	// errors.ErrNotFound if there is no such request.
+	ReleaseRequest(reqID uint32) error
+
+	// DeleteRequest deletes an in-flight request and any of its undelivered
+	// responses.
+	DeleteRequest(reqID uint32) error
+
+	// DeleteRequestsCreatedBefore deletes all requests created before the
+	// given time, in unix seconds, and any of their undelivered responses.
+	DeleteRequestsCreatedBefore(createdAt int64) error
+
+	// AddResponse appends a gateway's response to an in-flight request.
+	AddResponse(reqID uint32, response *protos.GatewayResponse) error
+
+	// PopResponses returns and deletes all responses to requests which
+	// originated at the replica, in order.
+	PopResponses(replica string) ([]*RoutedResponse, error)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySyncRPCStore(t *testing.T) {
+	testSyncRPCStore(t, storage.NewMemorySyncRPCStore())
+}
+
+func TestSQLSyncRPCStore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	testSyncRPCStore(t, storage.NewSQLSyncRPCStore(db, sqorc.GetSqlBuilder()))
+}
+
+func TestMemorySyncRPCStore_ConcurrentClaims(t *testing.T) {
+	testConcurrentClaims(t, storage.NewMemorySyncRPCStore())
+}
+
+func TestSQLSyncRPCStore_ConcurrentClaims(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	testConcurrentClaims(t, storage.NewSQLSyncRPCStore(db, sqorc.GetSqlBuilder()))
+}
+
+func testSyncRPCStore(t *testing.T, store storage.SyncRPCStore) {
+	assert.NoError(t, store.Initialize())
+
+	// Gateway ownership
+	_, err := store.GetGatewayReplica("gw1")
+	assert.Equal(t, merrors.ErrNotFound, err)
+	assert.NoError(t, store.SetGatewayReplica("gw1", "replica1"))
+	assert.NoError(t, store.SetGatewayReplica("gw2", "replica2"))
+	replica, err := store.GetGatewayReplica("gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, "replica1", replica)
+
+	// gw1 moves to replica2, then replica1's stale cleanup must not clear it
+	assert.NoError(t, store.SetGatewayReplica("gw1", "replica2"))
+	assert.NoError(t, store.ClearGatewayReplica("gw1", "replica1"))
+	replica, err = store.GetGatewayReplica("gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, "replica2", replica)
+	assert.NoError(t, store.ClearGatewayReplica("gw1", "replica2"))
+	_, err = store.GetGatewayReplica("gw1")
+	assert.Equal(t, merrors.ErrNotFound, err)
+
+	// Requests
+	req1 := &storage.RoutedRequest{ReqID: 1, GatewayID: "gw2", OriginReplica: "replica1", Request: &protos.GatewayRequest{GwId: "gw2", Path: "/a"}, CreatedAt: 100}
+	req2 := &storage.RoutedRequest{ReqID: 2, GatewayID: "gw2", OriginReplica: "replica1", Request: &protos.GatewayRequest{GwId: "gw2", Path: "/b"}, CreatedAt: 200}
+	req3 := &storage.RoutedRequest{ReqID: 3, GatewayID: "gw3", OriginReplica: "replica2", Request: &protos.GatewayRequest{GwId: "gw3", Path: "/c"}, CreatedAt: 300}
+	assert.NoError(t, store.CreateRequest(req1))
+	assert.NoError(t, store.CreateRequest(req2))
+	assert.NoError(t, store.CreateRequest(req3))
+	assert.Equal(t, storage.ErrDuplicateRequestID, store.CreateRequest(req1))
+
+	actual, err := store.GetRequest(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "gw2", actual.GatewayID)
+	assert.Equal(t, "replica1", actual.OriginReplica)
+	assert.Equal(t, "/a", actual.Request.Path)
+	assert.False(t, actual.Dispatched)
+	_, err = store.GetRequest(42)
+	assert.Equal(t, merrors.ErrNotFound, err)
+
+	// Only requests for gateways held by the replica are claimed, and only once
+	claimed, err := store.ClaimPendingRequests("replica1")
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+	claimed, err = store.ClaimPendingRequests("replica2")
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 2)
+	assert.Equal(t, uint32(1), claimed[0].ReqID)
+	assert.Equal(t, uint32(2), claimed[1].ReqID)
+	assert.True(t, claimed[0].Dispatched)
+	claimed, err = store.ClaimPendingRequests("replica2")
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// Cancelling a request makes it pending again
+	assert.NoError(t, store.CancelRequest(2))
+	assert.Equal(t, merrors.ErrNotFound, store.CancelRequest(42))
+	claimed, err = store.ClaimPendingRequests("replica2")
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, uint32(2), claimed[0].ReqID)
+	assert.True(t, claimed[0].Cancelled)
+
+	// Releasing a request makes it pending again
+	assert.NoError(t, store.ReleaseRequest(2))
+	assert.Equal(t, merrors.ErrNotFound, store.ReleaseRequest(42))
+	actual, err = store.GetRequest(2)
+	assert.NoError(t, err)
+	assert.False(t, actual.Dispatched)
+	claimed, err = store.ClaimPendingRequests("replica2")
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, uint32(2), claimed[0].ReqID)
+
+	// Responses are delivered to the originating replica in order
+	assert.NoError(t, store.AddResponse(1, &protos.GatewayResponse{Status: "200", KeepConnActive: true}))
+	assert.NoError(t, store.AddResponse(1, &protos.GatewayResponse{Status: "200", Payload: []byte("done")}))
+	assert.Equal(t, merrors.ErrNotFound, store.AddResponse(42, &protos.GatewayResponse{}))
+	responses, err := store.PopResponses("replica2")
+	assert.NoError(t, err)
+	assert.Empty(t, responses)
+	responses, err = store.PopResponses("replica1")
+	assert.NoError(t, err)
+	assert.Len(t, responses, 2)
+	assert.Equal(t, uint64(1), responses[0].Seq)
+	assert.True(t, responses[0].Response.KeepConnActive)
+	assert.Equal(t, uint64(2), responses[1].Seq)
+	assert.Equal(t, []byte("done"), responses[1].Response.Payload)
+	responses, err = store.PopResponses("replica1")
+	assert.NoError(t, err)
+	assert.Empty(t, responses)
+
+	// Deletion
+	assert.NoError(t, store.AddResponse(1, &protos.GatewayResponse{Status: "200"}))
+	assert.NoError(t, store.DeleteRequest(1))
+	_, err = store.GetRequest(1)
+	assert.Equal(t, merrors.ErrNotFound, err)
+	responses, err = store.PopResponses("replica1")
+	assert.NoError(t, err)
+	assert.Empty(t, responses)
+
+	assert.NoError(t, store.DeleteRequestsCreatedBefore(250))
+	_, err = store.GetRequest(2)
+	assert.Equal(t, merrors.ErrNotFound, err)
+	_, err = store.GetRequest(3)
+	assert.NoError(t, err)
+}
+
+// Each pending request is claimed exactly once, even when a replica's claims
+// run concurrently
+func testConcurrentClaims(t *testing.T, store storage.SyncRPCStore) {
+	const numRequests, numClaimers = 50, 4
+	assert.NoError(t, store.Initialize())
+	assert.NoError(t, store.SetGatewayReplica("gw1", "replica1"))
+	for i := uint32(1); i <= numRequests; i++ {
+		assert.NoError(t, store.CreateRequest(&storage.RoutedRequest{ReqID: i, GatewayID: "gw1", OriginReplica: "replica2", Request: &protos.GatewayRequest{GwId: "gw1"}}))
+	}
+
+	claims := make(chan []*storage.RoutedRequest, numClaimers)
+	for i := 0; i < numClaimers; i++ {
+		go func() {
+			claimed, err := store.ClaimPendingRequests("replica1")
+			assert.NoError(t, err)
+			claims <- claimed
+		}()
+	}
+	claimedIDs := map[uint32]int{}
+	for i := 0; i < numClaimers; i++ {
+		for _, req := range <-claims {
+			claimedIDs[req.ReqID]++
+		}
+	}
+	assert.Len(t, claimedIDs, numRequests)
+	for id, count := range claimedIDs {
+		assert.Equal(t, 1, count, "request %d claimed %d times", id, count)
+	}
+}
@@ -13,20 +13,31 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"time"
 
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/services/dispatcher"
 	sync_rpc_broker "magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/storage"
 	"magma/orc8r/cloud/go/services/dispatcher/httpserver"
 	"magma/orc8r/cloud/go/services/dispatcher/servicers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 	"google.golang.org/grpc"
 )
 
-const HTTP_SERVER_PORT = 9080
+const (
+	HTTP_SERVER_PORT = 9080
+
+	brokerBackendMemory       = "memory"
+	brokerBackendSQL          = "sql"
+	defaultBrokerPollInterval = 50 * time.Millisecond
+)
 
 func main() {
 	// Set MaxConnectionAge to infinity so Sync RPC stream doesn't restart
@@ -44,12 +55,12 @@ func main() {
 		glog.Fatalf("Error creating service: %s", err)
 	}
 
-	// create a broker
-	broker := sync_rpc_broker.NewGatewayReqRespBroker()
-
 	// get ec2 public host name
 	hostName := getHostName()
 	glog.V(2).Infof("hostName is: %v\n", hostName)
+
+	// create a broker
+	broker := newBroker(hostName)
 	// create servicer
 	syncRpcServicer, err := servicers.NewSyncRPCService(hostName, broker)
 	if err != nil {
@@ -71,6 +82,44 @@ func main() {
 	}
 }
 
+// newBroker creates the SyncRPC broker for the backend set in the service
+// config. The hostName identifies this replica to other replicas.
+func newBroker(hostName string) sync_rpc_broker.GatewayRPCBroker {
+	backend := brokerBackendMemory
+	pollInterval := defaultBrokerPollInterval
+	dispatcherConfig, err := config.GetServiceConfig(orc8r.ModuleName, dispatcher.ServiceName)
+	if err != nil {
+		glog.Errorf("Failed to load dispatcher config, using in-memory broker: %v", err)
+	} else {
+		if configured, err := dispatcherConfig.GetStringParam("broker_backend"); err == nil {
+			backend = configured
+		}
+		if intervalMs, err := dispatcherConfig.GetIntParam("broker_poll_interval_ms"); err == nil {
+			pollInterval = time.Duration(intervalMs) * time.Millisecond
+		}
+	}
+
+	switch backend {
+	case brokerBackendMemory:
+		return sync_rpc_broker.NewGatewayReqRespBroker()
+	case brokerBackendSQL:
+		db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+		if err != nil {
+			glog.Fatalf("Failed to connect to database: %s", err)
+		}
+		store := storage.NewSQLSyncRPCStore(db, sqorc.GetSqlBuilder())
+		if err := store.Initialize(); err != nil {
+			glog.Fatalf("Error initializing SyncRPC broker database: %s", err)
+		}
+		broker := sync_rpc_broker.NewDistributedGatewayRPCBroker(hostName, store)
+		go broker.Run(pollInterval)
+		return broker
+	default:
+		glog.Fatalf("Unrecognized SyncRPC broker backend %s", backend)
+		return nil
+	}
+}
+
 // getHostName of the current SyncRPCService instance
 func getHostName() string {
 	// If there is env variable override, use the env variable
@@ -0,0 +1,133 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+)
+
+// BatchWriter writes a batch of log records to a log backend
+type BatchWriter interface {
+	Write(records []*LogRecord) error
+}
+
+// PartialWriteError is returned by BatchWriters when some records of the
+// batch were written. The batch is dropped instead of retried so written
+// records aren't duplicated.
+type PartialWriteError struct {
+	Err error
+}
+
+func (e *PartialWriteError) Error() string {
+	return e.Err.Error()
+}
+
+// BufferedExporter queues submitted log entries and writes them to its
+// BatchWriter in batches. The queue is bounded: once it's full, Submit
+// rejects entries with a ResourceExhausted error instead of dropping queued
+// ones, so that clients back off while the backend is slow or unavailable.
+// Records which fail to be written stay queued and are retried on the next
+// export.
+type BufferedExporter struct {
+	name           string
+	writer         BatchWriter
+	queue          []*LogRecord
+	queueMutex     sync.Mutex
+	queueLen       int
+	batchSize      int
+	exportInterval time.Duration
+}
+
+func NewBufferedExporter(
+	name string,
+	writer BatchWriter,
+	queueLen int,
+	batchSize int,
+	exportInterval time.Duration,
+) *BufferedExporter {
+	return &BufferedExporter{
+		name:           name,
+		writer:         writer,
+		queueLen:       queueLen,
+		batchSize:      batchSize,
+		exportInterval: exportInterval,
+	}
+}
+
+func (e *BufferedExporter) Start() {
+	go e.exportEvery()
+}
+
+func (e *BufferedExporter) exportEvery() {
+	for range time.Tick(e.exportInterval) {
+		err := e.Export()
+		if err != nil {
+			glog.Errorf("Error in exporting to %s: %v", e.name, err)
+		}
+	}
+}
+
+// Export writes all queued records, one batch at a time. It stops at the
+// first batch which fails to be written.
+func (e *BufferedExporter) Export() error {
+	for {
+		e.queueMutex.Lock()
+		n := len(e.queue)
+		if n > e.batchSize {
+			n = e.batchSize
+		}
+		batch := e.queue[:n:n]
+		e.queueMutex.Unlock()
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := e.writer.Write(batch)
+		if _, ok := err.(*PartialWriteError); err != nil && !ok {
+			return err
+		}
+		// only Export removes records, so the batch is still at the head of
+		// the queue
+		e.queueMutex.Lock()
+		e.queue = e.queue[len(batch):]
+		e.queueMutex.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e *BufferedExporter) Submit(logEntries []*protos.LogEntry) error {
+	records, err := NewLogRecords(logEntries)
+	if err != nil {
+		return protos.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	e.queueMutex.Lock()
+	defer e.queueMutex.Unlock()
+	if len(e.queue)+len(records) > e.queueLen {
+		return protos.Errorf(
+			codes.ResourceExhausted,
+			"%s exporter queue is full, rejecting %d log entries", e.name, len(records))
+	}
+	e.queue = append(e.queue, records...)
+	return nil
+}
+
+// QueueLength returns the number of queued records
+func (e *BufferedExporter) QueueLength() int {
+	e.queueMutex.Lock()
+	defer e.queueMutex.Unlock()
+	return len(e.queue)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockWriter struct {
+	batches [][]*exporters.LogRecord
+	err     error
+}
+
+func (w *mockWriter) Write(records []*exporters.LogRecord) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.batches = append(w.batches, records)
+	return nil
+}
+
+func TestBufferedExporter(t *testing.T) {
+	writer := &mockWriter{}
+	exporter := exporters.NewBufferedExporter("test", writer, 5, 2, time.Second)
+
+	err := exporter.Submit([]*protos.LogEntry{{Category: "test"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 0, exporter.QueueLength())
+
+	assert.NoError(t, exporter.Submit(getLogEntries(3)))
+	// the queue is bounded, entries are rejected rather than dropped
+	err = exporter.Submit(getLogEntries(3))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 3, exporter.QueueLength())
+	assert.NoError(t, exporter.Submit(getLogEntries(2)))
+	assert.Equal(t, 5, exporter.QueueLength())
+
+	// failed batches stay queued
+	writer.err = errors.New("backend down")
+	assert.Error(t, exporter.Export())
+	assert.Equal(t, 5, exporter.QueueLength())
+
+	// partially written batches are dropped
+	writer.err = &exporters.PartialWriteError{Err: errors.New("partial")}
+	assert.Error(t, exporter.Export())
+	assert.Equal(t, 3, exporter.QueueLength())
+
+	writer.err = nil
+	assert.NoError(t, exporter.Export())
+	assert.Equal(t, 0, exporter.QueueLength())
+	assert.Len(t, writer.batches, 2)
+	assert.Len(t, writer.batches[0], 2)
+	assert.Len(t, writer.batches[1], 1)
+	assert.Equal(t, "test", writer.batches[0][0].Category)
+	assert.Equal(t, time.Unix(12347, 0).UTC(), writer.batches[0][0].Time)
+	assert.Equal(t, `{"normal":{"status":"ACTIVE"}}`, writer.batches[0][0].Message)
+
+	assert.NoError(t, exporter.Submit(getLogEntries(5)))
+}
+
+func getLogEntries(n int) []*protos.LogEntry {
+	entries := make([]*protos.LogEntry, 0, n)
+	for i := 0; i < n; i++ {
+		entries = append(entries, &protos.LogEntry{
+			Category:  "test",
+			NormalMap: map[string]string{"status": "ACTIVE"},
+			Time:      12345 + int64(i),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,259 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+)
+
+const (
+	ScribeExporterName = "scribe"
+
+	FluentdExporterType = "fluentd"
+	ElasticExporterType = "elasticsearch"
+	SyslogExporterType  = "syslog"
+
+	DefaultQueueLength    = 10000
+	DefaultBatchSize      = 500
+	DefaultExportInterval = 10 * time.Second
+
+	exportersConfigKey         = "exporters"
+	categoryExportersConfigKey = "category_exporters"
+	defaultExportersConfigKey  = "default_exporters"
+	typeConfigKey              = "type"
+	addressConfigKey           = "address"
+	urlConfigKey               = "url"
+	networkConfigKey           = "network"
+	tagPrefixConfigKey         = "tag_prefix"
+	indexPrefixConfigKey       = "index_prefix"
+	appNameConfigKey           = "app_name"
+	queueLengthConfigKey       = "queue_length"
+	batchSizeConfigKey         = "batch_size"
+	exportIntervalConfigKey    = "export_interval_secs"
+)
+
+// ExporterConfig is the config of a named buffered exporter. Address is
+// used by fluentd and syslog exporters, URL by elasticsearch exporters.
+type ExporterConfig struct {
+	Name           string
+	Type           string
+	Address        string
+	URL            string
+	Network        string
+	TagPrefix      string
+	IndexPrefix    string
+	AppName        string
+	QueueLength    int
+	BatchSize      int
+	ExportInterval time.Duration
+}
+
+// RoutingConfig selects the exporters of each log category by name. The
+// scribe exporter is named "scribe".
+type RoutingConfig struct {
+	// Exporters are the configured buffered exporters, sorted by name
+	Exporters         []ExporterConfig
+	CategoryExporters map[string][]string
+	DefaultExporters  []string
+}
+
+// GetRoutingConfig reads the exporters and their routing from the logger
+// config. Entries of categories without routes are exported to the default
+// exporters, scribe unless configured otherwise.
+//
+//	exporters:
+//	  fluentd:
+//	    type: fluentd
+//	    address: "fluentd:24224"
+//	  es:
+//	    type: elasticsearch
+//	    url: "http://elasticsearch:9200"
+//	    queue_length: 10000
+//	    batch_size: 500
+//	    export_interval_secs: 10
+//	category_exporters:
+//	  perfpipe_magma_gateway_service_exit: [scribe, es]
+//	default_exporters: [fluentd]
+func GetRoutingConfig(cfg *config.ConfigMap) (*RoutingConfig, error) {
+	ret := &RoutingConfig{
+		CategoryExporters: map[string][]string{},
+		DefaultExporters:  []string{ScribeExporterName},
+	}
+	if cfg == nil {
+		return ret, nil
+	}
+
+	exportersMap, err := getMap(cfg, exportersConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	for rawName, rawExporter := range exportersMap {
+		name, ok := rawName.(string)
+		if !ok || len(name) == 0 || name == ScribeExporterName {
+			return nil, fmt.Errorf("invalid exporter name %v", rawName)
+		}
+		exporterMap, ok := rawExporter.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config of exporter %s must be a map", name)
+		}
+		exporterCfg, err := getExporterConfig(name, config.NewConfigMap(exporterMap))
+		if err != nil {
+			return nil, err
+		}
+		ret.Exporters = append(ret.Exporters, exporterCfg)
+	}
+	sort.Slice(ret.Exporters, func(i, j int) bool { return ret.Exporters[i].Name < ret.Exporters[j].Name })
+
+	categoriesMap, err := getMap(cfg, categoryExportersConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	categoryCfg := config.NewConfigMap(categoriesMap)
+	for rawCategory := range categoriesMap {
+		category, ok := rawCategory.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid log category %v in %s", rawCategory, categoryExportersConfigKey)
+		}
+		names, err := categoryCfg.GetStringArrayParam(category)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exporters of log category %s: %s", category, err)
+		}
+		ret.CategoryExporters[category] = names
+	}
+
+	if _, found := cfg.RawMap[defaultExportersConfigKey]; found {
+		ret.DefaultExporters, err = cfg.GetStringArrayParam(defaultExportersConfigKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", defaultExportersConfigKey, err)
+		}
+	}
+	return ret, nil
+}
+
+// NewExporter creates the buffered exporter of the config
+func NewExporter(cfg ExporterConfig) (*BufferedExporter, error) {
+	switch cfg.Type {
+	case FluentdExporterType:
+		return NewFluentdExporter(cfg.Address, cfg.TagPrefix, cfg.QueueLength, cfg.BatchSize, cfg.ExportInterval), nil
+	case ElasticExporterType:
+		return NewElasticExporter(cfg.URL, cfg.IndexPrefix, cfg.QueueLength, cfg.BatchSize, cfg.ExportInterval)
+	case SyslogExporterType:
+		return NewSyslogExporter(cfg.Network, cfg.Address, cfg.AppName, cfg.QueueLength, cfg.BatchSize, cfg.ExportInterval)
+	default:
+		return nil, fmt.Errorf("unsupported type %s of exporter %s", cfg.Type, cfg.Name)
+	}
+}
+
+// NewRouter returns the CategoryRouter of the config given the exporters
+// by name. All exporters referenced by the config must be given.
+func (cfg *RoutingConfig) NewRouter(exportersByName map[string]Exporter) (*CategoryRouter, error) {
+	getExporters := func(names []string) ([]Exporter, error) {
+		ret := make([]Exporter, 0, len(names))
+		for _, name := range names {
+			exporter, ok := exportersByName[name]
+			if !ok {
+				return nil, fmt.Errorf("exporter %s is not configured", name)
+			}
+			ret = append(ret, exporter)
+		}
+		return ret, nil
+	}
+
+	routes := make(map[string][]Exporter, len(cfg.CategoryExporters))
+	for category, names := range cfg.CategoryExporters {
+		exporters, err := getExporters(names)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exporters of log category %s: %s", category, err)
+		}
+		routes[category] = exporters
+	}
+	defaults, err := getExporters(cfg.DefaultExporters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", defaultExportersConfigKey, err)
+	}
+	return NewCategoryRouter(routes, defaults), nil
+}
+
+func getExporterConfig(name string, cfg *config.ConfigMap) (ExporterConfig, error) {
+	ret := ExporterConfig{
+		Name:        name,
+		QueueLength: DefaultQueueLength,
+		BatchSize:   DefaultBatchSize,
+	}
+	strs := map[string]*string{
+		typeConfigKey:        &ret.Type,
+		addressConfigKey:     &ret.Address,
+		urlConfigKey:         &ret.URL,
+		networkConfigKey:     &ret.Network,
+		tagPrefixConfigKey:   &ret.TagPrefix,
+		indexPrefixConfigKey: &ret.IndexPrefix,
+		appNameConfigKey:     &ret.AppName,
+	}
+	for key, dst := range strs {
+		if _, found := cfg.RawMap[key]; !found {
+			continue
+		}
+		val, err := cfg.GetStringParam(key)
+		if err != nil {
+			return ret, fmt.Errorf("invalid %s of exporter %s: %s", key, name, err)
+		}
+		*dst = val
+	}
+	exportIntervalSecs := int(DefaultExportInterval / time.Second)
+	ints := map[string]*int{
+		queueLengthConfigKey:    &ret.QueueLength,
+		batchSizeConfigKey:      &ret.BatchSize,
+		exportIntervalConfigKey: &exportIntervalSecs,
+	}
+	for key, dst := range ints {
+		if _, found := cfg.RawMap[key]; !found {
+			continue
+		}
+		val, err := cfg.GetIntParam(key)
+		if err != nil {
+			return ret, fmt.Errorf("invalid %s of exporter %s: %s", key, name, err)
+		}
+		if val <= 0 {
+			return ret, fmt.Errorf("%s of exporter %s must be positive", key, name)
+		}
+		*dst = val
+	}
+	ret.ExportInterval = time.Duration(exportIntervalSecs) * time.Second
+
+	switch ret.Type {
+	case FluentdExporterType, SyslogExporterType:
+		if len(ret.Address) == 0 {
+			return ret, fmt.Errorf("%s exporter %s requires an %s", ret.Type, name, addressConfigKey)
+		}
+	case ElasticExporterType:
+		if len(ret.URL) == 0 {
+			return ret, fmt.Errorf("%s exporter %s requires a %s", ret.Type, name, urlConfigKey)
+		}
+	default:
+		return ret, fmt.Errorf("unsupported type '%s' of exporter %s, must be one of %s, %s, %s",
+			ret.Type, name, FluentdExporterType, ElasticExporterType, SyslogExporterType)
+	}
+	return ret, nil
+}
+
+func getMap(cfg *config.ConfigMap, key string) (map[interface{}]interface{}, error) {
+	raw, found := cfg.RawMap[key]
+	if !found || raw == nil {
+		return map[interface{}]interface{}{}, nil
+	}
+	ret, ok := raw.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s config must be a map", key)
+	}
+	return ret, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRoutingConfig(t *testing.T) {
+	cfg, err := exporters.GetRoutingConfig(config.NewConfigMap(map[interface{}]interface{}{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &exporters.RoutingConfig{
+		CategoryExporters: map[string][]string{},
+		DefaultExporters:  []string{"scribe"},
+	}, cfg)
+
+	cfg, err = exporters.GetRoutingConfig(config.NewConfigMap(map[interface{}]interface{}{
+		"exporters": map[interface{}]interface{}{
+			"fluentd": map[interface{}]interface{}{
+				"type":    "fluentd",
+				"address": "fluentd:24224",
+			},
+			"es": map[interface{}]interface{}{
+				"type":                 "elasticsearch",
+				"url":                  "http://elasticsearch:9200",
+				"index_prefix":         "logs",
+				"queue_length":         100,
+				"batch_size":           10,
+				"export_interval_secs": 5,
+			},
+		},
+		"category_exporters": map[interface{}]interface{}{
+			"test": []interface{}{"scribe", "es"},
+		},
+		"default_exporters": []interface{}{"fluentd"},
+	}))
+	assert.NoError(t, err)
+	expected := &exporters.RoutingConfig{
+		Exporters: []exporters.ExporterConfig{
+			{
+				Name:           "es",
+				Type:           exporters.ElasticExporterType,
+				URL:            "http://elasticsearch:9200",
+				IndexPrefix:    "logs",
+				QueueLength:    100,
+				BatchSize:      10,
+				ExportInterval: 5 * time.Second,
+			},
+			{
+				Name:           "fluentd",
+				Type:           exporters.FluentdExporterType,
+				Address:        "fluentd:24224",
+				QueueLength:    exporters.DefaultQueueLength,
+				BatchSize:      exporters.DefaultBatchSize,
+				ExportInterval: exporters.DefaultExportInterval,
+			},
+		},
+		CategoryExporters: map[string][]string{"test": {"scribe", "es"}},
+		DefaultExporters:  []string{"fluentd"},
+	}
+	assert.Equal(t, expected, cfg)
+
+	invalidExporters := []map[interface{}]interface{}{
+		{"type": "kafka", "address": "kafka:9092"},
+		{"type": "syslog"},
+		{"type": "elasticsearch", "address": "elasticsearch:9200"},
+		{"type": "fluentd", "address": "fluentd:24224", "queue_length": 0},
+	}
+	for _, invalid := range invalidExporters {
+		_, err = exporters.GetRoutingConfig(config.NewConfigMap(map[interface{}]interface{}{
+			"exporters": map[interface{}]interface{}{"invalid": invalid},
+		}))
+		assert.Error(t, err)
+	}
+	_, err = exporters.GetRoutingConfig(config.NewConfigMap(map[interface{}]interface{}{
+		"exporters": map[interface{}]interface{}{
+			"scribe": map[interface{}]interface{}{"type": "fluentd", "address": "fluentd:24224"},
+		},
+	}))
+	assert.EqualError(t, err, "invalid exporter name scribe")
+}
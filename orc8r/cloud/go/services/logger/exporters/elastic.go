@@ -0,0 +1,99 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	DefaultElasticIndexPrefix = "magma"
+	elasticIndexDateFormat    = "2006.01.02"
+	elasticRequestTimeout     = 30 * time.Second
+)
+
+// ElasticWriter indexes log records into daily <indexPrefix>-YYYY.MM.DD
+// Elasticsearch indices with the bulk API. Documents carry the fields the
+// log query API searches on: "@timestamp", "message" and "network_id". The
+// log ID is the document ID, so records of retried requests aren't
+// duplicated.
+type ElasticWriter struct {
+	client      *elastic.Client
+	indexPrefix string
+}
+
+func NewElasticWriter(url string, indexPrefix string) (*ElasticWriter, error) {
+	client, err := elastic.NewSimpleClient(elastic.SetURL(url))
+	if err != nil {
+		return nil, err
+	}
+	if len(indexPrefix) == 0 {
+		indexPrefix = DefaultElasticIndexPrefix
+	}
+	return &ElasticWriter{client: client, indexPrefix: indexPrefix}, nil
+}
+
+// NewElasticExporter returns a buffered exporter to the Elasticsearch at url
+func NewElasticExporter(
+	url string,
+	indexPrefix string,
+	queueLen int,
+	batchSize int,
+	exportInterval time.Duration,
+) (*BufferedExporter, error) {
+	writer, err := NewElasticWriter(url, indexPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return NewBufferedExporter("elasticsearch", writer, queueLen, batchSize, exportInterval), nil
+}
+
+func (w *ElasticWriter) Write(records []*LogRecord) error {
+	bulk := w.client.Bulk()
+	for _, record := range records {
+		bulk.Add(elastic.NewBulkIndexRequest().Index(w.getIndex(record)).Id(record.ID).Doc(getElasticDoc(record)))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), elasticRequestTimeout)
+	defer cancel()
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("elasticsearch bulk request failed: %v", err)
+	}
+	// Partially failed requests are not retried, re-indexing the succeeded
+	// documents would duplicate them
+	if resp.Errors {
+		failed := resp.Failed()
+		reasons := make([]string, 0, len(failed))
+		for _, item := range failed {
+			if item.Error != nil {
+				reasons = append(reasons, item.Error.Reason)
+			}
+		}
+		return &PartialWriteError{
+			Err: fmt.Errorf("failed to index %d log records: %s", len(failed), strings.Join(reasons, "; ")),
+		}
+	}
+	return nil
+}
+
+func (w *ElasticWriter) getIndex(record *LogRecord) string {
+	return fmt.Sprintf("%s-%s", w.indexPrefix, record.Time.Format(elasticIndexDateFormat))
+}
+
+func getElasticDoc(record *LogRecord) map[string]interface{} {
+	doc := record.Fields()
+	doc["@timestamp"] = record.Time.Format(time.RFC3339)
+	doc["message"] = record.Message
+	return doc
+}
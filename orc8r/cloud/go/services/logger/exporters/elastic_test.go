@@ -0,0 +1,74 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElasticWriter(t *testing.T) {
+	var requests []map[string]interface{}
+	response := `{"took": 1, "errors": false, "items": [{"index": {"status": 201}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			line := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			requests = append(requests, line)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	records, err := exporters.NewLogRecords([]*protos.LogEntry{
+		{
+			Category:  "test",
+			NormalMap: map[string]string{"status": "ACTIVE"},
+			IntMap:    map[string]int64{"port": 443},
+			TagSet:    []string{"tag"},
+			Time:      12345,
+		},
+	})
+	require.NoError(t, err)
+	writer, err := exporters.NewElasticWriter(server.URL, "")
+	require.NoError(t, err)
+	assert.NoError(t, writer.Write(records))
+
+	expected := []map[string]interface{}{
+		{"index": map[string]interface{}{"_index": "magma-1970.01.01", "_id": records[0].ID}},
+		{
+			"@timestamp": "1970-01-01T03:25:45Z",
+			"message":    `{"int":{"port":443},"normal":{"status":"ACTIVE"},"tagset":["tag"]}`,
+			"category":   "test",
+			"status":     "ACTIVE",
+			"port":       float64(443),
+			"tags":       []interface{}{"tag"},
+		},
+	}
+	assert.Equal(t, expected, requests)
+	assert.NotEmpty(t, records[0].ID)
+
+	// partially failed requests aren't retried
+	response = `{"took": 1, "errors": true, "items": [{"index": {"status": 400, "error": {"reason": "mapping error"}}}]}`
+	err = writer.Write(records)
+	assert.IsType(t, &exporters.PartialWriteError{}, err)
+	assert.Contains(t, err.Error(), "mapping error")
+}
@@ -0,0 +1,130 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+const (
+	DefaultFluentdTagPrefix = "magma"
+	fluentdDialTimeout      = 5 * time.Second
+	fluentdWriteTimeout     = 10 * time.Second
+)
+
+// FluentdWriter writes log records to a Fluentd (or Fluent Bit) in_forward
+// input using the Forward protocol
+// (https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1).
+// Records are sent in Forward mode, one message per category tagged
+// <tagPrefix>.<category>. The TCP connection is re-established after write
+// errors.
+type FluentdWriter struct {
+	address   string
+	tagPrefix string
+
+	connMutex sync.Mutex
+	conn      net.Conn
+}
+
+func NewFluentdWriter(address string, tagPrefix string) *FluentdWriter {
+	if len(tagPrefix) == 0 {
+		tagPrefix = DefaultFluentdTagPrefix
+	}
+	return &FluentdWriter{address: address, tagPrefix: tagPrefix}
+}
+
+// NewFluentdExporter returns a buffered exporter to the Fluentd at address
+func NewFluentdExporter(
+	address string,
+	tagPrefix string,
+	queueLen int,
+	batchSize int,
+	exportInterval time.Duration,
+) *BufferedExporter {
+	return NewBufferedExporter("fluentd", NewFluentdWriter(address, tagPrefix), queueLen, batchSize, exportInterval)
+}
+
+func (w *FluentdWriter) Write(records []*LogRecord) error {
+	messages, err := w.encode(records)
+	if err != nil {
+		return err
+	}
+
+	w.connMutex.Lock()
+	defer w.connMutex.Unlock()
+	if w.conn == nil {
+		conn, err := net.DialTimeout("tcp", w.address, fluentdDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to connect to fluentd at %s: %v", w.address, err)
+		}
+		w.conn = conn
+	}
+	for i, msg := range messages {
+		err = w.conn.SetWriteDeadline(time.Now().Add(fluentdWriteTimeout))
+		if err == nil {
+			_, err = w.conn.Write(msg)
+		}
+		if err != nil {
+			w.conn.Close()
+			w.conn = nil
+			err = fmt.Errorf("failed to write to fluentd at %s: %v", w.address, err)
+			if i > 0 {
+				return &PartialWriteError{Err: err}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *FluentdWriter) Close() error {
+	w.connMutex.Lock()
+	defer w.connMutex.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// encode returns a Forward mode message, [tag, [[time, record], ...]], for
+// each category of the records, in the order the categories first appear.
+// Record fields are encoded with sorted keys so messages are deterministic.
+func (w *FluentdWriter) encode(records []*LogRecord) ([][]byte, error) {
+	var tags []string
+	entriesByTag := map[string][]interface{}{}
+	for _, record := range records {
+		tag := w.tagPrefix
+		if len(record.Category) != 0 {
+			tag = fmt.Sprintf("%s.%s", w.tagPrefix, record.Category)
+		}
+		if _, ok := entriesByTag[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		entriesByTag[tag] = append(entriesByTag[tag], []interface{}{record.Time.Unix(), record.Fields()})
+	}
+
+	messages := make([][]byte, 0, len(tags))
+	for _, tag := range tags {
+		buf := &bytes.Buffer{}
+		err := msgpack.NewEncoder(buf).SortMapKeys(true).Encode([]interface{}{tag, entriesByTag[tag]})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, buf.Bytes())
+	}
+	return messages, nil
+}
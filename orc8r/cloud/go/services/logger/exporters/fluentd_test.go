@@ -0,0 +1,74 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+func TestFluentdWriter(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	received := make(chan []byte)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := &bytes.Buffer{}
+		io.Copy(buf, conn)
+		received <- buf.Bytes()
+	}()
+
+	records, err := exporters.NewLogRecords([]*protos.LogEntry{
+		{
+			Category:  "test",
+			NormalMap: map[string]string{"status": "ACTIVE"},
+			IntMap:    map[string]int64{"port": 443},
+			Time:      12345,
+		},
+	})
+	require.NoError(t, err)
+	writer := exporters.NewFluentdWriter(listener.Addr().String(), "")
+	assert.NoError(t, writer.Write(records))
+	assert.NoError(t, writer.Close())
+
+	select {
+	case actual := <-received:
+		var message []interface{}
+		require.NoError(t, msgpack.Unmarshal(actual, &message))
+		expected := []interface{}{
+			"magma.test",
+			[]interface{}{
+				[]interface{}{uint64(12345), map[interface{}]interface{}{"category": "test", "port": uint64(443), "status": "ACTIVE"}},
+			},
+		}
+		assert.Equal(t, expected, message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for fluentd message")
+	}
+
+	// unreachable fluentd
+	writer = exporters.NewFluentdWriter(listener.Addr().String(), "")
+	listener.Close()
+	assert.Error(t, writer.Write(records))
+}
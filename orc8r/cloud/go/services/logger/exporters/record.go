@@ -0,0 +1,109 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+// LogRecord is the backend agnostic form of a LogEntry exported by the
+// Fluentd, Elasticsearch and syslog exporters. The gateway which logged the
+// entry is resolved to its network and gateway IDs.
+type LogRecord struct {
+	// ID uniquely identifies the record. It's generated when the record is
+	// created, so retried writes of the record have the same ID.
+	ID        string
+	Category  string
+	Time      time.Time
+	HwID      string
+	NetworkID string
+	GatewayID string
+	// Message is the JSON encoded entry, in the same format as exported to
+	// scribe
+	Message string
+
+	entry *protos.LogEntry
+}
+
+// NewLogRecords converts log entries to LogRecords. Entries must have their
+// time set.
+func NewLogRecords(entries []*protos.LogEntry) ([]*LogRecord, error) {
+	records := make([]*LogRecord, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Time == 0 {
+			return nil, fmt.Errorf("LogEntry %v doesn't have time field set", entry)
+		}
+		record := &LogRecord{
+			ID:       uuid.New().String(),
+			Category: entry.Category,
+			Time:     time.Unix(entry.Time, 0).UTC(),
+			HwID:     entry.HwId,
+			entry:    entry,
+		}
+		if len(entry.HwId) != 0 {
+			record.NetworkID, record.GatewayID = getNetworkAndGatewayIDs(entry.HwId)
+		}
+		msg := ScribeLogMessage{
+			Normal:  entry.NormalMap,
+			Int:     entry.IntMap,
+			TagSet:  entry.TagSet,
+			NormVec: entry.Normvector,
+		}
+		msgJson, err := json.Marshal(msg)
+		if err != nil {
+			glog.Errorf("Error formatting LogEntry %v: %v", entry, err)
+			continue
+		}
+		record.Message = string(msgJson)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Fields returns the record flattened into a single map: the entry's normal
+// and int values and its category, tags, normvector and gateway identifiers.
+// The latter take precedence over normal and int values with the same name.
+func (r *LogRecord) Fields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(r.entry.NormalMap)+len(r.entry.IntMap)+6)
+	for k, v := range r.entry.NormalMap {
+		fields[k] = v
+	}
+	for k, v := range r.entry.IntMap {
+		fields[k] = v
+	}
+	fields["category"] = r.Category
+	if len(r.entry.TagSet) != 0 {
+		fields["tags"] = r.entry.TagSet
+	}
+	if len(r.entry.Normvector) != 0 {
+		fields["normvector"] = r.entry.Normvector
+	}
+	if len(r.HwID) != 0 {
+		fields["hw_id"] = r.HwID
+		fields["network_id"] = r.NetworkID
+		fields["gateway_id"] = r.GatewayID
+	}
+	return fields
+}
+
+func getNetworkAndGatewayIDs(hwID string) (string, string) {
+	networkID, gatewayID, err := configurator.GetNetworkAndEntityIDForPhysicalID(hwID)
+	if err != nil {
+		glog.Errorf("Error retrieving nwId and gwId for hwId %s: %v\n", hwID, err)
+	}
+	return networkID, gatewayID
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CategoryRouter is an Exporter which submits log entries to the exporters
+// configured for their category. Entries of categories without routes are
+// submitted to the default exporters.
+type CategoryRouter struct {
+	routes   map[string][]Exporter
+	defaults []Exporter
+}
+
+func NewCategoryRouter(routes map[string][]Exporter, defaults []Exporter) *CategoryRouter {
+	if routes == nil {
+		routes = map[string][]Exporter{}
+	}
+	return &CategoryRouter{routes: routes, defaults: defaults}
+}
+
+// Submit submits the entries to each of their exporters with a single call
+// per exporter. All exporters are submitted to even if some fail. An entry
+// is accepted once any of its exporters accepted it. If an entry was
+// rejected by all of its exporters, Submit returns a ResourceExhausted error
+// so the client retries, even though entries accepted by other exporters
+// are then submitted again.
+func (r *CategoryRouter) Submit(logEntries []*protos.LogEntry) error {
+	var exporterOrder []Exporter
+	entriesByExporter := map[Exporter][]int{}
+	routedEntries := 0
+	for i, entry := range logEntries {
+		exporters, ok := r.routes[entry.Category]
+		if !ok {
+			exporters = r.defaults
+		}
+		if len(exporters) != 0 {
+			routedEntries++
+		}
+		for _, exporter := range exporters {
+			if _, seen := entriesByExporter[exporter]; !seen {
+				exporterOrder = append(exporterOrder, exporter)
+			}
+			entriesByExporter[exporter] = append(entriesByExporter[exporter], i)
+		}
+	}
+
+	var firstErr error
+	accepted := make([]bool, len(logEntries))
+	for _, exporter := range exporterOrder {
+		indices := entriesByExporter[exporter]
+		entries := make([]*protos.LogEntry, 0, len(indices))
+		for _, i := range indices {
+			entries = append(entries, logEntries[i])
+		}
+		err := exporter.Submit(entries)
+		if err != nil {
+			glog.Errorf("Exporter rejected %d log entries: %v", len(entries), err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, i := range indices {
+			accepted[i] = true
+		}
+	}
+
+	acceptedEntries := 0
+	for _, ok := range accepted {
+		if ok {
+			acceptedEntries++
+		}
+	}
+	if acceptedEntries < routedEntries {
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"%d of %d log entries were rejected by all of their exporters: %v",
+			routedEntries-acceptedEntries, routedEntries, firstErr,
+		)
+	}
+	return nil
+}
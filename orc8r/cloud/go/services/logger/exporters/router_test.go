@@ -0,0 +1,85 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"errors"
+	"testing"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+	"magma/orc8r/cloud/go/services/logger/exporters/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCategoryRouter(t *testing.T) {
+	scribe := mocks.NewExposedMockExporter()
+	es := mocks.NewExposedMockExporter()
+	fluentd := mocks.NewExposedMockExporter()
+	cfg := &exporters.RoutingConfig{
+		CategoryExporters: map[string][]string{
+			"a": {"scribe", "es"},
+			"b": {"es"},
+			"c": {},
+		},
+		DefaultExporters: []string{"fluentd"},
+	}
+	router, err := cfg.NewRouter(map[string]exporters.Exporter{"scribe": scribe, "es": es, "fluentd": fluentd})
+	require.NoError(t, err)
+
+	a := &protos.LogEntry{Category: "a", Time: 1}
+	b := &protos.LogEntry{Category: "b", Time: 2}
+	c := &protos.LogEntry{Category: "c", Time: 3}
+	d := &protos.LogEntry{Category: "d", Time: 4}
+	scribe.On("Submit", []*protos.LogEntry{a}).Return(nil).Once()
+	es.On("Submit", []*protos.LogEntry{a, b}).Return(nil).Once()
+	fluentd.On("Submit", []*protos.LogEntry{d}).Return(nil).Once()
+	// entries of categories without exporters are dropped
+	err = router.Submit([]*protos.LogEntry{a, b, c, d})
+	assert.NoError(t, err)
+	scribe.AssertExpectations(t)
+	es.AssertExpectations(t)
+	fluentd.AssertExpectations(t)
+
+	// entries rejected by one exporter are accepted by another
+	scribe.On("Submit", []*protos.LogEntry{a}).Return(nil).Once()
+	es.On("Submit", []*protos.LogEntry{a}).Return(errors.New("es queue full")).Once()
+	err = router.Submit([]*protos.LogEntry{a, c})
+	assert.NoError(t, err)
+	scribe.AssertExpectations(t)
+	es.AssertExpectations(t)
+
+	// the request fails if any entry was rejected by all its exporters, even
+	// if others were accepted
+	scribe.On("Submit", []*protos.LogEntry{a}).Return(nil).Once()
+	es.On("Submit", []*protos.LogEntry{a, b}).Return(errors.New("es queue full")).Once()
+	fluentd.On("Submit", []*protos.LogEntry{d}).Return(nil).Once()
+	err = router.Submit([]*protos.LogEntry{a, b, d})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "1 of 3 log entries were rejected by all of their exporters: es queue full")
+	scribe.AssertExpectations(t)
+	es.AssertExpectations(t)
+	fluentd.AssertExpectations(t)
+
+	scribe.On("Submit", []*protos.LogEntry{a}).Return(errors.New("scribe queue full")).Once()
+	es.On("Submit", []*protos.LogEntry{a}).Return(errors.New("es queue full")).Once()
+	err = router.Submit([]*protos.LogEntry{a, c})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "scribe queue full")
+	scribe.AssertExpectations(t)
+	es.AssertExpectations(t)
+
+	// all referenced exporters must exist
+	_, err = cfg.NewRouter(map[string]exporters.Exporter{"es": es, "fluentd": fluentd})
+	assert.EqualError(t, err, "invalid exporters of log category a: exporter scribe is not configured")
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSyslogAppName = "magma"
+	// syslog facility local0 and severity informational
+	syslogPriority = 16*8 + 6
+	// IANA private enterprise number used in structured data IDs
+	syslogEnterpriseID = 32473
+	syslogNilValue     = "-"
+	syslogDialTimeout  = 5 * time.Second
+	syslogWriteTimeout = 10 * time.Second
+)
+
+// SyslogWriter writes log records as RFC 5424 syslog messages over UDP or
+// TCP. TCP messages are framed with octet counting (RFC 6587). The
+// category and gateway identifiers of a record are sent as structured data,
+// the JSON encoded entry as the message.
+type SyslogWriter struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+
+	connMutex sync.Mutex
+	conn      net.Conn
+}
+
+func NewSyslogWriter(network string, address string, appName string) (*SyslogWriter, error) {
+	switch network {
+	case "":
+		network = "udp"
+	case "udp", "tcp":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %s, must be one of udp, tcp", network)
+	}
+	if len(appName) == 0 {
+		appName = DefaultSyslogAppName
+	}
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = syslogNilValue
+	}
+	return &SyslogWriter{network: network, address: address, appName: appName, hostname: hostname}, nil
+}
+
+// NewSyslogExporter returns a buffered exporter to the syslog server at address
+func NewSyslogExporter(
+	network string,
+	address string,
+	appName string,
+	queueLen int,
+	batchSize int,
+	exportInterval time.Duration,
+) (*BufferedExporter, error) {
+	writer, err := NewSyslogWriter(network, address, appName)
+	if err != nil {
+		return nil, err
+	}
+	return NewBufferedExporter("syslog", writer, queueLen, batchSize, exportInterval), nil
+}
+
+func (w *SyslogWriter) Write(records []*LogRecord) error {
+	w.connMutex.Lock()
+	defer w.connMutex.Unlock()
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.address, syslogDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog at %s: %v", w.address, err)
+		}
+		w.conn = conn
+	}
+	for i, record := range records {
+		msg := w.format(record)
+		if w.network == "tcp" {
+			msg = fmt.Sprintf("%d %s", len(msg), msg)
+		}
+		err := w.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+		if err == nil {
+			_, err = w.conn.Write([]byte(msg))
+		}
+		if err != nil {
+			w.conn.Close()
+			w.conn = nil
+			err = fmt.Errorf("failed to write to syslog at %s: %v", w.address, err)
+			if i > 0 {
+				return &PartialWriteError{Err: err}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *SyslogWriter) Close() error {
+	w.connMutex.Lock()
+	defer w.connMutex.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// format returns the RFC 5424 message for the record:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME - MSGID [magma@32473 ...] MSG
+func (w *SyslogWriter) format(record *LogRecord) string {
+	msgID := syslogNilValue
+	if len(record.Category) != 0 {
+		msgID = toSyslogName(record.Category, 32)
+	}
+	return fmt.Sprintf(
+		"<%d>1 %s %s %s - %s %s %s",
+		syslogPriority,
+		record.Time.Format(time.RFC3339),
+		toSyslogName(w.hostname, 255),
+		toSyslogName(w.appName, 48),
+		msgID,
+		getSyslogStructuredData(record),
+		record.Message,
+	)
+}
+
+func getSyslogStructuredData(record *LogRecord) string {
+	params := []string{fmt.Sprintf("magma@%d", syslogEnterpriseID)}
+	addParam := func(name, value string) {
+		if len(value) != 0 {
+			params = append(params, fmt.Sprintf("%s=\"%s\"", name, escapeSyslogParamValue(value)))
+		}
+	}
+	addParam("category", record.Category)
+	addParam("network_id", record.NetworkID)
+	addParam("gateway_id", record.GatewayID)
+	addParam("hw_id", record.HwID)
+	return fmt.Sprintf("[%s]", strings.Join(params, " "))
+}
+
+// toSyslogName restricts header fields to printable US-ASCII without spaces
+// of at most maxLen characters, as required by RFC 5424
+func toSyslogName(s string, maxLen int) string {
+	name := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(name) > maxLen {
+		name = name[:maxLen]
+	}
+	return name
+}
+
+var syslogParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+func escapeSyslogParamValue(s string) string {
+	return syslogParamEscaper.Replace(s)
+}
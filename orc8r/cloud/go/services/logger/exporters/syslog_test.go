@@ -0,0 +1,53 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/logger/exporters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	records, err := exporters.NewLogRecords([]*protos.LogEntry{
+		{
+			Category:  "test category",
+			NormalMap: map[string]string{"status": "ACTIVE"},
+			Time:      12345,
+		},
+	})
+	require.NoError(t, err)
+	writer, err := exporters.NewSyslogWriter("udp", conn.LocalAddr().String(), "")
+	require.NoError(t, err)
+	defer writer.Close()
+	assert.NoError(t, writer.Write(records))
+
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	expected := regexp.MustCompile(
+		`^<134>1 1970-01-01T03:25:45Z \S+ magma - test_category ` +
+			`\[magma@32473 category="test category"\] \{"normal":\{"status":"ACTIVE"\}\}$`)
+	assert.Regexp(t, expected, string(buf[:n]))
+
+	_, err = exporters.NewSyslogWriter("unix", "/dev/log", "")
+	assert.Error(t, err)
+}
@@ -54,20 +54,39 @@ func main() {
 		nghttpxLogger.Run(NGHTTPX_LOG_FILE_PATH)
 	}
 
-	scribeExportURL := srv.Config.GetRequiredStringParam("scribe_export_url")
-	scribeAppID := srv.Config.GetRequiredStringParam("scribe_app_id")
-	scribeAppSecret := srv.Config.GetRequiredStringParam("scribe_app_secret")
+	routingConfig, err := exporters.GetRoutingConfig(srv.Config)
+	if err != nil {
+		glog.Fatalf("Error reading log exporters config: %v", err)
+	}
 
 	// Initialize exporters
-	scribeExporter := exporters.NewScribeExporter(
-		scribeExportURL,
-		scribeAppID,
-		scribeAppSecret,
-		SCRIBE_EXPORTER_QUEUE_LENGTH,
-		SCRIBE_EXPORTER_EXPORT_INTERVAL,
-	)
+	exportersByName := map[string]exporters.Exporter{}
+	var scribeExporter *exporters.ScribeExporter
+	if scribeExportURL, _ := srv.Config.GetStringParam("scribe_export_url"); len(scribeExportURL) != 0 {
+		scribeExporter = exporters.NewScribeExporter(
+			scribeExportURL,
+			srv.Config.GetRequiredStringParam("scribe_app_id"),
+			srv.Config.GetRequiredStringParam("scribe_app_secret"),
+			SCRIBE_EXPORTER_QUEUE_LENGTH,
+			SCRIBE_EXPORTER_EXPORT_INTERVAL,
+		)
+		exportersByName[exporters.ScribeExporterName] = scribeExporter
+	}
+	var bufferedExporters []*exporters.BufferedExporter
+	for _, exporterConfig := range routingConfig.Exporters {
+		exporter, err := exporters.NewExporter(exporterConfig)
+		if err != nil {
+			glog.Fatalf("Error creating log exporter %s: %v", exporterConfig.Name, err)
+		}
+		exportersByName[exporterConfig.Name] = exporter
+		bufferedExporters = append(bufferedExporters, exporter)
+	}
+	router, err := routingConfig.NewRouter(exportersByName)
+	if err != nil {
+		glog.Fatalf("Error configuring log exporters: %v", err)
+	}
 	logExporters := make(map[protos.LoggerDestination]exporters.Exporter)
-	logExporters[protos.LoggerDestination_SCRIBE] = scribeExporter
+	logExporters[protos.LoggerDestination_SCRIBE] = router
 
 	// Add servicers to the service
 	loggingServ, err := servicers.NewLoggingService(logExporters)
@@ -75,7 +94,12 @@ func main() {
 		glog.Fatalf("LoggingService Initialization Error: %s", err)
 	}
 	// start exporting asynchronously
-	scribeExporter.Start()
+	if scribeExporter != nil {
+		scribeExporter.Start()
+	}
+	for _, exporter := range bufferedExporters {
+		exporter.Start()
+	}
 
 	protos.RegisterLoggingServiceServer(srv.GrpcServer, loggingServ)
 	srv.GrpcServer.RegisterService(protos.GetLegacyLoggerDesc(), loggingServ)
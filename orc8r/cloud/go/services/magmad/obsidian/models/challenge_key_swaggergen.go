@@ -25,7 +25,7 @@ type ChallengeKey struct {
 
 	// key type
 	// Required: true
-	// Enum: [ECHO SOFTWARE_ECDSA_SHA256]
+	// Enum: [ECHO SOFTWARE_ECDSA_SHA256 HARDWARE_TPM2_SHA256]
 	KeyType string `json:"key_type"`
 }
 
@@ -62,7 +62,7 @@ var challengeKeyTypeKeyTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["ECHO","SOFTWARE_ECDSA_SHA256"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["ECHO","SOFTWARE_ECDSA_SHA256","HARDWARE_TPM2_SHA256"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -77,6 +77,9 @@ const (
 
 	// ChallengeKeyKeyTypeSOFTWAREECDSASHA256 captures enum value "SOFTWARE_ECDSA_SHA256"
 	ChallengeKeyKeyTypeSOFTWAREECDSASHA256 string = "SOFTWARE_ECDSA_SHA256"
+
+	// ChallengeKeyKeyTypeHARDWARETPM2SHA256 captures enum value "HARDWARE_TPM2_SHA256"
+	ChallengeKeyKeyTypeHARDWARETPM2SHA256 string = "HARDWARE_TPM2_SHA256"
 )
 
 // prop value enum
@@ -12,9 +12,15 @@ package confignames
 const (
 	Profile                 = "profile"
 	PrometheusPushAddresses = "prometheusPushAddresses"
+	PrometheusPushShardIDs  = "prometheusPushShardIDs"
 	PrometheusQueryAddress  = "prometheusQueryAddress"
 
 	PrometheusConfigServiceURL   = "prometheusConfigServiceURL"
 	AlertmanagerConfigServiceURL = "alertmanagerConfigServiceURL"
 	AlertmanagerApiURL           = "alertmanagerApiURL"
+
+	RemoteWrite     = "remoteWrite"
+	OpenMetricsFile = "openMetricsFile"
+
+	CardinalityLimits = "cardinalityLimits"
 )
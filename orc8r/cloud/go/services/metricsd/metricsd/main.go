@@ -35,6 +35,18 @@ func main() {
 		log.Fatalf("Error creating service: %s", err)
 	}
 	controllerServer := servicers.NewMetricsControllerServer()
+	limits, err := servicers.GetCardinalityLimits(srv.Config)
+	if err != nil {
+		log.Fatalf("Error loading cardinality limits: %s", err)
+	}
+	if limits != nil {
+		limiter, err := servicers.NewCardinalityLimiter(*limits)
+		if err != nil {
+			log.Fatalf("Error creating cardinality limiter: %s", err)
+		}
+		controllerServer.SetCardinalityLimiter(limiter)
+		go limiter.RunSeriesExpiry()
+	}
 	protos.RegisterMetricsControllerServer(srv.GrpcServer, controllerServer)
 	srv.GrpcServer.RegisterService(protos.GetLegacyMetricsdDesc(), controllerServer)
 
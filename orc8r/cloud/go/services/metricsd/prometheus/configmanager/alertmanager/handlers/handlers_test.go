@@ -51,6 +51,27 @@ var (
 		}},
 	}
 
+	sampleIntegrationsReceiver = receivers.Receiver{
+		Name: "testIntegrationsReceiver",
+		PagerDutyConfigs: []*receivers.PagerDutyConfig{{
+			NotifierConfig: config.NotifierConfig{VSendResolved: true},
+			RoutingKey:     "routing_key",
+			Severity:       "critical",
+		}},
+		OpsGenieConfigs: []*receivers.OpsGenieConfig{{
+			APIKey: "opsgenie_key",
+			Teams:  "oncall",
+		}},
+		PushoverConfigs: []*receivers.PushoverConfig{{
+			UserKey: "pushover_user",
+			Token:   "pushover_token",
+		}},
+		WechatConfigs: []*receivers.WechatConfig{{
+			APISecret: "wechat_secret",
+			CorpID:    "wechat_corp",
+		}},
+	}
+
 	fiveSeconds, _ = model.ParseDuration("5s")
 
 	sampleRoute = config.Route{
@@ -311,6 +332,12 @@ func TestDecodeReceiverPostRequest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, sampleReceiver, conf)
 
+	// Decode PagerDuty, OpsGenie, Pushover and WeChat receiver
+	c, _ = buildContext(sampleIntegrationsReceiver, http.MethodPost, "/", v1receiverPath, testNID)
+	conf, err = decodeReceiverPostRequest(c)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleIntegrationsReceiver, conf)
+
 	// error decoding route
 	c, _ = buildContext(struct {
 		Name bool `json:"name"`
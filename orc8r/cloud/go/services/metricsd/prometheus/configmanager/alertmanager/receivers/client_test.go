@@ -91,6 +91,18 @@ func TestClient_CreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	fsClient.AssertCalled(t, "WriteFile", "test/alertmanager.yml", mock.Anything, mock.Anything)
 
+	// Create PagerDuty, OpsGenie, Pushover and WeChat receivers
+	for _, rec := range []Receiver{samplePagerDutyReceiver, sampleOpsGenieReceiver, samplePushoverReceiver, sampleWechatReceiver} {
+		err = client.CreateReceiver(testNID, rec)
+		assert.NoError(t, err)
+	}
+	fsClient.AssertNumberOfCalls(t, "WriteFile", 7)
+
+	// OpsGenie receivers must set their own API key
+	err = client.CreateReceiver(testNID, Receiver{Name: "opsgenie", OpsGenieConfigs: []*OpsGenieConfig{{Teams: "oncall"}}})
+	assert.Error(t, err)
+	fsClient.AssertNumberOfCalls(t, "WriteFile", 7)
+
 	// create duplicate receiver
 	err = client.CreateReceiver(testNID, Receiver{Name: "receiver"})
 	assert.Regexp(t, regexp.MustCompile("notification config name \".*receiver\" is not unique"), err.Error())
@@ -77,6 +77,12 @@ func (c *Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	for _, rec := range c.Receivers {
+		err = rec.validateCredentials()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -84,9 +90,31 @@ func (c *Config) Validate() error {
 type Receiver struct {
 	Name string `yaml:"name" json:"name"`
 
-	SlackConfigs   []*SlackConfig          `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
-	WebhookConfigs []*config.WebhookConfig `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
-	EmailConfigs   []*EmailConfig          `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
+	SlackConfigs     []*SlackConfig          `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
+	WebhookConfigs   []*config.WebhookConfig `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
+	EmailConfigs     []*EmailConfig          `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
+	PagerDutyConfigs []*PagerDutyConfig      `yaml:"pagerduty_configs,omitempty" json:"pagerduty_configs,omitempty"`
+	OpsGenieConfigs  []*OpsGenieConfig       `yaml:"opsgenie_configs,omitempty" json:"opsgenie_configs,omitempty"`
+	PushoverConfigs  []*PushoverConfig       `yaml:"pushover_configs,omitempty" json:"pushover_configs,omitempty"`
+	WechatConfigs    []*WechatConfig         `yaml:"wechat_configs,omitempty" json:"wechat_configs,omitempty"`
+}
+
+// validateCredentials checks that the receiver's notifiers set their own
+// credentials. Alertmanager falls back to the global OpsGenie and WeChat
+// credentials otherwise, which would let a tenant's alerts be sent with
+// another tenant's account.
+func (r *Receiver) validateCredentials() error {
+	for _, conf := range r.OpsGenieConfigs {
+		if conf.APIKey == "" {
+			return fmt.Errorf("missing api_key in OpsGenie config of receiver %s", r.Name)
+		}
+	}
+	for _, conf := range r.WechatConfigs {
+		if conf.APISecret == "" || conf.CorpID == "" {
+			return fmt.Errorf("missing api_secret or corp_id in WeChat config of receiver %s", r.Name)
+		}
+	}
+	return nil
 }
 
 // Secure replaces the receiver's name with a tenantID prefix
@@ -135,6 +163,81 @@ type SlackConfig struct {
 	Actions     []*config.SlackAction `yaml:"actions,omitempty" json:"actions,omitempty"`
 }
 
+// PagerDutyConfig uses string instead of Secret for the ServiceKey and
+// RoutingKey fields so that they are marshaled as is instead of being
+// obscured, which is how alertmanager handles secrets
+type PagerDutyConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	ServiceKey  string                  `yaml:"service_key,omitempty" json:"service_key,omitempty"`
+	RoutingKey  string                  `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
+	URL         string                  `yaml:"url,omitempty" json:"url,omitempty"`
+	Client      string                  `yaml:"client,omitempty" json:"client,omitempty"`
+	ClientURL   string                  `yaml:"client_url,omitempty" json:"client_url,omitempty"`
+	Description string                  `yaml:"description,omitempty" json:"description,omitempty"`
+	Details     map[string]string       `yaml:"details,omitempty" json:"details,omitempty"`
+	Images      []config.PagerdutyImage `yaml:"images,omitempty" json:"images,omitempty"`
+	Links       []config.PagerdutyLink  `yaml:"links,omitempty" json:"links,omitempty"`
+	Severity    string                  `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Class       string                  `yaml:"class,omitempty" json:"class,omitempty"`
+	Component   string                  `yaml:"component,omitempty" json:"component,omitempty"`
+	Group       string                  `yaml:"group,omitempty" json:"group,omitempty"`
+}
+
+// OpsGenieConfig uses string instead of Secret for the APIKey field so that
+// it is marshaled as is instead of being obscured, which is how alertmanager
+// handles secrets
+type OpsGenieConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	APIKey      string            `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIURL      string            `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	Message     string            `yaml:"message,omitempty" json:"message,omitempty"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Source      string            `yaml:"source,omitempty" json:"source,omitempty"`
+	Details     map[string]string `yaml:"details,omitempty" json:"details,omitempty"`
+	Teams       string            `yaml:"teams,omitempty" json:"teams,omitempty"`
+	Tags        string            `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Note        string            `yaml:"note,omitempty" json:"note,omitempty"`
+	Priority    string            `yaml:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// PushoverConfig uses string instead of Secret for the UserKey and Token
+// fields so that they are marshaled as is instead of being obscured, which is
+// how alertmanager handles secrets. Retry and Expire are durations such as
+// "1m".
+type PushoverConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	UserKey  string `yaml:"user_key,omitempty" json:"user_key,omitempty"`
+	Token    string `yaml:"token,omitempty" json:"token,omitempty"`
+	Title    string `yaml:"title,omitempty" json:"title,omitempty"`
+	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
+	URL      string `yaml:"url,omitempty" json:"url,omitempty"`
+	URLTitle string `yaml:"url_title,omitempty" json:"url_title,omitempty"`
+	Sound    string `yaml:"sound,omitempty" json:"sound,omitempty"`
+	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Retry    string `yaml:"retry,omitempty" json:"retry,omitempty"`
+	Expire   string `yaml:"expire,omitempty" json:"expire,omitempty"`
+	HTML     bool   `yaml:"html,omitempty" json:"html,omitempty"`
+}
+
+// WechatConfig uses string instead of Secret for the APISecret field so that
+// it is marshaled as is instead of being obscured, which is how alertmanager
+// handles secrets
+type WechatConfig struct {
+	config.NotifierConfig `yaml:",inline" json:",inline"`
+
+	APISecret string `yaml:"api_secret,omitempty" json:"api_secret,omitempty"`
+	CorpID    string `yaml:"corp_id,omitempty" json:"corp_id,omitempty"`
+	Message   string `yaml:"message,omitempty" json:"message,omitempty"`
+	APIURL    string `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	ToUser    string `yaml:"to_user,omitempty" json:"to_user,omitempty"`
+	ToParty   string `yaml:"to_party,omitempty" json:"to_party,omitempty"`
+	ToTag     string `yaml:"to_tag,omitempty" json:"to_tag,omitempty"`
+	AgentID   string `yaml:"agent_id,omitempty" json:"agent_id,omitempty"`
+}
+
 // EmailConfig uses string instead of Secret for the AuthPassword and AuthSecret
 // field so that it is marshaled as is instead of being obscured which is how
 // alertmanager handles secrets. Otherwise the secrets would be obscured on write
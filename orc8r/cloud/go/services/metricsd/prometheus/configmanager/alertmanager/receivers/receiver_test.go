@@ -63,6 +63,39 @@ var (
 			Smarthost: "http://mail-server.com",
 		}},
 	}
+	samplePagerDutyReceiver = Receiver{
+		Name: "pagerduty_receiver",
+		PagerDutyConfigs: []*PagerDutyConfig{{
+			RoutingKey: "0123456789abcdef",
+			Severity:   "critical",
+			Links:      []config.PagerdutyLink{{HRef: "http://grafana.com", Text: "dashboard"}},
+		}},
+	}
+	sampleOpsGenieReceiver = Receiver{
+		Name: "opsgenie_receiver",
+		OpsGenieConfigs: []*OpsGenieConfig{{
+			APIKey:   "opsgenie_key",
+			Teams:    "oncall",
+			Priority: "P1",
+		}},
+	}
+	samplePushoverReceiver = Receiver{
+		Name: "pushover_receiver",
+		PushoverConfigs: []*PushoverConfig{{
+			UserKey: "pushover_user",
+			Token:   "pushover_token",
+			Retry:   "1m",
+			Expire:  "1h",
+		}},
+	}
+	sampleWechatReceiver = Receiver{
+		Name: "wechat_receiver",
+		WechatConfigs: []*WechatConfig{{
+			APISecret: "wechat_secret",
+			CorpID:    "wechat_corp",
+			ToUser:    "@all",
+		}},
+	}
 	sampleConfig = Config{
 		Route: &sampleRoute,
 		Receivers: []*Receiver{
@@ -128,6 +161,55 @@ func TestConfig_Validate(t *testing.T) {
 	assert.EqualError(t, err, `missing type in Slack action configuration`)
 }
 
+func TestConfig_ValidateIntegrations(t *testing.T) {
+	defaultGlobalConf := config.DefaultGlobalConfig()
+	makeConfig := func(rec *Receiver) Config {
+		return Config{
+			Route:     &config.Route{Receiver: rec.Name},
+			Receivers: []*Receiver{rec},
+			Global:    &defaultGlobalConf,
+		}
+	}
+	for _, rec := range []*Receiver{&samplePagerDutyReceiver, &sampleOpsGenieReceiver, &samplePushoverReceiver, &sampleWechatReceiver} {
+		conf := makeConfig(rec)
+		assert.NoError(t, conf.Validate(), rec.Name)
+	}
+
+	conf := makeConfig(&Receiver{Name: "pagerduty", PagerDutyConfigs: []*PagerDutyConfig{{Severity: "critical"}}})
+	assert.EqualError(t, conf.Validate(), "missing service or routing key in PagerDuty config")
+	conf = makeConfig(&Receiver{Name: "pagerduty", PagerDutyConfigs: []*PagerDutyConfig{{RoutingKey: "key", URL: "invalidURL"}}})
+	assert.EqualError(t, conf.Validate(), `unsupported scheme "" for URL`)
+
+	conf = makeConfig(&Receiver{Name: "pushover", PushoverConfigs: []*PushoverConfig{{UserKey: "user"}}})
+	assert.EqualError(t, conf.Validate(), "missing token in Pushover config")
+	conf = makeConfig(&Receiver{Name: "pushover", PushoverConfigs: []*PushoverConfig{{UserKey: "user", Token: "token", Retry: "abcd"}}})
+	assert.Error(t, conf.Validate())
+
+	// Tenants can't fall back to the global OpsGenie and WeChat credentials
+	globalConf := config.DefaultGlobalConfig()
+	globalConf.OpsGenieAPIKey = "global_key"
+	globalConf.WeChatAPISecret = "global_secret"
+	globalConf.WeChatAPICorpID = "global_corp"
+	conf = makeConfig(&Receiver{Name: "opsgenie", OpsGenieConfigs: []*OpsGenieConfig{{Teams: "oncall"}}})
+	conf.Global = &globalConf
+	assert.EqualError(t, conf.Validate(), "missing api_key in OpsGenie config of receiver opsgenie")
+	conf = makeConfig(&Receiver{Name: "wechat", WechatConfigs: []*WechatConfig{{APISecret: "secret"}}})
+	conf.Global = &globalConf
+	assert.EqualError(t, conf.Validate(), "missing api_secret or corp_id in WeChat config of receiver wechat")
+}
+
+// TestMarshalYamlIntegrationSecrets checks that secrets are written as is
+// instead of being obscured
+func TestMarshalYamlIntegrationSecrets(t *testing.T) {
+	conf := Config{Receivers: []*Receiver{&samplePagerDutyReceiver, &sampleOpsGenieReceiver, &samplePushoverReceiver, &sampleWechatReceiver}}
+	ymlData, err := yaml.Marshal(conf)
+	assert.NoError(t, err)
+	for _, secret := range []string{"0123456789abcdef", "opsgenie_key", "pushover_user", "pushover_token", "wechat_secret"} {
+		assert.Contains(t, string(ymlData), secret)
+	}
+	assert.NotContains(t, string(ymlData), "<secret>")
+}
+
 func TestConfig_GetReceiver(t *testing.T) {
 	rec := sampleConfig.GetReceiver("testReceiver")
 	assert.NotNil(t, rec)
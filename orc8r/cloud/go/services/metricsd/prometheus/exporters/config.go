@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/metricsd/confignames"
+)
+
+const (
+	urlConfigKey           = "url"
+	batchSizeConfigKey     = "batchSize"
+	pushIntervalConfigKey  = "pushIntervalSecs"
+	maxRetriesConfigKey    = "maxRetries"
+	timeoutConfigKey       = "timeoutSecs"
+	maxPendingConfigKey    = "maxPending"
+	pathConfigKey          = "path"
+	maxFileSizeConfigKey   = "maxFileSizeBytes"
+	maxBackupsConfigKey    = "maxBackups"
+	flushIntervalConfigKey = "flushIntervalSecs"
+)
+
+// GetRemoteWriteConfig reads the remote write exporter config from the
+// metricsd config, it returns nil if the exporter isn't configured.
+//
+//	remoteWrite:
+//	  url: "http://cortex:9009/api/prom/push"
+//	  batchSize: 1000
+//	  pushIntervalSecs: 30
+//	  maxRetries: 3
+//	  timeoutSecs: 10
+//	  maxPending: 100000
+func GetRemoteWriteConfig(cfg *config.ConfigMap) (*RemoteWriteConfig, error) {
+	subCfg, err := getSubConfig(cfg, confignames.RemoteWrite)
+	if subCfg == nil || err != nil {
+		return nil, err
+	}
+	url, err := subCfg.GetStringParam(urlConfigKey)
+	if err != nil || len(url) == 0 {
+		return nil, fmt.Errorf("%s config requires a %s", confignames.RemoteWrite, urlConfigKey)
+	}
+	ret := &RemoteWriteConfig{URL: url}
+	ints := map[string]*int{
+		batchSizeConfigKey:  &ret.BatchSize,
+		maxRetriesConfigKey: &ret.MaxRetries,
+		maxPendingConfigKey: &ret.MaxPending,
+	}
+	durations := map[string]*time.Duration{
+		pushIntervalConfigKey: &ret.PushInterval,
+		timeoutConfigKey:      &ret.Timeout,
+	}
+	err = readOptionalParams(subCfg, confignames.RemoteWrite, ints, durations)
+	return ret, err
+}
+
+// GetOpenMetricsFileConfig reads the OpenMetrics file exporter config from
+// the metricsd config, it returns nil if the exporter isn't configured.
+//
+//	openMetricsFile:
+//	  path: "/var/log/metricsd.om"
+//	  maxFileSizeBytes: 104857600
+//	  maxBackups: 3
+//	  flushIntervalSecs: 30
+func GetOpenMetricsFileConfig(cfg *config.ConfigMap) (*OpenMetricsFileConfig, error) {
+	subCfg, err := getSubConfig(cfg, confignames.OpenMetricsFile)
+	if subCfg == nil || err != nil {
+		return nil, err
+	}
+	path, err := subCfg.GetStringParam(pathConfigKey)
+	if err != nil || len(path) == 0 {
+		return nil, fmt.Errorf("%s config requires a %s", confignames.OpenMetricsFile, pathConfigKey)
+	}
+	ret := &OpenMetricsFileConfig{Path: path}
+	var maxFileSize int
+	ints := map[string]*int{
+		maxFileSizeConfigKey: &maxFileSize,
+		maxBackupsConfigKey:  &ret.MaxBackups,
+	}
+	durations := map[string]*time.Duration{
+		flushIntervalConfigKey: &ret.FlushInterval,
+	}
+	err = readOptionalParams(subCfg, confignames.OpenMetricsFile, ints, durations)
+	ret.MaxFileSize = int64(maxFileSize)
+	return ret, err
+}
+
+func getSubConfig(cfg *config.ConfigMap, key string) (*config.ConfigMap, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	raw, found := cfg.RawMap[key]
+	if !found || raw == nil {
+		return nil, nil
+	}
+	rawMap, ok := raw.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s config must be a map", key)
+	}
+	return config.NewConfigMap(rawMap), nil
+}
+
+// readOptionalParams reads the int params and the duration params, given in
+// seconds, which are present in the config
+func readOptionalParams(
+	cfg *config.ConfigMap,
+	name string,
+	ints map[string]*int,
+	durations map[string]*time.Duration,
+) error {
+	for key, dst := range ints {
+		if _, found := cfg.RawMap[key]; !found {
+			continue
+		}
+		val, err := cfg.GetIntParam(key)
+		if err != nil {
+			return fmt.Errorf("invalid %s in %s config: %s", key, name, err)
+		}
+		*dst = val
+	}
+	for key, dst := range durations {
+		if _, found := cfg.RawMap[key]; !found {
+			continue
+		}
+		secs, err := cfg.GetIntParam(key)
+		if err != nil {
+			return fmt.Errorf("invalid %s in %s config: %s", key, name, err)
+		}
+		*dst = time.Duration(secs) * time.Second
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExporterConfigs(t *testing.T) {
+	// Not configured
+	cfg := config.NewConfigMap(map[interface{}]interface{}{})
+	rw, err := GetRemoteWriteConfig(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, rw)
+	om, err := GetOpenMetricsFileConfig(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, om)
+
+	cfg = config.NewConfigMap(map[interface{}]interface{}{
+		"remoteWrite": map[interface{}]interface{}{
+			"url":              "http://cortex:9009/api/prom/push",
+			"batchSize":        500,
+			"pushIntervalSecs": 10,
+		},
+		"openMetricsFile": map[interface{}]interface{}{
+			"path":             "/var/log/metricsd.om",
+			"maxFileSizeBytes": 1024,
+		},
+	})
+	rw, err = GetRemoteWriteConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, &RemoteWriteConfig{
+		URL:          "http://cortex:9009/api/prom/push",
+		BatchSize:    500,
+		PushInterval: 10 * time.Second,
+	}, rw)
+	om, err = GetOpenMetricsFileConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, &OpenMetricsFileConfig{Path: "/var/log/metricsd.om", MaxFileSize: 1024}, om)
+
+	// Invalid configs
+	cfg = config.NewConfigMap(map[interface{}]interface{}{
+		"remoteWrite":     map[interface{}]interface{}{"batchSize": 500},
+		"openMetricsFile": "/var/log/metricsd.om",
+	})
+	_, err = GetRemoteWriteConfig(cfg)
+	assert.Error(t, err)
+	_, err = GetOpenMetricsFileConfig(cfg)
+	assert.Error(t, err)
+}
@@ -19,8 +19,10 @@ import (
 	"time"
 
 	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+	"magma/orc8r/cloud/go/services/metricsd/prometheus/prometheus-cache/cache"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 )
@@ -39,6 +41,10 @@ type CustomPushExporter struct {
 	familiesByName map[string]*io_prometheus_client.MetricFamily
 	exportInterval time.Duration
 	pushAddresses  []string
+	// pushShardIDs are the shard IDs of the pushgateways, in the order of
+	// pushAddresses. Empty if the pushgateways aren't sharded.
+	pushShardIDs []string
+	shardRing    *cache.HashRing
 	sync.Mutex
 }
 
@@ -56,19 +62,53 @@ func NewCustomPushExporter(pushAddresses []string) mxd_exp.Exporter {
 	}
 }
 
+// NewShardedCustomPushExporter creates a new exporter to custom pushgateways
+// sharing metrics by network, such as sharded prometheus-cache instances.
+// shardIDs are the shard IDs of the pushgateways at pushAddresses, in the same
+// order. Each network's metrics are only pushed to the pushgateway of the
+// shard owning the network.
+func NewShardedCustomPushExporter(pushAddresses []string, shardIDs []string) (mxd_exp.Exporter, error) {
+	if len(shardIDs) != len(pushAddresses) {
+		return nil, fmt.Errorf("got %d shard IDs for %d push addresses", len(shardIDs), len(pushAddresses))
+	}
+	exporter := NewCustomPushExporter(pushAddresses).(*CustomPushExporter)
+	exporter.pushShardIDs = shardIDs
+	exporter.shardRing = cache.NewHashRing(shardIDs, cache.DefaultShardReplicas)
+	return exporter, nil
+}
+
 // Submit takes in a MetricAndContext, adds labels and timestamps to the metrics
 // and stores them to be pushed later
 func (e *CustomPushExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
 	e.Lock()
 	defer e.Unlock()
 
+	for _, fam := range prepareFamilies(metrics) {
+		familyName := fam.GetName()
+		if baseFamily, ok := e.familiesByName[familyName]; ok {
+			addMetricsToFamily(baseFamily, fam)
+		} else {
+			e.familiesByName[familyName] = fam
+		}
+	}
+	return nil
+}
+
+// prepareFamilies converts the submitted metrics to gauge families with
+// prometheus compatible names, which is the form all prometheus exporters
+// export metrics in. Metrics with invalid labels are dropped and metrics
+// without a timestamp are timestamped with the current time.
+// The submitted families are shared by all exporters, so they're cloned
+// rather than modified.
+func prepareFamilies(metrics []mxd_exp.MetricAndContext) []*io_prometheus_client.MetricFamily {
+	var ret []*io_prometheus_client.MetricFamily
 	for _, metricAndContext := range metrics {
 		// Don't register family if it has 0 metrics. Would cause prometheus scrape
 		// to fail.
 		if len(metricAndContext.Family.Metric) == 0 {
 			continue
 		}
-		originalFamily := metricAndContext.Family
+		originalFamily := proto.Clone(metricAndContext.Family).(*io_prometheus_client.MetricFamily)
 		originalFamily.Name = sanitizePrometheusName(metricAndContext.Context.MetricName)
 		// Convert all families to gauges to avoid name collisions of different
 		// types.
@@ -86,14 +126,10 @@ func (e *CustomPushExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
 					metric.TimestampMs = &timeStamp
 				}
 			}
-			if baseFamily, ok := e.familiesByName[familyName]; ok {
-				addMetricsToFamily(baseFamily, fam)
-			} else {
-				e.familiesByName[familyName] = fam
-			}
+			ret = append(ret, fam)
 		}
 	}
-	return nil
+	return ret
 }
 
 // dropInvalidMetrics because invalid label names would cause the entire scrape
@@ -158,24 +194,32 @@ func (e *CustomPushExporter) pushFamilies() []error {
 	if len(e.familiesByName) == 0 {
 		return []error{}
 	}
-	bodyBuilder := strings.Builder{}
 
 	e.Lock()
-	for _, fam := range e.familiesByName {
-		familyString, err := familyToString(fam)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		bodyBuilder.WriteString(familyString)
-		bodyBuilder.WriteString("\n")
-	}
+	familiesByShard := e.getFamiliesByShard()
 	e.Unlock()
 
-	body := bodyBuilder.String()
 	client := http.Client{}
-	for _, address := range e.pushAddresses {
-		resp, err := client.Post(address, "text/plain", bytes.NewBufferString(body))
+	for i, address := range e.pushAddresses {
+		families := familiesByShard[""]
+		if e.shardRing != nil {
+			families = familiesByShard[e.pushShardIDs[i]]
+			if len(families) == 0 {
+				continue
+			}
+		}
+		bodyBuilder := strings.Builder{}
+		for _, fam := range families {
+			familyString, err := familyToString(fam)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			bodyBuilder.WriteString(familyString)
+			bodyBuilder.WriteString("\n")
+		}
+
+		resp, err := client.Post(address, "text/plain", bytes.NewBufferString(bodyBuilder.String()))
 		if err != nil {
 			errs = append(errs, fmt.Errorf("error making request: %v", err))
 			continue
@@ -189,6 +233,31 @@ func (e *CustomPushExporter) pushFamilies() []error {
 	return errs
 }
 
+// getFamiliesByShard splits the families by the shard owning the network of
+// each metric. All families are keyed by the empty shard ID if the
+// pushgateways aren't sharded.
+func (e *CustomPushExporter) getFamiliesByShard() map[string][]*io_prometheus_client.MetricFamily {
+	ret := map[string][]*io_prometheus_client.MetricFamily{}
+	for _, fam := range e.familiesByName {
+		if e.shardRing == nil {
+			ret[""] = append(ret[""], fam)
+			continue
+		}
+		shardFamilies := map[string]*io_prometheus_client.MetricFamily{}
+		for _, metric := range fam.Metric {
+			shardID := e.shardRing.Get(cache.GetNetworkID(metric))
+			shardFamily, ok := shardFamilies[shardID]
+			if !ok {
+				shardFamily = &io_prometheus_client.MetricFamily{Name: fam.Name, Help: fam.Help, Type: fam.Type}
+				shardFamilies[shardID] = shardFamily
+				ret[shardID] = append(ret[shardID], shardFamily)
+			}
+			shardFamily.Metric = append(shardFamily.Metric, metric)
+		}
+	}
+	return ret
+}
+
 func (e *CustomPushExporter) resetFamilies() {
 	e.familiesByName = make(map[string]*io_prometheus_client.MetricFamily)
 }
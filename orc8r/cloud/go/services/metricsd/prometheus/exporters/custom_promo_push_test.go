@@ -9,16 +9,24 @@
 package exporters
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"magma/orc8r/cloud/go/metrics"
 	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	"magma/orc8r/cloud/go/services/metricsd/prometheus/prometheus-cache/cache"
 	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
 
+	"github.com/golang/protobuf/proto"
 	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -60,6 +68,59 @@ func TestNewCustomPushExporter(t *testing.T) {
 	}
 }
 
+func TestShardedCustomPushExporter_Push(t *testing.T) {
+	_, err := NewShardedCustomPushExporter([]string{"prometheus-cache-0:9091"}, []string{"cache-0", "cache-1"})
+	assert.Error(t, err)
+
+	shardIDs := []string{"cache-0", "cache-1", "cache-2"}
+	var addresses []string
+	var lock sync.Mutex
+	pushedNetworks := map[string][]string{}
+	for _, shardID := range shardIDs {
+		shardID := shardID
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var parser expfmt.TextParser
+			families, err := parser.TextToMetricFamilies(r.Body)
+			assert.NoError(t, err)
+			lock.Lock()
+			defer lock.Unlock()
+			for _, fam := range families {
+				for _, metric := range fam.Metric {
+					pushedNetworks[shardID] = append(pushedNetworks[shardID], cache.GetNetworkID(metric))
+				}
+			}
+		}))
+		defer server.Close()
+		addresses = append(addresses, server.URL)
+	}
+	exp, err := NewShardedCustomPushExporter(addresses, shardIDs)
+	require.NoError(t, err)
+
+	var networkIDs []string
+	for i := 0; i < 20; i++ {
+		networkID := fmt.Sprintf("network%d", i)
+		networkIDs = append(networkIDs, networkID)
+		labels := []*dto.LabelPair{{Name: tests.MakeStringPointer(metrics.NetworkLabelName), Value: tests.MakeStringPointer(networkID)}}
+		err = exp.Submit([]exporters.MetricAndContext{{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, labels),
+			Context: exporters.MetricsContext{MetricName: sampleMetricName},
+		}})
+		require.NoError(t, err)
+	}
+	assert.Empty(t, exp.(*CustomPushExporter).export())
+
+	// Each network's metrics are pushed to the shard owning the network only
+	ring := cache.NewHashRing(shardIDs, cache.DefaultShardReplicas)
+	var allPushed []string
+	for shardID, pushed := range pushedNetworks {
+		for _, networkID := range pushed {
+			assert.Equal(t, ring.Get(networkID), shardID)
+		}
+		allPushed = append(allPushed, pushed...)
+	}
+	assert.ElementsMatch(t, networkIDs, allPushed)
+}
+
 func testSubmitGauge(t *testing.T) {
 	exp := makeTestCustomPushExporter()
 	err := submitNewMetric(&exp, dto.MetricType_GAUGE, sampleGatewayContext)
@@ -268,3 +329,38 @@ func makeTestCustomPushExporter() CustomPushExporter {
 		pushAddresses:  []string{""},
 	}
 }
+
+func TestPrepareFamilies_SubmittedFamiliesUnchanged(t *testing.T) {
+	// Labels with spare capacity must not be shared by the buckets
+	labels := make([]*dto.LabelPair, len(sampleLabels), len(sampleLabels)+1)
+	copy(labels, sampleLabels)
+	histogram := tests.MakeTestMetricFamily(dto.MetricType_HISTOGRAM, 1, labels)
+	gauge := tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+	submitted := []exporters.MetricAndContext{
+		{Family: histogram, Context: sampleGatewayContext},
+		{Family: gauge, Context: sampleGatewayContext},
+	}
+	originals := []proto.Message{proto.Clone(histogram), proto.Clone(gauge)}
+
+	// Every exporter prepares the same submitted families
+	families := prepareFamilies(submitted)
+	prepareFamilies(submitted)
+	assert.True(t, proto.Equal(originals[0], histogram))
+	assert.True(t, proto.Equal(originals[1], gauge))
+
+	upperBounds := map[string]bool{}
+	for _, fam := range families {
+		if fam.GetName() != sampleMetricName+bucketPostfix {
+			continue
+		}
+		for _, metric := range fam.Metric {
+			assert.NotNil(t, metric.TimestampMs)
+			for _, label := range metric.Label {
+				if label.GetName() == histogramBucketLabelName {
+					upperBounds[label.GetValue()] = true
+				}
+			}
+		}
+	}
+	assert.Len(t, upperBounds, len(histogram.Metric[0].Histogram.Bucket))
+}
@@ -27,6 +27,8 @@ var (
 	gaugeType = dto.MetricType_GAUGE
 )
 
+// convertFamilyToGauges converts the family to gauge families. Gauge families
+// are returned as is, so the caller must own the base family.
 func convertFamilyToGauges(baseFamily *dto.MetricFamily) []*dto.MetricFamily {
 	gaugeFamilies := make([]*dto.MetricFamily, 0)
 	switch *baseFamily.Type {
@@ -107,10 +109,7 @@ func histogramToGauges(family *dto.MetricFamily) []*dto.MetricFamily {
 		for _, bucket := range metric.Histogram.Bucket {
 			bucketValue := float64(*bucket.CumulativeCount)
 			bucketMetric := dto.Metric{
-				Label: append(metric.Label, &dto.LabelPair{
-					Name:  makeStringPointer(histogramBucketLabelName),
-					Value: makeStringPointer(fmt.Sprintf("%g", bucket.GetUpperBound())),
-				}),
+				Label: withLabel(metric.Label, histogramBucketLabelName, fmt.Sprintf("%g", bucket.GetUpperBound())),
 				Gauge: &dto.Gauge{
 					Value: &bucketValue,
 				},
@@ -163,10 +162,7 @@ func summaryToGauges(family *dto.MetricFamily) []*dto.MetricFamily {
 		for _, quant := range metric.Summary.Quantile {
 			quantValue := *quant.Value
 			quantMetric := dto.Metric{
-				Label: append(metric.Label, &dto.LabelPair{
-					Name:  makeStringPointer(summaryQuantileLabelName),
-					Value: makeStringPointer(fmt.Sprintf("%g", *quant.Quantile)),
-				}),
+				Label: withLabel(metric.Label, summaryQuantileLabelName, fmt.Sprintf("%g", *quant.Quantile)),
 				Gauge: &dto.Gauge{
 					Value: &quantValue,
 				},
@@ -199,3 +195,12 @@ func untypedToGauge(family *dto.MetricFamily) *dto.MetricFamily {
 	}
 	return &untypedFamily
 }
+
+// withLabel returns a copy of the labels with the given label added. Appending
+// to the labels directly could overwrite the label added for another bucket
+// or quantile of the same metric.
+func withLabel(labels []*dto.LabelPair, name, value string) []*dto.LabelPair {
+	ret := make([]*dto.LabelPair, 0, len(labels)+1)
+	ret = append(ret, labels...)
+	return append(ret, &dto.LabelPair{Name: makeStringPointer(name), Value: makeStringPointer(value)})
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_model/go"
+)
+
+const (
+	defaultOpenMetricsMaxFileSize = 100 * 1024 * 1024
+	defaultOpenMetricsMaxBackups  = 3
+)
+
+// OpenMetricsFileConfig configures an OpenMetricsFileExporter. Zero values
+// are replaced by defaults.
+type OpenMetricsFileConfig struct {
+	// Path of the file metrics are written to
+	Path string
+	// MaxFileSize in bytes after which the file is rotated
+	MaxFileSize int64
+	// MaxBackups is the number of rotated files kept as Path.1 ... Path.N,
+	// Path.1 being the most recent
+	MaxBackups int
+	// FlushInterval is how often buffered metrics are written
+	FlushInterval time.Duration
+}
+
+// OpenMetricsFileExporter writes metrics to a local, size rotated file in the
+// OpenMetrics text format. It's intended for offline debugging of the metrics
+// gateways send.
+type OpenMetricsFileExporter struct {
+	config         OpenMetricsFileConfig
+	familiesByName map[string]*io_prometheus_client.MetricFamily
+	sync.Mutex
+}
+
+// NewOpenMetricsFileExporter creates a new exporter to the configured file
+func NewOpenMetricsFileExporter(config OpenMetricsFileConfig) mxd_exp.Exporter {
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaultOpenMetricsMaxFileSize
+	}
+	if config.MaxBackups <= 0 {
+		config.MaxBackups = defaultOpenMetricsMaxBackups
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = pushInterval
+	}
+	return &OpenMetricsFileExporter{
+		config:         config,
+		familiesByName: make(map[string]*io_prometheus_client.MetricFamily),
+	}
+}
+
+// Submit buffers the metrics to be written at the next flush
+func (e *OpenMetricsFileExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
+	e.Lock()
+	defer e.Unlock()
+
+	for _, fam := range prepareFamilies(metrics) {
+		familyName := fam.GetName()
+		if baseFamily, ok := e.familiesByName[familyName]; ok {
+			addMetricsToFamily(baseFamily, fam)
+		} else {
+			e.familiesByName[familyName] = fam
+		}
+	}
+	return nil
+}
+
+// Start runs flushEvery() in a goroutine to continuously write metrics at
+// every flush interval
+func (e *OpenMetricsFileExporter) Start() {
+	go e.flushEvery()
+}
+
+func (e *OpenMetricsFileExporter) flushEvery() {
+	for range time.Tick(e.config.FlushInterval) {
+		if err := e.flush(); err != nil {
+			glog.Errorf("error in writing metrics file %s: %v", e.config.Path, err)
+		}
+	}
+}
+
+// flush appends all buffered metrics as one OpenMetrics exposition to the
+// file, rotating it first if it has grown past the maximum size
+func (e *OpenMetricsFileExporter) flush() error {
+	e.Lock()
+	families := e.familiesByName
+	e.familiesByName = make(map[string]*io_prometheus_client.MetricFamily)
+	e.Unlock()
+
+	if len(families) == 0 {
+		return nil
+	}
+	if err := e.rotateIfNeeded(); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(e.config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(familiesToOpenMetrics(families))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (e *OpenMetricsFileExporter) rotateIfNeeded() error {
+	info, err := os.Stat(e.config.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size() < e.config.MaxFileSize {
+		return nil
+	}
+	// Shift Path.N-1 -> Path.N, ..., Path -> Path.1, dropping the oldest
+	for i := e.config.MaxBackups - 1; i >= 0; i-- {
+		src := e.backupPath(i)
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(src, e.backupPath(i+1)); err != nil {
+			return fmt.Errorf("error rotating %s: %v", src, err)
+		}
+	}
+	return nil
+}
+
+func (e *OpenMetricsFileExporter) backupPath(i int) string {
+	if i == 0 {
+		return e.config.Path
+	}
+	return fmt.Sprintf("%s.%d", e.config.Path, i)
+}
+
+// familiesToOpenMetrics encodes gauge families in the OpenMetrics text format,
+// sorted by family name and terminated by the '# EOF' marker
+func familiesToOpenMetrics(families map[string]*io_prometheus_client.MetricFamily) string {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b := strings.Builder{}
+	for _, name := range names {
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		for _, metric := range families[name].Metric {
+			b.WriteString(name)
+			writeOpenMetricsLabels(&b, metric.Label)
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(metric.GetGauge().GetValue(), 'g', -1, 64))
+			if metric.TimestampMs != nil {
+				// OpenMetrics timestamps are in seconds
+				fmt.Fprintf(&b, " %d.%03d", metric.GetTimestampMs()/1000, metric.GetTimestampMs()%1000)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
+func writeOpenMetricsLabels(b *strings.Builder, labels []*io_prometheus_client.LabelPair) {
+	if len(labels) == 0 {
+		return
+	}
+	b.WriteByte('{')
+	for i, label := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(b, "%s=\"%s\"", label.GetName(), openMetricsLabelEscaper.Replace(label.GetValue()))
+	}
+	b.WriteByte('}')
+}
+
+var openMetricsLabelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFamiliesToOpenMetrics(t *testing.T) {
+	ts := int64(1500000000123)
+	families := map[string]*dto.MetricFamily{
+		"metric_b": {
+			Name: tests.MakeStringPointer("metric_b"),
+			Metric: []*dto.Metric{{
+				Gauge:       &dto.Gauge{Value: floatPointer(1.5)},
+				TimestampMs: &ts,
+			}},
+		},
+		"metric_a": {
+			Name: tests.MakeStringPointer("metric_a"),
+			Metric: []*dto.Metric{{
+				Label: []*dto.LabelPair{
+					{Name: tests.MakeStringPointer("a"), Value: tests.MakeStringPointer("x\"y\\z\n")},
+					{Name: tests.MakeStringPointer("b"), Value: tests.MakeStringPointer("c")},
+				},
+				Gauge: &dto.Gauge{Value: floatPointer(2)},
+			}},
+		},
+	}
+	expected := "# TYPE metric_a gauge\n" +
+		"metric_a{a=\"x\\\"y\\\\z\\n\",b=\"c\"} 2\n" +
+		"# TYPE metric_b gauge\n" +
+		"metric_b 1.5 1500000000.123\n" +
+		"# EOF\n"
+	assert.Equal(t, expected, familiesToOpenMetrics(families))
+}
+
+func TestOpenMetricsFileExporter_Rotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openmetrics")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.om")
+
+	exp := NewOpenMetricsFileExporter(OpenMetricsFileConfig{
+		Path:        path,
+		MaxFileSize: 1,
+		MaxBackups:  2,
+	}).(*OpenMetricsFileExporter)
+
+	// Nothing is written without metrics
+	assert.NoError(t, exp.flush())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	for i := 0; i < 4; i++ {
+		err = exp.Submit([]exporters.MetricAndContext{
+			{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels), Context: sampleGatewayContext},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, exp.flush())
+	}
+
+	// Every flush rotates as the max size is 1 byte, keeping the 2 latest
+	// backups
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		contents, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), fmt.Sprintf("# TYPE %s gauge\n", sampleMetricName))
+		assert.Contains(t, string(contents), "networkID=\"sampleNetwork\"")
+		assert.Regexp(t, "# EOF\n$", string(contents))
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func floatPointer(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/golang/glog"
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+const (
+	remoteWriteVersion = "0.1.0"
+	metricNameLabel    = "__name__"
+
+	defaultRemoteWriteBatchSize  = 1000
+	defaultRemoteWriteMaxRetries = 3
+	defaultRemoteWriteTimeout    = time.Second * 10
+	// Samples which are still buffered when the buffer is full are dropped,
+	// oldest first, to bound memory use while the backend is unreachable
+	defaultRemoteWriteMaxPending = 100000
+	remoteWriteBaseBackoff       = time.Millisecond * 500
+)
+
+// RemoteWriteConfig configures a RemoteWriteExporter. Zero values are
+// replaced by defaults.
+type RemoteWriteConfig struct {
+	// URL of the remote write endpoint, e.g. a Cortex or Thanos receiver
+	URL string
+	// BatchSize is the maximum number of samples sent in a single request
+	BatchSize int
+	// PushInterval is how often buffered samples are sent
+	PushInterval time.Duration
+	// MaxRetries is how many times a request which failed with a network
+	// error or a 5xx or 429 response is retried, with exponential backoff
+	MaxRetries int
+	// Timeout of each request
+	Timeout time.Duration
+	// MaxPending is the maximum number of samples buffered between pushes
+	MaxPending int
+}
+
+// RemoteWriteExporter sends metrics to a prometheus remote write endpoint, as
+// snappy compressed protobuf WriteRequests.
+type RemoteWriteExporter struct {
+	config  RemoteWriteConfig
+	client  *http.Client
+	pending []*prompb.TimeSeries
+	backoff time.Duration
+	sync.Mutex
+}
+
+// NewRemoteWriteExporter creates a new exporter to a remote write endpoint
+func NewRemoteWriteExporter(config RemoteWriteConfig) mxd_exp.Exporter {
+	if !strings.HasPrefix(config.URL, "http") {
+		config.URL = fmt.Sprintf("http://%s", config.URL)
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultRemoteWriteBatchSize
+	}
+	if config.PushInterval <= 0 {
+		config.PushInterval = pushInterval
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaultRemoteWriteMaxRetries
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultRemoteWriteTimeout
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = defaultRemoteWriteMaxPending
+	}
+	return &RemoteWriteExporter{
+		config:  config,
+		client:  &http.Client{Timeout: config.Timeout},
+		backoff: remoteWriteBaseBackoff,
+	}
+}
+
+// Submit converts the metrics to remote write time series, with one sample
+// each, and buffers them to be sent later
+func (e *RemoteWriteExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
+	var series []*prompb.TimeSeries
+	for _, fam := range prepareFamilies(metrics) {
+		for _, metric := range fam.Metric {
+			series = append(series, metricToTimeSeries(fam.GetName(), metric))
+		}
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	e.pending = append(e.pending, series...)
+	if excess := len(e.pending) - e.config.MaxPending; excess > 0 {
+		glog.Errorf("Remote write buffer is full, dropping %d samples", excess)
+		e.pending = e.pending[excess:]
+	}
+	return nil
+}
+
+// Start runs exportEvery() in a goroutine to continuously send metrics at
+// every push interval
+func (e *RemoteWriteExporter) Start() {
+	go e.exportEvery()
+}
+
+func (e *RemoteWriteExporter) exportEvery() {
+	for range time.Tick(e.config.PushInterval) {
+		errs := e.export()
+		if len(errs) > 0 {
+			glog.Errorf("error in sending to remote write endpoint: %v", errs)
+		}
+	}
+}
+
+// export sends all buffered samples in batches. Batches which still fail
+// after all retries are dropped.
+func (e *RemoteWriteExporter) export() []error {
+	e.Lock()
+	pending := e.pending
+	e.pending = nil
+	e.Unlock()
+
+	var errs []error
+	for start := 0; start < len(pending); start += e.config.BatchSize {
+		end := start + e.config.BatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if err := e.sendWithRetries(pending[start:end]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (e *RemoteWriteExporter) sendWithRetries(series []*prompb.TimeSeries) error {
+	req := &prompb.WriteRequest{Timeseries: series}
+	data, err := req.Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling write request: %v", err)
+	}
+	body := snappy.Encode(nil, data)
+
+	backoff := e.backoff
+	for attempt := 0; ; attempt++ {
+		recoverable, err := e.send(body)
+		if err == nil {
+			return nil
+		}
+		if !recoverable || attempt >= e.config.MaxRetries {
+			return fmt.Errorf("error sending %d samples to %s: %v", len(series), e.config.URL, err)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send posts a compressed write request, returning whether a failed request
+// may succeed if retried
+func (e *RemoteWriteExporter) send(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, e.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	err = fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// metricToTimeSeries converts a gauge metric to a time series with the
+// metric's name label and its labels sorted by name, as remote write requires
+func metricToTimeSeries(name string, metric *io_prometheus_client.Metric) *prompb.TimeSeries {
+	labels := make([]*prompb.Label, 0, len(metric.Label)+1)
+	labels = append(labels, &prompb.Label{Name: metricNameLabel, Value: name})
+	for _, label := range metric.Label {
+		labels = append(labels, &prompb.Label{Name: label.GetName(), Value: label.GetValue()})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return &prompb.TimeSeries{
+		Labels: labels,
+		Samples: []prompb.Sample{{
+			Value:     metric.GetGauge().GetValue(),
+			Timestamp: metric.GetTimestampMs(),
+		}},
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/metrics"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remoteWriteReceiver records the write requests it receives and responds
+// with the queued status codes, then with 200
+type remoteWriteReceiver struct {
+	requests []*prompb.WriteRequest
+	statuses []int
+	sync.Mutex
+}
+
+func (r *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	if req.Header.Get("Content-Encoding") != "snappy" ||
+		req.Header.Get("X-Prometheus-Remote-Write-Version") != remoteWriteVersion {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	writeReq := &prompb.WriteRequest{}
+	if err := writeReq.Unmarshal(data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.requests = append(r.requests, writeReq)
+}
+
+func TestRemoteWriteExporter_Export(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	exp := newTestRemoteWriteExporter(srv.URL)
+	err := exp.Submit([]exporters.MetricAndContext{
+		{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 3, sampleLabels), Context: sampleGatewayContext},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, exp.pending, 3)
+
+	// 3 samples in batches of 2
+	assert.Empty(t, exp.export())
+	assert.Empty(t, exp.pending)
+	require.Len(t, receiver.requests, 2)
+	assert.Len(t, receiver.requests[0].Timeseries, 2)
+	assert.Len(t, receiver.requests[1].Timeseries, 1)
+
+	series := receiver.requests[0].Timeseries[0]
+	assert.Equal(t, []*prompb.Label{
+		{Name: metricNameLabel, Value: sampleMetricName},
+		{Name: metrics.NetworkLabelName, Value: sampleNetworkID},
+		{Name: "testLabel", Value: "testValue"},
+	}, series.Labels)
+	require.Len(t, series.Samples, 1)
+	assert.NotZero(t, series.Samples[0].Timestamp)
+
+	// Nothing to send
+	assert.Empty(t, exp.export())
+	assert.Len(t, receiver.requests, 2)
+}
+
+func TestRemoteWriteExporter_Retries(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+	exp := newTestRemoteWriteExporter(srv.URL)
+	family := tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+
+	// Server errors and throttling are retried
+	receiver.statuses = []int{http.StatusInternalServerError, http.StatusTooManyRequests}
+	assert.NoError(t, exp.Submit([]exporters.MetricAndContext{{Family: family, Context: sampleGatewayContext}}))
+	assert.Empty(t, exp.export())
+	assert.Len(t, receiver.requests, 1)
+
+	// Other client errors aren't
+	receiver.statuses = []int{http.StatusBadRequest}
+	assert.NoError(t, exp.Submit([]exporters.MetricAndContext{{Family: family, Context: sampleGatewayContext}}))
+	assert.Len(t, exp.export(), 1)
+	assert.Len(t, receiver.requests, 1)
+	assert.Empty(t, receiver.statuses)
+
+	// Retries are limited
+	receiver.statuses = []int{
+		http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable,
+	}
+	assert.NoError(t, exp.Submit([]exporters.MetricAndContext{{Family: family, Context: sampleGatewayContext}}))
+	assert.Len(t, exp.export(), 1)
+	assert.Len(t, receiver.requests, 1)
+	assert.Empty(t, receiver.statuses)
+}
+
+func TestRemoteWriteExporter_MaxPending(t *testing.T) {
+	exp := NewRemoteWriteExporter(RemoteWriteConfig{URL: "localhost:9201", MaxPending: 2}).(*RemoteWriteExporter)
+	assert.Equal(t, "http://localhost:9201", exp.config.URL)
+	assert.Equal(t, defaultRemoteWriteBatchSize, exp.config.BatchSize)
+
+	err := exp.Submit([]exporters.MetricAndContext{
+		{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 3, sampleLabels), Context: sampleGatewayContext},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, exp.pending, 2)
+}
+
+func newTestRemoteWriteExporter(url string) *RemoteWriteExporter {
+	exp := NewRemoteWriteExporter(RemoteWriteConfig{
+		URL:        url,
+		BatchSize:  2,
+		MaxRetries: 3,
+	}).(*RemoteWriteExporter)
+	exp.backoff = time.Millisecond
+	return exp
+}
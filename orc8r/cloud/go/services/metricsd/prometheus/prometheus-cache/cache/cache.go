@@ -42,6 +42,10 @@ type MetricCache struct {
 	stats                cacheStats
 	sync.Mutex
 	scrapeTimeout int
+	// shard of the metrics this cache instance owns, nil if not sharded
+	shard *Shard
+	// wal persists unscraped datapoints, nil if persistence is disabled
+	wal *writeAheadLog
 }
 
 type cacheStats struct {
@@ -65,20 +69,57 @@ func NewMetricCache(limit int, scrapeTimeout int) *MetricCache {
 		glog.Info("Prometheus-Cache created with no limit\n")
 	}
 
-	cacheLimit := prometheus.NewGauge(prometheus.GaugeOpts{Name: internalMetricCacheLimit, Help: "Maximum number of datapoints in cache", ConstLabels: prometheus.Labels{"networkID": "internal"}})
-	cacheSize := prometheus.NewGauge(prometheus.GaugeOpts{Name: internalMetricCacheSize, Help: "Number of datapoints in cache", ConstLabels: prometheus.Labels{"networkID": "internal"}})
-	internalMetrics := map[string]prometheus.Gauge{internalMetricCacheLimit: cacheLimit, internalMetricCacheSize: cacheSize}
-
-	cacheLimit.Set(float64(limit))
-
 	return &MetricCache{
 		metricFamiliesByName: make(map[string]*familyAndMetrics),
-		internalMetrics:      internalMetrics,
+		internalMetrics:      newInternalMetrics(limit, prometheus.Labels{"networkID": "internal"}),
 		limit:                limit,
 		scrapeTimeout:        scrapeTimeout,
 	}
 }
 
+func newInternalMetrics(limit int, constLabels prometheus.Labels) map[string]prometheus.Gauge {
+	cacheLimit := prometheus.NewGauge(prometheus.GaugeOpts{Name: internalMetricCacheLimit, Help: "Maximum number of datapoints in cache", ConstLabels: constLabels})
+	cacheSize := prometheus.NewGauge(prometheus.GaugeOpts{Name: internalMetricCacheSize, Help: "Number of datapoints in cache", ConstLabels: constLabels})
+	cacheLimit.Set(float64(limit))
+	return map[string]prometheus.Gauge{internalMetricCacheLimit: cacheLimit, internalMetricCacheSize: cacheSize}
+}
+
+// SetShard makes the cache only accept the metrics of networks which belong
+// to the shard. Internal metrics are labeled with the shard.
+// It must be called before the cache receives metrics.
+func (c *MetricCache) SetShard(shard *Shard) {
+	c.Lock()
+	defer c.Unlock()
+	c.shard = shard
+	c.internalMetrics = newInternalMetrics(c.limit, prometheus.Labels{"networkID": "internal", "shard": shard.self})
+}
+
+// EnableWAL persists received datapoints to a write-ahead log in the
+// directory until they are scraped. Datapoints left in the log by a previous
+// run are loaded into the cache. It must be called before the cache receives
+// metrics.
+func (c *MetricCache) EnableWAL(dir string) error {
+	wal, records, err := openWAL(dir)
+	if err != nil {
+		return fmt.Errorf("error opening write-ahead log: %v", err)
+	}
+	// Datapoints of networks the shard no longer owns, after the shards
+	// changed, are still loaded to be scraped one last time from this shard
+	replayed := 0
+	for _, families := range records {
+		replayed += countDatapoints(families)
+		c.cacheMetrics(families)
+	}
+	glog.Infof("Loaded %d datapoints from write-ahead log in %s", replayed, dir)
+
+	c.Lock()
+	c.wal = wal
+	c.stats.currentCountDatapoints += replayed
+	c.internalMetrics[internalMetricCacheSize].Set(float64(c.stats.currentCountDatapoints))
+	c.Unlock()
+	return nil
+}
+
 // Receive is a handler function to receive metric pushes
 func (c *MetricCache) Receive(ctx echo.Context) error {
 	var parser expfmt.TextParser
@@ -89,10 +130,15 @@ func (c *MetricCache) Receive(ctx echo.Context) error {
 		return ctx.String(http.StatusBadRequest, fmt.Sprintf("error parsing metrics: %v", err))
 	}
 
-	newDatapoints := 0
-	for _, fam := range parsedFamilies {
-		newDatapoints += len(fam.Metric)
+	// Reject the whole push rather than dropping the metrics of networks
+	// other shards own, so that a pusher with a stale view of the shards
+	// finds out
+	if unowned := c.shard.unownedNetworks(parsedFamilies); len(unowned) > 0 {
+		errString := fmt.Sprintf("Not accepting push with metrics of networks %v, which shard %s doesn't own\n", unowned, c.shard.self)
+		glog.Error(errString)
+		return ctx.String(http.StatusMisdirectedRequest, errString)
 	}
+	newDatapoints := countDatapoints(parsedFamilies)
 
 	// Check if new datapoints will exceed the specified limit
 	if c.limit > 0 {
@@ -103,7 +149,11 @@ func (c *MetricCache) Receive(ctx echo.Context) error {
 		}
 	}
 
-	c.cacheMetrics(parsedFamilies)
+	err = c.persistAndCacheMetrics(parsedFamilies)
+	if err != nil {
+		glog.Errorf("error persisting metrics: %v", err)
+		return ctx.String(http.StatusInternalServerError, fmt.Sprintf("error persisting metrics: %v", err))
+	}
 
 	c.stats.lastReceiveTime = time.Now().Unix()
 	c.stats.lastReceiveSize = ctx.Request().ContentLength
@@ -114,9 +164,44 @@ func (c *MetricCache) Receive(ctx echo.Context) error {
 	return ctx.NoContent(http.StatusOK)
 }
 
+// persistAndCacheMetrics appends the families to the write-ahead log, if
+// enabled, and caches them. Both happen under the cache lock so the log
+// always holds exactly the unscraped datapoints, but the log is only synced
+// to disk once the lock is released.
+func (c *MetricCache) persistAndCacheMetrics(families map[string]*dto.MetricFamily) error {
+	var record []byte
+	var err error
+	if c.wal != nil {
+		record, err = newWALRecord(families)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Lock()
+	wal := c.wal
+	if wal != nil {
+		if err := wal.write(record); err != nil {
+			c.Unlock()
+			return err
+		}
+	}
+	c.cacheMetricsLocked(families)
+	c.Unlock()
+
+	if wal != nil {
+		return wal.sync()
+	}
+	return nil
+}
+
 func (c *MetricCache) cacheMetrics(families map[string]*dto.MetricFamily) {
 	c.Lock()
 	defer c.Unlock()
+	c.cacheMetricsLocked(families)
+}
+
+func (c *MetricCache) cacheMetricsLocked(families map[string]*dto.MetricFamily) {
 	for _, fam := range families {
 		if families, ok := c.metricFamiliesByName[fam.GetName()]; ok {
 			families.addMetrics(fam.Metric)
@@ -132,6 +217,11 @@ func (c *MetricCache) Scrape(ctx echo.Context) error {
 	c.Lock()
 	scrapeMetrics := c.metricFamiliesByName
 	c.clearMetrics()
+	if c.wal != nil {
+		if err := c.wal.reset(); err != nil {
+			glog.Errorf("error truncating write-ahead log: %v", err)
+		}
+	}
 	c.Unlock()
 
 	expositionString := c.exposeMetrics(scrapeMetrics, scrapeWorkerPoolSize)
@@ -231,7 +321,13 @@ func (c *MetricCache) Debug(ctx echo.Context) error {
 		utilizationValue = strconv.FormatFloat(float64(c.stats.currentCountDatapoints)*100/float64(c.limit), 'f', 2, 64)
 	}
 
+	shardValue := "None"
+	if c.shard != nil {
+		shardValue = c.shard.self
+	}
+
 	debugString := fmt.Sprintf(`Prometheus Cache running on %s
+Shard:             %s
 Cache Limit:       %s
 Cache Utilization: %s%%
 
@@ -245,7 +341,7 @@ Last Receive: %d
 
 Current Count Families:   %d
 Current Count Series:     %d
-Current Count Datapoints: %d `, hostname, limitValue, utilizationValue,
+Current Count Datapoints: %d `, hostname, shardValue, limitValue, utilizationValue,
 		c.stats.lastScrapeTime, c.stats.lastScrapeSize, c.stats.lastScrapeNumFamilies,
 		c.stats.lastReceiveTime, c.stats.lastReceiveSize, c.stats.lastReceiveNumFamilies,
 		c.stats.currentCountFamilies, c.stats.currentCountSeries, c.stats.currentCountDatapoints)
@@ -344,6 +440,14 @@ func makeLabeledName(metric *dto.Metric, metricName string) string {
 	return labeledName.String()
 }
 
+func countDatapoints(families map[string]*dto.MetricFamily) int {
+	count := 0
+	for _, fam := range families {
+		count += len(fam.Metric)
+	}
+	return count
+}
+
 func familyToString(family *dto.MetricFamily) (string, error) {
 	var buf bytes.Buffer
 	_, err := expfmt.MetricFamilyToText(&buf, family)
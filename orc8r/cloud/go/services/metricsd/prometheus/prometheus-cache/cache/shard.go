@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package cache
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	networkLabelName = "networkID"
+	// DefaultShardReplicas is the default number of points of each node on
+	// the hash ring. Pushers routing metrics to the shards must build their
+	// ring with the same number of points as the shards.
+	DefaultShardReplicas = 100
+)
+
+// HashRing assigns networks to cache instances by consistent hashing, so that
+// adding or removing an instance only moves the networks of its neighbours on
+// the ring
+type HashRing struct {
+	points []uint32
+	nodes  map[uint32]string
+}
+
+// NewHashRing creates a ring of the given nodes, each with the given number of
+// virtual points on the ring to spread load evenly
+func NewHashRing(nodes []string, replicas int) *HashRing {
+	if replicas <= 0 {
+		replicas = DefaultShardReplicas
+	}
+	ring := &HashRing{nodes: map[uint32]string{}}
+	for _, node := range nodes {
+		for i := 0; i < replicas; i++ {
+			point := hashKey(node + "#" + strconv.Itoa(i))
+			if _, ok := ring.nodes[point]; ok {
+				continue
+			}
+			ring.nodes[point] = node
+			ring.points = append(ring.points, point)
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+	return ring
+}
+
+// Get returns the node owning the key
+func (r *HashRing) Get(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.nodes[r.points[i]]
+}
+
+// Shard is the part of the ring of cache instances that a cache owns. All
+// series of a network, identified by their networkID label, belong to the
+// same shard. Series without a network label are owned by the shard of the
+// empty network ID. Pushers are expected to route each network's metrics to
+// the shard owning it: a shard rejects pushes with metrics it doesn't own.
+type Shard struct {
+	ring *HashRing
+	self string
+}
+
+// NewShard creates the shard of the instance self in a ring of all instances,
+// self must be one of the instances
+func NewShard(self string, instances []string, replicas int) (*Shard, error) {
+	for _, instance := range instances {
+		if instance == self {
+			return &Shard{ring: NewHashRing(instances, replicas), self: self}, nil
+		}
+	}
+	return nil, fmt.Errorf("shard %s is not one of the shard instances %v", self, instances)
+}
+
+// Owns returns true if the metric belongs to the shard. A nil shard owns all
+// metrics.
+func (s *Shard) Owns(metric *dto.Metric) bool {
+	if s == nil {
+		return true
+	}
+	return s.ring.Get(GetNetworkID(metric)) == s.self
+}
+
+// unownedNetworks returns the sorted IDs of the networks of the metrics in
+// the families which don't belong to the shard
+func (s *Shard) unownedNetworks(families map[string]*dto.MetricFamily) []string {
+	if s == nil {
+		return nil
+	}
+	unowned := map[string]struct{}{}
+	for _, fam := range families {
+		for _, metric := range fam.Metric {
+			if !s.Owns(metric) {
+				unowned[GetNetworkID(metric)] = struct{}{}
+			}
+		}
+	}
+	ret := make([]string, 0, len(unowned))
+	for networkID := range unowned {
+		ret = append(ret, networkID)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// GetNetworkID returns the value of the network label of the metric, which
+// decides the shard the metric belongs to
+func GetNetworkID(metric *dto.Metric) string {
+	for _, label := range metric.Label {
+		if label.GetName() == networkLabelName {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// hashKey hashes with md5 as it spreads similar keys, such as sequential
+// network IDs, much more evenly over the ring than fnv or crc32
+func hashKey(key string) uint32 {
+	sum := md5.Sum([]byte(key))
+	return binary.BigEndian.Uint32(sum[:4])
+}
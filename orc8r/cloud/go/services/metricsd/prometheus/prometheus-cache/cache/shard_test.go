@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package cache
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashRing(t *testing.T) {
+	ring := NewHashRing([]string{"cache-0", "cache-1", "cache-2"}, 0)
+	assert.Equal(t, "", NewHashRing(nil, 0).Get("network1"))
+
+	counts := map[string]int{}
+	owners := map[string]string{}
+	for i := 0; i < 3000; i++ {
+		network := fmt.Sprintf("network%d", i)
+		owners[network] = ring.Get(network)
+		counts[owners[network]]++
+	}
+	// Networks are spread over all nodes
+	assert.Len(t, counts, 3)
+	for _, count := range counts {
+		assert.True(t, count > 500, "unbalanced ring: %v", counts)
+	}
+
+	// Adding a node only moves networks to the new node
+	grown := NewHashRing([]string{"cache-0", "cache-1", "cache-2", "cache-3"}, 0)
+	for network, owner := range owners {
+		newOwner := grown.Get(network)
+		if newOwner != owner {
+			assert.Equal(t, "cache-3", newOwner)
+		}
+	}
+}
+
+func TestShardedCache(t *testing.T) {
+	instances := []string{"cache-0", "cache-1"}
+	_, err := NewShard("cache-2", instances, 10)
+	assert.Error(t, err)
+
+	ring := NewHashRing(instances, 10)
+	pushBodies := map[string]*strings.Builder{}
+	var fullPushBody strings.Builder
+	fullPushBody.WriteString("# TYPE metric_a gauge\n")
+	for i := 0; i < 20; i++ {
+		network := fmt.Sprintf("network%d", i)
+		owner := ring.Get(network)
+		if _, ok := pushBodies[owner]; !ok {
+			pushBodies[owner] = &strings.Builder{}
+			pushBodies[owner].WriteString("# TYPE metric_a gauge\n")
+		}
+		line := fmt.Sprintf("metric_a{networkID=\"%s\"} 1 1395066363000\n", network)
+		pushBodies[owner].WriteString(line)
+		fullPushBody.WriteString(line)
+	}
+	require.Len(t, pushBodies, 2)
+
+	// Each instance accepts the pushes of its own networks, and rejects
+	// pushes with metrics of other networks
+	seen := map[string]string{}
+	for _, instance := range instances {
+		shard, err := NewShard(instance, instances, 10)
+		require.NoError(t, err)
+		cache := NewMetricCache(0, 10)
+		cache.SetShard(shard)
+		resp, err := receiveString(cache, fullPushBody.String())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusMisdirectedRequest, resp.Code)
+		resp, err = receiveString(cache, pushBodies[instance].String())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		rec := scrapeCache(t, cache)
+		var parser expfmt.TextParser
+		families, err := parser.TextToMetricFamilies(rec.Body)
+		require.NoError(t, err)
+		for _, metric := range families["metric_a"].GetMetric() {
+			network := GetNetworkID(metric)
+			assert.NotContains(t, seen, network)
+			seen[network] = instance
+			assert.True(t, shard.Owns(metric))
+		}
+		assert.True(t, hasLabel(families[internalMetricCacheSize].Metric[0], "shard", instance))
+	}
+	assert.Len(t, seen, 20)
+}
+
+func hasLabel(metric *dto.Metric, name, value string) bool {
+	for _, label := range metric.Label {
+		if label.GetName() == name && label.GetValue() == value {
+			return true
+		}
+	}
+	return false
+}
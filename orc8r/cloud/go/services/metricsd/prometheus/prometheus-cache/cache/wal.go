@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	walFileName = "cache.wal"
+	// record header: payload length and CRC32 of the payload
+	walHeaderSize = 8
+)
+
+// writeAheadLog persists the datapoints received by the cache until they are
+// scraped, so that they survive restarts. Each received push is appended as a
+// record holding the families in the text exposition format. The log is
+// truncated when the cache is scraped.
+type writeAheadLog struct {
+	file *os.File
+}
+
+// openWAL opens or creates the log in the directory and returns the families
+// of all complete records in it. A torn record at the end of the log, left by
+// a crash during a write, is discarded.
+func openWAL(dir string) (*writeAheadLog, []map[string]*dto.MetricFamily, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, validSize, err := readRecords(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if err := f.Truncate(validSize); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if _, err := f.Seek(validSize, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return &writeAheadLog{file: f}, records, nil
+}
+
+func readRecords(f *os.File) ([]map[string]*dto.MetricFamily, int64, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	reader := bufio.NewReader(f)
+	var records []map[string]*dto.MetricFamily
+	var validSize int64
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err != io.EOF {
+				glog.Errorf("Discarding torn write-ahead log record header at offset %d", validSize)
+			}
+			return records, validSize, nil
+		}
+		size := binary.BigEndian.Uint32(header[:4])
+		checksum := binary.BigEndian.Uint32(header[4:])
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil || crc32.ChecksumIEEE(payload) != checksum {
+			glog.Errorf("Discarding corrupt write-ahead log record at offset %d", validSize)
+			return records, validSize, nil
+		}
+		var parser expfmt.TextParser
+		families, err := parser.TextToMetricFamilies(bytes.NewReader(payload))
+		if err != nil {
+			return nil, 0, fmt.Errorf("error parsing write-ahead log record at offset %d: %v", validSize, err)
+		}
+		records = append(records, families)
+		validSize += int64(walHeaderSize) + int64(size)
+	}
+}
+
+// newWALRecord encodes the families as a single record
+func newWALRecord(families map[string]*dto.MetricFamily) ([]byte, error) {
+	payload := strings.Builder{}
+	for _, fam := range families {
+		familyStr, err := familyToString(fam)
+		if err != nil {
+			return nil, err
+		}
+		payload.WriteString(familyStr)
+	}
+	data := []byte(payload.String())
+	record := make([]byte, walHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:walHeaderSize], crc32.ChecksumIEEE(data))
+	copy(record[walHeaderSize:], data)
+	return record, nil
+}
+
+// write appends a record to the log without syncing it to disk
+func (w *writeAheadLog) write(record []byte) error {
+	_, err := w.file.Write(record)
+	return err
+}
+
+// sync flushes the records written so far to disk
+func (w *writeAheadLog) sync() error {
+	return w.file.Sync()
+}
+
+// reset drops all records once their datapoints have been scraped
+func (w *writeAheadLog) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.file.Seek(0, io.SeekStart)
+	return err
+}
+
+func (w *writeAheadLog) close() error {
+	return w.file.Close()
+}
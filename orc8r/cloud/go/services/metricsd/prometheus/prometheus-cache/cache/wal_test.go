@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWALSurvivesRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache := NewMetricCache(0, 10)
+	require.NoError(t, cache.EnableWAL(dir))
+	resp, err := receiveString(cache, sampleReceiveString)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, cache.wal.close())
+
+	// A new cache loads the unscraped datapoints
+	restarted := NewMetricCache(0, 10)
+	require.NoError(t, restarted.EnableWAL(dir))
+	assert.Equal(t, 14, restarted.stats.currentCountDatapoints)
+	assert.Equal(t, 14, int(getGaugeValue(restarted.internalMetrics[internalMetricCacheSize])))
+	assert.Len(t, restarted.metricFamiliesByName, 3)
+
+	// Scraping consumes the datapoints and truncates the log
+	scrapeCache(t, restarted)
+	require.NoError(t, restarted.wal.close())
+	restarted = NewMetricCache(0, 10)
+	require.NoError(t, restarted.EnableWAL(dir))
+	assert.Empty(t, restarted.metricFamiliesByName)
+	require.NoError(t, restarted.wal.close())
+}
+
+func TestWALDiscardsTornRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache := NewMetricCache(0, 10)
+	require.NoError(t, cache.EnableWAL(dir))
+	_, err = receiveString(cache, sampleReceiveString)
+	assert.NoError(t, err)
+	_, err = receiveString(cache, sampleReceiveString)
+	assert.NoError(t, err)
+	require.NoError(t, cache.wal.close())
+
+	// Cut the last record in half as if the cache crashed while writing it
+	path := filepath.Join(dir, walFileName)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-20))
+
+	restarted := NewMetricCache(0, 10)
+	require.NoError(t, restarted.EnableWAL(dir))
+	assert.Equal(t, 14, restarted.stats.currentCountDatapoints)
+	truncatedInfo, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size()/2, truncatedInfo.Size())
+
+	// New records are appended after the last complete one
+	_, err = receiveString(restarted, sampleReceiveString)
+	assert.NoError(t, err)
+	require.NoError(t, restarted.wal.close())
+	restarted = NewMetricCache(0, 10)
+	require.NoError(t, restarted.EnableWAL(dir))
+	assert.Equal(t, 28, restarted.stats.currentCountDatapoints)
+	require.NoError(t, restarted.wal.close())
+}
+
+func TestWALReplaysNetworksMovedToOtherShards(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Pick the instance which won't own the metrics, which have no network
+	// label, once a second instance is added
+	instances := []string{"cache-0", "cache-1"}
+	self := instances[0]
+	if NewHashRing(instances, 10).Get("") == self {
+		self = instances[1]
+	}
+	shard, err := NewShard(self, []string{self}, 10)
+	require.NoError(t, err)
+	cache := NewMetricCache(0, 10)
+	cache.SetShard(shard)
+	require.NoError(t, cache.EnableWAL(dir))
+	resp, err := receiveString(cache, sampleReceiveString)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, cache.wal.close())
+
+	// The networks were moved to a new shard before the datapoints were
+	// scraped, they're still scraped from the shard which received them
+	shard, err = NewShard(self, instances, 10)
+	require.NoError(t, err)
+	require.False(t, shard.Owns(&dto.Metric{}))
+	restarted := NewMetricCache(0, 10)
+	restarted.SetShard(shard)
+	require.NoError(t, restarted.EnableWAL(dir))
+	assert.Equal(t, 14, restarted.stats.currentCountDatapoints)
+	rec := scrapeCache(t, restarted)
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	require.NoError(t, err)
+	assert.Len(t, families["http_requests_total"].GetMetric(), 5)
+	require.NoError(t, restarted.wal.close())
+}
+
+func scrapeCache(t *testing.T, cache *MetricCache) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, cache.Scrape(c))
+	return rec
+}
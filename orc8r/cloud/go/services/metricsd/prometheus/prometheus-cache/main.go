@@ -12,9 +12,11 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"magma/orc8r/cloud/go/services/metricsd/prometheus/prometheus-cache/cache"
 
+	"github.com/golang/glog"
 	"github.com/labstack/echo"
 )
 
@@ -28,9 +30,26 @@ func main() {
 	port := flag.String("port", defaultPort, fmt.Sprintf("Port to listen for requests. Default is %s", defaultPort))
 	totalMetricsLimit := flag.Int("limit", defaultLimit, fmt.Sprintf("Limit the total metrics in the cache at one time. Will reject a push if cache is full. Default is %d which is no limit.", defaultLimit))
 	scrapeTimeout := flag.Int("scrapeTimeout", defaultScrapeTimeout, fmt.Sprintf("Timeout for scrape calls. Default is %d", defaultScrapeTimeout))
+	walDir := flag.String("walDir", "", "Directory of the write-ahead log persisting unscraped metrics across restarts. Default is no persistence.")
+	shardID := flag.String("shardID", "", "ID of this cache instance in the list of shards. Default is no sharding.")
+	shards := flag.String("shards", "", "Comma separated IDs of all cache instances sharing metrics by network")
+	shardReplicas := flag.Int("shardReplicas", cache.DefaultShardReplicas, fmt.Sprintf("Number of points of each shard on the consistent hash ring, must match metricsd's. Default is %d", cache.DefaultShardReplicas))
 	flag.Parse()
 
 	metricCache := cache.NewMetricCache(*totalMetricsLimit, *scrapeTimeout)
+	if *shardID != "" {
+		shard, err := cache.NewShard(*shardID, strings.Split(*shards, ","), *shardReplicas)
+		if err != nil {
+			glog.Fatalf("Error creating shard: %v", err)
+		}
+		metricCache.SetShard(shard)
+	}
+	if *walDir != "" {
+		err := metricCache.EnableWAL(*walDir)
+		if err != nil {
+			glog.Fatalf("Error enabling write-ahead log: %v", err)
+		}
+	}
 	e := echo.New()
 
 	e.POST("/metrics", metricCache.Receive)
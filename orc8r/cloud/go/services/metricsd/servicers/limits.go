@@ -0,0 +1,374 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/metrics"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/golang/glog"
+	prometheusProto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// OverLimitDrop drops series which exceed a limit
+	OverLimitDrop = "drop"
+	// OverLimitAggregate merges series which exceed a limit into a single
+	// series per metric and gateway, stripped of all but the required labels
+	// and with their values summed. Summaries and histograms can't be merged
+	// and are dropped.
+	OverLimitAggregate = "aggregate"
+
+	// AggregatedLabelName marks series aggregated from over limit series
+	AggregatedLabelName = "cardinality_limited"
+
+	defaultSeriesTTL = time.Hour
+	// inlineExpiryInterval is the minimum time between two expiries of a
+	// network's series triggered by a series exceeding a limit
+	inlineExpiryInterval = time.Minute
+
+	dropReasonNetworkLimit = "network_limit"
+	dropReasonMetricLimit  = "metric_limit"
+)
+
+var invalidLabelNameChars = regexp.MustCompile("[^a-zA-Z0-9_]")
+
+// requiredLabels are added by metricsd itself and are never filtered out
+var requiredLabels = map[string]bool{
+	metrics.NetworkLabelName:   true,
+	metrics.GatewayLabelName:   true,
+	metrics.CloudHostLabelName: true,
+}
+
+// CardinalityLimits bound the number of series each network can export,
+// to protect the metrics backends shared by all networks from gateways
+// emitting high cardinality labels. Zero limits are unlimited.
+type CardinalityLimits struct {
+	// MaxSeriesPerNetwork is the maximum number of active series of a network
+	MaxSeriesPerNetwork int
+	// MaxSeriesPerMetric is the maximum number of active series of a network
+	// for any single metric
+	MaxSeriesPerMetric int
+	// MetricLimits overrides MaxSeriesPerMetric for individual metrics
+	MetricLimits map[string]int
+	// LabelAllowList, if not empty, is the set of labels kept on metrics
+	LabelAllowList []string
+	// LabelDenyList is the set of labels removed from metrics
+	LabelDenyList []string
+	// MaxLabelValueLength truncates longer label values
+	MaxLabelValueLength int
+	// OverLimitAction is either OverLimitDrop (default) or OverLimitAggregate
+	OverLimitAction string
+	// SeriesTTL is how long a series counts as active after it was last seen
+	SeriesTTL time.Duration
+}
+
+// CardinalityLimiter sanitizes the labels of gateway metrics and enforces
+// CardinalityLimits on them before they are exported
+type CardinalityLimiter struct {
+	limits      CardinalityLimits
+	allowLabels map[string]bool
+	denyLabels  map[string]bool
+	// active series by network ID
+	networks map[string]*networkSeries
+	sync.Mutex
+}
+
+type networkSeries struct {
+	// series key -> series
+	series map[string]*activeSeries
+	// metric name -> number of active series
+	seriesPerMetric map[string]int
+	// lastExpiry is when the network's inactive series were last expired
+	lastExpiry time.Time
+}
+
+type activeSeries struct {
+	metricName string
+	lastSeen   time.Time
+}
+
+// NewCardinalityLimiter creates a limiter enforcing the given limits
+func NewCardinalityLimiter(limits CardinalityLimits) (*CardinalityLimiter, error) {
+	switch limits.OverLimitAction {
+	case "":
+		limits.OverLimitAction = OverLimitDrop
+	case OverLimitDrop, OverLimitAggregate:
+	default:
+		return nil, fmt.Errorf("invalid over limit action %s", limits.OverLimitAction)
+	}
+	if limits.SeriesTTL <= 0 {
+		limits.SeriesTTL = defaultSeriesTTL
+	}
+	return &CardinalityLimiter{
+		limits:      limits,
+		allowLabels: toSet(limits.LabelAllowList),
+		denyLabels:  toSet(limits.LabelDenyList),
+		networks:    map[string]*networkSeries{},
+	}, nil
+}
+
+// Apply sanitizes the labels of the network's metrics and removes or
+// aggregates the series which exceed the limits. Families left without
+// metrics are removed.
+func (l *CardinalityLimiter) Apply(networkID string, metricsToSubmit []exporters.MetricAndContext) []exporters.MetricAndContext {
+	l.Lock()
+	defer l.Unlock()
+
+	now := clock.Now()
+	ret := make([]exporters.MetricAndContext, 0, len(metricsToSubmit))
+	for _, metricAndContext := range metricsToSubmit {
+		family := metricAndContext.Family
+		metricName := metricAndContext.Context.MetricName
+		admitted := make([]*prometheusProto.Metric, 0, len(family.Metric))
+		aggregates := map[string]*prometheusProto.Metric{}
+		for _, metric := range family.Metric {
+			l.sanitizeLabels(metric)
+			reason := l.admit(networkID, metricName, seriesKey(metricName, metric.Label), now)
+			if reason == "" {
+				admitted = append(admitted, metric)
+				continue
+			}
+			if l.limits.OverLimitAction == OverLimitAggregate && aggregateMetric(aggregates, family.GetType(), metric) {
+				aggregatedSeries.WithLabelValues(networkID).Inc()
+				continue
+			}
+			droppedSeries.WithLabelValues(networkID, reason).Inc()
+			glog.V(2).Infof("Dropping series of metric %s of network %s: %s", metricName, networkID, reason)
+		}
+		for _, aggregate := range aggregates {
+			admitted = append(admitted, aggregate)
+		}
+		if len(admitted) == 0 {
+			continue
+		}
+		family.Metric = admitted
+		ret = append(ret, metricAndContext)
+	}
+	return ret
+}
+
+// sanitizeLabels removes filtered labels, replaces characters which are
+// invalid in label names and truncates long label values. A label whose
+// sanitized name collides with another label's name is removed, labels with
+// valid names take precedence over sanitized ones.
+func (l *CardinalityLimiter) sanitizeLabels(metric *prometheusProto.Metric) {
+	names := make(map[string]bool, len(metric.Label))
+	for _, label := range metric.Label {
+		if sanitizeLabelName(label.GetName()) == label.GetName() {
+			names[label.GetName()] = true
+		}
+	}
+	labels := make([]*prometheusProto.LabelPair, 0, len(metric.Label))
+	for _, label := range metric.Label {
+		name := sanitizeLabelName(label.GetName())
+		if name != label.GetName() {
+			if names[name] {
+				glog.V(2).Infof("Dropping label %s which collides with label %s", label.GetName(), name)
+				continue
+			}
+			names[name] = true
+		}
+		if !requiredLabels[name] {
+			if l.denyLabels[name] || (len(l.allowLabels) > 0 && !l.allowLabels[name]) {
+				continue
+			}
+		}
+		value := label.GetValue()
+		if l.limits.MaxLabelValueLength > 0 && len(value) > l.limits.MaxLabelValueLength {
+			value = value[:l.limits.MaxLabelValueLength]
+		}
+		labels = append(labels, &prometheusProto.LabelPair{Name: &name, Value: &value})
+	}
+	metric.Label = labels
+}
+
+// admit registers the series as active and returns an empty string, or
+// returns the reason the series exceeds the limits. Only series subject to a
+// limit are registered.
+func (l *CardinalityLimiter) admit(networkID, metricName, key string, now time.Time) string {
+	if !l.isLimited(metricName) {
+		return ""
+	}
+	network, ok := l.networks[networkID]
+	if !ok {
+		network = &networkSeries{series: map[string]*activeSeries{}, seriesPerMetric: map[string]int{}}
+		l.networks[networkID] = network
+	}
+	if series, ok := network.series[key]; ok {
+		series.lastSeen = now
+		return ""
+	}
+
+	reason := l.exceededLimit(network, metricName)
+	if reason != "" && now.Sub(network.lastExpiry) >= inlineExpiryInterval {
+		// Make room by expiring series which haven't been seen for a while.
+		// This scans all of the network's series under the lock, so it's
+		// done at most once per interval, the rest is left to
+		// RunSeriesExpiry.
+		l.expireSeries(network, now)
+		reason = l.exceededLimit(network, metricName)
+	}
+	if reason != "" {
+		return reason
+	}
+	network.series[key] = &activeSeries{metricName: metricName, lastSeen: now}
+	network.seriesPerMetric[metricName]++
+	return ""
+}
+
+// isLimited returns true if the metric's series count towards a limit
+func (l *CardinalityLimiter) isLimited(metricName string) bool {
+	return l.limits.MaxSeriesPerNetwork > 0 || l.getMetricLimit(metricName) > 0
+}
+
+func (l *CardinalityLimiter) getMetricLimit(metricName string) int {
+	if limit, ok := l.limits.MetricLimits[metricName]; ok {
+		return limit
+	}
+	return l.limits.MaxSeriesPerMetric
+}
+
+func (l *CardinalityLimiter) exceededLimit(network *networkSeries, metricName string) string {
+	if l.limits.MaxSeriesPerNetwork > 0 && len(network.series) >= l.limits.MaxSeriesPerNetwork {
+		return dropReasonNetworkLimit
+	}
+	metricLimit := l.getMetricLimit(metricName)
+	if metricLimit > 0 && network.seriesPerMetric[metricName] >= metricLimit {
+		return dropReasonMetricLimit
+	}
+	return ""
+}
+
+// RunSeriesExpiry periodically expires the series which haven't been seen for
+// the series TTL, so networks don't hold on to the series they stopped
+// sending. Should be run in a goroutine as this blocks forever.
+func (l *CardinalityLimiter) RunSeriesExpiry() {
+	ticker := time.NewTicker(l.limits.SeriesTTL / 2)
+	defer ticker.Stop()
+	for range ticker.C {
+		l.expireAllSeries(clock.Now())
+	}
+}
+
+// expireAllSeries expires the inactive series of all networks and removes
+// the networks left without series
+func (l *CardinalityLimiter) expireAllSeries(now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	for networkID, network := range l.networks {
+		l.expireSeries(network, now)
+		if len(network.series) == 0 {
+			delete(l.networks, networkID)
+		}
+	}
+}
+
+func (l *CardinalityLimiter) expireSeries(network *networkSeries, now time.Time) {
+	network.lastExpiry = now
+	for key, series := range network.series {
+		if now.Sub(series.lastSeen) < l.limits.SeriesTTL {
+			continue
+		}
+		delete(network.series, key)
+		network.seriesPerMetric[series.metricName]--
+		if network.seriesPerMetric[series.metricName] <= 0 {
+			delete(network.seriesPerMetric, series.metricName)
+		}
+	}
+}
+
+// aggregateMetric adds the over limit metric's value to the aggregate series
+// of its required labels, it returns false if the metric can't be aggregated
+func aggregateMetric(
+	aggregates map[string]*prometheusProto.Metric,
+	metricType prometheusProto.MetricType,
+	metric *prometheusProto.Metric,
+) bool {
+	var value float64
+	switch metricType {
+	case prometheusProto.MetricType_COUNTER:
+		value = metric.GetCounter().GetValue()
+	case prometheusProto.MetricType_GAUGE:
+		value = metric.GetGauge().GetValue()
+	case prometheusProto.MetricType_UNTYPED:
+		value = metric.GetUntyped().GetValue()
+	default:
+		return false
+	}
+
+	labels := []*prometheusProto.LabelPair{}
+	for _, label := range metric.Label {
+		if requiredLabels[label.GetName()] {
+			labels = append(labels, label)
+		}
+	}
+	labels = append(labels, &prometheusProto.LabelPair{Name: makeStringPointer(AggregatedLabelName), Value: makeStringPointer("true")})
+	key := seriesKey("", labels)
+
+	aggregate, ok := aggregates[key]
+	if !ok {
+		aggregate = &prometheusProto.Metric{Label: labels}
+		switch metricType {
+		case prometheusProto.MetricType_COUNTER:
+			aggregate.Counter = &prometheusProto.Counter{Value: new(float64)}
+		case prometheusProto.MetricType_GAUGE:
+			aggregate.Gauge = &prometheusProto.Gauge{Value: new(float64)}
+		default:
+			aggregate.Untyped = &prometheusProto.Untyped{Value: new(float64)}
+		}
+		aggregates[key] = aggregate
+	}
+	switch metricType {
+	case prometheusProto.MetricType_COUNTER:
+		*aggregate.Counter.Value += value
+	case prometheusProto.MetricType_GAUGE:
+		*aggregate.Gauge.Value += value
+	default:
+		*aggregate.Untyped.Value += value
+	}
+	if metric.GetTimestampMs() > aggregate.GetTimestampMs() {
+		aggregate.TimestampMs = metric.TimestampMs
+	}
+	return true
+}
+
+// seriesKey identifies a series by its metric name and sorted labels
+func seriesKey(metricName string, labels []*prometheusProto.LabelPair) string {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%s{%s}", metricName, strings.Join(pairs, ","))
+}
+
+func sanitizeLabelName(name string) string {
+	name = invalidLabelNameChars.ReplaceAllString(name, "_")
+	if len(name) == 0 || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+func toSet(list []string) map[string]bool {
+	ret := make(map[string]bool, len(list))
+	for _, s := range list {
+		ret[s] = true
+	}
+	return ret
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/metricsd/confignames"
+)
+
+const (
+	maxSeriesPerNetworkConfigKey = "maxSeriesPerNetwork"
+	maxSeriesPerMetricConfigKey  = "maxSeriesPerMetric"
+	metricLimitsConfigKey        = "metricLimits"
+	labelAllowListConfigKey      = "labelAllowList"
+	labelDenyListConfigKey       = "labelDenyList"
+	maxLabelValueLengthConfigKey = "maxLabelValueLength"
+	overLimitActionConfigKey     = "overLimitAction"
+	seriesTTLConfigKey           = "seriesTTLSecs"
+)
+
+// GetCardinalityLimits reads the cardinality limits from the metricsd config,
+// it returns nil if no limits are configured.
+//
+//	cardinalityLimits:
+//	  maxSeriesPerNetwork: 100000
+//	  maxSeriesPerMetric: 10000
+//	  metricLimits:
+//	    ue_connected: 1000
+//	  labelDenyList: ["imsi", "ip_addr"]
+//	  maxLabelValueLength: 256
+//	  overLimitAction: "aggregate"
+//	  seriesTTLSecs: 3600
+func GetCardinalityLimits(cfg *config.ConfigMap) (*CardinalityLimits, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	raw, found := cfg.RawMap[confignames.CardinalityLimits]
+	if !found || raw == nil {
+		return nil, nil
+	}
+	rawMap, ok := raw.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s config must be a map", confignames.CardinalityLimits)
+	}
+	limitsCfg := config.NewConfigMap(rawMap)
+
+	ret := &CardinalityLimits{}
+	ints := map[string]*int{
+		maxSeriesPerNetworkConfigKey: &ret.MaxSeriesPerNetwork,
+		maxSeriesPerMetricConfigKey:  &ret.MaxSeriesPerMetric,
+		maxLabelValueLengthConfigKey: &ret.MaxLabelValueLength,
+	}
+	for key, dst := range ints {
+		if _, found := rawMap[key]; !found {
+			continue
+		}
+		val, err := limitsCfg.GetIntParam(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", key, err)
+		}
+		*dst = val
+	}
+	if _, found := rawMap[seriesTTLConfigKey]; found {
+		secs, err := limitsCfg.GetIntParam(seriesTTLConfigKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", seriesTTLConfigKey, err)
+		}
+		ret.SeriesTTL = time.Duration(secs) * time.Second
+	}
+	if _, found := rawMap[overLimitActionConfigKey]; found {
+		action, err := limitsCfg.GetStringParam(overLimitActionConfigKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", overLimitActionConfigKey, err)
+		}
+		ret.OverLimitAction = action
+	}
+
+	lists := map[string]*[]string{
+		labelAllowListConfigKey: &ret.LabelAllowList,
+		labelDenyListConfigKey:  &ret.LabelDenyList,
+	}
+	for key, dst := range lists {
+		if _, found := rawMap[key]; !found {
+			continue
+		}
+		list, err := limitsCfg.GetStringArrayParam(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", key, err)
+		}
+		*dst = list
+	}
+
+	if rawLimits, found := rawMap[metricLimitsConfigKey]; found {
+		metricLimits, ok := rawLimits.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a map of metric name to series limit", metricLimitsConfigKey)
+		}
+		ret.MetricLimits = make(map[string]int, len(metricLimits))
+		for rawName, rawLimit := range metricLimits {
+			name, ok := rawName.(string)
+			limit, ok2 := rawLimit.(int)
+			if !ok || !ok2 {
+				return nil, fmt.Errorf("invalid %s entry %v: %v", metricLimitsConfigKey, rawName, rawLimit)
+			}
+			ret.MetricLimits[name] = limit
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,264 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/metrics"
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	prometheusProto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityLimiter_SanitizeLabels(t *testing.T) {
+	limiter, err := NewCardinalityLimiter(CardinalityLimits{
+		LabelDenyList:       []string{"imsi", metrics.NetworkLabelName},
+		MaxLabelValueLength: 4,
+	})
+	require.NoError(t, err)
+
+	res := limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI001", "ip-addr", "10.0.0.1", "apn", "internet"),
+	})
+	require.Len(t, res, 1)
+	labels := res[0].Family.Metric[0].Label
+	assert.Len(t, labels, 3)
+	assert.True(t, tests.HasLabel(labels, "ip_addr", "10.0"))
+	assert.True(t, tests.HasLabel(labels, "apn", "inte"))
+	// Required labels aren't filtered
+	assert.True(t, tests.HasLabel(labels, metrics.NetworkLabelName, "net1"))
+
+	limiter, err = NewCardinalityLimiter(CardinalityLimits{LabelAllowList: []string{"apn"}})
+	require.NoError(t, err)
+	res = limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI001", "apn", "internet"),
+	})
+	require.Len(t, res, 1)
+	labels = res[0].Family.Metric[0].Label
+	assert.Len(t, labels, 2)
+	assert.True(t, tests.HasLabel(labels, "apn", "internet"))
+	assert.True(t, tests.HasLabel(labels, metrics.NetworkLabelName, "net1"))
+}
+
+func TestCardinalityLimiter_SanitizedLabelCollisions(t *testing.T) {
+	limiter, err := NewCardinalityLimiter(CardinalityLimits{})
+	require.NoError(t, err)
+
+	// Labels with valid names take precedence, otherwise the first label wins
+	res := limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "ip-addr", "10.0.0.1", "ip_addr", "10.0.0.2", "apn-name", "a", "apn.name", "b"),
+	})
+	require.Len(t, res, 1)
+	labels := res[0].Family.Metric[0].Label
+	assert.Len(t, labels, 3)
+	assert.True(t, tests.HasLabel(labels, "ip_addr", "10.0.0.2"))
+	assert.True(t, tests.HasLabel(labels, "apn_name", "a"))
+	assert.True(t, tests.HasLabel(labels, metrics.NetworkLabelName, "net1"))
+}
+
+func TestCardinalityLimiter_TrackedSeries(t *testing.T) {
+	limiter, err := NewCardinalityLimiter(CardinalityLimits{
+		MetricLimits: map[string]int{"metric_a": 10},
+		SeriesTTL:    time.Minute,
+	})
+	require.NoError(t, err)
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	// Only the series of limited metrics are tracked
+	res := limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI001"),
+		makeGaugeMetric("metric_b", 1, "imsi", "IMSI001"),
+	})
+	assert.Len(t, res, 2)
+	require.Contains(t, limiter.networks, "net1")
+	assert.Len(t, limiter.networks["net1"].series, 1)
+	assert.Equal(t, map[string]int{"metric_a": 1}, limiter.networks["net1"].seriesPerMetric)
+
+	// Inactive series and networks are expired
+	limiter.expireAllSeries(time.Unix(1000, 0).Add(time.Second))
+	assert.Contains(t, limiter.networks, "net1")
+	limiter.expireAllSeries(time.Unix(1000, 0).Add(time.Minute))
+	assert.Empty(t, limiter.networks)
+
+	limiter, err = NewCardinalityLimiter(CardinalityLimits{LabelDenyList: []string{"imsi"}})
+	require.NoError(t, err)
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_a", 1, "imsi", "IMSI001")})
+	assert.Len(t, res, 1)
+	assert.Empty(t, limiter.networks)
+}
+
+func TestCardinalityLimiter_Drop(t *testing.T) {
+	limiter, err := NewCardinalityLimiter(CardinalityLimits{
+		MaxSeriesPerNetwork: 4,
+		MaxSeriesPerMetric:  2,
+		MetricLimits:        map[string]int{"metric_c": 3},
+		SeriesTTL:           time.Minute,
+	})
+	require.NoError(t, err)
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	// metric_a is limited to 2 series
+	res := limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI001"),
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI002"),
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI003"),
+	})
+	assert.Len(t, res, 2)
+	assert.Equal(t, float64(1), getCounterValue(t, droppedSeries.WithLabelValues("net1", dropReasonMetricLimit)))
+
+	// Known series are still accepted, and limits are per network
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_a", 2, "imsi", "IMSI001")})
+	assert.Len(t, res, 1)
+	res = limiter.Apply("net2", []exporters.MetricAndContext{makeGaugeMetric("metric_a", 1, "imsi", "IMSI003")})
+	assert.Len(t, res, 1)
+
+	// metric_c has a higher limit, but the network limit of 4 series applies
+	res = limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_c", 1, "imsi", "IMSI001"),
+		makeGaugeMetric("metric_c", 1, "imsi", "IMSI002"),
+		makeGaugeMetric("metric_c", 1, "imsi", "IMSI003"),
+	})
+	assert.Len(t, res, 2)
+	assert.Equal(t, float64(1), getCounterValue(t, droppedSeries.WithLabelValues("net1", dropReasonNetworkLimit)))
+
+	// Series which aren't seen for the TTL expire
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0).Add(time.Minute))
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_c", 1, "imsi", "IMSI003")})
+	assert.Len(t, res, 1)
+
+	// Over limit series expire the network's series at most once per interval
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0).Add(2*time.Minute))
+	res = limiter.Apply("net1", []exporters.MetricAndContext{
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI001"),
+		makeGaugeMetric("metric_a", 1, "imsi", "IMSI002"),
+		makeGaugeMetric("metric_c", 1, "imsi", "IMSI004"),
+	})
+	assert.Len(t, res, 3)
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0).Add(150*time.Second))
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_c", 1, "imsi", "IMSI005")})
+	assert.Len(t, res, 1)
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0).Add(150*time.Second+inlineExpiryInterval/2))
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_a", 1, "imsi", "IMSI003")})
+	assert.Empty(t, res)
+	limiter.expireAllSeries(clock.Now())
+	res = limiter.Apply("net1", []exporters.MetricAndContext{makeGaugeMetric("metric_a", 1, "imsi", "IMSI003")})
+	assert.Len(t, res, 1)
+}
+
+func TestCardinalityLimiter_Aggregate(t *testing.T) {
+	limiter, err := NewCardinalityLimiter(CardinalityLimits{
+		MaxSeriesPerMetric: 1,
+		OverLimitAction:    OverLimitAggregate,
+	})
+	require.NoError(t, err)
+
+	res := limiter.Apply("net3", []exporters.MetricAndContext{{
+		Family: &prometheusProto.MetricFamily{
+			Name: tests.MakeStringPointer("metric_a"),
+			Type: tests.MakeMetricTypePointer(prometheusProto.MetricType_GAUGE),
+			Metric: []*prometheusProto.Metric{
+				makeGauge("net3", 1, "imsi", "IMSI001"),
+				makeGauge("net3", 2, "imsi", "IMSI002"),
+				makeGauge("net3", 3, "imsi", "IMSI003"),
+			},
+		},
+		Context: exporters.MetricsContext{MetricName: "metric_a"},
+	}})
+	require.Len(t, res, 1)
+	require.Len(t, res[0].Family.Metric, 2)
+	assert.True(t, tests.HasLabel(res[0].Family.Metric[0].Label, "imsi", "IMSI001"))
+	aggregate := res[0].Family.Metric[1]
+	assert.Equal(t, float64(5), aggregate.GetGauge().GetValue())
+	assert.Len(t, aggregate.Label, 2)
+	assert.True(t, tests.HasLabel(aggregate.Label, metrics.NetworkLabelName, "net3"))
+	assert.True(t, tests.HasLabel(aggregate.Label, AggregatedLabelName, "true"))
+	assert.Equal(t, float64(2), getCounterValue(t, aggregatedSeries.WithLabelValues("net3")))
+
+	// Histograms can't be aggregated
+	res = limiter.Apply("net3", []exporters.MetricAndContext{{
+		Family:  tests.MakeTestMetricFamily(prometheusProto.MetricType_HISTOGRAM, 1, nil),
+		Context: exporters.MetricsContext{MetricName: "metric_a"},
+	}})
+	assert.Empty(t, res)
+	assert.Equal(t, float64(1), getCounterValue(t, droppedSeries.WithLabelValues("net3", dropReasonMetricLimit)))
+
+	_, err = NewCardinalityLimiter(CardinalityLimits{OverLimitAction: "sample"})
+	assert.Error(t, err)
+}
+
+func TestGetCardinalityLimits(t *testing.T) {
+	limits, err := GetCardinalityLimits(config.NewConfigMap(map[interface{}]interface{}{}))
+	assert.NoError(t, err)
+	assert.Nil(t, limits)
+
+	limits, err = GetCardinalityLimits(config.NewConfigMap(map[interface{}]interface{}{
+		"cardinalityLimits": map[interface{}]interface{}{
+			"maxSeriesPerNetwork": 1000,
+			"metricLimits":        map[interface{}]interface{}{"metric_a": 10},
+			"labelDenyList":       []interface{}{"imsi"},
+			"overLimitAction":     "aggregate",
+			"seriesTTLSecs":       60,
+		},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, &CardinalityLimits{
+		MaxSeriesPerNetwork: 1000,
+		MetricLimits:        map[string]int{"metric_a": 10},
+		LabelDenyList:       []string{"imsi"},
+		OverLimitAction:     OverLimitAggregate,
+		SeriesTTL:           time.Minute,
+	}, limits)
+
+	_, err = GetCardinalityLimits(config.NewConfigMap(map[interface{}]interface{}{
+		"cardinalityLimits": map[interface{}]interface{}{"metricLimits": []interface{}{"metric_a"}},
+	}))
+	assert.Error(t, err)
+}
+
+// makeGaugeMetric creates a gauge family with a single metric of the given
+// label name/value pairs, labeled with the network it's limited for
+func makeGaugeMetric(name string, value float64, labels ...string) exporters.MetricAndContext {
+	return exporters.MetricAndContext{
+		Family: &prometheusProto.MetricFamily{
+			Name:   tests.MakeStringPointer(name),
+			Type:   tests.MakeMetricTypePointer(prometheusProto.MetricType_GAUGE),
+			Metric: []*prometheusProto.Metric{makeGauge("net1", value, labels...)},
+		},
+		Context: exporters.MetricsContext{MetricName: name},
+	}
+}
+
+func makeGauge(networkID string, value float64, labels ...string) *prometheusProto.Metric {
+	metric := &prometheusProto.Metric{Gauge: &prometheusProto.Gauge{Value: &value}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		metric.Label = append(metric.Label, &prometheusProto.LabelPair{
+			Name:  tests.MakeStringPointer(labels[i]),
+			Value: tests.MakeStringPointer(labels[i+1]),
+		})
+	}
+	addRequiredLabelToMetric(metric, metrics.NetworkLabelName, networkID)
+	return metric
+}
+
+func getCounterValue(t *testing.T, counter interface {
+	Write(*prometheusProto.Metric) error
+}) float64 {
+	metric := &prometheusProto.Metric{}
+	require.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
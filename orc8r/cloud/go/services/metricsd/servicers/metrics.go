@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"magma/orc8r/cloud/go/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	droppedSeries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_dropped_series_total",
+			Help: "Number of gateway series dropped for exceeding cardinality limits",
+		},
+		[]string{metrics.NetworkLabelName, "reason"},
+	)
+	aggregatedSeries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_aggregated_series_total",
+			Help: "Number of gateway series aggregated for exceeding cardinality limits",
+		},
+		[]string{metrics.NetworkLabelName},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(droppedSeries, aggregatedSeries)
+}
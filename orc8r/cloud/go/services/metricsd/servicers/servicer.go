@@ -20,12 +20,14 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd/exporters"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	prometheusProto "github.com/prometheus/client_model/go"
 	"golang.org/x/net/context"
 )
 
 type MetricsControllerServer struct {
 	exporters []exporters.Exporter
+	limiter   *CardinalityLimiter
 }
 
 func NewMetricsControllerServer() *MetricsControllerServer {
@@ -37,13 +39,8 @@ func (srv *MetricsControllerServer) Push(ctx context.Context, in *protos.PushedM
 		return new(protos.Void), nil
 	}
 
-	for _, e := range srv.exporters {
-		metricsToSubmit := pushedMetricsToMetricsAndContext(in)
-		err := e.Submit(metricsToSubmit)
-		if err != nil {
-			glog.Error(err)
-		}
-	}
+	metricsToSubmit := srv.applyLimits(in.NetworkId, pushedMetricsToMetricsAndContext(in))
+	srv.submitToExporters(metricsToSubmit)
 	return new(protos.Void), nil
 }
 
@@ -59,13 +56,8 @@ func (srv *MetricsControllerServer) Collect(ctx context.Context, in *protos.Metr
 	}
 	glog.V(2).Infof("collecting %v metrics from gateway %v\n", len(in.Family), in.GatewayId)
 
-	metricsToSubmit := metricsContainerToMetricAndContexts(in, networkID, gatewayID)
-	for _, e := range srv.exporters {
-		err := e.Submit(metricsToSubmit)
-		if err != nil {
-			glog.Error(err)
-		}
-	}
+	metricsToSubmit := srv.applyLimits(networkID, metricsContainerToMetricAndContexts(in, networkID, gatewayID))
+	srv.submitToExporters(metricsToSubmit)
 	return new(protos.Void), nil
 }
 
@@ -75,16 +67,38 @@ func (srv *MetricsControllerServer) Collect(ctx context.Context, in *protos.Metr
 func (srv *MetricsControllerServer) ConsumeCloudMetrics(inputChan chan *prometheusProto.MetricFamily, hostName string) error {
 	for family := range inputChan {
 		metricsToSubmit := preprocessCloudMetrics(family, hostName)
-		for _, e := range srv.exporters {
-			err := e.Submit([]exporters.MetricAndContext{metricsToSubmit})
-			if err != nil {
-				glog.Error(err)
-			}
-		}
+		srv.submitToExporters([]exporters.MetricAndContext{metricsToSubmit})
 	}
 	return nil
 }
 
+// submitToExporters submits the metrics to all exporters. Exporters may
+// modify the families they are submitted and keep them after Submit returns,
+// so each exporter gets its own copy.
+func (srv *MetricsControllerServer) submitToExporters(metricsToSubmit []exporters.MetricAndContext) {
+	for i, e := range srv.exporters {
+		exporterMetrics := metricsToSubmit
+		if i < len(srv.exporters)-1 {
+			exporterMetrics = copyMetrics(metricsToSubmit)
+		}
+		err := e.Submit(exporterMetrics)
+		if err != nil {
+			glog.Error(err)
+		}
+	}
+}
+
+func copyMetrics(metricsToCopy []exporters.MetricAndContext) []exporters.MetricAndContext {
+	ret := make([]exporters.MetricAndContext, 0, len(metricsToCopy))
+	for _, metricAndContext := range metricsToCopy {
+		ret = append(ret, exporters.MetricAndContext{
+			Family:  proto.Clone(metricAndContext.Family).(*prometheusProto.MetricFamily),
+			Context: metricAndContext.Context,
+		})
+	}
+	return ret
+}
+
 func preprocessCloudMetrics(family *prometheusProto.MetricFamily, hostName string) exporters.MetricAndContext {
 	ctx := exporters.MetricsContext{
 		MetricName: protos.GetDecodedName(family),
@@ -104,6 +118,22 @@ func (srv *MetricsControllerServer) RegisterExporter(e exporters.Exporter) []exp
 	return srv.exporters
 }
 
+// SetCardinalityLimiter sets the limiter applied to all gateway metrics
+// before they are exported
+func (srv *MetricsControllerServer) SetCardinalityLimiter(limiter *CardinalityLimiter) {
+	srv.limiter = limiter
+}
+
+func (srv *MetricsControllerServer) applyLimits(
+	networkID string,
+	metricsToSubmit []exporters.MetricAndContext,
+) []exporters.MetricAndContext {
+	if srv.limiter == nil {
+		return metricsToSubmit
+	}
+	return srv.limiter.Apply(networkID, metricsToSubmit)
+}
+
 func metricsContainerToMetricAndContexts(
 	in *protos.MetricsContainer,
 	networkID, gatewayID string,
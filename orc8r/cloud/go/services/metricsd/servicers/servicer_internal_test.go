@@ -78,3 +78,33 @@ func TestPushedMetricsToMetricsAndContext(t *testing.T) {
 	assert.True(t, tests.HasLabel(labels, metrics.NetworkLabelName, "testNetwork"))
 	assert.True(t, tests.HasLabel(labels, "labelName", "labelValue"))
 }
+
+type mutatingExporter struct {
+	submitted []exporters.MetricAndContext
+}
+
+func (e *mutatingExporter) Submit(metrics []exporters.MetricAndContext) error {
+	for _, metricAndContext := range metrics {
+		metricAndContext.Family.Name = tests.MakeStringPointer("renamed")
+	}
+	e.submitted = append(e.submitted, metrics...)
+	return nil
+}
+
+func (e *mutatingExporter) Start() {}
+
+func TestSubmitToExporters(t *testing.T) {
+	srv := NewMetricsControllerServer()
+	e1, e2 := &mutatingExporter{}, &mutatingExporter{}
+	srv.RegisterExporter(e1)
+	srv.RegisterExporter(e2)
+
+	family := tests.MakeTestMetricFamily(prometheusProto.MetricType_GAUGE, 1, testLabels)
+	srv.submitToExporters([]exporters.MetricAndContext{{Family: family}})
+
+	// Each exporter gets its own copy of the families
+	assert.Len(t, e1.submitted, 1)
+	assert.Len(t, e2.submitted, 1)
+	assert.False(t, e1.submitted[0].Family == e2.submitted[0].Family)
+	assert.False(t, e1.submitted[0].Family.Metric[0] == e2.submitted[0].Family.Metric[0])
+}
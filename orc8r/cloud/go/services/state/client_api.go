@@ -11,7 +11,6 @@ package state
 import (
 	"context"
 	"encoding/json"
-	"sync"
 
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/orc8r"
@@ -22,7 +21,6 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/thoas/go-funk"
-	"google.golang.org/grpc"
 )
 
 // State includes reported operational state and additional info about the reporter
@@ -46,33 +44,44 @@ type SerializedStateWithMeta struct {
 	Version                 uint64
 }
 
+// ExpiredStateEvent describes a state which was deleted because it wasn't
+// reported within the TTL of its type, as streamed by WatchExpiredStates.
+type ExpiredStateEvent struct {
+	NetworkID string
+	ID        StateID
+	// State is the expired state as it was last reported
+	State State
+}
+
 // StateID contains the identifying information of a state
 type StateID struct {
 	Type     string
 	DeviceID string
 }
 
-// Global clientconn that can be reused for this service
-var connSingleton = (*grpc.ClientConn)(nil)
-var connGuard = sync.Mutex{}
+// SearchFilter restricts the states returned by SearchStates. Zero-valued
+// fields don't restrict the search.
+type SearchFilter struct {
+	Types     []string
+	KeyPrefix string
+	// ReporterID is the hardware ID of the gateway which reported the state
+	ReporterID string
+	// UpdatedAfterMs and UpdatedBeforeMs bound the time the state was last
+	// reported, inclusive, in unix milliseconds
+	UpdatedAfterMs  uint64
+	UpdatedBeforeMs uint64
+}
 
+// GetStateClient returns a client for the state service. The underlying
+// connection is cached by the registry.
 func GetStateClient() (protos.StateServiceClient, error) {
-	if connSingleton == nil {
-		// Reading the conn optimistically to avoid unnecessary overhead
-		connGuard.Lock()
-		if connSingleton == nil {
-			conn, err := registry.GetConnection(ServiceName)
-			if err != nil {
-				initErr := errors.NewInitError(err, ServiceName)
-				glog.Error(initErr)
-				connGuard.Unlock()
-				return nil, initErr
-			}
-			connSingleton = conn
-		}
-		connGuard.Unlock()
+	conn, err := registry.GetConnection(ServiceName)
+	if err != nil {
+		initErr := errors.NewInitError(err, ServiceName)
+		glog.Error(initErr)
+		return nil, initErr
 	}
-	return protos.NewStateServiceClient(connSingleton), nil
+	return protos.NewStateServiceClient(conn), nil
 }
 
 // GetState returns the state specified by the networkID, typeVal, and hwID
@@ -135,6 +144,39 @@ func GetStates(networkID string, stateIDs []StateID) (map[StateID]State, error)
 	return idToValue, nil
 }
 
+// SearchStates returns a map of all states in the network which match the
+// filter
+func SearchStates(networkID string, filter SearchFilter) (map[StateID]State, error) {
+	client, err := GetStateClient()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.SearchStates(
+		context.Background(), &protos.SearchStatesRequest{
+			NetworkID:       networkID,
+			Types:           filter.Types,
+			KeyPrefix:       filter.KeyPrefix,
+			ReporterID:      filter.ReporterID,
+			UpdatedAfterMs:  filter.UpdatedAfterMs,
+			UpdatedBeforeMs: filter.UpdatedBeforeMs,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	idToValue := map[StateID]State{}
+	for _, pState := range res.States {
+		stateID := StateID{Type: pState.Type, DeviceID: pState.DeviceID}
+		state, err := toState(pState)
+		if err != nil {
+			return nil, err
+		}
+		idToValue[stateID] = state
+	}
+	return idToValue, nil
+}
+
 // DeleteStates deletes states specified by the networkID and a list of type and key
 func DeleteStates(networkID string, stateIDs []StateID) error {
 	client, err := GetStateClient()
@@ -151,6 +193,115 @@ func DeleteStates(networkID string, stateIDs []StateID) error {
 	return err
 }
 
+// GetStateHistory returns the previously reported values of the state
+// specified by the networkID, typeVal, and hwID, newest first. Only states
+// reported in the inclusive time range [startMs, endMs] are returned; an
+// endMs of 0 leaves the range unbounded above. At most limit states are
+// returned if limit is positive.
+// History is only kept for state types which have a retention policy
+// configured in the state service.
+func GetStateHistory(networkID string, typeVal string, hwID string, startMs uint64, endMs uint64, limit uint32) ([]State, error) {
+	client, err := GetStateClient()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.GetStateHistory(
+		context.Background(),
+		&protos.GetStateHistoryRequest{
+			NetworkID:   networkID,
+			Id:          &protos.StateID{Type: typeVal, DeviceID: hwID},
+			StartTimeMs: startMs,
+			EndTimeMs:   endMs,
+			Limit:       limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]State, 0, len(res.States))
+	for _, pState := range res.States {
+		state, err := toState(pState)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, state)
+	}
+	return ret, nil
+}
+
+// ExpiredStateWatch is an open stream of expired states, as returned by
+// OpenExpiredStateWatch.
+type ExpiredStateWatch struct {
+	stream protos.StateService_WatchExpiredStatesClient
+}
+
+// Recv blocks until the next expired state is received.
+func (w *ExpiredStateWatch) Recv() (ExpiredStateEvent, error) {
+	protoEvent, err := w.stream.Recv()
+	if err != nil {
+		return ExpiredStateEvent{}, err
+	}
+	state, err := toState(protoEvent.State)
+	if err != nil {
+		return ExpiredStateEvent{}, err
+	}
+	return ExpiredStateEvent{
+		NetworkID: protoEvent.NetworkID,
+		ID:        StateID{Type: protoEvent.State.Type, DeviceID: protoEvent.State.DeviceID},
+		State:     state,
+	}, nil
+}
+
+// OpenExpiredStateWatch opens a stream of the states which expire, optionally
+// filtered to a network and to the given state types. OpenExpiredStateWatch
+// returns once the watch is established: every state reaped after it returns
+// will be received, whichever state service instance reaped it. The stream
+// is closed when the context is cancelled.
+func OpenExpiredStateWatch(ctx context.Context, networkID string, types []string) (*ExpiredStateWatch, error) {
+	client, err := GetStateClient()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := client.WatchExpiredStates(ctx, &protos.WatchExpiredStatesRequest{NetworkID: networkID, Types: types})
+	if err != nil {
+		return nil, err
+	}
+	// The service sends the headers once the watch is established
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+	return &ExpiredStateWatch{stream: stream}, nil
+}
+
+// WatchExpiredStates streams every state which expires to the callback,
+// optionally filtered to a network and to the given state types. Only states
+// which expire after the stream is opened are streamed, see
+// OpenExpiredStateWatch.
+// WatchExpiredStates blocks until the context is cancelled, in which case it
+// returns nil, or until the stream or the callback returns an error.
+func WatchExpiredStates(ctx context.Context, networkID string, types []string, callback func(ExpiredStateEvent) error) error {
+	watch, err := OpenExpiredStateWatch(ctx, networkID, types)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	for {
+		event, err := watch.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+}
+
 func GetGatewayStatus(networkID string, deviceID string) (*models.GatewayStatus, error) {
 	state, err := GetState(networkID, orc8r.GatewayStateType, deviceID)
 	if err != nil {
@@ -176,6 +327,22 @@ func GetGatewayStatuses(networkID string, deviceIDs []string) (map[string]*model
 	return ret, nil
 }
 
+// GetGatewayStatusHistory returns the previously reported statuses of a
+// gateway, newest first. See GetStateHistory.
+func GetGatewayStatusHistory(networkID string, deviceID string, startMs uint64, endMs uint64, limit uint32) ([]*models.GatewayStatus, error) {
+	states, err := GetStateHistory(networkID, orc8r.GatewayStateType, deviceID, startMs, endMs, limit)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*models.GatewayStatus, 0, len(states))
+	for _, state := range states {
+		if gwStatus := fillInGatewayStatusState(state); gwStatus != nil {
+			ret = append(ret, gwStatus)
+		}
+	}
+	return ret, nil
+}
+
 func fillInGatewayStatusState(state State) *models.GatewayStatus {
 	if state.ReportedState == nil {
 		return nil
@@ -13,7 +13,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/orc8r"
 	models2 "magma/orc8r/cloud/go/pluginimpl/models"
@@ -25,11 +27,14 @@ import (
 	"magma/orc8r/cloud/go/services/device"
 	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
 	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/history"
 	stateTestInit "magma/orc8r/cloud/go/services/state/test_init"
 	"magma/orc8r/cloud/go/services/state/test_utils"
 
 	"github.com/golang/glog"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const testAgHwId = "Test-AGW-Hw-Id"
@@ -142,6 +147,235 @@ func TestStateService(t *testing.T) {
 	testGetStatesResponse(t, states, bundle0, bundle1)
 }
 
+func TestStateService_History(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	reaper := stateTestInit.StartTestServiceWithReaper(t, map[string]history.RetentionPolicy{
+		"test-serde": {MaxEntries: 2},
+	})
+	_ = serde.RegisterSerdes(
+		state.NewStateSerde("test-serde", &Name{}),
+		serde.NewBinarySerde(device.SerdeDomain, orc8r.AccessGatewayRecordType, &models2.GatewayDevice{}))
+	defer clock.UnfreezeClock(t)
+
+	networkID := "state_history_test_network"
+	configuratorTestUtils.RegisterNetwork(t, networkID, "State History Test")
+	configuratorTestUtils.RegisterGateway(t, networkID, testAgHwId, &models2.GatewayDevice{HardwareID: testAgHwId})
+	ctx := test_utils.GetContextWithCertificate(t, testAgHwId)
+
+	// Types without a retention policy don't keep history
+	reportTime := time.Now().Add(time.Minute).Truncate(time.Second)
+	reportTimeMs := uint64(reportTime.Unix()) * 1000
+	clock.SetAndFreezeClock(t, reportTime)
+	noHistory := makeStateBundle(orc8r.GatewayStateType, testAgHwId, &models2.GatewayStatus{})
+	_ = serde.RegisterSerdes(state.NewStateSerde(orc8r.GatewayStateType, &models2.GatewayStatus{}))
+	_, err := reportStates(ctx, noHistory)
+	assert.NoError(t, err)
+	states, err := state.GetStateHistory(networkID, orc8r.GatewayStateType, testAgHwId, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+
+	for i, name := range []string{"name0", "name1", "name2"} {
+		clock.SetAndFreezeClock(t, reportTime.Add(time.Duration(i)*time.Second))
+		_, err = reportStates(ctx, makeStateBundle("test-serde", "key0", Name{Name: name}))
+		assert.NoError(t, err)
+	}
+
+	// Oldest report is pruned past MaxEntries by the reaper
+	states, err = state.GetStateHistory(networkID, "test-serde", "key0", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, states, 3)
+	assert.NoError(t, reaper.ReapExpiredStates())
+	states, err = state.GetStateHistory(networkID, "test-serde", "key0", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.Equal(t, &Name{Name: "name2"}, states[0].ReportedState)
+	assert.Equal(t, reportTimeMs+2000, states[0].TimeMs)
+	assert.Equal(t, testAgHwId, states[0].ReporterID)
+	assert.Equal(t, &Name{Name: "name1"}, states[1].ReportedState)
+	assert.Equal(t, reportTimeMs+1000, states[1].TimeMs)
+
+	states, err = state.GetStateHistory(networkID, "test-serde", "key0", 0, reportTimeMs+1500, 0)
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	assert.Equal(t, &Name{Name: "name1"}, states[0].ReportedState)
+
+	// Bad time range
+	_, err = state.GetStateHistory(networkID, "test-serde", "key0", reportTimeMs, reportTimeMs-1, 0)
+	assert.Error(t, err)
+
+	// Deleting the state deletes its history
+	err = state.DeleteStates(networkID, []state.StateID{{Type: "test-serde", DeviceID: "key0"}})
+	assert.NoError(t, err)
+	states, err = state.GetStateHistory(networkID, "test-serde", "key0", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+}
+
+func TestStateService_Search(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	stateTestInit.StartTestService(t)
+	_ = serde.RegisterSerdes(
+		state.NewStateSerde("test-serde", &Name{}),
+		serde.NewBinarySerde(device.SerdeDomain, orc8r.AccessGatewayRecordType, &models2.GatewayDevice{}))
+	_ = serde.RegisterSerdes(state.NewStateSerde("test-serde-2", &Name{}))
+	defer clock.UnfreezeClock(t)
+
+	networkID := "state_search_test_network"
+	otherHwID := testAgHwId + "-2"
+	configuratorTestUtils.RegisterNetwork(t, networkID, "State Search Test")
+	configuratorTestUtils.RegisterGateway(t, networkID, testAgHwId, &models2.GatewayDevice{HardwareID: testAgHwId})
+	configuratorTestUtils.RegisterGateway(t, networkID, otherHwID, &models2.GatewayDevice{HardwareID: otherHwID})
+	ctx := test_utils.GetContextWithCertificate(t, testAgHwId)
+	otherCtx := test_utils.GetContextWithCertificate(t, otherHwID)
+
+	reportTime := time.Now().Add(time.Minute).Truncate(time.Second)
+	reportTimeMs := uint64(reportTime.Unix()) * 1000
+	clock.SetAndFreezeClock(t, reportTime)
+	_, err := reportStates(ctx,
+		makeStateBundle("test-serde", "gw1_a", Name{Name: "a"}),
+		makeStateBundle("test-serde-2", "gw1_b", Name{Name: "b"}),
+	)
+	assert.NoError(t, err)
+	clock.SetAndFreezeClock(t, reportTime.Add(10*time.Minute))
+	_, err = reportStates(otherCtx,
+		makeStateBundle("test-serde", "gw2_a", Name{Name: "c"}),
+	)
+	assert.NoError(t, err)
+
+	states, err := state.SearchStates(networkID, state.SearchFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, states, 3)
+
+	states, err = state.SearchStates(networkID, state.SearchFilter{Types: []string{"test-serde"}})
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.Equal(t, &Name{Name: "a"}, states[state.StateID{Type: "test-serde", DeviceID: "gw1_a"}].ReportedState)
+	assert.Equal(t, &Name{Name: "c"}, states[state.StateID{Type: "test-serde", DeviceID: "gw2_a"}].ReportedState)
+
+	states, err = state.SearchStates(networkID, state.SearchFilter{KeyPrefix: "gw1_"})
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.Contains(t, states, state.StateID{Type: "test-serde", DeviceID: "gw1_a"})
+	assert.Contains(t, states, state.StateID{Type: "test-serde-2", DeviceID: "gw1_b"})
+
+	states, err = state.SearchStates(networkID, state.SearchFilter{ReporterID: otherHwID})
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	assert.Equal(t, otherHwID, states[state.StateID{Type: "test-serde", DeviceID: "gw2_a"}].ReporterID)
+
+	// States which haven't been reported in the last 5 minutes
+	states, err = state.SearchStates(networkID, state.SearchFilter{UpdatedBeforeMs: reportTimeMs + 5*60*1000})
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.NotContains(t, states, state.StateID{Type: "test-serde", DeviceID: "gw2_a"})
+
+	states, err = state.SearchStates(networkID, state.SearchFilter{Types: []string{"test-serde"}, UpdatedAfterMs: reportTimeMs + 1})
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	assert.Contains(t, states, state.StateID{Type: "test-serde", DeviceID: "gw2_a"})
+
+	states, err = state.SearchStates("other_network", state.SearchFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+
+	// Bad time range and missing network
+	_, err = state.SearchStates(networkID, state.SearchFilter{UpdatedAfterMs: reportTimeMs, UpdatedBeforeMs: reportTimeMs - 1})
+	assert.Error(t, err)
+	_, err = state.SearchStates("", state.SearchFilter{})
+	assert.Error(t, err)
+}
+
+func TestStateService_Expiry(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	reaper := stateTestInit.StartTestServiceWithReaper(t, map[string]history.RetentionPolicy{
+		"expiring-serde": {MaxEntries: 10},
+	})
+	_ = serde.RegisterSerdes(
+		state.NewStateSerde("test-serde", &Name{}),
+		serde.NewBinarySerde(device.SerdeDomain, orc8r.AccessGatewayRecordType, &models2.GatewayDevice{}))
+	err := serde.RegisterSerdes(state.NewStateSerdeWithTTL("expiring-serde", &Name{}, time.Minute))
+	assert.NoError(t, err)
+	defer clock.UnfreezeClock(t)
+	assert.Equal(t, map[string]time.Duration{"expiring-serde": time.Minute}, state.GetStateTTLs())
+
+	networkID := "state_expiry_test_network"
+	configuratorTestUtils.RegisterNetwork(t, networkID, "State Expiry Test")
+	configuratorTestUtils.RegisterGateway(t, networkID, testAgHwId, &models2.GatewayDevice{HardwareID: testAgHwId})
+	ctx := test_utils.GetContextWithCertificate(t, testAgHwId)
+
+	reportTime := time.Now().Add(time.Minute).Truncate(time.Second)
+	clock.SetAndFreezeClock(t, reportTime)
+	expiring0 := makeStateBundle("expiring-serde", "key0", Name{Name: "name0"})
+	expiring1 := makeStateBundle("expiring-serde", "key1", Name{Name: "name1"})
+	permanent := makeStateBundle("test-serde", "key0", Name{Name: "name2"})
+	_, err = reportStates(ctx, expiring0, expiring1, permanent)
+	assert.NoError(t, err)
+	clock.SetAndFreezeClock(t, reportTime.Add(30*time.Second))
+	_, err = reportStates(ctx, expiring1)
+	assert.NoError(t, err)
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	watch, err := state.OpenExpiredStateWatch(watchCtx, networkID, []string{"expiring-serde"})
+	assert.NoError(t, err)
+	events := make(chan state.ExpiredStateEvent, 10)
+	watchDone := make(chan error, 1)
+	go func() {
+		for {
+			event, err := watch.Recv()
+			if err != nil {
+				watchDone <- err
+				return
+			}
+			events <- event
+		}
+	}()
+
+	// Nothing has expired yet
+	clock.SetAndFreezeClock(t, reportTime.Add(time.Minute))
+	assert.NoError(t, reaper.ReapExpiredStates())
+	states, err := state.GetStates(networkID, []state.StateID{expiring0.ID, expiring1.ID, permanent.ID})
+	assert.NoError(t, err)
+	assert.Len(t, states, 3)
+
+	// Only the state which wasn't reported again expires
+	clock.SetAndFreezeClock(t, reportTime.Add(time.Minute+time.Second))
+	assert.NoError(t, reaper.ReapExpiredStates())
+	states, err = state.GetStates(networkID, []state.StateID{expiring0.ID, expiring1.ID, permanent.ID})
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	testGetStatesResponse(t, states, expiring1, permanent)
+	history0, err := state.GetStateHistory(networkID, "expiring-serde", "key0", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, history0)
+	history1, err := state.GetStateHistory(networkID, "expiring-serde", "key1", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, history1, 2)
+
+	event := <-events
+	assert.Equal(t, networkID, event.NetworkID)
+	assert.Equal(t, expiring0.ID, event.ID)
+	assert.Equal(t, &Name{Name: "name0"}, event.State.ReportedState)
+	assert.Equal(t, uint64(reportTime.Unix())*1000, event.State.TimeMs)
+	assert.Equal(t, testAgHwId, event.State.ReporterID)
+
+	// States without a TTL never expire
+	clock.SetAndFreezeClock(t, reportTime.Add(time.Hour))
+	assert.NoError(t, reaper.ReapExpiredStates())
+	states, err = state.GetStates(networkID, []state.StateID{expiring0.ID, expiring1.ID, permanent.ID})
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	testGetStatesResponse(t, states, permanent)
+	event = <-events
+	assert.Equal(t, expiring1.ID, event.ID)
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(<-watchDone))
+	assert.Empty(t, events)
+}
+
 type NameAndAge struct {
 	// name
 	Name string `json:"name"`
@@ -0,0 +1,88 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package expiry
+
+import (
+	"sort"
+	"sync"
+
+	"magma/orc8r/cloud/go/storage"
+)
+
+type memoryStore struct {
+	sync.Mutex
+	expiriesByNetwork map[string]map[storage.TypeAndKey]uint64
+}
+
+// NewMemoryStore returns an expiry Store which keeps its entries in process
+// memory.
+func NewMemoryStore() Store {
+	return &memoryStore{expiriesByNetwork: map[string]map[storage.TypeAndKey]uint64{}}
+}
+
+func (m *memoryStore) Initialize() error {
+	return nil
+}
+
+func (m *memoryStore) Put(networkID string, entries []Entry) error {
+	m.Lock()
+	defer m.Unlock()
+	networkExpiries, ok := m.expiriesByNetwork[networkID]
+	if !ok {
+		networkExpiries = map[storage.TypeAndKey]uint64{}
+		m.expiriesByNetwork[networkID] = networkExpiries
+	}
+	for _, entry := range entries {
+		id := storage.TypeAndKey{Type: entry.Type, Key: entry.DeviceID}
+		if existing, exists := networkExpiries[id]; exists && existing > entry.ExpiresAtMs {
+			continue
+		}
+		networkExpiries[id] = entry.ExpiresAtMs
+	}
+	return nil
+}
+
+func (m *memoryStore) ListExpired(networkID string, nowMs uint64) ([]storage.TypeAndKey, error) {
+	m.Lock()
+	defer m.Unlock()
+	ret := []storage.TypeAndKey{}
+	for id, expiresAtMs := range m.expiriesByNetwork[networkID] {
+		if expiresAtMs < nowMs {
+			ret = append(ret, id)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Type != ret[j].Type {
+			return ret[i].Type < ret[j].Type
+		}
+		return ret[i].Key < ret[j].Key
+	})
+	return ret, nil
+}
+
+func (m *memoryStore) Delete(networkID string, ids []storage.TypeAndKey) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, id := range ids {
+		delete(m.expiriesByNetwork[networkID], id)
+	}
+	return nil
+}
+
+func (m *memoryStore) DeleteExpired(networkID string, ids []storage.TypeAndKey, nowMs uint64) error {
+	m.Lock()
+	defer m.Unlock()
+	networkExpiries := m.expiriesByNetwork[networkID]
+	for _, id := range ids {
+		if expiresAtMs, exists := networkExpiries[id]; exists && expiresAtMs < nowMs {
+			delete(networkExpiries, id)
+		}
+	}
+	return nil
+}
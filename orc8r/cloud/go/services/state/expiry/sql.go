@@ -0,0 +1,163 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package expiry
+
+import (
+	"database/sql"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TableName is the name of the SQL table storing the state expiry times
+	TableName = "states_expiry"
+
+	nidCol       = "network_id"
+	typeCol      = "type"
+	deviceIDCol  = "device_id"
+	expiresAtCol = "expires_at"
+)
+
+type sqlStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// NewSQLStore returns an expiry Store backed by the given SQL database.
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder) Store {
+	return &sqlStore{db: db, builder: builder}
+}
+
+func (store *sqlStore) Initialize() error {
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		_, err := store.builder.CreateTable(TableName).
+			IfNotExists().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(typeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(deviceIDCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(expiresAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			PrimaryKey(nidCol, typeCol, deviceIDCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create state expiry table")
+		}
+		_, err = store.builder.CreateIndex("states_expiry_time_idx").
+			IfNotExists().
+			On(TableName).
+			Columns(nidCol, expiresAtCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create state expiry time index")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlStore) Put(networkID string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		for _, entry := range entries {
+			res, err := store.builder.Update(TableName).
+				Set(expiresAtCol, entry.ExpiresAtMs).
+				Where(sq.And{
+					sq.Eq{nidCol: networkID, typeCol: entry.Type, deviceIDCol: entry.DeviceID},
+					sq.LtOrEq{expiresAtCol: entry.ExpiresAtMs},
+				}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to update expiry of state %s/%s", entry.Type, entry.DeviceID)
+			}
+			if n, err := res.RowsAffected(); err != nil || n > 0 {
+				continue
+			}
+			// Either the state has no entry yet or its entry expires later,
+			// in which case the insert is ignored
+			_, err = store.builder.Insert(TableName).
+				Columns(nidCol, typeCol, deviceIDCol, expiresAtCol).
+				Values(networkID, entry.Type, entry.DeviceID, entry.ExpiresAtMs).
+				OnConflict(nil, nidCol, typeCol, deviceIDCol).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to insert expiry of state %s/%s", entry.Type, entry.DeviceID)
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlStore) ListExpired(networkID string, nowMs uint64) ([]storage.TypeAndKey, error) {
+	rows, err := store.builder.Select(typeCol, deviceIDCol).
+		From(TableName).
+		Where(sq.And{sq.Eq{nidCol: networkID}, sq.Lt{expiresAtCol: nowMs}}).
+		OrderBy(typeCol, deviceIDCol).
+		RunWith(store.db).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query expired states")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "ListExpired")
+
+	ret := []storage.TypeAndKey{}
+	for rows.Next() {
+		id := storage.TypeAndKey{}
+		if err = rows.Scan(&id.Type, &id.Key); err != nil {
+			return nil, errors.Wrap(err, "failed to scan state expiry row")
+		}
+		ret = append(ret, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over state expiry rows")
+	}
+	return ret, nil
+}
+
+func (store *sqlStore) Delete(networkID string, ids []storage.TypeAndKey) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return store.delete(sq.And{sq.Eq{nidCol: networkID}, getIDsWhere(ids)})
+}
+
+func (store *sqlStore) DeleteExpired(networkID string, ids []storage.TypeAndKey, nowMs uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return store.delete(sq.And{sq.Eq{nidCol: networkID}, sq.Lt{expiresAtCol: nowMs}, getIDsWhere(ids)})
+}
+
+func (store *sqlStore) delete(where sq.Sqlizer) error {
+	_, err := store.builder.Delete(TableName).
+		Where(where).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete state expiry entries")
+	}
+	return nil
+}
+
+func getIDsWhere(ids []storage.TypeAndKey) sq.Or {
+	where := sq.Or{}
+	for _, id := range ids {
+		where = append(where, sq.Eq{typeCol: id.Type, deviceIDCol: id.Key})
+	}
+	return where
+}
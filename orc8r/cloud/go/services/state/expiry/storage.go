@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package expiry contains the storage for the expiry times of reported
+// states. States are stored in the blobstore as opaque values, so the time
+// at which each state whose type has a TTL expires is also kept in a store
+// which the state reaper queries for the expired states, instead of reading
+// every state.
+package expiry
+
+import (
+	"magma/orc8r/cloud/go/storage"
+)
+
+// Entry holds the expiry time of a state.
+type Entry struct {
+	Type     string
+	DeviceID string
+	// ExpiresAtMs is the time at which the state expires if it isn't
+	// reported again, in unix milliseconds
+	ExpiresAtMs uint64
+}
+
+// Store holds the expiry times of the states whose type has a TTL.
+// The blobstore remains the source of truth: the reaper checks the report
+// time of a state against the TTL of its type before deleting it.
+type Store interface {
+	// Initialize creates any tables or resources needed by the store.
+	Initialize() error
+
+	// Put records the expiry times of states in a network. An entry only
+	// replaces the entry of its state if it expires at the same time or
+	// later, so entries written out of order don't bring expiries forward.
+	Put(networkID string, entries []Entry) error
+
+	// ListExpired returns the IDs of the states in a network which expired
+	// before nowMs, sorted by type, then key.
+	ListExpired(networkID string, nowMs uint64) ([]storage.TypeAndKey, error)
+
+	// Delete removes the entries of the given states.
+	Delete(networkID string, ids []storage.TypeAndKey) error
+
+	// DeleteExpired removes the entries of the given states which expired
+	// before nowMs. Entries which were pushed back since they were listed
+	// are kept.
+	DeleteExpired(networkID string, ids []storage.TypeAndKey, nowMs uint64) error
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package expiry_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/services/state/expiry"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, expiry.NewMemoryStore())
+}
+
+func TestSQLStore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	testStore(t, expiry.NewSQLStore(db, sqorc.GetSqlBuilder()))
+}
+
+func testStore(t *testing.T, store expiry.Store) {
+	assert.NoError(t, store.Initialize())
+
+	// Empty contract
+	ids, err := store.ListExpired("n1", 10000)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	assert.NoError(t, store.Put("n1", []expiry.Entry{
+		{Type: "t1", DeviceID: "gw1", ExpiresAtMs: 1000},
+		{Type: "t1", DeviceID: "gw2", ExpiresAtMs: 2000},
+		{Type: "t2", DeviceID: "gw1", ExpiresAtMs: 3000},
+	}))
+	assert.NoError(t, store.Put("n2", []expiry.Entry{{Type: "t1", DeviceID: "gw1", ExpiresAtMs: 1000}}))
+
+	ids, err = store.ListExpired("n1", 1000)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	ids, err = store.ListExpired("n1", 2001)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "t1", Key: "gw1"}, {Type: "t1", Key: "gw2"}}, ids)
+
+	// Later expiries replace earlier ones, earlier expiries are ignored
+	assert.NoError(t, store.Put("n1", []expiry.Entry{
+		{Type: "t1", DeviceID: "gw1", ExpiresAtMs: 4000},
+		{Type: "t1", DeviceID: "gw2", ExpiresAtMs: 1500},
+	}))
+	ids, err = store.ListExpired("n1", 3500)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "t1", Key: "gw2"}, {Type: "t2", Key: "gw1"}}, ids)
+
+	// Entries which didn't expire yet are kept by DeleteExpired
+	assert.NoError(t, store.DeleteExpired("n1", []storage.TypeAndKey{{Type: "t1", Key: "gw1"}, {Type: "t1", Key: "gw2"}}, 3500))
+	ids, err = store.ListExpired("n1", 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "t1", Key: "gw1"}, {Type: "t2", Key: "gw1"}}, ids)
+
+	assert.NoError(t, store.Delete("n1", []storage.TypeAndKey{{Type: "t1", Key: "gw1"}}))
+	ids, err = store.ListExpired("n1", 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "t2", Key: "gw1"}}, ids)
+	ids, err = store.ListExpired("n2", 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.TypeAndKey{{Type: "t1", Key: "gw1"}}, ids)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+)
+
+const (
+	historyConfigKey    = "history"
+	maxEntriesConfigKey = "max_entries"
+	maxAgeConfigKey     = "max_age_secs"
+)
+
+// GetRetentionPolicies reads the per-type retention policies from the state
+// service config. State types without a policy don't keep any history.
+//
+// The policies are read from a map under the "history" key:
+//
+//	history:
+//	  gw_state:
+//	    max_entries: 1440
+//	    max_age_secs: 86400
+func GetRetentionPolicies(cfg *config.ConfigMap) (map[string]RetentionPolicy, error) {
+	ret := map[string]RetentionPolicy{}
+	if cfg == nil {
+		return ret, nil
+	}
+	rawHistory, found := cfg.RawMap[historyConfigKey]
+	if !found || rawHistory == nil {
+		return ret, nil
+	}
+	historyMap, ok := rawHistory.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("state history config must be a map of state type to retention policy")
+	}
+
+	for rawType, rawPolicy := range historyMap {
+		stateType, ok := rawType.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid state type %v in state history config", rawType)
+		}
+		policyMap, ok := rawPolicy.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("retention policy for state type %s must be a map", stateType)
+		}
+		policyCfg := config.NewConfigMap(policyMap)
+
+		policy := RetentionPolicy{}
+		if _, found := policyMap[maxEntriesConfigKey]; found {
+			maxEntries, err := policyCfg.GetIntParam(maxEntriesConfigKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s for state type %s: %s", maxEntriesConfigKey, stateType, err)
+			}
+			policy.MaxEntries = maxEntries
+		}
+		if _, found := policyMap[maxAgeConfigKey]; found {
+			maxAgeSecs, err := policyCfg.GetIntParam(maxAgeConfigKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s for state type %s: %s", maxAgeConfigKey, stateType, err)
+			}
+			policy.MaxAge = time.Duration(maxAgeSecs) * time.Second
+		}
+		if policy.MaxEntries <= 0 && policy.MaxAge <= 0 {
+			return nil, fmt.Errorf("retention policy for state type %s must set a positive %s or %s", stateType, maxEntriesConfigKey, maxAgeConfigKey)
+		}
+		ret[stateType] = policy
+	}
+	return ret, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/state/history"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGetRetentionPolicies(t *testing.T) {
+	policies, err := history.GetRetentionPolicies(parseConfig(t, `foo: bar`))
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	policies, err = history.GetRetentionPolicies(parseConfig(t, `
+history:
+  gw_state:
+    max_entries: 10
+    max_age_secs: 60
+  other:
+    max_age_secs: 5
+`))
+	assert.NoError(t, err)
+	expected := map[string]history.RetentionPolicy{
+		"gw_state": {MaxEntries: 10, MaxAge: time.Minute},
+		"other":    {MaxAge: 5 * time.Second},
+	}
+	assert.Equal(t, expected, policies)
+
+	_, err = history.GetRetentionPolicies(parseConfig(t, `
+history:
+  gw_state: {}
+`))
+	assert.EqualError(t, err, "retention policy for state type gw_state must set a positive max_entries or max_age_secs")
+
+	_, err = history.GetRetentionPolicies(parseConfig(t, `
+history:
+  gw_state:
+    max_entries: lots
+`))
+	assert.Error(t, err)
+}
+
+func parseConfig(t *testing.T, contents string) *config.ConfigMap {
+	rawMap := map[interface{}]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(contents), &rawMap))
+	return config.NewConfigMap(rawMap)
+}
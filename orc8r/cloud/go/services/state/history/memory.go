@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/storage"
+)
+
+type stateKey struct {
+	networkID string
+	id        storage.TypeAndKey
+}
+
+type memoryStore struct {
+	sync.Mutex
+	// entriesByState holds the history of each state sorted by report time,
+	// newest first
+	entriesByState map[stateKey][]Entry
+}
+
+// NewMemoryStore returns a history Store which keeps its entries in process
+// memory.
+func NewMemoryStore() Store {
+	return &memoryStore{entriesByState: map[stateKey][]Entry{}}
+}
+
+func (m *memoryStore) Initialize() error {
+	return nil
+}
+
+func (m *memoryStore) Append(networkID string, entries []Entry) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, entry := range entries {
+		sk := stateKey{networkID: networkID, id: storage.TypeAndKey{Type: entry.Type, Key: entry.DeviceID}}
+		stateEntries := m.entriesByState[sk]
+		entry.Value = copyBytes(entry.Value)
+
+		idx := sort.Search(len(stateEntries), func(i int) bool { return stateEntries[i].TimeMs <= entry.TimeMs })
+		if idx < len(stateEntries) && stateEntries[idx].TimeMs == entry.TimeMs {
+			stateEntries[idx] = entry
+			continue
+		}
+		stateEntries = append(stateEntries, Entry{})
+		copy(stateEntries[idx+1:], stateEntries[idx:])
+		stateEntries[idx] = entry
+		m.entriesByState[sk] = stateEntries
+	}
+	return nil
+}
+
+func (m *memoryStore) Get(networkID string, id storage.TypeAndKey, startMs uint64, endMs uint64, limit int) ([]Entry, error) {
+	m.Lock()
+	defer m.Unlock()
+	ret := []Entry{}
+	for _, entry := range m.entriesByState[stateKey{networkID: networkID, id: id}] {
+		if limit > 0 && len(ret) >= limit {
+			break
+		}
+		if entry.TimeMs < startMs || (endMs != 0 && entry.TimeMs > endMs) {
+			continue
+		}
+		entry.Value = copyBytes(entry.Value)
+		ret = append(ret, entry)
+	}
+	return ret, nil
+}
+
+func (m *memoryStore) Prune(networkID string, stateType string, policy RetentionPolicy, nowMs uint64) error {
+	m.Lock()
+	defer m.Unlock()
+	for sk, stateEntries := range m.entriesByState {
+		if sk.networkID != networkID || sk.id.Type != stateType {
+			continue
+		}
+		if policy.MaxEntries > 0 && len(stateEntries) > policy.MaxEntries {
+			stateEntries = stateEntries[:policy.MaxEntries]
+		}
+		if policy.MaxAge > 0 {
+			cutoffMs := getCutoffMs(policy.MaxAge, nowMs)
+			idx := sort.Search(len(stateEntries), func(i int) bool { return stateEntries[i].TimeMs < cutoffMs })
+			stateEntries = stateEntries[:idx]
+		}
+		if len(stateEntries) == 0 {
+			delete(m.entriesByState, sk)
+			continue
+		}
+		m.entriesByState[sk] = stateEntries
+	}
+	return nil
+}
+
+func (m *memoryStore) Delete(networkID string, ids []storage.TypeAndKey) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, id := range ids {
+		delete(m.entriesByState, stateKey{networkID: networkID, id: id})
+	}
+	return nil
+}
+
+// getCutoffMs returns the report time before which entries are older than
+// maxAge.
+func getCutoffMs(maxAge time.Duration, nowMs uint64) uint64 {
+	maxAgeMs := uint64(maxAge / time.Millisecond)
+	if maxAgeMs > nowMs {
+		return 0
+	}
+	return nowMs - maxAgeMs
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	ret := make([]byte, len(b))
+	copy(ret, b)
+	return ret
+}
@@ -0,0 +1,221 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package history
+
+import (
+	"database/sql"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TableName is the name of the SQL table storing state history
+	TableName = "states_history"
+
+	nidCol      = "network_id"
+	typeCol     = "type"
+	deviceIDCol = "device_id"
+	timeCol     = "time_ms"
+	valCol      = "value"
+)
+
+type sqlStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// NewSQLStore returns a history Store backed by the given SQL database.
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder) Store {
+	return &sqlStore{db: db, builder: builder}
+}
+
+func (store *sqlStore) Initialize() error {
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		_, err := store.builder.CreateTable(TableName).
+			IfNotExists().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(typeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(deviceIDCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(timeCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(valCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+			PrimaryKey(nidCol, typeCol, deviceIDCol, timeCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create state history table")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlStore) Append(networkID string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		for _, entry := range entries {
+			_, err := store.builder.Insert(TableName).
+				Columns(nidCol, typeCol, deviceIDCol, timeCol, valCol).
+				Values(networkID, entry.Type, entry.DeviceID, entry.TimeMs, entry.Value).
+				OnConflict(
+					[]sqorc.UpsertValue{{Column: valCol, Value: entry.Value}},
+					nidCol, typeCol, deviceIDCol, timeCol,
+				).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to append history for state %s/%s", entry.Type, entry.DeviceID)
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (store *sqlStore) Get(networkID string, id storage.TypeAndKey, startMs uint64, endMs uint64, limit int) ([]Entry, error) {
+	query := store.builder.Select(timeCol, valCol).
+		From(TableName).
+		Where(sq.And{
+			sq.Eq{nidCol: networkID, typeCol: id.Type, deviceIDCol: id.Key},
+			sq.GtOrEq{timeCol: startMs},
+		}).
+		OrderBy(timeCol + " DESC")
+	if endMs != 0 {
+		query = query.Where(sq.LtOrEq{timeCol: endMs})
+	}
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+	rows, err := query.RunWith(store.db).Query()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query history for state %s", id)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "Get")
+
+	ret := []Entry{}
+	for rows.Next() {
+		entry := Entry{Type: id.Type, DeviceID: id.Key}
+		if err = rows.Scan(&entry.TimeMs, &entry.Value); err != nil {
+			return nil, errors.Wrap(err, "failed to scan state history row")
+		}
+		ret = append(ret, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over state history rows")
+	}
+	return ret, nil
+}
+
+func (store *sqlStore) Prune(networkID string, stateType string, policy RetentionPolicy, nowMs uint64) error {
+	if policy.MaxEntries <= 0 && policy.MaxAge <= 0 {
+		return nil
+	}
+	typeWhere := sq.Eq{nidCol: networkID, typeCol: stateType}
+	_, err := sqorc.ExecInTx(store.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		if policy.MaxAge > 0 {
+			_, err := store.builder.Delete(TableName).
+				Where(sq.And{typeWhere, sq.Lt{timeCol: getCutoffMs(policy.MaxAge, nowMs)}}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to prune expired history for state type %s", stateType)
+			}
+		}
+		if policy.MaxEntries <= 0 {
+			return nil, nil
+		}
+
+		excessDeviceIDs, err := store.getDeviceIDsWithExcessEntries(tx, networkID, stateType, policy.MaxEntries)
+		if err != nil {
+			return nil, err
+		}
+		for _, deviceID := range excessDeviceIDs {
+			// Report times are unique per state, so everything at or before
+			// the first report time past MaxEntries is outside the policy
+			stateWhere := sq.Eq{nidCol: networkID, typeCol: stateType, deviceIDCol: deviceID}
+			var oldestExcessMs uint64
+			err := store.builder.Select(timeCol).
+				From(TableName).
+				Where(stateWhere).
+				OrderBy(timeCol + " DESC").
+				Offset(uint64(policy.MaxEntries)).
+				Limit(1).
+				RunWith(tx).
+				QueryRow().
+				Scan(&oldestExcessMs)
+			if err == sql.ErrNoRows {
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to find excess history for state %s/%s", stateType, deviceID)
+			}
+			_, err = store.builder.Delete(TableName).
+				Where(sq.And{stateWhere, sq.LtOrEq{timeCol: oldestExcessMs}}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to prune excess history for state %s/%s", stateType, deviceID)
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// getDeviceIDsWithExcessEntries returns the device IDs of the states of a type
+// which have more than maxEntries entries
+func (store *sqlStore) getDeviceIDsWithExcessEntries(tx *sql.Tx, networkID string, stateType string, maxEntries int) ([]string, error) {
+	rows, err := store.builder.Select(deviceIDCol).
+		From(TableName).
+		Where(sq.Eq{nidCol: networkID, typeCol: stateType}).
+		GroupBy(deviceIDCol).
+		Having("COUNT(*) > ?", maxEntries).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find states of type %s with excess history", stateType)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "getDeviceIDsWithExcessEntries")
+
+	var ret []string
+	for rows.Next() {
+		var deviceID string
+		if err = rows.Scan(&deviceID); err != nil {
+			return nil, errors.Wrap(err, "failed to scan state history row")
+		}
+		ret = append(ret, deviceID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over state history rows")
+	}
+	return ret, nil
+}
+
+func (store *sqlStore) Delete(networkID string, ids []storage.TypeAndKey) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	idWhere := sq.Or{}
+	for _, id := range ids {
+		idWhere = append(idWhere, sq.Eq{typeCol: id.Type, deviceIDCol: id.Key})
+	}
+	_, err := store.builder.Delete(TableName).
+		Where(sq.And{sq.Eq{nidCol: networkID}, idWhere}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete state history")
+	}
+	return nil
+}
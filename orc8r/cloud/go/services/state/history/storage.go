@@ -0,0 +1,64 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package history contains the storage for the history of reported states.
+// The state service only keeps the latest reported value of each state in the
+// blobstore. For state types which have a retention policy configured, every
+// reported value is also appended to a history store which the state reaper
+// periodically prunes according to that policy.
+package history
+
+import (
+	"time"
+
+	"magma/orc8r/cloud/go/storage"
+)
+
+// Entry is a single reported value of a state.
+type Entry struct {
+	Type     string
+	DeviceID string
+	// TimeMs is the time the state was reported, in unix milliseconds
+	TimeMs uint64
+	// Value is the serialized state, as stored in the state blobstore
+	Value []byte
+}
+
+// RetentionPolicy bounds the history kept for a single state.
+// A zero value for either field disables that bound.
+type RetentionPolicy struct {
+	// MaxEntries is the maximum number of entries kept per state
+	MaxEntries int
+	// MaxAge is how long an entry is kept after it was reported
+	MaxAge time.Duration
+}
+
+// Store persists the history of reported states.
+// Entries are unique by network, type, device ID, and report time; appending
+// an entry with the same report time as an existing one overwrites it.
+type Store interface {
+	// Initialize creates any tables or resources needed by the store.
+	Initialize() error
+
+	// Append adds entries to the history of a network.
+	Append(networkID string, entries []Entry) error
+
+	// Get returns the entries for a state which were reported in the time
+	// range [startMs, endMs], newest first. An endMs of 0 leaves the range
+	// unbounded above. At most limit entries are returned if limit is
+	// positive.
+	Get(networkID string, id storage.TypeAndKey, startMs uint64, endMs uint64, limit int) ([]Entry, error)
+
+	// Prune removes the entries for all states of a type in a network which
+	// fall outside the retention policy. nowMs is the current time in unix
+	// milliseconds.
+	Prune(networkID string, stateType string, policy RetentionPolicy, nowMs uint64) error
+
+	// Delete removes the entire history of the given states.
+	Delete(networkID string, ids []storage.TypeAndKey) error
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/services/state/history"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, history.NewMemoryStore())
+}
+
+func TestSQLStore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	testStore(t, history.NewSQLStore(db, sqorc.GetSqlBuilder()))
+}
+
+func testStore(t *testing.T, store history.Store) {
+	assert.NoError(t, store.Initialize())
+	id1 := storage.TypeAndKey{Type: "t1", Key: "gw1"}
+	id2 := storage.TypeAndKey{Type: "t2", Key: "gw1"}
+	id3 := storage.TypeAndKey{Type: "t1", Key: "gw2"}
+
+	// Empty contract
+	entries, err := store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Append out of order, duplicate report time overwrites
+	assert.NoError(t, store.Append("n1", []history.Entry{
+		makeEntry(id1, 2000, "b"),
+		makeEntry(id1, 1000, "a"),
+		makeEntry(id1, 3000, "c"),
+		makeEntry(id2, 1000, "z"),
+		makeEntry(id3, 1000, "y"),
+	}))
+	assert.NoError(t, store.Append("n1", []history.Entry{makeEntry(id1, 3000, "cc")}))
+	assert.NoError(t, store.Append("n2", []history.Entry{makeEntry(id1, 5000, "other")}))
+
+	entries, err = store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 3000, "cc"), makeEntry(id1, 2000, "b"), makeEntry(id1, 1000, "a")}, entries)
+
+	// Time range and limit
+	entries, err = store.Get("n1", id1, 1500, 3000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 3000, "cc"), makeEntry(id1, 2000, "b")}, entries)
+	entries, err = store.Get("n1", id1, 0, 2999, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 2000, "b")}, entries)
+
+	// Prune by entry count, per state
+	assert.NoError(t, store.Prune("n1", "t1", history.RetentionPolicy{MaxEntries: 2}, 3000))
+	entries, err = store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 3000, "cc"), makeEntry(id1, 2000, "b")}, entries)
+	entries, err = store.Get("n1", id3, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id3, 1000, "y")}, entries)
+
+	// Prune by age, 2000 is exactly at the cutoff so it's kept
+	assert.NoError(t, store.Prune("n1", "t1", history.RetentionPolicy{MaxAge: time.Second}, 3000))
+	entries, err = store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 3000, "cc"), makeEntry(id1, 2000, "b")}, entries)
+	entries, err = store.Get("n1", id3, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoError(t, store.Prune("n1", "t1", history.RetentionPolicy{MaxAge: time.Second}, 3500))
+	entries, err = store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 3000, "cc")}, entries)
+
+	// Other states and networks are untouched
+	entries, err = store.Get("n1", id2, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id2, 1000, "z")}, entries)
+	entries, err = store.Get("n2", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []history.Entry{makeEntry(id1, 5000, "other")}, entries)
+
+	// Delete
+	assert.NoError(t, store.Delete("n1", []storage.TypeAndKey{id1, id2}))
+	entries, err = store.Get("n1", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	entries, err = store.Get("n1", id2, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	entries, err = store.Get("n2", id1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func makeEntry(id storage.TypeAndKey, timeMs uint64, value string) history.Entry {
+	return history.Entry{Type: id.Type, DeviceID: id.Key, TimeMs: timeMs, Value: []byte(value)}
+}
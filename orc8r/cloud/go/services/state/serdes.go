@@ -10,6 +10,8 @@ package state
 
 import (
 	"encoding/json"
+	"time"
+
 	"magma/orc8r/cloud/go/serde"
 )
 
@@ -19,6 +21,42 @@ func NewStateSerde(stateType string, modelPtr serde.ValidateableBinaryConvertibl
 	return serde.NewBinarySerde(SerdeDomain, stateType, modelPtr)
 }
 
+// ExpiringSerde is a state serde for a state type whose reported states
+// expire if they aren't reported again within a TTL.
+type ExpiringSerde interface {
+	serde.Serde
+	// GetTTL returns how long a state of this type is kept after it was last
+	// reported
+	GetTTL() time.Duration
+}
+
+// NewStateSerdeWithTTL returns a state serde for a state type whose states
+// are deleted by the state service once they haven't been reported for ttl.
+// Subscribers are notified of deleted states through WatchExpiredStates.
+func NewStateSerdeWithTTL(stateType string, modelPtr serde.ValidateableBinaryConvertible, ttl time.Duration) ExpiringSerde {
+	return &expiringSerde{Serde: NewStateSerde(stateType, modelPtr), ttl: ttl}
+}
+
+type expiringSerde struct {
+	serde.Serde
+	ttl time.Duration
+}
+
+func (s *expiringSerde) GetTTL() time.Duration {
+	return s.ttl
+}
+
+// GetStateTTLs returns the TTL of each registered state type which has one.
+func GetStateTTLs() map[string]time.Duration {
+	ret := map[string]time.Duration{}
+	for _, s := range serde.GetSerdesForDomain(SerdeDomain) {
+		if expiring, ok := s.(ExpiringSerde); ok && expiring.GetTTL() > 0 {
+			ret[s.GetType()] = expiring.GetTTL()
+		}
+	}
+	return ret
+}
+
 // A generic map that holds key value pair both of type string. This is used on
 // the gateway side in checkin_cli.py to simply test the connection between the
 // cloud and the gateway.
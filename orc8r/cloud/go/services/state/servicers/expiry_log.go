@@ -0,0 +1,170 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	configuratorStorage "magma/orc8r/cloud/go/services/configurator/storage"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// expiryLogType is the blob type of the entries of the expiry log. The
+	// log is kept under the internal network, which has no states.
+	expiryLogType = "expired_state"
+	// ExpiryLogRetention is how long an entry is kept in the expiry log.
+	// A state service instance which can't read the log for longer than this
+	// misses the expiry events logged in the meantime.
+	ExpiryLogRetention = 10 * time.Minute
+)
+
+// The expiry log carries expiry events from the reaper which deleted the
+// states to the WatchExpiredStates streams of every state service instance.
+// Reapers append an entry for each deleted state in the transaction which
+// deletes it, and each instance runs an ExpiryLogTailer which publishes the
+// entries it hasn't seen yet to its own ExpiryBroadcaster.
+
+// getExpiryLogKey returns the key of the expiry log entry for a state reaped
+// at reapedAtMs. Keys sort by the time the state was reaped.
+func getExpiryLogKey(reapedAtMs uint64, networkID string, state *protos.State) string {
+	return fmt.Sprintf("%013d/%s/%s/%s/%d", reapedAtMs, networkID, state.Type, state.DeviceID, state.Version)
+}
+
+func getExpiryLogEntryTimeMs(key string) (uint64, error) {
+	return strconv.ParseUint(strings.SplitN(key, "/", 2)[0], 10, 64)
+}
+
+func appendToExpiryLog(store blobstore.TransactionalBlobStorage, events []*protos.StateExpiredEvent, reapedAtMs uint64) error {
+	entries := make([]blobstore.Blob, 0, len(events))
+	for _, event := range events {
+		value, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, blobstore.Blob{Type: expiryLogType, Key: getExpiryLogKey(reapedAtMs, event.NetworkID, event.State), Value: value})
+	}
+	return store.CreateOrUpdate(configuratorStorage.InternalNetworkID, entries)
+}
+
+// pruneExpiryLog deletes the expiry log entries which are older than
+// ExpiryLogRetention.
+func pruneExpiryLog(store blobstore.TransactionalBlobStorage, nowMs uint64) error {
+	keys, err := store.ListKeys(configuratorStorage.InternalNetworkID, expiryLogType)
+	if err != nil {
+		return err
+	}
+	retentionMs := uint64(ExpiryLogRetention / time.Millisecond)
+	var toDelete []storage.TypeAndKey
+	for _, key := range keys {
+		timeMs, err := getExpiryLogEntryTimeMs(key)
+		if err != nil || timeMs+retentionMs < nowMs {
+			toDelete = append(toDelete, storage.TypeAndKey{Type: expiryLogType, Key: key})
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+	return store.Delete(configuratorStorage.InternalNetworkID, toDelete)
+}
+
+// ExpiryLogTailer publishes the entries appended to the expiry log by the
+// reapers of all state service instances to the local ExpiryBroadcaster.
+// Only entries appended after the tailer was created are published.
+type ExpiryLogTailer struct {
+	factory     blobstore.BlobStorageFactory
+	broadcaster *ExpiryBroadcaster
+	// seenKeys holds the keys of the log entries which were already
+	// published
+	seenKeys map[string]struct{}
+}
+
+// NewExpiryLogTailer returns a tailer which publishes the entries appended
+// to the expiry log after it was created.
+func NewExpiryLogTailer(factory blobstore.BlobStorageFactory, broadcaster *ExpiryBroadcaster) (*ExpiryLogTailer, error) {
+	tailer := &ExpiryLogTailer{factory: factory, broadcaster: broadcaster, seenKeys: map[string]struct{}{}}
+	store, err := factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer store.Rollback()
+	keys, err := store.ListKeys(configuratorStorage.InternalNetworkID, expiryLogType)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		tailer.seenKeys[key] = struct{}{}
+	}
+	return tailer, nil
+}
+
+// Run publishes new expiry log entries every interval. Run never returns.
+func (t *ExpiryLogTailer) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := t.PublishNewEntries(); err != nil {
+			glog.Errorf("Failed to read state expiry log: %s", err)
+		}
+	}
+}
+
+// PublishNewEntries publishes the expiry log entries which weren't published
+// yet.
+func (t *ExpiryLogTailer) PublishNewEntries() error {
+	store, err := t.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer store.Rollback()
+
+	keys, err := store.ListKeys(configuratorStorage.InternalNetworkID, expiryLogType)
+	if err != nil {
+		return err
+	}
+	currentKeys := make(map[string]struct{}, len(keys))
+	var newIDs []storage.TypeAndKey
+	for _, key := range keys {
+		currentKeys[key] = struct{}{}
+		if _, seen := t.seenKeys[key]; !seen {
+			newIDs = append(newIDs, storage.TypeAndKey{Type: expiryLogType, Key: key})
+		}
+	}
+	if len(newIDs) == 0 {
+		t.seenKeys = currentKeys
+		return nil
+	}
+
+	entries, err := store.GetMany(configuratorStorage.InternalNetworkID, newIDs)
+	if err != nil {
+		return err
+	}
+	// Entries which were pruned since they were listed aren't published
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	events := make([]*protos.StateExpiredEvent, 0, len(entries))
+	for _, entry := range entries {
+		event := &protos.StateExpiredEvent{}
+		if err := proto.Unmarshal(entry.Value, event); err != nil {
+			glog.Errorf("Failed to unmarshal state expiry log entry %s: %s", entry.Key, err)
+			continue
+		}
+		events = append(events, event)
+	}
+	t.broadcaster.Publish(events)
+	// Keys which were pruned from the log are forgotten
+	t.seenKeys = currentKeys
+	return nil
+}
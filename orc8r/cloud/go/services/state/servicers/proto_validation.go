@@ -40,6 +40,33 @@ func ValidateSyncStatesRequest(req *protos.SyncStatesRequest) error {
 	return nil
 }
 
+// ValidateGetStateHistoryRequest checks that all required fields exist and
+// that the time range is well-formed
+func ValidateGetStateHistoryRequest(req *protos.GetStateHistoryRequest) error {
+	if len(req.GetNetworkID()) == 0 {
+		return errors.New("Network ID must be specified")
+	}
+	if len(req.GetId().GetType()) == 0 || len(req.GetId().GetDeviceID()) == 0 {
+		return errors.New("State type and device ID must be specified")
+	}
+	if req.GetEndTimeMs() != 0 && req.GetEndTimeMs() < req.GetStartTimeMs() {
+		return errors.New("End time must not be before start time")
+	}
+	return nil
+}
+
+// ValidateSearchStatesRequest checks that all required fields exist and
+// that the update time range is well-formed
+func ValidateSearchStatesRequest(req *protos.SearchStatesRequest) error {
+	if len(req.GetNetworkID()) == 0 {
+		return errors.New("Network ID must be specified")
+	}
+	if req.GetUpdatedBeforeMs() != 0 && req.GetUpdatedBeforeMs() < req.GetUpdatedAfterMs() {
+		return errors.New("Updated before time must not be before updated after time")
+	}
+	return nil
+}
+
 // PartitionStatesBySerializability checks that each state is deserializable.
 // If a state is not deserializable, we will send back the states type, key, and error.
 func PartitionStatesBySerializability(req *protos.ReportStatesRequest) ([]*protos.State, []*protos.IDAndError, error) {
@@ -0,0 +1,298 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"encoding/json"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	stateService "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/expiry"
+	"magma/orc8r/cloud/go/services/state/history"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// Reaper deletes states which haven't been reported within the TTL of their
+// type, along with their history. The expired states are
+// looked up by their expiry time in the expiry store, so only those are read
+// from the blobstore. The deleted states are appended to the
+// expiry log, from which the ExpiryLogTailer of every state service instance
+// publishes them to its watchers.
+// TTLs are registered along with the state serdes, see
+// state.NewStateSerdeWithTTL.
+// The Reaper also prunes the history of the state types in
+// retentionPolicies according to their policy.
+type Reaper struct {
+	factory           blobstore.BlobStorageFactory
+	historyStore      history.Store
+	retentionPolicies map[string]history.RetentionPolicy
+	expiryStore       expiry.Store
+}
+
+func NewReaper(
+	factory blobstore.BlobStorageFactory,
+	historyStore history.Store,
+	retentionPolicies map[string]history.RetentionPolicy,
+	expiryStore expiry.Store,
+) *Reaper {
+	return &Reaper{
+		factory:           factory,
+		historyStore:      historyStore,
+		retentionPolicies: retentionPolicies,
+		expiryStore:       expiryStore,
+	}
+}
+
+// Run reaps expired states every interval. Run never returns.
+func (r *Reaper) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := r.ReapExpiredStates(); err != nil {
+			glog.Errorf("Failed to reap expired states: %s", err)
+		}
+	}
+}
+
+// ReapExpiredStates deletes the expired states across all networks, prunes
+// the state history and prunes the expiry log.
+// A state is only deleted if it wasn't reported again since it was read, so
+// multiple state service replicas can reap concurrently and each expired
+// state is logged once.
+func (r *Reaper) ReapExpiredStates() error {
+	nowMs := uint64(clock.Now().UnixNano()) / uint64(time.Millisecond)
+	if err := r.pruneExpiryLog(nowMs); err != nil {
+		return errors.Wrap(err, "failed to prune expiry log")
+	}
+
+	ttls := stateService.GetStateTTLs()
+	if len(ttls) == 0 && len(r.retentionPolicies) == 0 {
+		return nil
+	}
+	networkIDs, err := configurator.ListNetworkIDs()
+	if err != nil {
+		return errors.Wrap(err, "failed to list networks")
+	}
+	for _, networkID := range networkIDs {
+		r.pruneHistory(networkID, nowMs)
+		if len(ttls) == 0 {
+			continue
+		}
+		reapedIDs, err := r.reapNetwork(networkID, ttls, nowMs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to reap expired states in network %s", networkID)
+		}
+		if len(reapedIDs) == 0 {
+			continue
+		}
+		// History is kept in a separate store, so it's deleted once the
+		// states are. History left behind by a failure here is still pruned
+		// by its retention policy.
+		if err := r.historyStore.Delete(networkID, reapedIDs); err != nil {
+			glog.Errorf("Failed to delete history of expired states in network %s: %s", networkID, err)
+		}
+	}
+	return nil
+}
+
+// AddStoredStateExpiries records the expiry times of the states already in
+// blobstorage, so that states which were reported before their expiry was
+// recorded are reaped without waiting for their gateways to report them
+// again. Entries are only replaced by later expiries, so this is safe to run
+// while states are being reported.
+func AddStoredStateExpiries(factory blobstore.BlobStorageFactory, expiryStore expiry.Store) error {
+	ttls := stateService.GetStateTTLs()
+	if len(ttls) == 0 {
+		return nil
+	}
+	types := make([]string, 0, len(ttls))
+	for stateType := range ttls {
+		types = append(types, stateType)
+	}
+	networkIDs, err := configurator.ListNetworkIDs()
+	if err != nil {
+		return errors.Wrap(err, "failed to list networks")
+	}
+	for _, networkID := range networkIDs {
+		if err := addNetworkStateExpiries(factory, expiryStore, networkID, types, ttls); err != nil {
+			return errors.Wrapf(err, "failed to record expiry of states in network %s", networkID)
+		}
+	}
+	return nil
+}
+
+func addNetworkStateExpiries(
+	factory blobstore.BlobStorageFactory,
+	expiryStore expiry.Store,
+	networkID string,
+	types []string,
+	ttls map[string]time.Duration,
+) error {
+	store, err := factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	blobsByNetwork, err := store.Search(blobstore.SearchFilter{NetworkID: &networkID, Types: types})
+	if err != nil {
+		store.Rollback()
+		return err
+	}
+	if err := store.Commit(); err != nil {
+		return err
+	}
+
+	var entries []expiry.Entry
+	for _, blob := range blobsByNetwork[networkID] {
+		wrapped := stateService.SerializedStateWithMeta{}
+		if err := json.Unmarshal(blob.Value, &wrapped); err != nil {
+			glog.Errorf("Failed to unmarshal state %s/%s in network %s: %s", blob.Type, blob.Key, networkID, err)
+			continue
+		}
+		expiresAtMs := wrapped.TimeMs + uint64(ttls[blob.Type]/time.Millisecond)
+		entries = append(entries, expiry.Entry{Type: blob.Type, DeviceID: blob.Key, ExpiresAtMs: expiresAtMs})
+	}
+	return expiryStore.Put(networkID, entries)
+}
+
+// pruneHistory prunes the history of a network's states according to the
+// retention policy of their type. Failures are logged, the history is pruned
+// again by the next run.
+func (r *Reaper) pruneHistory(networkID string, nowMs uint64) {
+	for stateType, policy := range r.retentionPolicies {
+		if err := r.historyStore.Prune(networkID, stateType, policy, nowMs); err != nil {
+			glog.Errorf("Failed to prune history of %s states in network %s: %s", stateType, networkID, err)
+		}
+	}
+}
+
+func (r *Reaper) pruneExpiryLog(nowMs uint64) error {
+	store, err := r.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	if err := pruneExpiryLog(store, nowMs); err != nil {
+		store.Rollback()
+		return err
+	}
+	return store.Commit()
+}
+
+// reapNetwork deletes the expired states of a network and logs them in the
+// expiry log in a single transaction. It returns the IDs of the deleted
+// states.
+// Expiry entries which are behind their state, because its TTL was raised
+// since it was reported, are moved to the state's current expiry time.
+// Entries of deleted states and of types which no longer have a TTL are
+// removed.
+func (r *Reaper) reapNetwork(networkID string, ttls map[string]time.Duration, nowMs uint64) ([]storage.TypeAndKey, error) {
+	listedIDs, err := r.expiryStore.ListExpired(networkID, nowMs)
+	if err != nil {
+		return nil, err
+	}
+	if len(listedIDs) == 0 {
+		return nil, nil
+	}
+
+	store, err := r.factory.StartTransaction(nil)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := store.GetMany(networkID, listedIDs)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+
+	expiredBlobs := map[storage.TypeAndKey]blobstore.Blob{}
+	expectedVersions := map[storage.TypeAndKey]uint64{}
+	var laterEntries []expiry.Entry
+	for _, blob := range blobs {
+		ttl, hasTTL := ttls[blob.Type]
+		if !hasTTL {
+			continue
+		}
+		wrapped := stateService.SerializedStateWithMeta{}
+		if err := json.Unmarshal(blob.Value, &wrapped); err != nil {
+			glog.Errorf("Failed to unmarshal state %s/%s in network %s: %s", blob.Type, blob.Key, networkID, err)
+			continue
+		}
+		expiresAtMs := wrapped.TimeMs + uint64(ttl/time.Millisecond)
+		if expiresAtMs >= nowMs {
+			laterEntries = append(laterEntries, expiry.Entry{Type: blob.Type, DeviceID: blob.Key, ExpiresAtMs: expiresAtMs})
+			continue
+		}
+		id := storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
+		expiredBlobs[id] = blob
+		expectedVersions[id] = blob.Version
+	}
+
+	var deletedIDs []storage.TypeAndKey
+	if len(expectedVersions) != 0 {
+		// States reported again since they were read are kept
+		deletedIDs, err = store.DeleteIfVersion(networkID, expectedVersions)
+		if err != nil {
+			store.Rollback()
+			return nil, err
+		}
+	}
+	if len(deletedIDs) != 0 {
+		events := make([]*protos.StateExpiredEvent, 0, len(deletedIDs))
+		for _, id := range deletedIDs {
+			blob := expiredBlobs[id]
+			events = append(events, &protos.StateExpiredEvent{
+				NetworkID: networkID,
+				State:     &protos.State{Type: blob.Type, DeviceID: blob.Key, Value: blob.Value, Version: blob.Version},
+			})
+		}
+		if err := appendToExpiryLog(store, events, nowMs); err != nil {
+			store.Rollback()
+			return nil, err
+		}
+	}
+	if err := store.Commit(); err != nil {
+		return nil, err
+	}
+
+	// The entries of states which weren't deleted are left alone by
+	// DeleteExpired, and entries left behind by a failure here are listed
+	// again by the next run
+	if err := r.expiryStore.Put(networkID, laterEntries); err != nil {
+		glog.Errorf("Failed to update expiry of states in network %s: %s", networkID, err)
+	}
+	if err := r.expiryStore.DeleteExpired(networkID, getStaleExpiryIDs(listedIDs, blobs, ttls, deletedIDs), nowMs); err != nil {
+		glog.Errorf("Failed to delete expiry entries in network %s: %s", networkID, err)
+	}
+	return deletedIDs, nil
+}
+
+// getStaleExpiryIDs returns the IDs of the listed expiry entries whose state
+// was deleted, doesn't exist, or whose type no longer has a TTL.
+func getStaleExpiryIDs(listedIDs []storage.TypeAndKey, blobs []blobstore.Blob, ttls map[string]time.Duration, deletedIDs []storage.TypeAndKey) []storage.TypeAndKey {
+	live := map[storage.TypeAndKey]bool{}
+	for _, blob := range blobs {
+		if _, hasTTL := ttls[blob.Type]; hasTTL {
+			live[storage.TypeAndKey{Type: blob.Type, Key: blob.Key}] = true
+		}
+	}
+	for _, id := range deletedIDs {
+		live[id] = false
+	}
+	var ret []storage.TypeAndKey
+	for _, id := range listedIDs {
+		if !live[id] {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
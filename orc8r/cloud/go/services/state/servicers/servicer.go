@@ -17,22 +17,64 @@ import (
 	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/protos"
 	stateService "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/expiry"
+	"magma/orc8r/cloud/go/services/state/history"
+	"magma/orc8r/cloud/go/storage"
 
+	"github.com/golang/glog"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type stateServicer struct {
 	factory blobstore.BlobStorageFactory
+	// historyStore keeps the reported values of the state types which have
+	// a retention policy
+	historyStore      history.Store
+	retentionPolicies map[string]history.RetentionPolicy
+	// expiryStore holds the expiry time of every state whose type has a
+	// TTL, which the Reaper queries for the expired states
+	expiryStore expiry.Store
+	// expiryBroadcaster publishes the states deleted by the Reaper
+	expiryBroadcaster *ExpiryBroadcaster
 }
 
-// NewStateServicer returns a state server backed by storage passed in
-func NewStateServicer(factory blobstore.BlobStorageFactory) (protos.StateServiceServer, error) {
+// NewStateServicer returns a state server backed by storage passed in.
+// States whose type has an entry in retentionPolicies also have their
+// reported values appended to historyStore. The expiry time of states whose
+// type has a TTL is recorded in expiryStore. WatchExpiredStates streams the events published to
+// expiryBroadcaster.
+func NewStateServicer(
+	factory blobstore.BlobStorageFactory,
+	historyStore history.Store,
+	retentionPolicies map[string]history.RetentionPolicy,
+	expiryStore expiry.Store,
+	expiryBroadcaster *ExpiryBroadcaster,
+) (protos.StateServiceServer, error) {
 	if factory == nil {
 		return nil, fmt.Errorf("Storage factory is nil")
 	}
-	return &stateServicer{factory}, nil
+	if historyStore == nil {
+		return nil, fmt.Errorf("History store is nil")
+	}
+	if expiryStore == nil {
+		return nil, fmt.Errorf("Expiry store is nil")
+	}
+	if expiryBroadcaster == nil {
+		return nil, fmt.Errorf("Expiry broadcaster is nil")
+	}
+	if retentionPolicies == nil {
+		retentionPolicies = map[string]history.RetentionPolicy{}
+	}
+	return &stateServicer{
+		factory:           factory,
+		historyStore:      historyStore,
+		retentionPolicies: retentionPolicies,
+		expiryStore:       expiryStore,
+		expiryBroadcaster: expiryBroadcaster,
+	}, nil
 }
 
 // GetStates retrieves states from blobstorage
@@ -77,6 +119,12 @@ func (srv *stateServicer) ReportStates(context context.Context, req *protos.Repo
 	certExpiry := protos.GetClientCertExpiration(context)
 	timeMs := uint64(clock.Now().UnixNano()) / uint64(time.Millisecond)
 
+	// Expiry times are recorded before the states are saved, so every saved
+	// state has an expiry entry which is never earlier than its own
+	err = srv.expiryStore.Put(networkID, makeExpiryEntries(validatedStates, stateService.GetStateTTLs(), timeMs))
+	if err != nil {
+		return response, status.Error(codes.Internal, err.Error())
+	}
 	states, err := addWrapperAndMakeBlobs(validatedStates, hwID, timeMs, certExpiry)
 	if err != nil {
 		return response, err
@@ -91,7 +139,14 @@ func (srv *stateServicer) ReportStates(context context.Context, req *protos.Repo
 		store.Rollback()
 		return response, err
 	}
-	return response, store.Commit()
+	err = store.Commit()
+	if err != nil {
+		return response, err
+	}
+
+	// History is best-effort, the latest states have already been saved
+	srv.recordHistory(networkID, validatedStates, timeMs)
+	return response, nil
 }
 
 // DeleteStates deletes states from blobstorage
@@ -123,7 +178,104 @@ func (srv *stateServicer) DeleteStates(context context.Context, req *protos.Dele
 		store.Rollback()
 		return ret, err
 	}
-	return ret, store.Commit()
+	err = store.Commit()
+	if err != nil {
+		return ret, err
+	}
+	if err = srv.expiryStore.Delete(networkID, ids); err != nil {
+		return ret, err
+	}
+	return ret, srv.historyStore.Delete(networkID, ids)
+}
+
+// GetStateHistory retrieves the previously reported values of a state from
+// the history store, newest first
+func (srv *stateServicer) GetStateHistory(
+	context context.Context,
+	req *protos.GetStateHistoryRequest,
+) (*protos.GetStateHistoryResponse, error) {
+	if err := ValidateGetStateHistoryRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id := storage.TypeAndKey{Type: req.Id.Type, Key: req.Id.DeviceID}
+	entries, err := srv.historyStore.Get(req.NetworkID, id, req.StartTimeMs, req.EndTimeMs, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ret := &protos.GetStateHistoryResponse{States: make([]*protos.State, 0, len(entries))}
+	for _, entry := range entries {
+		ret.States = append(ret.States, &protos.State{Type: entry.Type, DeviceID: entry.DeviceID, Value: entry.Value})
+	}
+	return ret, nil
+}
+
+// SearchStates retrieves the states in a network which match the filters in
+// the request. States are searched by type and key prefix in blobstorage,
+// the reporter and update time are then matched against the metadata the
+// states are wrapped with.
+func (srv *stateServicer) SearchStates(
+	context context.Context,
+	req *protos.SearchStatesRequest,
+) (*protos.SearchStatesResponse, error) {
+	if err := ValidateSearchStatesRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	blobs, err := srv.searchBlobs(req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ret := &protos.SearchStatesResponse{States: make([]*protos.State, 0, len(blobs))}
+	for _, blob := range blobs {
+		if !blobMatchesSearch(blob, req) {
+			continue
+		}
+		ret.States = append(ret.States, &protos.State{Type: blob.Type, DeviceID: blob.Key, Value: blob.Value, Version: blob.Version})
+	}
+	return ret, nil
+}
+
+func (srv *stateServicer) searchBlobs(req *protos.SearchStatesRequest) ([]blobstore.Blob, error) {
+	filter := blobstore.SearchFilter{NetworkID: &req.NetworkID, Types: req.Types}
+	if req.KeyPrefix != "" {
+		filter.KeyPrefix = &req.KeyPrefix
+	}
+	store, err := srv.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	blobsByNetwork, err := store.Search(filter)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	return blobsByNetwork[req.NetworkID], store.Commit()
+}
+
+// blobMatchesSearch returns true if the state was reported by the reporter
+// and within the time range of the request. All states match requests which
+// filter on neither.
+func blobMatchesSearch(blob blobstore.Blob, req *protos.SearchStatesRequest) bool {
+	if req.ReporterID == "" && req.UpdatedAfterMs == 0 && req.UpdatedBeforeMs == 0 {
+		return true
+	}
+	wrapped := stateService.SerializedStateWithMeta{}
+	if err := json.Unmarshal(blob.Value, &wrapped); err != nil {
+		glog.Errorf("Failed to unmarshal state %s/%s: %s", blob.Type, blob.Key, err)
+		return false
+	}
+	if req.ReporterID != "" && wrapped.ReporterID != req.ReporterID {
+		return false
+	}
+	if wrapped.TimeMs < req.UpdatedAfterMs {
+		return false
+	}
+	if req.UpdatedBeforeMs != 0 && wrapped.TimeMs > req.UpdatedBeforeMs {
+		return false
+	}
+	return true
 }
 
 // SyncStates retrieves states from blobstorage, compares their versions to
@@ -177,6 +329,62 @@ func (srv *stateServicer) SyncStates(
 	return &protos.SyncStatesResponse{UnsyncedStates: unsyncedStates}, store.Commit()
 }
 
+// WatchExpiredStates streams the states deleted by the reaper of this state
+// service instance
+func (srv *stateServicer) WatchExpiredStates(req *protos.WatchExpiredStatesRequest, stream protos.StateService_WatchExpiredStatesServer) error {
+	watcher := srv.expiryBroadcaster.watch(req.NetworkID, req.Types)
+	defer srv.expiryBroadcaster.unwatch(watcher)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, reload states and watch again")
+			}
+			if err := stream.Send(event.(*protos.StateExpiredEvent)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// recordHistory appends the reported states which have a retention policy to
+// the history store. The Reaper prunes the history according to the policy.
+// states must already hold their wrapped values.
+func (srv *stateServicer) recordHistory(networkID string, states []*protos.State, timeMs uint64) {
+	var entries []history.Entry
+	for _, state := range states {
+		if _, hasPolicy := srv.retentionPolicies[state.Type]; !hasPolicy {
+			continue
+		}
+		entries = append(entries, history.Entry{Type: state.Type, DeviceID: state.DeviceID, TimeMs: timeMs, Value: state.Value})
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	err := srv.historyStore.Append(networkID, entries)
+	if err != nil {
+		glog.Errorf("Failed to record state history for network %s: %s", networkID, err)
+	}
+}
+
+func makeExpiryEntries(states []*protos.State, ttls map[string]time.Duration, timeMs uint64) []expiry.Entry {
+	var entries []expiry.Entry
+	for _, state := range states {
+		ttl, hasTTL := ttls[state.Type]
+		if !hasTTL {
+			continue
+		}
+		entries = append(entries, expiry.Entry{Type: state.Type, DeviceID: state.DeviceID, ExpiresAtMs: timeMs + uint64(ttl/time.Millisecond)})
+	}
+	return entries
+}
+
 func isStateSynced(deviceIdToStates map[string][]*protos.State, reqIdAndVersion *protos.IDAndVersion) (bool, uint64) {
 	statesForDevice, ok := deviceIdToStates[reqIdAndVersion.Id.DeviceID]
 	if !ok {
@@ -0,0 +1,54 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"magma/orc8r/cloud/go/broadcast"
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/thoas/go-funk"
+)
+
+// watchBufferSize is the number of events which can be queued for a watcher
+// before it is considered to have fallen behind and is disconnected.
+const watchBufferSize = 1024
+
+// ExpiryBroadcaster fans out state expiry events to all open
+// WatchExpiredStates streams on this state service instance. Watchers which
+// fall more than watchBufferSize events behind have their channel closed.
+type ExpiryBroadcaster struct {
+	broadcaster *broadcast.Broadcaster
+}
+
+func NewExpiryBroadcaster() *ExpiryBroadcaster {
+	return &ExpiryBroadcaster{broadcaster: broadcast.NewBroadcaster(watchBufferSize)}
+}
+
+// Publish sends the events to every watcher they match.
+func (b *ExpiryBroadcaster) Publish(events []*protos.StateExpiredEvent) {
+	toPublish := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		toPublish = append(toPublish, event)
+	}
+	b.broadcaster.Publish(toPublish...)
+}
+
+func (b *ExpiryBroadcaster) watch(networkID string, types []string) *broadcast.Subscription {
+	return b.broadcaster.Subscribe(func(e interface{}) bool {
+		event := e.(*protos.StateExpiredEvent)
+		if networkID != "" && event.NetworkID != networkID {
+			return false
+		}
+		return len(types) == 0 || funk.ContainsString(types, event.State.Type)
+	})
+}
+
+func (b *ExpiryBroadcaster) unwatch(s *broadcast.Subscription) {
+	b.broadcaster.Unsubscribe(s)
+}
@@ -16,7 +16,10 @@ import (
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/expiry"
+	"magma/orc8r/cloud/go/services/state/history"
 	"magma/orc8r/cloud/go/services/state/metrics"
 	"magma/orc8r/cloud/go/services/state/servicers"
 	"magma/orc8r/cloud/go/sqorc"
@@ -24,8 +27,14 @@ import (
 	"github.com/golang/glog"
 )
 
-// how often to report gateway status
-const gatewayStatusReportInterval = time.Second * 60
+const (
+	// how often to report gateway status
+	gatewayStatusReportInterval = time.Second * 60
+	// default for how often to delete expired states
+	defaultReaperInterval = time.Second * 60
+	// how often to read the states reaped by all instances from the expiry log
+	expiryLogPollInterval = time.Second * 5
+)
 
 func main() {
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, state.ServiceName)
@@ -42,12 +51,53 @@ func main() {
 		glog.Fatalf("Error initializing state database: %s", err)
 	}
 
-	server, err := servicers.NewStateServicer(store)
+	historyStore := history.NewSQLStore(db, sqorc.GetSqlBuilder())
+	err = historyStore.Initialize()
+	if err != nil {
+		glog.Fatalf("Error initializing state history database: %s", err)
+	}
+	expiryStore := expiry.NewSQLStore(db, sqorc.GetSqlBuilder())
+	err = expiryStore.Initialize()
+	if err != nil {
+		glog.Fatalf("Error initializing state expiry database: %s", err)
+	}
+	var retentionPolicies map[string]history.RetentionPolicy
+	stateConfig, err := config.GetServiceConfig(orc8r.ModuleName, state.ServiceName)
+	if err != nil {
+		glog.Errorf("Failed to load state config, state history is disabled: %v", err)
+	} else {
+		retentionPolicies, err = history.GetRetentionPolicies(stateConfig)
+		if err != nil {
+			glog.Fatalf("Error reading state history retention policies: %s", err)
+		}
+	}
+
+	expiryBroadcaster := servicers.NewExpiryBroadcaster()
+	server, err := servicers.NewStateServicer(store, historyStore, retentionPolicies, expiryStore, expiryBroadcaster)
 	if err != nil {
 		glog.Fatalf("Error creating state server: %s", err)
 	}
 	protos.RegisterStateServiceServer(srv.GrpcServer, server)
 
+	// record the expiry of the states reported before the expiry table was
+	// created
+	go func() {
+		if err := servicers.AddStoredStateExpiries(store, expiryStore); err != nil {
+			glog.Errorf("Failed to record expiry of stored states: %s", err)
+		}
+	}()
+
+	// periodically delete states which outlived the TTL of their type
+	reaper := servicers.NewReaper(store, historyStore, retentionPolicies, expiryStore)
+	go reaper.Run(getReaperInterval(stateConfig))
+	// publish the states reaped by any state service instance to the
+	// watchers of this instance
+	tailer, err := servicers.NewExpiryLogTailer(store, expiryBroadcaster)
+	if err != nil {
+		glog.Fatalf("Error reading state expiry log: %s", err)
+	}
+	go tailer.Run(expiryLogPollInterval)
+
 	// periodically go through all existing gateways and log metrics
 	go metrics.PeriodicallyReportGatewayStatus(gatewayStatusReportInterval)
 
@@ -56,3 +106,14 @@ func main() {
 		glog.Fatalf("Error running service: %s", err)
 	}
 }
+
+func getReaperInterval(stateConfig *config.ConfigMap) time.Duration {
+	if stateConfig == nil {
+		return defaultReaperInterval
+	}
+	intervalSecs, err := stateConfig.GetIntParam("reaper_interval_secs")
+	if err != nil || intervalSecs <= 0 {
+		return defaultReaperInterval
+	}
+	return time.Duration(intervalSecs) * time.Second
+}
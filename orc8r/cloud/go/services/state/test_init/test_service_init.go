@@ -10,11 +10,14 @@ package test_init
 
 import (
 	"testing"
+	"time"
 
 	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/expiry"
+	"magma/orc8r/cloud/go/services/state/history"
 	"magma/orc8r/cloud/go/services/state/servicers"
 	"magma/orc8r/cloud/go/test_utils"
 
@@ -23,10 +26,37 @@ import (
 
 // StartTestService instantiates a service backed by an in-memory storage
 func StartTestService(t *testing.T) {
+	startTestService(t, nil)
+}
+
+// StartTestServiceWithHistory instantiates a service backed by an in-memory
+// storage which keeps the history of the state types in retentionPolicies
+func StartTestServiceWithHistory(t *testing.T, retentionPolicies map[string]history.RetentionPolicy) {
+	startTestService(t, retentionPolicies)
+}
+
+// StartTestServiceWithReaper instantiates a service backed by an in-memory
+// storage which keeps the history of the state types in retentionPolicies,
+// and returns the reaper for the service. The reaper isn't run in
+// the background, tests should call ReapExpiredStates directly. The reaped
+// states are published to watchers in the background.
+func StartTestServiceWithReaper(t *testing.T, retentionPolicies map[string]history.RetentionPolicy) *servicers.Reaper {
+	return startTestService(t, retentionPolicies)
+}
+
+func startTestService(t *testing.T, retentionPolicies map[string]history.RetentionPolicy) *servicers.Reaper {
 	factory := blobstore.NewMemoryBlobStorageFactory()
+	historyStore := history.NewMemoryStore()
+	expiryStore := expiry.NewMemoryStore()
+	expiryBroadcaster := servicers.NewExpiryBroadcaster()
 	srv, lis := test_utils.NewTestService(t, orc8r.ModuleName, state.ServiceName)
-	server, err := servicers.NewStateServicer(factory)
+	server, err := servicers.NewStateServicer(factory, historyStore, retentionPolicies, expiryStore, expiryBroadcaster)
 	assert.NoError(t, err)
 	protos.RegisterStateServiceServer(srv.GrpcServer, server)
 	go srv.RunTest(lis)
+
+	tailer, err := servicers.NewExpiryLogTailer(factory, expiryBroadcaster)
+	assert.NoError(t, err)
+	go tailer.Run(10 * time.Millisecond)
+	return servicers.NewReaper(factory, historyStore, retentionPolicies, expiryStore)
 }
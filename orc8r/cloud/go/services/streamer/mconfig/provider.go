@@ -50,6 +50,34 @@ func (provider *ConfigProvider) GetUpdates(gatewayId string, extraArgs *any.Any)
 	return mconfigToUpdate(resp.Configs, resp.LogicalID, "")
 }
 
+// GetUpdatesSince implements providers.IncrementalStreamProvider. The stream
+// has a single key, so the digest of the stream is the digest of the gateway's
+// mconfig and any change is sent as a full resync.
+func (provider *ConfigProvider) GetUpdatesSince(gatewayId string, extraArgs *any.Any, previousDigest string) (*protos.DataUpdateBatch, error) {
+	resp, err := configurator.GetMconfigFor(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+	digest := resp.Configs.Metadata.Digest.Md5HexDigest
+	if previousDigest == digest {
+		return &protos.DataUpdateBatch{Digest: digest}, nil
+	}
+
+	receivedDigest := ""
+	if extraArgs != nil {
+		configsDigest := &protos.GatewayConfigsDigest{}
+		if err := ptypes.UnmarshalAny(extraArgs, configsDigest); err == nil {
+			receivedDigest = configsDigest.Md5HexDigest
+		}
+	}
+	updates, err := mconfigToUpdate(resp.Configs, resp.LogicalID, receivedDigest)
+	if err != nil {
+		return nil, err
+	}
+	// An empty update means the gateway already has the mconfig
+	return &protos.DataUpdateBatch{Updates: updates, Resync: len(updates) > 0, Digest: digest}, nil
+}
+
 func mconfigToUpdate(configs *protos.GatewayConfigs, key string, digest string) ([]*protos.DataUpdate, error) {
 	// Early/empty return if gateway already has config that would be sent here
 	if digest == configs.Metadata.Digest.Md5HexDigest {
@@ -103,6 +103,31 @@ func TestMconfigStreamer_Configurator(t *testing.T) {
 	actualMarshaled, err = streamerClient.Recv()
 	assert.NoError(t, err)
 	assert.Empty(t, actualMarshaled.Updates)
+	assert.False(t, actualMarshaled.Resync)
+	assert.Equal(t, actual.Metadata.Digest.Md5HexDigest, actualMarshaled.Digest)
+
+	// The stream digest is the mconfig digest: an unchanged mconfig is an
+	// empty delta, a changed one is a full resync
+	streamerClient, err = grpcClient.GetUpdates(
+		context.Background(),
+		&protos.StreamRequest{GatewayId: "hw1", StreamName: "configs", PreviousDigest: actual.Metadata.Digest.Md5HexDigest},
+	)
+	assert.NoError(t, err)
+	actualMarshaled, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.Empty(t, actualMarshaled.Updates)
+	assert.False(t, actualMarshaled.Resync)
+
+	streamerClient, err = grpcClient.GetUpdates(
+		context.Background(),
+		&protos.StreamRequest{GatewayId: "hw1", StreamName: "configs", PreviousDigest: "stale"},
+	)
+	assert.NoError(t, err)
+	actualMarshaled, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, actualMarshaled.Resync)
+	assert.Len(t, actualMarshaled.Updates, 1)
+	assert.Equal(t, actual.Metadata.Digest.Md5HexDigest, actualMarshaled.Digest)
 
 	mockBuilder.AssertExpectations(t)
 }
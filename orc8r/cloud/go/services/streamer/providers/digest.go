@@ -0,0 +1,56 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+
+	"magma/orc8r/cloud/go/protos"
+)
+
+// GetDigest returns a digest of the full contents of a stream. The digest
+// does not depend on the order of the updates.
+func GetDigest(updates []*protos.DataUpdate) string {
+	return GetDigestFromKeyDigests(GetKeyDigests(updates))
+}
+
+// GetKeyDigests returns a digest of the value of each update in the stream,
+// keyed by the update's key.
+func GetKeyDigests(updates []*protos.DataUpdate) map[string]string {
+	ret := make(map[string]string, len(updates))
+	for _, update := range updates {
+		sum := sha256.Sum256(update.Value)
+		ret[update.Key] = hex.EncodeToString(sum[:])
+	}
+	return ret
+}
+
+// GetDigestFromKeyDigests returns the digest of a stream given the digest of
+// each of its values, as returned by GetKeyDigests.
+func GetDigestFromKeyDigests(keyDigests map[string]string) string {
+	keys := make([]string, 0, len(keyDigests))
+	for k := range keyDigests {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hasher := sha256.New()
+	lenBuf := make([]byte, 8)
+	for _, k := range keys {
+		// Length-prefix keys so that different key sets can't collide
+		binary.BigEndian.PutUint64(lenBuf, uint64(len(k)))
+		hasher.Write(lenBuf)
+		hasher.Write([]byte(k))
+		hasher.Write([]byte(keyDigests[k]))
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -28,6 +28,23 @@ type StreamProvider interface {
 	GetUpdates(gatewayId string, extraArgs *any.Any) ([]*protos.DataUpdate, error)
 }
 
+// IncrementalStreamProvider is an optional extension of StreamProvider for
+// streams which can compute the changes since a previously streamed version
+// themselves, without loading the full contents of the stream.
+// Providers which only implement StreamProvider still support incremental
+// updates: the streamer service will compute the delta against the last
+// snapshot it sent to the gateway.
+type IncrementalStreamProvider interface {
+	StreamProvider
+
+	// GetUpdatesSince returns the batch of updates to bring a gateway from
+	// the version identified by previousDigest to the current contents of the
+	// stream. If the provider cannot compute a delta from previousDigest, it
+	// should return a full batch with Resync set to true. The returned batch
+	// must always have its Digest field set.
+	GetUpdatesSince(gatewayId string, extraArgs *any.Any, previousDigest string) (*protos.DataUpdateBatch, error)
+}
+
 type providerRegistry struct {
 	sync.RWMutex
 	providersByStream map[string]StreamProvider
@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/streamer/providers"
+)
+
+var (
+	// SnapshotCacheSize is the maximum number of snapshots kept by each
+	// streamer replica. The least recently used snapshots are evicted first.
+	SnapshotCacheSize = 10000
+	// SnapshotTTL is the duration after which an unused snapshot is evicted.
+	SnapshotTTL = 30 * time.Minute
+)
+
+type snapshotKey struct {
+	streamName string
+	gatewayID  string
+}
+
+// snapshot is the last version of a stream sent to a gateway. Only digests
+// of the values are kept to bound memory usage.
+type snapshot struct {
+	key        snapshotKey
+	digest     string
+	keyDigests map[string]string
+	lastUsed   time.Time
+}
+
+// snapshotCache holds the last snapshot sent to each gateway for each stream,
+// so that deltas can be computed for providers which don't support them
+// natively. The cache is local to each streamer replica; a gateway whose
+// previous digest isn't in the cache gets a full resync.
+// The cache is bounded by SnapshotCacheSize and SnapshotTTL.
+type snapshotCache struct {
+	sync.Mutex
+	snapshotsByKey map[snapshotKey]*list.Element
+	// lru is ordered from most to least recently used
+	lru *list.List
+}
+
+var snapshots = newSnapshotCache()
+
+func newSnapshotCache() *snapshotCache {
+	return &snapshotCache{snapshotsByKey: map[snapshotKey]*list.Element{}, lru: list.New()}
+}
+
+// swap records current as the snapshot for its key and returns the previous
+// snapshot, if there is one which hasn't expired
+func (c *snapshotCache) swap(current *snapshot) (*snapshot, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	now := clock.Now()
+	current.lastUsed = now
+	c.evictExpiredUnsafe(now)
+
+	var previous *snapshot
+	elem, ok := c.snapshotsByKey[current.key]
+	if ok {
+		previous = elem.Value.(*snapshot)
+		elem.Value = current
+		c.lru.MoveToFront(elem)
+	} else {
+		c.snapshotsByKey[current.key] = c.lru.PushFront(current)
+	}
+	for c.lru.Len() > SnapshotCacheSize {
+		c.removeUnsafe(c.lru.Back())
+	}
+	return previous, ok
+}
+
+func (c *snapshotCache) evictExpiredUnsafe(now time.Time) {
+	for elem := c.lru.Back(); elem != nil; elem = c.lru.Back() {
+		if now.Sub(elem.Value.(*snapshot).lastUsed) < SnapshotTTL {
+			return
+		}
+		c.removeUnsafe(elem)
+	}
+}
+
+func (c *snapshotCache) removeUnsafe(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.snapshotsByKey, elem.Value.(*snapshot).key)
+}
+
+// getDelta returns the batch of updates which transforms the snapshot
+// identified by previousDigest into the given updates. The given updates are
+// recorded as the new snapshot for the key.
+func (c *snapshotCache) getDelta(key snapshotKey, updates []*protos.DataUpdate, previousDigest string) *protos.DataUpdateBatch {
+	keyDigests := providers.GetKeyDigests(updates)
+	current := &snapshot{key: key, digest: providers.GetDigestFromKeyDigests(keyDigests), keyDigests: keyDigests}
+	previous, ok := c.swap(current)
+
+	if previousDigest == current.digest {
+		return &protos.DataUpdateBatch{Resync: false, Digest: current.digest}
+	}
+	if previousDigest == "" || !ok || previous.digest != previousDigest {
+		return &protos.DataUpdateBatch{Updates: updates, Resync: true, Digest: current.digest}
+	}
+
+	ret := &protos.DataUpdateBatch{Resync: false, Digest: current.digest}
+	for _, update := range updates {
+		if previous.keyDigests[update.Key] != current.keyDigests[update.Key] {
+			ret.Updates = append(ret.Updates, update)
+		}
+	}
+	for k := range previous.keyDigests {
+		if _, exists := current.keyDigests[k]; !exists {
+			ret.RemovedKeys = append(ret.RemovedKeys, k)
+		}
+	}
+	sort.Strings(ret.RemovedKeys)
+	return ret
+}
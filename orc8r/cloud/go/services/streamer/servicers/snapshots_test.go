@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCache_Eviction(t *testing.T) {
+	oldSize, oldTTL := SnapshotCacheSize, SnapshotTTL
+	SnapshotCacheSize, SnapshotTTL = 2, time.Minute
+	defer func() { SnapshotCacheSize, SnapshotTTL = oldSize, oldTTL }()
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	cache := newSnapshotCache()
+	updates := []*protos.DataUpdate{{Key: "a", Value: []byte("1")}}
+	key := func(gw string) snapshotKey { return snapshotKey{streamName: "s", gatewayID: gw} }
+
+	first := cache.getDelta(key("gw1"), updates, "")
+	cache.getDelta(key("gw2"), updates, "")
+	// gw1 is used again, so gw2 is the least recently used
+	assert.False(t, cache.getDelta(key("gw1"), updates, first.Digest).Resync)
+	cache.getDelta(key("gw3"), updates, "")
+	assert.Len(t, cache.snapshotsByKey, 2)
+	assert.Contains(t, cache.snapshotsByKey, key("gw1"))
+	assert.Contains(t, cache.snapshotsByKey, key("gw3"))
+
+	// a changed stream for an evicted snapshot is a full resync
+	changed := []*protos.DataUpdate{{Key: "a", Value: []byte("2")}}
+	assert.True(t, cache.getDelta(key("gw2"), changed, first.Digest).Resync)
+	assert.Len(t, cache.snapshotsByKey, 2)
+
+	// snapshots expire after the TTL
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(2*time.Minute))
+	assert.True(t, cache.getDelta(key("gw3"), changed, first.Digest).Resync)
+	assert.Len(t, cache.snapshotsByKey, 1)
+	assert.Equal(t, 1, cache.lru.Len())
+}
@@ -16,16 +16,35 @@ the updates would be pushed through that channel.
 package servicers
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 
+	"github.com/golang/glog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type StreamingServer struct{}
 
+var (
+	// KeepOpenPollInterval is the interval at which the streamer checks for
+	// changes to a stream which a gateway has asked to keep open, when the
+	// gateway's network can't be watched.
+	KeepOpenPollInterval = 30 * time.Second
+	// KeepOpenResyncInterval is the interval at which the streamer checks for
+	// changes to a kept open stream while watching the gateway's network,
+	// which bounds the delay of changes to data outside of configurator. It's
+	// also the interval at which the streamer tries to watch the network of
+	// a gateway whose stream is being polled.
+	KeepOpenResyncInterval = 5 * time.Minute
+)
+
 func GetUpdatesUnverified(
 	request *protos.StreamRequest,
 	stream protos.Streamer_GetUpdatesServer,
@@ -34,15 +53,158 @@ func GetUpdatesUnverified(
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "Stream %s does not exist", request.GetStreamName())
 	}
+	if !request.GetKeepOpen() {
+		updateBatch, err := getUpdateBatch(streamProvider, request, request.GetPreviousDigest())
+		if err != nil {
+			return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+		}
+		return stream.Send(updateBatch)
+	}
+
+	// Watch the gateway's network before loading the first batch so that no
+	// change is missed in between
+	ctx := stream.Context()
+	var changes, watchFailed <-chan struct{}
+	var lastWatch time.Time
+	cancelWatch := func() {}
+	ticker := time.NewTicker(KeepOpenResyncInterval)
+	defer func() {
+		cancelWatch()
+		ticker.Stop()
+	}()
+	// watch opens a new watch of the gateway's network, falling back to
+	// polling the stream if the network can't be watched
+	watch := func() {
+		cancelWatch()
+		var watchCtx context.Context
+		watchCtx, cancelWatch = context.WithCancel(ctx)
+		lastWatch = time.Now()
+		interval := KeepOpenResyncInterval
+		var err error
+		changes, watchFailed, err = watchGatewayNetwork(watchCtx, request.GetGatewayId())
+		if err != nil {
+			glog.V(2).Infof("Polling stream %s for gateway %s, failed to watch network: %s", request.GetStreamName(), request.GetGatewayId(), err)
+			interval = KeepOpenPollInterval
+		}
+		ticker.Stop()
+		ticker = time.NewTicker(interval)
+	}
+	watch()
+
+	lastDigest := request.GetPreviousDigest()
+	for sent := false; ; sent = true {
+		if sent {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-watchFailed:
+				// Changes made while the watch was down are picked up by the
+				// update check below. A watch which fails right away isn't
+				// retried before the next resync.
+				if time.Since(lastWatch) < KeepOpenPollInterval {
+					glog.Warningf("Watch of network of gateway %s failed, polling stream %s", request.GetGatewayId(), request.GetStreamName())
+					cancelWatch()
+					changes, watchFailed = nil, nil
+					ticker.Stop()
+					ticker = time.NewTicker(KeepOpenPollInterval)
+				} else {
+					glog.Warningf("Watch of network of gateway %s failed, watching it again for stream %s", request.GetGatewayId(), request.GetStreamName())
+					watch()
+				}
+			case <-changes:
+			case <-ticker.C:
+				if changes == nil && time.Since(lastWatch) >= KeepOpenResyncInterval {
+					watch()
+				}
+			}
+		}
+
+		updateBatch, err := getUpdateBatch(streamProvider, request, lastDigest)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+		}
+		if sent && updateBatch.Digest == lastDigest {
+			continue
+		}
+		if err := stream.Send(updateBatch); err != nil {
+			return err
+		}
+		lastDigest = updateBatch.Digest
+	}
+}
+
+// watchGatewayNetwork watches the entities and the configuration of the
+// gateway's network. The returned changes channel receives a value after
+// changes are made to the network; multiple changes may be coalesced into a
+// single value. The returned failed channel is closed if the watch fails.
+// Both channels are only valid until the context is done.
+func watchGatewayNetwork(ctx context.Context, gatewayID string) (<-chan struct{}, <-chan struct{}, error) {
+	networkID, _, err := configurator.GetNetworkAndEntityIDForPhysicalID(gatewayID)
+	if err != nil {
+		return nil, nil, err
+	}
+	entityWatch, err := configurator.OpenEntityWatch(ctx, networkID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	networkWatch, err := configurator.OpenNetworkWatch(ctx, []string{networkID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	failed := make(chan struct{})
+	var failOnce sync.Once
+	notify := func(err error) {
+		if err != nil {
+			if ctx.Err() == nil {
+				glog.Errorf("Error watching network %s: %s", networkID, err)
+			}
+			failOnce.Do(func() { close(failed) })
+			return
+		}
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+	go func() {
+		for {
+			_, err := entityWatch.Recv()
+			notify(err)
+			if err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			_, err := networkWatch.Recv()
+			notify(err)
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return changes, failed, nil
+}
+
+// getUpdateBatch returns the batch of updates to send to a gateway which last
+// applied the version of the stream identified by previousDigest.
+func getUpdateBatch(
+	streamProvider providers.StreamProvider,
+	request *protos.StreamRequest,
+	previousDigest string,
+) (*protos.DataUpdateBatch, error) {
+	if incrementalProvider, ok := streamProvider.(providers.IncrementalStreamProvider); ok {
+		return incrementalProvider.GetUpdatesSince(request.GetGatewayId(), request.ExtraArgs, previousDigest)
+	}
+
 	updates, err := streamProvider.GetUpdates(request.GetGatewayId(), request.ExtraArgs)
 	if err != nil {
-		return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+		return nil, err
 	}
-	updateBatch := new(protos.DataUpdateBatch)
-	updateBatch.Resync = true
-	updateBatch.Updates = updates
-	stream.Send(updateBatch)
-	return nil
+	return snapshots.getDelta(snapshotKey{streamName: request.GetStreamName(), gatewayID: request.GetGatewayId()}, updates, previousDigest), nil
 }
 
 func (srv *StreamingServer) GetUpdates(
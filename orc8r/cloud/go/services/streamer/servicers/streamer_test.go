@@ -10,12 +10,18 @@ package servicers_test
 
 import (
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/registry"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/services/streamer"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/services/streamer/servicers"
 	streamer_test_init "magma/orc8r/cloud/go/services/streamer/test_init"
 
 	"github.com/golang/protobuf/ptypes/any"
@@ -84,3 +90,149 @@ func TestStreamingServer_GetUpdates(t *testing.T) {
 	_, err = streamerClient.Recv()
 	assert.Error(t, err, "Stream stream_dne does not exist", codes.Unavailable)
 }
+
+func TestStreamingServer_GetUpdates_Incremental(t *testing.T) {
+	streamer_test_init.StartTestService(t)
+	conn, err := registry.GetConnection(streamer.ServiceName)
+	assert.NoError(t, err)
+	grpcClient := protos.NewStreamerClient(conn)
+
+	provider := &mockStreamProvider{
+		name: "incremental",
+		retVal: []*protos.DataUpdate{
+			{Key: "a", Value: []byte("123")},
+			{Key: "b", Value: []byte("456")},
+		},
+	}
+	providers.RegisterStreamProvider(provider)
+
+	// No previous digest: full resync
+	first := recvOne(t, grpcClient, &protos.StreamRequest{GatewayId: "hwId", StreamName: "incremental"})
+	assert.True(t, first.Resync)
+	assert.Len(t, first.Updates, 2)
+	assert.NotEmpty(t, first.Digest)
+	assert.Equal(t, providers.GetDigest(provider.retVal), first.Digest)
+
+	// Nothing changed: empty delta with the same digest
+	unchanged := recvOne(t, grpcClient, &protos.StreamRequest{GatewayId: "hwId", StreamName: "incremental", PreviousDigest: first.Digest})
+	assert.False(t, unchanged.Resync)
+	assert.Empty(t, unchanged.Updates)
+	assert.Empty(t, unchanged.RemovedKeys)
+	assert.Equal(t, first.Digest, unchanged.Digest)
+
+	// Change b, remove a, add c
+	provider.retVal = []*protos.DataUpdate{
+		{Key: "b", Value: []byte("789")},
+		{Key: "c", Value: []byte("abc")},
+	}
+	delta := recvOne(t, grpcClient, &protos.StreamRequest{GatewayId: "hwId", StreamName: "incremental", PreviousDigest: first.Digest})
+	assert.False(t, delta.Resync)
+	assert.Equal(t, []string{"a"}, delta.RemovedKeys)
+	assert.Equal(t, protos.TestMarshal(&protos.DataUpdate{Key: "b", Value: []byte("789")}), protos.TestMarshal(delta.Updates[0]))
+	assert.Equal(t, protos.TestMarshal(&protos.DataUpdate{Key: "c", Value: []byte("abc")}), protos.TestMarshal(delta.Updates[1]))
+	assert.Equal(t, providers.GetDigest(provider.retVal), delta.Digest)
+
+	// Unknown previous digest: full resync
+	resync := recvOne(t, grpcClient, &protos.StreamRequest{GatewayId: "hwId", StreamName: "incremental", PreviousDigest: "stale"})
+	assert.True(t, resync.Resync)
+	assert.Len(t, resync.Updates, 2)
+	assert.Empty(t, resync.RemovedKeys)
+}
+
+func TestStreamingServer_GetUpdates_KeepOpen(t *testing.T) {
+	streamer_test_init.StartTestService(t)
+	conn, err := registry.GetConnection(streamer.ServiceName)
+	assert.NoError(t, err)
+	grpcClient := protos.NewStreamerClient(conn)
+
+	oldInterval := servicers.KeepOpenPollInterval
+	servicers.KeepOpenPollInterval = 10 * time.Millisecond
+	defer func() { servicers.KeepOpenPollInterval = oldInterval }()
+
+	provider := &syncedStreamProvider{name: "keep_open", retVal: []*protos.DataUpdate{{Key: "a", Value: []byte("123")}}}
+	providers.RegisterStreamProvider(provider)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient, err := grpcClient.GetUpdates(ctx, &protos.StreamRequest{GatewayId: "hwId", StreamName: "keep_open", KeepOpen: true})
+	assert.NoError(t, err)
+
+	first, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, first.Resync)
+
+	provider.set([]*protos.DataUpdate{{Key: "a", Value: []byte("456")}})
+	second, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.False(t, second.Resync)
+	assert.Len(t, second.Updates, 1)
+	assert.Equal(t, []byte("456"), second.Updates[0].Value)
+	assert.NotEqual(t, first.Digest, second.Digest)
+}
+
+func TestStreamingServer_GetUpdates_KeepOpenWatch(t *testing.T) {
+	configurator_test_init.StartTestService(t)
+	streamer_test_init.StartTestService(t)
+	conn, err := registry.GetConnection(streamer.ServiceName)
+	assert.NoError(t, err)
+	grpcClient := protos.NewStreamerClient(conn)
+
+	// Only changes to the gateway's network should trigger an update
+	oldPoll, oldResync := servicers.KeepOpenPollInterval, servicers.KeepOpenResyncInterval
+	servicers.KeepOpenPollInterval, servicers.KeepOpenResyncInterval = time.Hour, time.Hour
+	defer func() { servicers.KeepOpenPollInterval, servicers.KeepOpenResyncInterval = oldPoll, oldResync }()
+
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n1"}))
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "gw1", PhysicalID: "hw1"})
+	assert.NoError(t, err)
+
+	provider := &syncedStreamProvider{name: "keep_open_watch", retVal: []*protos.DataUpdate{{Key: "a", Value: []byte("123")}}}
+	providers.RegisterStreamProvider(provider)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient, err := grpcClient.GetUpdates(ctx, &protos.StreamRequest{GatewayId: "hw1", StreamName: "keep_open_watch", KeepOpen: true})
+	assert.NoError(t, err)
+	first, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, first.Resync)
+
+	provider.set([]*protos.DataUpdate{{Key: "a", Value: []byte("456")}})
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	second, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.False(t, second.Resync)
+	assert.Len(t, second.Updates, 1)
+	assert.Equal(t, []byte("456"), second.Updates[0].Value)
+}
+
+type syncedStreamProvider struct {
+	sync.Mutex
+	name   string
+	retVal []*protos.DataUpdate
+}
+
+func (m *syncedStreamProvider) GetStreamName() string {
+	return m.name
+}
+
+func (m *syncedStreamProvider) GetUpdates(gatewayId string, extraArgs *any.Any) ([]*protos.DataUpdate, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.retVal, nil
+}
+
+func (m *syncedStreamProvider) set(updates []*protos.DataUpdate) {
+	m.Lock()
+	defer m.Unlock()
+	m.retVal = updates
+}
+
+func recvOne(t *testing.T, client protos.StreamerClient, request *protos.StreamRequest) *protos.DataUpdateBatch {
+	streamerClient, err := client.GetUpdates(context.Background(), request)
+	assert.NoError(t, err)
+	ret, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	return ret
+}
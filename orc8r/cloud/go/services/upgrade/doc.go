@@ -0,0 +1,19 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package upgrade contains the upgrade service.
+// Release channels and tiers are managed through the orc8r REST API and stored
+// in configurator. The upgrade service advances the staged rollouts of tiers
+// through their waves. Only the replica holding the controller lease in the
+// datastore advances rollouts.
+package upgrade
+
+const (
+	// ServiceName is the name of this service
+	ServiceName = "UPGRADE"
+)
@@ -0,0 +1,346 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rollouts
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/cloud/go/errors"
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+)
+
+const (
+	defaultCheckinTimeout = 5 * time.Minute
+
+	// magmaPackageName is the name of the package gateways report their
+	// version with
+	magmaPackageName = "magma"
+)
+
+// Controller advances in progress rollouts through their waves. Before
+// advancing, it checks that the gateways of the current wave run the rollout's
+// version, and at any time that they keep checking in. Rollouts whose gateways
+// fail either check are paused.
+// All writes are conditioned on the version of the rollout the controller
+// read, so operator changes made during a reconciliation aren't overwritten.
+type Controller struct {
+	lease *LeaderLease
+}
+
+// NewController returns a controller which only reconciles rollouts while it
+// holds the lease, so a single replica of the upgrade service advances
+// rollouts at a time. A nil lease always reconciles.
+func NewController(lease *LeaderLease) *Controller {
+	return &Controller{lease: lease}
+}
+
+// Run reconciles the rollouts of all networks every interval. It never
+// returns.
+func (c *Controller) Run(interval time.Duration) {
+	for {
+		if c.isLeader() {
+			if err := c.ReconcileAll(); err != nil {
+				glog.Errorf("Error reconciling rollouts: %v", err)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+func (c *Controller) isLeader() bool {
+	if c.lease == nil {
+		return true
+	}
+	isLeader, err := c.lease.Acquire()
+	if err != nil {
+		glog.Errorf("Error acquiring rollout controller lease: %v", err)
+		return false
+	}
+	return isLeader
+}
+
+// ReconcileAll reconciles the rollouts of all networks
+func (c *Controller) ReconcileAll() error {
+	networkIDs, err := configurator.ListNetworkIDs()
+	if err != nil {
+		return err
+	}
+	for _, networkID := range networkIDs {
+		if err := c.ReconcileNetwork(networkID); err != nil {
+			glog.Errorf("Error reconciling rollouts of network %s: %v", networkID, err)
+		}
+	}
+	return nil
+}
+
+// ReconcileNetwork reconciles the in progress rollouts of the network
+func (c *Controller) ReconcileNetwork(networkID string) error {
+	rollouts, versions, err := listWithVersions(networkID)
+	if err != nil {
+		return err
+	}
+	for i, rollout := range rollouts {
+		if err := c.reconcileWithRetry(networkID, rollout, versions[i]); err != nil {
+			glog.Errorf("Error reconciling rollout %s of network %s: %v", rollout.ID, networkID, err)
+		}
+	}
+	return nil
+}
+
+// reconcileWithRetry reconciles the rollout read at the given version. If the
+// rollout was modified concurrently, it's read and reconciled again.
+func (c *Controller) reconcileWithRetry(networkID string, rollout *models.Rollout, version uint64) error {
+	for i := 0; ; i++ {
+		if rollout.Status == nil || rollout.Status.State != models.RolloutStatusStateInProgress {
+			return nil
+		}
+		err := c.reconcile(networkID, rollout, version)
+		if !isVersionConflict(err) {
+			return err
+		}
+		if i+1 >= maxConflictRetries {
+			return conflictErrorf("rollout %s was modified concurrently", rollout.ID)
+		}
+		rollout, version, err = load(networkID, string(rollout.ID))
+		if err == merrors.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Controller) reconcile(networkID string, rollout *models.Rollout, version uint64) error {
+	tier, err := configurator.LoadEntity(
+		networkID, orc8r.UpgradeTierEntityType, string(rollout.Tier),
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsFromThis: true},
+	)
+	if err == merrors.ErrNotFound {
+		glog.Errorf("Aborting rollout %s of network %s, tier %s was deleted", rollout.ID, networkID, rollout.Tier)
+		rollout.Status.State = models.RolloutStatusStateAborted
+		return update(networkID, rollout, version)
+	}
+	if err != nil {
+		return err
+	}
+	tierGateways := getGatewayKeys(tier.Associations)
+	status := rollout.Status
+	now := clock.Now()
+	nowMs := now.UnixNano() / int64(1e6)
+
+	if status.WaveStartedAt == 0 {
+		status.CurrentWave = 0
+		status.Gateways = selectGateways(rollout, tierGateways, rollout.Waves[0])
+		status.WaveStartedAt = nowMs
+		return update(networkID, rollout, version)
+	}
+
+	// Gateways removed from the tier are no longer part of the rollout
+	status.Gateways = filterGateways(status.Gateways, tierGateways)
+	gwStatuses, err := getGatewayStatuses(networkID, status.Gateways)
+	if err != nil {
+		return err
+	}
+	if reason := checkCheckins(rollout, gwStatuses, now); reason != "" {
+		return pause(networkID, rollout, version, reason)
+	}
+	waveEnd := time.Unix(0, status.WaveStartedAt*int64(1e6)).Add(time.Duration(*rollout.WaveIntervalSeconds) * time.Second)
+	if now.Before(waveEnd) {
+		return nil
+	}
+	if reason := checkVersions(rollout, gwStatuses); reason != "" {
+		return pause(networkID, rollout, version, reason)
+	}
+
+	if int(status.CurrentWave) >= len(rollout.Waves)-1 {
+		return complete(networkID, rollout, version, tier)
+	}
+	status.CurrentWave++
+	status.Gateways = selectGateways(rollout, tierGateways, rollout.Waves[status.CurrentWave])
+	status.WaveStartedAt = nowMs
+	return update(networkID, rollout, version)
+}
+
+func pause(networkID string, rollout *models.Rollout, version uint64, reason string) error {
+	glog.Warningf("Pausing rollout %s of network %s: %s", rollout.ID, networkID, reason)
+	rollout.Status.State = models.RolloutStatusStatePaused
+	rollout.Status.PauseReason = reason
+	return update(networkID, rollout, version)
+}
+
+// complete sets the tier's version to the rollout's version and ends the
+// rollout in the same write, so no gateway goes back to the previous version.
+// The write fails if either the rollout or the tier changed since they were
+// read.
+func complete(networkID string, rollout *models.Rollout, version uint64, tier configurator.NetworkEntity) error {
+	tierConfig, ok := tier.Config.(*models.Tier)
+	if !ok {
+		return fmt.Errorf("invalid config of tier %s", tier.Key)
+	}
+	tierConfig.Version = rollout.Version
+	rollout.Status.State = models.RolloutStatusStateCompleted
+	_, err := configurator.UpdateEntities(networkID, []configurator.EntityUpdateCriteria{
+		{Type: orc8r.UpgradeTierEntityType, Key: tier.Key, NewConfig: tierConfig, ExpectedVersion: &tier.Version},
+		getFinishUpdate(rollout, version),
+	})
+	return err
+}
+
+// checkCheckins returns the reason to pause the rollout if any of the
+// rollout's gateways hasn't checked in within the checkin timeout
+func checkCheckins(rollout *models.Rollout, gwStatuses map[string]*models.GatewayStatus, now time.Time) string {
+	timeout := defaultCheckinTimeout
+	if rollout.CheckinTimeoutSeconds > 0 {
+		timeout = time.Duration(rollout.CheckinTimeoutSeconds) * time.Second
+	}
+	for _, gw := range rollout.Status.Gateways {
+		gwStatus, ok := gwStatuses[string(gw)]
+		if !ok {
+			return fmt.Sprintf("gateway %s has not reported its status", gw)
+		}
+		lastCheckin := time.Unix(0, int64(gwStatus.CheckinTime)*int64(1e6))
+		if now.Sub(lastCheckin) > timeout {
+			return fmt.Sprintf("gateway %s failed to check in since %s", gw, lastCheckin.UTC().Format(time.RFC3339))
+		}
+	}
+	return ""
+}
+
+// checkVersions returns the reason to pause the rollout if any of the
+// rollout's gateways doesn't run the rollout's version
+func checkVersions(rollout *models.Rollout, gwStatuses map[string]*models.GatewayStatus) string {
+	for _, gw := range rollout.Status.Gateways {
+		reported := getReportedVersion(gwStatuses[string(gw)])
+		if reported != rollout.Version.ToString() {
+			return fmt.Sprintf("gateway %s runs version %q instead of %q", gw, reported, rollout.Version)
+		}
+	}
+	return ""
+}
+
+func getReportedVersion(gwStatus *models.GatewayStatus) string {
+	if gwStatus == nil {
+		return ""
+	}
+	if gwStatus.PlatformInfo != nil {
+		for _, pkg := range gwStatus.PlatformInfo.Packages {
+			if pkg != nil && pkg.Name == magmaPackageName {
+				return pkg.Version
+			}
+		}
+	}
+	return gwStatus.Version
+}
+
+// getGatewayStatuses returns the statuses of the gateways keyed by gateway ID
+func getGatewayStatuses(networkID string, gatewayIDs []models1.GatewayID) (map[string]*models.GatewayStatus, error) {
+	ret := map[string]*models.GatewayStatus{}
+	if len(gatewayIDs) == 0 {
+		return ret, nil
+	}
+	tks := make([]storage.TypeAndKey, 0, len(gatewayIDs))
+	for _, gw := range gatewayIDs {
+		tks = append(tks, storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: string(gw)})
+	}
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	gatewayIDsByHwID := map[string]string{}
+	hwIDs := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		gatewayIDsByHwID[ent.PhysicalID] = ent.Key
+		hwIDs = append(hwIDs, ent.PhysicalID)
+	}
+	statuses, err := state.GetGatewayStatuses(networkID, hwIDs)
+	if err != nil {
+		return nil, err
+	}
+	for hwID, gwStatus := range statuses {
+		if gwStatus != nil {
+			ret[gatewayIDsByHwID[hwID]] = gwStatus
+		}
+	}
+	return ret, nil
+}
+
+// selectGateways returns the gateways the rollout reached after a wave of the
+// given percentage. Gateways are added in an order fixed by the rollout ID, so
+// the gateways of earlier waves are always part of later waves.
+func selectGateways(rollout *models.Rollout, tierGateways []string, percent uint32) []models1.GatewayID {
+	target := (len(tierGateways)*int(percent) + 99) / 100
+	selected := filterGateways(rollout.Status.Gateways, tierGateways)
+	isSelected := map[string]bool{}
+	for _, gw := range selected {
+		isSelected[string(gw)] = true
+	}
+
+	candidates := make([]string, 0, len(tierGateways))
+	for _, gw := range tierGateways {
+		if !isSelected[gw] {
+			candidates = append(candidates, gw)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		hi, hj := hashGateway(rollout.ID, candidates[i]), hashGateway(rollout.ID, candidates[j])
+		if hi != hj {
+			return hi < hj
+		}
+		return candidates[i] < candidates[j]
+	})
+	for _, gw := range candidates {
+		if len(selected) >= target {
+			break
+		}
+		selected = append(selected, models1.GatewayID(gw))
+	}
+	return selected
+}
+
+func hashGateway(rolloutID models.RolloutID, gatewayID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(string(rolloutID) + "/" + gatewayID))
+	return h.Sum32()
+}
+
+func filterGateways(gateways []models1.GatewayID, tierGateways []string) []models1.GatewayID {
+	inTier := map[string]bool{}
+	for _, gw := range tierGateways {
+		inTier[gw] = true
+	}
+	ret := []models1.GatewayID{}
+	for _, gw := range gateways {
+		if inTier[string(gw)] {
+			ret = append(ret, gw)
+		}
+	}
+	return ret
+}
+
+func getGatewayKeys(tks []storage.TypeAndKey) []string {
+	ret := []string{}
+	for _, tk := range tks {
+		if tk.Type == orc8r.MagmadGatewayType {
+			ret = append(ret, tk.Key)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rollouts
+
+import (
+	"testing"
+
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_ConcurrentModification(t *testing.T) {
+	// pluginimpl depends on this package, so the serdes it would register
+	// are registered here. If another test already registered the plugin,
+	// its serdes are used instead and left in place.
+	err := serde.RegisterSerdes(
+		configurator.NewNetworkEntityConfigSerde(orc8r.UpgradeTierEntityType, &models.Tier{}),
+		configurator.NewNetworkEntityConfigSerde(orc8r.UpgradeRolloutEntityType, &models.Rollout{}),
+	)
+	if err == nil {
+		defer serde.UnregisterSerdesForDomain(t, configurator.NetworkEntitySerdeDomain)
+	}
+	configuratorTestInit.StartTestService(t)
+	require.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n1"}))
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{
+		Type: orc8r.UpgradeTierEntityType, Key: "t1",
+		Config: &models.Tier{ID: "t1", Version: "1.0.0", Images: models.TierImages{}, Gateways: models.TierGateways{}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, Start("n1", &models.Rollout{
+		ID:                  "r1",
+		Tier:                "t1",
+		Version:             "2.0.0",
+		Waves:               []uint32{100},
+		WaveIntervalSeconds: swag.Uint32(3600),
+	}))
+	rollout, version, err := load("n1", "r1")
+	require.NoError(t, err)
+
+	// The operator pauses the rollout after the controller read it
+	require.NoError(t, Pause("n1", "r1", "paused by operator"))
+	err = NewController(nil).reconcile("n1", rollout, version)
+	assert.True(t, isVersionConflict(err))
+	paused, err := Get("n1", "r1")
+	require.NoError(t, err)
+	assert.Equal(t, models.RolloutStatusStatePaused, paused.Status.State)
+
+	// The controller rereads modified rollouts and leaves paused ones alone
+	rollout, version, err = load("n1", "r1")
+	require.NoError(t, err)
+	require.NoError(t, Resume("n1", "r1"))
+	rollout.Status.State = models.RolloutStatusStateInProgress
+	require.NoError(t, NewController(nil).reconcileWithRetry("n1", rollout, version))
+	resumed, err := Get("n1", "r1")
+	require.NoError(t, err)
+	assert.Equal(t, models.RolloutStatusStateInProgress, resumed.Status.State)
+	assert.NotZero(t, resumed.Status.WaveStartedAt)
+	assert.Equal(t, []models1.GatewayID{}, resumed.Status.Gateways)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rollouts_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/device"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+	stateTestInit "magma/orc8r/cloud/go/services/state/test_init"
+	stateTestUtils "magma/orc8r/cloud/go/services/state/test_utils"
+	"magma/orc8r/cloud/go/services/upgrade/rollouts"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gateways = map[string]string{"g1": "hw1", "g2": "hw2", "g3": "hw3", "g4": "hw4"}
+
+func TestController_Reconcile(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	start := time.Unix(1000000, 0)
+	clock.SetAndFreezeClock(t, start)
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+	setupTier(t)
+	controller := rollouts.NewController(nil)
+
+	// Invalid starts
+	err := rollouts.Start("n1", newRollout("r0", "unknown"))
+	assert.IsType(t, &rollouts.ConflictError{}, err)
+	require.NoError(t, rollouts.Start("n1", newRollout("r1", "t1")))
+	err = rollouts.Start("n1", newRollout("r2", "t1"))
+	assert.EqualError(t, err, "tier t1 already has active rollout r1")
+
+	// First wave reaches 25% of the gateways
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout := getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStateInProgress, rollout.Status.State)
+	assert.Equal(t, uint32(0), rollout.Status.CurrentWave)
+	assert.Equal(t, start.Unix()*1000, rollout.Status.WaveStartedAt)
+	require.Len(t, rollout.Status.Gateways, 1)
+	firstGw := string(rollout.Status.Gateways[0])
+
+	// Gateways of the wave which don't report their status pause the rollout
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStatePaused, rollout.Status.State)
+	assert.Equal(t, "gateway "+firstGw+" has not reported its status", rollout.Status.PauseReason)
+
+	// Paused rollouts aren't reconciled
+	reportVersion(t, firstGw, "2.0.0")
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	assert.Equal(t, models.RolloutStatusStatePaused, getRollout(t, "r1").Status.State)
+	require.NoError(t, rollouts.Resume("n1", "r1"))
+	assert.IsType(t, &rollouts.ConflictError{}, rollouts.Resume("n1", "r1"))
+
+	// Gateways not running the version at the end of the wave pause the
+	// rollout
+	clock.SetAndFreezeClock(t, start.Add(time.Hour))
+	reportVersion(t, firstGw, "1.0.0")
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStatePaused, rollout.Status.State)
+	assert.Equal(t, `gateway `+firstGw+` runs version "1.0.0" instead of "2.0.0"`, rollout.Status.PauseReason)
+
+	// Resuming restarts the wave
+	require.NoError(t, rollouts.Resume("n1", "r1"))
+	reportVersion(t, firstGw, "2.0.0")
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStateInProgress, rollout.Status.State)
+	assert.Equal(t, uint32(0), rollout.Status.CurrentWave)
+
+	// Second wave reaches all gateways
+	clock.SetAndFreezeClock(t, start.Add(2*time.Hour))
+	reportVersion(t, firstGw, "2.0.0")
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStateInProgress, rollout.Status.State)
+	assert.Equal(t, uint32(1), rollout.Status.CurrentWave)
+	assert.Equal(t, start.Add(2*time.Hour).Unix()*1000, rollout.Status.WaveStartedAt)
+	assert.Len(t, rollout.Status.Gateways, 4)
+	assert.Equal(t, models1.GatewayID(firstGw), rollout.Status.Gateways[0])
+
+	// Gateways which stop checking in pause the rollout
+	for gw := range gateways {
+		reportVersion(t, gw, "2.0.0")
+	}
+	clock.SetAndFreezeClock(t, start.Add(2*time.Hour+10*time.Minute))
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStatePaused, rollout.Status.State)
+	assert.Contains(t, rollout.Status.PauseReason, "failed to check in since")
+	require.NoError(t, rollouts.Resume("n1", "r1"))
+
+	// Completing the last wave sets the tier version
+	clock.SetAndFreezeClock(t, start.Add(3*time.Hour+10*time.Minute))
+	for gw := range gateways {
+		reportVersion(t, gw, "2.0.0")
+	}
+	require.NoError(t, controller.ReconcileNetwork("n1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStateCompleted, rollout.Status.State)
+	tier, err := configurator.LoadEntityConfig("n1", orc8r.UpgradeTierEntityType, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TierVersion("2.0.0"), tier.(*models.Tier).Version)
+	ent, err := configurator.LoadEntity("n1", orc8r.UpgradeRolloutEntityType, "r1", configurator.EntityLoadCriteria{LoadAssocsFromThis: true})
+	assert.NoError(t, err)
+	assert.Empty(t, ent.Associations)
+	assert.IsType(t, &rollouts.ConflictError{}, rollouts.Pause("n1", "r1", "paused"))
+}
+
+func TestRollouts_Abort(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+	setupTier(t)
+
+	require.NoError(t, rollouts.Start("n1", newRollout("r1", "t1")))
+	require.NoError(t, rollouts.NewController(nil).ReconcileNetwork("n1"))
+	assert.IsType(t, &rollouts.ConflictError{}, rollouts.Delete("n1", "r1"))
+	require.NoError(t, rollouts.Pause("n1", "r1", "paused by operator"))
+	rollout := getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStatePaused, rollout.Status.State)
+	assert.Equal(t, "paused by operator", rollout.Status.PauseReason)
+
+	require.NoError(t, rollouts.Abort("n1", "r1"))
+	rollout = getRollout(t, "r1")
+	assert.Equal(t, models.RolloutStatusStateAborted, rollout.Status.State)
+	assert.False(t, rollout.IsActive())
+	assert.IsType(t, &rollouts.ConflictError{}, rollouts.Abort("n1", "r1"))
+	tier, err := configurator.LoadEntityConfig("n1", orc8r.UpgradeTierEntityType, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TierVersion("1.0.0"), tier.(*models.Tier).Version)
+
+	// A new rollout can start once the previous one is aborted
+	require.NoError(t, rollouts.Start("n1", newRollout("r2", "t1")))
+	require.NoError(t, rollouts.Delete("n1", "r1"))
+	all, err := rollouts.List("n1")
+	assert.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, models.RolloutID("r2"), all[0].ID)
+}
+
+func setupTier(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	stateTestInit.StartTestService(t)
+
+	require.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n1"}))
+	ents := []configurator.NetworkEntity{}
+	tierGateways := []storage.TypeAndKey{}
+	for gw, hwID := range gateways {
+		ents = append(ents, configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: gw, PhysicalID: hwID})
+		tierGateways = append(tierGateways, storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: gw})
+		err := device.RegisterDevice("n1", orc8r.AccessGatewayRecordType, hwID, &models.GatewayDevice{HardwareID: hwID, Key: &models.ChallengeKey{KeyType: "ECHO"}})
+		require.NoError(t, err)
+	}
+	ents = append(ents, configurator.NetworkEntity{
+		Type: orc8r.UpgradeTierEntityType, Key: "t1",
+		Config: &models.Tier{
+			ID:       "t1",
+			Version:  "1.0.0",
+			Images:   models.TierImages{},
+			Gateways: models.TierGateways{},
+		},
+		Associations: tierGateways,
+	})
+	_, err := configurator.CreateEntities("n1", ents)
+	require.NoError(t, err)
+}
+
+func newRollout(id string, tier string) *models.Rollout {
+	return &models.Rollout{
+		ID:                  models.RolloutID(id),
+		Tier:                models.TierID(tier),
+		Version:             "2.0.0",
+		Waves:               []uint32{25, 100},
+		WaveIntervalSeconds: swag.Uint32(3600),
+	}
+}
+
+func getRollout(t *testing.T, id string) *models.Rollout {
+	rollout, err := rollouts.Get("n1", id)
+	require.NoError(t, err)
+	return rollout
+}
+
+func reportVersion(t *testing.T, gatewayID string, version string) {
+	hwID := gateways[gatewayID]
+	gwStatus := models.NewDefaultGatewayStatus(hwID)
+	gwStatus.PlatformInfo.Packages = []*models.Package{{Name: "magma", Version: version}}
+	ctx := stateTestUtils.GetContextWithCertificate(t, hwID)
+	stateTestUtils.ReportGatewayStatus(t, ctx, gwStatus)
+}
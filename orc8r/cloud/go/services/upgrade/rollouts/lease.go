@@ -0,0 +1,81 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rollouts
+
+import (
+	"encoding/json"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	leaseTable = "rollout_controller_lease"
+	leaseKey   = "leader"
+)
+
+// LeaderLease elects a single leader among the replicas of the upgrade
+// service. The leader holds a lease in the datastore until it stops renewing
+// it, after which any other replica can acquire it.
+type LeaderLease struct {
+	store    datastore.TxApi
+	holderID string
+	ttl      time.Duration
+}
+
+type lease struct {
+	HolderID  string `json:"holder_id"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// NewLeaderLease returns a lease acquired by holderID, which must be unique
+// among the replicas. The lease expires ttl after it was last acquired.
+func NewLeaderLease(store datastore.TxApi, holderID string, ttl time.Duration) *LeaderLease {
+	return &LeaderLease{store: store, holderID: holderID, ttl: ttl}
+}
+
+// Acquire acquires the lease if it's free or expired, or renews it if it's
+// already held by this replica. It returns true if this replica holds the
+// lease.
+func (l *LeaderLease) Acquire() (bool, error) {
+	acquired := false
+	err := l.store.DoInTx([]string{leaseTable}, func(store datastore.TxApi) error {
+		acquired = false
+		now := clock.Now()
+		marshaledLease, _, err := store.Get(leaseTable, leaseKey)
+		if err != nil && !datastore.IsErrNotFound(err) {
+			return errors.Wrap(err, "failed to load lease")
+		}
+		if err == nil {
+			current := lease{}
+			if err := json.Unmarshal(marshaledLease, &current); err != nil {
+				return errors.Wrap(err, "failed to unmarshal lease")
+			}
+			if current.HolderID != l.holderID && now.Before(time.Unix(0, current.ExpiresAt)) {
+				return nil
+			}
+		}
+		marshaledLease, err = json.Marshal(lease{HolderID: l.holderID, ExpiresAt: now.Add(l.ttl).UnixNano()})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal lease")
+		}
+		if err := store.Put(leaseTable, leaseKey, marshaledLease); err != nil {
+			return errors.Wrap(err, "failed to write lease")
+		}
+		acquired = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return acquired, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rollouts_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/upgrade/rollouts"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderLease(t *testing.T) {
+	start := time.Unix(1000000, 0)
+	clock.SetAndFreezeClock(t, start)
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+	store := test_utils.NewMockDatastore()
+	replica1 := rollouts.NewLeaderLease(store, "replica1", time.Minute)
+	replica2 := rollouts.NewLeaderLease(store, "replica2", time.Minute)
+
+	// The first replica acquires the lease and renews it
+	assertAcquire(t, replica1, true)
+	assertAcquire(t, replica2, false)
+	clock.SetAndFreezeClock(t, start.Add(50*time.Second))
+	assertAcquire(t, replica1, true)
+	clock.SetAndFreezeClock(t, start.Add(100*time.Second))
+	assertAcquire(t, replica2, false)
+
+	// Another replica takes over once the lease expires
+	clock.SetAndFreezeClock(t, start.Add(110*time.Second))
+	assertAcquire(t, replica2, true)
+	assertAcquire(t, replica1, false)
+}
+
+func assertAcquire(t *testing.T, lease *rollouts.LeaderLease, expected bool) {
+	acquired, err := lease.Acquire()
+	require.NoError(t, err)
+	assert.Equal(t, expected, acquired)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package rollouts implements staged rollouts of a version to the gateways of
+// an upgrade tier. A rollout moves a growing percentage of the tier's gateways
+// to its version in waves, and sets the tier's version once the last wave
+// completes. Gateways reached by the waves of an in progress or paused rollout
+// get the rollout's version in their mconfig, all other gateways keep the
+// tier's version.
+package rollouts
+
+import (
+	"fmt"
+
+	"magma/orc8r/cloud/go/clock"
+	models1 "magma/orc8r/cloud/go/models"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxConflictRetries is how many times a rollout operation is attempted when
+// the rollout keeps being modified between its read and its write
+const maxConflictRetries = 3
+
+// ConflictError is returned when the state of a rollout or of its tier doesn't
+// allow the requested operation
+type ConflictError struct {
+	msg string
+}
+
+func (e *ConflictError) Error() string {
+	return e.msg
+}
+
+func conflictErrorf(format string, args ...interface{}) error {
+	return &ConflictError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Start creates the rollout in progress. Its first wave starts with the next
+// reconciliation of the rollout controller. A tier can only have one active
+// rollout at a time.
+func Start(networkID string, rollout *models.Rollout) error {
+	exists, err := configurator.DoesEntityExist(networkID, orc8r.UpgradeTierEntityType, string(rollout.Tier))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return conflictErrorf("tier %s does not exist", rollout.Tier)
+	}
+	rollouts, err := List(networkID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range rollouts {
+		if existing.Tier == rollout.Tier && existing.IsActive() {
+			return conflictErrorf("tier %s already has active rollout %s", rollout.Tier, existing.ID)
+		}
+	}
+	rollout.Status = &models.RolloutStatus{
+		State:    models.RolloutStatusStateInProgress,
+		Gateways: []models1.GatewayID{},
+	}
+	_, err = configurator.CreateEntity(networkID, rollout.ToNetworkEntity())
+	return err
+}
+
+// Get returns the rollout with its progress
+func Get(networkID string, rolloutID string) (*models.Rollout, error) {
+	rollout, _, err := load(networkID, rolloutID)
+	return rollout, err
+}
+
+// List returns all rollouts of the network
+func List(networkID string) ([]*models.Rollout, error) {
+	rollouts, _, err := listWithVersions(networkID)
+	return rollouts, err
+}
+
+// Pause stops an in progress rollout from advancing. The gateways the rollout
+// reached keep its version.
+func Pause(networkID string, rolloutID string, reason string) error {
+	return retryOnConflict(rolloutID, func() error {
+		rollout, version, err := load(networkID, rolloutID)
+		if err != nil {
+			return err
+		}
+		if rollout.Status.State != models.RolloutStatusStateInProgress {
+			return conflictErrorf("rollout %s is %s", rolloutID, rollout.Status.State)
+		}
+		rollout.Status.State = models.RolloutStatusStatePaused
+		rollout.Status.PauseReason = reason
+		return update(networkID, rollout, version)
+	})
+}
+
+// Resume continues a paused rollout. Its current wave is restarted, so the
+// gateways of the wave get a full wave interval to report the version again.
+func Resume(networkID string, rolloutID string) error {
+	return retryOnConflict(rolloutID, func() error {
+		rollout, version, err := load(networkID, rolloutID)
+		if err != nil {
+			return err
+		}
+		if rollout.Status.State != models.RolloutStatusStatePaused {
+			return conflictErrorf("rollout %s is %s", rolloutID, rollout.Status.State)
+		}
+		rollout.Status.State = models.RolloutStatusStateInProgress
+		rollout.Status.PauseReason = ""
+		if rollout.Status.WaveStartedAt != 0 {
+			rollout.Status.WaveStartedAt = clock.Now().UnixNano() / int64(1e6)
+		}
+		return update(networkID, rollout, version)
+	})
+}
+
+// Abort ends an active rollout. All gateways of the tier go back to the tier's
+// version.
+func Abort(networkID string, rolloutID string) error {
+	return retryOnConflict(rolloutID, func() error {
+		rollout, version, err := load(networkID, rolloutID)
+		if err != nil {
+			return err
+		}
+		if !rollout.IsActive() {
+			return conflictErrorf("rollout %s is %s", rolloutID, rollout.Status.State)
+		}
+		rollout.Status.State = models.RolloutStatusStateAborted
+		_, err = configurator.UpdateEntity(networkID, getFinishUpdate(rollout, version))
+		return err
+	})
+}
+
+// Delete removes a completed or aborted rollout
+func Delete(networkID string, rolloutID string) error {
+	rollout, err := Get(networkID, rolloutID)
+	if err != nil {
+		return err
+	}
+	if rollout.IsActive() {
+		return conflictErrorf("rollout %s is %s, abort it first", rolloutID, rollout.Status.State)
+	}
+	return configurator.DeleteEntity(networkID, orc8r.UpgradeRolloutEntityType, rolloutID)
+}
+
+// load returns the rollout and the version of its entity, which conditions
+// the writes of the rollout's changes
+func load(networkID string, rolloutID string) (*models.Rollout, uint64, error) {
+	ent, err := configurator.LoadEntity(
+		networkID, orc8r.UpgradeRolloutEntityType, rolloutID, configurator.EntityLoadCriteria{LoadConfig: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	rollout, ok := ent.Config.(*models.Rollout)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid config of rollout %s", rolloutID)
+	}
+	return rollout, ent.Version, nil
+}
+
+// listWithVersions returns all rollouts of the network and the versions of
+// their entities
+func listWithVersions(networkID string) ([]*models.Rollout, []uint64, error) {
+	ents, err := configurator.LoadAllEntitiesInNetwork(
+		networkID, orc8r.UpgradeRolloutEntityType, configurator.EntityLoadCriteria{LoadConfig: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	rollouts := make([]*models.Rollout, 0, len(ents))
+	versions := make([]uint64, 0, len(ents))
+	for _, ent := range ents {
+		if rollout, ok := ent.Config.(*models.Rollout); ok {
+			rollouts = append(rollouts, rollout)
+			versions = append(versions, ent.Version)
+		}
+	}
+	return rollouts, versions, nil
+}
+
+// update writes the rollout if its entity is still at the version it was
+// read at
+func update(networkID string, rollout *models.Rollout, version uint64) error {
+	_, err := configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+		Type:            orc8r.UpgradeRolloutEntityType,
+		Key:             string(rollout.ID),
+		NewConfig:       rollout,
+		ExpectedVersion: &version,
+	})
+	return err
+}
+
+// getFinishUpdate returns the update of a completed or aborted rollout. The
+// rollout is detached from its tier so it's no longer part of the tier's
+// gateways' graphs.
+func getFinishUpdate(rollout *models.Rollout, version uint64) configurator.EntityUpdateCriteria {
+	return configurator.EntityUpdateCriteria{
+		Type:                 orc8r.UpgradeRolloutEntityType,
+		Key:                  string(rollout.ID),
+		NewConfig:            rollout,
+		AssociationsToDelete: []storage.TypeAndKey{{Type: orc8r.UpgradeTierEntityType, Key: string(rollout.Tier)}},
+		ExpectedVersion:      &version,
+	}
+}
+
+// isVersionConflict returns true if a write failed because the entity was
+// modified after it was read
+func isVersionConflict(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition
+}
+
+// retryOnConflict runs fn, which reads the rollout and writes its changes,
+// again while the write fails because the rollout was modified concurrently
+func retryOnConflict(rolloutID string, fn func() error) error {
+	for i := 0; i < maxConflictRetries; i++ {
+		err := fn()
+		if !isVersionConflict(err) {
+			return err
+		}
+	}
+	return conflictErrorf("rollout %s was modified concurrently", rolloutID)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/upgrade"
+	"magma/orc8r/cloud/go/services/upgrade/rollouts"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// default for how often rollouts are advanced and health checked
+	defaultReconcileInterval = time.Minute
+	// the controller lease outlives a few missed reconciliations, so
+	// leadership only changes when the leader is gone
+	leaseIntervals = 3
+)
+
+func main() {
+	srv, err := service.NewOrchestratorService(orc8r.ModuleName, upgrade.ServiceName)
+	if err != nil {
+		glog.Fatalf("Error creating upgrade service %s", err)
+	}
+
+	interval := defaultReconcileInterval
+	upgradeConfig, err := config.GetServiceConfig(orc8r.ModuleName, upgrade.ServiceName)
+	if err != nil {
+		glog.Errorf("Failed to load upgrade config, using defaults: %v", err)
+	} else if secs, err := upgradeConfig.GetIntParam("rollout_reconcile_interval_secs"); err == nil && secs > 0 {
+		interval = time.Duration(secs) * time.Second
+	}
+
+	// Only one replica runs the rollout controller at a time
+	store, err := datastore.NewSqlDb(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE, sqorc.GetSqlBuilder())
+	if err != nil {
+		glog.Fatalf("Failed to initialize datastore: %s", err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		glog.Fatalf("Failed to get hostname: %s", err)
+	}
+	holderID := fmt.Sprintf("%s/%d", hostname, os.Getpid())
+	lease := rollouts.NewLeaderLease(store, holderID, leaseIntervals*interval)
+	go rollouts.NewController(lease).Run(interval)
+
+	err = srv.Run()
+	if err != nil {
+		glog.Fatalf("Error running service: %s", err)
+	}
+}
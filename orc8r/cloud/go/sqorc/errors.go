@@ -0,0 +1,64 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sqorc
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// uniqueViolationMessages are the error messages of each supported driver for
+// a statement violating a primary key or unique constraint
+var uniqueViolationMessages = []string{
+	"duplicate key value violates unique constraint", // PostgreSQL
+	"Error 1062",               // MySQL/MariaDB ER_DUP_ENTRY
+	"UNIQUE constraint failed", // SQLite
+}
+
+// IsUniqueViolation returns true if the error, or its cause, was returned by
+// the database for a statement violating a primary key or unique constraint.
+func IsUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := errors.Cause(err).Error()
+	for _, violationMsg := range uniqueViolationMessages {
+		if strings.Contains(msg, violationMsg) {
+			return true
+		}
+	}
+	return false
+}
+
+// serializationFailureMessages are the error messages of each supported driver
+// for a transaction which failed because of a conflicting concurrent
+// transaction, and may succeed if it's retried
+var serializationFailureMessages = []string{
+	"could not serialize access", // PostgreSQL serialization_failure
+	"deadlock detected",          // PostgreSQL deadlock_detected
+	"Error 1213",                 // MySQL/MariaDB ER_LOCK_DEADLOCK
+	"database is locked",         // SQLite
+}
+
+// IsSerializationFailure returns true if the error, or its cause, was
+// returned by the database for a transaction which conflicted with a
+// concurrent transaction.
+func IsSerializationFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := errors.Cause(err).Error()
+	for _, failureMsg := range serializationFailureMessages {
+		if strings.Contains(msg, failureMsg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sqorc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE t (id INTEGER PRIMARY KEY)")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO t (id) VALUES (1)")
+	assert.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO t (id) VALUES (1)")
+	assert.True(t, sqorc.IsUniqueViolation(err))
+	assert.True(t, sqorc.IsUniqueViolation(errors.Wrap(err, "failed to insert")))
+
+	_, err = db.Exec("INSERT INTO unknown (id) VALUES (1)")
+	assert.False(t, sqorc.IsUniqueViolation(err))
+	assert.False(t, sqorc.IsUniqueViolation(nil))
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqorc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "test.db") + "?_busy_timeout=0"
+	db1, err := sqorc.Open("sqlite3", source)
+	require.NoError(t, err)
+	defer db1.Close()
+	db2, err := sqorc.Open("sqlite3", source)
+	require.NoError(t, err)
+	defer db2.Close()
+	_, err = db1.Exec("CREATE TABLE t (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+
+	tx, err := db1.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = tx.Exec("INSERT INTO t (id) VALUES (1)")
+	require.NoError(t, err)
+
+	_, err = db2.Exec("INSERT INTO t (id) VALUES (2)")
+	assert.True(t, sqorc.IsSerializationFailure(err))
+	assert.True(t, sqorc.IsSerializationFailure(errors.Wrap(err, "failed to insert")))
+
+	_, err = tx.Exec("INSERT INTO t (id) VALUES (1)")
+	assert.False(t, sqorc.IsSerializationFailure(err))
+	assert.False(t, sqorc.IsSerializationFailure(nil))
+}
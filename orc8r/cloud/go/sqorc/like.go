@@ -0,0 +1,39 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sqorc
+
+import (
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// likeEscapeChar escapes wildcards in LIKE patterns. SQLite has no default
+// escape character and MySQL string literals treat backslashes specially, so
+// the escape character is set explicitly to one which needs no quoting.
+const likeEscapeChar = "!"
+
+var likePatternEscaper = strings.NewReplacer(
+	likeEscapeChar, likeEscapeChar+likeEscapeChar,
+	"%", likeEscapeChar+"%",
+	"_", likeEscapeChar+"_",
+)
+
+// EscapeLikePattern escapes the LIKE wildcards in s so that it only matches
+// itself in a pattern built by HasPrefix.
+func EscapeLikePattern(s string) string {
+	return likePatternEscaper.Replace(s)
+}
+
+// HasPrefix returns a condition matching the rows whose column starts with
+// prefix. Wildcards in the prefix are matched literally. Depending on the
+// collation of the column, the match may be case-insensitive.
+func HasPrefix(column string, prefix string) squirrel.Sqlizer {
+	return squirrel.Expr(column+" LIKE ? ESCAPE '"+likeEscapeChar+"'", EscapeLikePattern(prefix)+"%")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sqorc_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPrefix(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE t (k TEXT)")
+	assert.NoError(t, err)
+	for _, k := range []string{"a_b", "axb", "a%c", "abc", "a!_b", "a!xb"} {
+		_, err = db.Exec("INSERT INTO t (k) VALUES (?)", k)
+		assert.NoError(t, err)
+	}
+
+	query := func(prefix string) []string {
+		rows, err := squirrel.Select("k").From("t").Where(sqorc.HasPrefix("k", prefix)).OrderBy("k").RunWith(db).Query()
+		assert.NoError(t, err)
+		defer rows.Close()
+		var ret []string
+		for rows.Next() {
+			var k string
+			assert.NoError(t, rows.Scan(&k))
+			ret = append(ret, k)
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"a_b"}, query("a_"))
+	assert.Equal(t, []string{"a%c"}, query("a%"))
+	assert.Equal(t, []string{"a!_b"}, query("a!_"))
+	assert.Equal(t, []string{"a!_b", "a!xb", "a%c", "a_b", "abc", "axb"}, query("a"))
+	assert.Equal(t, []string{"a!_b", "a!xb", "a%c", "a_b", "abc", "axb"}, query(""))
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Migration is a versioned schema change. The migrations of a module are
+// applied in increasing version order, each in its own transaction, and the
+// applied versions are recorded in the schema_version table.
+//
+// Each service applies the pending migrations of its own tables at startup,
+// once it created them, through MigrateUp. Destructive migrations are only
+// applied by the migrate tool, see tools/migrations/migrate.
+//
+// Migrations change the schema created by the services at startup, which is
+// the baseline: services still create their tables if they don't exist, and
+// the one-off migrations from the schemas predating the baseline
+// (tools/migrations m001 to m004, lte m003 and m006) remain separate tools
+// which have to be run before.
+//
+// MariaDB implicitly commits DDL statements, so a migration which fails there
+// may be partially applied. The migration is left marked as dirty, and no
+// migration of its service runs until it's resolved with the migrate tool.
+type Migration struct {
+	// Module namespaces the migration versions, usually it's the name of the
+	// plugin registering the migration
+	Module      string
+	Version     uint32
+	Description string
+	// Service is the name of the service owning the tables the migration
+	// changes, which applies it at startup. The versions of a module's
+	// migrations are ordered per service.
+	Service string
+	// Destructive migrations delete data, they're never applied at service
+	// startup. The migrations of the same module and service following a
+	// pending destructive migration are held back until it's applied.
+	Destructive bool
+
+	// Up returns the statements applying the migration
+	Up MigrationSteps
+	// Down returns the statements reverting the migration. Migrations without
+	// Down steps can't be reverted.
+	Down MigrationSteps
+}
+
+// MigrationSteps returns the statements of a migration for the SQL dialect of
+// the given builder. Raw statements can be provided with squirrel.Expr, in
+// which case they must use the placeholder format of the dialect.
+type MigrationSteps func(builder StatementBuilder) []squirrel.Sqlizer
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%s v%d (%s)", m.Module, m.Version, m.Description)
+}
+
+func (m Migration) validate() error {
+	if m.Module == "" {
+		return fmt.Errorf("migration %d has an empty module", m.Version)
+	}
+	if m.Version == 0 {
+		return fmt.Errorf("migration of module %s has version 0, versions start at 1", m.Module)
+	}
+	if m.Service == "" {
+		return fmt.Errorf("migration %s has an empty service", m)
+	}
+	if m.Up == nil {
+		return fmt.Errorf("migration %s has no up steps", m)
+	}
+	return nil
+}
+
+type migrationRegistry struct {
+	sync.RWMutex
+	migrationsByModule map[string]map[uint32]Migration
+}
+
+var migrations = &migrationRegistry{migrationsByModule: map[string]map[uint32]Migration{}}
+
+// RegisterMigrations registers a collection of migrations with the migration
+// runner. This function will roll back changes if any registration fails.
+// This function is thread-safe.
+func RegisterMigrations(migs ...Migration) error {
+	migrations.Lock()
+	defer migrations.Unlock()
+	for i, m := range migs {
+		if err := registerMigrationUnsafe(m); err != nil {
+			unregisterMigrationsUnsafe(migs[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMigrations returns all registered migrations, sorted by module and then
+// by version.
+func GetMigrations() []Migration {
+	migrations.RLock()
+	defer migrations.RUnlock()
+	var ret []Migration
+	for _, migs := range migrations.migrationsByModule {
+		for _, m := range migs {
+			ret = append(ret, m)
+		}
+	}
+	sortMigrations(ret)
+	return ret
+}
+
+// GetServiceMigrations returns the registered migrations of the service's
+// tables, sorted by module and then by version.
+func GetServiceMigrations(service string) []Migration {
+	var ret []Migration
+	for _, m := range GetMigrations() {
+		if m.Service == service {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+func registerMigrationUnsafe(m Migration) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	migs, ok := migrations.migrationsByModule[m.Module]
+	if !ok {
+		migs = map[uint32]Migration{}
+		migrations.migrationsByModule[m.Module] = migs
+	}
+	if _, exists := migs[m.Version]; exists {
+		return fmt.Errorf("migration %d already registered for module %s", m.Version, m.Module)
+	}
+	migs[m.Version] = m
+	return nil
+}
+
+func unregisterMigrationsUnsafe(migs []Migration) {
+	for _, m := range migs {
+		delete(migrations.migrationsByModule[m.Module], m.Version)
+		if len(migrations.migrationsByModule[m.Module]) == 0 {
+			delete(migrations.migrationsByModule, m.Module)
+		}
+	}
+}
+
+func sortMigrations(migs []Migration) {
+	sort.Slice(migs, func(i, j int) bool {
+		if migs[i].Module != migs[j].Module {
+			return migs[i].Module < migs[j].Module
+		}
+		return migs[i].Version < migs[j].Version
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// migrationLockName identifies the database-wide lock held while migrations
+// are applied
+const migrationLockName = "orc8r_schema_migrations"
+
+// MigrationLocker acquires and releases a database-wide lock so that
+// multiple replicas starting together don't apply migrations concurrently.
+// The lock is held by the connection it's acquired on, and it's released
+// if the connection is closed.
+type MigrationLocker interface {
+	// Lock blocks until the lock is acquired or the context is done
+	Lock(ctx context.Context, conn *sql.Conn) error
+	Unlock(ctx context.Context, conn *sql.Conn) error
+}
+
+// GetMigrationLocker returns a MigrationLocker for the configured SQL dialect
+// as found in the SQL_DIALECT env var.
+func GetMigrationLocker() MigrationLocker {
+	dialect, envFound := os.LookupEnv("SQL_DIALECT")
+	// Default to postgresql
+	if !envFound {
+		return NewPostgresMigrationLocker()
+	}
+
+	switch strings.ToLower(dialect) {
+	case postgresDialect:
+		return NewPostgresMigrationLocker()
+	case mariaDialect:
+		return NewMariaDBMigrationLocker()
+	default:
+		panic(fmt.Sprintf("unsupported sql dialect %s", dialect))
+	}
+}
+
+// NewPostgresMigrationLocker returns a MigrationLocker using a PostgreSQL
+// session-level advisory lock.
+func NewPostgresMigrationLocker() MigrationLocker {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(migrationLockName))
+	return postgresMigrationLocker{key: int64(h.Sum64())}
+}
+
+// NewMariaDBMigrationLocker returns a MigrationLocker using a MariaDB named
+// lock.
+func NewMariaDBMigrationLocker() MigrationLocker {
+	return mariaDBMigrationLocker{name: migrationLockName}
+}
+
+type postgresMigrationLocker struct {
+	key int64
+}
+
+func (l postgresMigrationLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	// pg_advisory_lock waits indefinitely, the wait is interrupted by
+	// cancelling the query when the context is done
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", l.key)
+	if err != nil {
+		return errors.Wrap(err, "failed to acquire migration lock")
+	}
+	return nil
+}
+
+func (l postgresMigrationLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	var released bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.key).Scan(&released)
+	if err != nil {
+		return errors.Wrap(err, "failed to release migration lock")
+	}
+	if !released {
+		return errors.New("migration lock was not held")
+	}
+	return nil
+}
+
+type mariaDBMigrationLocker struct {
+	name string
+}
+
+func (l mariaDBMigrationLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	// GET_LOCK takes the timeout in seconds, a negative timeout waits
+	// indefinitely
+	timeout := -1
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = int(math.Ceil(time.Until(deadline).Seconds()))
+		if timeout < 0 {
+			timeout = 0
+		}
+	}
+	var acquired sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", l.name, timeout).Scan(&acquired)
+	if err != nil {
+		return errors.Wrap(err, "failed to acquire migration lock")
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return errors.New("timed out waiting for migration lock")
+	}
+	return nil
+}
+
+func (l mariaDBMigrationLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	var released sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", l.name).Scan(&released)
+	if err != nil {
+		return errors.Wrap(err, "failed to release migration lock")
+	}
+	if !released.Valid || released.Int64 != 1 {
+		return errors.New("migration lock was not held")
+	}
+	return nil
+}
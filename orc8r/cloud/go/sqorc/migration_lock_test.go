@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPostgresMigrationLocker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	locker := NewPostgresMigrationLocker().(postgresMigrationLocker)
+	mock.ExpectExec("SELECT pg_advisory_lock\\(\\$1\\)").
+		WithArgs(locker.key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT pg_advisory_unlock\\(\\$1\\)").
+		WithArgs(locker.key).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_advisory_unlock"}).AddRow(true))
+	mock.ExpectQuery("SELECT pg_advisory_unlock\\(\\$1\\)").
+		WithArgs(locker.key).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_advisory_unlock"}).AddRow(false))
+
+	assert.NoError(t, locker.Lock(context.Background(), conn))
+	assert.NoError(t, locker.Unlock(context.Background(), conn))
+	assert.EqualError(t, locker.Unlock(context.Background(), conn), "migration lock was not held")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDBMigrationLocker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	locker := NewMariaDBMigrationLocker()
+	// without a deadline, the lock is waited for indefinitely
+	mock.ExpectQuery("SELECT GET_LOCK\\(\\?, \\?\\)").
+		WithArgs(migrationLockName, -1).
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectQuery("SELECT RELEASE_LOCK\\(\\?\\)").
+		WithArgs(migrationLockName).
+		WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(1))
+	mock.ExpectQuery("SELECT GET_LOCK\\(\\?, \\?\\)").
+		WithArgs(migrationLockName, 60).
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+
+	assert.NoError(t, locker.Lock(context.Background(), conn))
+	assert.NoError(t, locker.Unlock(context.Background(), conn))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.EqualError(t, locker.Lock(ctx, conn), "timed out waiting for migration lock")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,484 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+
+	"magma/orc8r/cloud/go/clock"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SchemaVersionTable records the applied migration versions of each
+	// module
+	SchemaVersionTable = "schema_version"
+
+	svModuleCol      = "module"
+	svVersionCol     = "version"
+	svDescriptionCol = "description"
+	svAppliedAtCol   = "applied_at"
+	svDirtyCol       = "dirty"
+)
+
+// Migrator applies and reverts migrations. All operations hold the
+// database-wide migration lock, so that they're safe to run from multiple
+// replicas at the same time.
+type Migrator struct {
+	db      *sql.DB
+	builder StatementBuilder
+	locker  MigrationLocker
+	// migrations of each module, sorted by version
+	migrationsByModule map[string][]Migration
+	// transactionalDDL is false for dialects which implicitly commit DDL
+	// statements, in which failed migrations may be partially applied
+	transactionalDDL bool
+
+	// DryRunOutput, if set, receives the statements of the migrations which
+	// would be applied or reverted instead of executing them, and Up and Down
+	// return these migrations. The schema_version table is still created if
+	// it doesn't exist.
+	DryRunOutput io.Writer
+	// AllowDestructive allows Up to apply destructive migrations
+	AllowDestructive bool
+}
+
+// NewMigrator returns a Migrator for the given migrations. The builder
+// determines the SQL dialect of the migration statements.
+func NewMigrator(db *sql.DB, builder StatementBuilder, locker MigrationLocker, migs []Migration) (*Migrator, error) {
+	migrationsByModule := map[string][]Migration{}
+	for _, m := range migs {
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		migrationsByModule[m.Module] = append(migrationsByModule[m.Module], m)
+	}
+	for module, moduleMigs := range migrationsByModule {
+		sortMigrations(moduleMigs)
+		for i := 1; i < len(moduleMigs); i++ {
+			if moduleMigs[i].Version == moduleMigs[i-1].Version {
+				return nil, fmt.Errorf("duplicate migration %d for module %s", moduleMigs[i].Version, module)
+			}
+		}
+	}
+	_, nonTransactionalDDL := builder.(mariaDBStatementBuilder)
+	return &Migrator{
+		db:                 db,
+		builder:            builder,
+		locker:             locker,
+		migrationsByModule: migrationsByModule,
+		transactionalDDL:   !nonTransactionalDDL,
+	}, nil
+}
+
+// MigrateUp applies the pending non-destructive migrations of the service's
+// tables to the database in the dialect found in the SQL_DIALECT env var.
+// Services call it at startup, after creating their tables, so that the
+// migrations are applied once when several replicas start at the same time.
+func MigrateUp(ctx context.Context, db *sql.DB, service string) error {
+	migrator, err := NewMigrator(db, GetSqlBuilder(), GetMigrationLocker(), GetServiceMigrations(service))
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(ctx)
+	return err
+}
+
+// GetAppliedVersions returns the applied migration versions of each module,
+// and the dirty versions of the migrations which didn't complete, sorted in
+// increasing order.
+func (m *Migrator) GetAppliedVersions(ctx context.Context) (map[string][]uint32, map[string][]uint32, error) {
+	var versions schemaVersions
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		versions, err = m.getSchemaVersions(ctx, conn)
+		return err
+	})
+	return versions.applied, versions.dirty, err
+}
+
+// Up applies all pending migrations, module by module in name order, and
+// returns the applied migrations. It stops at the first failing migration.
+// A pending migration with a lower version than an applied migration of the
+// same module and service is an error, since migrations must be applied in
+// order. Unless AllowDestructive is set, pending destructive migrations and
+// the migrations following them are held back.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.getSchemaVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDirtyVersions(versions); err != nil {
+			return err
+		}
+		pending, err := m.getPendingMigrations(versions.applied)
+		if err != nil {
+			return err
+		}
+		for _, mig := range pending {
+			if err := m.runMigration(ctx, conn, mig, true); err != nil {
+				return err
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the applied migrations of the module with a version greater
+// than the target version, in decreasing version order, and returns the
+// reverted migrations. It fails without reverting anything if any of these
+// migrations isn't known to the migrator or has no down steps.
+func (m *Migrator) Down(ctx context.Context, module string, targetVersion uint32) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.getSchemaVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDirtyVersions(versions); err != nil {
+			return err
+		}
+		var toRevert []Migration
+		applied := versions.applied[module]
+		for i := len(applied) - 1; i >= 0 && applied[i] > targetVersion; i-- {
+			mig, ok := m.getMigration(module, applied[i])
+			if !ok {
+				return fmt.Errorf("applied migration %d of module %s is unknown", applied[i], module)
+			}
+			if mig.Down == nil {
+				return fmt.Errorf("migration %s can't be reverted", mig)
+			}
+			toRevert = append(toRevert, mig)
+		}
+		for _, mig := range toRevert {
+			if err := m.runMigration(ctx, conn, mig, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Resolve clears the dirty mark of a migration which didn't complete, once
+// the schema was checked and fixed by hand. If applied is true, the migration
+// is recorded as applied, otherwise as not applied.
+func (m *Migrator) Resolve(ctx context.Context, module string, version uint32, applied bool) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.getSchemaVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if !containsVersion(versions.dirty[module], version) {
+			return fmt.Errorf("migration %d of module %s isn't dirty", version, module)
+		}
+		return m.execInTx(ctx, conn, func(tx *sql.Tx) error {
+			return execSqlizer(ctx, tx, m.getRecordResultStatement(module, version, applied))
+		})
+	})
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	// The lock is held by the connection, so all statements have to run on
+	// the same connection
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			glog.Errorf("error closing migration connection: %s", err)
+		}
+	}()
+
+	if err := m.locker.Lock(ctx, conn); err != nil {
+		return err
+	}
+	defer func() {
+		// Release the lock even if the context is done
+		if err := m.locker.Unlock(context.Background(), conn); err != nil {
+			glog.Errorf("error releasing migration lock: %s", err)
+		}
+	}()
+
+	if err := m.initSchemaVersionTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) initSchemaVersionTable(ctx context.Context, conn *sql.Conn) error {
+	create := m.builder.CreateTable(SchemaVersionTable).
+		IfNotExists().
+		Column(svModuleCol).Type(ColumnTypeText).NotNull().EndColumn().
+		Column(svVersionCol).Type(ColumnTypeBigInt).NotNull().EndColumn().
+		Column(svDescriptionCol).Type(ColumnTypeText).EndColumn().
+		Column(svAppliedAtCol).Type(ColumnTypeBigInt).NotNull().EndColumn().
+		Column(svDirtyCol).Type(ColumnTypeBool).NotNull().EndColumn().
+		PrimaryKey(svModuleCol, svVersionCol)
+	return m.execInTx(ctx, conn, func(tx *sql.Tx) error {
+		return execSqlizer(ctx, tx, create)
+	})
+}
+
+// schemaVersions are the migration versions recorded in the schema_version
+// table, by module. Dirty versions are recorded before their migration runs,
+// they're left behind by migrations which didn't complete.
+type schemaVersions struct {
+	applied map[string][]uint32
+	dirty   map[string][]uint32
+}
+
+func (m *Migrator) getSchemaVersions(ctx context.Context, conn *sql.Conn) (schemaVersions, error) {
+	query, args, err := m.builder.Select(svModuleCol, svVersionCol, svDirtyCol).From(SchemaVersionTable).ToSql()
+	if err != nil {
+		return schemaVersions{}, errors.Wrap(err, "failed to build schema version query")
+	}
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return schemaVersions{}, errors.Wrap(err, "failed to query schema versions")
+	}
+	defer CloseRowsLogOnError(rows, "getSchemaVersions")
+
+	ret := schemaVersions{applied: map[string][]uint32{}, dirty: map[string][]uint32{}}
+	for rows.Next() {
+		var module string
+		var version uint32
+		var dirty bool
+		if err := rows.Scan(&module, &version, &dirty); err != nil {
+			return schemaVersions{}, errors.Wrap(err, "failed to scan schema version")
+		}
+		if dirty {
+			ret.dirty[module] = append(ret.dirty[module], version)
+		} else {
+			ret.applied[module] = append(ret.applied[module], version)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return schemaVersions{}, errors.Wrap(err, "failed to query schema versions")
+	}
+	for _, versionsByModule := range []map[string][]uint32{ret.applied, ret.dirty} {
+		for _, versions := range versionsByModule {
+			sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+		}
+	}
+	return ret, nil
+}
+
+// checkDirtyVersions returns an error if one of the migrator's migrations
+// didn't complete. The schema may be partially migrated, so no migration can
+// run until the migration is resolved.
+func (m *Migrator) checkDirtyVersions(versions schemaVersions) error {
+	for module, dirty := range versions.dirty {
+		for _, version := range dirty {
+			if mig, ok := m.getMigration(module, version); ok {
+				return fmt.Errorf(
+					"migration %s didn't complete and may be partially applied, check the schema and resolve it with the migrate tool",
+					mig,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) getPendingMigrations(appliedVersions map[string][]uint32) ([]Migration, error) {
+	var modules []string
+	for module := range m.migrationsByModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	var ret []Migration
+	for _, module := range modules {
+		applied := map[uint32]bool{}
+		for _, version := range appliedVersions[module] {
+			applied[version] = true
+		}
+		// latest applied version and held back migrations by service
+		latest := map[string]uint32{}
+		for _, mig := range m.migrationsByModule[module] {
+			if applied[mig.Version] {
+				latest[mig.Service] = mig.Version
+			}
+		}
+		heldBack := map[string]bool{}
+		for _, mig := range m.migrationsByModule[module] {
+			if applied[mig.Version] || heldBack[mig.Service] {
+				continue
+			}
+			if mig.Version < latest[mig.Service] {
+				return nil, fmt.Errorf("migration %s is pending but version %d is already applied", mig, latest[mig.Service])
+			}
+			if mig.Destructive && !m.AllowDestructive {
+				glog.Warningf(
+					"Destructive migration %s is pending, it and the following migrations of service %s have to be applied with the migrate tool",
+					mig, mig.Service,
+				)
+				heldBack[mig.Service] = true
+				continue
+			}
+			ret = append(ret, mig)
+		}
+	}
+	return ret, nil
+}
+
+func (m *Migrator) getMigration(module string, version uint32) (Migration, bool) {
+	migs := m.migrationsByModule[module]
+	i := sort.Search(len(migs), func(i int) bool { return migs[i].Version >= version })
+	if i < len(migs) && migs[i].Version == version {
+		return migs[i], true
+	}
+	return Migration{}, false
+}
+
+// runMigration applies or reverts the migration and records its version in
+// the same transaction. The migration is marked as dirty beforehand, so that
+// a migration whose DDL statements were implicitly committed before it failed
+// is detected.
+func (m *Migrator) runMigration(ctx context.Context, conn *sql.Conn, mig Migration, up bool) error {
+	steps, direction := mig.Up, "up"
+	if !up {
+		steps, direction = mig.Down, "down"
+	}
+	statements := steps(m.builder)
+
+	if m.DryRunOutput != nil {
+		return m.writeDryRun(mig, direction, statements)
+	}
+
+	glog.Infof("Running migration %s %s", mig, direction)
+	err := m.execInTx(ctx, conn, func(tx *sql.Tx) error {
+		return execSqlizer(ctx, tx, m.getMarkDirtyStatement(mig, up))
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to mark migration %s as dirty", mig)
+	}
+	err = m.execInTx(ctx, conn, func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if err := execSqlizer(ctx, tx, stmt); err != nil {
+				return err
+			}
+		}
+		return execSqlizer(ctx, tx, m.getRecordResultStatement(mig.Module, mig.Version, up))
+	})
+	if err == nil {
+		return nil
+	}
+	if !m.transactionalDDL {
+		return errors.Wrapf(err, "migration %s %s failed and may be partially applied", mig, direction)
+	}
+
+	// The migration was rolled back, it can be run again
+	clearErr := m.execInTx(ctx, conn, func(tx *sql.Tx) error {
+		return execSqlizer(ctx, tx, m.getRecordResultStatement(mig.Module, mig.Version, !up))
+	})
+	if clearErr != nil {
+		glog.Errorf("error clearing the dirty mark of migration %s: %s", mig, clearErr)
+	}
+	return errors.Wrapf(err, "migration %s %s failed", mig, direction)
+}
+
+// getMarkDirtyStatement records a migration which is about to be applied or
+// reverted as dirty
+func (m *Migrator) getMarkDirtyStatement(mig Migration, up bool) squirrel.Sqlizer {
+	if up {
+		return m.builder.Insert(SchemaVersionTable).
+			Columns(svModuleCol, svVersionCol, svDescriptionCol, svAppliedAtCol, svDirtyCol).
+			Values(mig.Module, mig.Version, mig.Description, clock.Now().Unix(), true)
+	}
+	return m.builder.Update(SchemaVersionTable).
+		Set(svDirtyCol, true).
+		Where(squirrel.Eq{svModuleCol: mig.Module, svVersionCol: mig.Version})
+}
+
+// getRecordResultStatement clears the dirty mark of a migration, recording it
+// as applied or not
+func (m *Migrator) getRecordResultStatement(module string, version uint32, applied bool) squirrel.Sqlizer {
+	if applied {
+		return m.builder.Update(SchemaVersionTable).
+			Set(svDirtyCol, false).
+			Where(squirrel.Eq{svModuleCol: module, svVersionCol: version})
+	}
+	return m.builder.Delete(SchemaVersionTable).
+		Where(squirrel.Eq{svModuleCol: module, svVersionCol: version})
+}
+
+func (m *Migrator) writeDryRun(mig Migration, direction string, statements []squirrel.Sqlizer) error {
+	if _, err := fmt.Fprintf(m.DryRunOutput, "-- %s %s\n", mig, direction); err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		query, args, err := stmt.ToSql()
+		if err != nil {
+			return errors.Wrapf(err, "failed to build statement of migration %s", mig)
+		}
+		if len(args) > 0 {
+			_, err = fmt.Fprintf(m.DryRunOutput, "%s; -- args: %v\n", query, args)
+		} else {
+			_, err = fmt.Fprintf(m.DryRunOutput, "%s;\n", query)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) execInTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit()
+		default:
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				glog.Errorf("error rolling back tx: %s", rollbackErr)
+			}
+		}
+	}()
+	err = fn(tx)
+	return
+}
+
+func execSqlizer(ctx context.Context, tx *sql.Tx, stmt squirrel.Sqlizer) error {
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build statement")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrapf(err, "failed to execute %q", query)
+	}
+	return nil
+}
+
+func containsVersion(versions []uint32, version uint32) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
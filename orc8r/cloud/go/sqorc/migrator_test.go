@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLocker struct {
+	locked   int
+	unlocked int
+}
+
+func (l *testLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	l.locked++
+	return nil
+}
+
+func (l *testLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	l.unlocked++
+	return nil
+}
+
+func createTableMigration(module string, version uint32, table string) Migration {
+	return Migration{
+		Module:      module,
+		Version:     version,
+		Description: "create " + table,
+		Service:     "svc1",
+		Up: func(builder StatementBuilder) []squirrel.Sqlizer {
+			return []squirrel.Sqlizer{
+				builder.CreateTable(table).
+					Column("id").Type(ColumnTypeText).PrimaryKey().EndColumn(),
+				builder.Insert(table).Columns("id").Values("initial"),
+			}
+		},
+		Down: func(builder StatementBuilder) []squirrel.Sqlizer {
+			return []squirrel.Sqlizer{squirrel.Expr("DROP TABLE " + table)}
+		},
+	}
+}
+
+func TestRegisterMigrations(t *testing.T) {
+	defer func() { migrations.migrationsByModule = map[string]map[uint32]Migration{} }()
+
+	assert.NoError(t, RegisterMigrations(
+		createTableMigration("mod2", 1, "a"),
+		createTableMigration("mod1", 2, "b"),
+		createTableMigration("mod1", 1, "c"),
+	))
+	// a duplicate rolls back the whole registration
+	err := RegisterMigrations(createTableMigration("mod3", 1, "d"), createTableMigration("mod1", 1, "e"))
+	assert.EqualError(t, err, "migration 1 already registered for module mod1")
+	assert.Error(t, RegisterMigrations(Migration{Module: "mod3", Version: 1}))
+	assert.Error(t, RegisterMigrations(createTableMigration("mod3", 0, "f")))
+	noService := createTableMigration("mod3", 1, "g")
+	noService.Service = ""
+	assert.Error(t, RegisterMigrations(noService))
+
+	var actual []string
+	for _, m := range GetMigrations() {
+		actual = append(actual, m.String())
+	}
+	expected := []string{"mod1 v1 (create c)", "mod1 v2 (create b)", "mod2 v1 (create a)"}
+	assert.Equal(t, expected, actual)
+
+	otherService := createTableMigration("mod1", 3, "h")
+	otherService.Service = "svc2"
+	assert.NoError(t, RegisterMigrations(otherService))
+	assert.Len(t, GetServiceMigrations("svc1"), 3)
+	assert.Equal(t, stripSteps([]Migration{otherService}), stripSteps(GetServiceMigrations("svc2")))
+}
+
+func TestMigrator(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	locker := &testLocker{}
+	migs := []Migration{
+		createTableMigration("mod1", 2, "b"),
+		createTableMigration("mod1", 1, "a"),
+		createTableMigration("mod2", 1, "c"),
+	}
+
+	_, err = NewMigrator(db, NewPostgresStatementBuilder(), locker, append(migs, createTableMigration("mod1", 1, "d")))
+	assert.EqualError(t, err, "duplicate migration 1 for module mod1")
+
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), locker, migs)
+	require.NoError(t, err)
+
+	// dry run doesn't apply anything
+	out := &bytes.Buffer{}
+	migrator.DryRunOutput = out
+	applied, err := migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, applied, 3)
+	expectedOut := "-- mod1 v1 (create a) up\n" +
+		"CREATE TABLE a (\nid TEXT PRIMARY KEY\n);\n" +
+		"INSERT INTO a (id) VALUES ($1); -- args: [initial]\n" +
+		"-- mod1 v2 (create b) up\n" +
+		"CREATE TABLE b (\nid TEXT PRIMARY KEY\n);\n" +
+		"INSERT INTO b (id) VALUES ($1); -- args: [initial]\n" +
+		"-- mod2 v1 (create c) up\n" +
+		"CREATE TABLE c (\nid TEXT PRIMARY KEY\n);\n" +
+		"INSERT INTO c (id) VALUES ($1); -- args: [initial]\n"
+	assert.Equal(t, expectedOut, out.String())
+	migrator.DryRunOutput = nil
+	versions, dirty, err := migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+	assert.Empty(t, dirty)
+
+	applied, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps([]Migration{migs[1], migs[0], migs[2]}), stripSteps(applied))
+	assertTableRows(t, db, "a", 1)
+	assertTableRows(t, db, "b", 1)
+	assertTableRows(t, db, "c", 1)
+	versions, dirty, err = migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"mod1": {1, 2}, "mod2": {1}}, versions)
+
+	// nothing left to apply
+	applied, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// only new migrations are applied, a failing migration is rolled back
+	// and stops the run
+	failing := createTableMigration("mod2", 3, "e")
+	failing.Up = func(builder StatementBuilder) []squirrel.Sqlizer {
+		return []squirrel.Sqlizer{
+			builder.Insert("c").Columns("id").Values("from failing"),
+			squirrel.Expr("INSERT INTO unknown_table VALUES (1)"),
+		}
+	}
+	migs = append(migs, createTableMigration("mod2", 2, "d"), failing)
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), locker, migs)
+	require.NoError(t, err)
+	applied, err = migrator.Up(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, stripSteps(migs[3:4]), stripSteps(applied))
+	assertTableRows(t, db, "c", 1)
+	versions, dirty, err = migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"mod1": {1, 2}, "mod2": {1, 2}}, versions)
+	// the failed migration was rolled back, so it isn't dirty
+	assert.Empty(t, dirty)
+
+	// applied migrations must be known to be reverted
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), locker, migs[1:2])
+	require.NoError(t, err)
+	_, err = migrator.Down(context.Background(), "mod1", 0)
+	assert.EqualError(t, err, "applied migration 2 of module mod1 is unknown")
+	assertTableRows(t, db, "a", 1)
+
+	// down reverts in decreasing version order
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), locker, migs[:4])
+	require.NoError(t, err)
+	out.Reset()
+	migrator.DryRunOutput = out
+	reverted, err := migrator.Down(context.Background(), "mod1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "-- mod1 v2 (create b) down\nDROP TABLE b;\n-- mod1 v1 (create a) down\nDROP TABLE a;\n", out.String())
+	assert.Len(t, reverted, 2)
+	migrator.DryRunOutput = nil
+	reverted, err = migrator.Down(context.Background(), "mod1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps(migs[0:2]), stripSteps(reverted))
+	versions, dirty, err = migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"mod2": {1, 2}}, versions)
+	_, err = db.Exec("SELECT * FROM a")
+	assert.Error(t, err)
+
+	// migrations without down steps can't be reverted
+	irreversible := createTableMigration("mod2", 2, "d")
+	irreversible.Down = nil
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), locker, []Migration{migs[2], irreversible})
+	require.NoError(t, err)
+	_, err = migrator.Down(context.Background(), "mod2", 0)
+	assert.EqualError(t, err, "migration mod2 v2 (create d) can't be reverted")
+	assertTableRows(t, db, "c", 1)
+
+	// the lock is held for every operation
+	assert.Equal(t, 12, locker.locked)
+	assert.Equal(t, locker.locked, locker.unlocked)
+}
+
+func TestMigrator_OutOfOrder(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, []Migration{createTableMigration("mod1", 2, "b")})
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, []Migration{createTableMigration("mod1", 1, "a"), createTableMigration("mod1", 2, "b")})
+	require.NoError(t, err)
+	applied, err := migrator.Up(context.Background())
+	assert.EqualError(t, err, "migration mod1 v1 (create a) is pending but version 2 is already applied")
+	assert.Empty(t, applied)
+}
+
+// Versions are ordered among the migrations of the same service
+func TestMigrator_Services(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	svc2Mig := createTableMigration("mod1", 2, "b")
+	svc2Mig.Service = "svc2"
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, []Migration{svc2Mig})
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+
+	migs := []Migration{createTableMigration("mod1", 1, "a"), svc2Mig}
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, migs)
+	require.NoError(t, err)
+	applied, err := migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps(migs[:1]), stripSteps(applied))
+}
+
+func TestMigrator_Destructive(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	destructive := createTableMigration("mod1", 2, "b")
+	destructive.Destructive = true
+	migs := []Migration{
+		createTableMigration("mod1", 1, "a"),
+		destructive,
+		createTableMigration("mod1", 3, "c"),
+		createTableMigration("mod2", 1, "d"),
+	}
+	otherService := createTableMigration("mod1", 4, "e")
+	otherService.Service = "svc2"
+	migs = append(migs, otherService)
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, migs)
+	require.NoError(t, err)
+
+	// the destructive migration and the following ones of its service are
+	// held back
+	applied, err := migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps([]Migration{migs[0], migs[4], migs[3]}), stripSteps(applied))
+
+	migrator.AllowDestructive = true
+	applied, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps(migs[1:3]), stripSteps(applied))
+}
+
+// Migrations which fail in dialects without transactional DDL are left dirty
+func TestMigrator_Dirty(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	failing := createTableMigration("mod1", 2, "b")
+	failing.Up = func(builder StatementBuilder) []squirrel.Sqlizer {
+		return []squirrel.Sqlizer{squirrel.Expr("INSERT INTO unknown_table VALUES (1)")}
+	}
+	migs := []Migration{createTableMigration("mod1", 1, "a"), failing}
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, migs)
+	require.NoError(t, err)
+	migrator.transactionalDDL = false
+
+	applied, err := migrator.Up(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, stripSteps(migs[:1]), stripSteps(applied))
+	versions, dirty, err := migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"mod1": {1}}, versions)
+	assert.Equal(t, map[string][]uint32{"mod1": {2}}, dirty)
+
+	// nothing runs until the dirty migration is resolved
+	_, err = migrator.Up(context.Background())
+	assert.EqualError(t, err, "migration mod1 v2 (create b) didn't complete and may be partially applied, check the schema and resolve it with the migrate tool")
+	_, err = migrator.Down(context.Background(), "mod1", 0)
+	assert.Error(t, err)
+	assertTableRows(t, db, "a", 1)
+	assert.EqualError(t, migrator.Resolve(context.Background(), "mod1", 1, false), "migration 1 of module mod1 isn't dirty")
+
+	assert.NoError(t, migrator.Resolve(context.Background(), "mod1", 2, false))
+	migs[1] = createTableMigration("mod1", 2, "b")
+	migrator, err = NewMigrator(db, NewPostgresStatementBuilder(), &testLocker{}, migs)
+	require.NoError(t, err)
+	applied, err = migrator.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, stripSteps(migs[1:]), stripSteps(applied))
+	versions, dirty, err = migrator.GetAppliedVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"mod1": {1, 2}}, versions)
+	assert.Empty(t, dirty)
+}
+
+func TestMigrator_LockError(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	migrator, err := NewMigrator(db, NewPostgresStatementBuilder(), failingLocker{}, []Migration{createTableMigration("mod1", 1, "a")})
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	assert.EqualError(t, err, "lock error")
+	_, err = db.Exec("SELECT * FROM a")
+	assert.Error(t, err)
+}
+
+type failingLocker struct{}
+
+func (failingLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	return errors.New("lock error")
+}
+
+func (failingLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	return nil
+}
+
+func assertTableRows(t *testing.T, db *sql.DB, table string, expected int) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, count)
+}
+
+// stripSteps clears the step functions of the migrations, which can't be
+// compared
+func stripSteps(migs []Migration) []Migration {
+	var ret []Migration
+	for _, m := range migs {
+		m.Up, m.Down = nil, nil
+		ret = append(ret, m)
+	}
+	return ret
+}
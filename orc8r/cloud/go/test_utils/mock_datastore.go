@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package test_utils
 
 import (
+	"strings"
 	"sync"
 
 	"magma/orc8r/cloud/go/datastore"
@@ -107,6 +108,17 @@ func (m *MockDatastore) ListKeys(table string) ([]string, error) {
 	return keys, nil
 }
 
+func (m *MockDatastore) ListKeysWithPrefix(table string, prefix string) ([]string, error) {
+	m.initTable(table)
+	keys := []string{}
+	for key := range m.store[table] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 func (m *MockDatastore) DeleteTable(table string) error {
 	m.initTable(table)
 	delete(m.store, table)
@@ -122,3 +134,20 @@ func (m *MockDatastore) DoesKeyExist(table string, key string) (bool, error) {
 		return false, nil
 	}
 }
+
+// DoInTx calls fn with the datastore and restores the datastore's tables if
+// fn fails
+func (m *MockDatastore) DoInTx(tables []string, fn func(store datastore.TxApi) error) error {
+	snapshot := make(map[string]mockDatastoreTable, len(m.store))
+	for name, table := range m.store {
+		snapshot[name] = make(mockDatastoreTable, len(table))
+		for k, v := range table {
+			snapshot[name][k] = v
+		}
+	}
+	err := fn(m)
+	if err != nil {
+		m.store = snapshot
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package handlers implements individual accessc commands as well as common
+// across multiple commands functionality
+package handlers
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+	"magma/orc8r/cloud/go/services/certifier"
+	"magma/orc8r/cloud/go/tools/commands"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// CA rotation commands. A CA is rotated by:
+//  1. generating the next CA, e.g. with gocert -ca, and installing its
+//     private key file in the CA key directory of every certifier instance
+//  2. stage-ca: the next CA is trusted and published in the CA bundle
+//  3. activate-ca, once gateways have fetched the bundle: new certificates
+//     are signed by the next CA
+//  4. retire-ca for the previous CA, once its certificates have been renewed
+
+var caCertType string
+
+func init() {
+	cmd := CommandRegistry.Add(
+		"stage-ca",
+		"Add a new trusted CA, which doesn't sign certificates until activated",
+		stageCA)
+	f := cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s %s [OPTIONS] <CA Certificate File> <CA Private Key File Name>\n"+
+				"\tThe private key file must be in the CA key directory of the certifier\n",
+			os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	addCertTypeFlag(f)
+
+	cmd = CommandRegistry.Add(
+		"activate-ca",
+		"Sign new certificates with the given staged CA",
+		activateCA)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s [OPTIONS] <CA Serial Number>\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	addCertTypeFlag(f)
+
+	cmd = CommandRegistry.Add(
+		"retire-ca",
+		"Remove the given CA from trusted CAs",
+		retireCA)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s [OPTIONS] <CA Serial Number>\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	addCertTypeFlag(f)
+
+	cmd = CommandRegistry.Add(
+		"list-cas",
+		"List all CAs and their rotation states",
+		listCAs)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s [OPTIONS]\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	addCertTypeFlag(f)
+}
+
+func addCertTypeFlag(f *flag.FlagSet) {
+	f.StringVar(&caCertType, "type", "default", "CA type: default or vpn")
+}
+
+func getCertType() protos.CertType {
+	certType, ok := protos.CertType_value[strings.ToUpper(caCertType)]
+	if !ok {
+		log.Fatalf("Invalid CA type: %s", caCertType)
+	}
+	return protos.CertType(certType)
+}
+
+func stageCA(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	if f.NArg() != 2 {
+		f.Usage()
+		log.Fatalf("CA certificate file and private key file name must be specified.")
+	}
+	certPEM, err := ioutil.ReadFile(f.Arg(0))
+	if err != nil {
+		log.Fatalf("Cannot read CA certificate file '%s': %s", f.Arg(0), err)
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		log.Fatalf("No certificate found in '%s'", f.Arg(0))
+	}
+	caCert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		log.Fatalf("Cannot parse CA certificate from '%s': %s", f.Arg(0), err)
+	}
+	certType := getCertType()
+	sn := cert.SerialToString(caCert.SerialNumber)
+	fmt.Printf("Staging %s CA Serial Number: %s\n", certType, sn)
+	// The private key never leaves the certifier, it's loaded from the named
+	// file in its CA key directory
+	err = certifier.StageCA(certType, caCert.Raw, f.Arg(1))
+	if err != nil {
+		log.Fatalf("Error staging CA %s: %s", sn, err)
+	}
+	return 0
+}
+
+func activateCA(cmd *commands.Command, args []string) int {
+	sn := getCASN(cmd)
+	certType := getCertType()
+	fmt.Printf("Activating %s CA Serial Number: %s\n", certType, sn)
+	err := certifier.ActivateCA(certType, sn)
+	if err != nil {
+		log.Fatalf("Error activating CA %s: %s", sn, err)
+	}
+	return 0
+}
+
+func retireCA(cmd *commands.Command, args []string) int {
+	sn := getCASN(cmd)
+	certType := getCertType()
+	fmt.Printf("Retiring %s CA Serial Number: %s\n", certType, sn)
+	err := certifier.RetireCA(certType, sn)
+	if err != nil {
+		log.Fatalf("Error retiring CA %s: %s", sn, err)
+	}
+	return 0
+}
+
+func listCAs(cmd *commands.Command, args []string) int {
+	cas, err := certifier.ListCAs(getCertType())
+	if err != nil {
+		log.Fatalf("List CAs Error: %s", err)
+	}
+	fmt.Println()
+	for _, ca := range cas {
+		fmt.Printf("Serial Number: %s; State: %s", ca.Sn, ca.State)
+		caCert, err := x509.ParseCertificate(ca.CertDer)
+		if err != nil {
+			log.Printf("\nError %s parsing CA %s\n", err, ca.Sn)
+			continue
+		}
+		fmt.Printf(
+			"; Subject: %s; Not Before: %s; Not After: %s",
+			caCert.Subject,
+			caCert.NotBefore.In(time.Local),
+			caCert.NotAfter.In(time.Local))
+		// CAs loaded from files don't have a state change time
+		if changedAt, err := ptypes.Timestamp(ca.StateChangedAt); err == nil {
+			fmt.Printf("; State Changed: %s", changedAt.In(time.Local))
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	return 0
+}
+
+func getCASN(cmd *commands.Command) string {
+	f := cmd.Flags()
+	sn := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(sn) == 0 {
+		f.Usage()
+		log.Fatalf("A single CA Serial Number must be specified.")
+	}
+	return sn
+}
@@ -12,6 +12,7 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
 
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
@@ -109,5 +110,8 @@ func PrintACL(acl *accessprotos.AccessControl_List, certSNs []string) {
 			ent.Permissions.ToString(),
 			ent.Permissions)
 	}
+	if len(acl.Roles) > 0 {
+		fmt.Printf("\t\tRoles: %s\n", strings.Join(acl.Roles, ", "))
+	}
 	fmt.Println()
 }
@@ -0,0 +1,150 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package handlers implements individual accessc commands as well as common
+// across multiple commands functionality
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	"magma/orc8r/cloud/go/tools/commands"
+)
+
+// Role commands. Roles are named sets of ACL entities, their permissions are
+// added to the ACLs of all Operators the Role is bound to
+
+var roleDescription string
+
+func init() {
+	cmd := CommandRegistry.Add(
+		"set-role",
+		"Create a new Role or overwrite an existing Role",
+		setRole)
+	f := cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s %s [OPTIONS] <Role Name>\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	entHelp := "%s with required permissions in the form: <network Id|*>:" +
+		"R|W|RW. At least one permission must be given."
+	f.Var(&networks, "n", fmt.Sprintf(entHelp, "Networks"))
+	f.Var(&operators, "o", fmt.Sprintf(entHelp, "Operators"))
+	f.Var(&gateways, "g", fmt.Sprintf(entHelp, "Gateways"))
+	f.StringVar(&roleDescription, "d", "", "Role description")
+
+	cmd = CommandRegistry.Add(
+		"delete-role",
+		"Delete the given Role, the Role must not be bound to any Operator",
+		deleteRole)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <Role Name>\n", os.Args[0], cmd.Name())
+	}
+
+	cmd = CommandRegistry.Add(
+		"list-roles",
+		"List all Roles",
+		listRoles)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\tUsage: %s %s\n", os.Args[0], cmd.Name())
+	}
+
+	cmd = CommandRegistry.Add(
+		"set-roles",
+		"Overwrite the Roles bound to the given Operator, "+
+			"no Roles unbind all Operator's Roles",
+		setOperatorRoles)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <OperatorID> [Role Name...]\n", os.Args[0], cmd.Name())
+	}
+}
+
+func setRole(cmd *commands.Command, args []string) int {
+	name := getRoleName(cmd)
+	ents := BuildACLForEntities(networks, operators, gateways)
+	if len(ents) == 0 {
+		cmd.Flags().Usage()
+		log.Fatal("At least one ACL entity must be provided")
+	}
+	role := &accessprotos.Role{Name: name, Description: roleDescription, Entities: ents}
+	fmt.Printf("Setting Role: %s\n", name)
+	err := accessd.SetRole(role)
+	if err != nil {
+		log.Fatalf("Error setting Role %s: %s", name, err)
+	}
+	return 0
+}
+
+func deleteRole(cmd *commands.Command, args []string) int {
+	name := getRoleName(cmd)
+	fmt.Printf("Removing Role: %s\n", name)
+	err := accessd.DeleteRole(name)
+	if err != nil {
+		log.Fatalf("Error removing Role %s: %s", name, err)
+	}
+	return 0
+}
+
+func listRoles(cmd *commands.Command, args []string) int {
+	roles, err := accessd.ListRoles()
+	if err != nil {
+		log.Fatalf("List Roles Error: %s", err)
+	}
+	fmt.Println("Roles:")
+	for _, role := range roles {
+		fmt.Printf("\t%s: %q\n\t\tACL:\n", role.Name, role.Description)
+		for _, ent := range role.Entities {
+			fmt.Printf(
+				"\t\t  %s: %s (%d)\n",
+				ent.Id.HashString(),
+				ent.Permissions.ToString(),
+				ent.Permissions)
+		}
+	}
+	fmt.Println()
+	return 0
+}
+
+func setOperatorRoles(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	oid := strings.TrimSpace(f.Arg(0))
+	if f.NArg() < 1 || len(oid) == 0 {
+		f.Usage()
+		log.Fatalf("An Operator Id must be specified.")
+	}
+	operator := identity.NewOperator(oid)
+	roles := f.Args()[1:]
+	fmt.Printf("Binding Roles %v to Operator: %s (%s)\n", roles, oid, operator.HashString())
+	err := accessd.SetOperatorRoles(operator, roles)
+	if err != nil {
+		log.Fatalf("Error binding Roles to %s: %s", oid, err)
+	}
+	return 0
+}
+
+func getRoleName(cmd *commands.Command) string {
+	f := cmd.Flags()
+	name := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(name) == 0 {
+		f.Usage()
+		log.Fatalf("A single Role Name must be specified.")
+	}
+	return name
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package handlers implements individual accessc commands as well as common
+// across multiple commands functionality
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/accessd"
+	"magma/orc8r/cloud/go/tools/commands"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// API token commands. Tokens are bound to an existing operator and carry
+// a subset of its ACL, for clients which can't use client certificates.
+// Requests with a token carry it in the 'Authorization: Bearer <token>' header
+
+var (
+	tokenTTL         time.Duration
+	tokenDescription string
+)
+
+func init() {
+	cmd := CommandRegistry.Add(
+		"issue-token",
+		"Issue a new API Token for an existing Operator",
+		issueToken)
+	f := cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s %s [OPTIONS] <OperatorID>\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	entHelp := "%s with required permissions in the form: <network Id|*>:" +
+		"R|W|RW. Permissions must be granted by the Operator's ACL. At least " +
+		"one permission must be given."
+	f.Var(&networks, "n", fmt.Sprintf(entHelp, "Networks"))
+	f.Var(&operators, "o", fmt.Sprintf(entHelp, "Operators"))
+	f.Var(&gateways, "g", fmt.Sprintf(entHelp, "Gateways"))
+	f.DurationVar(&tokenTTL, "ttl", time.Hour*24*90, "Token validity duration")
+	f.StringVar(&tokenDescription, "d", "", "Token description")
+
+	cmd = CommandRegistry.Add(
+		"revoke-token",
+		"Revoke the given API Token",
+		revokeToken)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <Token ID>\n", os.Args[0], cmd.Name())
+	}
+
+	cmd = CommandRegistry.Add(
+		"list-tokens",
+		"List API Tokens of the given Operator",
+		listTokens)
+	f = cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <OperatorID>\n", os.Args[0], cmd.Name())
+	}
+}
+
+func issueToken(cmd *commands.Command, args []string) int {
+	operator := getTokenOperator(cmd)
+	scope := BuildACLForEntities(networks, operators, gateways)
+	if len(scope) == 0 {
+		cmd.Flags().Usage()
+		log.Fatal("At least one ACL entity must be provided")
+	}
+	issued, err := accessd.IssueToken(operator, scope, tokenDescription, time.Now().Add(tokenTTL))
+	if err != nil {
+		log.Fatalf("Error issuing token for %s: %s", operator.HashString(), err)
+	}
+	fmt.Printf("Token ID: %s\n", issued.Token.Id)
+	fmt.Printf("Token (it won't be shown again): %s\n", issued.Bearer)
+	return 0
+}
+
+func revokeToken(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	id := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(id) == 0 {
+		f.Usage()
+		log.Fatalf("A single Token ID must be specified.")
+	}
+	fmt.Printf("Revoking Token ID: %s\n", id)
+	err := accessd.RevokeToken(id)
+	if err != nil {
+		log.Fatalf("Error revoking token %s: %s", id, err)
+	}
+	return 0
+}
+
+func listTokens(cmd *commands.Command, args []string) int {
+	operator := getTokenOperator(cmd)
+	tokens, err := accessd.ListTokens(operator)
+	if err != nil {
+		log.Fatalf("List Tokens Error: %s", err)
+	}
+	fmt.Printf("Tokens of %s:\n", operator.HashString())
+	for _, token := range tokens {
+		fmt.Printf("\t%s: %q", token.Id, token.Description)
+		if createdAt, err := ptypes.Timestamp(token.CreatedAt); err == nil {
+			fmt.Printf("; Created: %s", createdAt.In(time.Local))
+		}
+		if expiresAt, err := ptypes.Timestamp(token.ExpiresAt); err == nil {
+			fmt.Printf("; Expires: %s", expiresAt.In(time.Local))
+		}
+		fmt.Println("\n\t\tScope:")
+		for _, ent := range token.Entities {
+			fmt.Printf(
+				"\t\t  %s: %s (%d)\n",
+				ent.Id.HashString(),
+				ent.Permissions.ToString(),
+				ent.Permissions)
+		}
+	}
+	fmt.Println()
+	return 0
+}
+
+func getTokenOperator(cmd *commands.Command) *protos.Identity {
+	f := cmd.Flags()
+	oid := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(oid) == 0 {
+		f.Usage()
+		log.Fatalf("A single Operator Id must be specified.")
+	}
+	return identity.NewOperator(oid)
+}
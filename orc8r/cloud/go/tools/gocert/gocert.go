@@ -129,6 +129,25 @@ Examples:
 
     $> %s -text client_cert.pem
 
+  5. Rotate the certifier's bootstrap CA without a hard cutover. Create the
+     next CA:
+
+    $> %s -ca -CN bootstrapper.magma -o bootstrapper_next
+
+     Install bootstrapper_next.key.pem in the CA key directory of every
+     certifier instance (certifier -ca-key-dir), the key is never sent to
+     the certifier over RPC. Stage the CA, so gateways receive it in the CA
+     bundle alongside the current CA, then activate it once they have, so
+     new certificates are signed by the next CA:
+
+    $> accessc stage-ca -type default bootstrapper_next.pem bootstrapper_next.key.pem
+    $> accessc activate-ca -type default <next CA serial number>
+
+     Retire the previous CA once the certificates it signed have been renewed:
+
+    $> accessc list-cas -type default
+    $> accessc retire-ca -type default <previous CA serial number>
+
 `
 
 func main() {
@@ -136,7 +155,7 @@ func main() {
 	flag.Usage = func() {
 		oldUsage()
 		cmd := os.Args[0]
-		fmt.Printf(usageExamples, cmd, cmd, cmd, cmd)
+		fmt.Printf(usageExamples, cmd, cmd, cmd, cmd, cmd)
 	}
 	flag.Parse()
 
@@ -240,7 +259,7 @@ func main() {
 
 	template.IsCA = *isCA
 	if *isCA {
-		template.KeyUsage |= x509.KeyUsageCertSign
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
 	}
 
 	ski := make([]byte, 32)
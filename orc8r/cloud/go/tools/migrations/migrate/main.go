@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// migrate applies, reverts and lists the SQL schema migrations registered by
+// the orchestrator plugins. Services apply the pending migrations of their
+// own tables at startup, except for destructive migrations, which are only
+// applied by this tool with -allow-destructive. It's also for dry runs,
+// status checks, reverting, and resolving migrations which didn't complete.
+// It's safe to run while services are starting, the migrations are applied
+// once while holding a database-wide lock.
+//
+// Usage:
+//
+//	migrate [-service <service>] [-allow-destructive] [-dry-run] [-timeout <duration>]
+//	migrate -status
+//	migrate -down -module <module> -version <version> [-dry-run]
+//	migrate -resolve applied|reverted -module <module> -version <version>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/golang/glog"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Print the statements of the migrations to run instead of executing them")
+	status := flag.Bool("status", false, "Print the applied and dirty migration versions of each module")
+	down := flag.Bool("down", false, "Revert the migrations of -module down to -version")
+	resolve := flag.String("resolve", "", "Record the dirty migration -module -version as applied or reverted, once the schema was fixed")
+	module := flag.String("module", "", "Module of the migrations to revert or resolve")
+	version := flag.Uint("version", 0, "Version to revert the module's migrations to, 0 reverts all of them, or version to resolve")
+	service := flag.String("service", "", "Only apply the migrations of this service's tables")
+	allowDestructive := flag.Bool("allow-destructive", false, "Apply destructive migrations, which delete data")
+	timeout := flag.Duration("timeout", 10*time.Minute, "Timeout for acquiring the migration lock and running the migrations")
+	flag.Parse()
+
+	if *down && *module == "" {
+		flag.Usage()
+		glog.Fatal("-module must be specified with -down")
+	}
+	if *resolve != "" && (*module == "" || *version == 0 || (*resolve != "applied" && *resolve != "reverted")) {
+		flag.Usage()
+		glog.Fatal("-resolve must be applied or reverted, and -module and -version must be specified with it")
+	}
+
+	plugin.LoadAllPluginsFatalOnError(&plugin.DefaultOrchestratorPluginLoader{})
+
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		glog.Fatalf("Failed to connect to database: %s", err)
+	}
+	migs := sqorc.GetMigrations()
+	if *service != "" {
+		migs = sqorc.GetServiceMigrations(*service)
+	}
+	migrator, err := sqorc.NewMigrator(db, sqorc.GetSqlBuilder(), sqorc.GetMigrationLocker(), migs)
+	if err != nil {
+		glog.Fatalf("Invalid migrations: %s", err)
+	}
+	if *dryRun {
+		migrator.DryRunOutput = os.Stdout
+	}
+	migrator.AllowDestructive = *allowDestructive
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch {
+	case *status:
+		versions, dirty, err := migrator.GetAppliedVersions(ctx)
+		if err != nil {
+			glog.Fatalf("Failed to get applied migration versions: %s", err)
+		}
+		var modules []string
+		for m := range versions {
+			modules = append(modules, m)
+		}
+		for m := range dirty {
+			if _, ok := versions[m]; !ok {
+				modules = append(modules, m)
+			}
+		}
+		sort.Strings(modules)
+		for _, m := range modules {
+			if len(dirty[m]) > 0 {
+				fmt.Printf("%s: %v, dirty: %v\n", m, versions[m], dirty[m])
+			} else {
+				fmt.Printf("%s: %v\n", m, versions[m])
+			}
+		}
+	case *resolve != "":
+		err := migrator.Resolve(ctx, *module, uint32(*version), *resolve == "applied")
+		if err != nil {
+			glog.Fatalf("Failed to resolve migration: %s", err)
+		}
+	case *down:
+		reverted, err := migrator.Down(ctx, *module, uint32(*version))
+		if err != nil {
+			glog.Fatalf("Failed to revert migrations: %s", err)
+		}
+		if !*dryRun {
+			for _, m := range reverted {
+				glog.Infof("Reverted migration %s", m)
+			}
+		}
+	default:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			glog.Fatalf("Failed to apply migrations: %s", err)
+		}
+		if !*dryRun {
+			for _, m := range applied {
+				glog.Infof("Applied migration %s", m)
+			}
+		}
+	}
+}
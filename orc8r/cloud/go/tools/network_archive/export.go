@@ -0,0 +1,61 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"magma/orc8r/cloud/go/services/configurator"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+func init() {
+	cmdExport := &cobra.Command{
+		Use:   "export --network=<network-id> [--out=<archive-file>]",
+		Short: "export a network's configuration to an archive",
+		Run:   exportCmd,
+	}
+	cmdExport.Flags().StringVar(&exportOutput, "out", "", "file to write the archive to, defaults to stdout")
+
+	rootCmd.AddCommand(cmdExport)
+}
+
+func exportCmd(cmd *cobra.Command, args []string) {
+	if networkId == "" {
+		glog.Error("--network must be specified")
+		os.Exit(1)
+	}
+	archive, err := configurator.ExportNetwork(networkId)
+	if err != nil {
+		glog.Errorf("failed to export network %s: %s", networkId, err)
+		os.Exit(1)
+	}
+	archiveBytes, err := configurator.MarshalNetworkArchive(archive)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+
+	if exportOutput == "" {
+		fmt.Println(string(archiveBytes))
+		return
+	}
+	err = ioutil.WriteFile(exportOutput, archiveBytes, 0600)
+	if err != nil {
+		glog.Errorf("failed to write archive to %s: %s", exportOutput, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Exported network %s with %d entities to %s\n", networkId, len(archive.Entities), exportOutput)
+}
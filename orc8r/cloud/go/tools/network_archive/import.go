@@ -0,0 +1,64 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"magma/orc8r/cloud/go/services/configurator"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	overwrite       bool
+	dropPhysicalIDs bool
+)
+
+func init() {
+	cmdImport := &cobra.Command{
+		Use:   "import [--network=<network-id>] [--overwrite] [--drop-physical-ids] <archive-file>",
+		Short: "import a network's configuration from an archive",
+		Long: "Import a network's configuration from an archive created by export. " +
+			"The network is imported under the archived network's ID unless --network is set.",
+		Args: cobra.ExactArgs(1),
+		Run:  importCmd,
+	}
+	cmdImport.Flags().BoolVar(&overwrite, "overwrite", false, "replace the network and all of its entities if it already exists")
+	cmdImport.Flags().BoolVar(&dropPhysicalIDs, "drop-physical-ids", false, "import the entities without their physical IDs")
+
+	rootCmd.AddCommand(cmdImport)
+}
+
+func importCmd(cmd *cobra.Command, args []string) {
+	archiveBytes, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		glog.Errorf("failed to read archive %s: %s", args[0], err)
+		os.Exit(1)
+	}
+	archive, err := configurator.UnmarshalNetworkArchive(archiveBytes)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+
+	targetNetworkID := networkId
+	if targetNetworkID == "" {
+		targetNetworkID = archive.Network.Id
+	}
+	err = configurator.ImportNetwork(archive, targetNetworkID, overwrite, dropPhysicalIDs)
+	if err != nil {
+		glog.Errorf("failed to import network %s: %s", targetNetworkID, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Imported network %s with %d entities\n", targetNetworkID, len(archive.Entities))
+}
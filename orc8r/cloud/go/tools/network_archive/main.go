@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Command line tool to export a network's configuration to an archive file
+// and to import an archive into a network
+package main
+
+import (
+	"os"
+
+	"magma/orc8r/cloud/go/plugin"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "network_archive",
+	Short: "Export and import network configurations",
+}
+
+var networkId string
+
+func main() {
+	plugin.LoadAllPluginsFatalOnError(&plugin.DefaultOrchestratorPluginLoader{})
+
+	rootCmd.PersistentFlags().StringVar(&networkId, "network", "", "the network id")
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(2)
+	}
+}